@@ -0,0 +1,172 @@
+package aggregate
+
+import (
+	"fmt"
+
+	"github.com/sorintlab/sircles/command/commands"
+	"github.com/sorintlab/sircles/common"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/util"
+)
+
+type ActionRepository struct {
+	es           *eventstore.EventStore
+	uidGenerator common.UIDGenerator
+}
+
+func NewActionRepository(es *eventstore.EventStore, uidGenerator common.UIDGenerator) *ActionRepository {
+	return &ActionRepository{es: es, uidGenerator: uidGenerator}
+}
+
+func (ar *ActionRepository) Load(id util.ID) (*Action, error) {
+	log.Debugf("Load id: %s", id)
+	a := NewAction(ar.uidGenerator, id)
+
+	if err := batchLoader(ar.es, id.String(), a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+type Action struct {
+	work
+
+	status models.ActionStatus
+
+	uidGenerator common.UIDGenerator
+}
+
+func NewAction(uidGenerator common.UIDGenerator, id util.ID) *Action {
+	return &Action{
+		work:         work{kind: "action", id: id},
+		uidGenerator: uidGenerator,
+	}
+}
+
+func (a *Action) AggregateType() AggregateType {
+	return ActionAggregate
+}
+
+func (a *Action) HandleCommand(command *commands.Command) ([]ep.Event, error) {
+	var events []ep.Event
+	var err error
+	switch command.CommandType {
+	case commands.CommandTypeCreateAction:
+		events, err = a.HandleCreateActionCommand(command)
+	case commands.CommandTypeUpdateAction:
+		events, err = a.HandleUpdateActionCommand(command)
+	case commands.CommandTypeChangeActionStatus:
+		events, err = a.HandleChangeActionStatusCommand(command)
+
+	default:
+		err = fmt.Errorf("unhandled command: %#v", command)
+	}
+
+	return events, err
+}
+
+func (a *Action) HandleCreateActionCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if err := a.checkCreate(); err != nil {
+		return nil, err
+	}
+
+	c := command.Data.(*commands.CreateAction)
+
+	action := &models.Action{
+		Title:       c.Title,
+		Description: c.Description,
+		DueDate:     c.DueDate,
+	}
+	action.ID = a.id
+
+	events = append(events, ep.NewEventActionCreated(action, c.RoleID, c.MemberID, c.TensionID))
+
+	return events, nil
+}
+
+func (a *Action) HandleUpdateActionCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if err := a.checkUpdate(a.status); err != nil {
+		return nil, err
+	}
+
+	c := command.Data.(*commands.UpdateAction)
+
+	action := &models.Action{
+		Title:       c.Title,
+		Description: c.Description,
+		DueDate:     c.DueDate,
+	}
+	action.ID = a.id
+
+	if !idPEqual(a.memberID, c.MemberID) {
+		events = append(events, ep.NewEventActionMemberChanged(a.id, a.memberID, c.MemberID))
+	}
+
+	events = append(events, ep.NewEventActionUpdated(action))
+
+	return events, nil
+}
+
+func (a *Action) HandleChangeActionStatusCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	c := command.Data.(*commands.ChangeActionStatus)
+
+	if err := a.checkChangeStatus(a.status, c.Status); err != nil {
+		return nil, err
+	}
+
+	events = append(events, ep.NewEventActionStatusChanged(a.id, a.status, c.Status))
+
+	return events, nil
+}
+
+func (a *Action) ApplyEvents(events []*eventstore.StoredEvent) error {
+	for _, e := range events {
+		if err := a.ApplyEvent(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *Action) ApplyEvent(event *eventstore.StoredEvent) error {
+	log.Debugf("event: %v", event)
+
+	data, err := ep.UnmarshalData(event)
+	if err != nil {
+		return err
+	}
+
+	a.version = event.Version
+
+	switch ep.EventType(event.EventType) {
+	case ep.EventTypeActionCreated:
+		data := data.(*ep.EventActionCreated)
+
+		a.roleID = data.RoleID
+		a.memberID = data.MemberID
+		a.status = models.ActionStatusOpen
+
+		a.created = true
+
+	case ep.EventTypeActionMemberChanged:
+		data := data.(*ep.EventActionMemberChanged)
+
+		a.memberID = data.MemberID
+
+	case ep.EventTypeActionStatusChanged:
+		data := data.(*ep.EventActionStatusChanged)
+
+		a.status = data.Status
+	}
+
+	return nil
+}
@@ -18,6 +18,18 @@ type Aggregate interface {
 	AggregateType() AggregateType
 }
 
+// Snapshotter is an optional interface implemented by aggregates whose state
+// can be saved in a snapshot. When loaded, they'll restore the latest snapshot
+// and apply only the events after it instead of all the stream events.
+type Snapshotter interface {
+	// SnapshotSchemaVersion is the version of the snapshot data format. It
+	// must be increased when the snapshot data or how the events are applied
+	// change. Snapshots with a different schema version are ignored.
+	SnapshotSchemaVersion() int64
+	Snapshot() ([]byte, error)
+	RestoreSnapshot(version int64, data []byte) error
+}
+
 type Repository interface {
 	Load(id util.ID) (Aggregate, error)
 }
@@ -41,7 +53,7 @@ func ExecCommand(command *commands.Command, a Aggregate, es *eventstore.EventSto
 
 	// The events correlationID is the command correlationID
 	// The events causationID is the command ID
-	eventsData, err := ep.GenEventData(events, &command.CorrelationID, &command.ID, &groupID, &command.IssuerID)
+	eventsData, err := ep.GenEventData(events, &command.CorrelationID, &command.ID, &groupID, &command.IssuerID, command.IssuerTokenID)
 	if err != nil {
 		return util.NilID, 0, err
 	}
@@ -49,3 +61,45 @@ func ExecCommand(command *commands.Command, a Aggregate, es *eventstore.EventSto
 	se, err := es.WriteEvents(eventsData, a.AggregateType().String(), a.ID(), a.Version())
 	return groupID, len(se), err
 }
+
+// AggregateCommand is a command to execute on an aggregate
+type AggregateCommand struct {
+	Command   *commands.Command
+	Aggregate Aggregate
+}
+
+// ExecCommands executes multiple commands on different aggregates atomically:
+// if a command fails no events are written, otherwise the events of all the
+// aggregates are written in a single event store transaction with the same
+// groupID.
+func ExecCommands(acs []*AggregateCommand, es *eventstore.EventStore, uidGenerator common.UIDGenerator) (util.ID, int, error) {
+	groupID := uidGenerator.UUID("")
+
+	streamsEventsData := []*eventstore.StreamEventsData{}
+	for _, ac := range acs {
+		command, a := ac.Command, ac.Aggregate
+		commandJson, err := json.Marshal(command)
+		if err == nil {
+			log.Infof("executing command on aggregate: %s %s: %s", a.AggregateType(), a.ID(), commandJson)
+		}
+
+		events, err := a.HandleCommand(command)
+		if err != nil {
+			return util.NilID, 0, &HandleCommandError{err}
+		}
+
+		eventsData, err := ep.GenEventData(events, &command.CorrelationID, &command.ID, &groupID, &command.IssuerID, command.IssuerTokenID)
+		if err != nil {
+			return util.NilID, 0, err
+		}
+		streamsEventsData = append(streamsEventsData, &eventstore.StreamEventsData{
+			EventsData: eventsData,
+			Category:   a.AggregateType().String(),
+			StreamID:   a.ID(),
+			Version:    a.Version(),
+		})
+	}
+
+	se, err := es.WriteStreamsEvents(streamsEventsData)
+	return groupID, len(se), err
+}
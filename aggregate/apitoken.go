@@ -0,0 +1,139 @@
+package aggregate
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sorintlab/sircles/command/commands"
+	"github.com/sorintlab/sircles/common"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/util"
+)
+
+type APITokenRepository struct {
+	es           *eventstore.EventStore
+	uidGenerator common.UIDGenerator
+}
+
+func NewAPITokenRepository(es *eventstore.EventStore, uidGenerator common.UIDGenerator) *APITokenRepository {
+	return &APITokenRepository{es: es, uidGenerator: uidGenerator}
+}
+
+func (ar *APITokenRepository) Load(id util.ID) (*APIToken, error) {
+	log.Debugf("Load id: %s", id)
+	a := NewAPIToken(ar.uidGenerator, id)
+
+	if err := batchLoader(ar.es, id.String(), a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// APIToken is a member token used to access the api without an interactive
+// login
+type APIToken struct {
+	id      util.ID
+	version int64
+
+	created      bool
+	revoked      bool
+	uidGenerator common.UIDGenerator
+}
+
+func NewAPIToken(uidGenerator common.UIDGenerator, id util.ID) *APIToken {
+	return &APIToken{
+		id:           id,
+		uidGenerator: uidGenerator,
+	}
+}
+
+func (a *APIToken) Version() int64 {
+	return a.version
+}
+
+func (a *APIToken) ID() string {
+	return a.id.String()
+}
+
+func (a *APIToken) AggregateType() AggregateType {
+	return APITokenAggregate
+}
+
+func (a *APIToken) HandleCommand(command *commands.Command) ([]ep.Event, error) {
+	var events []ep.Event
+	var err error
+	switch command.CommandType {
+	case commands.CommandTypeCreateAPIToken:
+		events, err = a.HandleCreateAPITokenCommand(command)
+	case commands.CommandTypeRevokeAPIToken:
+		events, err = a.HandleRevokeAPITokenCommand(command)
+
+	default:
+		err = fmt.Errorf("unhandled command: %#v", command)
+	}
+
+	return events, err
+}
+
+func (a *APIToken) HandleCreateAPITokenCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if a.created {
+		return nil, errors.New("api token already exists")
+	}
+
+	c := command.Data.(*commands.CreateAPIToken)
+
+	apiToken := &models.APIToken{
+		MemberID:   c.MemberID,
+		Name:       c.Name,
+		Scope:      c.Scope,
+		Expiration: c.Expiration,
+		TokenHash:  c.TokenHash,
+	}
+	apiToken.ID = a.id
+
+	events = append(events, ep.NewEventAPITokenCreated(apiToken))
+
+	return events, nil
+}
+
+func (a *APIToken) HandleRevokeAPITokenCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !a.created || a.revoked {
+		return nil, errors.New("unexistent api token")
+	}
+
+	events = append(events, ep.NewEventAPITokenRevoked(a.id))
+
+	return events, nil
+}
+
+func (a *APIToken) ApplyEvents(events []*eventstore.StoredEvent) error {
+	for _, e := range events {
+		if err := a.ApplyEvent(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *APIToken) ApplyEvent(event *eventstore.StoredEvent) error {
+	log.Debugf("event: %v", event)
+
+	a.version = event.Version
+
+	switch ep.EventType(event.EventType) {
+	case ep.EventTypeAPITokenCreated:
+		a.created = true
+
+	case ep.EventTypeAPITokenRevoked:
+		a.revoked = true
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package aggregate
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sorintlab/sircles/command/commands"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/util"
+)
+
+func TestCreateAPIToken(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	apiTokenID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewAPIToken(uidGenerator, apiTokenID)
+
+	expiration := time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC)
+	command := commands.NewCommand(commands.CommandTypeCreateAPIToken, correlationID, causationID, util.NilID, &commands.CreateAPIToken{
+		MemberID:   memberID,
+		Name:       "ci",
+		Scope:      models.APITokenScopeTensions,
+		Expiration: expiration,
+		TokenHash:  "hash",
+	})
+
+	out := []ep.Event{
+		&ep.EventAPITokenCreated{
+			MemberID:   memberID,
+			Name:       "ci",
+			Scope:      models.APITokenScopeTensions,
+			Expiration: expiration,
+			TokenHash:  "hash",
+		},
+	}
+
+	test := &testData{
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestRevokeRevokedAPIToken(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	apiTokenID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	storedEvents, err := toStoredEvents([]ep.Event{
+		&ep.EventAPITokenCreated{
+			MemberID:   memberID,
+			Name:       "ci",
+			Scope:      models.APITokenScopeReadOnly,
+			Expiration: time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC),
+			TokenHash:  "hash",
+		},
+		&ep.EventAPITokenRevoked{},
+	}, APITokenAggregate, apiTokenID.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	aggregate := NewAPIToken(uidGenerator, apiTokenID)
+
+	command := commands.NewCommand(commands.CommandTypeRevokeAPIToken, correlationID, causationID, util.NilID, &commands.RevokeAPIToken{})
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       fmt.Errorf("unexistent api token"),
+	}
+
+	runTest(t, test)
+}
@@ -4,8 +4,30 @@ import (
 	"github.com/sorintlab/sircles/eventstore"
 )
 
+// snapshotInterval is the number of events applied after the latest snapshot
+// that will trigger the save of a new snapshot
+var snapshotInterval int64 = 100
+
 func batchLoader(es *eventstore.EventStore, aggregateID string, a Aggregate) error {
 	var v int64 = 0
+
+	s, isSnapshotter := a.(Snapshotter)
+	if isSnapshotter {
+		snapshot, err := es.GetSnapshot(aggregateID)
+		if err != nil {
+			return err
+		}
+		// ignore snapshots with a different schema version, a new one will
+		// be saved
+		if snapshot != nil && snapshot.SchemaVersion == s.SnapshotSchemaVersion() {
+			if err := s.RestoreSnapshot(snapshot.Version, snapshot.Data); err != nil {
+				return err
+			}
+			v = snapshot.Version
+		}
+	}
+	snapshotVersion := v
+
 	for {
 		events, err := es.GetEvents(aggregateID, v+1, 100)
 		if err != nil {
@@ -13,7 +35,7 @@ func batchLoader(es *eventstore.EventStore, aggregateID string, a Aggregate) err
 		}
 
 		if len(events) == 0 {
-			return nil
+			break
 		}
 
 		v = events[len(events)-1].Version
@@ -22,4 +44,31 @@ func batchLoader(es *eventstore.EventStore, aggregateID string, a Aggregate) err
 			return err
 		}
 	}
+
+	if isSnapshotter && a.Version()-snapshotVersion >= snapshotInterval {
+		saveSnapshot(es, a, s)
+	}
+
+	return nil
+}
+
+// saveSnapshot saves a new aggregate snapshot. Since the aggregate can always
+// be loaded from its events, errors are just logged.
+func saveSnapshot(es *eventstore.EventStore, a Aggregate, s Snapshotter) {
+	data, err := s.Snapshot()
+	if err != nil {
+		log.Errorf("failed to create snapshot for aggregate %s %s: %+v", a.AggregateType(), a.ID(), err)
+		return
+	}
+
+	snapshot := &eventstore.Snapshot{
+		StreamID:      a.ID(),
+		Category:      a.AggregateType().String(),
+		Version:       a.Version(),
+		SchemaVersion: s.SnapshotSchemaVersion(),
+		Data:          data,
+	}
+	if err := es.WriteSnapshot(snapshot); err != nil {
+		log.Errorf("failed to save snapshot for aggregate %s %s: %+v", a.AggregateType(), a.ID(), err)
+	}
 }
@@ -0,0 +1,120 @@
+package aggregate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sorintlab/sircles/command/commands"
+	"github.com/sorintlab/sircles/util"
+)
+
+func reserveValues(t *testing.T, rr *UniqueValueRegistryRepository, uidGenerator *TestUIDGen, registryID string, values ...string) {
+	for _, value := range values {
+		r, err := rr.Load(registryID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		command := commands.NewCommand(commands.CommandTypeReserveValue, uidGenerator.UUID(""), uidGenerator.UUID(""), util.NilID, &commands.ReserveValue{
+			Value:     value,
+			ID:        uidGenerator.UUID(value),
+			RequestID: uidGenerator.UUID(""),
+		})
+		if _, _, err := ExecCommand(command, r, rr.es, uidGenerator); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestBatchLoaderSnapshot(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	prevSnapshotInterval := snapshotInterval
+	snapshotInterval = 3
+	defer func() { snapshotInterval = prevSnapshotInterval }()
+
+	es := newTestEventStore(t, filepath.Join(tmpDir, "es"))
+	uidGenerator := NewTestUIDGen()
+	rr := NewUniqueValueRegistryRepository(es, uidGenerator)
+	registryID := "registry01"
+
+	// a snapshot is saved when loading the aggregate after at least
+	// snapshotInterval events since the previous snapshot
+	expectedSnapshotVersions := []int64{0, 0, 0, 3, 3, 3, 6}
+	for i, expectedSnapshotVersion := range expectedSnapshotVersions {
+		reserveValues(t, rr, uidGenerator, registryID, fmt.Sprintf("value%02d", i))
+
+		snapshot, err := es.GetSnapshot(registryID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var snapshotVersion int64
+		if snapshot != nil {
+			snapshotVersion = snapshot.Version
+		}
+		if snapshotVersion != expectedSnapshotVersion {
+			t.Fatalf("expected snapshot version %d, got %d", expectedSnapshotVersion, snapshotVersion)
+		}
+	}
+
+	// the aggregate loaded from the snapshot must be equal to the one
+	// loaded replaying all the events
+	r, err := rr.Load(registryID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fr, err := NewUniqueValueRegistry(es, uidGenerator, registryID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	events, err := es.GetEvents(registryID, 1, 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := fr.ApplyEvents(events); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if r.Version() != fr.Version() {
+		t.Fatalf("expected version %d, got %d", fr.Version(), r.Version())
+	}
+	if !reflect.DeepEqual(r.values, fr.values) {
+		t.Fatalf("expected values %v, got %v", fr.values, r.values)
+	}
+	if !reflect.DeepEqual(r.reserveRequests, fr.reserveRequests) {
+		t.Fatalf("expected reserve requests %v, got %v", fr.reserveRequests, r.reserveRequests)
+	}
+
+	// a snapshot with a different schema version is ignored
+	snapshot, err := es.GetSnapshot(registryID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	snapshot.SchemaVersion = 0
+	snapshot.Data = []byte("invalid data")
+	if err := es.WriteSnapshot(snapshot); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, err = rr.Load(registryID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(r.values, fr.values) {
+		t.Fatalf("expected values %v, got %v", fr.values, r.values)
+	}
+	snapshot, err = es.GetSnapshot(registryID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if snapshot.SchemaVersion != uniqueValueRegistrySnapshotSchemaVersion {
+		t.Fatalf("expected snapshot schema version %d, got %d", uniqueValueRegistrySnapshotSchemaVersion, snapshot.SchemaVersion)
+	}
+}
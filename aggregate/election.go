@@ -0,0 +1,193 @@
+package aggregate
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sorintlab/sircles/command/commands"
+	"github.com/sorintlab/sircles/common"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/util"
+)
+
+type ElectionRepository struct {
+	es           *eventstore.EventStore
+	uidGenerator common.UIDGenerator
+}
+
+func NewElectionRepository(es *eventstore.EventStore, uidGenerator common.UIDGenerator) *ElectionRepository {
+	return &ElectionRepository{es: es, uidGenerator: uidGenerator}
+}
+
+func (er *ElectionRepository) Load(id util.ID) (*Election, error) {
+	log.Debugf("Load id: %s", id)
+	e := NewElection(er.uidGenerator, id)
+
+	if err := batchLoader(er.es, id.String(), e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// Election records the election of a circle core role member
+type Election struct {
+	id      util.ID
+	version int64
+
+	roleID   util.ID
+	roleType models.RoleType
+	status   models.ElectionStatus
+
+	// nominated candidates
+	candidates map[util.ID]struct{}
+
+	created      bool
+	uidGenerator common.UIDGenerator
+}
+
+func NewElection(uidGenerator common.UIDGenerator, id util.ID) *Election {
+	return &Election{
+		id:           id,
+		candidates:   make(map[util.ID]struct{}),
+		uidGenerator: uidGenerator,
+	}
+}
+
+func (e *Election) Version() int64 {
+	return e.version
+}
+
+func (e *Election) ID() string {
+	return e.id.String()
+}
+
+func (e *Election) AggregateType() AggregateType {
+	return ElectionAggregate
+}
+
+func (e *Election) HandleCommand(command *commands.Command) ([]ep.Event, error) {
+	var events []ep.Event
+	var err error
+	switch command.CommandType {
+	case commands.CommandTypeCreateElection:
+		events, err = e.HandleCreateElectionCommand(command)
+	case commands.CommandTypeNominateElectionCandidate:
+		events, err = e.HandleNominateElectionCandidateCommand(command)
+	case commands.CommandTypeCompleteElection:
+		events, err = e.HandleCompleteElectionCommand(command)
+
+	default:
+		err = fmt.Errorf("unhandled command: %#v", command)
+	}
+
+	return events, err
+}
+
+func (e *Election) HandleCreateElectionCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if e.created {
+		return nil, errors.New("election already exists")
+	}
+
+	c := command.Data.(*commands.CreateElection)
+
+	if !c.RoleType.IsElectedRoleType() {
+		return nil, errors.Errorf("invalid election role type %q", c.RoleType)
+	}
+
+	election := &models.Election{
+		RoleType: c.RoleType,
+	}
+	election.ID = e.id
+
+	events = append(events, ep.NewEventElectionCreated(election, c.RoleID, c.MemberID))
+
+	return events, nil
+}
+
+func (e *Election) HandleNominateElectionCandidateCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !e.created {
+		return nil, errors.New("unexistent election")
+	}
+	if e.status != models.ElectionStatusOpen {
+		return nil, errors.Errorf("cannot nominate candidates in an election in status %q", e.status)
+	}
+
+	c := command.Data.(*commands.NominateElectionCandidate)
+
+	if _, ok := e.candidates[c.CandidateID]; ok {
+		return nil, errors.Errorf("member %s already nominated", c.CandidateID)
+	}
+
+	events = append(events, ep.NewEventElectionCandidateNominated(e.id, c.CandidateID, c.NominatorID))
+
+	return events, nil
+}
+
+func (e *Election) HandleCompleteElectionCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !e.created {
+		return nil, errors.New("unexistent election")
+	}
+	if e.status != models.ElectionStatusOpen {
+		return nil, errors.Errorf("cannot complete an election in status %q", e.status)
+	}
+
+	c := command.Data.(*commands.CompleteElection)
+
+	if _, ok := e.candidates[c.ElectedMemberID]; !ok {
+		return nil, errors.Errorf("member %s isn't a nominated candidate", c.ElectedMemberID)
+	}
+
+	events = append(events, ep.NewEventElectionCompleted(e.id, c.ElectedMemberID, c.ElectionExpiration))
+
+	return events, nil
+}
+
+func (e *Election) ApplyEvents(events []*eventstore.StoredEvent) error {
+	for _, event := range events {
+		if err := e.ApplyEvent(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Election) ApplyEvent(event *eventstore.StoredEvent) error {
+	log.Debugf("event: %v", event)
+
+	data, err := ep.UnmarshalData(event)
+	if err != nil {
+		return err
+	}
+
+	e.version = event.Version
+
+	switch ep.EventType(event.EventType) {
+	case ep.EventTypeElectionCreated:
+		data := data.(*ep.EventElectionCreated)
+
+		e.roleID = data.RoleID
+		e.roleType = data.RoleType
+		e.status = models.ElectionStatusOpen
+
+		e.created = true
+
+	case ep.EventTypeElectionCandidateNominated:
+		data := data.(*ep.EventElectionCandidateNominated)
+
+		e.candidates[data.CandidateID] = struct{}{}
+
+	case ep.EventTypeElectionCompleted:
+		e.status = models.ElectionStatusCompleted
+	}
+
+	return nil
+}
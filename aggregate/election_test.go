@@ -0,0 +1,218 @@
+package aggregate
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sorintlab/sircles/command/commands"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/util"
+)
+
+func setupElection(t *testing.T, electionID, roleID util.ID, candidatesIDs []util.ID) []*eventstore.StoredEvent {
+	uidGenerator := NewTestUIDGen()
+
+	memberID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewElection(uidGenerator, electionID)
+
+	command := commands.NewCommand(commands.CommandTypeCreateElection, correlationID, causationID, util.NilID, &commands.CreateElection{
+		RoleID:   roleID,
+		RoleType: models.RoleTypeFacilitator,
+		MemberID: memberID,
+	})
+
+	out, err := aggregate.HandleCommand(command)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, candidateID := range candidatesIDs {
+		storedEvents, err := toStoredEvents(out, aggregate.AggregateType(), aggregate.ID())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		aggregate = NewElection(uidGenerator, electionID)
+		if err := aggregate.ApplyEvents(storedEvents); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		command := commands.NewCommand(commands.CommandTypeNominateElectionCandidate, correlationID, causationID, util.NilID, &commands.NominateElectionCandidate{
+			CandidateID: candidateID,
+			NominatorID: memberID,
+		})
+		nominateOut, err := aggregate.HandleCommand(command)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out = append(out, nominateOut...)
+	}
+
+	storedEvents, err := toStoredEvents(out, aggregate.AggregateType(), aggregate.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return storedEvents
+}
+
+func TestCreateElection(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	electionID := uidGenerator.UUID("")
+	roleID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewElection(uidGenerator, electionID)
+
+	command := commands.NewCommand(commands.CommandTypeCreateElection, correlationID, causationID, util.NilID, &commands.CreateElection{
+		RoleID:   roleID,
+		RoleType: models.RoleTypeSecretary,
+		MemberID: memberID,
+	})
+
+	out := []ep.Event{
+		&ep.EventElectionCreated{
+			RoleID:   roleID,
+			RoleType: models.RoleTypeSecretary,
+			MemberID: memberID,
+		},
+	}
+
+	test := &testData{
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestCreateElectionLeadLink(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	electionID := uidGenerator.UUID("")
+	roleID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewElection(uidGenerator, electionID)
+
+	command := commands.NewCommand(commands.CommandTypeCreateElection, correlationID, causationID, util.NilID, &commands.CreateElection{
+		RoleID:   roleID,
+		RoleType: models.RoleTypeLeadLink,
+		MemberID: memberID,
+	})
+
+	test := &testData{
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       fmt.Errorf(`invalid election role type "leadlink"`),
+	}
+
+	runTest(t, test)
+}
+
+func TestNominateElectionCandidateTwice(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	electionID := uidGenerator.UUID("")
+	roleID := uidGenerator.UUID("")
+	candidateID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+	storedEvents := setupElection(t, electionID, roleID, []util.ID{candidateID})
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewElection(uidGenerator, electionID)
+
+	command := commands.NewCommand(commands.CommandTypeNominateElectionCandidate, correlationID, causationID, util.NilID, &commands.NominateElectionCandidate{
+		CandidateID: candidateID,
+		NominatorID: memberID,
+	})
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       fmt.Errorf("member %s already nominated", candidateID),
+	}
+
+	runTest(t, test)
+}
+
+func TestCompleteElection(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	electionID := uidGenerator.UUID("")
+	roleID := uidGenerator.UUID("")
+	candidateID := uidGenerator.UUID("")
+	storedEvents := setupElection(t, electionID, roleID, []util.ID{candidateID})
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewElection(uidGenerator, electionID)
+
+	electionExpiration := time.Date(2018, 10, 26, 0, 0, 0, 0, time.UTC)
+
+	command := commands.NewCommand(commands.CommandTypeCompleteElection, correlationID, causationID, util.NilID, &commands.CompleteElection{
+		ElectedMemberID:    candidateID,
+		ElectionExpiration: &electionExpiration,
+	})
+
+	out := []ep.Event{
+		&ep.EventElectionCompleted{
+			ElectedMemberID:    candidateID,
+			ElectionExpiration: &electionExpiration,
+		},
+	}
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestCompleteElectionNotNominated(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	electionID := uidGenerator.UUID("")
+	roleID := uidGenerator.UUID("")
+	candidateID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+	storedEvents := setupElection(t, electionID, roleID, []util.ID{candidateID})
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewElection(uidGenerator, electionID)
+
+	command := commands.NewCommand(commands.CommandTypeCompleteElection, correlationID, causationID, util.NilID, &commands.CompleteElection{
+		ElectedMemberID: memberID,
+	})
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       fmt.Errorf("member %s isn't a nominated candidate", memberID),
+	}
+
+	runTest(t, test)
+}
@@ -0,0 +1,153 @@
+package aggregate
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sorintlab/sircles/command/commands"
+	"github.com/sorintlab/sircles/common"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/util"
+)
+
+type MeetingRepository struct {
+	es           *eventstore.EventStore
+	uidGenerator common.UIDGenerator
+}
+
+func NewMeetingRepository(es *eventstore.EventStore, uidGenerator common.UIDGenerator) *MeetingRepository {
+	return &MeetingRepository{es: es, uidGenerator: uidGenerator}
+}
+
+func (mr *MeetingRepository) Load(id util.ID) (*Meeting, error) {
+	log.Debugf("Load id: %s", id)
+	m := NewMeeting(mr.uidGenerator, id)
+
+	if err := batchLoader(mr.es, id.String(), m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Meeting records a meeting held by a circle
+type Meeting struct {
+	id      util.ID
+	version int64
+
+	roleID util.ID
+
+	created      bool
+	uidGenerator common.UIDGenerator
+}
+
+func NewMeeting(uidGenerator common.UIDGenerator, id util.ID) *Meeting {
+	return &Meeting{
+		id:           id,
+		uidGenerator: uidGenerator,
+	}
+}
+
+func (m *Meeting) Version() int64 {
+	return m.version
+}
+
+func (m *Meeting) ID() string {
+	return m.id.String()
+}
+
+func (m *Meeting) AggregateType() AggregateType {
+	return MeetingAggregate
+}
+
+func (m *Meeting) HandleCommand(command *commands.Command) ([]ep.Event, error) {
+	var events []ep.Event
+	var err error
+	switch command.CommandType {
+	case commands.CommandTypeCreateMeeting:
+		events, err = m.HandleCreateMeetingCommand(command)
+	case commands.CommandTypeReportMeetingValues:
+		events, err = m.HandleReportMeetingValuesCommand(command)
+
+	default:
+		err = fmt.Errorf("unhandled command: %#v", command)
+	}
+
+	return events, err
+}
+
+func (m *Meeting) HandleCreateMeetingCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if m.created {
+		return nil, errors.New("meeting already exists")
+	}
+
+	c := command.Data.(*commands.CreateMeeting)
+
+	if !c.MeetingType.IsValid() {
+		return nil, errors.Errorf("invalid meeting type %q", c.MeetingType)
+	}
+
+	meeting := &models.Meeting{
+		MeetingType: c.MeetingType,
+		Date:        c.Date,
+	}
+	meeting.ID = m.id
+
+	events = append(events, ep.NewEventMeetingCreated(meeting, c.RoleID, c.FacilitatorID, c.SecretaryID, c.AttendeesIDs))
+
+	return events, nil
+}
+
+func (m *Meeting) HandleReportMeetingValuesCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !m.created {
+		return nil, errors.New("unexistent meeting")
+	}
+
+	c := command.Data.(*commands.ReportMeetingValues)
+
+	for _, r := range c.ChecklistItemReports {
+		events = append(events, ep.NewEventMeetingChecklistItemReported(m.id, r.ChecklistItemID, r.Checked))
+	}
+	for _, r := range c.MetricReports {
+		events = append(events, ep.NewEventMeetingMetricReported(m.id, r.MetricID, r.Value))
+	}
+
+	return events, nil
+}
+
+func (m *Meeting) ApplyEvents(events []*eventstore.StoredEvent) error {
+	for _, e := range events {
+		if err := m.ApplyEvent(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Meeting) ApplyEvent(event *eventstore.StoredEvent) error {
+	log.Debugf("event: %v", event)
+
+	data, err := ep.UnmarshalData(event)
+	if err != nil {
+		return err
+	}
+
+	m.version = event.Version
+
+	switch ep.EventType(event.EventType) {
+	case ep.EventTypeMeetingCreated:
+		data := data.(*ep.EventMeetingCreated)
+
+		m.roleID = data.RoleID
+
+		m.created = true
+	}
+
+	return nil
+}
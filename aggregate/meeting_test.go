@@ -0,0 +1,167 @@
+package aggregate
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sorintlab/sircles/change"
+	"github.com/sorintlab/sircles/command/commands"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/util"
+)
+
+func TestCreateMeeting(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	meetingID := uidGenerator.UUID("")
+	roleID := uidGenerator.UUID("")
+	facilitatorID := uidGenerator.UUID("")
+	secretaryID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewMeeting(uidGenerator, meetingID)
+
+	date := time.Date(2017, 10, 26, 15, 0, 0, 0, time.UTC)
+
+	command := commands.NewCommand(commands.CommandTypeCreateMeeting, correlationID, causationID, util.NilID, &commands.CreateMeeting{
+		RoleID:        roleID,
+		MeetingType:   models.MeetingTypeTactical,
+		Date:          date,
+		FacilitatorID: &facilitatorID,
+		SecretaryID:   &secretaryID,
+		AttendeesIDs:  []util.ID{facilitatorID, secretaryID, memberID},
+	})
+
+	out := []ep.Event{
+		&ep.EventMeetingCreated{
+			RoleID:        roleID,
+			MeetingType:   models.MeetingTypeTactical,
+			Date:          date,
+			FacilitatorID: &facilitatorID,
+			SecretaryID:   &secretaryID,
+			AttendeesIDs:  []util.ID{facilitatorID, secretaryID, memberID},
+		},
+	}
+
+	test := &testData{
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestCreateMeetingInvalidType(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	meetingID := uidGenerator.UUID("")
+	roleID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewMeeting(uidGenerator, meetingID)
+
+	command := commands.NewCommand(commands.CommandTypeCreateMeeting, correlationID, causationID, util.NilID, &commands.CreateMeeting{
+		RoleID:      roleID,
+		MeetingType: models.MeetingType("weekly"),
+		Date:        time.Date(2017, 10, 26, 15, 0, 0, 0, time.UTC),
+	})
+
+	test := &testData{
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       fmt.Errorf(`invalid meeting type "weekly"`),
+	}
+
+	runTest(t, test)
+}
+
+func TestReportMeetingValues(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	meetingID := uidGenerator.UUID("")
+	roleID := uidGenerator.UUID("")
+	checklistItemID := uidGenerator.UUID("")
+	metricID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewMeeting(uidGenerator, meetingID)
+
+	command := commands.NewCommand(commands.CommandTypeCreateMeeting, correlationID, causationID, util.NilID, &commands.CreateMeeting{
+		RoleID:      roleID,
+		MeetingType: models.MeetingTypeTactical,
+		Date:        time.Date(2017, 10, 26, 15, 0, 0, 0, time.UTC),
+	})
+	out, err := aggregate.HandleCommand(command)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	storedEvents, err := toStoredEvents(out, aggregate.AggregateType(), aggregate.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	command = commands.NewCommand(commands.CommandTypeReportMeetingValues, correlationID, causationID, util.NilID, &commands.ReportMeetingValues{
+		ChecklistItemReports: []change.ChecklistItemReport{
+			{ChecklistItemID: checklistItemID, Checked: true},
+		},
+		MetricReports: []change.MetricReport{
+			{MetricID: metricID, Value: "42"},
+		},
+	})
+
+	out = []ep.Event{
+		&ep.EventMeetingChecklistItemReported{
+			ChecklistItemID: checklistItemID,
+			Checked:         true,
+		},
+		&ep.EventMeetingMetricReported{
+			MetricID: metricID,
+			Value:    "42",
+		},
+	}
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestReportUnexistentMeetingValues(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	meetingID := uidGenerator.UUID("")
+	metricID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewMeeting(uidGenerator, meetingID)
+
+	command := commands.NewCommand(commands.CommandTypeReportMeetingValues, correlationID, causationID, util.NilID, &commands.ReportMeetingValues{
+		MetricReports: []change.MetricReport{
+			{MetricID: metricID, Value: "42"},
+		},
+	})
+
+	test := &testData{
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       fmt.Errorf("unexistent meeting"),
+	}
+
+	runTest(t, test)
+}
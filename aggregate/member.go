@@ -1,6 +1,7 @@
 package aggregate
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -42,7 +43,8 @@ type Member struct {
 	matchUID string
 	isAdmin  bool
 
-	created bool
+	created     bool
+	deactivated bool
 
 	createRequests      map[util.ID]struct{}
 	updateRequests      map[util.ID]struct{}
@@ -74,6 +76,72 @@ func (m *Member) AggregateType() AggregateType {
 	return MemberAggregate
 }
 
+const memberSnapshotSchemaVersion = 2
+
+type memberSnapshot struct {
+	UserName string
+	FullName string
+	Email    string
+	MatchUID string
+	IsAdmin  bool
+
+	Created     bool
+	Deactivated bool
+
+	CreateRequests      map[util.ID]struct{}
+	UpdateRequests      map[util.ID]struct{}
+	SetMatchUIDRequests map[util.ID]struct{}
+}
+
+func (m *Member) SnapshotSchemaVersion() int64 {
+	return memberSnapshotSchemaVersion
+}
+
+func (m *Member) Snapshot() ([]byte, error) {
+	return json.Marshal(&memberSnapshot{
+		UserName: m.userName,
+		FullName: m.fullName,
+		Email:    m.email,
+		MatchUID: m.matchUID,
+		IsAdmin:  m.isAdmin,
+
+		Created:     m.created,
+		Deactivated: m.deactivated,
+
+		CreateRequests:      m.createRequests,
+		UpdateRequests:      m.updateRequests,
+		SetMatchUIDRequests: m.setMatchUIDRequests,
+	})
+}
+
+func (m *Member) RestoreSnapshot(version int64, data []byte) error {
+	s := &memberSnapshot{
+		CreateRequests:      make(map[util.ID]struct{}),
+		UpdateRequests:      make(map[util.ID]struct{}),
+		SetMatchUIDRequests: make(map[util.ID]struct{}),
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return errors.Wrap(err, "failed to unmarshal member snapshot")
+	}
+
+	m.version = version
+
+	m.userName = s.UserName
+	m.fullName = s.FullName
+	m.email = s.Email
+	m.matchUID = s.MatchUID
+	m.isAdmin = s.IsAdmin
+
+	m.created = s.Created
+	m.deactivated = s.Deactivated
+
+	m.createRequests = s.CreateRequests
+	m.updateRequests = s.UpdateRequests
+	m.setMatchUIDRequests = s.SetMatchUIDRequests
+
+	return nil
+}
+
 func (m *Member) HandleCommand(command *commands.Command) ([]ep.Event, error) {
 	var events []ep.Event
 	var err error
@@ -86,6 +154,10 @@ func (m *Member) HandleCommand(command *commands.Command) ([]ep.Event, error) {
 		events, err = m.HandleSetMemberPasswordCommand(command)
 	case commands.CommandTypeSetMemberMatchUID:
 		events, err = m.HandleSetMemberMatchUIDCommand(command)
+	case commands.CommandTypeDeactivateMember:
+		events, err = m.HandleDeactivateMemberCommand(command)
+	case commands.CommandTypeReactivateMember:
+		events, err = m.HandleReactivateMemberCommand(command)
 
 	default:
 		err = fmt.Errorf("unhandled command: %#v", command)
@@ -109,10 +181,11 @@ func (m *Member) HandleCreateMemberCommand(command *commands.Command) ([]ep.Even
 	}
 
 	member := &models.Member{
-		UserName: c.UserName,
-		FullName: c.FullName,
-		Email:    c.Email,
-		IsAdmin:  c.IsAdmin,
+		UserName:         c.UserName,
+		FullName:         c.FullName,
+		Email:            c.Email,
+		IsAdmin:          c.IsAdmin,
+		IsServiceAccount: c.IsServiceAccount,
 	}
 	member.ID = m.id
 
@@ -195,6 +268,36 @@ func (m *Member) HandleSetMemberMatchUIDCommand(command *commands.Command) ([]ep
 	return events, nil
 }
 
+func (m *Member) HandleDeactivateMemberCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !m.created {
+		return nil, fmt.Errorf("unexistent member")
+	}
+	if m.deactivated {
+		return nil, fmt.Errorf("member already deactivated")
+	}
+
+	events = append(events, ep.NewEventMemberDeactivated(m.id))
+
+	return events, nil
+}
+
+func (m *Member) HandleReactivateMemberCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !m.created {
+		return nil, fmt.Errorf("unexistent member")
+	}
+	if !m.deactivated {
+		return nil, fmt.Errorf("member not deactivated")
+	}
+
+	events = append(events, ep.NewEventMemberReactivated(m.id))
+
+	return events, nil
+}
+
 func (m *Member) ApplyEvents(events []*eventstore.StoredEvent) error {
 	for _, e := range events {
 		if err := m.ApplyEvent(e); err != nil {
@@ -243,6 +346,12 @@ func (m *Member) ApplyEvent(event *eventstore.StoredEvent) error {
 		m.matchUID = data.MatchUID
 
 		m.setMatchUIDRequests[data.MemberChangeID] = struct{}{}
+
+	case ep.EventTypeMemberDeactivated:
+		m.deactivated = true
+
+	case ep.EventTypeMemberReactivated:
+		m.deactivated = false
 	}
 
 	return nil
@@ -229,3 +229,47 @@ func TestUpdateNotExistingMember(t *testing.T) {
 
 	runTest(t, test)
 }
+
+func TestDeactivateMember(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	memberID := uidGenerator.UUID("")
+	storedEvents := setupMember(t, memberID)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewMember(uidGenerator, memberID)
+
+	command := commands.NewCommand(commands.CommandTypeDeactivateMember, correlationID, causationID, util.NilID, &commands.DeactivateMember{})
+
+	out := []ep.Event{
+		&ep.EventMemberDeactivated{},
+	}
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+
+	// deactivating an already deactivated member should return an error
+	deactivatedEvents, err := toStoredEvents(out, aggregate.AggregateType(), aggregate.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, e := range deactivatedEvents {
+		e.Version = int64(len(storedEvents) + i + 1)
+	}
+
+	test = &testData{
+		State:     append(storedEvents, deactivatedEvents...),
+		Aggregate: NewMember(uidGenerator, memberID),
+		Command:   command,
+		Err:       fmt.Errorf("member already deactivated"),
+	}
+	runTest(t, test)
+}
@@ -1,8 +1,10 @@
 package aggregate
 
 import (
+	"encoding/json"
 	"fmt"
 
+	"github.com/pkg/errors"
 	"github.com/sorintlab/sircles/command/commands"
 	"github.com/sorintlab/sircles/common"
 	ep "github.com/sorintlab/sircles/events"
@@ -64,6 +66,35 @@ func (m *MemberChange) AggregateType() AggregateType {
 	return MemberChangeAggregate
 }
 
+const memberChangeSnapshotSchemaVersion = 1
+
+type memberChangeSnapshot struct {
+	Completed bool
+}
+
+func (m *MemberChange) SnapshotSchemaVersion() int64 {
+	return memberChangeSnapshotSchemaVersion
+}
+
+func (m *MemberChange) Snapshot() ([]byte, error) {
+	return json.Marshal(&memberChangeSnapshot{
+		Completed: m.completed,
+	})
+}
+
+func (m *MemberChange) RestoreSnapshot(version int64, data []byte) error {
+	var s memberChangeSnapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.Wrap(err, "failed to unmarshal member change snapshot")
+	}
+
+	m.version = version
+
+	m.completed = s.Completed
+
+	return nil
+}
+
 func (m *MemberChange) HandleCommand(command *commands.Command) ([]ep.Event, error) {
 	var events []ep.Event
 	var err error
@@ -96,10 +127,11 @@ func (m *MemberChange) HandleRequestCreateMemberCommand(command *commands.Comman
 	c := command.Data.(*commands.RequestCreateMember)
 
 	member := &models.Member{
-		UserName: c.UserName,
-		FullName: c.FullName,
-		Email:    c.Email,
-		IsAdmin:  c.IsAdmin,
+		UserName:         c.UserName,
+		FullName:         c.FullName,
+		Email:            c.Email,
+		IsAdmin:          c.IsAdmin,
+		IsServiceAccount: c.IsServiceAccount,
 	}
 	member.ID = c.MemberID
 
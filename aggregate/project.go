@@ -0,0 +1,172 @@
+package aggregate
+
+import (
+	"fmt"
+
+	"github.com/sorintlab/sircles/command/commands"
+	"github.com/sorintlab/sircles/common"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/util"
+)
+
+type ProjectRepository struct {
+	es           *eventstore.EventStore
+	uidGenerator common.UIDGenerator
+}
+
+func NewProjectRepository(es *eventstore.EventStore, uidGenerator common.UIDGenerator) *ProjectRepository {
+	return &ProjectRepository{es: es, uidGenerator: uidGenerator}
+}
+
+func (pr *ProjectRepository) Load(id util.ID) (*Project, error) {
+	log.Debugf("Load id: %s", id)
+	p := NewProject(pr.uidGenerator, id)
+
+	if err := batchLoader(pr.es, id.String(), p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+type Project struct {
+	work
+
+	status models.ProjectStatus
+
+	uidGenerator common.UIDGenerator
+}
+
+func NewProject(uidGenerator common.UIDGenerator, id util.ID) *Project {
+	return &Project{
+		work:         work{kind: "project", id: id},
+		uidGenerator: uidGenerator,
+	}
+}
+
+func (p *Project) AggregateType() AggregateType {
+	return ProjectAggregate
+}
+
+func (p *Project) HandleCommand(command *commands.Command) ([]ep.Event, error) {
+	var events []ep.Event
+	var err error
+	switch command.CommandType {
+	case commands.CommandTypeCreateProject:
+		events, err = p.HandleCreateProjectCommand(command)
+	case commands.CommandTypeUpdateProject:
+		events, err = p.HandleUpdateProjectCommand(command)
+	case commands.CommandTypeChangeProjectStatus:
+		events, err = p.HandleChangeProjectStatusCommand(command)
+
+	default:
+		err = fmt.Errorf("unhandled command: %#v", command)
+	}
+
+	return events, err
+}
+
+func (p *Project) HandleCreateProjectCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if err := p.checkCreate(); err != nil {
+		return nil, err
+	}
+
+	c := command.Data.(*commands.CreateProject)
+
+	project := &models.Project{
+		Title:       c.Title,
+		Description: c.Description,
+		DueDate:     c.DueDate,
+	}
+	project.ID = p.id
+
+	events = append(events, ep.NewEventProjectCreated(project, c.RoleID, c.MemberID, c.TensionID))
+
+	return events, nil
+}
+
+func (p *Project) HandleUpdateProjectCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if err := p.checkUpdate(p.status); err != nil {
+		return nil, err
+	}
+
+	c := command.Data.(*commands.UpdateProject)
+
+	project := &models.Project{
+		Title:       c.Title,
+		Description: c.Description,
+		DueDate:     c.DueDate,
+	}
+	project.ID = p.id
+
+	if !idPEqual(p.memberID, c.MemberID) {
+		events = append(events, ep.NewEventProjectMemberChanged(p.id, p.memberID, c.MemberID))
+	}
+
+	events = append(events, ep.NewEventProjectUpdated(project))
+
+	return events, nil
+}
+
+func (p *Project) HandleChangeProjectStatusCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	c := command.Data.(*commands.ChangeProjectStatus)
+
+	if err := p.checkChangeStatus(p.status, c.Status); err != nil {
+		return nil, err
+	}
+
+	events = append(events, ep.NewEventProjectStatusChanged(p.id, p.status, c.Status))
+
+	return events, nil
+}
+
+func (p *Project) ApplyEvents(events []*eventstore.StoredEvent) error {
+	for _, e := range events {
+		if err := p.ApplyEvent(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Project) ApplyEvent(event *eventstore.StoredEvent) error {
+	log.Debugf("event: %v", event)
+
+	data, err := ep.UnmarshalData(event)
+	if err != nil {
+		return err
+	}
+
+	p.version = event.Version
+
+	switch ep.EventType(event.EventType) {
+	case ep.EventTypeProjectCreated:
+		data := data.(*ep.EventProjectCreated)
+
+		p.roleID = data.RoleID
+		p.memberID = data.MemberID
+		p.status = models.ProjectStatusActive
+
+		p.created = true
+
+	case ep.EventTypeProjectMemberChanged:
+		data := data.(*ep.EventProjectMemberChanged)
+
+		p.memberID = data.MemberID
+
+	case ep.EventTypeProjectStatusChanged:
+		data := data.(*ep.EventProjectStatusChanged)
+
+		p.status = data.Status
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package aggregate
+
+import (
+	"testing"
+
+	"github.com/sorintlab/sircles/command/commands"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/util"
+)
+
+func setupProject(t *testing.T, projectID, roleID util.ID, memberID *util.ID, status models.ProjectStatus) []*eventstore.StoredEvent {
+	uidGenerator := NewTestUIDGen()
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewProject(uidGenerator, projectID)
+
+	command := commands.NewCommand(commands.CommandTypeCreateProject, correlationID, causationID, util.NilID, &commands.CreateProject{
+		RoleID:      roleID,
+		MemberID:    memberID,
+		Title:       "project01",
+		Description: "Project 01",
+	})
+
+	out, err := aggregate.HandleCommand(command)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if status != models.ProjectStatusActive {
+		storedEvents, err := toStoredEvents(out, aggregate.AggregateType(), aggregate.ID())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := aggregate.ApplyEvents(storedEvents); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		command := commands.NewCommand(commands.CommandTypeChangeProjectStatus, correlationID, causationID, util.NilID, &commands.ChangeProjectStatus{Status: status})
+		statusOut, err := aggregate.HandleCommand(command)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out = append(out, statusOut...)
+	}
+
+	storedEvents, err := toStoredEvents(out, aggregate.AggregateType(), aggregate.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return storedEvents
+}
+
+func TestCreateProject(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	projectID := uidGenerator.UUID("")
+	roleID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+	tensionID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewProject(uidGenerator, projectID)
+
+	command := commands.NewCommand(commands.CommandTypeCreateProject, correlationID, causationID, util.NilID, &commands.CreateProject{
+		RoleID:      roleID,
+		MemberID:    &memberID,
+		TensionID:   &tensionID,
+		Title:       "project01",
+		Description: "Project 01",
+	})
+
+	out := []ep.Event{
+		&ep.EventProjectCreated{
+			Title:       "project01",
+			Description: "Project 01",
+			RoleID:      roleID,
+			MemberID:    &memberID,
+			TensionID:   &tensionID,
+		},
+	}
+
+	test := &testData{
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestUpdateProjectMember(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	projectID := uidGenerator.UUID("")
+	roleID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+	storedEvents := setupProject(t, projectID, roleID, nil, models.ProjectStatusActive)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewProject(uidGenerator, projectID)
+
+	command := commands.NewCommand(commands.CommandTypeUpdateProject, correlationID, causationID, util.NilID, &commands.UpdateProject{
+		MemberID:    &memberID,
+		Title:       "project 01 new title",
+		Description: "Project 01 new description",
+	})
+
+	out := []ep.Event{
+		&ep.EventProjectMemberChanged{
+			MemberID: &memberID,
+		},
+		&ep.EventProjectUpdated{
+			Title:       "project 01 new title",
+			Description: "Project 01 new description",
+		},
+	}
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestChangeProjectStatus(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	projectID := uidGenerator.UUID("")
+	roleID := uidGenerator.UUID("")
+	storedEvents := setupProject(t, projectID, roleID, nil, models.ProjectStatusWaiting)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewProject(uidGenerator, projectID)
+
+	command := commands.NewCommand(commands.CommandTypeChangeProjectStatus, correlationID, causationID, util.NilID, &commands.ChangeProjectStatus{
+		Status: models.ProjectStatusActive,
+	})
+
+	out := []ep.Event{
+		&ep.EventProjectStatusChanged{
+			PrevStatus: models.ProjectStatusWaiting,
+			Status:     models.ProjectStatusActive,
+		},
+	}
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
@@ -0,0 +1,398 @@
+package aggregate
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sorintlab/sircles/change"
+	"github.com/sorintlab/sircles/command/commands"
+	"github.com/sorintlab/sircles/common"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/util"
+)
+
+type ProposalRepository struct {
+	es           *eventstore.EventStore
+	uidGenerator common.UIDGenerator
+}
+
+func NewProposalRepository(es *eventstore.EventStore, uidGenerator common.UIDGenerator) *ProposalRepository {
+	return &ProposalRepository{es: es, uidGenerator: uidGenerator}
+}
+
+func (pr *ProposalRepository) Load(id util.ID) (*Proposal, error) {
+	log.Debugf("Load id: %s", id)
+	p := NewProposal(pr.uidGenerator, id)
+
+	if err := batchLoader(pr.es, id.String(), p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+type Proposal struct {
+	id      util.ID
+	version int64
+
+	roleID   util.ID
+	memberID util.ID
+	status   models.ProposalStatus
+	changes  change.ProposalChanges
+
+	// objections by objection id
+	objections map[util.ID]*proposalObjection
+	// members that gave their consent to the current proposal changes
+	consents map[util.ID]struct{}
+
+	created      bool
+	uidGenerator common.UIDGenerator
+}
+
+type proposalObjection struct {
+	memberID util.ID
+	open     bool
+}
+
+func NewProposal(uidGenerator common.UIDGenerator, id util.ID) *Proposal {
+	return &Proposal{
+		id:           id,
+		objections:   make(map[util.ID]*proposalObjection),
+		consents:     make(map[util.ID]struct{}),
+		uidGenerator: uidGenerator,
+	}
+}
+
+func (p *Proposal) Version() int64 {
+	return p.version
+}
+
+func (p *Proposal) ID() string {
+	return p.id.String()
+}
+
+func (p *Proposal) AggregateType() AggregateType {
+	return ProposalAggregate
+}
+
+// RoleID returns the id of the proposal circle
+func (p *Proposal) RoleID() util.ID {
+	return p.roleID
+}
+
+// ProposalChanges returns the current proposal changes
+func (p *Proposal) ProposalChanges() change.ProposalChanges {
+	return p.changes
+}
+
+func (p *Proposal) HandleCommand(command *commands.Command) ([]ep.Event, error) {
+	var events []ep.Event
+	var err error
+	switch command.CommandType {
+	case commands.CommandTypeCreateProposal:
+		events, err = p.HandleCreateProposalCommand(command)
+	case commands.CommandTypeUpdateProposal:
+		events, err = p.HandleUpdateProposalCommand(command)
+	case commands.CommandTypeSubmitProposal:
+		events, err = p.HandleSubmitProposalCommand(command)
+	case commands.CommandTypeObjectProposal:
+		events, err = p.HandleObjectProposalCommand(command)
+	case commands.CommandTypeAcceptProposal:
+		events, err = p.HandleAcceptProposalCommand(command)
+	case commands.CommandTypeWithdrawProposal:
+		events, err = p.HandleWithdrawProposalCommand(command)
+	case commands.CommandTypeResolveProposalObjection:
+		events, err = p.HandleResolveProposalObjectionCommand(command)
+	case commands.CommandTypeWithdrawProposalObjection:
+		events, err = p.HandleWithdrawProposalObjectionCommand(command)
+	case commands.CommandTypeConsentProposal:
+		events, err = p.HandleConsentProposalCommand(command)
+
+	default:
+		err = fmt.Errorf("unhandled command: %#v", command)
+	}
+
+	return events, err
+}
+
+func (p *Proposal) HandleCreateProposalCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if p.created {
+		return nil, errors.New("proposal already exists")
+	}
+
+	c := command.Data.(*commands.CreateProposal)
+
+	proposal := &models.Proposal{
+		Title:       c.Title,
+		Description: c.Description,
+	}
+	proposal.ID = p.id
+
+	events = append(events, ep.NewEventProposalCreated(proposal, c.RoleID, c.MemberID, c.ProposalChanges))
+
+	return events, nil
+}
+
+func (p *Proposal) HandleUpdateProposalCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !p.created {
+		return nil, errors.New("unexistent proposal")
+	}
+	if !p.status.IsEditable() {
+		return nil, errors.Errorf("cannot update a proposal in status %q", p.status)
+	}
+
+	c := command.Data.(*commands.UpdateProposal)
+
+	proposal := &models.Proposal{
+		Title:       c.Title,
+		Description: c.Description,
+	}
+	proposal.ID = p.id
+
+	events = append(events, ep.NewEventProposalUpdated(proposal, c.ProposalChanges))
+
+	return events, nil
+}
+
+func (p *Proposal) HandleSubmitProposalCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !p.created {
+		return nil, errors.New("unexistent proposal")
+	}
+	if p.status != models.ProposalStatusDraft {
+		return nil, errors.Errorf("cannot submit a proposal in status %q", p.status)
+	}
+
+	events = append(events, ep.NewEventProposalSubmitted(p.id))
+
+	return events, nil
+}
+
+func (p *Proposal) HandleObjectProposalCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !p.created {
+		return nil, errors.New("unexistent proposal")
+	}
+	if p.status != models.ProposalStatusSubmitted && p.status != models.ProposalStatusObjected {
+		return nil, errors.Errorf("cannot object to a proposal in status %q", p.status)
+	}
+
+	c := command.Data.(*commands.ObjectProposal)
+
+	if _, ok := p.objections[c.ObjectionID]; ok {
+		return nil, errors.Errorf("objection %s already exists", c.ObjectionID)
+	}
+
+	events = append(events, ep.NewEventProposalObjected(p.id, c.ObjectionID, c.MemberID, c.Reason))
+
+	return events, nil
+}
+
+func (p *Proposal) HandleResolveProposalObjectionCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !p.created {
+		return nil, errors.New("unexistent proposal")
+	}
+
+	if p.status != models.ProposalStatusObjected {
+		return nil, errors.Errorf("cannot change objections of a proposal in status %q", p.status)
+	}
+
+	c := command.Data.(*commands.ResolveProposalObjection)
+
+	objection, ok := p.objections[c.ObjectionID]
+	if !ok {
+		return nil, errors.Errorf("unexistent objection %s", c.ObjectionID)
+	}
+	if !objection.open {
+		return nil, errors.Errorf("objection %s already closed", c.ObjectionID)
+	}
+
+	events = append(events, ep.NewEventProposalObjectionResolved(p.id, c.ObjectionID, c.MemberID, c.Resolution))
+
+	return events, nil
+}
+
+func (p *Proposal) HandleWithdrawProposalObjectionCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !p.created {
+		return nil, errors.New("unexistent proposal")
+	}
+
+	if p.status != models.ProposalStatusObjected {
+		return nil, errors.Errorf("cannot change objections of a proposal in status %q", p.status)
+	}
+
+	c := command.Data.(*commands.WithdrawProposalObjection)
+
+	objection, ok := p.objections[c.ObjectionID]
+	if !ok {
+		return nil, errors.Errorf("unexistent objection %s", c.ObjectionID)
+	}
+	if !objection.open {
+		return nil, errors.Errorf("objection %s already closed", c.ObjectionID)
+	}
+	if objection.memberID != c.MemberID {
+		return nil, errors.Errorf("only the objection author can withdraw it")
+	}
+
+	events = append(events, ep.NewEventProposalObjectionWithdrawn(p.id, c.ObjectionID, c.MemberID))
+
+	return events, nil
+}
+
+func (p *Proposal) HandleConsentProposalCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !p.created {
+		return nil, errors.New("unexistent proposal")
+	}
+	if p.status != models.ProposalStatusSubmitted && p.status != models.ProposalStatusObjected {
+		return nil, errors.Errorf("cannot consent to a proposal in status %q", p.status)
+	}
+
+	c := command.Data.(*commands.ConsentProposal)
+
+	if _, ok := p.consents[c.MemberID]; ok {
+		return nil, errors.Errorf("member %s already consented", c.MemberID)
+	}
+	for _, objection := range p.objections {
+		if objection.open && objection.memberID == c.MemberID {
+			return nil, errors.Errorf("member %s has open objections", c.MemberID)
+		}
+	}
+
+	events = append(events, ep.NewEventProposalConsented(p.id, c.MemberID))
+
+	return events, nil
+}
+
+// openObjections returns the number of not resolved or withdrawn objections
+func (p *Proposal) openObjections() int {
+	n := 0
+	for _, objection := range p.objections {
+		if objection.open {
+			n++
+		}
+	}
+	return n
+}
+
+func (p *Proposal) HandleAcceptProposalCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !p.created {
+		return nil, errors.New("unexistent proposal")
+	}
+	if p.status != models.ProposalStatusSubmitted {
+		return nil, errors.Errorf("cannot accept a proposal in status %q", p.status)
+	}
+
+	events = append(events, ep.NewEventProposalAccepted(p.id))
+
+	return events, nil
+}
+
+func (p *Proposal) HandleWithdrawProposalCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !p.created {
+		return nil, errors.New("unexistent proposal")
+	}
+	if p.status.IsFinal() {
+		return nil, errors.Errorf("cannot withdraw a proposal in status %q", p.status)
+	}
+
+	events = append(events, ep.NewEventProposalWithdrawn(p.id))
+
+	return events, nil
+}
+
+func (p *Proposal) ApplyEvents(events []*eventstore.StoredEvent) error {
+	for _, e := range events {
+		if err := p.ApplyEvent(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Proposal) ApplyEvent(event *eventstore.StoredEvent) error {
+	log.Debugf("event: %v", event)
+
+	data, err := ep.UnmarshalData(event)
+	if err != nil {
+		return err
+	}
+
+	p.version = event.Version
+
+	switch ep.EventType(event.EventType) {
+	case ep.EventTypeProposalCreated:
+		data := data.(*ep.EventProposalCreated)
+
+		p.roleID = data.RoleID
+		p.memberID = data.MemberID
+		p.status = models.ProposalStatusDraft
+		p.changes = data.ProposalChanges
+
+		p.created = true
+
+	case ep.EventTypeProposalUpdated:
+		data := data.(*ep.EventProposalUpdated)
+
+		p.changes = data.ProposalChanges
+		// changes must be consented again
+		p.consents = make(map[util.ID]struct{})
+
+	case ep.EventTypeProposalSubmitted:
+		p.status = models.ProposalStatusSubmitted
+
+	case ep.EventTypeProposalObjected:
+		data := data.(*ep.EventProposalObjected)
+
+		p.objections[data.ObjectionID] = &proposalObjection{memberID: data.MemberID, open: true}
+		// an objecting member doesn't consent anymore
+		delete(p.consents, data.MemberID)
+		p.status = models.ProposalStatusObjected
+
+	case ep.EventTypeProposalObjectionResolved:
+		data := data.(*ep.EventProposalObjectionResolved)
+
+		p.objections[data.ObjectionID].open = false
+		if p.openObjections() == 0 {
+			p.status = models.ProposalStatusSubmitted
+		}
+
+	case ep.EventTypeProposalObjectionWithdrawn:
+		data := data.(*ep.EventProposalObjectionWithdrawn)
+
+		p.objections[data.ObjectionID].open = false
+		if p.openObjections() == 0 {
+			p.status = models.ProposalStatusSubmitted
+		}
+
+	case ep.EventTypeProposalConsented:
+		data := data.(*ep.EventProposalConsented)
+
+		p.consents[data.MemberID] = struct{}{}
+
+	case ep.EventTypeProposalAccepted:
+		p.status = models.ProposalStatusAccepted
+
+	case ep.EventTypeProposalWithdrawn:
+		p.status = models.ProposalStatusWithdrawn
+	}
+
+	return nil
+}
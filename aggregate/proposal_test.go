@@ -0,0 +1,418 @@
+package aggregate
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/sorintlab/sircles/change"
+	"github.com/sorintlab/sircles/command/commands"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/util"
+)
+
+func TestCreateProposal(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	proposalID := uidGenerator.UUID("")
+	roleID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewProposal(uidGenerator, proposalID)
+
+	proposalChanges := change.ProposalChanges{
+		CreateRoleChanges: []change.CreateRoleChange{
+			{Name: "role01", RoleType: "normal"},
+		},
+	}
+
+	command := commands.NewCommand(commands.CommandTypeCreateProposal, correlationID, causationID, util.NilID, &commands.CreateProposal{
+		RoleID:          roleID,
+		MemberID:        memberID,
+		Title:           "proposal01",
+		Description:     "Proposal 01",
+		ProposalChanges: proposalChanges,
+	})
+
+	out := []ep.Event{
+		&ep.EventProposalCreated{
+			Title:           "proposal01",
+			Description:     "Proposal 01",
+			RoleID:          roleID,
+			MemberID:        memberID,
+			ProposalChanges: proposalChanges,
+		},
+	}
+
+	test := &testData{
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func setupProposal(t *testing.T, proposalID util.ID, submit bool) []*eventstore.StoredEvent {
+	uidGenerator := NewTestUIDGen()
+
+	roleID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewProposal(uidGenerator, proposalID)
+
+	command := commands.NewCommand(commands.CommandTypeCreateProposal, correlationID, causationID, util.NilID, &commands.CreateProposal{
+		RoleID:      roleID,
+		MemberID:    memberID,
+		Title:       "proposal01",
+		Description: "Proposal 01",
+	})
+
+	out, err := aggregate.HandleCommand(command)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if submit {
+		storedEvents, err := toStoredEvents(out, aggregate.AggregateType(), aggregate.ID())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := aggregate.ApplyEvents(storedEvents); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		command := commands.NewCommand(commands.CommandTypeSubmitProposal, correlationID, causationID, util.NilID, &commands.SubmitProposal{})
+		submitOut, err := aggregate.HandleCommand(command)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out = append(out, submitOut...)
+	}
+
+	storedEvents, err := toStoredEvents(out, aggregate.AggregateType(), aggregate.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return storedEvents
+}
+
+func TestUpdateProposal(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	proposalID := uidGenerator.UUID("")
+	storedEvents := setupProposal(t, proposalID, false)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewProposal(uidGenerator, proposalID)
+
+	command := commands.NewCommand(commands.CommandTypeUpdateProposal, correlationID, causationID, util.NilID, &commands.UpdateProposal{
+		Title:       "proposal 01 new title",
+		Description: "Proposal 01 new description",
+	})
+
+	out := []ep.Event{
+		&ep.EventProposalUpdated{
+			Title:       "proposal 01 new title",
+			Description: "Proposal 01 new description",
+		},
+	}
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestUpdateSubmittedProposal(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	proposalID := uidGenerator.UUID("")
+	storedEvents := setupProposal(t, proposalID, true)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewProposal(uidGenerator, proposalID)
+
+	command := commands.NewCommand(commands.CommandTypeUpdateProposal, correlationID, causationID, util.NilID, &commands.UpdateProposal{
+		Title:       "proposal 01 new title",
+		Description: "Proposal 01 new description",
+	})
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       fmt.Errorf(`cannot update a proposal in status "submitted"`),
+	}
+
+	runTest(t, test)
+}
+
+func TestAcceptDraftProposal(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	proposalID := uidGenerator.UUID("")
+	storedEvents := setupProposal(t, proposalID, false)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewProposal(uidGenerator, proposalID)
+
+	command := commands.NewCommand(commands.CommandTypeAcceptProposal, correlationID, causationID, util.NilID, &commands.AcceptProposal{})
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       fmt.Errorf(`cannot accept a proposal in status "draft"`),
+	}
+
+	runTest(t, test)
+}
+
+func TestObjectProposal(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	proposalID := uidGenerator.UUID("")
+	objectionID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+	storedEvents := setupProposal(t, proposalID, true)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewProposal(uidGenerator, proposalID)
+
+	command := commands.NewCommand(commands.CommandTypeObjectProposal, correlationID, causationID, util.NilID, &commands.ObjectProposal{
+		ObjectionID: objectionID,
+		MemberID:    memberID,
+		Reason:      "it will cause harm",
+	})
+
+	out := []ep.Event{
+		&ep.EventProposalObjected{
+			ObjectionID: objectionID,
+			MemberID:    memberID,
+			Reason:      "it will cause harm",
+		},
+	}
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestWithdrawNotExistingProposal(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	proposalID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewProposal(uidGenerator, proposalID)
+
+	command := commands.NewCommand(commands.CommandTypeWithdrawProposal, correlationID, causationID, util.NilID, &commands.WithdrawProposal{})
+
+	test := &testData{
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       fmt.Errorf("unexistent proposal"),
+	}
+
+	runTest(t, test)
+}
+
+func objectedProposalEvents(t *testing.T, proposalID, objectionID, memberID util.ID) []*eventstore.StoredEvent {
+	uidGenerator := NewTestUIDGen()
+
+	storedEvents := setupProposal(t, proposalID, true)
+
+	events := []ep.Event{
+		&ep.EventProposalObjected{
+			ObjectionID: objectionID,
+			MemberID:    memberID,
+			Reason:      "it will cause harm",
+		},
+	}
+	objectedEvents, err := toStoredEvents(events, ProposalAggregate, proposalID.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	objectedEvents[0].Version = int64(len(storedEvents) + 1)
+	objectedEvents[0].ID = uidGenerator.UUID("")
+
+	return append(storedEvents, objectedEvents...)
+}
+
+func TestConsentProposalWithOpenObjection(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	proposalID := uidGenerator.UUID("")
+	objectionID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+	storedEvents := objectedProposalEvents(t, proposalID, objectionID, memberID)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewProposal(uidGenerator, proposalID)
+
+	command := commands.NewCommand(commands.CommandTypeConsentProposal, correlationID, causationID, util.NilID, &commands.ConsentProposal{
+		MemberID: memberID,
+	})
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       fmt.Errorf("member %s has open objections", memberID),
+	}
+
+	runTest(t, test)
+}
+
+func TestWithdrawProposalObjectionNotAuthor(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	proposalID := uidGenerator.UUID("")
+	objectionID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+	otherMemberID := uidGenerator.UUID("")
+	storedEvents := objectedProposalEvents(t, proposalID, objectionID, memberID)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewProposal(uidGenerator, proposalID)
+
+	command := commands.NewCommand(commands.CommandTypeWithdrawProposalObjection, correlationID, causationID, util.NilID, &commands.WithdrawProposalObjection{
+		ObjectionID: objectionID,
+		MemberID:    otherMemberID,
+	})
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       fmt.Errorf("only the objection author can withdraw it"),
+	}
+
+	runTest(t, test)
+}
+
+func TestAcceptProposalAfterObjectionResolved(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	proposalID := uidGenerator.UUID("")
+	objectionID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+	storedEvents := objectedProposalEvents(t, proposalID, objectionID, memberID)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewProposal(uidGenerator, proposalID)
+	if err := aggregate.ApplyEvents(storedEvents); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// an objected proposal cannot be accepted
+	command := commands.NewCommand(commands.CommandTypeAcceptProposal, correlationID, causationID, util.NilID, &commands.AcceptProposal{})
+	if _, err := aggregate.HandleCommand(command); err == nil {
+		t.Fatalf("expected error accepting an objected proposal")
+	}
+
+	command = commands.NewCommand(commands.CommandTypeResolveProposalObjection, correlationID, causationID, util.NilID, &commands.ResolveProposalObjection{
+		ObjectionID: objectionID,
+		MemberID:    memberID,
+		Resolution:  "integrated",
+	})
+	events, err := aggregate.HandleCommand(command)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resolvedEvents, err := toStoredEvents(events, aggregate.AggregateType(), aggregate.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	command = commands.NewCommand(commands.CommandTypeAcceptProposal, correlationID, causationID, util.NilID, &commands.AcceptProposal{})
+
+	out := []ep.Event{
+		&ep.EventProposalAccepted{},
+	}
+
+	test := &testData{
+		State:     resolvedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestProposalChanges(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	proposalID := uidGenerator.UUID("")
+	storedEvents := setupProposal(t, proposalID, false)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewProposal(uidGenerator, proposalID)
+	if err := aggregate.ApplyEvents(storedEvents); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	proposalChanges := change.ProposalChanges{
+		CreateRoleChanges: []change.CreateRoleChange{
+			{Name: "role01", RoleType: "normal"},
+		},
+	}
+
+	command := commands.NewCommand(commands.CommandTypeUpdateProposal, correlationID, causationID, util.NilID, &commands.UpdateProposal{
+		Title:           "proposal01",
+		Description:     "Proposal 01",
+		ProposalChanges: proposalChanges,
+	})
+	out, err := aggregate.HandleCommand(command)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updatedEvents, err := toStoredEvents(out, aggregate.AggregateType(), aggregate.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := aggregate.ApplyEvents(updatedEvents); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the changes to apply are the last updated ones
+	if !reflect.DeepEqual(aggregate.ProposalChanges(), proposalChanges) {
+		t.Fatalf("expected proposal changes %#v, got %#v", proposalChanges, aggregate.ProposalChanges())
+	}
+}
@@ -2,6 +2,7 @@ package aggregate
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 
@@ -19,6 +20,11 @@ import (
 
 const (
 	dbName = "rolestree.db"
+
+	// rolesTreeSnapshotSchemaVersion is the rolestree snapshot db schema
+	// version. It must be increased when changing the snapshot db schema or
+	// how the events are applied so the snapshot db will be rebuilt.
+	rolesTreeSnapshotSchemaVersion = 1
 )
 
 func newDB(dataDir string) (*db.DB, error) {
@@ -49,6 +55,10 @@ func (r *RolesTreeRepository) Load(id util.ID) (*RolesTree, error) {
 	}
 	defer ldb.Close()
 
+	if err := r.checkSnapshot(ldb, rt); err != nil {
+		return nil, err
+	}
+
 	for {
 		var n int
 		var version int64
@@ -83,6 +93,39 @@ func (r *RolesTreeRepository) Load(id util.ID) (*RolesTree, error) {
 	return rt, nil
 }
 
+// checkSnapshot verifies that the last event applied to the snapshot db is
+// the same event in the stream at that version. If not (the event store was
+// recreated or restored to a previous state) the snapshot db is rebuilt.
+func (r *RolesTreeRepository) checkSnapshot(ldb *db.DB, rt *RolesTree) error {
+	return ldb.Do(func(tx *db.Tx) error {
+		meta, err := db.ReadSnapshotMeta(tx)
+		if err != nil {
+			return err
+		}
+		version, err := rt.curVersion(tx)
+		if err != nil {
+			return err
+		}
+		if version == 0 {
+			return nil
+		}
+
+		events, err := r.es.GetEvents(rt.ID(), version, 1)
+		if err != nil {
+			return err
+		}
+		if len(events) > 0 && meta != nil && meta.LastEventID != nil && events[0].ID == *meta.LastEventID {
+			return nil
+		}
+
+		log.Infof("rolestree snapshot db at version %d incompatible with the event store stream, rebuilding it", version)
+		if err := db.ResetSnapshot(tx); err != nil {
+			return err
+		}
+		return initRolesTreeSnapshot(tx, rt.id)
+	})
+}
+
 func (r *RolesTreeRepository) load(id util.ID, rt *RolesTree, version int64) (int, error) {
 	events, err := r.es.GetEvents(id.String(), version+1, 100)
 	if err != nil {
@@ -112,15 +155,11 @@ func NewRolesTree(dataDir string, uidGenerator common.UIDGenerator, id util.ID)
 	defer ldb.Close()
 
 	err = ldb.Do(func(tx *db.Tx) error {
-		return tx.Do(func(tx *db.WrappedTx) error {
-			for _, stmt := range rolesTreeDBCreateStmts {
-				if _, err := tx.Exec(stmt); err != nil {
-					return errors.Wrapf(err, "create failed")
-				}
-			}
-			return nil
-		})
+		return initRolesTreeSnapshot(tx, id)
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	return &RolesTree{
 		dataDir:      dataDir,
@@ -129,6 +168,10 @@ func NewRolesTree(dataDir string, uidGenerator common.UIDGenerator, id util.ID)
 	}, nil
 }
 
+func initRolesTreeSnapshot(tx *db.Tx, id util.ID) error {
+	return db.InitSnapshot(tx, rolesTreeSnapshotSchemaVersion, id.String(), rolesTreeDBCreateStmts)
+}
+
 func (r *RolesTree) Version() int64 {
 	return r.version
 }
@@ -164,8 +207,12 @@ func (r *RolesTree) HandleCommand(command *commands.Command) ([]ep.Event, error)
 			events, err = r.HandleCircleUpdateChildRoleCommand(tx, command)
 		case commands.CommandTypeCircleDeleteChildRole:
 			events, err = r.HandleCircleDeleteChildRoleCommand(tx, command)
+		case commands.CommandTypeCircleApplyProposal:
+			events, err = r.HandleCircleApplyProposalCommand(tx, command)
 		case commands.CommandTypeSetRoleAdditionalContent:
 			events, err = r.HandleSetRoleAdditionalContentCommand(tx, command)
+		case commands.CommandTypeMoveRole:
+			events, err = r.HandleMoveRoleCommand(tx, command)
 		case commands.CommandTypeCircleAddDirectMember:
 			events, err = r.HandleCircleAddDirectMemberCommand(tx, command)
 		case commands.CommandTypeCircleRemoveDirectMember:
@@ -184,6 +231,8 @@ func (r *RolesTree) HandleCommand(command *commands.Command) ([]ep.Event, error)
 			events, err = r.HandleRoleRemoveMemberCommand(tx, command)
 		case commands.CommandTypeRoleUpdateMember:
 			events, err = r.HandleRoleUpdateMemberCommand(tx, command)
+		case commands.CommandTypeRemoveMemberAssignments:
+			events, err = r.HandleRemoveMemberAssignmentsCommand(tx, command)
 
 		default:
 			err = errors.Errorf("unhandled command: %#v", command)
@@ -729,6 +778,94 @@ func (r *RolesTree) HandleCircleUpdateChildRoleCommand(tx *db.Tx, command *comma
 		events = append(events, ep.NewEventRoleAccountabilityUpdated(childRole.ID, accountability))
 	}
 
+	checklistItems, err := r.roleChecklistItems(tx, childRole.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics, err := r.roleMetrics(tx, childRole.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, createChecklistItemChange := range c.UpdateRoleChange.CreateChecklistItemChanges {
+		checklistItem := models.ChecklistItem{}
+		checklistItem.Description = createChecklistItemChange.Description
+		checklistItem.ID = r.uidGenerator.UUID(checklistItem.Description)
+
+		events = append(events, ep.NewEventRoleChecklistItemCreated(childRole.ID, &checklistItem))
+	}
+
+	for _, deleteChecklistItemChange := range c.UpdateRoleChange.DeleteChecklistItemChanges {
+		found := false
+		for _, ci := range checklistItems {
+			if deleteChecklistItemChange.ID == ci.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("cannot delete unexistent checklist item %s", deleteChecklistItemChange.ID)
+		}
+		events = append(events, ep.NewEventRoleChecklistItemDeleted(childRole.ID, deleteChecklistItemChange.ID))
+	}
+
+	for _, updateChecklistItemChange := range c.UpdateRoleChange.UpdateChecklistItemChanges {
+		var checklistItem *models.ChecklistItem
+		for _, ci := range checklistItems {
+			if updateChecklistItemChange.ID == ci.ID {
+				checklistItem = ci
+				break
+			}
+		}
+		if checklistItem == nil {
+			return nil, errors.Errorf("cannot update unexistent checklist item %s", updateChecklistItemChange.ID)
+		}
+		if updateChecklistItemChange.DescriptionChanged {
+			checklistItem.Description = updateChecklistItemChange.Description
+		}
+		events = append(events, ep.NewEventRoleChecklistItemUpdated(childRole.ID, checklistItem))
+	}
+
+	for _, createMetricChange := range c.UpdateRoleChange.CreateMetricChanges {
+		metric := models.Metric{}
+		metric.Description = createMetricChange.Description
+		metric.ID = r.uidGenerator.UUID(metric.Description)
+
+		events = append(events, ep.NewEventRoleMetricCreated(childRole.ID, &metric))
+	}
+
+	for _, deleteMetricChange := range c.UpdateRoleChange.DeleteMetricChanges {
+		found := false
+		for _, m := range metrics {
+			if deleteMetricChange.ID == m.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("cannot delete unexistent metric %s", deleteMetricChange.ID)
+		}
+		events = append(events, ep.NewEventRoleMetricDeleted(childRole.ID, deleteMetricChange.ID))
+	}
+
+	for _, updateMetricChange := range c.UpdateRoleChange.UpdateMetricChanges {
+		var metric *models.Metric
+		for _, m := range metrics {
+			if updateMetricChange.ID == m.ID {
+				metric = m
+				break
+			}
+		}
+		if metric == nil {
+			return nil, errors.Errorf("cannot update unexistent metric %s", updateMetricChange.ID)
+		}
+		if updateMetricChange.DescriptionChanged {
+			metric.Description = updateMetricChange.Description
+		}
+		events = append(events, ep.NewEventRoleMetricUpdated(childRole.ID, metric))
+	}
+
 	return events, nil
 }
 
@@ -792,6 +929,129 @@ func (r *RolesTree) HandleCircleDeleteChildRoleCommand(tx *db.Tx, command *comma
 	return events, nil
 }
 
+// HandleCircleApplyProposalCommand applies all the proposal changes (in
+// order: role creations, updates and deletions). Every change sees the roles
+// tree as modified by the previous changes: their events are temporarily
+// applied to the snapshot db inside a savepoint that is then rolled back since
+// the snapshot db will be updated when the events are read back from the
+// event store. If one of the changes fails no event is emitted.
+func (r *RolesTree) HandleCircleApplyProposalCommand(tx *db.Tx, command *commands.Command) ([]ep.Event, error) {
+	c := command.Data.(*commands.CircleApplyProposal)
+
+	if len(c.NewRoleIDs) != len(c.ProposalChanges.CreateRoleChanges) {
+		return nil, errors.Errorf("wrong number of new role ids: %d, expected: %d", len(c.NewRoleIDs), len(c.ProposalChanges.CreateRoleChanges))
+	}
+
+	err := tx.Do(func(tx *db.WrappedTx) error {
+		_, err := tx.Exec("savepoint applyproposal")
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create savepoint")
+	}
+
+	events, err := r.applyProposal(tx, command, c)
+
+	rerr := tx.Do(func(tx *db.WrappedTx) error {
+		if _, err := tx.Exec("rollback to savepoint applyproposal"); err != nil {
+			return err
+		}
+		_, err := tx.Exec("release savepoint applyproposal")
+		return err
+	})
+	if rerr != nil {
+		return nil, errors.Wrap(rerr, "failed to rollback savepoint")
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot apply proposal")
+	}
+
+	return events, nil
+}
+
+func (r *RolesTree) applyProposal(tx *db.Tx, command *commands.Command, c *commands.CircleApplyProposal) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	version, err := r.curVersion(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// apply handles a single change and applies its events to the snapshot db
+	apply := func(h func(tx *db.Tx, command *commands.Command) ([]ep.Event, error), data interface{}) error {
+		cmd := *command
+		cmd.Data = data
+		es, err := h(tx, &cmd)
+		if err != nil {
+			return err
+		}
+		for _, e := range es {
+			version++
+			data, err := json.Marshal(e)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			se := &eventstore.StoredEvent{
+				EventType: e.EventType().String(),
+				Category:  r.AggregateType().String(),
+				StreamID:  r.ID(),
+				Version:   version,
+				Data:      data,
+			}
+			if err := r.ApplyEvent(tx, se); err != nil {
+				return err
+			}
+		}
+		events = append(events, es...)
+		return nil
+	}
+
+	// isChild checks that the provided role is currently a child of the
+	// proposal circle
+	isChild := func(roleID util.ID) error {
+		childs, err := r.childRoles(tx, c.RoleID)
+		if err != nil {
+			return err
+		}
+		for _, child := range childs {
+			if child.ID == roleID {
+				return nil
+			}
+		}
+		return errors.Errorf("role with id %s doesn't have parent circle with id %s", roleID, c.RoleID)
+	}
+
+	for i, createRoleChange := range c.ProposalChanges.CreateRoleChanges {
+		data := &commands.CircleCreateChildRole{RoleID: c.RoleID, NewRoleID: c.NewRoleIDs[i], CreateRoleChange: createRoleChange}
+		if err := apply(r.HandleCircleCreateChildRoleCommand, data); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, updateRoleChange := range c.ProposalChanges.UpdateRoleChanges {
+		if err := isChild(updateRoleChange.ID); err != nil {
+			return nil, err
+		}
+		data := &commands.CircleUpdateChildRole{RoleID: c.RoleID, UpdateRoleChange: updateRoleChange}
+		if err := apply(r.HandleCircleUpdateChildRoleCommand, data); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, deleteRoleChange := range c.ProposalChanges.DeleteRoleChanges {
+		if err := isChild(deleteRoleChange.ID); err != nil {
+			return nil, err
+		}
+		data := &commands.CircleDeleteChildRole{RoleID: c.RoleID, DeleteRoleChange: deleteRoleChange}
+		if err := apply(r.HandleCircleDeleteChildRoleCommand, data); err != nil {
+			return nil, err
+		}
+	}
+
+	return events, nil
+}
+
 func (r *RolesTree) HandleSetRoleAdditionalContentCommand(tx *db.Tx, command *commands.Command) ([]ep.Event, error) {
 	events := []ep.Event{}
 
@@ -810,6 +1070,61 @@ func (r *RolesTree) HandleSetRoleAdditionalContentCommand(tx *db.Tx, command *co
 	return events, nil
 }
 
+func (r *RolesTree) HandleMoveRoleCommand(tx *db.Tx, command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	c := command.Data.(*commands.MoveRole)
+
+	role, err := r.role(tx, c.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, errors.Errorf("role with id %s doesn't exist", c.RoleID)
+	}
+	if role.RoleType.IsCoreRoleType() {
+		return nil, errors.Errorf("role with id %s is a core role type (not a normal role or a circle)", c.RoleID)
+	}
+
+	parentID, err := r.roleParentID(tx, c.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	if parentID == nil {
+		return nil, errors.Errorf("role with id %s is the root role", c.RoleID)
+	}
+	if *parentID == c.NewParentRoleID {
+		return nil, errors.Errorf("role with id %s is already a child of role %s", c.RoleID, c.NewParentRoleID)
+	}
+
+	newParent, err := r.role(tx, c.NewParentRoleID)
+	if err != nil {
+		return nil, err
+	}
+	if newParent == nil {
+		return nil, errors.Errorf("role with id %s doesn't exist", c.NewParentRoleID)
+	}
+	if newParent.RoleType != models.RoleTypeCircle {
+		return nil, errors.Errorf("role with id %s isn't a circle", c.NewParentRoleID)
+	}
+
+	// the new parent must not be the role itself or one of its sub roles
+	curID := &newParent.ID
+	for curID != nil {
+		if *curID == c.RoleID {
+			return nil, errors.Errorf("cannot move role with id %s inside itself", c.RoleID)
+		}
+		curID, err = r.roleParentID(tx, *curID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	events = append(events, ep.NewEventRoleChangedParent(c.RoleID, &c.NewParentRoleID))
+
+	return events, nil
+}
+
 func (r *RolesTree) HandleCircleAddDirectMemberCommand(tx *db.Tx, command *commands.Command) ([]ep.Event, error) {
 	events := []ep.Event{}
 
@@ -1079,6 +1394,57 @@ func (r *RolesTree) HandleRoleUpdateMemberCommand(tx *db.Tx, command *commands.C
 	return events, nil
 }
 
+func (r *RolesTree) HandleRemoveMemberAssignmentsCommand(tx *db.Tx, command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	c := command.Data.(*commands.RemoveMemberAssignments)
+
+	rolesIDs, err := r.memberRolesIDs(tx, "rolemember", c.MemberID)
+	if err != nil {
+		return nil, err
+	}
+	for _, roleID := range rolesIDs {
+		role, err := r.role(tx, roleID)
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			return nil, errors.Errorf("role with id %s doesn't exist", roleID)
+		}
+
+		switch {
+		case role.RoleType == models.RoleTypeNormal:
+			events = append(events, ep.NewEventRoleMemberRemoved(role.ID, c.MemberID))
+		case role.RoleType.IsCoreRoleType():
+			// core roles are unset on behalf of their circle
+			parentID, err := r.roleParentID(tx, role.ID)
+			if err != nil {
+				return nil, err
+			}
+			if parentID == nil {
+				return nil, errors.Errorf("core role with id %s doesn't have a parent circle", role.ID)
+			}
+			if role.RoleType == models.RoleTypeLeadLink {
+				events = append(events, ep.NewEventCircleLeadLinkMemberUnset(*parentID, role.ID, c.MemberID))
+			} else {
+				events = append(events, ep.NewEventCircleCoreRoleMemberUnset(*parentID, role.ID, c.MemberID, role.RoleType))
+			}
+		default:
+			return nil, errors.Errorf("role with id %s of type %s cannot have members", role.ID, role.RoleType)
+		}
+	}
+
+	circlesIDs, err := r.memberRolesIDs(tx, "circledirectmember", c.MemberID)
+	if err != nil {
+		return nil, err
+	}
+	for _, circleID := range circlesIDs {
+		events = append(events, ep.NewEventCircleDirectMemberRemoved(circleID, c.MemberID))
+	}
+
+	return events, nil
+}
+
 func (r *RolesTree) deleteRoleRecursive(tx *db.Tx, roleID util.ID, skipchilds []util.ID) ([]ep.Event, error) {
 	events := []ep.Event{}
 
@@ -1113,6 +1479,16 @@ func (r *RolesTree) deleteRoleRecursive(tx *db.Tx, roleID util.ID, skipchilds []
 		return nil, err
 	}
 
+	checklistItems, err := r.roleChecklistItems(tx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics, err := r.roleMetrics(tx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
 	if role.RoleType == models.RoleTypeNormal {
 		// Remove role members (on normal role)
 		roleMembersIDs, err := r.roleMembersIDs(tx, roleID)
@@ -1180,6 +1556,16 @@ func (r *RolesTree) deleteRoleRecursive(tx *db.Tx, roleID util.ID, skipchilds []
 		events = append(events, ep.NewEventRoleAccountabilityDeleted(roleID, accountability.ID))
 	}
 
+	// Remove checklist items from role
+	for _, checklistItem := range checklistItems {
+		events = append(events, ep.NewEventRoleChecklistItemDeleted(roleID, checklistItem.ID))
+	}
+
+	// Remove metrics from role
+	for _, metric := range metrics {
+		events = append(events, ep.NewEventRoleMetricDeleted(roleID, metric.ID))
+	}
+
 	// First register roleDeleteEvent since its ID will be the causation ID of subsequent events
 	roleDeletedEvent := ep.NewEventRoleDeleted(roleID)
 	events = append(events, roleDeletedEvent)
@@ -1382,6 +1768,60 @@ func (r *RolesTree) ApplyEvent(tx *db.Tx, event *eventstore.StoredEvent) error {
 			return err
 		}
 
+	case ep.EventTypeRoleChecklistItemCreated:
+		data := data.(*ep.EventRoleChecklistItemCreated)
+		checklistItemID := data.ChecklistItemID
+		checklistItem := &models.ChecklistItem{
+			Description: data.Description,
+		}
+		if err := r.insertChecklistItem(tx, checklistItemID, data.RoleID, checklistItem); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleChecklistItemUpdated:
+		data := data.(*ep.EventRoleChecklistItemUpdated)
+		checklistItemID := data.ChecklistItemID
+		checklistItem := &models.ChecklistItem{
+			Description: data.Description,
+		}
+		if err := r.updateChecklistItem(tx, checklistItemID, checklistItem); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleChecklistItemDeleted:
+		data := data.(*ep.EventRoleChecklistItemDeleted)
+		checklistItemID := data.ChecklistItemID
+		if err := r.deleteChecklistItem(tx, checklistItemID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleMetricCreated:
+		data := data.(*ep.EventRoleMetricCreated)
+		metricID := data.MetricID
+		metric := &models.Metric{
+			Description: data.Description,
+		}
+		if err := r.insertMetric(tx, metricID, data.RoleID, metric); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleMetricUpdated:
+		data := data.(*ep.EventRoleMetricUpdated)
+		metricID := data.MetricID
+		metric := &models.Metric{
+			Description: data.Description,
+		}
+		if err := r.updateMetric(tx, metricID, metric); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleMetricDeleted:
+		data := data.(*ep.EventRoleMetricDeleted)
+		metricID := data.MetricID
+		if err := r.deleteMetric(tx, metricID); err != nil {
+			return err
+		}
+
 	case ep.EventTypeRoleAdditionalContentSet:
 
 	case ep.EventTypeRoleChangedParent:
@@ -1444,6 +1884,9 @@ func (r *RolesTree) ApplyEvent(tx *db.Tx, event *eventstore.StoredEvent) error {
 	if err := r.updateVersion(tx, event.Version); err != nil {
 		return err
 	}
+	if err := db.UpdateSnapshotLastEventID(tx, event.ID); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -1454,6 +1897,8 @@ var rolesTreeDBCreateStmts = []string{
 	"create table if not exists role (id uuid, parentid uuid, roletype varchar not null, name varchar, purpose varchar, PRIMARY KEY (id))",
 	"create table if not exists domain (id uuid, roleid uuid, description varchar, PRIMARY KEY (id))",
 	"create table if not exists accountability (id uuid, roleid uuid, description varchar, PRIMARY KEY (id))",
+	"create table if not exists checklistitem (id uuid, roleid uuid, description varchar, PRIMARY KEY (id))",
+	"create table if not exists metric (id uuid, roleid uuid, description varchar, PRIMARY KEY (id))",
 	"create table if not exists roleadditionalcontent (id uuid, roleid uuid, content varchar, PRIMARY KEY (id))",
 	"create table if not exists circledirectmember (memberid uuid, roleid uuid)",
 	"create table if not exists rolemember (memberid uuid, roleid uuid)",
@@ -1478,6 +1923,16 @@ var (
 	accountabilityDelete = sb.Delete("accountability")
 	accountabilityUpdate = sb.Update("accountability")
 
+	checklistItemSelect = sb.Select("id", "roleid", "description").From("checklistitem")
+	checklistItemInsert = sb.Insert("checklistitem").Columns("id", "roleid", "description")
+	checklistItemDelete = sb.Delete("checklistitem")
+	checklistItemUpdate = sb.Update("checklistitem")
+
+	metricSelect = sb.Select("id", "roleid", "description").From("metric")
+	metricInsert = sb.Insert("metric").Columns("id", "roleid", "description")
+	metricDelete = sb.Delete("metric")
+	metricUpdate = sb.Update("metric")
+
 	roleMemberSelect = sb.Select("memberid").From("rolemember")
 	roleMemberInsert = sb.Insert("rolemember").Columns("memberid", "roleid")
 	roleMemberDelete = sb.Delete("rolemember")
@@ -1717,6 +2172,102 @@ func (r *RolesTree) updateAccountability(tx *db.Tx, id util.ID, accountability *
 	return nil
 }
 
+func (r *RolesTree) insertChecklistItem(tx *db.Tx, id util.ID, roleID util.ID, checklistItem *models.ChecklistItem) error {
+	q, args, err := checklistItemInsert.Values(id, roleID, checklistItem.Description).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to insert checklist item: %v", checklistItem)
+	}
+	return nil
+}
+
+func (r *RolesTree) deleteChecklistItem(tx *db.Tx, id util.ID) error {
+	q, args, err := checklistItemDelete.Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete checklist item with id: %s", id)
+	}
+	return nil
+}
+
+func (r *RolesTree) updateChecklistItem(tx *db.Tx, id util.ID, checklistItem *models.ChecklistItem) error {
+	q, args, err := checklistItemUpdate.Where(sq.Eq{"id": id}).Set("description", checklistItem.Description).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to insert checklist item: %v", checklistItem)
+	}
+	return nil
+}
+
+func (r *RolesTree) insertMetric(tx *db.Tx, id util.ID, roleID util.ID, metric *models.Metric) error {
+	q, args, err := metricInsert.Values(id, roleID, metric.Description).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to insert metric: %v", metric)
+	}
+	return nil
+}
+
+func (r *RolesTree) deleteMetric(tx *db.Tx, id util.ID) error {
+	q, args, err := metricDelete.Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete metric with id: %s", id)
+	}
+	return nil
+}
+
+func (r *RolesTree) updateMetric(tx *db.Tx, id util.ID, metric *models.Metric) error {
+	q, args, err := metricUpdate.Where(sq.Eq{"id": id}).Set("description", metric.Description).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to insert metric: %v", metric)
+	}
+	return nil
+}
+
 func (r *RolesTree) updateVersion(tx *db.Tx, version int64) error {
 	q, args, err := versionDelete.ToSql()
 	if err != nil {
@@ -1799,6 +2350,25 @@ func (r *RolesTree) roleParent(tx *db.Tx, roleID util.ID) (*models.Role, error)
 	return r.role(tx, role.ID)
 }
 
+func (r *RolesTree) roleParentID(tx *db.Tx, roleID util.ID) (*util.ID, error) {
+	q, args, err := sb.Select("parentid").From("role").Where(sq.Eq{"id": roleID}).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	var parentID *util.ID
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		return tx.QueryRow(q, args...).Scan(&parentID)
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query parent of role with id: %s", roleID)
+	}
+	return parentID, nil
+}
+
 func (r *RolesTree) rootRole(tx *db.Tx) (*models.Role, error) {
 	q, args, err := roleSelect.Where(sq.Eq{"parentid": nil}).ToSql()
 	if err != nil {
@@ -1960,6 +2530,39 @@ func (r *RolesTree) circleDirectMembersIDs(tx *db.Tx, roleID util.ID) ([]util.ID
 	return members, nil
 }
 
+// memberRolesIDs returns the ids of the roles related to the member in the
+// provided table (rolemember or circledirectmember)
+func (r *RolesTree) memberRolesIDs(tx *db.Tx, table string, memberID util.ID) ([]util.ID, error) {
+	q, args, err := sb.Select("roleid").From(table).Where(sq.Eq{"memberid": memberID}).OrderBy("roleid").ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	roles := []util.ID{}
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		rows, err := tx.Query(q, args...)
+		if err != nil {
+			return errors.Wrap(err, "failed to execute query")
+		}
+		for rows.Next() {
+			role := util.ID{}
+			if err := rows.Scan(&role); err != nil {
+				rows.Close()
+				return errors.Wrap(err, "failed to scan rows")
+			}
+			roles = append(roles, role)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query roles for member with id: %s", memberID)
+	}
+	return roles, nil
+}
+
 func (r *RolesTree) roleDomains(tx *db.Tx, roleID util.ID) ([]*models.Domain, error) {
 	q, args, err := domainSelect.Where(sq.Eq{"roleid": roleID}).ToSql()
 	if err != nil {
@@ -2030,6 +2633,76 @@ func (r *RolesTree) roleAccountabilities(tx *db.Tx, roleID util.ID) ([]*models.A
 	return accountabilities, nil
 }
 
+func (r *RolesTree) roleChecklistItems(tx *db.Tx, roleID util.ID) ([]*models.ChecklistItem, error) {
+	q, args, err := checklistItemSelect.Where(sq.Eq{"roleid": roleID}).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	checklistItems := []*models.ChecklistItem{}
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		rows, err := tx.Query(q, args...)
+		if err != nil {
+			return errors.Wrap(err, "failed to execute query")
+		}
+		for rows.Next() {
+			checklistItem := models.ChecklistItem{}
+			var roleID util.ID
+			if err := rows.Scan(&checklistItem.ID, &roleID, &checklistItem.Description); err != nil {
+				return errors.Wrap(err, "failed to scan rows")
+			}
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			checklistItems = append(checklistItems, &checklistItem)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query checklist items for role with id: %s", roleID)
+	}
+	return checklistItems, nil
+}
+
+func (r *RolesTree) roleMetrics(tx *db.Tx, roleID util.ID) ([]*models.Metric, error) {
+	q, args, err := metricSelect.Where(sq.Eq{"roleid": roleID}).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	metrics := []*models.Metric{}
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		rows, err := tx.Query(q, args...)
+		if err != nil {
+			return errors.Wrap(err, "failed to execute query")
+		}
+		for rows.Next() {
+			metric := models.Metric{}
+			var roleID util.ID
+			if err := rows.Scan(&metric.ID, &roleID, &metric.Description); err != nil {
+				return errors.Wrap(err, "failed to scan rows")
+			}
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			metrics = append(metrics, &metric)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query metrics for role with id: %s", roleID)
+	}
+	return metrics, nil
+}
+
 func (r *RolesTree) CheckBrokenEdges(tx *db.Tx) error {
 	getIDs := func(q string, args []interface{}) ([]*util.ID, error) {
 		ids := []*util.ID{}
@@ -2083,6 +2756,14 @@ func (r *RolesTree) CheckBrokenEdges(tx *db.Tx) error {
 			table:    "accountability",
 			sourceID: "id",
 		},
+		{
+			table:    "checklistitem",
+			sourceID: "id",
+		},
+		{
+			table:    "metric",
+			sourceID: "id",
+		},
 		{
 			table:    "rolemember",
 			sourceID: "memberid",
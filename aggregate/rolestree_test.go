@@ -1,15 +1,22 @@
 package aggregate
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/sorintlab/sircles/change"
 	"github.com/sorintlab/sircles/command/commands"
+	"github.com/sorintlab/sircles/db"
 	ep "github.com/sorintlab/sircles/events"
 	"github.com/sorintlab/sircles/eventstore"
+	ln "github.com/sorintlab/sircles/listennotify"
+	"github.com/sorintlab/sircles/models"
 	"github.com/sorintlab/sircles/util"
+
+	"github.com/pkg/errors"
 )
 
 func idP(id util.ID) *util.ID {
@@ -182,6 +189,220 @@ func setupRolesTree(t *testing.T) []*eventstore.StoredEvent {
 	return storedEvents
 }
 
+func newTestEventStore(t *testing.T, dbpath string) *eventstore.EventStore {
+	edb, err := db.NewDB("sqlite3", dbpath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := edb.Migrate("eventstore", eventstore.Migrations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	localln := ln.NewLocalListenNotify()
+	nf := ln.NewLocalNotifierFactory(localln)
+	return eventstore.NewEventStore(edb, nf)
+}
+
+func snapshotState(t *testing.T, dataDir string) (int64, *db.SnapshotMeta) {
+	ldb, err := newDB(dataDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer ldb.Close()
+
+	var version int64
+	var meta *db.SnapshotMeta
+	err = ldb.Do(func(tx *db.Tx) error {
+		var err error
+		version, err = (&RolesTree{}).curVersion(tx)
+		if err != nil {
+			return err
+		}
+		meta, err = db.ReadSnapshotMeta(tx)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return version, meta
+}
+
+// Test that the snapshot db is reused between loads and rebuilt when the
+// schema version or the event store stream changes
+func TestRolesTreeRepositorySnapshot(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	dataDir := filepath.Join(tmpDir, "data")
+	if err := os.Mkdir(dataDir, 0700); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	uidGenerator := NewTestUIDGen()
+
+	storedEvents := setupRolesTree(t)
+	lastEvent := storedEvents[len(storedEvents)-1]
+
+	es := newTestEventStore(t, filepath.Join(tmpDir, "es01"))
+	if err := es.RestoreEvents(storedEvents); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rtr := NewRolesTreeRepository(dataDir, es, uidGenerator)
+	if _, err := rtr.Load(RolesTreeAggregateID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	version, meta := snapshotState(t, dataDir)
+	if version != lastEvent.Version {
+		t.Fatalf("expected snapshot version %d, got %d", lastEvent.Version, version)
+	}
+	if meta.LastEventID == nil || *meta.LastEventID != lastEvent.ID {
+		t.Fatalf("expected snapshot last event id %s, got %v", lastEvent.ID, meta.LastEventID)
+	}
+
+	// a new load resumes from the current snapshot
+	if _, err := rtr.Load(RolesTreeAggregateID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	version, meta = snapshotState(t, dataDir)
+	if version != lastEvent.Version {
+		t.Fatalf("expected snapshot version %d, got %d", lastEvent.Version, version)
+	}
+
+	// a different event store with the same number of events must trigger
+	// a snapshot rebuild
+	storedEvents = setupRolesTree(t)
+	lastEvent = storedEvents[len(storedEvents)-1]
+
+	es = newTestEventStore(t, filepath.Join(tmpDir, "es02"))
+	if err := es.RestoreEvents(storedEvents); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rtr = NewRolesTreeRepository(dataDir, es, uidGenerator)
+	if _, err := rtr.Load(RolesTreeAggregateID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	version, meta = snapshotState(t, dataDir)
+	if version != lastEvent.Version {
+		t.Fatalf("expected snapshot version %d, got %d", lastEvent.Version, version)
+	}
+	if meta.LastEventID == nil || *meta.LastEventID != lastEvent.ID {
+		t.Fatalf("expected snapshot last event id %s, got %v", lastEvent.ID, meta.LastEventID)
+	}
+
+	// a different schema version must trigger a snapshot rebuild
+	ldb, err := newDB(dataDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = ldb.Do(func(tx *db.Tx) error {
+		return tx.Do(func(tx *db.WrappedTx) error {
+			_, err := tx.Exec("update snapshotmeta set schemaversion = 0")
+			return err
+		})
+	})
+	ldb.Close()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := NewRolesTree(dataDir, uidGenerator, RolesTreeAggregateID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	version, meta = snapshotState(t, dataDir)
+	if version != 0 {
+		t.Fatalf("expected snapshot version 0, got %d", version)
+	}
+	if meta.SchemaVersion != rolesTreeSnapshotSchemaVersion {
+		t.Fatalf("expected snapshot schema version %d, got %d", rolesTreeSnapshotSchemaVersion, meta.SchemaVersion)
+	}
+}
+
+// Test that the aggregates are correctly loaded from the events of a dump
+// created before event schema versioning was introduced
+func TestReplayDumpV1(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	f, err := os.Open("../events/testdata/dump-v1.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	events := []*eventstore.StoredEvent{}
+	dec := json.NewDecoder(f)
+	for dec.More() {
+		var e *eventstore.StoredEvent
+		if err := dec.Decode(&e); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		events = append(events, e)
+	}
+
+	es := newTestEventStore(t, filepath.Join(tmpDir, "es"))
+	if err := es.RestoreEvents(events); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	uidGenerator := NewTestUIDGen()
+
+	rtr := NewRolesTreeRepository(tmpDir, es, uidGenerator)
+	rt, err := rtr.Load(RolesTreeAggregateID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ldb, err := newDB(tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer ldb.Close()
+
+	expectedRoles := map[string]string{
+		"6f8eb6b6-3c2d-4a0a-9c5e-0e6f5b7e5a01": "General",
+		"6f8eb6b6-3c2d-4a0a-9c5e-0e6f5b7e5a02": "Development",
+		"6f8eb6b6-3c2d-4a0a-9c5e-0e6f5b7e5a03": "Tester",
+	}
+	err = ldb.Do(func(tx *db.Tx) error {
+		for id, name := range expectedRoles {
+			role, err := rt.role(tx, util.IDFromStringOrNil(id))
+			if err != nil {
+				return err
+			}
+			if role == nil {
+				return errors.Errorf("role %s doesn't exist", id)
+			}
+			if role.Name != name {
+				return errors.Errorf("expected role %s name %q, got %q", id, name, role.Name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tr := NewTensionRepository(es, uidGenerator)
+	tension, err := tr.Load(util.IDFromStringOrNil("6f8eb6b6-3c2d-4a0a-9c5e-0e6f5b7e5a04"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tension.title != "Flaky tests" {
+		t.Fatalf("expected tension title %q, got %q", "Flaky tests", tension.title)
+	}
+	if tension.status != models.TensionStatusDropped {
+		t.Fatalf("expected tension status %q, got %q", models.TensionStatusDropped, tension.status)
+	}
+}
+
 // Create a new child role of type normal
 func TestCircleCreateChildRole1(t *testing.T) {
 	tmpDir, err := ioutil.TempDir("", "")
@@ -395,3 +616,247 @@ func TestCircleCreateChildRole2(t *testing.T) {
 
 	runTest(t, test)
 }
+
+func setupMoveRoleRolesTree(t *testing.T, uidGenerator *TestUIDGen) []*eventstore.StoredEvent {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate, err := NewRolesTree(tmpDir, uidGenerator, RolesTreeAggregateID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rootRoleID := uidGenerator.UUID("General")
+
+	out, err := aggregate.HandleCommand(commands.NewCommand(commands.CommandTypeSetupRootRole, correlationID, causationID, util.NilID, &commands.SetupRootRole{
+		RootRoleID: rootRoleID,
+		Name:       "General",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out = append(out,
+		&ep.EventRoleCreated{
+			RoleID:       uidGenerator.UUID("circle01"),
+			RoleType:     "circle",
+			Name:         "circle01",
+			ParentRoleID: &rootRoleID,
+		},
+		&ep.EventRoleCreated{
+			RoleID:       uidGenerator.UUID("circle02"),
+			RoleType:     "circle",
+			Name:         "circle02",
+			ParentRoleID: idP(uidGenerator.UUID("circle01")),
+		},
+		&ep.EventRoleCreated{
+			RoleID:       uidGenerator.UUID("role01"),
+			RoleType:     "normal",
+			Name:         "role01",
+			ParentRoleID: &rootRoleID,
+		},
+	)
+
+	storedEvents, err := toStoredEvents(out, aggregate.AggregateType(), aggregate.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return storedEvents
+}
+
+// Move a normal role inside a sub circle
+func TestMoveRole(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	uidGenerator := NewTestUIDGen()
+
+	storedEvents := setupMoveRoleRolesTree(t, uidGenerator)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	roleID := uidGenerator.UUID("role01")
+	newParentRoleID := uidGenerator.UUID("circle02")
+
+	command := commands.NewCommand(commands.CommandTypeMoveRole, correlationID, causationID, util.NilID, &commands.MoveRole{
+		RoleID:          roleID,
+		NewParentRoleID: newParentRoleID,
+	})
+
+	aggregate, err := NewRolesTree(tmpDir, uidGenerator, RolesTreeAggregateID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := []ep.Event{
+		&ep.EventRoleChangedParent{
+			RoleID:       roleID,
+			ParentRoleID: &newParentRoleID,
+		},
+	}
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+// Move a circle inside one of its sub circles
+func TestMoveRoleInsideItself(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	uidGenerator := NewTestUIDGen()
+
+	storedEvents := setupMoveRoleRolesTree(t, uidGenerator)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	roleID := uidGenerator.UUID("circle01")
+
+	command := commands.NewCommand(commands.CommandTypeMoveRole, correlationID, causationID, util.NilID, &commands.MoveRole{
+		RoleID:          roleID,
+		NewParentRoleID: uidGenerator.UUID("circle02"),
+	})
+
+	aggregate, err := NewRolesTree(tmpDir, uidGenerator, RolesTreeAggregateID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       errors.Errorf("cannot move role with id %s inside itself", roleID),
+	}
+
+	runTest(t, test)
+}
+
+func TestRemoveMemberAssignments(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	uidGenerator := NewTestUIDGen()
+
+	memberID := uidGenerator.UUID("member01")
+	otherMemberID := uidGenerator.UUID("member02")
+	rootRoleID := uidGenerator.UUID("General")
+	leadLinkRoleID := uidGenerator.UUID("General-Lead Link")
+	roleID := uidGenerator.UUID("role01")
+	circleID := uidGenerator.UUID("circle02")
+
+	storedEvents := setupMoveRoleRolesTree(t, uidGenerator)
+	assignmentsEvents, err := toStoredEvents([]ep.Event{
+		ep.NewEventRoleMemberAdded(roleID, memberID, nil, false),
+		ep.NewEventRoleMemberAdded(roleID, otherMemberID, nil, false),
+		ep.NewEventCircleLeadLinkMemberSet(rootRoleID, leadLinkRoleID, memberID),
+		ep.NewEventCircleDirectMemberAdded(circleID, memberID),
+		ep.NewEventCircleDirectMemberAdded(circleID, otherMemberID),
+	}, RolesTreeAggregate, RolesTreeAggregateID.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// continue the versions of the setup events
+	for i, e := range assignmentsEvents {
+		e.Version = int64(len(storedEvents) + i + 1)
+	}
+	storedEvents = append(storedEvents, assignmentsEvents...)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	command := commands.NewCommand(commands.CommandTypeRemoveMemberAssignments, correlationID, causationID, util.NilID, &commands.RemoveMemberAssignments{
+		MemberID: memberID,
+	})
+
+	aggregate, err := NewRolesTree(tmpDir, uidGenerator, RolesTreeAggregateID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// roles are ordered by id
+	out := []ep.Event{
+		ep.NewEventCircleLeadLinkMemberUnset(rootRoleID, leadLinkRoleID, memberID),
+		ep.NewEventRoleMemberRemoved(roleID, memberID),
+		ep.NewEventCircleDirectMemberRemoved(circleID, memberID),
+	}
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestCircleApplyProposal(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	uidGenerator := NewTestUIDGen()
+
+	rootRoleID := uidGenerator.UUID("General")
+	proposalID := uidGenerator.UUID("proposal01")
+	newRoleID := uidGenerator.UUID("role03")
+
+	storedEvents := setupMoveRoleRolesTree(t, uidGenerator)
+
+	command := commands.NewCommand(commands.CommandTypeCircleApplyProposal, uidGenerator.UUID(""), uidGenerator.UUID(""), util.NilID, &commands.CircleApplyProposal{
+		RoleID:     rootRoleID,
+		ProposalID: proposalID,
+		NewRoleIDs: []util.ID{newRoleID},
+		ProposalChanges: change.ProposalChanges{
+			CreateRoleChanges: []change.CreateRoleChange{
+				{Name: "role03", RoleType: models.RoleTypeNormal},
+			},
+		},
+	})
+
+	aggregate, err := NewRolesTree(tmpDir, uidGenerator, RolesTreeAggregateID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := aggregate.ApplyEvents(storedEvents); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := aggregate.HandleCommand(command)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(out))
+	}
+	if e, ok := out[0].(*ep.EventRoleCreated); !ok || e.RoleID != newRoleID {
+		t.Fatalf("unexpected event: %#v", out[0])
+	}
+}
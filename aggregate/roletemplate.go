@@ -0,0 +1,220 @@
+package aggregate
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sorintlab/sircles/command/commands"
+	"github.com/sorintlab/sircles/common"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/util"
+)
+
+type RoleTemplateRepository struct {
+	es           *eventstore.EventStore
+	uidGenerator common.UIDGenerator
+}
+
+func NewRoleTemplateRepository(es *eventstore.EventStore, uidGenerator common.UIDGenerator) *RoleTemplateRepository {
+	return &RoleTemplateRepository{es: es, uidGenerator: uidGenerator}
+}
+
+func (rr *RoleTemplateRepository) Load(id util.ID) (*RoleTemplate, error) {
+	log.Debugf("Load id: %s", id)
+	r := NewRoleTemplate(rr.uidGenerator, id)
+
+	if err := batchLoader(rr.es, id.String(), r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// RoleTemplate is a role shape that can be instantiated in any circle
+type RoleTemplate struct {
+	id      util.ID
+	version int64
+
+	// roles instantiated from the template and kept linked to it
+	instances map[util.ID]struct{}
+
+	created      bool
+	deleted      bool
+	uidGenerator common.UIDGenerator
+}
+
+func NewRoleTemplate(uidGenerator common.UIDGenerator, id util.ID) *RoleTemplate {
+	return &RoleTemplate{
+		id:           id,
+		instances:    make(map[util.ID]struct{}),
+		uidGenerator: uidGenerator,
+	}
+}
+
+func (r *RoleTemplate) Version() int64 {
+	return r.version
+}
+
+func (r *RoleTemplate) ID() string {
+	return r.id.String()
+}
+
+func (r *RoleTemplate) AggregateType() AggregateType {
+	return RoleTemplateAggregate
+}
+
+func (r *RoleTemplate) HandleCommand(command *commands.Command) ([]ep.Event, error) {
+	var events []ep.Event
+	var err error
+	switch command.CommandType {
+	case commands.CommandTypeCreateRoleTemplate:
+		events, err = r.HandleCreateRoleTemplateCommand(command)
+	case commands.CommandTypeUpdateRoleTemplate:
+		events, err = r.HandleUpdateRoleTemplateCommand(command)
+	case commands.CommandTypeDeleteRoleTemplate:
+		events, err = r.HandleDeleteRoleTemplateCommand(command)
+	case commands.CommandTypeLinkRoleTemplateInstance:
+		events, err = r.HandleLinkRoleTemplateInstanceCommand(command)
+	case commands.CommandTypeUnlinkRoleTemplateInstance:
+		events, err = r.HandleUnlinkRoleTemplateInstanceCommand(command)
+
+	default:
+		err = fmt.Errorf("unhandled command: %#v", command)
+	}
+
+	return events, err
+}
+
+func (r *RoleTemplate) HandleCreateRoleTemplateCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if r.created {
+		return nil, errors.New("role template already exists")
+	}
+
+	c := command.Data.(*commands.CreateRoleTemplate)
+
+	roleTemplate := &models.RoleTemplate{
+		Name:             c.Name,
+		Purpose:          c.Purpose,
+		Domains:          c.Domains,
+		Accountabilities: c.Accountabilities,
+	}
+	roleTemplate.ID = r.id
+
+	events = append(events, ep.NewEventRoleTemplateCreated(roleTemplate, c.SourceRoleID))
+
+	return events, nil
+}
+
+func (r *RoleTemplate) HandleUpdateRoleTemplateCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !r.created || r.deleted {
+		return nil, errors.New("unexistent role template")
+	}
+
+	c := command.Data.(*commands.UpdateRoleTemplate)
+
+	roleTemplate := &models.RoleTemplate{
+		Name:             c.Name,
+		Purpose:          c.Purpose,
+		Domains:          c.Domains,
+		Accountabilities: c.Accountabilities,
+	}
+	roleTemplate.ID = r.id
+
+	events = append(events, ep.NewEventRoleTemplateUpdated(roleTemplate))
+
+	return events, nil
+}
+
+func (r *RoleTemplate) HandleDeleteRoleTemplateCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !r.created || r.deleted {
+		return nil, errors.New("unexistent role template")
+	}
+
+	events = append(events, ep.NewEventRoleTemplateDeleted(r.id))
+
+	return events, nil
+}
+
+func (r *RoleTemplate) HandleLinkRoleTemplateInstanceCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !r.created || r.deleted {
+		return nil, errors.New("unexistent role template")
+	}
+
+	c := command.Data.(*commands.LinkRoleTemplateInstance)
+
+	if _, ok := r.instances[c.RoleID]; ok {
+		return nil, errors.Errorf("role %s already linked to the template", c.RoleID)
+	}
+
+	events = append(events, ep.NewEventRoleTemplateInstanceLinked(r.id, c.RoleID))
+
+	return events, nil
+}
+
+func (r *RoleTemplate) HandleUnlinkRoleTemplateInstanceCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !r.created || r.deleted {
+		return nil, errors.New("unexistent role template")
+	}
+
+	c := command.Data.(*commands.UnlinkRoleTemplateInstance)
+
+	if _, ok := r.instances[c.RoleID]; !ok {
+		return nil, errors.Errorf("role %s isn't linked to the template", c.RoleID)
+	}
+
+	events = append(events, ep.NewEventRoleTemplateInstanceUnlinked(r.id, c.RoleID))
+
+	return events, nil
+}
+
+func (r *RoleTemplate) ApplyEvents(events []*eventstore.StoredEvent) error {
+	for _, e := range events {
+		if err := r.ApplyEvent(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RoleTemplate) ApplyEvent(event *eventstore.StoredEvent) error {
+	log.Debugf("event: %v", event)
+
+	data, err := ep.UnmarshalData(event)
+	if err != nil {
+		return err
+	}
+
+	r.version = event.Version
+
+	switch ep.EventType(event.EventType) {
+	case ep.EventTypeRoleTemplateCreated:
+		r.created = true
+
+	case ep.EventTypeRoleTemplateDeleted:
+		r.deleted = true
+
+	case ep.EventTypeRoleTemplateInstanceLinked:
+		data := data.(*ep.EventRoleTemplateInstanceLinked)
+
+		r.instances[data.RoleID] = struct{}{}
+
+	case ep.EventTypeRoleTemplateInstanceUnlinked:
+		data := data.(*ep.EventRoleTemplateInstanceUnlinked)
+
+		delete(r.instances, data.RoleID)
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package aggregate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sorintlab/sircles/command/commands"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/util"
+)
+
+func setupRoleTemplate(t *testing.T, roleTemplateID util.ID, additionalCommands ...*commands.Command) []*eventstore.StoredEvent {
+	uidGenerator := NewTestUIDGen()
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewRoleTemplate(uidGenerator, roleTemplateID)
+
+	command := commands.NewCommand(commands.CommandTypeCreateRoleTemplate, correlationID, causationID, util.NilID, &commands.CreateRoleTemplate{
+		Name:             "Tech Lead",
+		Purpose:          "Technical direction",
+		Domains:          []string{"architecture"},
+		Accountabilities: []string{"reviewing designs"},
+	})
+
+	out, err := aggregate.HandleCommand(command)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, command := range additionalCommands {
+		storedEvents, err := toStoredEvents(out, aggregate.AggregateType(), aggregate.ID())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		aggregate = NewRoleTemplate(uidGenerator, roleTemplateID)
+		if err := aggregate.ApplyEvents(storedEvents); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		commandOut, err := aggregate.HandleCommand(command)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out = append(out, commandOut...)
+	}
+
+	storedEvents, err := toStoredEvents(out, aggregate.AggregateType(), aggregate.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return storedEvents
+}
+
+func TestCreateRoleTemplate(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	roleTemplateID := uidGenerator.UUID("")
+	sourceRoleID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewRoleTemplate(uidGenerator, roleTemplateID)
+
+	command := commands.NewCommand(commands.CommandTypeCreateRoleTemplate, correlationID, causationID, util.NilID, &commands.CreateRoleTemplate{
+		Name:             "Tech Lead",
+		Purpose:          "Technical direction",
+		Domains:          []string{"architecture"},
+		Accountabilities: []string{"reviewing designs", "mentoring"},
+		SourceRoleID:     &sourceRoleID,
+	})
+
+	out := []ep.Event{
+		&ep.EventRoleTemplateCreated{
+			Name:             "Tech Lead",
+			Purpose:          "Technical direction",
+			Domains:          []string{"architecture"},
+			Accountabilities: []string{"reviewing designs", "mentoring"},
+			SourceRoleID:     &sourceRoleID,
+		},
+	}
+
+	test := &testData{
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestUpdateDeletedRoleTemplate(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	roleTemplateID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	storedEvents := setupRoleTemplate(t, roleTemplateID,
+		commands.NewCommand(commands.CommandTypeDeleteRoleTemplate, correlationID, causationID, util.NilID, &commands.DeleteRoleTemplate{}),
+	)
+
+	aggregate := NewRoleTemplate(uidGenerator, roleTemplateID)
+
+	command := commands.NewCommand(commands.CommandTypeUpdateRoleTemplate, correlationID, causationID, util.NilID, &commands.UpdateRoleTemplate{
+		Name: "Tech Lead",
+	})
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       fmt.Errorf("unexistent role template"),
+	}
+
+	runTest(t, test)
+}
+
+func TestLinkRoleTemplateInstance(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	roleTemplateID := uidGenerator.UUID("")
+	roleID := uidGenerator.UUID("")
+	storedEvents := setupRoleTemplate(t, roleTemplateID)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewRoleTemplate(uidGenerator, roleTemplateID)
+
+	command := commands.NewCommand(commands.CommandTypeLinkRoleTemplateInstance, correlationID, causationID, util.NilID, &commands.LinkRoleTemplateInstance{
+		RoleID: roleID,
+	})
+
+	out := []ep.Event{
+		&ep.EventRoleTemplateInstanceLinked{
+			RoleID: roleID,
+		},
+	}
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestLinkRoleTemplateInstanceTwice(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	roleTemplateID := uidGenerator.UUID("")
+	roleID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	storedEvents := setupRoleTemplate(t, roleTemplateID,
+		commands.NewCommand(commands.CommandTypeLinkRoleTemplateInstance, correlationID, causationID, util.NilID, &commands.LinkRoleTemplateInstance{RoleID: roleID}),
+	)
+
+	aggregate := NewRoleTemplate(uidGenerator, roleTemplateID)
+
+	command := commands.NewCommand(commands.CommandTypeLinkRoleTemplateInstance, correlationID, causationID, util.NilID, &commands.LinkRoleTemplateInstance{
+		RoleID: roleID,
+	})
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       fmt.Errorf("role %s already linked to the template", roleID),
+	}
+
+	runTest(t, test)
+}
@@ -0,0 +1,179 @@
+package aggregate
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sorintlab/sircles/command/commands"
+	"github.com/sorintlab/sircles/common"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/util"
+)
+
+type SessionRepository struct {
+	es           *eventstore.EventStore
+	uidGenerator common.UIDGenerator
+}
+
+func NewSessionRepository(es *eventstore.EventStore, uidGenerator common.UIDGenerator) *SessionRepository {
+	return &SessionRepository{es: es, uidGenerator: uidGenerator}
+}
+
+func (sr *SessionRepository) Load(id util.ID) (*Session, error) {
+	log.Debugf("Load id: %s", id)
+	s := NewSession(sr.uidGenerator, id)
+
+	if err := batchLoader(sr.es, id.String(), s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Session is a member login session. Its id is the id (jti) of the issued
+// access tokens. A session has only one valid refresh token that is replaced
+// every time it's used, all the session refresh tokens are a token family.
+type Session struct {
+	id      util.ID
+	version int64
+
+	created        bool
+	revoked        bool
+	refreshTokenID util.ID
+	uidGenerator   common.UIDGenerator
+}
+
+func NewSession(uidGenerator common.UIDGenerator, id util.ID) *Session {
+	return &Session{
+		id:           id,
+		uidGenerator: uidGenerator,
+	}
+}
+
+func (s *Session) Version() int64 {
+	return s.version
+}
+
+func (s *Session) ID() string {
+	return s.id.String()
+}
+
+func (s *Session) AggregateType() AggregateType {
+	return SessionAggregate
+}
+
+func (s *Session) HandleCommand(command *commands.Command) ([]ep.Event, error) {
+	var events []ep.Event
+	var err error
+	switch command.CommandType {
+	case commands.CommandTypeCreateSession:
+		events, err = s.HandleCreateSessionCommand(command)
+	case commands.CommandTypeRotateSessionRefreshToken:
+		events, err = s.HandleRotateSessionRefreshTokenCommand(command)
+	case commands.CommandTypeRevokeSession:
+		events, err = s.HandleRevokeSessionCommand(command)
+
+	default:
+		err = fmt.Errorf("unhandled command: %#v", command)
+	}
+
+	return events, err
+}
+
+func (s *Session) HandleCreateSessionCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if s.created {
+		return nil, errors.New("session already exists")
+	}
+
+	c := command.Data.(*commands.CreateSession)
+
+	events = append(events, ep.NewEventSessionCreated(s.id, c.MemberID, c.Expiration))
+	events = append(events, ep.NewEventSessionRefreshTokenIssued(s.id, c.RefreshTokenID, c.RefreshTokenHash, nil))
+
+	return events, nil
+}
+
+func (s *Session) HandleRotateSessionRefreshTokenCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !s.created || s.revoked {
+		return nil, errors.New("unexistent session")
+	}
+
+	c := command.Data.(*commands.RotateSessionRefreshToken)
+
+	// The refresh token has already been used (the read db could be behind
+	// and not report it): someone could have stolen it so revoke the session
+	if c.RefreshTokenID != s.refreshTokenID {
+		events = append(events, ep.NewEventSessionRevoked(s.id, true))
+		return events, nil
+	}
+
+	events = append(events, ep.NewEventSessionRefreshTokenIssued(s.id, c.NewRefreshTokenID, c.NewRefreshTokenHash, &c.RefreshTokenID))
+
+	return events, nil
+}
+
+// IsCurrentRefreshToken reports if the refresh token is the current, not yet
+// used, session refresh token
+func (s *Session) IsCurrentRefreshToken(refreshTokenID util.ID) bool {
+	return s.refreshTokenID == refreshTokenID
+}
+
+func (s *Session) HandleRevokeSessionCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !s.created {
+		return nil, errors.New("unexistent session")
+	}
+
+	// the session could have been already revoked by a concurrent request,
+	// just ignore it
+	if s.revoked {
+		return events, nil
+	}
+
+	c := command.Data.(*commands.RevokeSession)
+
+	events = append(events, ep.NewEventSessionRevoked(s.id, c.RefreshTokenReused))
+
+	return events, nil
+}
+
+func (s *Session) ApplyEvents(events []*eventstore.StoredEvent) error {
+	for _, e := range events {
+		if err := s.ApplyEvent(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Session) ApplyEvent(event *eventstore.StoredEvent) error {
+	log.Debugf("event: %v", event)
+
+	data, err := ep.UnmarshalData(event)
+	if err != nil {
+		return err
+	}
+
+	s.version = event.Version
+
+	switch ep.EventType(event.EventType) {
+	case ep.EventTypeSessionCreated:
+		s.created = true
+
+	case ep.EventTypeSessionRefreshTokenIssued:
+		data := data.(*ep.EventSessionRefreshTokenIssued)
+
+		s.refreshTokenID = data.RefreshTokenID
+
+	case ep.EventTypeSessionRevoked:
+		s.revoked = true
+	}
+
+	return nil
+}
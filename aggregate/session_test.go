@@ -0,0 +1,247 @@
+package aggregate
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sorintlab/sircles/command/commands"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/util"
+)
+
+func setupSession(t *testing.T, sessionID, memberID, refreshTokenID util.ID, additionalCommands ...*commands.Command) []*eventstore.StoredEvent {
+	uidGenerator := NewTestUIDGen()
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewSession(uidGenerator, sessionID)
+
+	command := commands.NewCommand(commands.CommandTypeCreateSession, correlationID, causationID, util.NilID, &commands.CreateSession{
+		MemberID:         memberID,
+		Expiration:       time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC),
+		RefreshTokenID:   refreshTokenID,
+		RefreshTokenHash: "refreshtokenhash01",
+	})
+
+	out, err := aggregate.HandleCommand(command)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, command := range additionalCommands {
+		storedEvents, err := toStoredEvents(out, aggregate.AggregateType(), aggregate.ID())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		aggregate = NewSession(uidGenerator, sessionID)
+		if err := aggregate.ApplyEvents(storedEvents); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		commandOut, err := aggregate.HandleCommand(command)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out = append(out, commandOut...)
+	}
+
+	storedEvents, err := toStoredEvents(out, aggregate.AggregateType(), aggregate.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return storedEvents
+}
+
+func TestCreateSession(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	sessionID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+	refreshTokenID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewSession(uidGenerator, sessionID)
+
+	expiration := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	command := commands.NewCommand(commands.CommandTypeCreateSession, correlationID, causationID, util.NilID, &commands.CreateSession{
+		MemberID:         memberID,
+		Expiration:       expiration,
+		RefreshTokenID:   refreshTokenID,
+		RefreshTokenHash: "refreshtokenhash01",
+	})
+
+	out := []ep.Event{
+		&ep.EventSessionCreated{
+			MemberID:   memberID,
+			Expiration: expiration,
+		},
+		&ep.EventSessionRefreshTokenIssued{
+			RefreshTokenID:   refreshTokenID,
+			RefreshTokenHash: "refreshtokenhash01",
+		},
+	}
+
+	test := &testData{
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestRotateSessionRefreshToken(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	sessionID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+	refreshTokenID := uidGenerator.UUID("")
+	newRefreshTokenID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	storedEvents := setupSession(t, sessionID, memberID, refreshTokenID)
+
+	aggregate := NewSession(uidGenerator, sessionID)
+
+	command := commands.NewCommand(commands.CommandTypeRotateSessionRefreshToken, correlationID, causationID, util.NilID, &commands.RotateSessionRefreshToken{
+		RefreshTokenID:      refreshTokenID,
+		NewRefreshTokenID:   newRefreshTokenID,
+		NewRefreshTokenHash: "refreshtokenhash02",
+	})
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out: []ep.Event{
+			&ep.EventSessionRefreshTokenIssued{
+				RefreshTokenID:     newRefreshTokenID,
+				RefreshTokenHash:   "refreshtokenhash02",
+				PrevRefreshTokenID: &refreshTokenID,
+			},
+		},
+	}
+
+	runTest(t, test)
+}
+
+func TestRotateUsedSessionRefreshToken(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	sessionID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+	refreshTokenID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	storedEvents := setupSession(t, sessionID, memberID, refreshTokenID,
+		commands.NewCommand(commands.CommandTypeRotateSessionRefreshToken, correlationID, causationID, util.NilID, &commands.RotateSessionRefreshToken{
+			RefreshTokenID:      refreshTokenID,
+			NewRefreshTokenID:   uidGenerator.UUID(""),
+			NewRefreshTokenHash: "refreshtokenhash02",
+		}),
+	)
+
+	aggregate := NewSession(uidGenerator, sessionID)
+
+	command := commands.NewCommand(commands.CommandTypeRotateSessionRefreshToken, correlationID, causationID, util.NilID, &commands.RotateSessionRefreshToken{
+		RefreshTokenID:      refreshTokenID,
+		NewRefreshTokenID:   uidGenerator.UUID(""),
+		NewRefreshTokenHash: "refreshtokenhash03",
+	})
+
+	// the reused refresh token revokes the session
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out: []ep.Event{
+			&ep.EventSessionRevoked{RefreshTokenReused: true},
+		},
+	}
+
+	runTest(t, test)
+}
+
+func TestRevokeSession(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	sessionID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	storedEvents := setupSession(t, sessionID, memberID, uidGenerator.UUID(""))
+
+	aggregate := NewSession(uidGenerator, sessionID)
+
+	command := commands.NewCommand(commands.CommandTypeRevokeSession, correlationID, causationID, util.NilID, &commands.RevokeSession{})
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out: []ep.Event{
+			&ep.EventSessionRevoked{},
+		},
+	}
+
+	runTest(t, test)
+}
+
+func TestRevokeRevokedSession(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	sessionID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	storedEvents := setupSession(t, sessionID, memberID, uidGenerator.UUID(""),
+		commands.NewCommand(commands.CommandTypeRevokeSession, correlationID, causationID, util.NilID, &commands.RevokeSession{}),
+	)
+
+	aggregate := NewSession(uidGenerator, sessionID)
+
+	command := commands.NewCommand(commands.CommandTypeRevokeSession, correlationID, causationID, util.NilID, &commands.RevokeSession{})
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       []ep.Event{},
+	}
+
+	runTest(t, test)
+}
+
+func TestRevokeUnexistentSession(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	sessionID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewSession(uidGenerator, sessionID)
+
+	command := commands.NewCommand(commands.CommandTypeRevokeSession, correlationID, causationID, util.NilID, &commands.RevokeSession{})
+
+	test := &testData{
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       fmt.Errorf("unexistent session"),
+	}
+
+	runTest(t, test)
+}
@@ -1,6 +1,7 @@
 package aggregate
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -39,6 +40,9 @@ type Tension struct {
 	title       string
 	description string
 	roleID      *util.ID
+	status      models.TensionStatus
+	assigneeID  *util.ID
+	meetingID   *util.ID
 
 	created      bool
 	uidGenerator common.UIDGenerator
@@ -63,6 +67,56 @@ func (t *Tension) AggregateType() AggregateType {
 	return TensionAggregate
 }
 
+const tensionSnapshotSchemaVersion = 1
+
+type tensionSnapshot struct {
+	Title       string
+	Description string
+	RoleID      *util.ID
+	Status      models.TensionStatus
+	AssigneeID  *util.ID
+	MeetingID   *util.ID
+
+	Created bool
+}
+
+func (t *Tension) SnapshotSchemaVersion() int64 {
+	return tensionSnapshotSchemaVersion
+}
+
+func (t *Tension) Snapshot() ([]byte, error) {
+	return json.Marshal(&tensionSnapshot{
+		Title:       t.title,
+		Description: t.description,
+		RoleID:      t.roleID,
+		Status:      t.status,
+		AssigneeID:  t.assigneeID,
+		MeetingID:   t.meetingID,
+
+		Created: t.created,
+	})
+}
+
+func (t *Tension) RestoreSnapshot(version int64, data []byte) error {
+	var s tensionSnapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.Wrap(err, "failed to unmarshal tension snapshot")
+	}
+
+	t.version = version
+
+	t.title = s.Title
+	t.description = s.Description
+	t.roleID = s.RoleID
+	t.status = s.Status
+	t.assigneeID = s.AssigneeID
+	t.meetingID = s.MeetingID
+
+	t.created = s.Created
+
+	return nil
+}
+
 func (t *Tension) HandleCommand(command *commands.Command) ([]ep.Event, error) {
 	var events []ep.Event
 	var err error
@@ -75,6 +129,14 @@ func (t *Tension) HandleCommand(command *commands.Command) ([]ep.Event, error) {
 		events, err = t.HandleChangeTensionRoleCommand(command)
 	case commands.CommandTypeCloseTension:
 		events, err = t.HandleCloseTensionCommand(command)
+	case commands.CommandTypeChangeTensionStatus:
+		events, err = t.HandleChangeTensionStatusCommand(command)
+	case commands.CommandTypeChangeTensionAssignee:
+		events, err = t.HandleChangeTensionAssigneeCommand(command)
+	case commands.CommandTypeChangeTensionMeeting:
+		events, err = t.HandleChangeTensionMeetingCommand(command)
+	case commands.CommandTypeResolveTension:
+		events, err = t.HandleResolveTensionCommand(command)
 
 	default:
 		err = fmt.Errorf("unhandled command: %#v", command)
@@ -156,13 +218,119 @@ func (t *Tension) HandleChangeTensionRoleCommand(command *commands.Command) ([]e
 func (t *Tension) HandleCloseTensionCommand(command *commands.Command) ([]ep.Event, error) {
 	events := []ep.Event{}
 
+	if !t.created {
+		return nil, errors.New("unexistent tension")
+	}
+	if t.status.IsFinal() {
+		return nil, errors.New("tension already closed")
+	}
+
 	c := command.Data.(*commands.CloseTension)
 
-	events = append(events, ep.NewEventTensionClosed(t.id, c.Reason))
+	events = append(events, ep.NewEventTensionClosed(t.id, c.Reason, c.MeetingID))
 
 	return events, nil
 }
 
+func (t *Tension) HandleChangeTensionStatusCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !t.created {
+		return nil, errors.New("unexistent tension")
+	}
+	if t.status.IsFinal() {
+		return nil, errors.New("tension already closed")
+	}
+
+	c := command.Data.(*commands.ChangeTensionStatus)
+
+	if !c.Status.IsValid() {
+		return nil, errors.Errorf("invalid tension status %q", c.Status)
+	}
+	// final statuses are reached only resolving or closing the tension
+	if c.Status.IsFinal() {
+		return nil, errors.Errorf("cannot change tension status to %q", c.Status)
+	}
+	if c.Status == t.status {
+		return nil, errors.Errorf("tension already in status %q", c.Status)
+	}
+
+	events = append(events, ep.NewEventTensionStatusChanged(t.id, t.status, c.Status))
+
+	return events, nil
+}
+
+func (t *Tension) HandleChangeTensionAssigneeCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !t.created {
+		return nil, errors.New("unexistent tension")
+	}
+	if t.status.IsFinal() {
+		return nil, errors.New("tension already closed")
+	}
+
+	c := command.Data.(*commands.ChangeTensionAssignee)
+
+	if idPEqual(t.assigneeID, c.MemberID) {
+		return events, nil
+	}
+
+	events = append(events, ep.NewEventTensionAssigneeChanged(t.id, t.assigneeID, c.MemberID))
+
+	return events, nil
+}
+
+func (t *Tension) HandleChangeTensionMeetingCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !t.created {
+		return nil, errors.New("unexistent tension")
+	}
+	// unlike the other changes this is permitted also on closed tensions: the
+	// meeting where a tension was processed is usually recorded after the
+	// tension has been resolved or closed
+
+	c := command.Data.(*commands.ChangeTensionMeeting)
+
+	if idPEqual(t.meetingID, c.MeetingID) {
+		return events, nil
+	}
+
+	events = append(events, ep.NewEventTensionMeetingChanged(t.id, c.MeetingID))
+
+	return events, nil
+}
+
+func (t *Tension) HandleResolveTensionCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !t.created {
+		return nil, errors.New("unexistent tension")
+	}
+	if t.status.IsFinal() {
+		return nil, errors.New("tension already closed")
+	}
+
+	c := command.Data.(*commands.ResolveTension)
+
+	if !c.OutcomeType.IsValid() {
+		return nil, errors.Errorf("invalid tension outcome type %q", c.OutcomeType)
+	}
+
+	events = append(events, ep.NewEventTensionResolved(t.id, c.OutcomeType, c.OutcomeReferenceID, c.OutcomeDescription))
+
+	return events, nil
+}
+
+// idPEqual reports if the two optional ids are both nil or have the same value
+func idPEqual(a, b *util.ID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
 func (t *Tension) ApplyEvents(events []*eventstore.StoredEvent) error {
 	for _, e := range events {
 		if err := t.ApplyEvent(e); err != nil {
@@ -189,6 +357,7 @@ func (t *Tension) ApplyEvent(event *eventstore.StoredEvent) error {
 		t.title = data.Title
 		t.description = data.Description
 		t.roleID = data.RoleID
+		t.status = models.TensionStatusNew
 
 		t.created = true
 
@@ -204,7 +373,30 @@ func (t *Tension) ApplyEvent(event *eventstore.StoredEvent) error {
 		t.roleID = data.RoleID
 
 	case ep.EventTypeTensionClosed:
+		data := data.(*ep.EventTensionClosed)
+
+		t.status = models.TensionStatusDropped
+		if data.MeetingID != nil {
+			t.meetingID = data.MeetingID
+		}
+
+	case ep.EventTypeTensionStatusChanged:
+		data := data.(*ep.EventTensionStatusChanged)
+
+		t.status = data.Status
+
+	case ep.EventTypeTensionAssigneeChanged:
+		data := data.(*ep.EventTensionAssigneeChanged)
+
+		t.assigneeID = data.MemberID
+
+	case ep.EventTypeTensionMeetingChanged:
+		data := data.(*ep.EventTensionMeetingChanged)
+
+		t.meetingID = data.MeetingID
 
+	case ep.EventTypeTensionResolved:
+		t.status = models.TensionStatusResolved
 	}
 
 	return nil
@@ -7,6 +7,7 @@ import (
 	"github.com/sorintlab/sircles/command/commands"
 	ep "github.com/sorintlab/sircles/events"
 	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/models"
 	"github.com/sorintlab/sircles/util"
 )
 
@@ -150,3 +151,219 @@ func TestUpdateNotExistingTension(t *testing.T) {
 
 	runTest(t, test)
 }
+
+func TestChangeTensionStatus(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	tensionID := uidGenerator.UUID("")
+	storedEvents := setupTension(t, tensionID)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewTension(uidGenerator, tensionID)
+
+	command := commands.NewCommand(commands.CommandTypeChangeTensionStatus, correlationID, causationID, util.NilID, &commands.ChangeTensionStatus{
+		Status: models.TensionStatusInAgenda,
+	})
+
+	out := []ep.Event{
+		&ep.EventTensionStatusChanged{
+			PrevStatus: models.TensionStatusNew,
+			Status:     models.TensionStatusInAgenda,
+		},
+	}
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestChangeTensionStatusToFinal(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	tensionID := uidGenerator.UUID("")
+	storedEvents := setupTension(t, tensionID)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewTension(uidGenerator, tensionID)
+
+	command := commands.NewCommand(commands.CommandTypeChangeTensionStatus, correlationID, causationID, util.NilID, &commands.ChangeTensionStatus{
+		Status: models.TensionStatusResolved,
+	})
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       fmt.Errorf(`cannot change tension status to "resolved"`),
+	}
+
+	runTest(t, test)
+}
+
+func TestResolveTension(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	tensionID := uidGenerator.UUID("")
+	projectID := uidGenerator.UUID("")
+	storedEvents := setupTension(t, tensionID)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewTension(uidGenerator, tensionID)
+
+	command := commands.NewCommand(commands.CommandTypeResolveTension, correlationID, causationID, util.NilID, &commands.ResolveTension{
+		OutcomeType:        models.TensionOutcomeTypeProject,
+		OutcomeReferenceID: &projectID,
+		OutcomeDescription: "new project",
+	})
+
+	out := []ep.Event{
+		&ep.EventTensionResolved{
+			OutcomeType:        models.TensionOutcomeTypeProject,
+			OutcomeReferenceID: &projectID,
+			OutcomeDescription: "new project",
+		},
+	}
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestAssignClosedTension(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	tensionID := uidGenerator.UUID("")
+	memberID := uidGenerator.UUID("")
+	storedEvents := setupTension(t, tensionID)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewTension(uidGenerator, tensionID)
+	if err := aggregate.ApplyEvents(storedEvents); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	command := commands.NewCommand(commands.CommandTypeCloseTension, correlationID, causationID, util.NilID, &commands.CloseTension{
+		Reason: "not relevant anymore",
+	})
+	events, err := aggregate.HandleCommand(command)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	closedEvents, err := toStoredEvents(events, aggregate.AggregateType(), aggregate.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	command = commands.NewCommand(commands.CommandTypeChangeTensionAssignee, correlationID, causationID, util.NilID, &commands.ChangeTensionAssignee{
+		MemberID: &memberID,
+	})
+
+	test := &testData{
+		State:     closedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       fmt.Errorf("tension already closed"),
+	}
+
+	runTest(t, test)
+}
+
+func TestCloseTensionInMeeting(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	tensionID := uidGenerator.UUID("")
+	meetingID := uidGenerator.UUID("")
+	storedEvents := setupTension(t, tensionID)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewTension(uidGenerator, tensionID)
+
+	command := commands.NewCommand(commands.CommandTypeCloseTension, correlationID, causationID, util.NilID, &commands.CloseTension{
+		Reason:    "processed",
+		MeetingID: &meetingID,
+	})
+
+	out := []ep.Event{
+		&ep.EventTensionClosed{
+			Reason:    "processed",
+			MeetingID: &meetingID,
+		},
+	}
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestChangeClosedTensionMeeting(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	tensionID := uidGenerator.UUID("")
+	meetingID := uidGenerator.UUID("")
+	storedEvents := setupTension(t, tensionID)
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewTension(uidGenerator, tensionID)
+	if err := aggregate.ApplyEvents(storedEvents); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	command := commands.NewCommand(commands.CommandTypeCloseTension, correlationID, causationID, util.NilID, &commands.CloseTension{
+		Reason: "processed",
+	})
+	events, err := aggregate.HandleCommand(command)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	closedEvents, err := toStoredEvents(events, aggregate.AggregateType(), aggregate.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the meeting can be linked after the tension has been closed
+	command = commands.NewCommand(commands.CommandTypeChangeTensionMeeting, correlationID, causationID, util.NilID, &commands.ChangeTensionMeeting{
+		MeetingID: &meetingID,
+	})
+
+	out := []ep.Event{
+		&ep.EventTensionMeetingChanged{
+			MeetingID: &meetingID,
+		},
+	}
+
+	test := &testData{
+		State:     closedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
@@ -20,9 +20,18 @@ func (at AggregateType) String() string {
 }
 
 const (
-	RolesTreeAggregate AggregateType = "rolestree"
-	MemberAggregate    AggregateType = "member"
-	TensionAggregate   AggregateType = "tension"
+	RolesTreeAggregate    AggregateType = "rolestree"
+	MemberAggregate       AggregateType = "member"
+	TensionAggregate      AggregateType = "tension"
+	ProposalAggregate     AggregateType = "proposal"
+	ProjectAggregate      AggregateType = "project"
+	ActionAggregate       AggregateType = "action"
+	MeetingAggregate      AggregateType = "meeting"
+	ElectionAggregate     AggregateType = "election"
+	RoleTemplateAggregate AggregateType = "roletemplate"
+	WebhookAggregate      AggregateType = "webhook"
+	SessionAggregate      AggregateType = "session"
+	APITokenAggregate     AggregateType = "apitoken"
 
 	MemberChangeAggregate         AggregateType = "memberchange"
 	MemberRequestHandlerAggregate AggregateType = "memberrequesthandler"
@@ -1,8 +1,10 @@
 package aggregate
 
 import (
+	"encoding/json"
 	"fmt"
 
+	"github.com/pkg/errors"
 	"github.com/sorintlab/sircles/command/commands"
 	"github.com/sorintlab/sircles/common"
 	ep "github.com/sorintlab/sircles/events"
@@ -69,6 +71,45 @@ func (r *UniqueValueRegistry) AggregateType() AggregateType {
 	return UniqueValueRegistryAggregate
 }
 
+const uniqueValueRegistrySnapshotSchemaVersion = 1
+
+type uniqueValueRegistrySnapshot struct {
+	Values          map[string]util.ID
+	ReserveRequests map[util.ID]struct{}
+	ReleaseRequests map[util.ID]struct{}
+}
+
+func (r *UniqueValueRegistry) SnapshotSchemaVersion() int64 {
+	return uniqueValueRegistrySnapshotSchemaVersion
+}
+
+func (r *UniqueValueRegistry) Snapshot() ([]byte, error) {
+	return json.Marshal(&uniqueValueRegistrySnapshot{
+		Values:          r.values,
+		ReserveRequests: r.reserveRequests,
+		ReleaseRequests: r.releaseRequests,
+	})
+}
+
+func (r *UniqueValueRegistry) RestoreSnapshot(version int64, data []byte) error {
+	s := &uniqueValueRegistrySnapshot{
+		Values:          make(map[string]util.ID),
+		ReserveRequests: make(map[util.ID]struct{}),
+		ReleaseRequests: make(map[util.ID]struct{}),
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return errors.Wrap(err, "failed to unmarshal unique value registry snapshot")
+	}
+
+	r.version = version
+
+	r.values = s.Values
+	r.reserveRequests = s.ReserveRequests
+	r.releaseRequests = s.ReleaseRequests
+
+	return nil
+}
+
 func (r *UniqueValueRegistry) HandleCommand(command *commands.Command) ([]ep.Event, error) {
 	var events []ep.Event
 	var err error
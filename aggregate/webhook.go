@@ -0,0 +1,195 @@
+package aggregate
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sorintlab/sircles/command/commands"
+	"github.com/sorintlab/sircles/common"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/util"
+)
+
+type WebhookRepository struct {
+	es           *eventstore.EventStore
+	uidGenerator common.UIDGenerator
+}
+
+func NewWebhookRepository(es *eventstore.EventStore, uidGenerator common.UIDGenerator) *WebhookRepository {
+	return &WebhookRepository{es: es, uidGenerator: uidGenerator}
+}
+
+func (wr *WebhookRepository) Load(id util.ID) (*Webhook, error) {
+	log.Debugf("Load id: %s", id)
+	w := NewWebhook(wr.uidGenerator, id)
+
+	if err := batchLoader(wr.es, id.String(), w); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Webhook is an outgoing endpoint receiving the events of some types
+type Webhook struct {
+	id      util.ID
+	version int64
+
+	created bool
+	deleted bool
+	// deadLetterSequenceNumber is the sequence number of the last event
+	// recorded as a dead letter
+	deadLetterSequenceNumber int64
+	uidGenerator             common.UIDGenerator
+}
+
+func NewWebhook(uidGenerator common.UIDGenerator, id util.ID) *Webhook {
+	return &Webhook{
+		id:           id,
+		uidGenerator: uidGenerator,
+	}
+}
+
+func (w *Webhook) Version() int64 {
+	return w.version
+}
+
+func (w *Webhook) ID() string {
+	return w.id.String()
+}
+
+func (w *Webhook) AggregateType() AggregateType {
+	return WebhookAggregate
+}
+
+func (w *Webhook) HandleCommand(command *commands.Command) ([]ep.Event, error) {
+	var events []ep.Event
+	var err error
+	switch command.CommandType {
+	case commands.CommandTypeCreateWebhook:
+		events, err = w.HandleCreateWebhookCommand(command)
+	case commands.CommandTypeUpdateWebhook:
+		events, err = w.HandleUpdateWebhookCommand(command)
+	case commands.CommandTypeDeleteWebhook:
+		events, err = w.HandleDeleteWebhookCommand(command)
+	case commands.CommandTypeRecordWebhookDeliveryFailure:
+		events, err = w.HandleRecordWebhookDeliveryFailureCommand(command)
+
+	default:
+		err = fmt.Errorf("unhandled command: %#v", command)
+	}
+
+	return events, err
+}
+
+func (w *Webhook) HandleCreateWebhookCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if w.created {
+		return nil, errors.New("webhook already exists")
+	}
+
+	c := command.Data.(*commands.CreateWebhook)
+
+	webhook := &models.Webhook{
+		URL:        c.URL,
+		EventTypes: c.EventTypes,
+		Secret:     c.Secret,
+	}
+	webhook.ID = w.id
+
+	events = append(events, ep.NewEventWebhookCreated(webhook))
+
+	return events, nil
+}
+
+func (w *Webhook) HandleUpdateWebhookCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !w.created || w.deleted {
+		return nil, errors.New("unexistent webhook")
+	}
+
+	c := command.Data.(*commands.UpdateWebhook)
+
+	webhook := &models.Webhook{
+		URL:        c.URL,
+		EventTypes: c.EventTypes,
+		Secret:     c.Secret,
+	}
+	webhook.ID = w.id
+
+	events = append(events, ep.NewEventWebhookUpdated(webhook))
+
+	return events, nil
+}
+
+func (w *Webhook) HandleDeleteWebhookCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	if !w.created || w.deleted {
+		return nil, errors.New("unexistent webhook")
+	}
+
+	events = append(events, ep.NewEventWebhookDeleted(w.id))
+
+	return events, nil
+}
+
+func (w *Webhook) HandleRecordWebhookDeliveryFailureCommand(command *commands.Command) ([]ep.Event, error) {
+	events := []ep.Event{}
+
+	// the webhook could have been deleted while retrying the delivery, just
+	// ignore the failure
+	if !w.created || w.deleted {
+		return events, nil
+	}
+
+	c := command.Data.(*commands.RecordWebhookDeliveryFailure)
+
+	// the deliveries are done in events order so an event with a lower
+	// sequence number has already been recorded
+	if c.SequenceNumber <= w.deadLetterSequenceNumber {
+		return events, nil
+	}
+
+	events = append(events, ep.NewEventWebhookDeliveryFailed(w.id, c.EventID, c.SequenceNumber, c.EventType, c.Attempts, c.LastError))
+
+	return events, nil
+}
+
+func (w *Webhook) ApplyEvents(events []*eventstore.StoredEvent) error {
+	for _, e := range events {
+		if err := w.ApplyEvent(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Webhook) ApplyEvent(event *eventstore.StoredEvent) error {
+	log.Debugf("event: %v", event)
+
+	data, err := ep.UnmarshalData(event)
+	if err != nil {
+		return err
+	}
+
+	w.version = event.Version
+
+	switch ep.EventType(event.EventType) {
+	case ep.EventTypeWebhookCreated:
+		w.created = true
+
+	case ep.EventTypeWebhookDeleted:
+		w.deleted = true
+
+	case ep.EventTypeWebhookDeliveryFailed:
+		data := data.(*ep.EventWebhookDeliveryFailed)
+		w.deadLetterSequenceNumber = data.SequenceNumber
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package aggregate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sorintlab/sircles/command/commands"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/util"
+)
+
+func setupWebhook(t *testing.T, webhookID util.ID, additionalCommands ...*commands.Command) []*eventstore.StoredEvent {
+	uidGenerator := NewTestUIDGen()
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewWebhook(uidGenerator, webhookID)
+
+	command := commands.NewCommand(commands.CommandTypeCreateWebhook, correlationID, causationID, util.NilID, &commands.CreateWebhook{
+		URL:        "https://example.com/hook",
+		EventTypes: []string{"RoleMemberAdded"},
+		Secret:     "secret",
+	})
+
+	out, err := aggregate.HandleCommand(command)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, command := range additionalCommands {
+		storedEvents, err := toStoredEvents(out, aggregate.AggregateType(), aggregate.ID())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		aggregate = NewWebhook(uidGenerator, webhookID)
+		if err := aggregate.ApplyEvents(storedEvents); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		commandOut, err := aggregate.HandleCommand(command)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out = append(out, commandOut...)
+	}
+
+	storedEvents, err := toStoredEvents(out, aggregate.AggregateType(), aggregate.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return storedEvents
+}
+
+func TestCreateWebhook(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	webhookID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	aggregate := NewWebhook(uidGenerator, webhookID)
+
+	command := commands.NewCommand(commands.CommandTypeCreateWebhook, correlationID, causationID, util.NilID, &commands.CreateWebhook{
+		URL:        "https://example.com/hook",
+		EventTypes: []string{"RoleMemberAdded", "TensionCreated"},
+		Secret:     "secret",
+	})
+
+	out := []ep.Event{
+		&ep.EventWebhookCreated{
+			URL:        "https://example.com/hook",
+			EventTypes: []string{"RoleMemberAdded", "TensionCreated"},
+			Secret:     "secret",
+		},
+	}
+
+	test := &testData{
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       out,
+	}
+
+	runTest(t, test)
+}
+
+func TestUpdateDeletedWebhook(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	webhookID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	storedEvents := setupWebhook(t, webhookID,
+		commands.NewCommand(commands.CommandTypeDeleteWebhook, correlationID, causationID, util.NilID, &commands.DeleteWebhook{}),
+	)
+
+	aggregate := NewWebhook(uidGenerator, webhookID)
+
+	command := commands.NewCommand(commands.CommandTypeUpdateWebhook, correlationID, causationID, util.NilID, &commands.UpdateWebhook{
+		URL: "https://example.com/hook",
+	})
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Err:       fmt.Errorf("unexistent webhook"),
+	}
+
+	runTest(t, test)
+}
+
+func TestRecordDeletedWebhookDeliveryFailure(t *testing.T) {
+	uidGenerator := NewTestUIDGen()
+
+	webhookID := uidGenerator.UUID("")
+	eventID := uidGenerator.UUID("")
+
+	correlationID := uidGenerator.UUID("")
+	causationID := uidGenerator.UUID("")
+
+	storedEvents := setupWebhook(t, webhookID,
+		commands.NewCommand(commands.CommandTypeDeleteWebhook, correlationID, causationID, util.NilID, &commands.DeleteWebhook{}),
+	)
+
+	aggregate := NewWebhook(uidGenerator, webhookID)
+
+	command := commands.NewCommand(commands.CommandTypeRecordWebhookDeliveryFailure, correlationID, causationID, util.NilID, &commands.RecordWebhookDeliveryFailure{
+		EventID:        eventID,
+		SequenceNumber: 10,
+		EventType:      "RoleMemberAdded",
+		Attempts:       8,
+		LastError:      "unexpected status code 500",
+	})
+
+	test := &testData{
+		State:     storedEvents,
+		Aggregate: aggregate,
+		Command:   command,
+		Out:       []ep.Event{},
+	}
+
+	runTest(t, test)
+}
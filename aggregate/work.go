@@ -0,0 +1,65 @@
+package aggregate
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/util"
+)
+
+// work contains the state and the lifecycle shared by the role projects and
+// actions: they are created on a role, can be assigned to a member and change
+// status until they reach a final one. The aggregates embedding it keep their
+// own typed status.
+type work struct {
+	// kind is the work kind ("project" or "action") reported in errors
+	kind string
+
+	id      util.ID
+	version int64
+
+	roleID   util.ID
+	memberID *util.ID
+
+	created bool
+}
+
+func (w *work) Version() int64 {
+	return w.version
+}
+
+func (w *work) ID() string {
+	return w.id.String()
+}
+
+func (w *work) checkCreate() error {
+	if w.created {
+		return errors.Errorf("%s already exists", w.kind)
+	}
+	return nil
+}
+
+func (w *work) checkUpdate(status models.WorkStatus) error {
+	if !w.created {
+		return errors.Errorf("unexistent %s", w.kind)
+	}
+	if status.IsFinal() {
+		return errors.Errorf("%s in status %q cannot be updated", w.kind, status)
+	}
+	return nil
+}
+
+func (w *work) checkChangeStatus(prevStatus, status models.WorkStatus) error {
+	if !w.created {
+		return errors.Errorf("unexistent %s", w.kind)
+	}
+	if prevStatus.IsFinal() {
+		return errors.Errorf("%s in status %q cannot be changed", w.kind, prevStatus)
+	}
+	if !status.IsValid() {
+		return errors.Errorf("invalid %s status %q", w.kind, status)
+	}
+	if status.String() == prevStatus.String() {
+		return errors.Errorf("%s already in status %q", w.kind, status)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package aggregate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sorintlab/sircles/models"
+)
+
+func TestWorkCheckUpdate(t *testing.T) {
+	tests := []struct {
+		name   string
+		w      work
+		status models.WorkStatus
+		err    error
+	}{
+		{
+			name:   "active project",
+			w:      work{kind: "project", created: true},
+			status: models.ProjectStatusActive,
+		},
+		{
+			name:   "unexistent action",
+			w:      work{kind: "action"},
+			status: models.ActionStatusOpen,
+			err:    fmt.Errorf("unexistent action"),
+		},
+		{
+			name:   "done project",
+			w:      work{kind: "project", created: true},
+			status: models.ProjectStatusDone,
+			err:    fmt.Errorf(`project in status "done" cannot be updated`),
+		},
+		{
+			name:   "dropped action",
+			w:      work{kind: "action", created: true},
+			status: models.ActionStatusDropped,
+			err:    fmt.Errorf(`action in status "dropped" cannot be updated`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkWorkErr(t, tt.w.checkUpdate(tt.status), tt.err)
+		})
+	}
+}
+
+func TestWorkCheckChangeStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		w          work
+		prevStatus models.WorkStatus
+		status     models.WorkStatus
+		err        error
+	}{
+		{
+			name:       "waiting project to active",
+			w:          work{kind: "project", created: true},
+			prevStatus: models.ProjectStatusWaiting,
+			status:     models.ProjectStatusActive,
+		},
+		{
+			name:       "open action to done",
+			w:          work{kind: "action", created: true},
+			prevStatus: models.ActionStatusOpen,
+			status:     models.ActionStatusDone,
+		},
+		{
+			name:       "unexistent project",
+			w:          work{kind: "project"},
+			prevStatus: models.ProjectStatus(""),
+			status:     models.ProjectStatusDone,
+			err:        fmt.Errorf("unexistent project"),
+		},
+		{
+			// waiting is only a project status
+			name:       "open action to waiting",
+			w:          work{kind: "action", created: true},
+			prevStatus: models.ActionStatusOpen,
+			status:     models.ActionStatus("waiting"),
+			err:        fmt.Errorf(`invalid action status "waiting"`),
+		},
+		{
+			name:       "done project to active",
+			w:          work{kind: "project", created: true},
+			prevStatus: models.ProjectStatusDone,
+			status:     models.ProjectStatusActive,
+			err:        fmt.Errorf(`project in status "done" cannot be changed`),
+		},
+		{
+			name:       "open action to open",
+			w:          work{kind: "action", created: true},
+			prevStatus: models.ActionStatusOpen,
+			status:     models.ActionStatusOpen,
+			err:        fmt.Errorf(`action already in status "open"`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkWorkErr(t, tt.w.checkChangeStatus(tt.prevStatus, tt.status), tt.err)
+		})
+	}
+}
+
+func checkWorkErr(t *testing.T, err, expectedErr error) {
+	if err != nil {
+		if expectedErr == nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if expectedErr.Error() != err.Error() {
+			t.Fatalf("got error: %q want error: %q", err, expectedErr)
+		}
+		return
+	}
+	if expectedErr != nil {
+		t.Fatalf("expected error: %q but got no error", expectedErr)
+	}
+}
@@ -0,0 +1,107 @@
+package graphql
+
+import (
+	"github.com/sorintlab/sircles/change"
+	"github.com/sorintlab/sircles/dataloader"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/readdb"
+	"github.com/sorintlab/sircles/util"
+)
+
+type actionResolver struct {
+	workResolver
+}
+
+func newActionResolver(s readdb.ReadDBService, action *models.Action, timeLine util.TimeLineNumber, dataLoaders *dataloader.DataLoaders) *actionResolver {
+	return &actionResolver{workResolver{
+		s:           s,
+		timeLine:    timeLine,
+		dataLoaders: dataLoaders,
+		kind:        "action",
+		id:          action.ID,
+		title:       action.Title,
+		description: action.Description,
+		status:      action.Status,
+		dueDate:     action.DueDate,
+	}}
+}
+
+type createActionResultResolver struct {
+	s        readdb.ReadDBService
+	action   *models.Action
+	res      *change.CreateActionResult
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *createActionResultResolver) Action() *actionResolver {
+	if r.action == nil {
+		return nil
+	}
+	return newActionResolver(r.s, r.action, r.timeLine, r.dataLoaders)
+}
+
+func (r *createActionResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *createActionResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
+
+func (r *createActionResultResolver) CreateActionChangeErrors() *workChangeErrorsResolver {
+	return &workChangeErrorsResolver{title: r.res.CreateActionChangeErrors.Title, description: r.res.CreateActionChangeErrors.Description}
+}
+
+type updateActionResultResolver struct {
+	s        readdb.ReadDBService
+	action   *models.Action
+	res      *change.UpdateActionResult
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *updateActionResultResolver) Action() *actionResolver {
+	if r.action == nil {
+		return nil
+	}
+	return newActionResolver(r.s, r.action, r.timeLine, r.dataLoaders)
+}
+
+func (r *updateActionResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *updateActionResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
+
+func (r *updateActionResultResolver) UpdateActionChangeErrors() *workChangeErrorsResolver {
+	return &workChangeErrorsResolver{title: r.res.UpdateActionChangeErrors.Title, description: r.res.UpdateActionChangeErrors.Description}
+}
+
+type actionResultResolver struct {
+	s        readdb.ReadDBService
+	action   *models.Action
+	res      *change.GenericResult
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *actionResultResolver) Action() *actionResolver {
+	if r.action == nil {
+		return nil
+	}
+	return newActionResolver(r.s, r.action, r.timeLine, r.dataLoaders)
+}
+
+func (r *actionResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *actionResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
@@ -0,0 +1,59 @@
+package graphql
+
+import (
+	"github.com/sorintlab/sircles/change"
+	"github.com/sorintlab/sircles/models"
+
+	graphql "github.com/neelance/graphql-go"
+)
+
+type apiTokenResolver struct {
+	t *models.APIToken
+}
+
+func (r *apiTokenResolver) UID() graphql.ID {
+	return marshalUID("apitoken", r.t.ID)
+}
+
+func (r *apiTokenResolver) Name() string {
+	return r.t.Name
+}
+
+func (r *apiTokenResolver) Scope() string {
+	return string(r.t.Scope)
+}
+
+func (r *apiTokenResolver) CreationTime() graphql.Time {
+	return graphql.Time{Time: r.t.CreationTime}
+}
+
+func (r *apiTokenResolver) Expiration() graphql.Time {
+	return graphql.Time{Time: r.t.Expiration}
+}
+
+type createAPITokenResultResolver struct {
+	apiToken *models.APIToken
+	res      *change.CreateAPITokenResult
+}
+
+func (r *createAPITokenResultResolver) APIToken() *apiTokenResolver {
+	if r.apiToken == nil {
+		return nil
+	}
+	return &apiTokenResolver{r.apiToken}
+}
+
+func (r *createAPITokenResultResolver) Token() *string {
+	if r.res.Token == "" {
+		return nil
+	}
+	return &r.res.Token
+}
+
+func (r *createAPITokenResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *createAPITokenResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
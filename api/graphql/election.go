@@ -0,0 +1,185 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/sorintlab/sircles/change"
+	"github.com/sorintlab/sircles/dataloader"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/readdb"
+	"github.com/sorintlab/sircles/util"
+
+	graphql "github.com/neelance/graphql-go"
+)
+
+// DefaultUpcomingElectionsInterval is the default interval used to look for
+// upcoming elections
+const DefaultUpcomingElectionsInterval = 30 * 24 * time.Hour
+
+type electionResolver struct {
+	s        readdb.ReadDBService
+	e        *models.Election
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *electionResolver) UID() graphql.ID {
+	return marshalUID("election", r.e.ID)
+}
+
+func (r *electionResolver) RoleType() string {
+	return string(r.e.RoleType)
+}
+
+func (r *electionResolver) Status() string {
+	return string(r.e.Status)
+}
+
+func (r *electionResolver) Role() (*roleResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).ElectionRole.Load(r.e.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	role := data.(*models.Role)
+	return &roleResolver{r.s, role, r.timeLine, r.dataLoaders}, nil
+}
+
+func (r *electionResolver) Nominations() (*[]*electionNominationResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).ElectionNominations.Load(r.e.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	nominations := data.([]*models.ElectionNomination)
+	l := make([]*electionNominationResolver, len(nominations))
+	for i, nomination := range nominations {
+		l[i] = &electionNominationResolver{r.s, nomination, r.timeLine, r.dataLoaders}
+	}
+	return &l, nil
+}
+
+func (r *electionResolver) Elected() (*memberResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).ElectionElected.Load(r.e.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	member := data.(*models.Member)
+	return &memberResolver{r.s, member, r.timeLine, r.dataLoaders}, nil
+}
+
+func (r *electionResolver) ElectionExpiration() *graphql.Time {
+	if r.e.ElectionExpiration == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.e.ElectionExpiration}
+}
+
+type electionNominationResolver struct {
+	s        readdb.ReadDBService
+	n        *models.ElectionNomination
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *electionNominationResolver) Candidate() *memberResolver {
+	return &memberResolver{r.s, r.n.Candidate, r.timeLine, r.dataLoaders}
+}
+
+func (r *electionNominationResolver) Nominator(ctx context.Context) (*memberResolver, error) {
+	member, err := r.s.Member(ctx, r.timeLine, r.n.NominatorID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, nil
+	}
+	return &memberResolver{r.s, member, r.timeLine, r.dataLoaders}, nil
+}
+
+type coreRoleTermResolver struct {
+	s        readdb.ReadDBService
+	t        *models.CoreRoleTerm
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *coreRoleTermResolver) CoreRole() *roleResolver {
+	return &roleResolver{r.s, r.t.CoreRole, r.timeLine, r.dataLoaders}
+}
+
+func (r *coreRoleTermResolver) Circle() (*roleResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).RoleParent.Load(r.t.CoreRole.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	role := data.(*models.Role)
+	return &roleResolver{r.s, role, r.timeLine, r.dataLoaders}, nil
+}
+
+func (r *coreRoleTermResolver) Member() *memberResolver {
+	return &memberResolver{r.s, r.t.Member, r.timeLine, r.dataLoaders}
+}
+
+func (r *coreRoleTermResolver) ElectionExpiration() graphql.Time {
+	return graphql.Time{Time: r.t.ElectionExpiration}
+}
+
+type createElectionResultResolver struct {
+	s        readdb.ReadDBService
+	election *models.Election
+	res      *change.CreateElectionResult
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *createElectionResultResolver) Election() *electionResolver {
+	if r.election == nil {
+		return nil
+	}
+	return &electionResolver{r.s, r.election, r.timeLine, r.dataLoaders}
+}
+
+func (r *createElectionResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *createElectionResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
+
+type electionResultResolver struct {
+	s        readdb.ReadDBService
+	election *models.Election
+	res      *change.GenericResult
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *electionResultResolver) Election() *electionResolver {
+	if r.election == nil {
+		return nil
+	}
+	return &electionResolver{r.s, r.election, r.timeLine, r.dataLoaders}
+}
+
+func (r *electionResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *electionResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
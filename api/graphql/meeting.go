@@ -0,0 +1,272 @@
+package graphql
+
+import (
+	"sort"
+
+	"github.com/sorintlab/sircles/change"
+	"github.com/sorintlab/sircles/dataloader"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/readdb"
+	"github.com/sorintlab/sircles/util"
+
+	graphql "github.com/neelance/graphql-go"
+)
+
+type meetingResolver struct {
+	s        readdb.ReadDBService
+	m        *models.Meeting
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *meetingResolver) UID() graphql.ID {
+	return marshalUID("meeting", r.m.ID)
+}
+
+func (r *meetingResolver) MeetingType() string {
+	return string(r.m.MeetingType)
+}
+
+func (r *meetingResolver) Date() graphql.Time {
+	return graphql.Time{Time: r.m.Date}
+}
+
+func (r *meetingResolver) Role() (*roleResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).MeetingRole.Load(r.m.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	role := data.(*models.Role)
+	return &roleResolver{r.s, role, r.timeLine, r.dataLoaders}, nil
+}
+
+func (r *meetingResolver) Facilitator() (*memberResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).MeetingFacilitator.Load(r.m.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	member := data.(*models.Member)
+	return &memberResolver{r.s, member, r.timeLine, r.dataLoaders}, nil
+}
+
+func (r *meetingResolver) Secretary() (*memberResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).MeetingSecretary.Load(r.m.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	member := data.(*models.Member)
+	return &memberResolver{r.s, member, r.timeLine, r.dataLoaders}, nil
+}
+
+func (r *meetingResolver) Attendees() (*[]*memberResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).MeetingAttendees.Load(r.m.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	members := data.([]*models.Member)
+	l := make([]*memberResolver, len(members))
+	for i, member := range members {
+		l[i] = &memberResolver{r.s, member, r.timeLine, r.dataLoaders}
+	}
+	return &l, nil
+}
+
+func (r *meetingResolver) Tensions() (*[]*tensionResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).MeetingTensions.Load(r.m.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	tensions := data.([]*models.Tension)
+	l := make([]*tensionResolver, len(tensions))
+	for i, tension := range tensions {
+		l[i] = &tensionResolver{r.s, tension, r.timeLine, r.dataLoaders}
+	}
+	return &l, nil
+}
+
+func (r *meetingResolver) Outcomes() (*[]*tensionOutcomeResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).MeetingTensions.Load(r.m.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	tensions := data.([]*models.Tension)
+	l := []*tensionOutcomeResolver{}
+	for _, tension := range tensions {
+		if tension.Status != models.TensionStatusResolved {
+			continue
+		}
+		l = append(l, &tensionOutcomeResolver{tension})
+	}
+	return &l, nil
+}
+
+func (r *meetingResolver) ChecklistItemReports() (*[]*checklistItemReportResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).MeetingChecklistItemReports.Load(r.m.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	reports := data.([]*models.ChecklistItemReport)
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].ChecklistItem.Description < reports[j].ChecklistItem.Description
+	})
+	l := make([]*checklistItemReportResolver, len(reports))
+	for i, report := range reports {
+		l[i] = &checklistItemReportResolver{r.s, report.ChecklistItem, r.m, report.Checked, r.timeLine, r.dataLoaders}
+	}
+	return &l, nil
+}
+
+func (r *meetingResolver) MetricReports() (*[]*metricReportResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).MeetingMetricReports.Load(r.m.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	reports := data.([]*models.MetricReport)
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Metric.Description < reports[j].Metric.Description
+	})
+	l := make([]*metricReportResolver, len(reports))
+	for i, report := range reports {
+		l[i] = &metricReportResolver{r.s, report.Metric, r.m, report.Value, r.timeLine, r.dataLoaders}
+	}
+	return &l, nil
+}
+
+type checklistItemReportResolver struct {
+	s             readdb.ReadDBService
+	checklistItem *models.ChecklistItem
+	meeting       *models.Meeting
+	checked       bool
+	timeLine      util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *checklistItemReportResolver) ChecklistItem() *checklistItemResolver {
+	return &checklistItemResolver{r.s, r.checklistItem, r.timeLine, r.dataLoaders}
+}
+
+func (r *checklistItemReportResolver) Meeting() *meetingResolver {
+	return &meetingResolver{r.s, r.meeting, r.timeLine, r.dataLoaders}
+}
+
+func (r *checklistItemReportResolver) Checked() bool {
+	return r.checked
+}
+
+type metricReportResolver struct {
+	s        readdb.ReadDBService
+	metric   *models.Metric
+	meeting  *models.Meeting
+	value    string
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *metricReportResolver) Metric() *metricResolver {
+	return &metricResolver{r.s, r.metric, r.timeLine, r.dataLoaders}
+}
+
+func (r *metricReportResolver) Meeting() *meetingResolver {
+	return &meetingResolver{r.s, r.meeting, r.timeLine, r.dataLoaders}
+}
+
+func (r *metricReportResolver) Value() string {
+	return r.value
+}
+
+type meetingConnectionResolver struct {
+	s           readdb.ReadDBService
+	meetings    []*models.Meeting
+	hasMoreData bool
+	timeLine    util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *meetingConnectionResolver) HasMoreData() bool {
+	return r.hasMoreData
+}
+
+func (r *meetingConnectionResolver) Edges() *[]*meetingEdgeResolver {
+	l := make([]*meetingEdgeResolver, len(r.meetings))
+	for i, meeting := range r.meetings {
+		l[i] = &meetingEdgeResolver{r.s, meeting, r.timeLine, r.dataLoaders}
+	}
+	return &l
+}
+
+type meetingEdgeResolver struct {
+	s        readdb.ReadDBService
+	meeting  *models.Meeting
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *meetingEdgeResolver) Cursor() (string, error) {
+	return marshalMeetingConnectionCursor(&MeetingConnectionCursor{TimeLineID: r.timeLine, Date: r.meeting.Date, ID: r.meeting.ID})
+}
+
+func (r *meetingEdgeResolver) Meeting() *meetingResolver {
+	return &meetingResolver{r.s, r.meeting, r.timeLine, r.dataLoaders}
+}
+
+type createMeetingResultResolver struct {
+	s        readdb.ReadDBService
+	meeting  *models.Meeting
+	res      *change.CreateMeetingResult
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *createMeetingResultResolver) Meeting() *meetingResolver {
+	if r.meeting == nil {
+		return nil
+	}
+	return &meetingResolver{r.s, r.meeting, r.timeLine, r.dataLoaders}
+}
+
+func (r *createMeetingResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *createMeetingResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
+
+type meetingResultResolver struct {
+	s        readdb.ReadDBService
+	meeting  *models.Meeting
+	res      *change.GenericResult
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *meetingResultResolver) Meeting() *meetingResolver {
+	if r.meeting == nil {
+		return nil
+	}
+	return &meetingResolver{r.s, r.meeting, r.timeLine, r.dataLoaders}
+}
+
+func (r *meetingResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *meetingResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
@@ -124,6 +124,35 @@ func (r *memberResolver) Email() string {
 	return r.m.Email
 }
 
+func (r *memberResolver) IsDeactivated() bool {
+	return r.m.IsDeactivated
+}
+
+func (r *memberResolver) IsServiceAccount() bool {
+	return r.m.IsServiceAccount
+}
+
+func (r *memberResolver) APITokens(ctx context.Context) (*[]*apiTokenResolver, error) {
+	// only the member itself or an admin can see the member api tokens
+	callingMember, err := r.s.CallingMember(ctx, r.timeLineID)
+	if err != nil {
+		return nil, err
+	}
+	if !callingMember.IsAdmin && callingMember.ID != r.m.ID {
+		return nil, nil
+	}
+
+	apiTokens, err := r.s.MemberAPITokens(ctx, r.timeLineID, r.m.ID)
+	if err != nil {
+		return nil, err
+	}
+	l := make([]*apiTokenResolver, len(apiTokens))
+	for i, apiToken := range apiTokens {
+		l[i] = &apiTokenResolver{apiToken}
+	}
+	return &l, nil
+}
+
 func (r *memberResolver) Circles() (*[]*memberCircleEdgeResolver, error) {
 	data, err := r.dataLoaders.Get(r.timeLineID).MemberCircleEdges.Load(r.m.ID.String())()
 	if err != nil {
@@ -150,6 +179,14 @@ func (r *memberResolver) Roles() (*[]*memberRoleEdgeResolver, error) {
 	return &l, nil
 }
 
+func (r *memberResolver) Assignments(ctx context.Context) (*memberAssignmentsResolver, error) {
+	assignments, err := r.s.MemberAssignments(ctx, r.timeLineID, r.m.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &memberAssignmentsResolver{r.s, assignments, r.timeLineID, r.dataLoaders}, nil
+}
+
 func (r *memberResolver) Tensions() (*[]*tensionResolver, error) {
 	data, err := r.dataLoaders.Get(r.timeLineID).MemberTensions.Load(r.m.ID.String())()
 	if err != nil {
@@ -163,6 +200,32 @@ func (r *memberResolver) Tensions() (*[]*tensionResolver, error) {
 	return &l, nil
 }
 
+func (r *memberResolver) Projects() (*[]*projectResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLineID).MemberProjects.Load(r.m.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	projects := data.([]*models.Project)
+	l := make([]*projectResolver, len(projects))
+	for i, project := range projects {
+		l[i] = newProjectResolver(r.s, project, r.timeLineID, r.dataLoaders)
+	}
+	return &l, nil
+}
+
+func (r *memberResolver) Actions() (*[]*actionResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLineID).MemberActions.Load(r.m.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	actions := data.([]*models.Action)
+	l := make([]*actionResolver, len(actions))
+	for i, action := range actions {
+		l[i] = newActionResolver(r.s, action, r.timeLineID, r.dataLoaders)
+	}
+	return &l, nil
+}
+
 type memberConnectionResolver struct {
 	s           readdb.ReadDBService
 	members     []*models.Member
@@ -276,6 +339,69 @@ func (r *updateMemberResultResolver) UpdateMemberChangeErrors() *updateMemberCha
 	return &updateMemberChangeErrorsResolver{r: r.res.UpdateMemberChangeErrors}
 }
 
+type memberAssignmentsResolver struct {
+	s          readdb.ReadDBService
+	a          *models.MemberAssignments
+	timeLineID util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *memberAssignmentsResolver) memberRoleEdges(memberRoleEdges []*models.MemberRoleEdge) []*memberRoleEdgeResolver {
+	l := make([]*memberRoleEdgeResolver, len(memberRoleEdges))
+	for i, memberRoleEdge := range memberRoleEdges {
+		l[i] = &memberRoleEdgeResolver{r.s, memberRoleEdge, r.timeLineID, r.dataLoaders}
+	}
+	return l
+}
+
+func (r *memberAssignmentsResolver) roles(roles []*models.Role) []*roleResolver {
+	l := make([]*roleResolver, len(roles))
+	for i, role := range roles {
+		l[i] = &roleResolver{r.s, role, r.timeLineID, r.dataLoaders}
+	}
+	return l
+}
+
+func (r *memberAssignmentsResolver) Roles() []*memberRoleEdgeResolver {
+	return r.memberRoleEdges(r.a.Roles)
+}
+
+func (r *memberAssignmentsResolver) LeadLinkCircles() []*roleResolver {
+	return r.roles(r.a.LeadLinkCircles)
+}
+
+func (r *memberAssignmentsResolver) CoreRoles() []*memberRoleEdgeResolver {
+	return r.memberRoleEdges(r.a.CoreRoles)
+}
+
+func (r *memberAssignmentsResolver) DirectCircles() []*roleResolver {
+	return r.roles(r.a.DirectCircles)
+}
+
+type deactivateMemberResultResolver struct {
+	s          readdb.ReadDBService
+	res        *change.DeactivateMemberResult
+	timeLineID util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *deactivateMemberResultResolver) Assignments() *memberAssignmentsResolver {
+	if r.res.Assignments == nil {
+		return nil
+	}
+	return &memberAssignmentsResolver{r.s, r.res.Assignments, r.timeLineID, r.dataLoaders}
+}
+
+func (r *deactivateMemberResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *deactivateMemberResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
+
 type updateMemberChangeErrorsResolver struct {
 	r change.UpdateMemberChangeErrors
 }
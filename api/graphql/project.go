@@ -0,0 +1,107 @@
+package graphql
+
+import (
+	"github.com/sorintlab/sircles/change"
+	"github.com/sorintlab/sircles/dataloader"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/readdb"
+	"github.com/sorintlab/sircles/util"
+)
+
+type projectResolver struct {
+	workResolver
+}
+
+func newProjectResolver(s readdb.ReadDBService, project *models.Project, timeLine util.TimeLineNumber, dataLoaders *dataloader.DataLoaders) *projectResolver {
+	return &projectResolver{workResolver{
+		s:           s,
+		timeLine:    timeLine,
+		dataLoaders: dataLoaders,
+		kind:        "project",
+		id:          project.ID,
+		title:       project.Title,
+		description: project.Description,
+		status:      project.Status,
+		dueDate:     project.DueDate,
+	}}
+}
+
+type createProjectResultResolver struct {
+	s        readdb.ReadDBService
+	project  *models.Project
+	res      *change.CreateProjectResult
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *createProjectResultResolver) Project() *projectResolver {
+	if r.project == nil {
+		return nil
+	}
+	return newProjectResolver(r.s, r.project, r.timeLine, r.dataLoaders)
+}
+
+func (r *createProjectResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *createProjectResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
+
+func (r *createProjectResultResolver) CreateProjectChangeErrors() *workChangeErrorsResolver {
+	return &workChangeErrorsResolver{title: r.res.CreateProjectChangeErrors.Title, description: r.res.CreateProjectChangeErrors.Description}
+}
+
+type updateProjectResultResolver struct {
+	s        readdb.ReadDBService
+	project  *models.Project
+	res      *change.UpdateProjectResult
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *updateProjectResultResolver) Project() *projectResolver {
+	if r.project == nil {
+		return nil
+	}
+	return newProjectResolver(r.s, r.project, r.timeLine, r.dataLoaders)
+}
+
+func (r *updateProjectResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *updateProjectResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
+
+func (r *updateProjectResultResolver) UpdateProjectChangeErrors() *workChangeErrorsResolver {
+	return &workChangeErrorsResolver{title: r.res.UpdateProjectChangeErrors.Title, description: r.res.UpdateProjectChangeErrors.Description}
+}
+
+type projectResultResolver struct {
+	s        readdb.ReadDBService
+	project  *models.Project
+	res      *change.GenericResult
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *projectResultResolver) Project() *projectResolver {
+	if r.project == nil {
+		return nil
+	}
+	return newProjectResolver(r.s, r.project, r.timeLine, r.dataLoaders)
+}
+
+func (r *projectResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *projectResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
@@ -0,0 +1,306 @@
+package graphql
+
+import (
+	"github.com/sorintlab/sircles/change"
+	"github.com/sorintlab/sircles/dataloader"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/readdb"
+	"github.com/sorintlab/sircles/util"
+
+	graphql "github.com/neelance/graphql-go"
+)
+
+type proposalResolver struct {
+	s        readdb.ReadDBService
+	p        *models.Proposal
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *proposalResolver) UID() graphql.ID {
+	return marshalUID("proposal", r.p.ID)
+}
+
+func (r *proposalResolver) Title() string {
+	return r.p.Title
+}
+
+func (r *proposalResolver) Description() string {
+	return r.p.Description
+}
+
+func (r *proposalResolver) Status() string {
+	return string(r.p.Status)
+}
+
+func (r *proposalResolver) Role() (*roleResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).ProposalRole.Load(r.p.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	role := data.(*models.Role)
+	return &roleResolver{r.s, role, r.timeLine, r.dataLoaders}, nil
+}
+
+func (r *proposalResolver) Member() (*memberResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).ProposalMember.Load(r.p.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	member := data.(*models.Member)
+	return &memberResolver{r.s, member, r.timeLine, r.dataLoaders}, nil
+}
+
+func (r *proposalResolver) Changes() (*[]*proposalRoleChangeResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).ProposalChanges.Load(r.p.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	pc := data.(*change.ProposalChanges)
+
+	l := []*proposalRoleChangeResolver{}
+	for _, c := range pc.CreateRoleChanges {
+		name := c.Name
+		l = append(l, &proposalRoleChangeResolver{changeType: "create", name: &name})
+	}
+	for _, c := range pc.UpdateRoleChanges {
+		uid := marshalUID("role", c.ID)
+		var name *string
+		if c.NameChanged {
+			n := c.Name
+			name = &n
+		}
+		l = append(l, &proposalRoleChangeResolver{changeType: "update", roleUID: &uid, name: name})
+	}
+	for _, c := range pc.DeleteRoleChanges {
+		uid := marshalUID("role", c.ID)
+		l = append(l, &proposalRoleChangeResolver{changeType: "delete", roleUID: &uid})
+	}
+	return &l, nil
+}
+
+func (r *proposalResolver) Objections() (*[]*proposalObjectionResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).ProposalObjections.Load(r.p.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	objections := data.([]*models.ProposalObjection)
+	l := make([]*proposalObjectionResolver, len(objections))
+	for i, objection := range objections {
+		l[i] = &proposalObjectionResolver{r.s, objection, r.timeLine, r.dataLoaders}
+	}
+	return &l, nil
+}
+
+func (r *proposalResolver) Consents() (*[]*memberResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).ProposalConsents.Load(r.p.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	members := data.([]*models.Member)
+	l := make([]*memberResolver, len(members))
+	for i, member := range members {
+		l[i] = &memberResolver{r.s, member, r.timeLine, r.dataLoaders}
+	}
+	return &l, nil
+}
+
+type proposalObjectionResolver struct {
+	s        readdb.ReadDBService
+	o        *models.ProposalObjection
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *proposalObjectionResolver) UID() graphql.ID {
+	return marshalUID("proposalobjection", r.o.ID)
+}
+
+func (r *proposalObjectionResolver) Member() (*memberResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).ObjectionMember.Load(r.o.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	member := data.(*models.Member)
+	return &memberResolver{r.s, member, r.timeLine, r.dataLoaders}, nil
+}
+
+func (r *proposalObjectionResolver) Reason() string {
+	return r.o.Reason
+}
+
+func (r *proposalObjectionResolver) Resolution() string {
+	return r.o.Resolution
+}
+
+func (r *proposalObjectionResolver) Status() string {
+	return string(r.o.Status)
+}
+
+type proposalRoleChangeResolver struct {
+	changeType string
+	roleUID    *graphql.ID
+	name       *string
+}
+
+func (r *proposalRoleChangeResolver) ChangeType() string {
+	return r.changeType
+}
+
+func (r *proposalRoleChangeResolver) RoleUID() *graphql.ID {
+	return r.roleUID
+}
+
+func (r *proposalRoleChangeResolver) Name() *string {
+	return r.name
+}
+
+type createProposalResultResolver struct {
+	s        readdb.ReadDBService
+	proposal *models.Proposal
+	res      *change.CreateProposalResult
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *createProposalResultResolver) Proposal() *proposalResolver {
+	if r.proposal == nil {
+		return nil
+	}
+	return &proposalResolver{r.s, r.proposal, r.timeLine, r.dataLoaders}
+}
+
+func (r *createProposalResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *createProposalResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
+
+func (r *createProposalResultResolver) CreateProposalChangeErrors() *createProposalChangeErrorsResolver {
+	return &createProposalChangeErrorsResolver{r: r.res.CreateProposalChangeErrors}
+}
+
+type createProposalChangeErrorsResolver struct {
+	r change.CreateProposalChangeErrors
+}
+
+func (r *createProposalChangeErrorsResolver) Title() *string {
+	return errorToStringP(r.r.Title)
+}
+
+func (r *createProposalChangeErrorsResolver) Description() *string {
+	return errorToStringP(r.r.Description)
+}
+
+func (r *createProposalChangeErrorsResolver) CreateRoleChangesErrors() *[]*createRoleChangeErrorsResolver {
+	l := make([]*createRoleChangeErrorsResolver, len(r.r.CreateRoleChangesErrors))
+	for i, r := range r.r.CreateRoleChangesErrors {
+		l[i] = &createRoleChangeErrorsResolver{r: r}
+	}
+	return &l
+}
+
+func (r *createProposalChangeErrorsResolver) UpdateRoleChangesErrors() *[]*updateRoleChangeErrorsResolver {
+	l := make([]*updateRoleChangeErrorsResolver, len(r.r.UpdateRoleChangesErrors))
+	for i, r := range r.r.UpdateRoleChangesErrors {
+		l[i] = &updateRoleChangeErrorsResolver{r: r}
+	}
+	return &l
+}
+
+type updateProposalResultResolver struct {
+	s        readdb.ReadDBService
+	proposal *models.Proposal
+	res      *change.UpdateProposalResult
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *updateProposalResultResolver) Proposal() *proposalResolver {
+	if r.proposal == nil {
+		return nil
+	}
+	return &proposalResolver{r.s, r.proposal, r.timeLine, r.dataLoaders}
+}
+
+func (r *updateProposalResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *updateProposalResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
+
+func (r *updateProposalResultResolver) UpdateProposalChangeErrors() *updateProposalChangeErrorsResolver {
+	return &updateProposalChangeErrorsResolver{r: r.res.UpdateProposalChangeErrors}
+}
+
+type updateProposalChangeErrorsResolver struct {
+	r change.UpdateProposalChangeErrors
+}
+
+func (r *updateProposalChangeErrorsResolver) Title() *string {
+	return errorToStringP(r.r.Title)
+}
+
+func (r *updateProposalChangeErrorsResolver) Description() *string {
+	return errorToStringP(r.r.Description)
+}
+
+func (r *updateProposalChangeErrorsResolver) CreateRoleChangesErrors() *[]*createRoleChangeErrorsResolver {
+	l := make([]*createRoleChangeErrorsResolver, len(r.r.CreateRoleChangesErrors))
+	for i, r := range r.r.CreateRoleChangesErrors {
+		l[i] = &createRoleChangeErrorsResolver{r: r}
+	}
+	return &l
+}
+
+func (r *updateProposalChangeErrorsResolver) UpdateRoleChangesErrors() *[]*updateRoleChangeErrorsResolver {
+	l := make([]*updateRoleChangeErrorsResolver, len(r.r.UpdateRoleChangesErrors))
+	for i, r := range r.r.UpdateRoleChangesErrors {
+		l[i] = &updateRoleChangeErrorsResolver{r: r}
+	}
+	return &l
+}
+
+type proposalResultResolver struct {
+	s        readdb.ReadDBService
+	proposal *models.Proposal
+	res      *change.GenericResult
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *proposalResultResolver) Proposal() *proposalResolver {
+	if r.proposal == nil {
+		return nil
+	}
+	return &proposalResolver{r.s, r.proposal, r.timeLine, r.dataLoaders}
+}
+
+func (r *proposalResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *proposalResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
@@ -3,6 +3,7 @@ package graphql
 import (
 	"context"
 	"sort"
+	"time"
 
 	"github.com/sorintlab/sircles/change"
 	"github.com/sorintlab/sircles/dataloader"
@@ -76,6 +77,34 @@ func (r *roleResolver) Accountabilities() (*[]*accountabilityResolver, error) {
 	return &l, nil
 }
 
+func (r *roleResolver) ChecklistItems() (*[]*checklistItemResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLineID).RoleChecklistItems.Load(r.r.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	checklistItems := data.([]*models.ChecklistItem)
+	sort.Sort(models.ChecklistItems(checklistItems))
+	l := make([]*checklistItemResolver, len(checklistItems))
+	for i, checklistItem := range checklistItems {
+		l[i] = &checklistItemResolver{r.s, checklistItem, r.timeLineID, r.dataLoaders}
+	}
+	return &l, nil
+}
+
+func (r *roleResolver) Metrics() (*[]*metricResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLineID).RoleMetrics.Load(r.r.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	metrics := data.([]*models.Metric)
+	sort.Sort(models.Metrics(metrics))
+	l := make([]*metricResolver, len(metrics))
+	for i, metric := range metrics {
+		l[i] = &metricResolver{r.s, metric, r.timeLineID, r.dataLoaders}
+	}
+	return &l, nil
+}
+
 func (r *roleResolver) AdditionalContent() (*roleAdditionalContentResolver, error) {
 	data, err := r.dataLoaders.Get(r.timeLineID).RoleAdditionalContent.Load(r.r.ID.String())()
 	if err != nil {
@@ -169,6 +198,102 @@ func (r *roleResolver) Tensions() (*[]*tensionResolver, error) {
 	return &l, nil
 }
 
+func (r *roleResolver) Proposals() (*[]*proposalResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLineID).RoleProposals.Load(r.r.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	proposals := data.([]*models.Proposal)
+	l := make([]*proposalResolver, len(proposals))
+	for i, proposal := range proposals {
+		l[i] = &proposalResolver{r.s, proposal, r.timeLineID, r.dataLoaders}
+	}
+	return &l, nil
+}
+
+func (r *roleResolver) Projects() (*[]*projectResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLineID).RoleProjects.Load(r.r.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	projects := data.([]*models.Project)
+	l := make([]*projectResolver, len(projects))
+	for i, project := range projects {
+		l[i] = newProjectResolver(r.s, project, r.timeLineID, r.dataLoaders)
+	}
+	return &l, nil
+}
+
+func (r *roleResolver) Actions() (*[]*actionResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLineID).RoleActions.Load(r.r.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	actions := data.([]*models.Action)
+	l := make([]*actionResolver, len(actions))
+	for i, action := range actions {
+		l[i] = newActionResolver(r.s, action, r.timeLineID, r.dataLoaders)
+	}
+	return &l, nil
+}
+
+func (r *roleResolver) Elections() (*[]*electionResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLineID).RoleElections.Load(r.r.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	elections := data.([]*models.Election)
+	l := make([]*electionResolver, len(elections))
+	for i, election := range elections {
+		l[i] = &electionResolver{r.s, election, r.timeLineID, r.dataLoaders}
+	}
+	return &l, nil
+}
+
+func (r *roleResolver) Template() (*roleTemplateResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLineID).RoleRoleTemplate.Load(r.r.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	roleTemplate := data.(*models.RoleTemplate)
+	return &roleTemplateResolver{r.s, roleTemplate, r.timeLineID, r.dataLoaders}, nil
+}
+
+func (r *roleResolver) Meetings(ctx context.Context, args *struct {
+	First *float64
+	After *string
+}) (*meetingConnectionResolver, error) {
+	timeLineID := r.timeLineID
+	var afterDate *time.Time
+	var afterID *util.ID
+
+	// by default, if no cursor is defined use the query provided timeline
+	if args.After != nil {
+		cursor, err := unmarshalMeetingConnectionCursor(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		timeLineID = cursor.TimeLineID
+		afterDate = &cursor.Date
+		afterID = &cursor.ID
+	}
+	first := 0
+	if args.First != nil {
+		first = int(*args.First)
+	}
+	meetings, hasMoreData, err := r.s.RoleMeetings(ctx, timeLineID, r.r.ID, first, afterDate, afterID)
+	if err != nil {
+		return nil, err
+	}
+	return &meetingConnectionResolver{r.s, meetings, hasMoreData, timeLineID, r.dataLoaders}, nil
+}
+
 func (r *roleResolver) MemberCirclePermissions(ctx context.Context) (*memberCirclePermissionsResolver, error) {
 	m, err := r.s.MemberCirclePermissions(ctx, r.timeLineID, r.r.ID)
 	if err != nil {
@@ -236,6 +361,64 @@ func (r *accountabilityResolver) Description() string {
 	return r.d.Description
 }
 
+type checklistItemResolver struct {
+	s          readdb.ReadDBService
+	c          *models.ChecklistItem
+	timeLineID util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *checklistItemResolver) UID() graphql.ID {
+	return marshalUID("checklistitem", r.c.ID)
+}
+
+func (r *checklistItemResolver) Description() string {
+	return r.c.Description
+}
+
+func (r *checklistItemResolver) Reports() (*[]*checklistItemReportResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLineID).ChecklistItemReports.Load(r.c.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	reports := data.([]*models.ChecklistItemReport)
+	l := make([]*checklistItemReportResolver, len(reports))
+	for i, report := range reports {
+		l[i] = &checklistItemReportResolver{r.s, r.c, report.Meeting, report.Checked, r.timeLineID, r.dataLoaders}
+	}
+	return &l, nil
+}
+
+type metricResolver struct {
+	s          readdb.ReadDBService
+	m          *models.Metric
+	timeLineID util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *metricResolver) UID() graphql.ID {
+	return marshalUID("metric", r.m.ID)
+}
+
+func (r *metricResolver) Description() string {
+	return r.m.Description
+}
+
+func (r *metricResolver) Reports() (*[]*metricReportResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLineID).MetricReports.Load(r.m.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	reports := data.([]*models.MetricReport)
+	l := make([]*metricReportResolver, len(reports))
+	for i, report := range reports {
+		l[i] = &metricReportResolver{r.s, r.m, report.Meeting, report.Value, r.timeLineID, r.dataLoaders}
+	}
+	return &l, nil
+}
+
 type roleAdditionalContentResolver struct {
 	s          readdb.ReadDBService
 	c          *models.RoleAdditionalContent
@@ -582,6 +765,38 @@ func (r *updateRoleChangeErrorsResolver) UpdateAccountabilityChangesErrors() *[]
 	return &l
 }
 
+func (r *updateRoleChangeErrorsResolver) CreateChecklistItemChangesErrors() *[]*createChecklistItemChangeErrorsResolver {
+	l := make([]*createChecklistItemChangeErrorsResolver, len(r.r.CreateChecklistItemChangesErrors))
+	for i, r := range r.r.CreateChecklistItemChangesErrors {
+		l[i] = &createChecklistItemChangeErrorsResolver{r: r}
+	}
+	return &l
+}
+
+func (r *updateRoleChangeErrorsResolver) UpdateChecklistItemChangesErrors() *[]*updateChecklistItemChangeErrorsResolver {
+	l := make([]*updateChecklistItemChangeErrorsResolver, len(r.r.UpdateChecklistItemChangesErrors))
+	for i, r := range r.r.UpdateChecklistItemChangesErrors {
+		l[i] = &updateChecklistItemChangeErrorsResolver{r: r}
+	}
+	return &l
+}
+
+func (r *updateRoleChangeErrorsResolver) CreateMetricChangesErrors() *[]*createMetricChangeErrorsResolver {
+	l := make([]*createMetricChangeErrorsResolver, len(r.r.CreateMetricChangesErrors))
+	for i, r := range r.r.CreateMetricChangesErrors {
+		l[i] = &createMetricChangeErrorsResolver{r: r}
+	}
+	return &l
+}
+
+func (r *updateRoleChangeErrorsResolver) UpdateMetricChangesErrors() *[]*updateMetricChangeErrorsResolver {
+	l := make([]*updateMetricChangeErrorsResolver, len(r.r.UpdateMetricChangesErrors))
+	for i, r := range r.r.UpdateMetricChangesErrors {
+		l[i] = &updateMetricChangeErrorsResolver{r: r}
+	}
+	return &l
+}
+
 func (r *updateRoleChangeErrorsResolver) Name() *string {
 	return errorToStringP(r.r.Name)
 }
@@ -638,6 +853,38 @@ func (r *updateAccountabilityChangeErrorsResolver) Description() *string {
 	return errorToStringP(r.r.Description)
 }
 
+type createChecklistItemChangeErrorsResolver struct {
+	r change.CreateChecklistItemChangeErrors
+}
+
+func (r *createChecklistItemChangeErrorsResolver) Description() *string {
+	return errorToStringP(r.r.Description)
+}
+
+type updateChecklistItemChangeErrorsResolver struct {
+	r change.UpdateChecklistItemChangeErrors
+}
+
+func (r *updateChecklistItemChangeErrorsResolver) Description() *string {
+	return errorToStringP(r.r.Description)
+}
+
+type createMetricChangeErrorsResolver struct {
+	r change.CreateMetricChangeErrors
+}
+
+func (r *createMetricChangeErrorsResolver) Description() *string {
+	return errorToStringP(r.r.Description)
+}
+
+type updateMetricChangeErrorsResolver struct {
+	r change.UpdateMetricChangeErrors
+}
+
+func (r *updateMetricChangeErrorsResolver) Description() *string {
+	return errorToStringP(r.r.Description)
+}
+
 type setRoleAdditionalContentResultResolver struct {
 	s          readdb.ReadDBService
 	c          *models.RoleAdditionalContent
@@ -661,3 +908,27 @@ func (r *setRoleAdditionalContentResultResolver) HasErrors() bool {
 func (r *setRoleAdditionalContentResultResolver) GenericError() *string {
 	return errorToStringP(r.res.GenericError)
 }
+
+type moveRoleResultResolver struct {
+	s          readdb.ReadDBService
+	role       *models.Role
+	res        *change.MoveRoleResult
+	timeLineID util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *moveRoleResultResolver) Role() *roleResolver {
+	if r.role == nil {
+		return nil
+	}
+	return &roleResolver{r.s, r.role, r.timeLineID, r.dataLoaders}
+}
+
+func (r *moveRoleResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *moveRoleResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
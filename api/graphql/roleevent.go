@@ -29,6 +29,8 @@ func (r *roleEventConnectionResolver) Edges() *[]*roleEventEdgeResolver {
 		switch event.EventType {
 		case models.RoleEventTypeCircleChangesApplied:
 			ok = true
+		case models.RoleEventTypeProposalReviewed:
+			ok = true
 		}
 		if ok {
 			l = append(l, &roleEventEdgeResolver{r.s, event, r.dataLoaders})
@@ -53,6 +55,9 @@ func (r *roleEventEdgeResolver) Event() *roleEventResolver {
 	case models.RoleEventTypeCircleChangesApplied:
 		eventData := r.event.Data.(*models.RoleEventCircleChangesApplied)
 		return &roleEventResolver{&roleEventCircleChangesAppliedResolver{r.s, r.event, eventData, r.dataLoaders}}
+	case models.RoleEventTypeProposalReviewed:
+		eventData := r.event.Data.(*models.RoleEventProposalReviewed)
+		return &roleEventResolver{&roleEventProposalReviewedResolver{r.s, r.event, eventData, r.dataLoaders}}
 	default:
 		return nil
 	}
@@ -72,6 +77,11 @@ func (r *roleEventResolver) ToRoleEventCircleChangesApplied() (*roleEventCircleC
 	return t, ok
 }
 
+func (r *roleEventResolver) ToRoleEventProposalReviewed() (*roleEventProposalReviewedResolver, bool) {
+	t, ok := r.roleEvent.(*roleEventProposalReviewedResolver)
+	return t, ok
+}
+
 type roleEventCircleChangesAppliedResolver struct {
 	s         readdb.ReadDBService
 	event     *models.RoleEvent
@@ -288,3 +298,81 @@ func (r *roleParentChangeResolver) NewParent(ctx context.Context) (*roleResolver
 	}
 	return NewRoleResolver(r.s, role, r.event.TimeLineID, r.dataLoaders), nil
 }
+
+type roleEventProposalReviewedResolver struct {
+	s         readdb.ReadDBService
+	event     *models.RoleEvent
+	eventData *models.RoleEventProposalReviewed
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *roleEventProposalReviewedResolver) TimeLine(ctx context.Context) (*timeLineResolver, error) {
+	tl, err := r.s.TimeLine(ctx, r.event.TimeLineID)
+	if err != nil {
+		return nil, err
+	}
+	if tl == nil {
+		return nil, nil
+	}
+	return &timeLineResolver{r.s, tl, r.dataLoaders}, nil
+}
+
+func (r *roleEventProposalReviewedResolver) Type() string {
+	return string(r.event.EventType)
+}
+
+func (r *roleEventProposalReviewedResolver) Role(ctx context.Context) (*roleResolver, error) {
+	role, err := r.s.Role(ctx, r.event.TimeLineID, r.event.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+	return NewRoleResolver(r.s, role, r.event.TimeLineID, r.dataLoaders), nil
+}
+
+func (r *roleEventProposalReviewedResolver) Proposal(ctx context.Context) (*proposalResolver, error) {
+	proposal, err := r.s.Proposal(ctx, r.event.TimeLineID, r.eventData.ProposalID)
+	if err != nil {
+		return nil, err
+	}
+	if proposal == nil {
+		return nil, nil
+	}
+	return &proposalResolver{r.s, proposal, r.event.TimeLineID, r.dataLoaders}, nil
+}
+
+func (r *roleEventProposalReviewedResolver) Member(ctx context.Context) (*memberResolver, error) {
+	member, err := r.s.Member(ctx, r.event.TimeLineID, r.eventData.MemberID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, nil
+	}
+	return &memberResolver{r.s, member, r.event.TimeLineID, r.dataLoaders}, nil
+}
+
+func (r *roleEventProposalReviewedResolver) ReviewType() string {
+	return string(r.eventData.ReviewType)
+}
+
+func (r *roleEventProposalReviewedResolver) Objection(ctx context.Context) (*proposalObjectionResolver, error) {
+	if r.eventData.ObjectionID == nil {
+		return nil, nil
+	}
+	objection, err := r.s.ProposalObjection(ctx, r.event.TimeLineID, *r.eventData.ObjectionID)
+	if err != nil {
+		return nil, err
+	}
+	if objection == nil {
+		return nil, nil
+	}
+	return &proposalObjectionResolver{r.s, objection, r.event.TimeLineID, r.dataLoaders}, nil
+}
+
+func (r *roleEventProposalReviewedResolver) Text() string {
+	return r.eventData.Text
+}
@@ -0,0 +1,128 @@
+package graphql
+
+import (
+	"github.com/sorintlab/sircles/change"
+	"github.com/sorintlab/sircles/dataloader"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/readdb"
+	"github.com/sorintlab/sircles/util"
+
+	graphql "github.com/neelance/graphql-go"
+)
+
+type roleTemplateResolver struct {
+	s        readdb.ReadDBService
+	rt       *models.RoleTemplate
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *roleTemplateResolver) UID() graphql.ID {
+	return marshalUID("roletemplate", r.rt.ID)
+}
+
+func (r *roleTemplateResolver) Name() string {
+	return r.rt.Name
+}
+
+func (r *roleTemplateResolver) Purpose() string {
+	return r.rt.Purpose
+}
+
+func (r *roleTemplateResolver) Domains() []string {
+	return r.rt.Domains
+}
+
+func (r *roleTemplateResolver) Accountabilities() []string {
+	return r.rt.Accountabilities
+}
+
+func (r *roleTemplateResolver) Instances() (*[]*roleResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).RoleTemplateInstances.Load(r.rt.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	roles := data.([]*models.Role)
+	l := make([]*roleResolver, len(roles))
+	for i, role := range roles {
+		l[i] = &roleResolver{r.s, role, r.timeLine, r.dataLoaders}
+	}
+	return &l, nil
+}
+
+type createRoleTemplateResultResolver struct {
+	s            readdb.ReadDBService
+	roleTemplate *models.RoleTemplate
+	res          *change.CreateRoleTemplateResult
+	timeLine     util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *createRoleTemplateResultResolver) RoleTemplate() *roleTemplateResolver {
+	if r.roleTemplate == nil {
+		return nil
+	}
+	return &roleTemplateResolver{r.s, r.roleTemplate, r.timeLine, r.dataLoaders}
+}
+
+func (r *createRoleTemplateResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *createRoleTemplateResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
+
+type roleTemplateResultResolver struct {
+	s            readdb.ReadDBService
+	roleTemplate *models.RoleTemplate
+	res          *change.GenericResult
+	timeLine     util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *roleTemplateResultResolver) RoleTemplate() *roleTemplateResolver {
+	if r.roleTemplate == nil {
+		return nil
+	}
+	return &roleTemplateResolver{r.s, r.roleTemplate, r.timeLine, r.dataLoaders}
+}
+
+func (r *roleTemplateResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *roleTemplateResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
+
+type proposeRoleTemplateUpdateResultResolver struct {
+	s         readdb.ReadDBService
+	proposals []*models.Proposal
+	res       *change.ProposeRoleTemplateUpdateResult
+	timeLine  util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *proposeRoleTemplateUpdateResultResolver) Proposals() *[]*proposalResolver {
+	if r.proposals == nil {
+		return nil
+	}
+	l := make([]*proposalResolver, len(r.proposals))
+	for i, proposal := range r.proposals {
+		l[i] = &proposalResolver{r.s, proposal, r.timeLine, r.dataLoaders}
+	}
+	return &l
+}
+
+func (r *proposeRoleTemplateUpdateResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *proposeRoleTemplateUpdateResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sorintlab/sircles/auth"
@@ -34,6 +35,7 @@ var Schema = `
 	schema {
 		query: Query
 		mutation: Mutation
+		subscription: Subscription
 	}
 
 	# The query type, represents all of the entry points into our object graph
@@ -42,18 +44,37 @@ var Schema = `
 
 		timeLine(id: TimeLineID): TimeLine
 		timeLines(fromTime: Time, fromID: String, first: Int, last: Int, after: String, before: String, aggregateType: String, aggregateID: ID): TimeLineConnection
+		// roles changes between two timelines, optionally limited to the subtree of the provided role
+		timeLineDiff(from: TimeLineID!, to: TimeLineID!, roleUID: ID): TimeLineDiff
 
 		rootRole(timeLineID: TimeLineID): Role
 		role(timeLineID: TimeLineID, uid: ID!): Role
 		member(timeLineID: TimeLineID, uid: ID!): Member
 		tension(timeLineID: TimeLineID, uid: ID!): Tension
+		proposal(timeLineID: TimeLineID, uid: ID!): Proposal
+		project(timeLineID: TimeLineID, uid: ID!): Project
+		action(timeLineID: TimeLineID, uid: ID!): Action
+		meeting(timeLineID: TimeLineID, uid: ID!): Meeting
+		election(timeLineID: TimeLineID, uid: ID!): Election
+		// core roles assignments whose election expires before the provided time (by default in the next 30 days)
+		upcomingElections(timeLineID: TimeLineID, before: Time): [CoreRoleTerm!]
+		// core roles assignments whose election already expired
+		expiredElections(timeLineID: TimeLineID): [CoreRoleTerm!]
+		roleTemplate(timeLineID: TimeLineID, uid: ID!): RoleTemplate
+		// the role templates library, ordered by name
+		roleTemplates(timeLineID: TimeLineID): [RoleTemplate!]
+		// the registered webhooks, ordered by url. Only admins can list them
+		webhooks: [Webhook!]
 
 		members(timeLineID: TimeLineID, search: String, first: Int, after: String): MemberConnection
 
 		// TODO(sgotti) add pagination
 		roles(timeLineID: TimeLineID): [Role!]
 
-		search(query: String!): SearchResult!
+		// search roles, members and tensions. types restricts the search to
+		// the provided types
+		search(query: String!, types: [SearchType!], first: Int, after: String): SearchResult!
+		searchIndexStatus: SearchIndexStatus!
 	}
 
 	type Mutation {
@@ -66,6 +87,8 @@ var Schema = `
 		circleUpdateChildRole(roleUID: ID!, updateRoleChange: UpdateRoleChange!): UpdateRoleResult
 		// deletes a sub role inside a circle
 		circleDeleteChildRole(roleUID: ID!, deleteRoleChange: DeleteRoleChange!): DeleteRoleResult
+		// moves a role, with all its sub roles, under another circle
+		moveRole(roleUID: ID!, newParentUID: ID!): MoveRoleResult
 
 		setRoleAdditionalContent(roleUID: ID! content: String!): SetRoleAdditionalContentResult
 
@@ -93,11 +116,100 @@ var Schema = `
 		updateMember(updateMemberChange: UpdateMemberChange): UpdateMemberResult
 		setMemberPassword(memberUID: ID!, curPassword: String, newPassword: String!): GenericResult
 		setMemberMatchUID(memberUID: ID!, matchUID: String!): GenericResult
+		// deactivates a member, when removeAssignments is true the member is
+		// also removed from all its roles and circles
+		deactivateMember(memberUID: ID!, removeAssignments: Boolean): DeactivateMemberResult
+		reactivateMember(memberUID: ID!): GenericResult
+		// revokes all the member sessions (logout from everywhere)
+		revokeMemberSessions(memberUID: ID!): GenericResult
+		// creates an api token for the viewer or, only for admins, for a service account
+		createAPIToken(createAPITokenChange: CreateAPITokenChange!): CreateAPITokenResult
+		revokeAPIToken(uid: ID!): GenericResult
 		importMember(loginName: String!): Member
 
 		createTension(createTensionChange: CreateTensionChange): CreateTensionResult
 		updateTension(updateTensionChange: UpdateTensionChange): UpdateTensionResult
+		// closes a tension dropping it
 		closeTension(closeTensionChange: CloseTensionChange): CloseTensionResult
+		// changes the status of an open tension. The resolved and dropped statuses are set with resolveTension and closeTension
+		changeTensionStatus(tensionUID: ID!, status: TensionStatus!): TensionTriageResult
+		// assigns an open tension to a member, a null memberUID unassigns it
+		changeTensionAssignee(tensionUID: ID!, memberUID: ID): TensionTriageResult
+		// links the tension to the meeting where it was processed, a null meetingUID removes the link
+		changeTensionMeeting(tensionUID: ID!, meetingUID: ID): TensionTriageResult
+		// resolves a tension recording its outcome
+		resolveTension(resolveTensionChange: ResolveTensionChange!): TensionTriageResult
+
+		// creates a draft governance proposal for a circle
+		createProposal(createProposalChange: CreateProposalChange!): CreateProposalResult
+		// updates a draft or objected proposal
+		updateProposal(updateProposalChange: UpdateProposalChange!): UpdateProposalResult
+		// submits a draft or objected proposal to the circle
+		submitProposal(proposalUID: ID!): ProposalResult
+		// objects to a submitted proposal, only circle core members can object
+		objectProposal(proposalUID: ID!, reason: String!): ProposalResult
+		// accepts a submitted proposal atomically applying all its changes
+		acceptProposal(proposalUID: ID!): ProposalResult
+		// withdraws a not yet accepted proposal
+		withdrawProposal(proposalUID: ID!): ProposalResult
+		// resolves a proposal objection, only the objection author or the proposer can resolve it
+		resolveProposalObjection(proposalUID: ID!, objectionUID: ID!, resolution: String!): ProposalResult
+		// withdraws a proposal objection, only the objection author can withdraw it
+		withdrawProposalObjection(proposalUID: ID!, objectionUID: ID!): ProposalResult
+		// consents to a submitted proposal, only circle core members can consent
+		consentProposal(proposalUID: ID!): ProposalResult
+
+		// creates a project owned by a role, only the role members can create it
+		createProject(createProjectChange: CreateProjectChange!): CreateProjectResult
+		// updates a not done or dropped project
+		updateProject(updateProjectChange: UpdateProjectChange!): UpdateProjectResult
+		changeProjectStatus(projectUID: ID!, status: ProjectStatus!): ProjectResult
+
+		// creates a next action owned by a role, only the role members can create it
+		createAction(createActionChange: CreateActionChange!): CreateActionResult
+		// updates a not done or dropped action
+		updateAction(updateActionChange: UpdateActionChange!): UpdateActionResult
+		changeActionStatus(actionUID: ID!, status: ActionStatus!): ActionResult
+
+		// records a circle meeting, only circle members can record it
+		createMeeting(createMeetingChange: CreateMeetingChange!): CreateMeetingResult
+		// records the checklist items and metrics values reported in a meeting, only circle members can report them
+		reportMeetingValues(reportMeetingValuesChange: ReportMeetingValuesChange!): MeetingResult
+
+		// opens an election for a circle core role, only circle members can open it
+		createElection(createElectionChange: CreateElectionChange!): CreateElectionResult
+		// nominates a circle member as a candidate of an open election
+		nominateElectionCandidate(electionUID: ID!, memberUID: ID!): ElectionResult
+		// completes an election assigning the core role to the elected candidate
+		completeElection(electionUID: ID!, memberUID: ID!, electionExpiration: Time): ElectionResult
+
+		// saves an existing role purpose, domains and accountabilities as a named template, only admins can manage templates
+		createRoleTemplate(createRoleTemplateChange: CreateRoleTemplateChange!): CreateRoleTemplateResult
+		updateRoleTemplate(updateRoleTemplateChange: UpdateRoleTemplateChange!): RoleTemplateResult
+		deleteRoleTemplate(uid: ID!): GenericResult
+		// creates a new role inside a circle from a template, optionally keeping it linked to the template
+		instantiateRoleTemplate(instantiateRoleTemplateChange: InstantiateRoleTemplateChange!): CreateRoleResult
+		// detaches a role from its template
+		unlinkRoleTemplateInstance(roleUID: ID!): GenericResult
+		// creates a draft proposal for every linked role that differs from the template
+		proposeRoleTemplateUpdate(uid: ID!): ProposeRoleTemplateUpdateResult
+
+		// registers an endpoint receiving the events of the provided types, only admins can manage webhooks
+		createWebhook(createWebhookChange: CreateWebhookChange!): CreateWebhookResult
+		updateWebhook(updateWebhookChange: UpdateWebhookChange!): WebhookResult
+		deleteWebhook(uid: ID!): GenericResult
+	}
+
+	# The subscription type, served over websocket at /api/graphql/ws. Every
+	# field is null when there are no changes since the previous notification
+	type Subscription {
+		// the timelines created since the previous notification
+		timeLines: [TimeLine!]
+		// roles created, updated or deleted since the previous notification,
+		// optionally limited to the subtree of the provided role
+		roleChanges(roleUID: ID): TimeLineDiff
+		// viewer tensions created or updated since the previous notification
+		viewerTensions: [Tension!]
 	}
 
 	enum RoleType {
@@ -142,6 +254,8 @@ var Schema = `
 		purpose: String!
 		domains: [Domain!]
 		accountabilities: [Accountability!]
+		checklistItems: [ChecklistItem!]
+		metrics: [Metric!]
 		additionalContent: RoleAdditionalContent
 		parent: Role
 		parents: [Role!]
@@ -153,6 +267,17 @@ var Schema = `
 		roleMembers: [RoleMemberEdge!]
 		// tensions for this role, only lead link members can see them
 		tensions: [Tension!]
+		// governance proposals for this circle
+		proposals: [Proposal!]
+		projects: [Project!]
+		// next actions of this role
+		actions: [Action!]
+		// meetings of this circle, from the most recent
+		meetings(first: Int, after: String): MeetingConnection!
+		// elections of this circle core roles, the open ones first
+		elections: [Election!]
+		// the template this role is linked to
+		template: RoleTemplate
 		memberCirclePermissions: MemberCirclePermission
 		events(first: Int, after: String): RoleEventConnection!
 	}
@@ -179,6 +304,34 @@ var Schema = `
 		description: String!
 	}
 
+	# A role checklist item, checked in the circle meetings
+	type ChecklistItem {
+		uid: ID!
+		description: String!
+		// values reported in the meetings, from the most recent meeting
+		reports: [ChecklistItemReport!]
+	}
+
+	# A role metric, reported in the circle meetings
+	type Metric {
+		uid: ID!
+		description: String!
+		// values reported in the meetings, from the most recent meeting
+		reports: [MetricReport!]
+	}
+
+	type ChecklistItemReport {
+		checklistItem: ChecklistItem!
+		meeting: Meeting!
+		checked: Boolean!
+	}
+
+	type MetricReport {
+		metric: Metric!
+		meeting: Meeting!
+		value: String!
+	}
+
 	type RoleAdditionalContent {
 		content: String!
 	}
@@ -191,10 +344,22 @@ var Schema = `
 		userName: String!
 		fullName: String!
 		email: String!
+		// a deactivated member cannot login
+		isDeactivated: Boolean!
+		// a service account cannot login and can access the api only using api tokens
+		isServiceAccount: Boolean!
+		// Member api tokens, only the member and the admins can see them
+		apiTokens: [APIToken!]
 		circles: [MemberCircleEdge!]
 		roles: [MemberRoleEdge!]
+		// all the roles and circles memberships held by the member
+		assignments: MemberAssignments!
 		// Member tensions, only the member can see them
 		tensions: [Tension!]
+		// projects assigned to the member
+		projects: [Project!]
+		// next actions assigned to the member
+		actions: [Action!]
 	}
 
 	type MemberConnection {
@@ -216,6 +381,242 @@ var Schema = `
 		closed: Boolean!
 		closeReason: String!
 		member: Member!
+		status: TensionStatus!
+		assignee: Member
+		// the meeting where the tension was processed
+		meetingUID: ID
+		meeting: Meeting
+		// the outcome of a resolved tension
+		outcome: TensionOutcome
+	}
+
+	enum TensionStatus {
+		NEW
+		INAGENDA
+		PROCESSING
+		RESOLVED
+		DROPPED
+	}
+
+	enum TensionOutcomeType {
+		PROJECT
+		ACTION
+		GOVERNANCE
+	}
+
+	# The structured outcome of a resolved tension
+	type TensionOutcome {
+		outcomeType: TensionOutcomeType!
+		// the project, action or proposal created to resolve the tension
+		referenceUID: ID
+		description: String!
+	}
+
+	enum ProposalStatus {
+		DRAFT
+		SUBMITTED
+		OBJECTED
+		ACCEPTED
+		WITHDRAWN
+	}
+
+	# A governance proposal
+	type Proposal {
+		uid: ID!
+		title: String!
+		description: String!
+		status: ProposalStatus!
+		role: Role
+		member: Member!
+		changes: [ProposalRoleChange!]
+		objections: [ProposalObjection!]
+		// core members that consented to the current proposal changes
+		consents: [Member!]
+	}
+
+	enum ProposalObjectionStatus {
+		OPEN
+		RESOLVED
+		WITHDRAWN
+	}
+
+	# An objection to a proposal raised by a circle core member
+	type ProposalObjection {
+		uid: ID!
+		member: Member!
+		reason: String!
+		resolution: String!
+		status: ProposalObjectionStatus!
+	}
+
+	enum ProposalRoleChangeType {
+		CREATE
+		UPDATE
+		DELETE
+	}
+
+	# A role change contained in a proposal
+	type ProposalRoleChange {
+		changeType: ProposalRoleChangeType!
+		// the role to update or delete, empty for created roles
+		roleUID: ID
+		// the new role name, empty if unchanged
+		name: String
+	}
+
+	enum MeetingType {
+		TACTICAL
+		GOVERNANCE
+	}
+
+	# A tactical or governance circle meeting
+	type Meeting {
+		uid: ID!
+		meetingType: MeetingType!
+		date: Time!
+		role: Role
+		// the circle facilitator at the meeting timeline
+		facilitator: Member
+		// the circle secretary at the meeting timeline
+		secretary: Member
+		attendees: [Member!]
+		// tensions processed in the meeting
+		tensions: [Tension!]
+		// outcomes of the tensions resolved in the meeting
+		outcomes: [TensionOutcome!]
+		// checklist items values reported in the meeting
+		checklistItemReports: [ChecklistItemReport!]
+		// metrics values reported in the meeting
+		metricReports: [MetricReport!]
+	}
+
+	type MeetingConnection {
+		edges: [MeetingEdge!]
+		hasMoreData: Boolean!
+	}
+
+	type MeetingEdge {
+		cursor: String!
+		meeting: Meeting!
+	}
+
+	enum ElectionStatus {
+		OPEN
+		COMPLETED
+	}
+
+	# An election of a circle core role member
+	type Election {
+		uid: ID!
+		roleType: RoleType!
+		status: ElectionStatus!
+		// the circle
+		role: Role
+		nominations: [ElectionNomination!]
+		// the elected member, available when the election is completed
+		elected: Member
+		electionExpiration: Time
+	}
+
+	type ElectionNomination {
+		candidate: Member!
+		nominator: Member
+	}
+
+	# A named role shape that can be instantiated in any circle
+	type RoleTemplate {
+		uid: ID!
+		name: String!
+		purpose: String!
+		domains: [String!]!
+		accountabilities: [String!]!
+		// roles instantiated from the template and kept linked to it
+		instances: [Role!]
+	}
+
+	# A token used to access the api without an interactive login
+	type APIToken {
+		uid: ID!
+		name: String!
+		scope: APITokenScope!
+		creationTime: Time!
+		expiration: Time!
+	}
+
+	enum APITokenScope {
+		// read only access
+		READONLY
+		// read access and tensions management
+		TENSIONS
+		// read access and all the organization changes except members credentials, sessions and api tokens
+		GOVERNANCE
+	}
+
+	# An endpoint receiving the events of the provided types as signed JSON payloads
+	type Webhook {
+		uid: ID!
+		url: String!
+		eventTypes: [String!]!
+		// the events that couldn't be delivered after all the retries, the most recent first
+		deadLetters: [WebhookDeadLetter!]
+	}
+
+	type WebhookDeadLetter {
+		eventID: String!
+		sequenceNumber: Int!
+		eventType: String!
+		attempts: Int!
+		lastError: String!
+		time: Time!
+	}
+
+	# A core role assignment with an election expiration
+	type CoreRoleTerm {
+		coreRole: Role!
+		circle: Role
+		member: Member!
+		electionExpiration: Time!
+	}
+
+	enum ProjectStatus {
+		ACTIVE
+		WAITING
+		DONE
+		DROPPED
+	}
+
+	# A project owned by a role
+	type Project {
+		uid: ID!
+		title: String!
+		description: String!
+		status: ProjectStatus!
+		dueDate: Time
+		role: Role
+		// the member filling the role that is working on the project
+		member: Member
+		// the tension that originated the project
+		tension: Tension
+	}
+
+	enum ActionStatus {
+		OPEN
+		DONE
+		DROPPED
+	}
+
+	# A next action owned by a role
+	type Action {
+		uid: ID!
+		title: String!
+		description: String!
+		status: ActionStatus!
+		dueDate: Time
+		role: Role
+		// the member filling the role that will do the action
+		member: Member
+		// the tension that originated the action
+		tension: Tension
 	}
 
 	# A role member edge
@@ -323,6 +724,50 @@ var Schema = `
 		uid: ID
 	}
 
+	input CreateChecklistItemChange {
+		description: String!
+	}
+
+	type CreateChecklistItemChangeErrors {
+		description: String
+	}
+
+	input UpdateChecklistItemChange {
+		uid: ID
+		descriptionChanged: Boolean
+		description: String
+	}
+
+	type UpdateChecklistItemChangeErrors {
+		description: String
+	}
+
+	input DeleteChecklistItemChange {
+		uid: ID
+	}
+
+	input CreateMetricChange {
+		description: String!
+	}
+
+	type CreateMetricChangeErrors {
+		description: String
+	}
+
+	input UpdateMetricChange {
+		uid: ID
+		descriptionChanged: Boolean
+		description: String
+	}
+
+	type UpdateMetricChangeErrors {
+		description: String
+	}
+
+	input DeleteMetricChange {
+		uid: ID
+	}
+
 	input UpdateRootRoleChange {
 		// just to check we are really updating the root role
 		uid: ID!
@@ -390,6 +835,12 @@ var Schema = `
 		createAccountabilityChanges: [CreateAccountabilityChange!]
 		updateAccountabilityChanges: [UpdateAccountabilityChange!]
 		deleteAccountabilityChanges: [DeleteAccountabilityChange!]
+		createChecklistItemChanges: [CreateChecklistItemChange!]
+		updateChecklistItemChanges: [UpdateChecklistItemChange!]
+		deleteChecklistItemChanges: [DeleteChecklistItemChange!]
+		createMetricChanges: [CreateMetricChange!]
+		updateMetricChanges: [UpdateMetricChange!]
+		deleteMetricChanges: [DeleteMetricChange!]
 		makeCircle: Boolean
 		makeRole: Boolean
 		rolesToParent: [ID!] // when converting a circle to a role, list of child roles uids to move inside parent circle
@@ -410,6 +861,10 @@ var Schema = `
 		updateDomainChangesErrors: [UpdateDomainChangeErrors!]
 		createAccountabilityChangesErrors: [CreateAccountabilityChangeErrors!]
 		updateAccountabilityChangesErrors: [UpdateAccountabilityChangeErrors!]
+		createChecklistItemChangesErrors: [CreateChecklistItemChangeErrors!]
+		updateChecklistItemChangesErrors: [UpdateChecklistItemChangeErrors!]
+		createMetricChangesErrors: [CreateMetricChangeErrors!]
+		updateMetricChangesErrors: [UpdateMetricChangeErrors!]
 	}
 
 	input DeleteRoleChange {
@@ -433,6 +888,12 @@ var Schema = `
 		genericError: String
 	}
 
+	type MoveRoleResult {
+		role: Role
+		hasErrors: Boolean!
+		genericError: String
+	}
+
 	input AvatarData {
 		cropX: Int!
 		cropY: Int!
@@ -441,6 +902,8 @@ var Schema = `
 
 	input CreateMemberChange  {
 		isAdmin: Boolean!
+		// a service account must be created without a password
+		isServiceAccount: Boolean
 		userName: String!
 		fullName: String!
 		email: String!
@@ -484,6 +947,24 @@ var Schema = `
 		email: String
 	}
 
+	type MemberAssignments {
+		// filled normal roles
+		roles: [MemberRoleEdge!]!
+		// circles where the member is the lead link
+		leadLinkCircles: [Role!]!
+		// filled core roles (except the lead link)
+		coreRoles: [MemberRoleEdge!]!
+		// circles where the member is a direct member
+		directCircles: [Role!]!
+	}
+
+	type DeactivateMemberResult {
+		// the member assignments before the deactivation
+		assignments: MemberAssignments
+		hasErrors: Boolean!
+		genericError: String
+	}
+
 	input CreateTensionChange  {
 		title: String!
 		description: String!
@@ -524,6 +1005,8 @@ var Schema = `
 	input CloseTensionChange  {
 		uid: ID!
 		reason: String!
+		// the meeting where the tension was processed
+		meetingUID: ID
 	}
 
 	type CloseTensionResult {
@@ -531,367 +1014,615 @@ var Schema = `
 		genericError: String
 	}
 
-	type GenericResult {
+	input ResolveTensionChange {
+		uid: ID!
+		outcomeType: TensionOutcomeType!
+		referenceUID: ID
+		description: String!
+	}
+
+	type TensionTriageResult {
+		tension: Tension
 		hasErrors: Boolean!
 		genericError: String
 	}
 
-	// TODO(sgotti) As a first step we just expose the bleve search results json
-	// as a string field
-	type SearchResult {
-		totalHits: Int!
-		hits: [ID!]!
-		result: String!
+	input CreateProposalChange {
+		roleUID: ID!
+		title: String!
+		description: String!
+		createRoleChanges: [CreateRoleChange!]
+		updateRoleChanges: [UpdateRoleChange!]
+		deleteRoleChanges: [DeleteRoleChange!]
 	}
 
-	enum RoleEventType {
-		CircleChangesApplied
+	type CreateProposalResult {
+		proposal: Proposal
+		hasErrors: Boolean!
+		genericError: String
+		createProposalChangeErrors: CreateProposalChangeErrors
 	}
 
-	interface RoleEvent {
-		timeLine: TimeLine!
-		type: RoleEventType!
+	type CreateProposalChangeErrors {
+		title: String
+		description: String
+		createRoleChangesErrors: [CreateRoleChangeErrors!]
+		updateRoleChangesErrors: [UpdateRoleChangeErrors!]
 	}
 
-	type RoleEventCircleChangesApplied implements RoleEvent {
-		// The circle at the event timeline
-		role: Role
-		// The issuer at the event timeline
-		issuer: Member!
-		changedRoles: [RoleChange!]
-		rolesFromCircle: [RoleParentChange!]
-		rolesToCircle: [RoleParentChange!]
+	input UpdateProposalChange {
+		uid: ID!
+		title: String!
+		description: String!
+		createRoleChanges: [CreateRoleChange!]
+		updateRoleChanges: [UpdateRoleChange!]
+		deleteRoleChanges: [DeleteRoleChange!]
 	}
 
-	type RoleChange {
-		role: Role
-		// previous role if the role was changed
-		previousRole: Role
-		moved: RoleParentChange
-		changeType: String!
-		rolesMovedFromParent: [Role!]
-		rolesMovedToParent: [Role!]
+	type UpdateProposalResult {
+		proposal: Proposal
+		hasErrors: Boolean!
+		genericError: String
+		updateProposalChangeErrors: UpdateProposalChangeErrors
 	}
 
-	type RoleParentChange {
-		role: Role
-		previousParent: Role!
-		newParent: Role!
+	type UpdateProposalChangeErrors {
+		title: String
+		description: String
+		createRoleChangesErrors: [CreateRoleChangeErrors!]
+		updateRoleChangesErrors: [UpdateRoleChangeErrors!]
 	}
-`
-
-// NOTE(sgotti) we currently don't provide relay like Node global IDs.
-// To avoid ambiguities we call the id fields in the graphql schema as 'uid'
-// Due to the time travelling nature an object (role, member etc...) having an
-// uid can be provided in different "versions" based on the required timeline.
-// So these object versions should have different relay global IDs.
-// A relay global id could be create used something like: kind+id+start_tl
 
-// marshalUID marshals an util.ID (uuid) to a shortuuid
-func marshalUID(kind string, id util.ID) graphql.ID {
-	return graphql.ID(shortuuid.DefaultEncoder.Encode(id.UUID))
-}
+	type ProposalResult {
+		proposal: Proposal
+		hasErrors: Boolean!
+		genericError: String
+	}
 
-// MarshalUID unmarshals a shortuuid or and uuid to a util.ID (uuid)
-func unmarshalUID(uid graphql.ID) (util.ID, error) {
-	var id uuid.UUID
-	var err error
-	id, err = shortuuid.DefaultEncoder.Decode(string(uid))
-	if err != nil {
-		id, err = uuid.FromString(string(uid))
-		if err != nil {
-			return util.NilID, errors.Wrapf(err, "cannot unmarshall uid %q", uid)
-		}
+	input CreateProjectChange {
+		roleUID: ID!
+		memberUID: ID
+		tensionUID: ID
+		title: String!
+		description: String!
+		dueDate: Time
 	}
-	return util.NewFromUUID(id), nil
-}
 
-type TimeLineCursor struct {
-	TimeLineID    string
-	AggregateType string
-	AggregateID   *util.ID
-}
+	type CreateProjectResult {
+		project: Project
+		hasErrors: Boolean!
+		genericError: String
+		createProjectChangeErrors: CreateProjectChangeErrors
+	}
 
-func marshalTimeLineCursor(c *TimeLineCursor) (string, error) {
-	cj, err := json.Marshal(c)
-	if err != nil {
-		return "", err
+	type CreateProjectChangeErrors {
+		title: String
+		description: String
 	}
-	return base64.StdEncoding.EncodeToString(cj), nil
-}
 
-func unmarshalTimeLineCursor(s string) (*TimeLineCursor, error) {
-	cj, err := base64.StdEncoding.DecodeString(s)
-	if err != nil {
-		return nil, err
+	input UpdateProjectChange {
+		uid: ID!
+		memberUID: ID
+		title: String!
+		description: String!
+		dueDate: Time
 	}
-	var c *TimeLineCursor
-	if err := json.Unmarshal(cj, &c); err != nil {
-		return nil, err
+
+	type UpdateProjectResult {
+		project: Project
+		hasErrors: Boolean!
+		genericError: String
+		updateProjectChangeErrors: UpdateProjectChangeErrors
 	}
-	return c, nil
-}
 
-type MemberConnectionCursor struct {
-	TimeLineID   util.TimeLineNumber
-	SearchString string
-	FullName     string
-}
+	type UpdateProjectChangeErrors {
+		title: String
+		description: String
+	}
 
-func marshalMemberConnectionCursor(c *MemberConnectionCursor) (string, error) {
-	cj, err := json.Marshal(c)
-	if err != nil {
-		return "", err
+	type ProjectResult {
+		project: Project
+		hasErrors: Boolean!
+		genericError: String
 	}
-	return base64.StdEncoding.EncodeToString(cj), nil
-}
 
-func unmarshalMemberConnectionCursor(s string) (*MemberConnectionCursor, error) {
-	cj, err := base64.StdEncoding.DecodeString(s)
-	if err != nil {
-		return nil, err
-	}
-	var c *MemberConnectionCursor
-	if err := json.Unmarshal(cj, &c); err != nil {
-		return nil, err
+	input CreateActionChange {
+		roleUID: ID!
+		memberUID: ID
+		tensionUID: ID
+		title: String!
+		description: String!
+		dueDate: Time
 	}
-	return c, nil
-}
 
-type RoleEventConnectionCursor struct {
-	TimeLineID util.TimeLineNumber
-}
+	type CreateActionResult {
+		action: Action
+		hasErrors: Boolean!
+		genericError: String
+		createActionChangeErrors: CreateActionChangeErrors
+	}
 
-func marshalRoleEventConnectionCursor(c *RoleEventConnectionCursor) (string, error) {
-	cj, err := json.Marshal(c)
-	if err != nil {
-		return "", err
+	type CreateActionChangeErrors {
+		title: String
+		description: String
 	}
-	return base64.StdEncoding.EncodeToString(cj), nil
-}
 
-func unmarshalRoleEventConnectionCursor(s string) (*RoleEventConnectionCursor, error) {
-	cj, err := base64.StdEncoding.DecodeString(s)
-	if err != nil {
-		return nil, err
+	input UpdateActionChange {
+		uid: ID!
+		memberUID: ID
+		title: String!
+		description: String!
+		dueDate: Time
 	}
-	var c *RoleEventConnectionCursor
-	if err := json.Unmarshal(cj, &c); err != nil {
-		return nil, err
+
+	type UpdateActionResult {
+		action: Action
+		hasErrors: Boolean!
+		genericError: String
+		updateActionChangeErrors: UpdateActionChangeErrors
 	}
-	return c, nil
-}
 
-func errorToStringP(err error) *string {
-	if err == nil {
-		return nil
+	type UpdateActionChangeErrors {
+		title: String
+		description: String
 	}
-	s := err.Error()
-	return &s
-}
 
-type UpdateRootRoleChange struct {
-	UID                         graphql.ID
-	NameChanged                 *bool
-	Name                        *string
-	PurposeChanged              *bool
-	Purpose                     *string
-	CreateDomainChanges         *[]*CreateDomainChange
-	UpdateDomainChanges         *[]*UpdateDomainChange
-	DeleteDomainChanges         *[]*DeleteDomainChange
-	CreateAccountabilityChanges *[]*CreateAccountabilityChange
-	UpdateAccountabilityChanges *[]*UpdateAccountabilityChange
-	DeleteAccountabilityChanges *[]*DeleteAccountabilityChange
-}
+	type ActionResult {
+		action: Action
+		hasErrors: Boolean!
+		genericError: String
+	}
 
-func (r *UpdateRootRoleChange) toCommandChange() (*change.UpdateRootRoleChange, error) {
-	mr := &change.UpdateRootRoleChange{}
+	input CreateMeetingChange {
+		roleUID: ID!
+		meetingType: MeetingType!
+		date: Time!
+		// the attending circle members, if empty all the circle members
+		attendeeUIDs: [ID!]
+	}
 
-	id, err := unmarshalUID(r.UID)
-	if err != nil {
-		return nil, err
+	type CreateMeetingResult {
+		meeting: Meeting
+		hasErrors: Boolean!
+		genericError: String
 	}
-	mr.ID = id
 
-	if r.NameChanged != nil {
-		mr.NameChanged = *r.NameChanged
+	input ChecklistItemReportChange {
+		checklistItemUID: ID!
+		checked: Boolean!
 	}
 
-	if r.Name != nil {
-		mr.Name = *r.Name
+	input MetricReportChange {
+		metricUID: ID!
+		value: String!
 	}
 
-	if r.PurposeChanged != nil {
-		mr.PurposeChanged = *r.PurposeChanged
+	input ReportMeetingValuesChange {
+		meetingUID: ID!
+		checklistItemReports: [ChecklistItemReportChange!]
+		metricReports: [MetricReportChange!]
 	}
 
-	if r.Purpose != nil {
-		mr.Purpose = *r.Purpose
+	type MeetingResult {
+		meeting: Meeting
+		hasErrors: Boolean!
+		genericError: String
 	}
 
-	if r.CreateDomainChanges != nil {
-		for _, d := range *r.CreateDomainChanges {
-			CreateDomainChange, err := d.toCommandChange()
-			if err != nil {
-				return nil, err
-			}
-			mr.CreateDomainChanges = append(mr.CreateDomainChanges, *CreateDomainChange)
-		}
+	input CreateElectionChange {
+		roleUID: ID!
+		roleType: RoleType!
 	}
 
-	if r.DeleteDomainChanges != nil {
-		for _, d := range *r.DeleteDomainChanges {
-			DeleteDomainChange, err := d.toCommandChange()
-			if err != nil {
-				return nil, err
-			}
-			mr.DeleteDomainChanges = append(mr.DeleteDomainChanges, *DeleteDomainChange)
-		}
+	type CreateElectionResult {
+		election: Election
+		hasErrors: Boolean!
+		genericError: String
 	}
-	if r.UpdateDomainChanges != nil {
-		for _, d := range *r.UpdateDomainChanges {
-			UpdateDomainChange, err := d.toCommandChange()
-			if err != nil {
-				return nil, err
-			}
-			mr.UpdateDomainChanges = append(mr.UpdateDomainChanges, *UpdateDomainChange)
-		}
+
+	type ElectionResult {
+		election: Election
+		hasErrors: Boolean!
+		genericError: String
 	}
 
-	if r.CreateAccountabilityChanges != nil {
-		for _, d := range *r.CreateAccountabilityChanges {
-			CreateAccountabilityChange, err := d.toCommandChange()
-			if err != nil {
-				return nil, err
-			}
-			mr.CreateAccountabilityChanges = append(mr.CreateAccountabilityChanges, *CreateAccountabilityChange)
-		}
+	input CreateRoleTemplateChange {
+		// the role to save as a template
+		roleUID: ID!
+		name: String!
 	}
 
-	if r.DeleteAccountabilityChanges != nil {
-		for _, d := range *r.DeleteAccountabilityChanges {
-			DeleteAccountabilityChange, err := d.toCommandChange()
-			if err != nil {
-				return nil, err
-			}
-			mr.DeleteAccountabilityChanges = append(mr.DeleteAccountabilityChanges, *DeleteAccountabilityChange)
-		}
+	type CreateRoleTemplateResult {
+		roleTemplate: RoleTemplate
+		hasErrors: Boolean!
+		genericError: String
 	}
-	if r.UpdateAccountabilityChanges != nil {
-		for _, d := range *r.UpdateAccountabilityChanges {
-			UpdateAccountabilityChange, err := d.toCommandChange()
-			if err != nil {
-				return nil, err
-			}
-			mr.UpdateAccountabilityChanges = append(mr.UpdateAccountabilityChanges, *UpdateAccountabilityChange)
-		}
+
+	input UpdateRoleTemplateChange {
+		uid: ID!
+		name: String!
+		purpose: String!
+		domains: [String!]
+		accountabilities: [String!]
 	}
 
-	return mr, nil
-}
+	type RoleTemplateResult {
+		roleTemplate: RoleTemplate
+		hasErrors: Boolean!
+		genericError: String
+	}
 
-type CreateRoleChange struct {
-	Name                        string
-	RoleType                    string
-	Purpose                     *string
-	CreateDomainChanges         *[]*CreateDomainChange
-	CreateAccountabilityChanges *[]*CreateAccountabilityChange
+	input InstantiateRoleTemplateChange {
+		roleTemplateUID: ID!
+		// the circle where the role will be created
+		roleUID: ID!
+		// the new role name, defaults to the template name
+		name: String
+		// keep the new role linked to the template
+		linked: Boolean
+	}
 
-	RolesFromParent *[]graphql.ID
-}
+	input CreateAPITokenChange {
+		// the token member, defaults to the viewer
+		memberUID: ID
+		name: String!
+		scope: APITokenScope!
+		expiration: Time!
+	}
 
-func (r *CreateRoleChange) toCommandChange() (*change.CreateRoleChange, error) {
-	mr := &change.CreateRoleChange{}
+	type CreateAPITokenResult {
+		apiToken: APIToken
+		// the api token, it's returned only here and cannot be retrieved later
+		token: String
+		hasErrors: Boolean!
+		genericError: String
+	}
 
-	mr.Name = r.Name
-	mr.RoleType = models.RoleTypeFromString(r.RoleType)
-	if r.Purpose != nil {
-		mr.Purpose = *r.Purpose
+	input CreateWebhookChange {
+		url: String!
+		// the event types to deliver (i.e. RoleMemberAdded, TensionCreated)
+		eventTypes: [String!]!
+		// the secret used to sign the payloads
+		secret: String!
 	}
 
-	if r.CreateDomainChanges != nil {
-		for _, d := range *r.CreateDomainChanges {
-			CreateDomainChange, err := d.toCommandChange()
-			if err != nil {
-				return nil, err
-			}
-			mr.CreateDomainChanges = append(mr.CreateDomainChanges, *CreateDomainChange)
-		}
+	type CreateWebhookResult {
+		webhook: Webhook
+		hasErrors: Boolean!
+		genericError: String
 	}
 
-	if r.CreateAccountabilityChanges != nil {
-		for _, d := range *r.CreateAccountabilityChanges {
-			CreateAccountabilityChange, err := d.toCommandChange()
-			if err != nil {
-				return nil, err
-			}
-			mr.CreateAccountabilityChanges = append(mr.CreateAccountabilityChanges, *CreateAccountabilityChange)
-		}
+	input UpdateWebhookChange {
+		uid: ID!
+		url: String!
+		eventTypes: [String!]!
+		// the new secret, the current one is kept when not provided
+		secret: String
 	}
 
-	if r.RolesFromParent != nil {
-		for _, gid := range *r.RolesFromParent {
-			id, err := unmarshalUID(gid)
-			if err != nil {
-				return nil, err
-			}
-			mr.RolesFromParent = append(mr.RolesFromParent, id)
-		}
+	type WebhookResult {
+		webhook: Webhook
+		hasErrors: Boolean!
+		genericError: String
 	}
 
-	return mr, nil
-}
+	type ProposeRoleTemplateUpdateResult {
+		proposals: [Proposal!]
+		hasErrors: Boolean!
+		genericError: String
+	}
 
-type DeleteRoleChange struct {
-	UID           *graphql.ID
-	RolesToParent *[]graphql.ID
-}
+	type GenericResult {
+		hasErrors: Boolean!
+		genericError: String
+	}
 
-func (r *DeleteRoleChange) toCommandChange() (*change.DeleteRoleChange, error) {
-	mr := &change.DeleteRoleChange{}
+	enum SearchType {
+		ROLE
+		MEMBER
+		TENSION
+	}
 
-	if r.UID != nil {
-		id, err := unmarshalUID(*r.UID)
-		if err != nil {
-			return nil, err
-		}
-		mr.ID = id
+	type SearchResult {
+		totalHits: Int!
+		edges: [SearchEdge!]
+		hasMoreData: Boolean!
+		// matching documents counts by type (role, member, tension)
+		typeFacets: [SearchFacet!]!
+		// matching roles counts by role type (normal, circle)
+		roleTypeFacets: [SearchFacet!]!
 	}
 
-	if r.RolesToParent != nil {
-		for _, gid := range *r.RolesToParent {
-			id, err := unmarshalUID(gid)
-			if err != nil {
-				return nil, err
-			}
-			mr.RolesToParent = append(mr.RolesToParent, id)
-		}
+	type SearchEdge {
+		cursor: String!
+		hit: SearchHit!
 	}
-	return mr, nil
-}
 
-type UpdateRoleChange struct {
-	UID                         graphql.ID
-	NameChanged                 *bool
-	Name                        *string
-	PurposeChanged              *bool
-	Purpose                     *string
-	CreateDomainChanges         *[]*CreateDomainChange
-	UpdateDomainChanges         *[]*UpdateDomainChange
-	DeleteDomainChanges         *[]*DeleteDomainChange
-	CreateAccountabilityChanges *[]*CreateAccountabilityChange
-	UpdateAccountabilityChanges *[]*UpdateAccountabilityChange
-	DeleteAccountabilityChanges *[]*DeleteAccountabilityChange
+	union SearchHitNode = Role | Member | Tension
 
-	MakeCircle *bool
-	MakeRole   *bool
+	type SearchHit {
+		score: Float!
+		// the matching fields highlighted fragments
+		fragments: [SearchFragment!]
+		// null if the document doesn't exist anymore
+		node: SearchHitNode
+	}
 
-	RolesToParent   *[]graphql.ID
-	RolesFromParent *[]graphql.ID
+	type SearchFragment {
+		field: String!
+		fragments: [String!]!
+	}
+
+	type SearchFacet {
+		term: String!
+		count: Int!
+	}
+
+	type SearchIndexStatus {
+		// the sequence number of the last indexed event
+		lastIndexedSequenceNumber: Int!
+		// the sequence number of the last event in the event store
+		lastEventSequenceNumber: Int!
+		// the number of events not yet indexed
+		lag: Int!
+	}
+
+	enum RoleEventType {
+		CircleChangesApplied
+		ProposalReviewed
+	}
+
+	interface RoleEvent {
+		timeLine: TimeLine!
+		type: RoleEventType!
+	}
+
+	type RoleEventCircleChangesApplied implements RoleEvent {
+		// The circle at the event timeline
+		role: Role
+		// The issuer at the event timeline
+		issuer: Member!
+		changedRoles: [RoleChange!]
+		rolesFromCircle: [RoleParentChange!]
+		rolesToCircle: [RoleParentChange!]
+	}
+
+	type RoleEventProposalReviewed implements RoleEvent {
+		// The circle at the event timeline
+		role: Role
+		// The proposal at the event timeline
+		proposal: Proposal
+		// The consenting or objecting member at the event timeline
+		member: Member!
+		// one of consent, objection, objectionresolved, objectionwithdrawn
+		reviewType: String!
+		objection: ProposalObjection
+		// the objection reason or resolution
+		text: String!
+	}
+
+	type RoleChange {
+		role: Role
+		// previous role if the role was changed
+		previousRole: Role
+		moved: RoleParentChange
+		changeType: String!
+		rolesMovedFromParent: [Role!]
+		rolesMovedToParent: [Role!]
+	}
+
+	type RoleParentChange {
+		role: Role
+		previousParent: Role!
+		newParent: Role!
+	}
+
+	# The roles changes between two timelines
+	type TimeLineDiff {
+		from: TimeLine!
+		to: TimeLine!
+		// created, deleted and changed roles ordered by name
+		roles: [RoleDiff!]
+	}
+
+	type RoleDiff {
+		// one of new, updated, deleted
+		changeType: String!
+		// the role at the to timeline, null if deleted
+		role: Role
+		// the role at the from timeline, null if created
+		previousRole: Role
+		// the parents at the from and to timelines if the role was moved
+		previousParent: Role
+		newParent: Role
+		createdDomains: [Domain!]
+		updatedDomains: [Domain!]
+		deletedDomains: [Domain!]
+		createdAccountabilities: [Accountability!]
+		updatedAccountabilities: [Accountability!]
+		deletedAccountabilities: [Accountability!]
+		// members filling the role or directly added to the circle
+		addedMembers: [Member!]
+		removedMembers: [Member!]
+	}
+`
+
+// NOTE(sgotti) we currently don't provide relay like Node global IDs.
+// To avoid ambiguities we call the id fields in the graphql schema as 'uid'
+// Due to the time travelling nature an object (role, member etc...) having an
+// uid can be provided in different "versions" based on the required timeline.
+// So these object versions should have different relay global IDs.
+// A relay global id could be create used something like: kind+id+start_tl
+
+// marshalUID marshals an util.ID (uuid) to a shortuuid
+func marshalUID(kind string, id util.ID) graphql.ID {
+	return graphql.ID(shortuuid.DefaultEncoder.Encode(id.UUID))
 }
 
-func (r *UpdateRoleChange) toCommandChange() (*change.UpdateRoleChange, error) {
-	mr := &change.UpdateRoleChange{}
+// MarshalUID unmarshals a shortuuid or and uuid to a util.ID (uuid)
+func unmarshalUID(uid graphql.ID) (util.ID, error) {
+	var id uuid.UUID
+	var err error
+	id, err = shortuuid.DefaultEncoder.Decode(string(uid))
+	if err != nil {
+		id, err = uuid.FromString(string(uid))
+		if err != nil {
+			return util.NilID, errors.Wrapf(err, "cannot unmarshall uid %q", uid)
+		}
+	}
+	return util.NewFromUUID(id), nil
+}
+
+type TimeLineCursor struct {
+	TimeLineID    string
+	AggregateType string
+	AggregateID   *util.ID
+}
+
+func marshalTimeLineCursor(c *TimeLineCursor) (string, error) {
+	cj, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(cj), nil
+}
+
+func unmarshalTimeLineCursor(s string) (*TimeLineCursor, error) {
+	cj, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c *TimeLineCursor
+	if err := json.Unmarshal(cj, &c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type MemberConnectionCursor struct {
+	TimeLineID   util.TimeLineNumber
+	SearchString string
+	FullName     string
+}
+
+func marshalMemberConnectionCursor(c *MemberConnectionCursor) (string, error) {
+	cj, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(cj), nil
+}
+
+func unmarshalMemberConnectionCursor(s string) (*MemberConnectionCursor, error) {
+	cj, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c *MemberConnectionCursor
+	if err := json.Unmarshal(cj, &c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type MeetingConnectionCursor struct {
+	TimeLineID util.TimeLineNumber
+	Date       time.Time
+	ID         util.ID
+}
+
+func marshalMeetingConnectionCursor(c *MeetingConnectionCursor) (string, error) {
+	cj, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(cj), nil
+}
+
+func unmarshalMeetingConnectionCursor(s string) (*MeetingConnectionCursor, error) {
+	cj, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c *MeetingConnectionCursor
+	if err := json.Unmarshal(cj, &c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type SearchCursor struct {
+	Offset int
+}
+
+func marshalSearchCursor(c *SearchCursor) (string, error) {
+	cj, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(cj), nil
+}
+
+func unmarshalSearchCursor(s string) (*SearchCursor, error) {
+	cj, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c *SearchCursor
+	if err := json.Unmarshal(cj, &c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type RoleEventConnectionCursor struct {
+	TimeLineID util.TimeLineNumber
+}
+
+func marshalRoleEventConnectionCursor(c *RoleEventConnectionCursor) (string, error) {
+	cj, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(cj), nil
+}
+
+func unmarshalRoleEventConnectionCursor(s string) (*RoleEventConnectionCursor, error) {
+	cj, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c *RoleEventConnectionCursor
+	if err := json.Unmarshal(cj, &c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func errorToStringP(err error) *string {
+	if err == nil {
+		return nil
+	}
+	s := err.Error()
+	return &s
+}
+
+type UpdateRootRoleChange struct {
+	UID                         graphql.ID
+	NameChanged                 *bool
+	Name                        *string
+	PurposeChanged              *bool
+	Purpose                     *string
+	CreateDomainChanges         *[]*CreateDomainChange
+	UpdateDomainChanges         *[]*UpdateDomainChange
+	DeleteDomainChanges         *[]*DeleteDomainChange
+	CreateAccountabilityChanges *[]*CreateAccountabilityChange
+	UpdateAccountabilityChanges *[]*UpdateAccountabilityChange
+	DeleteAccountabilityChanges *[]*DeleteAccountabilityChange
+}
+
+func (r *UpdateRootRoleChange) toCommandChange() (*change.UpdateRootRoleChange, error) {
+	mr := &change.UpdateRootRoleChange{}
 
 	id, err := unmarshalUID(r.UID)
 	if err != nil {
@@ -973,23 +1704,273 @@ func (r *UpdateRoleChange) toCommandChange() (*change.UpdateRoleChange, error) {
 		}
 	}
 
-	if r.MakeCircle != nil {
-		mr.MakeCircle = *r.MakeCircle
+	return mr, nil
+}
+
+type CreateRoleChange struct {
+	Name                        string
+	RoleType                    string
+	Purpose                     *string
+	CreateDomainChanges         *[]*CreateDomainChange
+	CreateAccountabilityChanges *[]*CreateAccountabilityChange
+
+	RolesFromParent *[]graphql.ID
+}
+
+func (r *CreateRoleChange) toCommandChange() (*change.CreateRoleChange, error) {
+	mr := &change.CreateRoleChange{}
+
+	mr.Name = r.Name
+	mr.RoleType = models.RoleTypeFromString(r.RoleType)
+	if r.Purpose != nil {
+		mr.Purpose = *r.Purpose
 	}
 
-	if r.RolesFromParent != nil {
-		for _, gid := range *r.RolesFromParent {
-			id, err := unmarshalUID(gid)
+	if r.CreateDomainChanges != nil {
+		for _, d := range *r.CreateDomainChanges {
+			CreateDomainChange, err := d.toCommandChange()
 			if err != nil {
 				return nil, err
 			}
-			mr.RolesFromParent = append(mr.RolesFromParent, id)
+			mr.CreateDomainChanges = append(mr.CreateDomainChanges, *CreateDomainChange)
 		}
 	}
 
-	if r.RolesToParent != nil {
-		for _, gid := range *r.RolesToParent {
-			id, err := unmarshalUID(gid)
+	if r.CreateAccountabilityChanges != nil {
+		for _, d := range *r.CreateAccountabilityChanges {
+			CreateAccountabilityChange, err := d.toCommandChange()
+			if err != nil {
+				return nil, err
+			}
+			mr.CreateAccountabilityChanges = append(mr.CreateAccountabilityChanges, *CreateAccountabilityChange)
+		}
+	}
+
+	if r.RolesFromParent != nil {
+		for _, gid := range *r.RolesFromParent {
+			id, err := unmarshalUID(gid)
+			if err != nil {
+				return nil, err
+			}
+			mr.RolesFromParent = append(mr.RolesFromParent, id)
+		}
+	}
+
+	return mr, nil
+}
+
+type DeleteRoleChange struct {
+	UID           *graphql.ID
+	RolesToParent *[]graphql.ID
+}
+
+func (r *DeleteRoleChange) toCommandChange() (*change.DeleteRoleChange, error) {
+	mr := &change.DeleteRoleChange{}
+
+	if r.UID != nil {
+		id, err := unmarshalUID(*r.UID)
+		if err != nil {
+			return nil, err
+		}
+		mr.ID = id
+	}
+
+	if r.RolesToParent != nil {
+		for _, gid := range *r.RolesToParent {
+			id, err := unmarshalUID(gid)
+			if err != nil {
+				return nil, err
+			}
+			mr.RolesToParent = append(mr.RolesToParent, id)
+		}
+	}
+	return mr, nil
+}
+
+type UpdateRoleChange struct {
+	UID                         graphql.ID
+	NameChanged                 *bool
+	Name                        *string
+	PurposeChanged              *bool
+	Purpose                     *string
+	CreateDomainChanges         *[]*CreateDomainChange
+	UpdateDomainChanges         *[]*UpdateDomainChange
+	DeleteDomainChanges         *[]*DeleteDomainChange
+	CreateAccountabilityChanges *[]*CreateAccountabilityChange
+	UpdateAccountabilityChanges *[]*UpdateAccountabilityChange
+	DeleteAccountabilityChanges *[]*DeleteAccountabilityChange
+	CreateChecklistItemChanges  *[]*CreateChecklistItemChange
+	UpdateChecklistItemChanges  *[]*UpdateChecklistItemChange
+	DeleteChecklistItemChanges  *[]*DeleteChecklistItemChange
+	CreateMetricChanges         *[]*CreateMetricChange
+	UpdateMetricChanges         *[]*UpdateMetricChange
+	DeleteMetricChanges         *[]*DeleteMetricChange
+
+	MakeCircle *bool
+	MakeRole   *bool
+
+	RolesToParent   *[]graphql.ID
+	RolesFromParent *[]graphql.ID
+}
+
+func (r *UpdateRoleChange) toCommandChange() (*change.UpdateRoleChange, error) {
+	mr := &change.UpdateRoleChange{}
+
+	id, err := unmarshalUID(r.UID)
+	if err != nil {
+		return nil, err
+	}
+	mr.ID = id
+
+	if r.NameChanged != nil {
+		mr.NameChanged = *r.NameChanged
+	}
+
+	if r.Name != nil {
+		mr.Name = *r.Name
+	}
+
+	if r.PurposeChanged != nil {
+		mr.PurposeChanged = *r.PurposeChanged
+	}
+
+	if r.Purpose != nil {
+		mr.Purpose = *r.Purpose
+	}
+
+	if r.CreateDomainChanges != nil {
+		for _, d := range *r.CreateDomainChanges {
+			CreateDomainChange, err := d.toCommandChange()
+			if err != nil {
+				return nil, err
+			}
+			mr.CreateDomainChanges = append(mr.CreateDomainChanges, *CreateDomainChange)
+		}
+	}
+
+	if r.DeleteDomainChanges != nil {
+		for _, d := range *r.DeleteDomainChanges {
+			DeleteDomainChange, err := d.toCommandChange()
+			if err != nil {
+				return nil, err
+			}
+			mr.DeleteDomainChanges = append(mr.DeleteDomainChanges, *DeleteDomainChange)
+		}
+	}
+	if r.UpdateDomainChanges != nil {
+		for _, d := range *r.UpdateDomainChanges {
+			UpdateDomainChange, err := d.toCommandChange()
+			if err != nil {
+				return nil, err
+			}
+			mr.UpdateDomainChanges = append(mr.UpdateDomainChanges, *UpdateDomainChange)
+		}
+	}
+
+	if r.CreateAccountabilityChanges != nil {
+		for _, d := range *r.CreateAccountabilityChanges {
+			CreateAccountabilityChange, err := d.toCommandChange()
+			if err != nil {
+				return nil, err
+			}
+			mr.CreateAccountabilityChanges = append(mr.CreateAccountabilityChanges, *CreateAccountabilityChange)
+		}
+	}
+
+	if r.DeleteAccountabilityChanges != nil {
+		for _, d := range *r.DeleteAccountabilityChanges {
+			DeleteAccountabilityChange, err := d.toCommandChange()
+			if err != nil {
+				return nil, err
+			}
+			mr.DeleteAccountabilityChanges = append(mr.DeleteAccountabilityChanges, *DeleteAccountabilityChange)
+		}
+	}
+	if r.UpdateAccountabilityChanges != nil {
+		for _, d := range *r.UpdateAccountabilityChanges {
+			UpdateAccountabilityChange, err := d.toCommandChange()
+			if err != nil {
+				return nil, err
+			}
+			mr.UpdateAccountabilityChanges = append(mr.UpdateAccountabilityChanges, *UpdateAccountabilityChange)
+		}
+	}
+
+	if r.CreateChecklistItemChanges != nil {
+		for _, d := range *r.CreateChecklistItemChanges {
+			CreateChecklistItemChange, err := d.toCommandChange()
+			if err != nil {
+				return nil, err
+			}
+			mr.CreateChecklistItemChanges = append(mr.CreateChecklistItemChanges, *CreateChecklistItemChange)
+		}
+	}
+
+	if r.DeleteChecklistItemChanges != nil {
+		for _, d := range *r.DeleteChecklistItemChanges {
+			DeleteChecklistItemChange, err := d.toCommandChange()
+			if err != nil {
+				return nil, err
+			}
+			mr.DeleteChecklistItemChanges = append(mr.DeleteChecklistItemChanges, *DeleteChecklistItemChange)
+		}
+	}
+	if r.UpdateChecklistItemChanges != nil {
+		for _, d := range *r.UpdateChecklistItemChanges {
+			UpdateChecklistItemChange, err := d.toCommandChange()
+			if err != nil {
+				return nil, err
+			}
+			mr.UpdateChecklistItemChanges = append(mr.UpdateChecklistItemChanges, *UpdateChecklistItemChange)
+		}
+	}
+
+	if r.CreateMetricChanges != nil {
+		for _, d := range *r.CreateMetricChanges {
+			CreateMetricChange, err := d.toCommandChange()
+			if err != nil {
+				return nil, err
+			}
+			mr.CreateMetricChanges = append(mr.CreateMetricChanges, *CreateMetricChange)
+		}
+	}
+
+	if r.DeleteMetricChanges != nil {
+		for _, d := range *r.DeleteMetricChanges {
+			DeleteMetricChange, err := d.toCommandChange()
+			if err != nil {
+				return nil, err
+			}
+			mr.DeleteMetricChanges = append(mr.DeleteMetricChanges, *DeleteMetricChange)
+		}
+	}
+	if r.UpdateMetricChanges != nil {
+		for _, d := range *r.UpdateMetricChanges {
+			UpdateMetricChange, err := d.toCommandChange()
+			if err != nil {
+				return nil, err
+			}
+			mr.UpdateMetricChanges = append(mr.UpdateMetricChanges, *UpdateMetricChange)
+		}
+	}
+
+	if r.MakeCircle != nil {
+		mr.MakeCircle = *r.MakeCircle
+	}
+
+	if r.RolesFromParent != nil {
+		for _, gid := range *r.RolesFromParent {
+			id, err := unmarshalUID(gid)
+			if err != nil {
+				return nil, err
+			}
+			mr.RolesFromParent = append(mr.RolesFromParent, id)
+		}
+	}
+
+	if r.RolesToParent != nil {
+		for _, gid := range *r.RolesToParent {
+			id, err := unmarshalUID(gid)
 			if err != nil {
 				return nil, err
 			}
@@ -1116,56 +2097,172 @@ func (d *UpdateAccountabilityChange) toCommandChange() (*change.UpdateAccountabi
 	return md, nil
 }
 
-type AvatarData struct {
-	CropX    float64
-	CropY    float64
-	CropSize float64
+type CreateChecklistItemChange struct {
+	Description string
 }
 
-type CreateMemberChange struct {
-	IsAdmin    bool
-	UserName   string
-	FullName   string
-	Email      string
-	Password   string
-	AvatarData *AvatarData
+func (d *CreateChecklistItemChange) toCommandChange() (*change.CreateChecklistItemChange, error) {
+	md := &change.CreateChecklistItemChange{}
+
+	md.Description = d.Description
+
+	return md, nil
 }
 
-func (m *CreateMemberChange) toCommandChange() (*change.CreateMemberChange, error) {
-	mm := &change.CreateMemberChange{}
+type DeleteChecklistItemChange struct {
+	UID *graphql.ID
+}
 
-	mm.IsAdmin = m.IsAdmin
-	mm.UserName = m.UserName
-	mm.FullName = m.FullName
-	mm.Email = m.Email
-	mm.Password = m.Password
+func (d *DeleteChecklistItemChange) toCommandChange() (*change.DeleteChecklistItemChange, error) {
+	md := &change.DeleteChecklistItemChange{}
 
-	if m.AvatarData != nil {
-		mm.AvatarData = &change.AvatarData{
-			CropX:    int(m.AvatarData.CropX),
-			CropY:    int(m.AvatarData.CropY),
-			CropSize: int(m.AvatarData.CropSize),
+	if d.UID != nil {
+		id, err := unmarshalUID(*d.UID)
+		if err != nil {
+			return nil, err
 		}
+		md.ID = id
 	}
 
-	return mm, nil
+	return md, nil
 }
 
-type UpdateMemberChange struct {
-	UID        graphql.ID
-	IsAdmin    bool
-	UserName   string
-	FullName   string
-	Email      string
-	AvatarData *AvatarData
+type UpdateChecklistItemChange struct {
+	UID                *graphql.ID
+	DescriptionChanged *bool
+	Description        *string
 }
 
-func (m *UpdateMemberChange) toCommandChange() (*change.UpdateMemberChange, error) {
-	mm := &change.UpdateMemberChange{}
+func (d *UpdateChecklistItemChange) toCommandChange() (*change.UpdateChecklistItemChange, error) {
+	md := &change.UpdateChecklistItemChange{}
 
-	id, err := unmarshalUID(m.UID)
-	if err != nil {
-		return nil, err
+	if d.UID != nil {
+		id, err := unmarshalUID(*d.UID)
+		if err != nil {
+			return nil, err
+		}
+		md.ID = id
+	}
+	if d.DescriptionChanged != nil {
+		md.DescriptionChanged = *d.DescriptionChanged
+	}
+	if d.Description != nil {
+		md.Description = *d.Description
+	}
+
+	return md, nil
+}
+
+type CreateMetricChange struct {
+	Description string
+}
+
+func (d *CreateMetricChange) toCommandChange() (*change.CreateMetricChange, error) {
+	md := &change.CreateMetricChange{}
+
+	md.Description = d.Description
+
+	return md, nil
+}
+
+type DeleteMetricChange struct {
+	UID *graphql.ID
+}
+
+func (d *DeleteMetricChange) toCommandChange() (*change.DeleteMetricChange, error) {
+	md := &change.DeleteMetricChange{}
+
+	if d.UID != nil {
+		id, err := unmarshalUID(*d.UID)
+		if err != nil {
+			return nil, err
+		}
+		md.ID = id
+	}
+
+	return md, nil
+}
+
+type UpdateMetricChange struct {
+	UID                *graphql.ID
+	DescriptionChanged *bool
+	Description        *string
+}
+
+func (d *UpdateMetricChange) toCommandChange() (*change.UpdateMetricChange, error) {
+	md := &change.UpdateMetricChange{}
+
+	if d.UID != nil {
+		id, err := unmarshalUID(*d.UID)
+		if err != nil {
+			return nil, err
+		}
+		md.ID = id
+	}
+	if d.DescriptionChanged != nil {
+		md.DescriptionChanged = *d.DescriptionChanged
+	}
+	if d.Description != nil {
+		md.Description = *d.Description
+	}
+
+	return md, nil
+}
+
+type AvatarData struct {
+	CropX    float64
+	CropY    float64
+	CropSize float64
+}
+
+type CreateMemberChange struct {
+	IsAdmin          bool
+	IsServiceAccount *bool
+	UserName         string
+	FullName         string
+	Email            string
+	Password         string
+	AvatarData       *AvatarData
+}
+
+func (m *CreateMemberChange) toCommandChange() (*change.CreateMemberChange, error) {
+	mm := &change.CreateMemberChange{}
+
+	mm.IsAdmin = m.IsAdmin
+	if m.IsServiceAccount != nil {
+		mm.IsServiceAccount = *m.IsServiceAccount
+	}
+	mm.UserName = m.UserName
+	mm.FullName = m.FullName
+	mm.Email = m.Email
+	mm.Password = m.Password
+
+	if m.AvatarData != nil {
+		mm.AvatarData = &change.AvatarData{
+			CropX:    int(m.AvatarData.CropX),
+			CropY:    int(m.AvatarData.CropY),
+			CropSize: int(m.AvatarData.CropSize),
+		}
+	}
+
+	return mm, nil
+}
+
+type UpdateMemberChange struct {
+	UID        graphql.ID
+	IsAdmin    bool
+	UserName   string
+	FullName   string
+	Email      string
+	AvatarData *AvatarData
+}
+
+func (m *UpdateMemberChange) toCommandChange() (*change.UpdateMemberChange, error) {
+	mm := &change.UpdateMemberChange{}
+
+	id, err := unmarshalUID(m.UID)
+	if err != nil {
+		return nil, err
 	}
 	mm.ID = id
 
@@ -1202,440 +2299,2627 @@ func (t *CreateTensionChange) toCommandChange() (*change.CreateTensionChange, er
 		if err != nil {
 			return nil, err
 		}
-		mt.RoleID = &id
+		mt.RoleID = &id
+	}
+
+	return mt, nil
+}
+
+type UpdateTensionChange struct {
+	UID         graphql.ID
+	Title       string
+	Description string
+	RoleUID     *graphql.ID
+}
+
+func (t *UpdateTensionChange) toCommandChange() (*change.UpdateTensionChange, error) {
+	mt := &change.UpdateTensionChange{}
+
+	id, err := unmarshalUID(t.UID)
+	if err != nil {
+		return nil, err
+	}
+	mt.ID = id
+
+	mt.Title = t.Title
+	mt.Description = t.Description
+
+	if t.RoleUID != nil {
+		id, err := unmarshalUID(*t.RoleUID)
+		if err != nil {
+			return nil, err
+		}
+		mt.RoleID = &id
+	}
+
+	return mt, nil
+}
+
+type CloseTensionChange struct {
+	UID        graphql.ID
+	Reason     string
+	MeetingUID *graphql.ID
+}
+
+func (t *CloseTensionChange) toCommandChange() (*change.CloseTensionChange, error) {
+	mt := &change.CloseTensionChange{}
+
+	id, err := unmarshalUID(t.UID)
+	if err != nil {
+		return nil, err
+	}
+	mt.ID = id
+
+	mt.Reason = t.Reason
+
+	if t.MeetingUID != nil {
+		meetingID, err := unmarshalUID(*t.MeetingUID)
+		if err != nil {
+			return nil, err
+		}
+		mt.MeetingID = &meetingID
+	}
+
+	return mt, nil
+}
+
+type ResolveTensionChange struct {
+	UID          graphql.ID
+	OutcomeType  string
+	ReferenceUID *graphql.ID
+	Description  string
+}
+
+func (t *ResolveTensionChange) toCommandChange() (*change.ResolveTensionChange, error) {
+	mt := &change.ResolveTensionChange{}
+
+	id, err := unmarshalUID(t.UID)
+	if err != nil {
+		return nil, err
+	}
+	mt.ID = id
+
+	mt.OutcomeType = models.TensionOutcomeType(strings.ToLower(t.OutcomeType))
+	if t.ReferenceUID != nil {
+		referenceID, err := unmarshalUID(*t.ReferenceUID)
+		if err != nil {
+			return nil, err
+		}
+		mt.OutcomeReferenceID = &referenceID
+	}
+	mt.OutcomeDescription = t.Description
+
+	return mt, nil
+}
+
+type ProposalChanges struct {
+	CreateRoleChanges *[]*CreateRoleChange
+	UpdateRoleChanges *[]*UpdateRoleChange
+	DeleteRoleChanges *[]*DeleteRoleChange
+}
+
+func (p *ProposalChanges) toCommandChange() (*change.ProposalChanges, error) {
+	mp := &change.ProposalChanges{}
+
+	if p.CreateRoleChanges != nil {
+		for _, r := range *p.CreateRoleChanges {
+			createRoleChange, err := r.toCommandChange()
+			if err != nil {
+				return nil, err
+			}
+			mp.CreateRoleChanges = append(mp.CreateRoleChanges, *createRoleChange)
+		}
+	}
+
+	if p.UpdateRoleChanges != nil {
+		for _, r := range *p.UpdateRoleChanges {
+			updateRoleChange, err := r.toCommandChange()
+			if err != nil {
+				return nil, err
+			}
+			mp.UpdateRoleChanges = append(mp.UpdateRoleChanges, *updateRoleChange)
+		}
+	}
+
+	if p.DeleteRoleChanges != nil {
+		for _, r := range *p.DeleteRoleChanges {
+			deleteRoleChange, err := r.toCommandChange()
+			if err != nil {
+				return nil, err
+			}
+			mp.DeleteRoleChanges = append(mp.DeleteRoleChanges, *deleteRoleChange)
+		}
+	}
+
+	return mp, nil
+}
+
+type CreateProposalChange struct {
+	RoleUID     graphql.ID
+	Title       string
+	Description string
+	ProposalChanges
+}
+
+func (p *CreateProposalChange) toCommandChange() (*change.CreateProposalChange, error) {
+	mp := &change.CreateProposalChange{}
+
+	id, err := unmarshalUID(p.RoleUID)
+	if err != nil {
+		return nil, err
+	}
+	mp.RoleID = id
+
+	mp.Title = p.Title
+	mp.Description = p.Description
+
+	proposalChanges, err := p.ProposalChanges.toCommandChange()
+	if err != nil {
+		return nil, err
+	}
+	mp.ProposalChanges = *proposalChanges
+
+	return mp, nil
+}
+
+type UpdateProposalChange struct {
+	UID         graphql.ID
+	Title       string
+	Description string
+	ProposalChanges
+}
+
+func (p *UpdateProposalChange) toCommandChange() (*change.UpdateProposalChange, error) {
+	mp := &change.UpdateProposalChange{}
+
+	id, err := unmarshalUID(p.UID)
+	if err != nil {
+		return nil, err
+	}
+	mp.ID = id
+
+	mp.Title = p.Title
+	mp.Description = p.Description
+
+	proposalChanges, err := p.ProposalChanges.toCommandChange()
+	if err != nil {
+		return nil, err
+	}
+	mp.ProposalChanges = *proposalChanges
+
+	return mp, nil
+}
+
+type CreateProjectChange struct {
+	RoleUID     graphql.ID
+	MemberUID   *graphql.ID
+	TensionUID  *graphql.ID
+	Title       string
+	Description string
+	DueDate     *graphql.Time
+}
+
+func (c *CreateProjectChange) toCommandChange() (*change.CreateProjectChange, error) {
+	mc := &change.CreateProjectChange{}
+
+	roleID, err := unmarshalUID(c.RoleUID)
+	if err != nil {
+		return nil, err
+	}
+	mc.RoleID = roleID
+
+	if c.MemberUID != nil {
+		id, err := unmarshalUID(*c.MemberUID)
+		if err != nil {
+			return nil, err
+		}
+		mc.MemberID = &id
+	}
+	if c.TensionUID != nil {
+		id, err := unmarshalUID(*c.TensionUID)
+		if err != nil {
+			return nil, err
+		}
+		mc.TensionID = &id
+	}
+
+	mc.Title = c.Title
+	mc.Description = c.Description
+	if c.DueDate != nil {
+		mc.DueDate = &c.DueDate.Time
+	}
+
+	return mc, nil
+}
+
+type UpdateProjectChange struct {
+	UID         graphql.ID
+	MemberUID   *graphql.ID
+	Title       string
+	Description string
+	DueDate     *graphql.Time
+}
+
+func (c *UpdateProjectChange) toCommandChange() (*change.UpdateProjectChange, error) {
+	mc := &change.UpdateProjectChange{}
+
+	id, err := unmarshalUID(c.UID)
+	if err != nil {
+		return nil, err
+	}
+	mc.ID = id
+
+	if c.MemberUID != nil {
+		id, err := unmarshalUID(*c.MemberUID)
+		if err != nil {
+			return nil, err
+		}
+		mc.MemberID = &id
+	}
+
+	mc.Title = c.Title
+	mc.Description = c.Description
+	if c.DueDate != nil {
+		mc.DueDate = &c.DueDate.Time
+	}
+
+	return mc, nil
+}
+
+type CreateActionChange struct {
+	RoleUID     graphql.ID
+	MemberUID   *graphql.ID
+	TensionUID  *graphql.ID
+	Title       string
+	Description string
+	DueDate     *graphql.Time
+}
+
+func (c *CreateActionChange) toCommandChange() (*change.CreateActionChange, error) {
+	mc := &change.CreateActionChange{}
+
+	roleID, err := unmarshalUID(c.RoleUID)
+	if err != nil {
+		return nil, err
+	}
+	mc.RoleID = roleID
+
+	if c.MemberUID != nil {
+		id, err := unmarshalUID(*c.MemberUID)
+		if err != nil {
+			return nil, err
+		}
+		mc.MemberID = &id
+	}
+	if c.TensionUID != nil {
+		id, err := unmarshalUID(*c.TensionUID)
+		if err != nil {
+			return nil, err
+		}
+		mc.TensionID = &id
+	}
+
+	mc.Title = c.Title
+	mc.Description = c.Description
+	if c.DueDate != nil {
+		mc.DueDate = &c.DueDate.Time
+	}
+
+	return mc, nil
+}
+
+type UpdateActionChange struct {
+	UID         graphql.ID
+	MemberUID   *graphql.ID
+	Title       string
+	Description string
+	DueDate     *graphql.Time
+}
+
+func (c *UpdateActionChange) toCommandChange() (*change.UpdateActionChange, error) {
+	mc := &change.UpdateActionChange{}
+
+	id, err := unmarshalUID(c.UID)
+	if err != nil {
+		return nil, err
+	}
+	mc.ID = id
+
+	if c.MemberUID != nil {
+		id, err := unmarshalUID(*c.MemberUID)
+		if err != nil {
+			return nil, err
+		}
+		mc.MemberID = &id
+	}
+
+	mc.Title = c.Title
+	mc.Description = c.Description
+	if c.DueDate != nil {
+		mc.DueDate = &c.DueDate.Time
+	}
+
+	return mc, nil
+}
+
+type CreateMeetingChange struct {
+	RoleUID      graphql.ID
+	MeetingType  string
+	Date         graphql.Time
+	AttendeeUIDs *[]graphql.ID
+}
+
+func (c *CreateMeetingChange) toCommandChange() (*change.CreateMeetingChange, error) {
+	mc := &change.CreateMeetingChange{}
+
+	roleID, err := unmarshalUID(c.RoleUID)
+	if err != nil {
+		return nil, err
+	}
+	mc.RoleID = roleID
+
+	mc.MeetingType = models.MeetingType(strings.ToLower(c.MeetingType))
+	mc.Date = c.Date.Time
+
+	if c.AttendeeUIDs != nil {
+		for _, attendeeUID := range *c.AttendeeUIDs {
+			id, err := unmarshalUID(attendeeUID)
+			if err != nil {
+				return nil, err
+			}
+			mc.AttendeesIDs = append(mc.AttendeesIDs, id)
+		}
+	}
+
+	return mc, nil
+}
+
+type CreateElectionChange struct {
+	RoleUID  graphql.ID
+	RoleType string
+}
+
+func (c *CreateElectionChange) toCommandChange() (*change.CreateElectionChange, error) {
+	ec := &change.CreateElectionChange{}
+
+	roleID, err := unmarshalUID(c.RoleUID)
+	if err != nil {
+		return nil, err
+	}
+	ec.RoleID = roleID
+	ec.RoleType = models.RoleTypeFromString(strings.ToLower(c.RoleType))
+
+	return ec, nil
+}
+
+type CreateRoleTemplateChange struct {
+	RoleUID graphql.ID
+	Name    string
+}
+
+func (c *CreateRoleTemplateChange) toCommandChange() (*change.CreateRoleTemplateChange, error) {
+	rc := &change.CreateRoleTemplateChange{}
+
+	roleID, err := unmarshalUID(c.RoleUID)
+	if err != nil {
+		return nil, err
+	}
+	rc.RoleID = roleID
+	rc.Name = c.Name
+
+	return rc, nil
+}
+
+type UpdateRoleTemplateChange struct {
+	UID              graphql.ID
+	Name             string
+	Purpose          string
+	Domains          *[]string
+	Accountabilities *[]string
+}
+
+func (c *UpdateRoleTemplateChange) toCommandChange() (*change.UpdateRoleTemplateChange, error) {
+	rc := &change.UpdateRoleTemplateChange{}
+
+	id, err := unmarshalUID(c.UID)
+	if err != nil {
+		return nil, err
+	}
+	rc.ID = id
+	rc.Name = c.Name
+	rc.Purpose = c.Purpose
+	rc.Domains = []string{}
+	if c.Domains != nil {
+		rc.Domains = *c.Domains
+	}
+	rc.Accountabilities = []string{}
+	if c.Accountabilities != nil {
+		rc.Accountabilities = *c.Accountabilities
+	}
+
+	return rc, nil
+}
+
+type CreateAPITokenChange struct {
+	MemberUID  *graphql.ID
+	Name       string
+	Scope      string
+	Expiration graphql.Time
+}
+
+func (c *CreateAPITokenChange) toCommandChange() (*change.CreateAPITokenChange, error) {
+	rc := &change.CreateAPITokenChange{}
+	if c.MemberUID != nil {
+		memberID, err := unmarshalUID(*c.MemberUID)
+		if err != nil {
+			return nil, err
+		}
+		rc.MemberID = memberID
+	}
+	rc.Name = c.Name
+	rc.Scope = models.APITokenScope(strings.ToLower(c.Scope))
+	rc.Expiration = c.Expiration.Time
+	return rc, nil
+}
+
+type CreateWebhookChange struct {
+	URL        string
+	EventTypes []string
+	Secret     string
+}
+
+func (c *CreateWebhookChange) toCommandChange() (*change.CreateWebhookChange, error) {
+	rc := &change.CreateWebhookChange{}
+
+	rc.URL = c.URL
+	rc.EventTypes = c.EventTypes
+	rc.Secret = c.Secret
+
+	return rc, nil
+}
+
+type UpdateWebhookChange struct {
+	UID        graphql.ID
+	URL        string
+	EventTypes []string
+	Secret     *string
+}
+
+func (c *UpdateWebhookChange) toCommandChange() (*change.UpdateWebhookChange, error) {
+	rc := &change.UpdateWebhookChange{}
+
+	id, err := unmarshalUID(c.UID)
+	if err != nil {
+		return nil, err
+	}
+	rc.ID = id
+	rc.URL = c.URL
+	rc.EventTypes = c.EventTypes
+	rc.Secret = c.Secret
+
+	return rc, nil
+}
+
+type InstantiateRoleTemplateChange struct {
+	RoleTemplateUID graphql.ID
+	RoleUID         graphql.ID
+	Name            *string
+	Linked          *bool
+}
+
+func (c *InstantiateRoleTemplateChange) toCommandChange() (*change.InstantiateRoleTemplateChange, error) {
+	rc := &change.InstantiateRoleTemplateChange{}
+
+	roleTemplateID, err := unmarshalUID(c.RoleTemplateUID)
+	if err != nil {
+		return nil, err
+	}
+	rc.RoleTemplateID = roleTemplateID
+	roleID, err := unmarshalUID(c.RoleUID)
+	if err != nil {
+		return nil, err
+	}
+	rc.RoleID = roleID
+	rc.Name = c.Name
+	if c.Linked != nil {
+		rc.Linked = *c.Linked
+	}
+
+	return rc, nil
+}
+
+type ChecklistItemReportChange struct {
+	ChecklistItemUID graphql.ID
+	Checked          bool
+}
+
+type MetricReportChange struct {
+	MetricUID graphql.ID
+	Value     string
+}
+
+type ReportMeetingValuesChange struct {
+	MeetingUID           graphql.ID
+	ChecklistItemReports *[]*ChecklistItemReportChange
+	MetricReports        *[]*MetricReportChange
+}
+
+func (c *ReportMeetingValuesChange) toCommandChange() (*change.ReportMeetingValuesChange, error) {
+	mc := &change.ReportMeetingValuesChange{}
+
+	meetingID, err := unmarshalUID(c.MeetingUID)
+	if err != nil {
+		return nil, err
+	}
+	mc.MeetingID = meetingID
+
+	if c.ChecklistItemReports != nil {
+		for _, r := range *c.ChecklistItemReports {
+			id, err := unmarshalUID(r.ChecklistItemUID)
+			if err != nil {
+				return nil, err
+			}
+			mc.ChecklistItemReports = append(mc.ChecklistItemReports, change.ChecklistItemReport{ChecklistItemID: id, Checked: r.Checked})
+		}
+	}
+
+	if c.MetricReports != nil {
+		for _, r := range *c.MetricReports {
+			id, err := unmarshalUID(r.MetricUID)
+			if err != nil {
+				return nil, err
+			}
+			mc.MetricReports = append(mc.MetricReports, change.MetricReport{MetricID: id, Value: r.Value})
+		}
+	}
+
+	return mc, nil
+}
+
+func getTimeLineNumber(ctx context.Context, readDB readdb.ReadDBService, v *util.TimeLineNumber) (util.TimeLineNumber, error) {
+	curTl := readDB.CurTimeLine(ctx)
+
+	if v == nil {
+		return curTl.Number(), nil
+	}
+
+	timeLineID := *v
+	if timeLineID == 0 {
+		return curTl.Number(), nil
+	}
+
+	if timeLineID < 0 {
+		if !test {
+			return 0, errors.Errorf("invalid timeLineID %d", *v)
+		}
+
+		// TODO(sgotti) ugly hack used only for tests, should to be removed
+		// a negative values means that we will use current timeLineID - v
+
+		n := int(-*v)
+		tls, _, err := readDB.TimeLines(ctx, nil, curTl.Number(), n, false, "", nil)
+		if err != nil {
+			return 0, err
+		}
+		if len(tls) < n {
+			return 0, errors.Errorf("invalid timeLineID %d", *v)
+		}
+		timeLineID = tls[n-1].Number()
+	}
+
+	return timeLineID, nil
+}
+
+type Resolver struct {
+}
+
+func NewResolver() *Resolver {
+	return &Resolver{}
+}
+
+func (r *Resolver) TimeLine(ctx context.Context, args *struct {
+	ID *util.TimeLineNumber
+}) (*timeLineResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tl *util.TimeLine
+	timeLineID, err := getTimeLineNumber(ctx, s, args.ID)
+	if err != nil {
+		return nil, err
+	}
+	if timeLineID == 0 {
+		tl = s.CurTimeLine(ctx)
+	} else {
+		tl, err = s.TimeLine(ctx, timeLineID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if tl == nil {
+		return nil, nil
+	}
+	return &timeLineResolver{s, tl, dataloader.NewDataLoaders(ctx, s)}, nil
+}
+
+func (r *Resolver) TimeLines(ctx context.Context, args *struct {
+	FromTime      *graphql.Time
+	FromID        *string
+	AggregateType *string
+	AggregateID   *graphql.ID
+	First         *float64
+	Last          *float64
+	After         *string
+	Before        *string
+}) (*timeLineConnectionResolver, error) {
+	// accept only an After or a Before cursor
+	if args.After != nil && args.Before != nil {
+		return nil, errors.New("only one cursor can be provided")
+	}
+	// accept only a fromTime or fromID
+	if args.FromTime != nil && args.FromID != nil {
+		return nil, errors.New("only one of fromTime or fromID can be provided")
+	}
+	// accept only a cursor or a fromTime/fromID/AggregateType/AggregateID
+	if (args.After != nil || args.Before != nil) && (args.FromTime != nil || args.FromID != nil || args.AggregateType != nil || args.AggregateID != nil) {
+		return nil, errors.New("only the cursor or the fromTime/fromID/AggregateType/AggregateID can be provided")
+	}
+	// accept only a First or Last
+	if args.First != nil && args.Last != nil {
+		return nil, errors.New("only one of first or last can be provided")
+	}
+
+	var timeLineID util.TimeLineNumber
+	var fromTime *time.Time
+	var fromID int64
+	var aggregateType string
+	var aggregateID *util.ID
+	var err error
+
+	if args.AggregateType != nil {
+		aggregateType = *args.AggregateType
+	}
+	if args.AggregateID != nil {
+		id, err := unmarshalUID(*args.AggregateID)
+		if err != nil {
+			return nil, err
+		}
+		aggregateID = &id
+	}
+	if args.After != nil {
+		cursor, err := unmarshalTimeLineCursor(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		tl, err := strconv.ParseInt(cursor.TimeLineID, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		timeLineID = util.TimeLineNumber(tl)
+		aggregateType = cursor.AggregateType
+		aggregateID = cursor.AggregateID
+	}
+	if args.Before != nil {
+		cursor, err := unmarshalTimeLineCursor(*args.Before)
+		if err != nil {
+			return nil, err
+		}
+		tl, err := strconv.ParseInt(cursor.TimeLineID, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		timeLineID = util.TimeLineNumber(tl)
+		aggregateType = cursor.AggregateType
+		aggregateID = cursor.AggregateID
+	}
+	if args.FromTime != nil {
+		fromTime = &args.FromTime.Time
+	}
+	if args.FromID != nil {
+		fromID, err = strconv.ParseInt(*args.FromID, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	limit := 0
+	if args.First != nil {
+		limit = int(*args.First)
+	}
+	if args.Last != nil {
+		limit = int(*args.Last)
+	}
+
+	if fromID != 0 {
+		timeLineID = util.TimeLineNumber(fromID)
+	}
+
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	timeLines, hasMoreData, err := s.TimeLines(ctx, fromTime, timeLineID, limit, args.Last == nil, aggregateType, aggregateID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &timeLineConnectionResolver{s, timeLines, aggregateType, aggregateID, hasMoreData, dataloader.NewDataLoaders(ctx, s)}, nil
+}
+
+func (r *Resolver) Viewer(ctx context.Context) (*viewerResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeLineID, err := getTimeLineNumber(ctx, s, nil)
+	if err != nil {
+		return nil, err
+	}
+	member, err := s.CallingMember(ctx, timeLineID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, nil
+	}
+	return &viewerResolver{s, member, timeLineID, dataloader.NewDataLoaders(ctx, s)}, nil
+}
+
+func (r *Resolver) TimeLineDiff(ctx context.Context, args *struct {
+	From    util.TimeLineNumber
+	To      util.TimeLineNumber
+	RoleUID *graphql.ID
+}) (*timeLineDiffResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tls := make([]*util.TimeLine, 2)
+	for i, v := range []util.TimeLineNumber{args.From, args.To} {
+		timeLineID, err := getTimeLineNumber(ctx, s, &v)
+		if err != nil {
+			return nil, err
+		}
+		tl, err := s.TimeLine(ctx, timeLineID)
+		if err != nil {
+			return nil, err
+		}
+		if tl == nil {
+			return nil, errors.Errorf("timeline %d doesn't exist", timeLineID)
+		}
+		tls[i] = tl
+	}
+
+	var roleID *util.ID
+	if args.RoleUID != nil {
+		id, err := unmarshalUID(*args.RoleUID)
+		if err != nil {
+			return nil, err
+		}
+		roleID = &id
+	}
+
+	diffs, err := s.RolesDiff(ctx, tls[0].Number(), tls[1].Number(), roleID)
+	if err != nil {
+		return nil, err
+	}
+	return &timeLineDiffResolver{s, tls[0], tls[1], diffs, dataloader.NewDataLoaders(ctx, s)}, nil
+}
+
+func (r *Resolver) RootRole(ctx context.Context, args *struct{ TimeLineID *util.TimeLineNumber }) (*roleResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeLineID, err := getTimeLineNumber(ctx, s, args.TimeLineID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.RootRole(ctx, timeLineID)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+	return NewRoleResolver(s, role, timeLineID, dataloader.NewDataLoaders(ctx, s)), nil
+}
+
+func (r *Resolver) Role(ctx context.Context, args *struct {
+	TimeLineID *util.TimeLineNumber
+	UID        graphql.ID
+}) (*roleResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeLineID, err := getTimeLineNumber(ctx, s, args.TimeLineID)
+	if err != nil {
+		return nil, err
+	}
+	// Get role id
+	id, err := unmarshalUID(args.UID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.Role(ctx, timeLineID, id)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+	return NewRoleResolver(s, role, timeLineID, dataloader.NewDataLoaders(ctx, s)), nil
+}
+
+func (r *Resolver) Member(ctx context.Context, args *struct {
+	TimeLineID *util.TimeLineNumber
+	UID        graphql.ID
+}) (*memberResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeLineID, err := getTimeLineNumber(ctx, s, args.TimeLineID)
+	if err != nil {
+		return nil, err
+	}
+	// Get member id
+	id, err := unmarshalUID(args.UID)
+	if err != nil {
+		return nil, err
+	}
+	member, err := s.Member(ctx, timeLineID, id)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, nil
+	}
+	return &memberResolver{s, member, timeLineID, dataloader.NewDataLoaders(ctx, s)}, nil
+}
+
+func (r *Resolver) Tension(ctx context.Context, args *struct {
+	TimeLineID *util.TimeLineNumber
+	UID        graphql.ID
+}) (*tensionResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeLineID, err := getTimeLineNumber(ctx, s, args.TimeLineID)
+	if err != nil {
+		return nil, err
+	}
+	// Get tension id
+	id, err := unmarshalUID(args.UID)
+	if err != nil {
+		return nil, err
+	}
+	tension, err := s.Tension(ctx, timeLineID, id)
+	if err != nil {
+		return nil, err
+	}
+	if tension == nil {
+		return nil, nil
+	}
+	return &tensionResolver{s, tension, timeLineID, dataloader.NewDataLoaders(ctx, s)}, nil
+}
+
+func (r *Resolver) Proposal(ctx context.Context, args *struct {
+	TimeLineID *util.TimeLineNumber
+	UID        graphql.ID
+}) (*proposalResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeLineID, err := getTimeLineNumber(ctx, s, args.TimeLineID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := unmarshalUID(args.UID)
+	if err != nil {
+		return nil, err
+	}
+	proposal, err := s.Proposal(ctx, timeLineID, id)
+	if err != nil {
+		return nil, err
+	}
+	if proposal == nil {
+		return nil, nil
+	}
+	return &proposalResolver{s, proposal, timeLineID, dataloader.NewDataLoaders(ctx, s)}, nil
+}
+
+func (r *Resolver) Project(ctx context.Context, args *struct {
+	TimeLineID *util.TimeLineNumber
+	UID        graphql.ID
+}) (*projectResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeLineID, err := getTimeLineNumber(ctx, s, args.TimeLineID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := unmarshalUID(args.UID)
+	if err != nil {
+		return nil, err
+	}
+	project, err := s.Project(ctx, timeLineID, id)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, nil
+	}
+	return newProjectResolver(s, project, timeLineID, dataloader.NewDataLoaders(ctx, s)), nil
+}
+
+func (r *Resolver) Action(ctx context.Context, args *struct {
+	TimeLineID *util.TimeLineNumber
+	UID        graphql.ID
+}) (*actionResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeLineID, err := getTimeLineNumber(ctx, s, args.TimeLineID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := unmarshalUID(args.UID)
+	if err != nil {
+		return nil, err
+	}
+	action, err := s.Action(ctx, timeLineID, id)
+	if err != nil {
+		return nil, err
+	}
+	if action == nil {
+		return nil, nil
+	}
+	return newActionResolver(s, action, timeLineID, dataloader.NewDataLoaders(ctx, s)), nil
+}
+
+func (r *Resolver) Meeting(ctx context.Context, args *struct {
+	TimeLineID *util.TimeLineNumber
+	UID        graphql.ID
+}) (*meetingResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeLineID, err := getTimeLineNumber(ctx, s, args.TimeLineID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := unmarshalUID(args.UID)
+	if err != nil {
+		return nil, err
+	}
+	meeting, err := s.Meeting(ctx, timeLineID, id)
+	if err != nil {
+		return nil, err
+	}
+	if meeting == nil {
+		return nil, nil
+	}
+	return &meetingResolver{s, meeting, timeLineID, dataloader.NewDataLoaders(ctx, s)}, nil
+}
+
+func (r *Resolver) Election(ctx context.Context, args *struct {
+	TimeLineID *util.TimeLineNumber
+	UID        graphql.ID
+}) (*electionResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeLineID, err := getTimeLineNumber(ctx, s, args.TimeLineID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := unmarshalUID(args.UID)
+	if err != nil {
+		return nil, err
+	}
+	election, err := s.Election(ctx, timeLineID, id)
+	if err != nil {
+		return nil, err
+	}
+	if election == nil {
+		return nil, nil
+	}
+	return &electionResolver{s, election, timeLineID, dataloader.NewDataLoaders(ctx, s)}, nil
+}
+
+func (r *Resolver) UpcomingElections(ctx context.Context, args *struct {
+	TimeLineID *util.TimeLineNumber
+	Before     *graphql.Time
+}) (*[]*coreRoleTermResolver, error) {
+	now := time.Now()
+	before := now.Add(DefaultUpcomingElectionsInterval)
+	if args.Before != nil {
+		before = args.Before.Time
+	}
+	return r.coreRoleTerms(ctx, args.TimeLineID, &now, &before)
+}
+
+func (r *Resolver) ExpiredElections(ctx context.Context, args *struct {
+	TimeLineID *util.TimeLineNumber
+}) (*[]*coreRoleTermResolver, error) {
+	now := time.Now()
+	return r.coreRoleTerms(ctx, args.TimeLineID, nil, &now)
+}
+
+func (r *Resolver) RoleTemplate(ctx context.Context, args *struct {
+	TimeLineID *util.TimeLineNumber
+	UID        graphql.ID
+}) (*roleTemplateResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeLineID, err := getTimeLineNumber(ctx, s, args.TimeLineID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := unmarshalUID(args.UID)
+	if err != nil {
+		return nil, err
+	}
+	roleTemplate, err := s.RoleTemplate(ctx, timeLineID, id)
+	if err != nil {
+		return nil, err
+	}
+	if roleTemplate == nil {
+		return nil, nil
+	}
+	return &roleTemplateResolver{s, roleTemplate, timeLineID, dataloader.NewDataLoaders(ctx, s)}, nil
+}
+
+func (r *Resolver) RoleTemplates(ctx context.Context, args *struct {
+	TimeLineID *util.TimeLineNumber
+}) (*[]*roleTemplateResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeLineID, err := getTimeLineNumber(ctx, s, args.TimeLineID)
+	if err != nil {
+		return nil, err
+	}
+	roleTemplates, err := s.RoleTemplates(ctx, timeLineID)
+	if err != nil {
+		return nil, err
+	}
+	dataLoaders := dataloader.NewDataLoaders(ctx, s)
+	l := make([]*roleTemplateResolver, len(roleTemplates))
+	for i, roleTemplate := range roleTemplates {
+		l[i] = &roleTemplateResolver{s, roleTemplate, timeLineID, dataLoaders}
+	}
+	return &l, nil
+}
+
+// Webhooks returns the registered webhooks, only admins can list them since
+// they could expose internal endpoints
+func (r *Resolver) Webhooks(ctx context.Context) (*[]*webhookResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeLineID := s.CurTimeLine(ctx).Number()
+
+	callingMember, err := s.CallingMember(ctx, timeLineID)
+	if err != nil {
+		return nil, err
+	}
+	if !callingMember.IsAdmin {
+		return nil, errors.Errorf("member not authorized")
+	}
+
+	webhooks, err := s.Webhooks(ctx, timeLineID)
+	if err != nil {
+		return nil, err
+	}
+	l := make([]*webhookResolver, len(webhooks))
+	for i, webhook := range webhooks {
+		l[i] = &webhookResolver{s, webhook, timeLineID}
+	}
+	return &l, nil
+}
+
+func (r *Resolver) coreRoleTerms(ctx context.Context, tl *util.TimeLineNumber, after, before *time.Time) (*[]*coreRoleTermResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeLineID, err := getTimeLineNumber(ctx, s, tl)
+	if err != nil {
+		return nil, err
+	}
+	terms, err := s.CoreRoleTerms(ctx, timeLineID, after, before)
+	if err != nil {
+		return nil, err
+	}
+	dataLoaders := dataloader.NewDataLoaders(ctx, s)
+	l := make([]*coreRoleTermResolver, len(terms))
+	for i, term := range terms {
+		l[i] = &coreRoleTermResolver{s, term, timeLineID, dataLoaders}
+	}
+	return &l, nil
+}
+
+func (r *Resolver) Members(ctx context.Context, args *struct {
+	TimeLineID *util.TimeLineNumber
+	Search     *string
+	First      *float64
+	After      *string
+}) (*memberConnectionResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// accept only a cursor or a timeline + search
+	if args.After != nil && (args.Search != nil || args.TimeLineID != nil) {
+		return nil, errors.New("only the cursor or the search and timeline can be provided")
+	}
+
+	var timeLineID util.TimeLineNumber
+	var search string
+	var fullName *string
+	if args.After != nil {
+		cursor, err := unmarshalMemberConnectionCursor(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		timeLineID = cursor.TimeLineID
+		search = cursor.SearchString
+		fullName = &cursor.FullName
+
+	} else {
+		var err error
+		timeLineID, err = getTimeLineNumber(ctx, s, args.TimeLineID)
+		if err != nil {
+			return nil, err
+		}
+		if args.Search != nil && *args.Search != "" {
+			search = *args.Search
+		}
+	}
+	first := 0
+	if args.First != nil {
+		first = int(*args.First)
+	}
+
+	members, hasMoreData, err := s.Members(ctx, timeLineID, search, first, fullName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &memberConnectionResolver{s, members, hasMoreData, timeLineID, dataloader.NewDataLoaders(ctx, s)}, nil
+}
+
+func (r *Resolver) Roles(ctx context.Context, args *struct{ TimeLineID *util.TimeLineNumber }) (*[]*roleResolver, error) {
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeLineID, err := getTimeLineNumber(ctx, s, args.TimeLineID)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.Roles(ctx, timeLineID, nil)
+	if err != nil {
+		return nil, err
+	}
+	l := make([]*roleResolver, len(roles))
+	for i, role := range roles {
+		l[i] = &roleResolver{s, role, timeLineID, dataloader.NewDataLoaders(ctx, s)}
+	}
+	return &l, nil
+}
+
+// Mutations
+func (r *Resolver) UpdateRootRole(ctx context.Context, args *struct {
+	UpdateRootRoleChange *UpdateRootRoleChange
+}) (*updateRootRoleResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	urc, err := args.UpdateRootRoleChange.toCommandChange()
+	if err != nil {
+		return nil, err
+	}
+
+	res, groupID, err := cs.UpdateRootRole(ctx, urc)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &updateRootRoleResultResolver{nil, nil, res, -1, nil}, nil
+	}
+
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	readdb, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var role *models.Role
+	if err == nil {
+		role, err = readdb.Role(ctx, tl.Number(), urc.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &updateRootRoleResultResolver{readdb, role, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+}
+
+func (r *Resolver) CircleCreateChildRole(ctx context.Context, args *struct {
+	RoleUID          graphql.ID
+	CreateRoleChange *CreateRoleChange
+}) (*createRoleResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	crc, err := args.CreateRoleChange.toCommandChange()
+	if err != nil {
+		return nil, err
+	}
+
+	roleID, err := unmarshalUID(args.RoleUID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, groupID, err := cs.CircleCreateChildRole(ctx, roleID, crc)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &createRoleResultResolver{nil, nil, res, -1, nil}, nil
+	}
+
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	readdb, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var role *models.Role
+	if err == nil && res.RoleID != nil {
+		role, err = readdb.Role(ctx, tl.Number(), *res.RoleID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &createRoleResultResolver{readdb, role, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+}
+
+func (r *Resolver) CircleUpdateChildRole(ctx context.Context, args *struct {
+	RoleUID          graphql.ID
+	UpdateRoleChange *UpdateRoleChange
+}) (*updateRoleResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	urc, err := args.UpdateRoleChange.toCommandChange()
+	if err != nil {
+		return nil, err
+	}
+
+	roleID, err := unmarshalUID(args.RoleUID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, groupID, err := cs.CircleUpdateChildRole(ctx, roleID, urc)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &updateRoleResultResolver{nil, nil, res, -1, nil}, nil
+	}
+
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	readdb, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var role *models.Role
+	if err == nil {
+		role, err = readdb.Role(ctx, tl.Number(), urc.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &updateRoleResultResolver{readdb, role, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+}
+
+func (r *Resolver) CircleDeleteChildRole(ctx context.Context, args *struct {
+	RoleUID          graphql.ID
+	DeleteRoleChange *DeleteRoleChange
+}) (*deleteRoleResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	drc, err := args.DeleteRoleChange.toCommandChange()
+	if err != nil {
+		return nil, err
+	}
+
+	roleID, err := unmarshalUID(args.RoleUID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, groupID, err := cs.CircleDeleteChildRole(ctx, roleID, drc)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &deleteRoleResultResolver{nil, res, -1, nil}, nil
+	}
+
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	readdb, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deleteRoleResultResolver{readdb, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+}
+
+func (r *Resolver) SetRoleAdditionalContent(ctx context.Context, args *struct {
+	RoleUID graphql.ID
+	Content string
+}) (*setRoleAdditionalContentResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+
+	roleID, err := unmarshalUID(args.RoleUID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, groupID, err := cs.SetRoleAdditionalContent(ctx, roleID, args.Content)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &setRoleAdditionalContentResultResolver{nil, nil, res, -1, nil}, nil
+	}
+
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	readdb, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	dataLoaders := dataloader.NewDataLoaders(ctx, readdb)
+	data, err := dataLoaders.Get(tl.Number()).RoleAdditionalContent.Load(roleID.String())()
+	if err != nil {
+		return nil, err
+	}
+	roleAdditionalContent := data.(*models.RoleAdditionalContent)
+	if err != nil {
+		return nil, err
+	}
+	return &setRoleAdditionalContentResultResolver{readdb, roleAdditionalContent, res, tl.Number(), dataLoaders}, nil
+}
+
+func (r *Resolver) MoveRole(ctx context.Context, args *struct {
+	RoleUID      graphql.ID
+	NewParentUID graphql.ID
+}) (*moveRoleResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+
+	roleID, err := unmarshalUID(args.RoleUID)
+	if err != nil {
+		return nil, err
+	}
+	newParentID, err := unmarshalUID(args.NewParentUID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, groupID, err := cs.MoveRole(ctx, roleID, newParentID)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &moveRoleResultResolver{nil, nil, res, -1, nil}, nil
+	}
+
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	readdb, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := readdb.Role(ctx, tl.Number(), roleID)
+	if err != nil {
+		return nil, err
+	}
+	return &moveRoleResultResolver{readdb, role, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+}
+
+func (r *Resolver) CreateMember(ctx context.Context, args *struct {
+	CreateMemberChange *CreateMemberChange
+}) (*createMemberResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	avatar := ctx.Value("image")
+
+	mr, err := args.CreateMemberChange.toCommandChange()
+	if err != nil {
+		return nil, err
+	}
+
+	if mr.AvatarData != nil && avatar != nil {
+		mr.AvatarData.Avatar = avatar.([]byte)
+	}
+
+	res, groupID, err := cs.CreateMember(ctx, mr)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &createMemberResultResolver{nil, nil, res, -1, nil}, nil
+	}
+
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	readdb, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var member *models.Member
+	if err == nil && res.MemberID != nil {
+		member, err = readdb.Member(ctx, tl.Number(), *res.MemberID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &createMemberResultResolver{readdb, member, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+}
+
+func (r *Resolver) UpdateMember(ctx context.Context, args *struct {
+	UpdateMemberChange *UpdateMemberChange
+}) (*updateMemberResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	avatar := ctx.Value("image")
+
+	mr, err := args.UpdateMemberChange.toCommandChange()
+	if err != nil {
+		return nil, err
+	}
+
+	if mr.AvatarData != nil && avatar != nil {
+		mr.AvatarData.Avatar = avatar.([]byte)
+	}
+
+	res, groupID, err := cs.UpdateMember(ctx, mr)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &updateMemberResultResolver{nil, nil, res, -1, nil}, nil
+	}
+
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	readdb, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var member *models.Member
+	if err == nil {
+		member, err = readdb.Member(ctx, tl.Number(), mr.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &updateMemberResultResolver{readdb, member, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+}
+
+func (r *Resolver) DeactivateMember(ctx context.Context, args *struct {
+	MemberUID         graphql.ID
+	RemoveAssignments *bool
+}) (*deactivateMemberResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+
+	memberID, err := unmarshalUID(args.MemberUID)
+	if err != nil {
+		return nil, err
+	}
+	removeAssignments := false
+	if args.RemoveAssignments != nil {
+		removeAssignments = *args.RemoveAssignments
+	}
+
+	res, groupID, err := cs.DeactivateMember(ctx, memberID, removeAssignments)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &deactivateMemberResultResolver{nil, res, -1, nil}, nil
+	}
+
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	readdb, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deactivateMemberResultResolver{readdb, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+}
+
+func (r *Resolver) ReactivateMember(ctx context.Context, args *struct {
+	MemberUID graphql.ID
+}) (*genericResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+
+	memberID, err := unmarshalUID(args.MemberUID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, groupID, err := cs.ReactivateMember(ctx, memberID)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+
+	if err != command.ErrValidation {
+		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &genericResultResolver{res}, nil
+}
+
+func (r *Resolver) RevokeMemberSessions(ctx context.Context, args *struct {
+	MemberUID graphql.ID
+}) (*genericResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+
+	memberID, err := unmarshalUID(args.MemberUID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, groupID, err := cs.RevokeMemberSessions(ctx, memberID)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+
+	// groupID is nil when there weren't sessions to revoke
+	if err != command.ErrValidation && groupID != util.NilID {
+		if err := readDBListener.WaitGroupID(ctx, groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &genericResultResolver{res}, nil
+}
+
+func (r *Resolver) CreateAPIToken(ctx context.Context, args *struct {
+	CreateAPITokenChange *CreateAPITokenChange
+}) (*createAPITokenResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	rc, err := args.CreateAPITokenChange.toCommandChange()
+	if err != nil {
+		return nil, err
+	}
+
+	res, groupID, err := cs.CreateAPIToken(ctx, rc)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &createAPITokenResultResolver{nil, res}, nil
+	}
+
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	readdb, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	apiToken, err := readdb.APIToken(ctx, tl.Number(), *res.APITokenID)
+	if err != nil {
+		return nil, err
+	}
+	return &createAPITokenResultResolver{apiToken, res}, nil
+}
+
+func (r *Resolver) RevokeAPIToken(ctx context.Context, args *struct {
+	UID graphql.ID
+}) (*genericResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+
+	apiTokenID, err := unmarshalUID(args.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, groupID, err := cs.RevokeAPIToken(ctx, apiTokenID)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+
+	if err != command.ErrValidation {
+		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &genericResultResolver{res}, nil
+}
+
+func (r *Resolver) SetMemberPassword(ctx context.Context, args *struct {
+	MemberUID   graphql.ID
+	CurPassword *string
+	NewPassword string
+}) (*genericResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+
+	memberID, err := unmarshalUID(args.MemberUID)
+	if err != nil {
+		return nil, err
+	}
+	var curPassword string
+	if args.CurPassword != nil {
+		curPassword = *args.CurPassword
+	}
+
+	res, groupID, err := cs.SetMemberPassword(ctx, memberID, curPassword, args.NewPassword)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+
+	if err != command.ErrValidation {
+		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &genericResultResolver{res}, nil
+}
+
+func (r *Resolver) SetMemberMatchUID(ctx context.Context, args *struct {
+	MemberUID graphql.ID
+	MatchUID  string
+}) (*genericResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+
+	memberID, err := unmarshalUID(args.MemberUID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, groupID, err := cs.SetMemberMatchUID(ctx, memberID, args.MatchUID)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+
+	if err != command.ErrValidation {
+		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &genericResultResolver{res}, nil
+}
+
+func (r *Resolver) ImportMember(ctx context.Context, args *struct {
+	LoginName string
+}) (*memberResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	memberProvider := ctx.Value("memberprovider").(auth.MemberProvider)
+
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sn := util.TimeLineNumber(0)
+	timeLineID, err := getTimeLineNumber(ctx, s, &sn)
+	if err != nil {
+		return nil, err
+	}
+
+	res, groupID, err := auth.ImportMember(ctx, s, cs, memberProvider, args.LoginName)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	readdb, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var member *models.Member
+	if err == nil && res.MemberID != nil {
+		member, err = readdb.Member(ctx, tl.Number(), *res.MemberID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &memberResolver{s, member, timeLineID, dataloader.NewDataLoaders(ctx, s)}, nil
+}
+
+func (r *Resolver) CreateTension(ctx context.Context, args *struct {
+	CreateTensionChange *CreateTensionChange
+}) (*createTensionResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	mr, err := args.CreateTensionChange.toCommandChange()
+	if err != nil {
+		return nil, err
+	}
+
+	res, groupID, err := cs.CreateTension(ctx, mr)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &createTensionResultResolver{nil, nil, res, -1, nil}, nil
+	}
+
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	readdb, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tension *models.Tension
+	if err == nil && res.TensionID != nil {
+		tension, err = readdb.Tension(ctx, tl.Number(), *res.TensionID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &createTensionResultResolver{readdb, tension, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+}
+
+func (r *Resolver) UpdateTension(ctx context.Context, args *struct {
+	UpdateTensionChange *UpdateTensionChange
+}) (*updateTensionResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	mr, err := args.UpdateTensionChange.toCommandChange()
+	if err != nil {
+		return nil, err
+	}
+
+	res, groupID, err := cs.UpdateTension(ctx, mr)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &updateTensionResultResolver{nil, nil, res, -1, nil}, nil
+	}
+
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	readdb, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tension *models.Tension
+	if err == nil {
+		tension, err = readdb.Tension(ctx, tl.Number(), mr.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &updateTensionResultResolver{readdb, tension, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+}
+
+func (r *Resolver) CloseTension(ctx context.Context, args *struct {
+	CloseTensionChange *CloseTensionChange
+}) (*closeTensionResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	mr, err := args.CloseTensionChange.toCommandChange()
+	if err != nil {
+		return nil, err
+	}
+
+	res, groupID, err := cs.CloseTension(ctx, mr)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &closeTensionResultResolver{nil, res, -1, nil}, nil
+	}
+
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	readdb, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &closeTensionResultResolver{readdb, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+}
+
+func (r *Resolver) ChangeTensionStatus(ctx context.Context, args *struct {
+	TensionUID graphql.ID
+	Status     string
+}) (*tensionTriageResultResolver, error) {
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	tensionID, err := unmarshalUID(args.TensionUID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, groupID, err := cs.ChangeTensionStatus(ctx, tensionID, models.TensionStatus(strings.ToLower(args.Status)))
+	return r.tensionTriageResult(ctx, tensionID, res, groupID, err)
+}
+
+func (r *Resolver) ChangeTensionAssignee(ctx context.Context, args *struct {
+	TensionUID graphql.ID
+	MemberUID  *graphql.ID
+}) (*tensionTriageResultResolver, error) {
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	tensionID, err := unmarshalUID(args.TensionUID)
+	if err != nil {
+		return nil, err
+	}
+	var memberID *util.ID
+	if args.MemberUID != nil {
+		id, err := unmarshalUID(*args.MemberUID)
+		if err != nil {
+			return nil, err
+		}
+		memberID = &id
 	}
 
-	return mt, nil
-}
-
-type UpdateTensionChange struct {
-	UID         graphql.ID
-	Title       string
-	Description string
-	RoleUID     *graphql.ID
+	res, groupID, err := cs.ChangeTensionAssignee(ctx, tensionID, memberID)
+	return r.tensionTriageResult(ctx, tensionID, res, groupID, err)
 }
 
-func (t *UpdateTensionChange) toCommandChange() (*change.UpdateTensionChange, error) {
-	mt := &change.UpdateTensionChange{}
-
-	id, err := unmarshalUID(t.UID)
+func (r *Resolver) ChangeTensionMeeting(ctx context.Context, args *struct {
+	TensionUID graphql.ID
+	MeetingUID *graphql.ID
+}) (*tensionTriageResultResolver, error) {
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	tensionID, err := unmarshalUID(args.TensionUID)
 	if err != nil {
 		return nil, err
 	}
-	mt.ID = id
-
-	mt.Title = t.Title
-	mt.Description = t.Description
-
-	if t.RoleUID != nil {
-		id, err := unmarshalUID(*t.RoleUID)
+	var meetingID *util.ID
+	if args.MeetingUID != nil {
+		id, err := unmarshalUID(*args.MeetingUID)
 		if err != nil {
 			return nil, err
 		}
-		mt.RoleID = &id
+		meetingID = &id
 	}
 
-	return mt, nil
+	res, groupID, err := cs.ChangeTensionMeeting(ctx, tensionID, meetingID)
+	return r.tensionTriageResult(ctx, tensionID, res, groupID, err)
 }
 
-type CloseTensionChange struct {
-	UID    graphql.ID
-	Reason string
+func (r *Resolver) ResolveTension(ctx context.Context, args *struct {
+	ResolveTensionChange *ResolveTensionChange
+}) (*tensionTriageResultResolver, error) {
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	mr, err := args.ResolveTensionChange.toCommandChange()
+	if err != nil {
+		return nil, err
+	}
+
+	res, groupID, err := cs.ResolveTension(ctx, mr)
+	return r.tensionTriageResult(ctx, mr.ID, res, groupID, err)
 }
 
-func (t *CloseTensionChange) toCommandChange() (*change.CloseTensionChange, error) {
-	mt := &change.CloseTensionChange{}
+// tensionTriageResult waits for the command timeline and returns the tension
+// at that timeline
+func (r *Resolver) tensionTriageResult(ctx context.Context, tensionID util.ID, res *change.GenericResult, groupID util.ID, err error) (*tensionTriageResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 
-	id, err := unmarshalUID(t.UID)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &tensionTriageResultResolver{nil, nil, res, -1, nil}, nil
+	}
+
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	readdb, err := r.setupReadDB(ctx)
 	if err != nil {
 		return nil, err
 	}
-	mt.ID = id
 
-	mt.Reason = t.Reason
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
 
-	return mt, nil
+	tension, err := readdb.Tension(ctx, tl.Number(), tensionID)
+	if err != nil {
+		return nil, err
+	}
+	return &tensionTriageResultResolver{readdb, tension, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
 }
 
-func getTimeLineNumber(ctx context.Context, readDB readdb.ReadDBService, v *util.TimeLineNumber) (util.TimeLineNumber, error) {
-	curTl := readDB.CurTimeLine(ctx)
+func (r *Resolver) CreateProposal(ctx context.Context, args *struct {
+	CreateProposalChange *CreateProposalChange
+}) (*createProposalResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	mp, err := args.CreateProposalChange.toCommandChange()
+	if err != nil {
+		return nil, err
+	}
 
-	if v == nil {
-		return curTl.Number(), nil
+	res, groupID, err := cs.CreateProposal(ctx, mp)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &createProposalResultResolver{nil, nil, res, -1, nil}, nil
 	}
 
-	timeLineID := *v
-	if timeLineID == 0 {
-		return curTl.Number(), nil
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
 	}
 
-	if timeLineID < 0 {
-		if !test {
-			return 0, errors.Errorf("invalid timeLineID %d", *v)
-		}
+	readdb, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-		// TODO(sgotti) ugly hack used only for tests, should to be removed
-		// a negative values means that we will use current timeLineID - v
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
 
-		n := int(-*v)
-		tls, _, err := readDB.TimeLines(ctx, nil, curTl.Number(), n, false, "", nil)
+	var proposal *models.Proposal
+	if res.ProposalID != nil {
+		proposal, err = readdb.Proposal(ctx, tl.Number(), *res.ProposalID)
 		if err != nil {
-			return 0, err
-		}
-		if len(tls) < n {
-			return 0, errors.Errorf("invalid timeLineID %d", *v)
+			return nil, err
 		}
-		timeLineID = tls[n-1].Number()
 	}
-
-	return timeLineID, nil
+	return &createProposalResultResolver{readdb, proposal, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
 }
 
-type Resolver struct {
-}
+func (r *Resolver) UpdateProposal(ctx context.Context, args *struct {
+	UpdateProposalChange *UpdateProposalChange
+}) (*updateProposalResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	mp, err := args.UpdateProposalChange.toCommandChange()
+	if err != nil {
+		return nil, err
+	}
 
-func NewResolver() *Resolver {
-	return &Resolver{}
-}
+	res, groupID, err := cs.UpdateProposal(ctx, mp)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &updateProposalResultResolver{nil, nil, res, -1, nil}, nil
+	}
 
-func (r *Resolver) TimeLine(ctx context.Context, args *struct {
-	ID *util.TimeLineNumber
-}) (*timeLineResolver, error) {
-	s, err := r.setupReadDB(ctx)
-	if err != nil {
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
 		return nil, err
 	}
 
-	var tl *util.TimeLine
-	timeLineID, err := getTimeLineNumber(ctx, s, args.ID)
+	readdb, err := r.setupReadDB(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if timeLineID == 0 {
-		tl = s.CurTimeLine(ctx)
-	} else {
-		tl, err = s.TimeLine(ctx, timeLineID)
-	}
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
 	if err != nil {
 		return nil, err
 	}
-	if tl == nil {
-		return nil, nil
+
+	proposal, err := readdb.Proposal(ctx, tl.Number(), mp.ID)
+	if err != nil {
+		return nil, err
 	}
-	return &timeLineResolver{s, tl, dataloader.NewDataLoaders(ctx, s)}, nil
+	return &updateProposalResultResolver{readdb, proposal, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
 }
 
-func (r *Resolver) TimeLines(ctx context.Context, args *struct {
-	FromTime      *graphql.Time
-	FromID        *string
-	AggregateType *string
-	AggregateID   *graphql.ID
-	First         *float64
-	Last          *float64
-	After         *string
-	Before        *string
-}) (*timeLineConnectionResolver, error) {
-	// accept only an After or a Before cursor
-	if args.After != nil && args.Before != nil {
-		return nil, errors.New("only one cursor can be provided")
-	}
-	// accept only a fromTime or fromID
-	if args.FromTime != nil && args.FromID != nil {
-		return nil, errors.New("only one of fromTime or fromID can be provided")
+func (r *Resolver) SubmitProposal(ctx context.Context, args *struct {
+	ProposalUID graphql.ID
+}) (*proposalResultResolver, error) {
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	proposalID, err := unmarshalUID(args.ProposalUID)
+	if err != nil {
+		return nil, err
 	}
-	// accept only a cursor or a fromTime/fromID/AggregateType/AggregateID
-	if (args.After != nil || args.Before != nil) && (args.FromTime != nil || args.FromID != nil || args.AggregateType != nil || args.AggregateID != nil) {
-		return nil, errors.New("only the cursor or the fromTime/fromID/AggregateType/AggregateID can be provided")
+
+	res, groupID, err := cs.SubmitProposal(ctx, proposalID)
+	return r.proposalResult(ctx, proposalID, res, groupID, err)
+}
+
+func (r *Resolver) ObjectProposal(ctx context.Context, args *struct {
+	ProposalUID graphql.ID
+	Reason      string
+}) (*proposalResultResolver, error) {
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	proposalID, err := unmarshalUID(args.ProposalUID)
+	if err != nil {
+		return nil, err
 	}
-	// accept only a First or Last
-	if args.First != nil && args.Last != nil {
-		return nil, errors.New("only one of first or last can be provided")
+
+	res, groupID, err := cs.ObjectProposal(ctx, &change.ObjectProposalChange{ID: proposalID, Reason: args.Reason})
+	return r.proposalResult(ctx, proposalID, res, groupID, err)
+}
+
+func (r *Resolver) AcceptProposal(ctx context.Context, args *struct {
+	ProposalUID graphql.ID
+}) (*proposalResultResolver, error) {
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	proposalID, err := unmarshalUID(args.ProposalUID)
+	if err != nil {
+		return nil, err
 	}
 
-	var timeLineID util.TimeLineNumber
-	var fromTime *time.Time
-	var fromID int64
-	var aggregateType string
-	var aggregateID *util.ID
-	var err error
+	res, groupID, err := cs.AcceptProposal(ctx, proposalID)
+	return r.proposalResult(ctx, proposalID, res, groupID, err)
+}
 
-	if args.AggregateType != nil {
-		aggregateType = *args.AggregateType
+func (r *Resolver) WithdrawProposal(ctx context.Context, args *struct {
+	ProposalUID graphql.ID
+}) (*proposalResultResolver, error) {
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	proposalID, err := unmarshalUID(args.ProposalUID)
+	if err != nil {
+		return nil, err
 	}
-	if args.AggregateID != nil {
-		id, err := unmarshalUID(*args.AggregateID)
-		if err != nil {
-			return nil, err
-		}
-		aggregateID = &id
+
+	res, groupID, err := cs.WithdrawProposal(ctx, proposalID)
+	return r.proposalResult(ctx, proposalID, res, groupID, err)
+}
+
+func (r *Resolver) ResolveProposalObjection(ctx context.Context, args *struct {
+	ProposalUID  graphql.ID
+	ObjectionUID graphql.ID
+	Resolution   string
+}) (*proposalResultResolver, error) {
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	proposalID, err := unmarshalUID(args.ProposalUID)
+	if err != nil {
+		return nil, err
 	}
-	if args.After != nil {
-		cursor, err := unmarshalTimeLineCursor(*args.After)
-		if err != nil {
-			return nil, err
-		}
-		tl, err := strconv.ParseInt(cursor.TimeLineID, 10, 64)
-		if err != nil {
-			return nil, err
-		}
-		timeLineID = util.TimeLineNumber(tl)
-		aggregateType = cursor.AggregateType
-		aggregateID = cursor.AggregateID
+	objectionID, err := unmarshalUID(args.ObjectionUID)
+	if err != nil {
+		return nil, err
 	}
-	if args.Before != nil {
-		cursor, err := unmarshalTimeLineCursor(*args.Before)
-		if err != nil {
-			return nil, err
-		}
-		tl, err := strconv.ParseInt(cursor.TimeLineID, 10, 64)
-		if err != nil {
-			return nil, err
-		}
-		timeLineID = util.TimeLineNumber(tl)
-		aggregateType = cursor.AggregateType
-		aggregateID = cursor.AggregateID
+
+	res, groupID, err := cs.ResolveProposalObjection(ctx, &change.ResolveProposalObjectionChange{ID: proposalID, ObjectionID: objectionID, Resolution: args.Resolution})
+	return r.proposalResult(ctx, proposalID, res, groupID, err)
+}
+
+func (r *Resolver) WithdrawProposalObjection(ctx context.Context, args *struct {
+	ProposalUID  graphql.ID
+	ObjectionUID graphql.ID
+}) (*proposalResultResolver, error) {
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	proposalID, err := unmarshalUID(args.ProposalUID)
+	if err != nil {
+		return nil, err
 	}
-	if args.FromTime != nil {
-		fromTime = &args.FromTime.Time
+	objectionID, err := unmarshalUID(args.ObjectionUID)
+	if err != nil {
+		return nil, err
 	}
-	if args.FromID != nil {
-		fromID, err = strconv.ParseInt(*args.FromID, 10, 64)
-		if err != nil {
-			return nil, err
-		}
+
+	res, groupID, err := cs.WithdrawProposalObjection(ctx, proposalID, objectionID)
+	return r.proposalResult(ctx, proposalID, res, groupID, err)
+}
+
+func (r *Resolver) ConsentProposal(ctx context.Context, args *struct {
+	ProposalUID graphql.ID
+}) (*proposalResultResolver, error) {
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	proposalID, err := unmarshalUID(args.ProposalUID)
+	if err != nil {
+		return nil, err
 	}
-	limit := 0
-	if args.First != nil {
-		limit = int(*args.First)
+
+	res, groupID, err := cs.ConsentProposal(ctx, proposalID)
+	return r.proposalResult(ctx, proposalID, res, groupID, err)
+}
+
+// proposalResult waits for the command timeline and returns the proposal at
+// that timeline
+func (r *Resolver) proposalResult(ctx context.Context, proposalID util.ID, res *change.GenericResult, groupID util.ID, err error) (*proposalResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+
+	if err != nil && err != command.ErrValidation {
+		return nil, err
 	}
-	if args.Last != nil {
-		limit = int(*args.Last)
+	if err == command.ErrValidation {
+		return &proposalResultResolver{nil, nil, res, -1, nil}, nil
 	}
 
-	if fromID != 0 {
-		timeLineID = util.TimeLineNumber(fromID)
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
 	}
 
-	s, err := r.setupReadDB(ctx)
+	readdb, err := r.setupReadDB(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	timeLines, hasMoreData, err := s.TimeLines(ctx, fromTime, timeLineID, limit, args.Last == nil, aggregateType, aggregateID)
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &timeLineConnectionResolver{s, timeLines, aggregateType, aggregateID, hasMoreData, dataloader.NewDataLoaders(ctx, s)}, nil
+	proposal, err := readdb.Proposal(ctx, tl.Number(), proposalID)
+	if err != nil {
+		return nil, err
+	}
+	return &proposalResultResolver{readdb, proposal, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
 }
 
-func (r *Resolver) Viewer(ctx context.Context) (*viewerResolver, error) {
-	s, err := r.setupReadDB(ctx)
+func (r *Resolver) CircleSetLeadLinkMember(ctx context.Context, args *struct {
+	RoleUID   graphql.ID
+	MemberUID graphql.ID
+}) (*genericResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	roleUID, err := unmarshalUID(args.RoleUID)
 	if err != nil {
 		return nil, err
 	}
-	timeLineID, err := getTimeLineNumber(ctx, s, nil)
+	memberUID, err := unmarshalUID(args.MemberUID)
 	if err != nil {
 		return nil, err
 	}
-	member, err := s.CallingMember(ctx, timeLineID)
-	if err != nil {
+	res, groupID, err := cs.CircleSetLeadLinkMember(ctx, roleUID, memberUID)
+	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
-	if member == nil {
-		return nil, nil
+
+	if err != command.ErrValidation {
+		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+			return nil, err
+		}
 	}
-	return &viewerResolver{s, member, timeLineID, dataloader.NewDataLoaders(ctx, s)}, nil
+
+	return &genericResultResolver{res}, nil
 }
 
-func (r *Resolver) RootRole(ctx context.Context, args *struct{ TimeLineID *util.TimeLineNumber }) (*roleResolver, error) {
-	s, err := r.setupReadDB(ctx)
-	if err != nil {
-		return nil, err
-	}
-	timeLineID, err := getTimeLineNumber(ctx, s, args.TimeLineID)
+func (r *Resolver) CircleUnsetLeadLinkMember(ctx context.Context, args *struct {
+	RoleUID graphql.ID
+}) (*genericResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	roleUID, err := unmarshalUID(args.RoleUID)
 	if err != nil {
 		return nil, err
 	}
-	role, err := s.RootRole(ctx, timeLineID)
-	if err != nil {
+	res, groupID, err := cs.CircleUnsetLeadLinkMember(ctx, roleUID)
+	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
-	if role == nil {
-		return nil, nil
+
+	if err != command.ErrValidation {
+		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+			return nil, err
+		}
 	}
-	return NewRoleResolver(s, role, timeLineID, dataloader.NewDataLoaders(ctx, s)), nil
+
+	return &genericResultResolver{res}, nil
 }
 
-func (r *Resolver) Role(ctx context.Context, args *struct {
-	TimeLineID *util.TimeLineNumber
-	UID        graphql.ID
-}) (*roleResolver, error) {
-	s, err := r.setupReadDB(ctx)
+func (r *Resolver) CircleSetCoreRoleMember(ctx context.Context, args *struct {
+	RoleType           string
+	RoleUID            graphql.ID
+	MemberUID          graphql.ID
+	ElectionExpiration *graphql.Time
+}) (*genericResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	roleUID, err := unmarshalUID(args.RoleUID)
 	if err != nil {
 		return nil, err
 	}
-	timeLineID, err := getTimeLineNumber(ctx, s, args.TimeLineID)
+	memberUID, err := unmarshalUID(args.MemberUID)
 	if err != nil {
 		return nil, err
 	}
-	// Get role id
-	id, err := unmarshalUID(args.UID)
-	if err != nil {
-		return nil, err
+	var time *time.Time
+	if args.ElectionExpiration != nil {
+		time = &args.ElectionExpiration.Time
 	}
-	role, err := s.Role(ctx, timeLineID, id)
-	if err != nil {
+	res, groupID, err := cs.CircleSetCoreRoleMember(ctx, models.RoleTypeFromString(args.RoleType), roleUID, memberUID, time)
+	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
-	if role == nil {
-		return nil, nil
+
+	if err != command.ErrValidation {
+		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+			return nil, err
+		}
 	}
-	return NewRoleResolver(s, role, timeLineID, dataloader.NewDataLoaders(ctx, s)), nil
+
+	return &genericResultResolver{res}, nil
 }
 
-func (r *Resolver) Member(ctx context.Context, args *struct {
-	TimeLineID *util.TimeLineNumber
-	UID        graphql.ID
-}) (*memberResolver, error) {
-	s, err := r.setupReadDB(ctx)
+func (r *Resolver) CircleUnsetCoreRoleMember(ctx context.Context, args *struct {
+	RoleType string
+	RoleUID  graphql.ID
+}) (*genericResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	roleUID, err := unmarshalUID(args.RoleUID)
 	if err != nil {
 		return nil, err
 	}
-	timeLineID, err := getTimeLineNumber(ctx, s, args.TimeLineID)
-	if err != nil {
+	res, groupID, err := cs.CircleUnsetCoreRoleMember(ctx, models.RoleTypeFromString(args.RoleType), roleUID)
+	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
-	// Get member id
-	id, err := unmarshalUID(args.UID)
+
+	if err != command.ErrValidation {
+		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &genericResultResolver{res}, nil
+}
+
+func (r *Resolver) CircleAddDirectMember(ctx context.Context, args *struct {
+	RoleUID   graphql.ID
+	MemberUID graphql.ID
+}) (*genericResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	roleUID, err := unmarshalUID(args.RoleUID)
 	if err != nil {
 		return nil, err
 	}
-	member, err := s.Member(ctx, timeLineID, id)
+	memberUID, err := unmarshalUID(args.MemberUID)
 	if err != nil {
 		return nil, err
 	}
-	if member == nil {
-		return nil, nil
+	res, groupID, err := cs.CircleAddDirectMember(ctx, roleUID, memberUID)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
 	}
-	return &memberResolver{s, member, timeLineID, dataloader.NewDataLoaders(ctx, s)}, nil
+
+	if err != command.ErrValidation {
+		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &genericResultResolver{res}, nil
 }
 
-func (r *Resolver) Tension(ctx context.Context, args *struct {
-	TimeLineID *util.TimeLineNumber
-	UID        graphql.ID
-}) (*tensionResolver, error) {
-	s, err := r.setupReadDB(ctx)
+func (r *Resolver) CircleRemoveDirectMember(ctx context.Context, args *struct {
+	RoleUID   graphql.ID
+	MemberUID graphql.ID
+}) (*genericResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	roleUID, err := unmarshalUID(args.RoleUID)
 	if err != nil {
 		return nil, err
 	}
-	timeLineID, err := getTimeLineNumber(ctx, s, args.TimeLineID)
+	memberUID, err := unmarshalUID(args.MemberUID)
 	if err != nil {
 		return nil, err
 	}
-	// Get tension id
-	id, err := unmarshalUID(args.UID)
+	res, groupID, err := cs.CircleRemoveDirectMember(ctx, roleUID, memberUID)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+
+	if err != command.ErrValidation {
+		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &genericResultResolver{res}, nil
+}
+
+func (r *Resolver) RoleAddMember(ctx context.Context, args *struct {
+	RoleUID      graphql.ID
+	MemberUID    graphql.ID
+	Focus        *string
+	NoCoreMember bool
+}) (*genericResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	roleUID, err := unmarshalUID(args.RoleUID)
 	if err != nil {
 		return nil, err
 	}
-	tension, err := s.Tension(ctx, timeLineID, id)
+	memberUID, err := unmarshalUID(args.MemberUID)
 	if err != nil {
 		return nil, err
 	}
-	if tension == nil {
-		return nil, nil
+	res, groupID, err := cs.RoleAddMember(ctx, roleUID, memberUID, args.Focus, args.NoCoreMember)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
 	}
-	return &tensionResolver{s, tension, timeLineID, dataloader.NewDataLoaders(ctx, s)}, nil
+
+	if err != command.ErrValidation {
+		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &genericResultResolver{res}, nil
 }
 
-func (r *Resolver) Members(ctx context.Context, args *struct {
-	TimeLineID *util.TimeLineNumber
-	Search     *string
-	First      *float64
-	After      *string
-}) (*memberConnectionResolver, error) {
-	s, err := r.setupReadDB(ctx)
+func (r *Resolver) RoleRemoveMember(ctx context.Context, args *struct {
+	RoleUID   graphql.ID
+	MemberUID graphql.ID
+}) (*genericResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	roleUID, err := unmarshalUID(args.RoleUID)
 	if err != nil {
 		return nil, err
 	}
-
-	// accept only a cursor or a timeline + search
-	if args.After != nil && (args.Search != nil || args.TimeLineID != nil) {
-		return nil, errors.New("only the cursor or the search and timeline can be provided")
+	memberUID, err := unmarshalUID(args.MemberUID)
+	if err != nil {
+		return nil, err
+	}
+	res, groupID, err := cs.RoleRemoveMember(ctx, roleUID, memberUID)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
 	}
 
-	var timeLineID util.TimeLineNumber
-	var search string
-	var fullName *string
-	if args.After != nil {
-		cursor, err := unmarshalMemberConnectionCursor(*args.After)
-		if err != nil {
+	if err != command.ErrValidation {
+		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
 			return nil, err
 		}
-		timeLineID = cursor.TimeLineID
-		search = cursor.SearchString
-		fullName = &cursor.FullName
+	}
+
+	return &genericResultResolver{res}, nil
+}
+
+func (r *Resolver) Search(ctx context.Context, args *struct {
+	Query string
+	Types *[]string
+	First *float64
+	After *string
+}) (*searchResultResolver, error) {
+	se := ctx.Value("searchEngine").(*search.SearchEngine)
+
+	s, err := r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	} else {
-		var err error
-		timeLineID, err = getTimeLineNumber(ctx, s, args.TimeLineID)
+	opts := &search.SearchOptions{}
+	if args.Types != nil {
+		for _, t := range *args.Types {
+			opts.Types = append(opts.Types, strings.ToLower(t))
+		}
+	}
+	if args.First != nil {
+		opts.Size = int(*args.First)
+	}
+	if args.After != nil {
+		cursor, err := unmarshalSearchCursor(*args.After)
 		if err != nil {
 			return nil, err
 		}
-		if args.Search != nil && *args.Search != "" {
-			search = *args.Search
-		}
+		opts.From = cursor.Offset
 	}
-	first := 0
-	if args.First != nil {
-		first = int(*args.First)
+	timeLineID, err := getTimeLineNumber(ctx, s, nil)
+	if err != nil {
+		return nil, err
 	}
-
-	members, hasMoreData, err := s.Members(ctx, timeLineID, search, first, fullName)
+	member, err := s.CallingMember(ctx, timeLineID)
 	if err != nil {
 		return nil, err
 	}
-
-	return &memberConnectionResolver{s, members, hasMoreData, timeLineID, dataloader.NewDataLoaders(ctx, s)}, nil
+	if member == nil {
+		return nil, errors.New("no calling member")
+	}
+	res, err := se.Search(args.Query, member.ID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &searchResultResolver{s, res, opts.From, timeLineID, dataloader.NewDataLoaders(ctx, s)}, nil
 }
 
-func (r *Resolver) Roles(ctx context.Context, args *struct{ TimeLineID *util.TimeLineNumber }) (*[]*roleResolver, error) {
-	s, err := r.setupReadDB(ctx)
+func (r *Resolver) SearchIndexStatus(ctx context.Context) (*searchIndexStatusResolver, error) {
+	se := ctx.Value("searchEngine").(*search.SearchEngine)
+
+	indexedSeqNumber, lastSeqNumber, err := se.Lag()
 	if err != nil {
 		return nil, err
 	}
-	timeLineID, err := getTimeLineNumber(ctx, s, args.TimeLineID)
-	if err != nil {
+	return &searchIndexStatusResolver{indexedSeqNumber, lastSeqNumber}, nil
+}
+
+type genericResultResolver struct {
+	res *change.GenericResult
+}
+
+func (r *genericResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *genericResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
+
+func (r *Resolver) setupReadDB(ctx context.Context) (readdb.ReadDBService, error) {
+	utx := ctx.Value("utx").(*db.Tx)
+	config := ctx.Value("config").(*config.Config)
+	if err := utx.Start(); err != nil {
 		return nil, err
 	}
-	roles, err := s.Roles(ctx, timeLineID, nil)
+
+	s, err := readdb.NewReadDBService(utx)
 	if err != nil {
 		return nil, err
 	}
-	l := make([]*roleResolver, len(roles))
-	for i, role := range roles {
-		l[i] = &roleResolver{s, role, timeLineID, dataloader.NewDataLoaders(ctx, s)}
+
+	if config != nil && config.AdminMember != "" {
+		s.SetForceAdminMemberUserName(config.AdminMember)
 	}
-	return &l, nil
+	return s, nil
 }
 
-// Mutations
-func (r *Resolver) UpdateRootRole(ctx context.Context, args *struct {
-	UpdateRootRoleChange *UpdateRootRoleChange
-}) (*updateRootRoleResultResolver, error) {
+func (r *Resolver) CreateProject(ctx context.Context, args *struct {
+	CreateProjectChange *CreateProjectChange
+}) (*createProjectResultResolver, error) {
 	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	cs := ctx.Value("commandservice").(*command.CommandService)
-	urc, err := args.UpdateRootRoleChange.toCommandChange()
+	mc, err := args.CreateProjectChange.toCommandChange()
 	if err != nil {
 		return nil, err
 	}
 
-	res, groupID, err := cs.UpdateRootRole(ctx, urc)
+	res, groupID, err := cs.CreateProject(ctx, mc)
 	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
 	if err == command.ErrValidation {
-		return &updateRootRoleResultResolver{nil, nil, res, -1, nil}, nil
+		return &createProjectResultResolver{nil, nil, res, -1, nil}, nil
 	}
 
 	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
@@ -1652,38 +4936,32 @@ func (r *Resolver) UpdateRootRole(ctx context.Context, args *struct {
 		return nil, err
 	}
 
-	var role *models.Role
-	if err == nil {
-		role, err = readdb.Role(ctx, tl.Number(), urc.ID)
+	var project *models.Project
+	if res.ProjectID != nil {
+		project, err = readdb.Project(ctx, tl.Number(), *res.ProjectID)
 		if err != nil {
 			return nil, err
 		}
 	}
-	return &updateRootRoleResultResolver{readdb, role, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+	return &createProjectResultResolver{readdb, project, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
 }
 
-func (r *Resolver) CircleCreateChildRole(ctx context.Context, args *struct {
-	RoleUID          graphql.ID
-	CreateRoleChange *CreateRoleChange
-}) (*createRoleResultResolver, error) {
+func (r *Resolver) UpdateProject(ctx context.Context, args *struct {
+	UpdateProjectChange *UpdateProjectChange
+}) (*updateProjectResultResolver, error) {
 	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	cs := ctx.Value("commandservice").(*command.CommandService)
-	crc, err := args.CreateRoleChange.toCommandChange()
-	if err != nil {
-		return nil, err
-	}
-
-	roleID, err := unmarshalUID(args.RoleUID)
+	mc, err := args.UpdateProjectChange.toCommandChange()
 	if err != nil {
 		return nil, err
 	}
 
-	res, groupID, err := cs.CircleCreateChildRole(ctx, roleID, crc)
+	res, groupID, err := cs.UpdateProject(ctx, mc)
 	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
 	if err == command.ErrValidation {
-		return &createRoleResultResolver{nil, nil, res, -1, nil}, nil
+		return &updateProjectResultResolver{nil, nil, res, -1, nil}, nil
 	}
 
 	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
@@ -1700,38 +4978,30 @@ func (r *Resolver) CircleCreateChildRole(ctx context.Context, args *struct {
 		return nil, err
 	}
 
-	var role *models.Role
-	if err == nil && res.RoleID != nil {
-		role, err = readdb.Role(ctx, tl.Number(), *res.RoleID)
-		if err != nil {
-			return nil, err
-		}
+	project, err := readdb.Project(ctx, tl.Number(), mc.ID)
+	if err != nil {
+		return nil, err
 	}
-	return &createRoleResultResolver{readdb, role, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+	return &updateProjectResultResolver{readdb, project, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
 }
 
-func (r *Resolver) CircleUpdateChildRole(ctx context.Context, args *struct {
-	RoleUID          graphql.ID
-	UpdateRoleChange *UpdateRoleChange
-}) (*updateRoleResultResolver, error) {
+func (r *Resolver) ChangeProjectStatus(ctx context.Context, args *struct {
+	ProjectUID graphql.ID
+	Status     string
+}) (*projectResultResolver, error) {
 	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	cs := ctx.Value("commandservice").(*command.CommandService)
-	urc, err := args.UpdateRoleChange.toCommandChange()
-	if err != nil {
-		return nil, err
-	}
-
-	roleID, err := unmarshalUID(args.RoleUID)
+	projectID, err := unmarshalUID(args.ProjectUID)
 	if err != nil {
 		return nil, err
 	}
 
-	res, groupID, err := cs.CircleUpdateChildRole(ctx, roleID, urc)
+	res, groupID, err := cs.ChangeProjectStatus(ctx, projectID, models.ProjectStatus(strings.ToLower(args.Status)))
 	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
 	if err == command.ErrValidation {
-		return &updateRoleResultResolver{nil, nil, res, -1, nil}, nil
+		return &projectResultResolver{nil, nil, res, -1, nil}, nil
 	}
 
 	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
@@ -1748,38 +5018,29 @@ func (r *Resolver) CircleUpdateChildRole(ctx context.Context, args *struct {
 		return nil, err
 	}
 
-	var role *models.Role
-	if err == nil {
-		role, err = readdb.Role(ctx, tl.Number(), urc.ID)
-		if err != nil {
-			return nil, err
-		}
+	project, err := readdb.Project(ctx, tl.Number(), projectID)
+	if err != nil {
+		return nil, err
 	}
-	return &updateRoleResultResolver{readdb, role, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+	return &projectResultResolver{readdb, project, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
 }
 
-func (r *Resolver) CircleDeleteChildRole(ctx context.Context, args *struct {
-	RoleUID          graphql.ID
-	DeleteRoleChange *DeleteRoleChange
-}) (*deleteRoleResultResolver, error) {
+func (r *Resolver) CreateAction(ctx context.Context, args *struct {
+	CreateActionChange *CreateActionChange
+}) (*createActionResultResolver, error) {
 	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	cs := ctx.Value("commandservice").(*command.CommandService)
-	drc, err := args.DeleteRoleChange.toCommandChange()
+	mc, err := args.CreateActionChange.toCommandChange()
 	if err != nil {
 		return nil, err
 	}
 
-	roleID, err := unmarshalUID(args.RoleUID)
-	if err != nil {
-		return nil, err
-	}
-
-	res, groupID, err := cs.CircleDeleteChildRole(ctx, roleID, drc)
+	res, groupID, err := cs.CreateAction(ctx, mc)
 	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
 	if err == command.ErrValidation {
-		return &deleteRoleResultResolver{nil, res, -1, nil}, nil
+		return &createActionResultResolver{nil, nil, res, -1, nil}, nil
 	}
 
 	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
@@ -1796,27 +5057,32 @@ func (r *Resolver) CircleDeleteChildRole(ctx context.Context, args *struct {
 		return nil, err
 	}
 
-	return &deleteRoleResultResolver{readdb, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+	var action *models.Action
+	if res.ActionID != nil {
+		action, err = readdb.Action(ctx, tl.Number(), *res.ActionID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &createActionResultResolver{readdb, action, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
 }
 
-func (r *Resolver) SetRoleAdditionalContent(ctx context.Context, args *struct {
-	RoleUID graphql.ID
-	Content string
-}) (*setRoleAdditionalContentResultResolver, error) {
+func (r *Resolver) UpdateAction(ctx context.Context, args *struct {
+	UpdateActionChange *UpdateActionChange
+}) (*updateActionResultResolver, error) {
 	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	cs := ctx.Value("commandservice").(*command.CommandService)
-
-	roleID, err := unmarshalUID(args.RoleUID)
+	mc, err := args.UpdateActionChange.toCommandChange()
 	if err != nil {
 		return nil, err
 	}
 
-	res, groupID, err := cs.SetRoleAdditionalContent(ctx, roleID, args.Content)
+	res, groupID, err := cs.UpdateAction(ctx, mc)
 	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
 	if err == command.ErrValidation {
-		return &setRoleAdditionalContentResultResolver{nil, nil, res, -1, nil}, nil
+		return &updateActionResultResolver{nil, nil, res, -1, nil}, nil
 	}
 
 	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
@@ -1833,40 +5099,30 @@ func (r *Resolver) SetRoleAdditionalContent(ctx context.Context, args *struct {
 		return nil, err
 	}
 
-	dataLoaders := dataloader.NewDataLoaders(ctx, readdb)
-	data, err := dataLoaders.Get(tl.Number()).RoleAdditionalContent.Load(roleID.String())()
-	if err != nil {
-		return nil, err
-	}
-	roleAdditionalContent := data.(*models.RoleAdditionalContent)
+	action, err := readdb.Action(ctx, tl.Number(), mc.ID)
 	if err != nil {
 		return nil, err
 	}
-	return &setRoleAdditionalContentResultResolver{readdb, roleAdditionalContent, res, tl.Number(), dataLoaders}, nil
+	return &updateActionResultResolver{readdb, action, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
 }
 
-func (r *Resolver) CreateMember(ctx context.Context, args *struct {
-	CreateMemberChange *CreateMemberChange
-}) (*createMemberResultResolver, error) {
+func (r *Resolver) ChangeActionStatus(ctx context.Context, args *struct {
+	ActionUID graphql.ID
+	Status    string
+}) (*actionResultResolver, error) {
 	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	cs := ctx.Value("commandservice").(*command.CommandService)
-	avatar := ctx.Value("image")
-
-	mr, err := args.CreateMemberChange.toCommandChange()
+	actionID, err := unmarshalUID(args.ActionUID)
 	if err != nil {
 		return nil, err
 	}
 
-	if mr.AvatarData != nil && avatar != nil {
-		mr.AvatarData.Avatar = avatar.([]byte)
-	}
-
-	res, groupID, err := cs.CreateMember(ctx, mr)
+	res, groupID, err := cs.ChangeActionStatus(ctx, actionID, models.ActionStatus(strings.ToLower(args.Status)))
 	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
 	if err == command.ErrValidation {
-		return &createMemberResultResolver{nil, nil, res, -1, nil}, nil
+		return &actionResultResolver{nil, nil, res, -1, nil}, nil
 	}
 
 	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
@@ -1883,38 +5139,29 @@ func (r *Resolver) CreateMember(ctx context.Context, args *struct {
 		return nil, err
 	}
 
-	var member *models.Member
-	if err == nil && res.MemberID != nil {
-		member, err = readdb.Member(ctx, tl.Number(), *res.MemberID)
-		if err != nil {
-			return nil, err
-		}
+	action, err := readdb.Action(ctx, tl.Number(), actionID)
+	if err != nil {
+		return nil, err
 	}
-	return &createMemberResultResolver{readdb, member, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+	return &actionResultResolver{readdb, action, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
 }
 
-func (r *Resolver) UpdateMember(ctx context.Context, args *struct {
-	UpdateMemberChange *UpdateMemberChange
-}) (*updateMemberResultResolver, error) {
+func (r *Resolver) CreateMeeting(ctx context.Context, args *struct {
+	CreateMeetingChange *CreateMeetingChange
+}) (*createMeetingResultResolver, error) {
 	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	cs := ctx.Value("commandservice").(*command.CommandService)
-	avatar := ctx.Value("image")
-
-	mr, err := args.UpdateMemberChange.toCommandChange()
+	mc, err := args.CreateMeetingChange.toCommandChange()
 	if err != nil {
 		return nil, err
 	}
 
-	if mr.AvatarData != nil && avatar != nil {
-		mr.AvatarData.Avatar = avatar.([]byte)
-	}
-
-	res, groupID, err := cs.UpdateMember(ctx, mr)
+	res, groupID, err := cs.CreateMeeting(ctx, mc)
 	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
 	if err == command.ErrValidation {
-		return &updateMemberResultResolver{nil, nil, res, -1, nil}, nil
+		return &createMeetingResultResolver{nil, nil, res, -1, nil}, nil
 	}
 
 	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
@@ -1931,136 +5178,145 @@ func (r *Resolver) UpdateMember(ctx context.Context, args *struct {
 		return nil, err
 	}
 
-	var member *models.Member
-	if err == nil {
-		member, err = readdb.Member(ctx, tl.Number(), mr.ID)
+	var meeting *models.Meeting
+	if res.MeetingID != nil {
+		meeting, err = readdb.Meeting(ctx, tl.Number(), *res.MeetingID)
 		if err != nil {
 			return nil, err
 		}
 	}
-	return &updateMemberResultResolver{readdb, member, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+	return &createMeetingResultResolver{readdb, meeting, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
 }
 
-func (r *Resolver) SetMemberPassword(ctx context.Context, args *struct {
-	MemberUID   graphql.ID
-	CurPassword *string
-	NewPassword string
-}) (*genericResultResolver, error) {
+func (r *Resolver) ReportMeetingValues(ctx context.Context, args *struct {
+	ReportMeetingValuesChange *ReportMeetingValuesChange
+}) (*meetingResultResolver, error) {
 	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	cs := ctx.Value("commandservice").(*command.CommandService)
-
-	memberID, err := unmarshalUID(args.MemberUID)
+	mc, err := args.ReportMeetingValuesChange.toCommandChange()
 	if err != nil {
 		return nil, err
 	}
-	var curPassword string
-	if args.CurPassword != nil {
-		curPassword = *args.CurPassword
-	}
 
-	res, groupID, err := cs.SetMemberPassword(ctx, memberID, curPassword, args.NewPassword)
+	res, groupID, err := cs.ReportMeetingValues(ctx, mc)
 	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
-
-	if err != command.ErrValidation {
-		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
-			return nil, err
-		}
+	if err == command.ErrValidation {
+		return &meetingResultResolver{nil, nil, res, -1, nil}, nil
 	}
 
-	return &genericResultResolver{res}, nil
-}
-
-func (r *Resolver) SetMemberMatchUID(ctx context.Context, args *struct {
-	MemberUID graphql.ID
-	MatchUID  string
-}) (*genericResultResolver, error) {
-	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
-	cs := ctx.Value("commandservice").(*command.CommandService)
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
 
-	memberID, err := unmarshalUID(args.MemberUID)
+	readdb, err := r.setupReadDB(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	res, groupID, err := cs.SetMemberMatchUID(ctx, memberID, args.MatchUID)
-	if err != nil && err != command.ErrValidation {
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
 		return nil, err
 	}
 
-	if err != command.ErrValidation {
-		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
-			return nil, err
-		}
+	meeting, err := readdb.Meeting(ctx, tl.Number(), mc.MeetingID)
+	if err != nil {
+		return nil, err
 	}
-
-	return &genericResultResolver{res}, nil
+	return &meetingResultResolver{readdb, meeting, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
 }
 
-func (r *Resolver) ImportMember(ctx context.Context, args *struct {
-	LoginName string
-}) (*memberResolver, error) {
+func (r *Resolver) CreateElection(ctx context.Context, args *struct {
+	CreateElectionChange *CreateElectionChange
+}) (*createElectionResultResolver, error) {
 	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	cs := ctx.Value("commandservice").(*command.CommandService)
-	memberProvider := ctx.Value("memberprovider").(auth.MemberProvider)
-
-	s, err := r.setupReadDB(ctx)
+	ec, err := args.CreateElectionChange.toCommandChange()
 	if err != nil {
 		return nil, err
 	}
 
-	sn := util.TimeLineNumber(0)
-	timeLineID, err := getTimeLineNumber(ctx, s, &sn)
-	if err != nil {
+	res, groupID, err := cs.CreateElection(ctx, ec)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &createElectionResultResolver{nil, nil, res, -1, nil}, nil
+	}
+
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
 		return nil, err
 	}
 
-	res, groupID, err := auth.ImportMember(ctx, s, cs, memberProvider, args.LoginName)
+	readdb, err := r.setupReadDB(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
 		return nil, err
 	}
 
-	readdb, err := r.setupReadDB(ctx)
+	var election *models.Election
+	if res.ElectionID != nil {
+		election, err = readdb.Election(ctx, tl.Number(), *res.ElectionID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &createElectionResultResolver{readdb, election, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+}
+
+func (r *Resolver) NominateElectionCandidate(ctx context.Context, args *struct {
+	ElectionUID graphql.ID
+	MemberUID   graphql.ID
+}) (*electionResultResolver, error) {
+	cs := ctx.Value("commandservice").(*command.CommandService)
+	electionID, err := unmarshalUID(args.ElectionUID)
 	if err != nil {
 		return nil, err
 	}
-
-	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	memberID, err := unmarshalUID(args.MemberUID)
 	if err != nil {
 		return nil, err
 	}
 
-	var member *models.Member
-	if err == nil && res.MemberID != nil {
-		member, err = readdb.Member(ctx, tl.Number(), *res.MemberID)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return &memberResolver{s, member, timeLineID, dataloader.NewDataLoaders(ctx, s)}, nil
+	res, groupID, err := cs.NominateElectionCandidate(ctx, electionID, memberID)
+	return r.electionResult(ctx, electionID, res, groupID, err)
 }
 
-func (r *Resolver) CreateTension(ctx context.Context, args *struct {
-	CreateTensionChange *CreateTensionChange
-}) (*createTensionResultResolver, error) {
-	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+func (r *Resolver) CompleteElection(ctx context.Context, args *struct {
+	ElectionUID        graphql.ID
+	MemberUID          graphql.ID
+	ElectionExpiration *graphql.Time
+}) (*electionResultResolver, error) {
 	cs := ctx.Value("commandservice").(*command.CommandService)
-	mr, err := args.CreateTensionChange.toCommandChange()
+	electionID, err := unmarshalUID(args.ElectionUID)
+	if err != nil {
+		return nil, err
+	}
+	memberID, err := unmarshalUID(args.MemberUID)
 	if err != nil {
 		return nil, err
 	}
+	var electionExpiration *time.Time
+	if args.ElectionExpiration != nil {
+		electionExpiration = &args.ElectionExpiration.Time
+	}
 
-	res, groupID, err := cs.CreateTension(ctx, mr)
+	res, groupID, err := cs.CompleteElection(ctx, electionID, memberID, electionExpiration)
+	return r.electionResult(ctx, electionID, res, groupID, err)
+}
+
+func (r *Resolver) electionResult(ctx context.Context, electionID util.ID, res *change.GenericResult, groupID util.ID, err error) (*electionResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
 	if err == command.ErrValidation {
-		return &createTensionResultResolver{nil, nil, res, -1, nil}, nil
+		return &electionResultResolver{nil, nil, res, -1, nil}, nil
 	}
 
 	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
@@ -2077,32 +5333,29 @@ func (r *Resolver) CreateTension(ctx context.Context, args *struct {
 		return nil, err
 	}
 
-	var tension *models.Tension
-	if err == nil && res.TensionID != nil {
-		tension, err = readdb.Tension(ctx, tl.Number(), *res.TensionID)
-		if err != nil {
-			return nil, err
-		}
+	election, err := readdb.Election(ctx, tl.Number(), electionID)
+	if err != nil {
+		return nil, err
 	}
-	return &createTensionResultResolver{readdb, tension, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+	return &electionResultResolver{readdb, election, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
 }
 
-func (r *Resolver) UpdateTension(ctx context.Context, args *struct {
-	UpdateTensionChange *UpdateTensionChange
-}) (*updateTensionResultResolver, error) {
+func (r *Resolver) CreateRoleTemplate(ctx context.Context, args *struct {
+	CreateRoleTemplateChange *CreateRoleTemplateChange
+}) (*createRoleTemplateResultResolver, error) {
 	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	cs := ctx.Value("commandservice").(*command.CommandService)
-	mr, err := args.UpdateTensionChange.toCommandChange()
+	rc, err := args.CreateRoleTemplateChange.toCommandChange()
 	if err != nil {
 		return nil, err
 	}
 
-	res, groupID, err := cs.UpdateTension(ctx, mr)
+	res, groupID, err := cs.CreateRoleTemplate(ctx, rc)
 	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
 	if err == command.ErrValidation {
-		return &updateTensionResultResolver{nil, nil, res, -1, nil}, nil
+		return &createRoleTemplateResultResolver{nil, nil, res, -1, nil}, nil
 	}
 
 	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
@@ -2119,32 +5372,32 @@ func (r *Resolver) UpdateTension(ctx context.Context, args *struct {
 		return nil, err
 	}
 
-	var tension *models.Tension
-	if err == nil {
-		tension, err = readdb.Tension(ctx, tl.Number(), mr.ID)
+	var roleTemplate *models.RoleTemplate
+	if res.RoleTemplateID != nil {
+		roleTemplate, err = readdb.RoleTemplate(ctx, tl.Number(), *res.RoleTemplateID)
 		if err != nil {
 			return nil, err
 		}
 	}
-	return &updateTensionResultResolver{readdb, tension, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+	return &createRoleTemplateResultResolver{readdb, roleTemplate, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
 }
 
-func (r *Resolver) CloseTension(ctx context.Context, args *struct {
-	CloseTensionChange *CloseTensionChange
-}) (*closeTensionResultResolver, error) {
+func (r *Resolver) UpdateRoleTemplate(ctx context.Context, args *struct {
+	UpdateRoleTemplateChange *UpdateRoleTemplateChange
+}) (*roleTemplateResultResolver, error) {
 	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	cs := ctx.Value("commandservice").(*command.CommandService)
-	mr, err := args.CloseTensionChange.toCommandChange()
+	rc, err := args.UpdateRoleTemplateChange.toCommandChange()
 	if err != nil {
 		return nil, err
 	}
 
-	res, groupID, err := cs.CloseTension(ctx, mr)
+	res, groupID, err := cs.UpdateRoleTemplate(ctx, rc)
 	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
 	if err == command.ErrValidation {
-		return &closeTensionResultResolver{nil, res, -1, nil}, nil
+		return &roleTemplateResultResolver{nil, nil, res, -1, nil}, nil
 	}
 
 	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
@@ -2161,24 +5414,25 @@ func (r *Resolver) CloseTension(ctx context.Context, args *struct {
 		return nil, err
 	}
 
-	return &closeTensionResultResolver{readdb, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
+	roleTemplate, err := readdb.RoleTemplate(ctx, tl.Number(), rc.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &roleTemplateResultResolver{readdb, roleTemplate, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
 }
 
-func (r *Resolver) CircleSetLeadLinkMember(ctx context.Context, args *struct {
-	RoleUID   graphql.ID
-	MemberUID graphql.ID
+func (r *Resolver) DeleteRoleTemplate(ctx context.Context, args *struct {
+	UID graphql.ID
 }) (*genericResultResolver, error) {
 	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	cs := ctx.Value("commandservice").(*command.CommandService)
-	roleUID, err := unmarshalUID(args.RoleUID)
-	if err != nil {
-		return nil, err
-	}
-	memberUID, err := unmarshalUID(args.MemberUID)
+
+	roleTemplateID, err := unmarshalUID(args.UID)
 	if err != nil {
 		return nil, err
 	}
-	res, groupID, err := cs.CircleSetLeadLinkMember(ctx, roleUID, memberUID)
+
+	res, groupID, err := cs.DeleteRoleTemplate(ctx, roleTemplateID)
 	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
@@ -2192,130 +5446,99 @@ func (r *Resolver) CircleSetLeadLinkMember(ctx context.Context, args *struct {
 	return &genericResultResolver{res}, nil
 }
 
-func (r *Resolver) CircleUnsetLeadLinkMember(ctx context.Context, args *struct {
-	RoleUID graphql.ID
-}) (*genericResultResolver, error) {
+func (r *Resolver) CreateWebhook(ctx context.Context, args *struct {
+	CreateWebhookChange *CreateWebhookChange
+}) (*createWebhookResultResolver, error) {
 	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	cs := ctx.Value("commandservice").(*command.CommandService)
-	roleUID, err := unmarshalUID(args.RoleUID)
+	rc, err := args.CreateWebhookChange.toCommandChange()
 	if err != nil {
 		return nil, err
 	}
-	res, groupID, err := cs.CircleUnsetLeadLinkMember(ctx, roleUID)
+
+	res, groupID, err := cs.CreateWebhook(ctx, rc)
 	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
-
-	if err != command.ErrValidation {
-		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
-			return nil, err
-		}
+	if err == command.ErrValidation {
+		return &createWebhookResultResolver{nil, nil, res, -1}, nil
 	}
 
-	return &genericResultResolver{res}, nil
-}
-
-func (r *Resolver) CircleSetCoreRoleMember(ctx context.Context, args *struct {
-	RoleType           string
-	RoleUID            graphql.ID
-	MemberUID          graphql.ID
-	ElectionExpiration *graphql.Time
-}) (*genericResultResolver, error) {
-	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
-	cs := ctx.Value("commandservice").(*command.CommandService)
-	roleUID, err := unmarshalUID(args.RoleUID)
-	if err != nil {
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
 		return nil, err
 	}
-	memberUID, err := unmarshalUID(args.MemberUID)
+
+	readdb, err := r.setupReadDB(ctx)
 	if err != nil {
 		return nil, err
 	}
-	var time *time.Time
-	if args.ElectionExpiration != nil {
-		time = &args.ElectionExpiration.Time
-	}
-	res, groupID, err := cs.CircleSetCoreRoleMember(ctx, models.RoleTypeFromString(args.RoleType), roleUID, memberUID, time)
-	if err != nil && err != command.ErrValidation {
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
 		return nil, err
 	}
 
-	if err != command.ErrValidation {
-		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+	var webhook *models.Webhook
+	if res.WebhookID != nil {
+		webhook, err = readdb.Webhook(ctx, tl.Number(), *res.WebhookID)
+		if err != nil {
 			return nil, err
 		}
 	}
-
-	return &genericResultResolver{res}, nil
+	return &createWebhookResultResolver{readdb, webhook, res, tl.Number()}, nil
 }
 
-func (r *Resolver) CircleUnsetCoreRoleMember(ctx context.Context, args *struct {
-	RoleType string
-	RoleUID  graphql.ID
-}) (*genericResultResolver, error) {
+func (r *Resolver) UpdateWebhook(ctx context.Context, args *struct {
+	UpdateWebhookChange *UpdateWebhookChange
+}) (*webhookResultResolver, error) {
 	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	cs := ctx.Value("commandservice").(*command.CommandService)
-	roleUID, err := unmarshalUID(args.RoleUID)
+	rc, err := args.UpdateWebhookChange.toCommandChange()
 	if err != nil {
 		return nil, err
 	}
-	res, groupID, err := cs.CircleUnsetCoreRoleMember(ctx, models.RoleTypeFromString(args.RoleType), roleUID)
+
+	res, groupID, err := cs.UpdateWebhook(ctx, rc)
 	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
-
-	if err != command.ErrValidation {
-		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
-			return nil, err
-		}
+	if err == command.ErrValidation {
+		return &webhookResultResolver{nil, nil, res, -1}, nil
 	}
 
-	return &genericResultResolver{res}, nil
-}
-
-func (r *Resolver) CircleAddDirectMember(ctx context.Context, args *struct {
-	RoleUID   graphql.ID
-	MemberUID graphql.ID
-}) (*genericResultResolver, error) {
-	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
-	cs := ctx.Value("commandservice").(*command.CommandService)
-	roleUID, err := unmarshalUID(args.RoleUID)
-	if err != nil {
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
 		return nil, err
 	}
-	memberUID, err := unmarshalUID(args.MemberUID)
+
+	readdb, err := r.setupReadDB(ctx)
 	if err != nil {
 		return nil, err
 	}
-	res, groupID, err := cs.CircleAddDirectMember(ctx, roleUID, memberUID)
-	if err != nil && err != command.ErrValidation {
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
 		return nil, err
 	}
 
-	if err != command.ErrValidation {
-		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
-			return nil, err
-		}
+	webhook, err := readdb.Webhook(ctx, tl.Number(), rc.ID)
+	if err != nil {
+		return nil, err
 	}
-
-	return &genericResultResolver{res}, nil
+	return &webhookResultResolver{readdb, webhook, res, tl.Number()}, nil
 }
 
-func (r *Resolver) CircleRemoveDirectMember(ctx context.Context, args *struct {
-	RoleUID   graphql.ID
-	MemberUID graphql.ID
+func (r *Resolver) DeleteWebhook(ctx context.Context, args *struct {
+	UID graphql.ID
 }) (*genericResultResolver, error) {
 	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	cs := ctx.Value("commandservice").(*command.CommandService)
-	roleUID, err := unmarshalUID(args.RoleUID)
-	if err != nil {
-		return nil, err
-	}
-	memberUID, err := unmarshalUID(args.MemberUID)
+
+	webhookID, err := unmarshalUID(args.UID)
 	if err != nil {
 		return nil, err
 	}
-	res, groupID, err := cs.CircleRemoveDirectMember(ctx, roleUID, memberUID)
+
+	res, groupID, err := cs.DeleteWebhook(ctx, webhookID)
 	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
@@ -2329,51 +5552,60 @@ func (r *Resolver) CircleRemoveDirectMember(ctx context.Context, args *struct {
 	return &genericResultResolver{res}, nil
 }
 
-func (r *Resolver) RoleAddMember(ctx context.Context, args *struct {
-	RoleUID      graphql.ID
-	MemberUID    graphql.ID
-	Focus        *string
-	NoCoreMember bool
-}) (*genericResultResolver, error) {
+func (r *Resolver) InstantiateRoleTemplate(ctx context.Context, args *struct {
+	InstantiateRoleTemplateChange *InstantiateRoleTemplateChange
+}) (*createRoleResultResolver, error) {
 	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	cs := ctx.Value("commandservice").(*command.CommandService)
-	roleUID, err := unmarshalUID(args.RoleUID)
+	rc, err := args.InstantiateRoleTemplateChange.toCommandChange()
 	if err != nil {
 		return nil, err
 	}
-	memberUID, err := unmarshalUID(args.MemberUID)
+
+	res, groupID, err := cs.InstantiateRoleTemplate(ctx, rc)
+	if err != nil && err != command.ErrValidation {
+		return nil, err
+	}
+	if err == command.ErrValidation {
+		return &createRoleResultResolver{nil, nil, res, -1, nil}, nil
+	}
+
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	readdb, err := r.setupReadDB(ctx)
 	if err != nil {
 		return nil, err
 	}
-	res, groupID, err := cs.RoleAddMember(ctx, roleUID, memberUID, args.Focus, args.NoCoreMember)
-	if err != nil && err != command.ErrValidation {
+
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
+	if err != nil {
 		return nil, err
 	}
 
-	if err != command.ErrValidation {
-		if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+	var role *models.Role
+	if res.RoleID != nil {
+		role, err = readdb.Role(ctx, tl.Number(), *res.RoleID)
+		if err != nil {
 			return nil, err
 		}
 	}
-
-	return &genericResultResolver{res}, nil
+	return &createRoleResultResolver{readdb, role, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
 }
 
-func (r *Resolver) RoleRemoveMember(ctx context.Context, args *struct {
-	RoleUID   graphql.ID
-	MemberUID graphql.ID
+func (r *Resolver) UnlinkRoleTemplateInstance(ctx context.Context, args *struct {
+	RoleUID graphql.ID
 }) (*genericResultResolver, error) {
 	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
 	cs := ctx.Value("commandservice").(*command.CommandService)
-	roleUID, err := unmarshalUID(args.RoleUID)
-	if err != nil {
-		return nil, err
-	}
-	memberUID, err := unmarshalUID(args.MemberUID)
+
+	roleID, err := unmarshalUID(args.RoleUID)
 	if err != nil {
 		return nil, err
 	}
-	res, groupID, err := cs.RoleRemoveMember(ctx, roleUID, memberUID)
+
+	res, groupID, err := cs.UnlinkRoleTemplateInstance(ctx, roleID)
 	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
@@ -2387,48 +5619,48 @@ func (r *Resolver) RoleRemoveMember(ctx context.Context, args *struct {
 	return &genericResultResolver{res}, nil
 }
 
-func (r *Resolver) Search(ctx context.Context, args *struct {
-	Query string
-}) (*searchResultResolver, error) {
-	se := ctx.Value("searchEngine").(*search.SearchEngine)
+func (r *Resolver) ProposeRoleTemplateUpdate(ctx context.Context, args *struct {
+	UID graphql.ID
+}) (*proposeRoleTemplateUpdateResultResolver, error) {
+	readDBListener := ctx.Value("readdblistener").(readdb.ReadDBListener)
+	cs := ctx.Value("commandservice").(*command.CommandService)
 
-	s, err := r.setupReadDB(ctx)
+	roleTemplateID, err := unmarshalUID(args.UID)
 	if err != nil {
 		return nil, err
 	}
-	res, err := se.Search(args.Query)
-	if err != nil {
+
+	res, groupID, err := cs.ProposeRoleTemplateUpdate(ctx, roleTemplateID)
+	if err != nil && err != command.ErrValidation {
 		return nil, err
 	}
-	return &searchResultResolver{s, res, dataloader.NewDataLoaders(ctx, s)}, nil
-}
-
-type genericResultResolver struct {
-	res *change.GenericResult
-}
-
-func (r *genericResultResolver) HasErrors() bool {
-	return r.res.HasErrors
-}
+	if err == command.ErrValidation {
+		return &proposeRoleTemplateUpdateResultResolver{nil, nil, res, -1, nil}, nil
+	}
 
-func (r *genericResultResolver) GenericError() *string {
-	return errorToStringP(r.res.GenericError)
-}
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
 
-func (r *Resolver) setupReadDB(ctx context.Context) (readdb.ReadDBService, error) {
-	utx := ctx.Value("utx").(*db.Tx)
-	config := ctx.Value("config").(*config.Config)
-	if err := utx.Start(); err != nil {
+	readdb, err := r.setupReadDB(ctx)
+	if err != nil {
 		return nil, err
 	}
 
-	s, err := readdb.NewReadDBService(utx)
+	tl, err := readdb.TimeLineForGroupID(ctx, groupID)
 	if err != nil {
 		return nil, err
 	}
 
-	if config != nil && config.AdminMember != "" {
-		s.SetForceAdminMemberUserName(config.AdminMember)
+	proposals := []*models.Proposal{}
+	for _, proposalID := range res.ProposalsIDs {
+		proposal, err := readdb.Proposal(ctx, tl.Number(), proposalID)
+		if err != nil {
+			return nil, err
+		}
+		if proposal != nil {
+			proposals = append(proposals, proposal)
+		}
 	}
-	return s, nil
+	return &proposeRoleTemplateUpdateResultResolver{readdb, proposals, res, tl.Number(), dataloader.NewDataLoaders(ctx, readdb)}, nil
 }
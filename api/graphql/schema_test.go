@@ -3151,90 +3151,162 @@ func TestCreateMember(t *testing.T) {
 	})
 }
 
-func TestCreateMemberExistingEmail(t *testing.T) {
+func TestDeactivateMember(t *testing.T) {
+	// user03
 	RunTests(t, initBasic, []*Test{
 		{
 			Query: `
-			mutation CreateMember($createMemberChange: CreateMemberChange!) {
-				createMember(createMemberChange: $createMemberChange) {
-					hasErrors
+				mutation DeactivateMember($memberUID: ID!) {
+					deactivateMember(memberUID: $memberUID, removeAssignments: true) {
+						assignments {
+							roles { role { name } }
+							leadLinkCircles { name }
+							coreRoles { role { name } }
+							directCircles { name }
+						}
+						hasErrors
+						genericError
+					}
+				}
+			`,
+			Variables: `
+			{
+				"memberUID": "58170eb6-8600-5bfd-8018-7bd75e60b1fd"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"deactivateMember": {
+					"assignments": {
+						"roles": [],
+						"leadLinkCircles": [
+							{ "name": "rootRole-circle02" }
+						],
+						"coreRoles": [
+							{ "role": { "name": "Secretary" } }
+						],
+						"directCircles": []
+					},
+					"hasErrors": false,
+					"genericError": null
 				}
 			}
 			`,
+		},
+		{
+			Query: `
+				query Member($memberUID: ID!) {
+					member(uid: $memberUID) {
+						userName
+						isDeactivated
+						assignments {
+							roles { role { name } }
+							leadLinkCircles { name }
+							coreRoles { role { name } }
+							directCircles { name }
+						}
+					}
+				}
+			`,
 			Variables: `
 			{
-				"createMemberChange": {
-					"userName": "newuser01",
-					"fullName": "newuser01",
-					"email": "user01@example.com",
-					"password": "password"
+				"memberUID": "58170eb6-8600-5bfd-8018-7bd75e60b1fd"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"member": {
+					"userName": "user03",
+					"isDeactivated": true,
+					"assignments": {
+						"roles": [],
+						"leadLinkCircles": [],
+						"coreRoles": [],
+						"directCircles": []
+					}
+				}
+			}
+			`,
+		},
+		{
+			Query: `
+				mutation CircleAddDirectMember($roleUID: ID!, $memberUID: ID!) {
+					circleAddDirectMember(roleUID: $roleUID, memberUID: $memberUID) {
+						hasErrors
+						genericError
+					}
 				}
+			`,
+			Variables: `
+			{
+				"roleUID": "LUJMgnvykhzsX6Edb656JL",
+				"memberUID": "58170eb6-8600-5bfd-8018-7bd75e60b1fd"
 			}
 			`,
 			ExpectedResult: `
 			{
-				"createMember": {
-					"hasErrors": true
+				"circleAddDirectMember": {
+					"hasErrors": true,
+					"genericError": "member with id 58170eb6-8600-5bfd-8018-7bd75e60b1fd is deactivated"
 				}
 			}
 			`,
 		},
-	})
-}
-
-func TestConcurrentCreateMemberSameUsername(t *testing.T) {
-	runTests(t, initBasic, []*Test{
 		{
 			Query: `
-			mutation CreateMember($createMemberChange: CreateMemberChange!) {
-				createMember(createMemberChange: $createMemberChange) {
+			mutation CreateProject($createProjectChange: CreateProjectChange!) {
+				createProject(createProjectChange: $createProjectChange) {
 					hasErrors
+					genericError
 				}
 			}
 			`,
 			Variables: `
 			{
-				"createMemberChange": {
-					"userName": "newuser01",
-					"fullName": "newuser01",
-					"email": "newuser01@example.com",
-					"password": "password"
+				"createProjectChange": {
+					"roleUID": "0f2af650-b98b-57f3-9dcb-bb8bd8bf6479",
+					"memberUID": "58170eb6-8600-5bfd-8018-7bd75e60b1fd",
+					"title": "project01"
 				}
 			}
 			`,
 			ExpectedResult: `
 			{
-				"createMember": {
-					"hasErrors": false
+				"createProject": {
+					"hasErrors": true,
+					"genericError": "member with id 58170eb6-8600-5bfd-8018-7bd75e60b1fd is deactivated"
 				}
 			}
 			`,
 		},
 		{
 			Query: `
-			mutation CreateMember($createMemberChange: CreateMemberChange!) {
-				createMember(createMemberChange: $createMemberChange) {
-					hasErrors
+				mutation ReactivateMember($memberUID: ID!) {
+					reactivateMember(memberUID: $memberUID) {
+						hasErrors
+						genericError
+					}
 				}
-			}
 			`,
 			Variables: `
 			{
-				"createMemberChange": {
-					"userName": "newuser01",
-					"fullName": "newuser01",
-					"email": "anothernewuser01@example.com",
-					"password": "password"
+				"memberUID": "58170eb6-8600-5bfd-8018-7bd75e60b1fd"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"reactivateMember": {
+					"hasErrors": false,
+					"genericError": null
 				}
 			}
 			`,
-			Error: fmt.Errorf(`graphql: username "newuser01" already reserved`),
 		},
-	}, true, false)
+	})
 }
 
-func TestConcurrentCreateMemberSameEmail(t *testing.T) {
-	runTests(t, initBasic, []*Test{
+func TestCreateMemberExistingEmail(t *testing.T) {
+	RunTests(t, initBasic, []*Test{
 		{
 			Query: `
 			mutation CreateMember($createMemberChange: CreateMemberChange!) {
@@ -3248,7 +3320,7 @@ func TestConcurrentCreateMemberSameEmail(t *testing.T) {
 				"createMemberChange": {
 					"userName": "newuser01",
 					"fullName": "newuser01",
-					"email": "newuser01@example.com",
+					"email": "user01@example.com",
 					"password": "password"
 				}
 			}
@@ -3256,186 +3328,257 @@ func TestConcurrentCreateMemberSameEmail(t *testing.T) {
 			ExpectedResult: `
 			{
 				"createMember": {
-					"hasErrors": false
+					"hasErrors": true
 				}
 			}
 			`,
 		},
+	})
+}
+
+func initSessions(ctx context.Context, t *testing.T, rootRoleID util.ID, readDBListener readdb.ReadDBListener, commandService *command.CommandService) {
+	initBasic(ctx, t, rootRoleID, readDBListener, commandService)
+
+	// user03
+	memberID := util.IDFromStringOrNil("58170eb6-8600-5bfd-8018-7bd75e60b1fd")
+	_, refreshToken, groupID, err := commandService.CreateSession(ctx, memberID, time.Now().Add(1*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := readDBListener.WaitGroupID(ctx, groupID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, groupID, err = commandService.RefreshSession(ctx, refreshToken); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := readDBListener.WaitGroupID(ctx, groupID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSessionsNoTimeLines(t *testing.T) {
+	sessionTimeLinesTest := &Test{
+		Query: `
+			query timeLines($aggregateType: String) {
+				timeLines(first: 10, aggregateType: $aggregateType) {
+					edges {
+						timeLine {
+							id
+						}
+					}
+					hasMoreData
+				}
+			}
+		`,
+		Variables: `
+			{
+				"aggregateType": "session"
+			}
+		`,
+		ExpectedResult: `
+			{
+				"timeLines": {
+					"edges": [],
+					"hasMoreData": false
+				}
+			}
+		`,
+	}
+
+	RunTests(t, initSessions, []*Test{
+		sessionTimeLinesTest,
 		{
 			Query: `
-			mutation CreateMember($createMemberChange: CreateMemberChange!) {
-				createMember(createMemberChange: $createMemberChange) {
+			mutation RevokeMemberSessions($memberUID: ID!) {
+				revokeMemberSessions(memberUID: $memberUID) {
 					hasErrors
+					genericError
 				}
 			}
 			`,
 			Variables: `
 			{
-				"createMemberChange": {
-					"userName": "newuser02",
-					"fullName": "newuser02",
-					"email": "newuser01@example.com",
-					"password": "password"
+				"memberUID": "58170eb6-8600-5bfd-8018-7bd75e60b1fd"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"revokeMemberSessions": {
+					"hasErrors": false,
+					"genericError": null
 				}
 			}
 			`,
-			Error: fmt.Errorf(`graphql: email "newuser01@example.com" already reserved`),
 		},
-	}, true, false)
+		sessionTimeLinesTest,
+	})
 }
 
-func TestUnsetDeletedCircleFromTension(t *testing.T) {
+func TestServiceAccountAPITokens(t *testing.T) {
 	RunTests(t, initBasic, []*Test{
-		// Add member admin to role rootRole-circle01-role01
+		// a service account cannot have a password
 		{
 			Query: `
-			mutation RoleAddMember($roleUID: ID!, $memberUID: ID!) {
-				roleAddMember(roleUID: $roleUID, memberUID: $memberUID, focus: $focus) {
+			mutation CreateMember($createMemberChange: CreateMemberChange!) {
+				createMember(createMemberChange: $createMemberChange) {
 					hasErrors
+					createMemberChangeErrors {
+						password
+					}
 				}
 			}
 			`,
 			Variables: `
 			{
-				"roleUID": "0f2af650-b98b-57f3-9dcb-bb8bd8bf6479",
-				"memberUID": "bace0701-15e3-5144-97c5-47487d543032",
-				"focus": "focus01"
+				"createMemberChange": {
+					"isServiceAccount": true,
+					"userName": "serviceaccount01",
+					"fullName": "serviceaccount01",
+					"email": "serviceaccount01@example.com",
+					"password": "password"
+				}
 			}
 			`,
 			ExpectedResult: `
 			{
-				"roleAddMember": {
-					"hasErrors": false
+				"createMember": {
+					"hasErrors": true,
+					"createMemberChangeErrors": {
+						"password": "service accounts cannot have a password"
+					}
 				}
 			}
 			`,
 		},
-		// Create tension as member admin on circle rootRole-circle01
 		{
 			Query: `
-			mutation CreateTension($createTensionChange: CreateTensionChange!) {
-				createTension(createTensionChange: $createTensionChange) {
+			mutation CreateMember($createMemberChange: CreateMemberChange!) {
+				createMember(createMemberChange: $createMemberChange) {
+					member {
+						userName
+						isServiceAccount
+					}
 					hasErrors
 				}
 			}
 			`,
 			Variables: `
 			{
-				"createTensionChange": {
-					"title": "newtension",
-					"description": "newtension",
-					"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c"
+				"createMemberChange": {
+					"isServiceAccount": true,
+					"userName": "serviceaccount01",
+					"fullName": "serviceaccount01",
+					"email": "serviceaccount01@example.com",
+					"password": ""
 				}
 			}
 			`,
 			ExpectedResult: `
 			{
-				"createTension": {
+				"createMember": {
+					"member": {
+						"userName": "serviceaccount01",
+						"isServiceAccount": true
+					},
 					"hasErrors": false
 				}
 			}
 			`,
 		},
-		// Check that the tension has been created with the right circle
-		// assigned
 		{
 			Query: `
-			query tensionViewerQuery {
-				viewer {
-					member {
-						tensions {
-							uid
-							title
-							role {
-								uid
-								name
-							}
-							closed
-						}
+			mutation CreateAPIToken($createAPITokenChange: CreateAPITokenChange!) {
+				createAPIToken(createAPITokenChange: $createAPITokenChange) {
+					apiToken {
+						name
+						scope
+						expiration
 					}
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createAPITokenChange": {
+					"memberUID": "9c4e92d8-5042-53ce-b709-f193d05d2e80",
+					"name": "token01",
+					"scope": "TENSIONS",
+					"expiration": "2100-01-01T00:00:00Z"
 				}
 			}
 			`,
 			ExpectedResult: `
 			{
-				"viewer": {
-					"member": {
-						"tensions": [
-						{
-							"closed": false,
-							"role": {
-								"name": "rootRole-circle01",
-								"uid": "LUJMgnvykhzsX6Edb656JL"
-							},
-							"title": "newtension",
-							"uid": "YiAJCY5FDuKXisdSfcDgQY"
-						}
-						]
-					}
+				"createAPIToken": {
+					"apiToken": {
+						"name": "token01",
+						"scope": "tensions",
+						"expiration": "2100-01-01T00:00:00Z"
+					},
+					"hasErrors": false,
+					"genericError": null
 				}
 			}
 			`,
 		},
-		// Delete rootRole-circle01
+		// the expiration must be in the future
 		{
 			Query: `
-			mutation CircleDeleteChildRole($roleUID: ID!, $deleteRoleChange: DeleteRoleChange!) {
-				circleDeleteChildRole(roleUID: $roleUID, deleteRoleChange: $deleteRoleChange) {
+			mutation CreateAPIToken($createAPITokenChange: CreateAPITokenChange!) {
+				createAPIToken(createAPITokenChange: $createAPITokenChange) {
+					apiToken {
+						name
+					}
+					token
 					hasErrors
+					genericError
 				}
 			}
 			`,
 			Variables: `
 			{
-				"roleUID": "FDi26qza4rFLLTLdbqzpsd",
-				"deleteRoleChange": {
-					"uid": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c"
+				"createAPITokenChange": {
+					"name": "token02",
+					"scope": "READONLY",
+					"expiration": "2000-01-01T00:00:00Z"
 				}
 			}
 			`,
 			ExpectedResult: `
 			{
-				"circleDeleteChildRole": {
-					"hasErrors": false
+				"createAPIToken": {
+					"apiToken": null,
+					"token": null,
+					"hasErrors": true,
+					"genericError": "api token expiration must be in the future"
 				}
 			}
 			`,
 		},
-		// Check that the tension has been changed unsetting the circle since it
-		// had been deleted
 		{
-			// Wait for the deletedRoleTensionHandler to alter the tension
-			StartSleep: 1 * time.Second,
 			Query: `
-			query tensionViewerQuery {
-				viewer {
-					member {
-						tensions {
-							uid
-							title
-							role {
-								uid
-								name
-							}
-							closed
-						}
+			query Member($memberUID: ID!) {
+				member(uid: $memberUID) {
+					apiTokens {
+						name
+						scope
 					}
 				}
 			}
 			`,
+			Variables: `
+			{
+				"memberUID": "9c4e92d8-5042-53ce-b709-f193d05d2e80"
+			}
+			`,
 			ExpectedResult: `
 			{
-				"viewer": {
-					"member": {
-						"tensions": [
-						{
-							"closed": false,
-							"role": null,
-							"title": "newtension",
-							"uid": "YiAJCY5FDuKXisdSfcDgQY"
-						}
-						]
-					}
+				"member": {
+					"apiTokens": [
+						{ "name": "token01", "scope": "tensions" }
+					]
 				}
 			}
 			`,
@@ -3443,7 +3586,109 @@ func TestUnsetDeletedCircleFromTension(t *testing.T) {
 	})
 }
 
-func TestUnsetCircleChangedToNormalRoleFromTension(t *testing.T) {
+func TestConcurrentCreateMemberSameUsername(t *testing.T) {
+	runTests(t, initBasic, []*Test{
+		{
+			Query: `
+			mutation CreateMember($createMemberChange: CreateMemberChange!) {
+				createMember(createMemberChange: $createMemberChange) {
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createMemberChange": {
+					"userName": "newuser01",
+					"fullName": "newuser01",
+					"email": "newuser01@example.com",
+					"password": "password"
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"createMember": {
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		{
+			Query: `
+			mutation CreateMember($createMemberChange: CreateMemberChange!) {
+				createMember(createMemberChange: $createMemberChange) {
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createMemberChange": {
+					"userName": "newuser01",
+					"fullName": "newuser01",
+					"email": "anothernewuser01@example.com",
+					"password": "password"
+				}
+			}
+			`,
+			Error: fmt.Errorf(`graphql: username "newuser01" already reserved`),
+		},
+	}, true, false)
+}
+
+func TestConcurrentCreateMemberSameEmail(t *testing.T) {
+	runTests(t, initBasic, []*Test{
+		{
+			Query: `
+			mutation CreateMember($createMemberChange: CreateMemberChange!) {
+				createMember(createMemberChange: $createMemberChange) {
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createMemberChange": {
+					"userName": "newuser01",
+					"fullName": "newuser01",
+					"email": "newuser01@example.com",
+					"password": "password"
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"createMember": {
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		{
+			Query: `
+			mutation CreateMember($createMemberChange: CreateMemberChange!) {
+				createMember(createMemberChange: $createMemberChange) {
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createMemberChange": {
+					"userName": "newuser02",
+					"fullName": "newuser02",
+					"email": "newuser01@example.com",
+					"password": "password"
+				}
+			}
+			`,
+			Error: fmt.Errorf(`graphql: email "newuser01@example.com" already reserved`),
+		},
+	}, true, false)
+}
+
+func TestUnsetDeletedCircleFromTension(t *testing.T) {
 	RunTests(t, initBasic, []*Test{
 		// Add member admin to role rootRole-circle01-role01
 		{
@@ -3535,48 +3780,33 @@ func TestUnsetCircleChangedToNormalRoleFromTension(t *testing.T) {
 			}
 			`,
 		},
-		// Make rootRole-circle01 a normal role
+		// Delete rootRole-circle01
 		{
 			Query: `
-			mutation CircleUpdateChildRole($roleUID: ID!, $updateRoleChange: UpdateRoleChange!) {
-				circleUpdateChildRole(roleUID: $roleUID, updateRoleChange: $updateRoleChange) {
+			mutation CircleDeleteChildRole($roleUID: ID!, $deleteRoleChange: DeleteRoleChange!) {
+				circleDeleteChildRole(roleUID: $roleUID, deleteRoleChange: $deleteRoleChange) {
 					hasErrors
-					role {
-						roleType
-						uid
-						name
-						roles {
-							name
-						}
-					}
 				}
 			}
 			`,
 			Variables: `
 			{
 				"roleUID": "FDi26qza4rFLLTLdbqzpsd",
-				"updateRoleChange": {
-					"uid": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
-					"makeRole": true
+				"deleteRoleChange": {
+					"uid": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c"
 				}
 			}
 			`,
 			ExpectedResult: `
 			{
-				"circleUpdateChildRole": {
-					"hasErrors": false,
-					"role": {
-						"name": "rootRole-circle01",
-						"roleType": "normal",
-						"uid": "LUJMgnvykhzsX6Edb656JL",
-						"roles": []
-					}
+				"circleDeleteChildRole": {
+					"hasErrors": false
 				}
 			}
 			`,
 		},
 		// Check that the tension has been changed unsetting the circle since it
-		// had been changed to a normal role
+		// had been deleted
 		{
 			// Wait for the deletedRoleTensionHandler to alter the tension
 			StartSleep: 1 * time.Second,
@@ -3616,3 +3846,3576 @@ func TestUnsetCircleChangedToNormalRoleFromTension(t *testing.T) {
 		},
 	})
 }
+
+func TestUnsetCircleChangedToNormalRoleFromTension(t *testing.T) {
+	RunTests(t, initBasic, []*Test{
+		// Add member admin to role rootRole-circle01-role01
+		{
+			Query: `
+			mutation RoleAddMember($roleUID: ID!, $memberUID: ID!) {
+				roleAddMember(roleUID: $roleUID, memberUID: $memberUID, focus: $focus) {
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "0f2af650-b98b-57f3-9dcb-bb8bd8bf6479",
+				"memberUID": "bace0701-15e3-5144-97c5-47487d543032",
+				"focus": "focus01"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"roleAddMember": {
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		// Create tension as member admin on circle rootRole-circle01
+		{
+			Query: `
+			mutation CreateTension($createTensionChange: CreateTensionChange!) {
+				createTension(createTensionChange: $createTensionChange) {
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createTensionChange": {
+					"title": "newtension",
+					"description": "newtension",
+					"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c"
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"createTension": {
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		// Check that the tension has been created with the right circle
+		// assigned
+		{
+			Query: `
+			query tensionViewerQuery {
+				viewer {
+					member {
+						tensions {
+							uid
+							title
+							role {
+								uid
+								name
+							}
+							closed
+						}
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"viewer": {
+					"member": {
+						"tensions": [
+						{
+							"closed": false,
+							"role": {
+								"name": "rootRole-circle01",
+								"uid": "LUJMgnvykhzsX6Edb656JL"
+							},
+							"title": "newtension",
+							"uid": "YiAJCY5FDuKXisdSfcDgQY"
+						}
+						]
+					}
+				}
+			}
+			`,
+		},
+		// Make rootRole-circle01 a normal role
+		{
+			Query: `
+			mutation CircleUpdateChildRole($roleUID: ID!, $updateRoleChange: UpdateRoleChange!) {
+				circleUpdateChildRole(roleUID: $roleUID, updateRoleChange: $updateRoleChange) {
+					hasErrors
+					role {
+						roleType
+						uid
+						name
+						roles {
+							name
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "FDi26qza4rFLLTLdbqzpsd",
+				"updateRoleChange": {
+					"uid": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+					"makeRole": true
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"circleUpdateChildRole": {
+					"hasErrors": false,
+					"role": {
+						"name": "rootRole-circle01",
+						"roleType": "normal",
+						"uid": "LUJMgnvykhzsX6Edb656JL",
+						"roles": []
+					}
+				}
+			}
+			`,
+		},
+		// Check that the tension has been changed unsetting the circle since it
+		// had been changed to a normal role
+		{
+			// Wait for the deletedRoleTensionHandler to alter the tension
+			StartSleep: 1 * time.Second,
+			Query: `
+			query tensionViewerQuery {
+				viewer {
+					member {
+						tensions {
+							uid
+							title
+							role {
+								uid
+								name
+							}
+							closed
+						}
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"viewer": {
+					"member": {
+						"tensions": [
+						{
+							"closed": false,
+							"role": null,
+							"title": "newtension",
+							"uid": "YiAJCY5FDuKXisdSfcDgQY"
+						}
+						]
+					}
+				}
+			}
+			`,
+		},
+	})
+}
+
+func TestTensionTriage(t *testing.T) {
+	RunTests(t, initBasic, []*Test{
+		// Add member admin to role rootRole-circle01-role01
+		{
+			Query: `
+			mutation RoleAddMember($roleUID: ID!, $memberUID: ID!) {
+				roleAddMember(roleUID: $roleUID, memberUID: $memberUID, focus: $focus) {
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "0f2af650-b98b-57f3-9dcb-bb8bd8bf6479",
+				"memberUID": "bace0701-15e3-5144-97c5-47487d543032",
+				"focus": "focus01"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"roleAddMember": {
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		// Create tension as member admin on circle rootRole-circle01
+		{
+			Query: `
+			mutation CreateTension($createTensionChange: CreateTensionChange!) {
+				createTension(createTensionChange: $createTensionChange) {
+					hasErrors
+					tension {
+						uid
+						status
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createTensionChange": {
+					"title": "newtension",
+					"description": "newtension",
+					"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c"
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"createTension": {
+					"hasErrors": false,
+					"tension": {
+						"status": "new",
+						"uid": "YiAJCY5FDuKXisdSfcDgQY"
+					}
+				}
+			}
+			`,
+		},
+		// Put the tension in agenda
+		{
+			Query: `
+			mutation ChangeTensionStatus($tensionUID: ID!) {
+				changeTensionStatus(tensionUID: $tensionUID, status: INAGENDA) {
+					hasErrors
+					genericError
+					tension {
+						status
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"tensionUID": "YiAJCY5FDuKXisdSfcDgQY"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"changeTensionStatus": {
+					"genericError": null,
+					"hasErrors": false,
+					"tension": {
+						"status": "inagenda"
+					}
+				}
+			}
+			`,
+		},
+		// A final status cannot be directly set
+		{
+			Query: `
+			mutation ChangeTensionStatus($tensionUID: ID!) {
+				changeTensionStatus(tensionUID: $tensionUID, status: RESOLVED) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"tensionUID": "YiAJCY5FDuKXisdSfcDgQY"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"changeTensionStatus": {
+					"genericError": "tension status \"resolved\" can be set only resolving or closing the tension",
+					"hasErrors": true
+				}
+			}
+			`,
+		},
+		// Assign the tension to user05, a circle direct member
+		{
+			Query: `
+			mutation ChangeTensionAssignee($tensionUID: ID!, $memberUID: ID) {
+				changeTensionAssignee(tensionUID: $tensionUID, memberUID: $memberUID) {
+					hasErrors
+					genericError
+					tension {
+						assignee {
+							userName
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"tensionUID": "YiAJCY5FDuKXisdSfcDgQY",
+				"memberUID": "1699e266-8401-558e-b9f5-7e2d7f965b82"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"changeTensionAssignee": {
+					"genericError": null,
+					"hasErrors": false,
+					"tension": {
+						"assignee": {
+							"userName": "user05"
+						}
+					}
+				}
+			}
+			`,
+		},
+		// Resolve the tension as an action
+		{
+			Query: `
+			mutation ResolveTension($resolveTensionChange: ResolveTensionChange!) {
+				resolveTension(resolveTensionChange: $resolveTensionChange) {
+					hasErrors
+					genericError
+					tension {
+						status
+						closed
+						outcome {
+							outcomeType
+							referenceUID
+							description
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"resolveTensionChange": {
+					"uid": "YiAJCY5FDuKXisdSfcDgQY",
+					"outcomeType": "ACTION",
+					"description": "user05 will do it"
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"resolveTension": {
+					"genericError": null,
+					"hasErrors": false,
+					"tension": {
+						"closed": true,
+						"outcome": {
+							"description": "user05 will do it",
+							"outcomeType": "action",
+							"referenceUID": null
+						},
+						"status": "resolved"
+					}
+				}
+			}
+			`,
+		},
+		// A resolved tension cannot change status
+		{
+			Query: `
+			mutation ChangeTensionStatus($tensionUID: ID!) {
+				changeTensionStatus(tensionUID: $tensionUID, status: PROCESSING) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"tensionUID": "YiAJCY5FDuKXisdSfcDgQY"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"changeTensionStatus": {
+					"genericError": "tension already closed",
+					"hasErrors": true
+				}
+			}
+			`,
+		},
+	})
+}
+
+func TestProposal(t *testing.T) {
+	RunTests(t, initBasic, []*Test{
+		// Create a proposal on circle rootRole-circle01 adding a new role and
+		// deleting rootRole-circle01-role01
+		{
+			Query: `
+			mutation CreateProposal($createProposalChange: CreateProposalChange!) {
+				createProposal(createProposalChange: $createProposalChange) {
+					proposal {
+						uid
+						title
+						status
+						role {
+							name
+						}
+						member {
+							userName
+						}
+						changes {
+							changeType
+							roleUID
+							name
+						}
+					}
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createProposalChange": {
+					"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+					"title": "proposal01",
+					"description": "proposal01",
+					"createRoleChanges": [
+						{ "name": "proposal-role01", "roleType": "normal", "purpose": "" }
+					],
+					"deleteRoleChanges": [
+						{ "uid": "0f2af650-b98b-57f3-9dcb-bb8bd8bf6479" }
+					]
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"createProposal": {
+					"hasErrors": false,
+					"proposal": {
+						"uid": "BpAeH74wSJt9M5VjjRcBtj",
+						"title": "proposal01",
+						"status": "draft",
+						"role": { "name": "rootRole-circle01" },
+						"member": { "userName": "admin" },
+						"changes": [
+							{ "changeType": "create", "roleUID": null, "name": "proposal-role01" },
+							{ "changeType": "delete", "roleUID": "7mrgT8NECqH3Z57snrzph4", "name": null }
+						]
+					}
+				}
+			}
+			`,
+		},
+		// Check that the proposal changes aren't applied before acceptance
+		{
+			Query: `
+			query roleQuery {
+				role(uid: "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c") {
+					roles {
+						name
+					}
+					proposals {
+						title
+						status
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"role": {
+					"roles": [
+						{ "name": "Facilitator" },
+						{ "name": "Lead Link" },
+						{ "name": "Rep Link" },
+						{ "name": "Secretary" },
+						{ "name": "rootRole-circle01-role01" },
+						{ "name": "rootRole-circle01-role02" },
+						{ "name": "rootRole-circle01-role03" },
+						{ "name": "rootRole-circle01-role04" }
+					],
+					"proposals": [
+						{ "title": "proposal01", "status": "draft" }
+					]
+				}
+			}
+			`,
+		},
+		// A draft proposal cannot be accepted
+		{
+			Query: `
+			mutation AcceptProposal($proposalUID: ID!) {
+				acceptProposal(proposalUID: $proposalUID) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"proposalUID": "eb62d3a8-74fe-55b6-9f21-36fdc7551c3f"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"acceptProposal": {
+					"hasErrors": true,
+					"genericError": "proposal in status \"draft\" cannot be accepted"
+				}
+			}
+			`,
+		},
+		// Submit the proposal
+		{
+			Query: `
+			mutation SubmitProposal($proposalUID: ID!) {
+				submitProposal(proposalUID: $proposalUID) {
+					proposal {
+						status
+					}
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"proposalUID": "eb62d3a8-74fe-55b6-9f21-36fdc7551c3f"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"submitProposal": {
+					"hasErrors": false,
+					"proposal": { "status": "submitted" }
+				}
+			}
+			`,
+		},
+		// Accept the proposal applying its changes
+		{
+			Query: `
+			mutation AcceptProposal($proposalUID: ID!) {
+				acceptProposal(proposalUID: $proposalUID) {
+					proposal {
+						status
+						role {
+							roles {
+								name
+							}
+						}
+					}
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"proposalUID": "eb62d3a8-74fe-55b6-9f21-36fdc7551c3f"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"acceptProposal": {
+					"hasErrors": false,
+					"proposal": {
+						"status": "accepted",
+						"role": {
+							"roles": [
+								{ "name": "Facilitator" },
+								{ "name": "Lead Link" },
+								{ "name": "Rep Link" },
+								{ "name": "Secretary" },
+								{ "name": "proposal-role01" },
+								{ "name": "rootRole-circle01-role02" },
+								{ "name": "rootRole-circle01-role03" },
+								{ "name": "rootRole-circle01-role04" }
+							]
+						}
+					}
+				}
+			}
+			`,
+		},
+		// An accepted proposal cannot be withdrawn
+		{
+			Query: `
+			mutation WithdrawProposal($proposalUID: ID!) {
+				withdrawProposal(proposalUID: $proposalUID) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"proposalUID": "eb62d3a8-74fe-55b6-9f21-36fdc7551c3f"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"withdrawProposal": {
+					"hasErrors": true,
+					"genericError": "proposal in status \"accepted\" cannot be withdrawn"
+				}
+			}
+			`,
+		},
+	})
+}
+
+func initProposalObjection(ctx context.Context, t *testing.T, rootRoleID util.ID, readDBListener readdb.ReadDBListener, commandService *command.CommandService) {
+	initBasic(ctx, t, rootRoleID, readDBListener, commandService)
+
+	circleID := util.IDFromStringOrNil("66c0cc1f-f608-53dc-88b5-f3afd68a4d6c")
+	user02ID := util.IDFromStringOrNil("18724eb3-ccc9-5c96-b0b7-91dcf95bacbf")
+	user05ID := util.IDFromStringOrNil("1699e266-8401-558e-b9f5-7e2d7f965b82")
+
+	// admin creates and submits a proposal on rootRole-circle01
+	res, groupID, err := commandService.CreateProposal(ctx, &change.CreateProposalChange{
+		RoleID:      circleID,
+		Title:       "proposal01",
+		Description: "proposal01",
+		ProposalChanges: change.ProposalChanges{
+			CreateRoleChanges: []change.CreateRoleChange{
+				{Name: "proposal-role01", RoleType: models.RoleTypeNormal},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	proposalID := *res.ProposalID
+
+	if _, groupID, err = commandService.SubmitProposal(ctx, proposalID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// user02 (circle lead link) objects
+	user02Ctx := context.WithValue(ctx, "userid", user02ID.String())
+	if _, groupID, err = commandService.ObjectProposal(user02Ctx, &change.ObjectProposalChange{ID: proposalID, Reason: "it will cause harm"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// user05 (circle direct member) consents
+	user05Ctx := context.WithValue(ctx, "userid", user05ID.String())
+	if _, groupID, err = commandService.ConsentProposal(user05Ctx, proposalID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestProposalObjection(t *testing.T) {
+	RunTests(t, initProposalObjection, []*Test{
+		// Check the proposal objections and consents
+		{
+			Query: `
+			query proposalQuery {
+				proposal(uid: "eb62d3a8-74fe-55b6-9f21-36fdc7551c3f") {
+					status
+					objections {
+						uid
+						member {
+							userName
+						}
+						reason
+						resolution
+						status
+					}
+					consents {
+						userName
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"proposal": {
+					"status": "objected",
+					"objections": [
+						{
+							"uid": "Dye6iyzo6LsDwajuHdLpiF",
+							"member": { "userName": "user02" },
+							"reason": "it will cause harm",
+							"resolution": "",
+							"status": "open"
+						}
+					],
+					"consents": [
+						{ "userName": "user05" }
+					]
+				}
+			}
+			`,
+		},
+		// Check the circle history
+		{
+			Query: `
+			query roleQuery {
+				role(uid: "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c") {
+					events(first: 2) {
+						edges {
+							event {
+								type
+								... on RoleEventProposalReviewed {
+									proposal {
+										title
+									}
+									member {
+										userName
+									}
+									reviewType
+									text
+								}
+							}
+						}
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"role": {
+					"events": {
+						"edges": [
+							{
+								"event": {
+									"type": "ProposalReviewed",
+									"proposal": { "title": "proposal01" },
+									"member": { "userName": "user05" },
+									"reviewType": "consent",
+									"text": ""
+								}
+							},
+							{
+								"event": {
+									"type": "ProposalReviewed",
+									"proposal": { "title": "proposal01" },
+									"member": { "userName": "user02" },
+									"reviewType": "objection",
+									"text": "it will cause harm"
+								}
+							}
+						]
+					}
+				}
+			}
+			`,
+		},
+		// A proposal with open objections cannot be accepted
+		{
+			Query: `
+			mutation AcceptProposal($proposalUID: ID!) {
+				acceptProposal(proposalUID: $proposalUID) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"proposalUID": "eb62d3a8-74fe-55b6-9f21-36fdc7551c3f"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"acceptProposal": {
+					"hasErrors": true,
+					"genericError": "proposal has unresolved objections"
+				}
+			}
+			`,
+		},
+		// Only core members can object
+		{
+			Query: `
+			mutation ObjectProposal($proposalUID: ID!) {
+				objectProposal(proposalUID: $proposalUID, reason: "another objection") {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"proposalUID": "eb62d3a8-74fe-55b6-9f21-36fdc7551c3f"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"objectProposal": {
+					"hasErrors": true,
+					"genericError": "member not authorized"
+				}
+			}
+			`,
+		},
+		// The proposer resolves the objection
+		{
+			Query: `
+			mutation ResolveProposalObjection($proposalUID: ID!, $objectionUID: ID!) {
+				resolveProposalObjection(proposalUID: $proposalUID, objectionUID: $objectionUID, resolution: "integrated") {
+					proposal {
+						status
+						objections {
+							resolution
+							status
+						}
+					}
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"proposalUID": "eb62d3a8-74fe-55b6-9f21-36fdc7551c3f",
+				"objectionUID": "4d172569-bf09-5845-ad6e-1db9c678ef20"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"resolveProposalObjection": {
+					"hasErrors": false,
+					"proposal": {
+						"status": "submitted",
+						"objections": [
+							{ "resolution": "integrated", "status": "resolved" }
+						]
+					}
+				}
+			}
+			`,
+		},
+		// Accept the proposal
+		{
+			Query: `
+			mutation AcceptProposal($proposalUID: ID!) {
+				acceptProposal(proposalUID: $proposalUID) {
+					proposal {
+						status
+					}
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"proposalUID": "eb62d3a8-74fe-55b6-9f21-36fdc7551c3f"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"acceptProposal": {
+					"hasErrors": false,
+					"proposal": { "status": "accepted" }
+				}
+			}
+			`,
+		},
+	})
+}
+
+func TestProjects(t *testing.T) {
+	RunTests(t, initBasic, []*Test{
+		// Add member admin to role rootRole-circle01-role01
+		{
+			Query: `
+			mutation RoleAddMember($roleUID: ID!, $memberUID: ID!) {
+				roleAddMember(roleUID: $roleUID, memberUID: $memberUID, focus: $focus) {
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "0f2af650-b98b-57f3-9dcb-bb8bd8bf6479",
+				"memberUID": "bace0701-15e3-5144-97c5-47487d543032",
+				"focus": "focus01"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"roleAddMember": {
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		// Create a project on role rootRole-circle01-role01 assigned to member admin
+		{
+			Query: `
+			mutation CreateProject($createProjectChange: CreateProjectChange!) {
+				createProject(createProjectChange: $createProjectChange) {
+					hasErrors
+					genericError
+					project {
+						uid
+						title
+						status
+						dueDate
+						role {
+							name
+						}
+						member {
+							userName
+						}
+						tension {
+							uid
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createProjectChange": {
+					"roleUID": "0f2af650-b98b-57f3-9dcb-bb8bd8bf6479",
+					"memberUID": "bace0701-15e3-5144-97c5-47487d543032",
+					"title": "project01",
+					"description": "project01",
+					"dueDate": "2030-01-01T00:00:00Z"
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"createProject": {
+					"genericError": null,
+					"hasErrors": false,
+					"project": {
+						"dueDate": "2030-01-01T00:00:00Z",
+						"member": {
+							"userName": "admin"
+						},
+						"role": {
+							"name": "rootRole-circle01-role01"
+						},
+						"status": "active",
+						"tension": null,
+						"title": "project01",
+						"uid": "9k6eFzZBWVGYjmCreEypaM"
+					}
+				}
+			}
+			`,
+		},
+		// Only members filling the role can be assigned to the project
+		{
+			Query: `
+			mutation UpdateProject($updateProjectChange: UpdateProjectChange!) {
+				updateProject(updateProjectChange: $updateProjectChange) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"updateProjectChange": {
+					"uid": "9k6eFzZBWVGYjmCreEypaM",
+					"memberUID": "1699e266-8401-558e-b9f5-7e2d7f965b82",
+					"title": "project01",
+					"description": "project01"
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"updateProject": {
+					"genericError": "member with id 1699e266-8401-558e-b9f5-7e2d7f965b82 is not member of role",
+					"hasErrors": true
+				}
+			}
+			`,
+		},
+		// Create a next action on the same role
+		{
+			Query: `
+			mutation CreateAction($createActionChange: CreateActionChange!) {
+				createAction(createActionChange: $createActionChange) {
+					hasErrors
+					genericError
+					action {
+						title
+						status
+						member {
+							userName
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createActionChange": {
+					"roleUID": "0f2af650-b98b-57f3-9dcb-bb8bd8bf6479",
+					"title": "action01",
+					"description": "action01"
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"createAction": {
+					"action": {
+						"member": null,
+						"status": "open",
+						"title": "action01"
+					},
+					"genericError": null,
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		// Complete the project
+		{
+			Query: `
+			mutation ChangeProjectStatus($projectUID: ID!) {
+				changeProjectStatus(projectUID: $projectUID, status: DONE) {
+					hasErrors
+					genericError
+					project {
+						status
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"projectUID": "9k6eFzZBWVGYjmCreEypaM"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"changeProjectStatus": {
+					"genericError": null,
+					"hasErrors": false,
+					"project": {
+						"status": "done"
+					}
+				}
+			}
+			`,
+		},
+		// A done project cannot be updated
+		{
+			Query: `
+			mutation UpdateProject($updateProjectChange: UpdateProjectChange!) {
+				updateProject(updateProjectChange: $updateProjectChange) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"updateProjectChange": {
+					"uid": "9k6eFzZBWVGYjmCreEypaM",
+					"title": "project01 new title",
+					"description": "project01"
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"updateProject": {
+					"genericError": "project in status \"done\" cannot be updated",
+					"hasErrors": true
+				}
+			}
+			`,
+		},
+		// Role and member projects and actions
+		{
+			Query: `
+			query {
+				role(uid: "0f2af650-b98b-57f3-9dcb-bb8bd8bf6479") {
+					projects {
+						title
+						status
+					}
+					actions {
+						title
+						status
+					}
+				}
+				member(uid: "bace0701-15e3-5144-97c5-47487d543032") {
+					projects {
+						title
+					}
+					actions {
+						title
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"member": {
+					"actions": [],
+					"projects": [
+						{
+							"title": "project01"
+						}
+					]
+				},
+				"role": {
+					"actions": [
+						{
+							"status": "open",
+							"title": "action01"
+						}
+					],
+					"projects": [
+						{
+							"status": "done",
+							"title": "project01"
+						}
+					]
+				}
+			}
+			`,
+		},
+	})
+}
+
+func TestMeetings(t *testing.T) {
+	RunTests(t, initBasic, []*Test{
+		// Create a tactical meeting on circle rootRole-circle01
+		{
+			Query: `
+			mutation CreateMeeting($createMeetingChange: CreateMeetingChange!) {
+				createMeeting(createMeetingChange: $createMeetingChange) {
+					hasErrors
+					genericError
+					meeting {
+						uid
+						meetingType
+						date
+						role {
+							name
+						}
+						facilitator {
+							userName
+						}
+						secretary {
+							userName
+						}
+						attendees {
+							userName
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createMeetingChange": {
+					"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+					"meetingType": "TACTICAL",
+					"date": "2030-01-01T10:00:00Z"
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"createMeeting": {
+					"genericError": null,
+					"hasErrors": false,
+					"meeting": {
+						"attendees": [
+							{
+								"userName": "user05"
+							},
+							{
+								"userName": "user02"
+							}
+						],
+						"date": "2030-01-01T10:00:00Z",
+						"facilitator": null,
+						"meetingType": "tactical",
+						"role": {
+							"name": "rootRole-circle01"
+						},
+						"secretary": null,
+						"uid": "GsjozMPRYkBYNJCiofGbVX"
+					}
+				}
+			}
+			`,
+		},
+		// Set member user05 as facilitator of circle rootRole-circle01
+		{
+			Query: `
+			mutation CircleSetCoreRoleMember($roleType: RoleType!, $roleUID: ID!, $memberUID: ID!) {
+				circleSetCoreRoleMember(roleType: $roleType, roleUID: $roleUID, memberUID: $memberUID) {
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleType": "facilitator",
+				"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+				"memberUID": "1699e266-8401-558e-b9f5-7e2d7f965b82"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"circleSetCoreRoleMember": {
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		// Create a governance meeting with only some attendees. The
+		// facilitator is taken from the circle core role
+		{
+			Query: `
+			mutation CreateMeeting($createMeetingChange: CreateMeetingChange!) {
+				createMeeting(createMeetingChange: $createMeetingChange) {
+					hasErrors
+					genericError
+					meeting {
+						meetingType
+						facilitator {
+							userName
+						}
+						attendees {
+							userName
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createMeetingChange": {
+					"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+					"meetingType": "GOVERNANCE",
+					"date": "2029-12-01T10:00:00Z",
+					"attendeeUIDs": ["1699e266-8401-558e-b9f5-7e2d7f965b82"]
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"createMeeting": {
+					"genericError": null,
+					"hasErrors": false,
+					"meeting": {
+						"attendees": [
+							{
+								"userName": "user05"
+							}
+						],
+						"facilitator": {
+							"userName": "user05"
+						},
+						"meetingType": "governance"
+					}
+				}
+			}
+			`,
+		},
+		// Attendees must be circle members
+		{
+			Query: `
+			mutation CreateMeeting($createMeetingChange: CreateMeetingChange!) {
+				createMeeting(createMeetingChange: $createMeetingChange) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createMeetingChange": {
+					"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+					"meetingType": "GOVERNANCE",
+					"date": "2029-12-01T10:00:00Z",
+					"attendeeUIDs": ["bace0701-15e3-5144-97c5-47487d543032"]
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"createMeeting": {
+					"genericError": "member with id bace0701-15e3-5144-97c5-47487d543032 is not a circle member",
+					"hasErrors": true
+				}
+			}
+			`,
+		},
+		// Add member admin to role rootRole-circle01-role01
+		{
+			Query: `
+			mutation RoleAddMember($roleUID: ID!, $memberUID: ID!) {
+				roleAddMember(roleUID: $roleUID, memberUID: $memberUID, focus: $focus) {
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "0f2af650-b98b-57f3-9dcb-bb8bd8bf6479",
+				"memberUID": "bace0701-15e3-5144-97c5-47487d543032",
+				"focus": "focus01"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"roleAddMember": {
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		// Create tension as member admin on circle rootRole-circle01
+		{
+			Query: `
+			mutation CreateTension($createTensionChange: CreateTensionChange!) {
+				createTension(createTensionChange: $createTensionChange) {
+					hasErrors
+					tension {
+						uid
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createTensionChange": {
+					"title": "newtension",
+					"description": "newtension",
+					"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c"
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"createTension": {
+					"hasErrors": false,
+					"tension": {
+						"uid": "YiAJCY5FDuKXisdSfcDgQY"
+					}
+				}
+			}
+			`,
+		},
+		// Close the tension recording the tactical meeting where it was processed
+		{
+			Query: `
+			mutation CloseTension($closeTensionChange: CloseTensionChange!) {
+				closeTension(closeTensionChange: $closeTensionChange) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"closeTensionChange": {
+					"uid": "YiAJCY5FDuKXisdSfcDgQY",
+					"reason": "not relevant",
+					"meetingUID": "GsjozMPRYkBYNJCiofGbVX"
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"closeTension": {
+					"genericError": null,
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		// The tension references the meeting where it was processed
+		{
+			Query: `
+			query {
+				tension(uid: "YiAJCY5FDuKXisdSfcDgQY") {
+					status
+					meeting {
+						meetingType
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"tension": {
+					"meeting": {
+						"meetingType": "tactical"
+					},
+					"status": "dropped"
+				}
+			}
+			`,
+		},
+		// Circle meetings are ordered by date, most recent first
+		{
+			Query: `
+			query {
+				role(uid: "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c") {
+					meetings(first: 1) {
+						hasMoreData
+						edges {
+							meeting {
+								meetingType
+								date
+								tensions {
+									title
+								}
+							}
+						}
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"role": {
+					"meetings": {
+						"edges": [
+							{
+								"meeting": {
+									"date": "2030-01-01T10:00:00Z",
+									"meetingType": "tactical",
+									"tensions": [
+										{
+											"title": "newtension"
+										}
+									]
+								}
+							}
+						],
+						"hasMoreData": true
+					}
+				}
+			}
+			`,
+		},
+	})
+}
+
+func TestChecklistsAndMetrics(t *testing.T) {
+	RunTests(t, initBasic, []*Test{
+		// Add checklist items and metrics to role rootRole-circle01-role01
+		{
+			Query: `
+			mutation CircleUpdateChildRole($roleUID: ID!, $updateRoleChange: UpdateRoleChange!) {
+				circleUpdateChildRole(roleUID: $roleUID, updateRoleChange: $updateRoleChange) {
+					hasErrors
+					role {
+						checklistItems {
+							uid
+							description
+						}
+						metrics {
+							uid
+							description
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+				"updateRoleChange": {
+					"uid": "0f2af650-b98b-57f3-9dcb-bb8bd8bf6479",
+					"createChecklistItemChanges": [
+						{
+							"description": "weekly newsletter sent"
+						},
+						{
+							"description": "backlog reviewed"
+						}
+					],
+					"createMetricChanges": [
+						{
+							"description": "open bugs"
+						}
+					]
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"circleUpdateChildRole": {
+					"hasErrors": false,
+					"role": {
+						"checklistItems": [
+							{
+								"description": "backlog reviewed",
+								"uid": "BgBndh7Q9YCxfPoNWZhA3d"
+							},
+							{
+								"description": "weekly newsletter sent",
+								"uid": "GTkZ45d8xS6b7QDipwDZpL"
+							}
+						],
+						"metrics": [
+							{
+								"description": "open bugs",
+								"uid": "BNb5erXke3L43yWsMjrXan"
+							}
+						]
+					}
+				}
+			}
+			`,
+		},
+		// Create a tactical meeting on circle rootRole-circle01
+		{
+			Query: `
+			mutation CreateMeeting($createMeetingChange: CreateMeetingChange!) {
+				createMeeting(createMeetingChange: $createMeetingChange) {
+					hasErrors
+					meeting {
+						uid
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createMeetingChange": {
+					"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+					"meetingType": "TACTICAL",
+					"date": "2030-01-01T10:00:00Z"
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"createMeeting": {
+					"hasErrors": false,
+					"meeting": {
+						"uid": "GsjozMPRYkBYNJCiofGbVX"
+					}
+				}
+			}
+			`,
+		},
+		// Report the values in the tactical meeting
+		{
+			Query: `
+			mutation ReportMeetingValues($reportMeetingValuesChange: ReportMeetingValuesChange!) {
+				reportMeetingValues(reportMeetingValuesChange: $reportMeetingValuesChange) {
+					hasErrors
+					genericError
+					meeting {
+						checklistItemReports {
+							checklistItem {
+								description
+							}
+							checked
+						}
+						metricReports {
+							metric {
+								description
+							}
+							value
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"reportMeetingValuesChange": {
+					"meetingUID": "GsjozMPRYkBYNJCiofGbVX",
+					"checklistItemReports": [
+						{ "checklistItemUID": "GTkZ45d8xS6b7QDipwDZpL", "checked": true },
+						{ "checklistItemUID": "BgBndh7Q9YCxfPoNWZhA3d", "checked": false }
+					],
+					"metricReports": [
+						{ "metricUID": "BNb5erXke3L43yWsMjrXan", "value": "12" }
+					]
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"reportMeetingValues": {
+					"genericError": null,
+					"hasErrors": false,
+					"meeting": {
+						"checklistItemReports": [
+							{
+								"checked": false,
+								"checklistItem": {
+									"description": "backlog reviewed"
+								}
+							},
+							{
+								"checked": true,
+								"checklistItem": {
+									"description": "weekly newsletter sent"
+								}
+							}
+						],
+						"metricReports": [
+							{
+								"metric": {
+									"description": "open bugs"
+								},
+								"value": "12"
+							}
+						]
+					}
+				}
+			}
+			`,
+		},
+		// Report again a metric in the tactical meeting, the new value replaces
+		// the previous one
+		{
+			Query: `
+			mutation ReportMeetingValues($reportMeetingValuesChange: ReportMeetingValuesChange!) {
+				reportMeetingValues(reportMeetingValuesChange: $reportMeetingValuesChange) {
+					hasErrors
+					genericError
+					meeting {
+						checklistItemReports {
+							checklistItem {
+								description
+							}
+							checked
+						}
+						metricReports {
+							metric {
+								description
+							}
+							value
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"reportMeetingValuesChange": {
+					"meetingUID": "GsjozMPRYkBYNJCiofGbVX",
+					"checklistItemReports": [],
+					"metricReports": [
+						{ "metricUID": "BNb5erXke3L43yWsMjrXan", "value": "10" }
+					]
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"reportMeetingValues": {
+					"genericError": null,
+					"hasErrors": false,
+					"meeting": {
+						"checklistItemReports": [
+							{
+								"checked": false,
+								"checklistItem": {
+									"description": "backlog reviewed"
+								}
+							},
+							{
+								"checked": true,
+								"checklistItem": {
+									"description": "weekly newsletter sent"
+								}
+							}
+						],
+						"metricReports": [
+							{
+								"metric": {
+									"description": "open bugs"
+								},
+								"value": "10"
+							}
+						]
+					}
+				}
+			}
+			`,
+		},
+		// A metric cannot be reported multiple times in the same change
+		{
+			Query: `
+			mutation ReportMeetingValues($reportMeetingValuesChange: ReportMeetingValuesChange!) {
+				reportMeetingValues(reportMeetingValuesChange: $reportMeetingValuesChange) {
+					hasErrors
+					genericError
+					meeting {
+						checklistItemReports {
+							checklistItem {
+								description
+							}
+							checked
+						}
+						metricReports {
+							metric {
+								description
+							}
+							value
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"reportMeetingValuesChange": {
+					"meetingUID": "GsjozMPRYkBYNJCiofGbVX",
+					"checklistItemReports": [],
+					"metricReports": [
+						{ "metricUID": "BNb5erXke3L43yWsMjrXan", "value": "10" },
+						{ "metricUID": "BNb5erXke3L43yWsMjrXan", "value": "11" }
+					]
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"reportMeetingValues": {
+					"genericError": "metric with id fa81f1b2-b46c-5d5d-86d2-30d618eeebaf reported multiple times",
+					"hasErrors": true,
+					"meeting": null
+				}
+			}
+			`,
+		},
+		// Create a governance meeting on circle rootRole-circle01
+		{
+			Query: `
+			mutation CreateMeeting($createMeetingChange: CreateMeetingChange!) {
+				createMeeting(createMeetingChange: $createMeetingChange) {
+					hasErrors
+					meeting {
+						uid
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createMeetingChange": {
+					"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+					"meetingType": "GOVERNANCE",
+					"date": "2030-01-08T10:00:00Z"
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"createMeeting": {
+					"hasErrors": false,
+					"meeting": {
+						"uid": "NTtDGG67JiQY8fQtZRqDoe"
+					}
+				}
+			}
+			`,
+		},
+		// Report a metric value in the governance meeting
+		{
+			Query: `
+			mutation ReportMeetingValues($reportMeetingValuesChange: ReportMeetingValuesChange!) {
+				reportMeetingValues(reportMeetingValuesChange: $reportMeetingValuesChange) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"reportMeetingValuesChange": {
+					"meetingUID": "NTtDGG67JiQY8fQtZRqDoe",
+					"metricReports": [
+						{ "metricUID": "BNb5erXke3L43yWsMjrXan", "value": "8" }
+					]
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"reportMeetingValues": {
+					"genericError": null,
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		// Query the checklist items and metrics history, from the most recent
+		// meeting
+		{
+			Query: `
+			query {
+				role(uid: "0f2af650-b98b-57f3-9dcb-bb8bd8bf6479") {
+					checklistItems {
+						description
+						reports {
+							meeting {
+								meetingType
+								date
+							}
+							checked
+						}
+					}
+					metrics {
+						description
+						reports {
+							meeting {
+								meetingType
+								date
+							}
+							value
+						}
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"role": {
+					"checklistItems": [
+						{
+							"description": "backlog reviewed",
+							"reports": [
+								{
+									"checked": false,
+									"meeting": {
+										"date": "2030-01-01T10:00:00Z",
+										"meetingType": "tactical"
+									}
+								}
+							]
+						},
+						{
+							"description": "weekly newsletter sent",
+							"reports": [
+								{
+									"checked": true,
+									"meeting": {
+										"date": "2030-01-01T10:00:00Z",
+										"meetingType": "tactical"
+									}
+								}
+							]
+						}
+					],
+					"metrics": [
+						{
+							"description": "open bugs",
+							"reports": [
+								{
+									"meeting": {
+										"date": "2030-01-08T10:00:00Z",
+										"meetingType": "governance"
+									},
+									"value": "8"
+								},
+								{
+									"meeting": {
+										"date": "2030-01-01T10:00:00Z",
+										"meetingType": "tactical"
+									},
+									"value": "10"
+								}
+							]
+						}
+					]
+				}
+			}
+			`,
+		},
+		// A metric description cannot be empty
+		{
+			Query: `
+			mutation CircleUpdateChildRole($roleUID: ID!, $updateRoleChange: UpdateRoleChange!) {
+				circleUpdateChildRole(roleUID: $roleUID, updateRoleChange: $updateRoleChange) {
+					hasErrors
+					updateRoleChangeErrors {
+						updateMetricChangesErrors {
+							description
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+				"updateRoleChange": {
+					"uid": "0f2af650-b98b-57f3-9dcb-bb8bd8bf6479",
+					"updateMetricChanges": [
+						{
+							"uid": "BNb5erXke3L43yWsMjrXan",
+							"descriptionChanged": true,
+							"description": ""
+						}
+					]
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"circleUpdateChildRole": {
+					"hasErrors": true,
+					"updateRoleChangeErrors": {
+						"updateMetricChangesErrors": [
+							{
+								"description": "empty metric"
+							}
+						]
+					}
+				}
+			}
+			`,
+		},
+		// Delete a checklist item with reported values, its reports are
+		// removed from the meeting
+		{
+			Query: `
+			mutation CircleUpdateChildRole($roleUID: ID!, $updateRoleChange: UpdateRoleChange!) {
+				circleUpdateChildRole(roleUID: $roleUID, updateRoleChange: $updateRoleChange) {
+					hasErrors
+					role {
+						checklistItems {
+							description
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+				"updateRoleChange": {
+					"uid": "0f2af650-b98b-57f3-9dcb-bb8bd8bf6479",
+					"deleteChecklistItemChanges": [
+						{
+							"uid": "GTkZ45d8xS6b7QDipwDZpL"
+						}
+					]
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"circleUpdateChildRole": {
+					"hasErrors": false,
+					"role": {
+						"checklistItems": [
+							{
+								"description": "backlog reviewed"
+							}
+						]
+					}
+				}
+			}
+			`,
+		},
+		{
+			Query: `
+			query {
+				meeting(uid: "GsjozMPRYkBYNJCiofGbVX") {
+					checklistItemReports {
+						checklistItem {
+							description
+						}
+						checked
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"meeting": {
+					"checklistItemReports": [
+						{
+							"checked": false,
+							"checklistItem": {
+								"description": "backlog reviewed"
+							}
+						}
+					]
+				}
+			}
+			`,
+		},
+	})
+}
+
+func TestElections(t *testing.T) {
+	RunTests(t, initBasic, []*Test{
+		// Open a facilitator election for circle rootRole-circle01
+		{
+			Query: `
+			mutation CreateElection($createElectionChange: CreateElectionChange!) {
+				createElection(createElectionChange: $createElectionChange) {
+					hasErrors
+					genericError
+					election {
+						uid
+						roleType
+						status
+						role {
+							name
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createElectionChange": {
+					"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+					"roleType": "FACILITATOR"
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"createElection": {
+					"election": {
+						"role": {
+							"name": "rootRole-circle01"
+						},
+						"roleType": "facilitator",
+						"status": "open",
+						"uid": "n8Jr5SoauEdZotbjuiVvom"
+					},
+					"genericError": null,
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		// The lead link isn't elected
+		{
+			Query: `
+			mutation CreateElection($createElectionChange: CreateElectionChange!) {
+				createElection(createElectionChange: $createElectionChange) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createElectionChange": {
+					"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+					"roleType": "LEADLINK"
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"createElection": {
+					"genericError": "invalid election role type \"leadlink\"",
+					"hasErrors": true
+				}
+			}
+			`,
+		},
+		// Nominate user05
+		{
+			Query: `
+			mutation NominateElectionCandidate($electionUID: ID!, $memberUID: ID!) {
+				nominateElectionCandidate(electionUID: $electionUID, memberUID: $memberUID) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"electionUID": "n8Jr5SoauEdZotbjuiVvom",
+				"memberUID": "1699e266-8401-558e-b9f5-7e2d7f965b82"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"nominateElectionCandidate": {
+					"genericError": null,
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		// A member cannot be nominated twice
+		{
+			Query: `
+			mutation NominateElectionCandidate($electionUID: ID!, $memberUID: ID!) {
+				nominateElectionCandidate(electionUID: $electionUID, memberUID: $memberUID) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"electionUID": "n8Jr5SoauEdZotbjuiVvom",
+				"memberUID": "1699e266-8401-558e-b9f5-7e2d7f965b82"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"nominateElectionCandidate": {
+					"genericError": "member 1699e266-8401-558e-b9f5-7e2d7f965b82 already nominated",
+					"hasErrors": true
+				}
+			}
+			`,
+		},
+		// Only circle members can be nominated
+		{
+			Query: `
+			mutation NominateElectionCandidate($electionUID: ID!, $memberUID: ID!) {
+				nominateElectionCandidate(electionUID: $electionUID, memberUID: $memberUID) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"electionUID": "n8Jr5SoauEdZotbjuiVvom",
+				"memberUID": "bace0701-15e3-5144-97c5-47487d543032"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"nominateElectionCandidate": {
+					"genericError": "member with id bace0701-15e3-5144-97c5-47487d543032 is not a circle member",
+					"hasErrors": true
+				}
+			}
+			`,
+		},
+		// Complete the election electing user05
+		{
+			Query: `
+			mutation CompleteElection($electionUID: ID!, $memberUID: ID!, $electionExpiration: Time) {
+				completeElection(electionUID: $electionUID, memberUID: $memberUID, electionExpiration: $electionExpiration) {
+					hasErrors
+					genericError
+					election {
+						status
+						nominations {
+							candidate {
+								userName
+							}
+							nominator {
+								userName
+							}
+						}
+						elected {
+							userName
+						}
+						electionExpiration
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"electionUID": "n8Jr5SoauEdZotbjuiVvom",
+				"memberUID": "1699e266-8401-558e-b9f5-7e2d7f965b82",
+				"electionExpiration": "2090-01-01T00:00:00Z"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"completeElection": {
+					"election": {
+						"elected": {
+							"userName": "user05"
+						},
+						"electionExpiration": "2090-01-01T00:00:00Z",
+						"nominations": [
+							{
+								"candidate": {
+									"userName": "user05"
+								},
+								"nominator": {
+									"userName": "admin"
+								}
+							}
+						],
+						"status": "completed"
+					},
+					"genericError": null,
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		{
+			Query: `
+			query {
+				role(uid: "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c") {
+					roles {
+						roleType
+						roleMembers {
+							member {
+								userName
+							}
+							electionExpiration
+						}
+					}
+					elections {
+						roleType
+						status
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"role": {
+					"elections": [
+						{
+							"roleType": "facilitator",
+							"status": "completed"
+						}
+					],
+					"roles": [
+						{
+							"roleMembers": [
+								{
+									"electionExpiration": "2090-01-01T00:00:00Z",
+									"member": {
+										"userName": "user05"
+									}
+								}
+							],
+							"roleType": "facilitator"
+						},
+						{
+							"roleMembers": [
+								{
+									"electionExpiration": null,
+									"member": {
+										"userName": "user02"
+									}
+								}
+							],
+							"roleType": "leadlink"
+						},
+						{
+							"roleMembers": [],
+							"roleType": "replink"
+						},
+						{
+							"roleMembers": [],
+							"roleType": "secretary"
+						},
+						{
+							"roleMembers": [],
+							"roleType": "normal"
+						},
+						{
+							"roleMembers": [],
+							"roleType": "normal"
+						},
+						{
+							"roleMembers": [],
+							"roleType": "normal"
+						},
+						{
+							"roleMembers": [],
+							"roleType": "normal"
+						}
+					]
+				}
+			}
+			`,
+		},
+		// A completed election cannot be completed again
+		{
+			Query: `
+			mutation CompleteElection($electionUID: ID!, $memberUID: ID!) {
+				completeElection(electionUID: $electionUID, memberUID: $memberUID) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"electionUID": "n8Jr5SoauEdZotbjuiVvom",
+				"memberUID": "1699e266-8401-558e-b9f5-7e2d7f965b82"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"completeElection": {
+					"genericError": "cannot complete an election in status \"completed\"",
+					"hasErrors": true
+				}
+			}
+			`,
+		},
+		// Assign the secretary with an already expired election
+		{
+			Query: `
+			mutation CircleSetCoreRoleMember($roleType: RoleType!, $roleUID: ID!, $memberUID: ID!, $electionExpiration: Time) {
+				circleSetCoreRoleMember(roleType: $roleType, roleUID: $roleUID, memberUID: $memberUID, electionExpiration: $electionExpiration) {
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleType": "secretary",
+				"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+				"memberUID": "1699e266-8401-558e-b9f5-7e2d7f965b82",
+				"electionExpiration": "2000-01-01T00:00:00Z"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"circleSetCoreRoleMember": {
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		{
+			Query: `
+			query UpcomingElections($before: Time) {
+				upcomingElections(before: $before) {
+					coreRole {
+						roleType
+					}
+					circle {
+						name
+					}
+					member {
+						userName
+					}
+					electionExpiration
+				}
+				expiredElections {
+					coreRole {
+						roleType
+					}
+					circle {
+						name
+					}
+					member {
+						userName
+					}
+					electionExpiration
+				}
+			}
+			`,
+			Variables: `
+			{
+				"before": "2100-01-01T00:00:00Z"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"expiredElections": [
+					{
+						"circle": {
+							"name": "rootRole-circle01"
+						},
+						"coreRole": {
+							"roleType": "secretary"
+						},
+						"electionExpiration": "2000-01-01T00:00:00Z",
+						"member": {
+							"userName": "user05"
+						}
+					}
+				],
+				"upcomingElections": [
+					{
+						"circle": {
+							"name": "rootRole-circle01"
+						},
+						"coreRole": {
+							"roleType": "facilitator"
+						},
+						"electionExpiration": "2090-01-01T00:00:00Z",
+						"member": {
+							"userName": "user05"
+						}
+					}
+				]
+			}
+			`,
+		},
+	})
+}
+
+func TestRoleTemplates(t *testing.T) {
+	RunTests(t, initBasic, []*Test{
+		// Create a role template from role rootRole-role01
+		{
+			Query: `
+			mutation CreateRoleTemplate($createRoleTemplateChange: CreateRoleTemplateChange!) {
+				createRoleTemplate(createRoleTemplateChange: $createRoleTemplateChange) {
+					hasErrors
+					genericError
+					roleTemplate {
+						uid
+						name
+						purpose
+						domains
+						accountabilities
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"createRoleTemplateChange": {
+					"roleUID": "0f2af650-b98b-57f3-9dcb-bb8bd8bf6479",
+					"name": "Tech Lead"
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"createRoleTemplate": {
+					"genericError": null,
+					"hasErrors": false,
+					"roleTemplate": {
+						"accountabilities": [],
+						"domains": [],
+						"name": "Tech Lead",
+						"purpose": "",
+						"uid": "yh8eKwrR8L6VMNREheJMhZ"
+					}
+				}
+			}
+			`,
+		},
+		// Update the role template
+		{
+			Query: `
+			mutation UpdateRoleTemplate($updateRoleTemplateChange: UpdateRoleTemplateChange!) {
+				updateRoleTemplate(updateRoleTemplateChange: $updateRoleTemplateChange) {
+					hasErrors
+					genericError
+					roleTemplate {
+						name
+						purpose
+						domains
+						accountabilities
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"updateRoleTemplateChange": {
+					"uid": "yh8eKwrR8L6VMNREheJMhZ",
+					"name": "Tech Lead",
+					"purpose": "Technical direction",
+					"domains": ["architecture"],
+					"accountabilities": ["reviewing designs"]
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"updateRoleTemplate": {
+					"genericError": null,
+					"hasErrors": false,
+					"roleTemplate": {
+						"accountabilities": [
+							"reviewing designs"
+						],
+						"domains": [
+							"architecture"
+						],
+						"name": "Tech Lead",
+						"purpose": "Technical direction"
+					}
+				}
+			}
+			`,
+		},
+		// Instantiate the role template, linked, inside circle rootRole-circle01
+		{
+			Query: `
+			mutation InstantiateRoleTemplate($instantiateRoleTemplateChange: InstantiateRoleTemplateChange!) {
+				instantiateRoleTemplate(instantiateRoleTemplateChange: $instantiateRoleTemplateChange) {
+					hasErrors
+					genericError
+					role {
+						name
+						purpose
+						domains {
+							description
+						}
+						accountabilities {
+							description
+						}
+						template {
+							name
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"instantiateRoleTemplateChange": {
+					"roleTemplateUID": "yh8eKwrR8L6VMNREheJMhZ",
+					"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+					"name": "Circle01 Tech Lead",
+					"linked": true
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"instantiateRoleTemplate": {
+					"genericError": null,
+					"hasErrors": false,
+					"role": {
+						"accountabilities": [
+							{
+								"description": "reviewing designs"
+							}
+						],
+						"domains": [
+							{
+								"description": "architecture"
+							}
+						],
+						"name": "Circle01 Tech Lead",
+						"purpose": "Technical direction",
+						"template": {
+							"name": "Tech Lead"
+						}
+					}
+				}
+			}
+			`,
+		},
+		// Instantiating an unexistent template should fail
+		{
+			Query: `
+			mutation InstantiateRoleTemplate($instantiateRoleTemplateChange: InstantiateRoleTemplateChange!) {
+				instantiateRoleTemplate(instantiateRoleTemplateChange: $instantiateRoleTemplateChange) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"instantiateRoleTemplateChange": {
+					"roleTemplateUID": "0f2af650-b98b-57f3-9dcb-bb8bd8bf6479",
+					"roleUID": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+					"name": "Circle01 Tech Lead",
+					"linked": true
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"instantiateRoleTemplate": {
+					"genericError": "role template with id 0f2af650-b98b-57f3-9dcb-bb8bd8bf6479 doesn't exist",
+					"hasErrors": true
+				}
+			}
+			`,
+		},
+		// Nothing to propose since the linked role is aligned with the template
+		{
+			Query: `
+			mutation ProposeRoleTemplateUpdate($uid: ID!) {
+				proposeRoleTemplateUpdate(uid: $uid) {
+					hasErrors
+					genericError
+					proposals {
+						title
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"uid": "yh8eKwrR8L6VMNREheJMhZ"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"proposeRoleTemplateUpdate": {
+					"genericError": "all the linked roles are already aligned with the role template",
+					"hasErrors": true,
+					"proposals": null
+				}
+			}
+			`,
+		},
+		// Update the role template again
+		{
+			Query: `
+			mutation UpdateRoleTemplate($updateRoleTemplateChange: UpdateRoleTemplateChange!) {
+				updateRoleTemplate(updateRoleTemplateChange: $updateRoleTemplateChange) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"updateRoleTemplateChange": {
+					"uid": "yh8eKwrR8L6VMNREheJMhZ",
+					"name": "Tech Lead",
+					"purpose": "Technical direction and quality",
+					"domains": ["architecture", "ci pipelines"],
+					"accountabilities": ["mentoring"]
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"updateRoleTemplate": {
+					"genericError": null,
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		// Propose the template update to the linked roles
+		{
+			Query: `
+			mutation ProposeRoleTemplateUpdate($uid: ID!) {
+				proposeRoleTemplateUpdate(uid: $uid) {
+					hasErrors
+					genericError
+					proposals {
+						title
+						description
+						role {
+							name
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"uid": "yh8eKwrR8L6VMNREheJMhZ"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"proposeRoleTemplateUpdate": {
+					"genericError": null,
+					"hasErrors": false,
+					"proposals": [
+						{
+							"description": "Align the role purpose, domains and accountabilities with the ones of the role template \"Tech Lead\"",
+							"role": {
+								"name": "rootRole-circle01"
+							},
+							"title": "Update role Circle01 Tech Lead from template Tech Lead"
+						}
+					]
+				}
+			}
+			`,
+		},
+		// Query the templates library
+		{
+			Query: `
+			query {
+				roleTemplates {
+					name
+					purpose
+					instances {
+						name
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"roleTemplates": [
+					{
+						"instances": [
+							{
+								"name": "Circle01 Tech Lead"
+							}
+						],
+						"name": "Tech Lead",
+						"purpose": "Technical direction and quality"
+					}
+				]
+			}
+			`,
+		},
+		// Unlink the role from the template
+		{
+			Query: `
+			mutation UnlinkRoleTemplateInstance($roleUID: ID!) {
+				unlinkRoleTemplateInstance(roleUID: $roleUID) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "2a601ed0-99f6-5789-8d13-65d91e180c19"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"unlinkRoleTemplateInstance": {
+					"genericError": null,
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		// Delete the role template
+		{
+			Query: `
+			mutation DeleteRoleTemplate($uid: ID!) {
+				deleteRoleTemplate(uid: $uid) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"uid": "yh8eKwrR8L6VMNREheJMhZ"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"deleteRoleTemplate": {
+					"genericError": null,
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		{
+			Query: `
+			query {
+				roleTemplates {
+					name
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"roleTemplates": []
+			}
+			`,
+		},
+	})
+}
+
+func TestMoveRole(t *testing.T) {
+	RunTests(t, initBasic, []*Test{
+		// Move circle rootRole-circle02 (with its sub roles) inside circle rootRole-circle01
+		{
+			Query: `
+			mutation MoveRole($roleUID: ID!, $newParentUID: ID!) {
+				moveRole(roleUID: $roleUID, newParentUID: $newParentUID) {
+					hasErrors
+					genericError
+					role {
+						name
+						depth
+						parent {
+							name
+						}
+						roles {
+							name
+							depth
+							roleMembers {
+								member {
+									userName
+								}
+							}
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "xfFUSNW7mZUWNYZ6JufB7J",
+				"newParentUID": "LUJMgnvykhzsX6Edb656JL"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"moveRole": {
+					"genericError": null,
+					"hasErrors": false,
+					"role": {
+						"depth": 2,
+						"name": "rootRole-circle02",
+						"parent": {
+							"name": "rootRole-circle01"
+						},
+						"roles": [
+							{
+								"depth": 3,
+								"name": "Facilitator",
+								"roleMembers": []
+							},
+							{
+								"depth": 3,
+								"name": "Lead Link",
+								"roleMembers": [
+									{
+										"member": {
+											"userName": "user03"
+										}
+									}
+								]
+							},
+							{
+								"depth": 3,
+								"name": "Rep Link",
+								"roleMembers": []
+							},
+							{
+								"depth": 3,
+								"name": "Secretary",
+								"roleMembers": [
+									{
+										"member": {
+											"userName": "user03"
+										}
+									}
+								]
+							},
+							{
+								"depth": 3,
+								"name": "rootRole-circle02-role01",
+								"roleMembers": []
+							},
+							{
+								"depth": 3,
+								"name": "rootRole-circle02-role02",
+								"roleMembers": []
+							},
+							{
+								"depth": 3,
+								"name": "rootRole-circle02-role03",
+								"roleMembers": []
+							},
+							{
+								"depth": 3,
+								"name": "rootRole-circle02-role04",
+								"roleMembers": []
+							}
+						]
+					}
+				}
+			}
+			`,
+		},
+		// Moving a circle inside one of its sub circles should fail
+		{
+			Query: `
+			mutation MoveRole($roleUID: ID!, $newParentUID: ID!) {
+				moveRole(roleUID: $roleUID, newParentUID: $newParentUID) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "LUJMgnvykhzsX6Edb656JL",
+				"newParentUID": "xfFUSNW7mZUWNYZ6JufB7J"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"moveRole": {
+					"genericError": "cannot move role with id 66c0cc1f-f608-53dc-88b5-f3afd68a4d6c inside itself",
+					"hasErrors": true
+				}
+			}
+			`,
+		},
+		// Moving a role inside its current parent should fail
+		{
+			Query: `
+			mutation MoveRole($roleUID: ID!, $newParentUID: ID!) {
+				moveRole(roleUID: $roleUID, newParentUID: $newParentUID) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "7mrgT8NECqH3Z57snrzph4",
+				"newParentUID": "LUJMgnvykhzsX6Edb656JL"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"moveRole": {
+					"genericError": "role with id 0f2af650-b98b-57f3-9dcb-bb8bd8bf6479 is already a child of role 66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+					"hasErrors": true
+				}
+			}
+			`,
+		},
+		// Moving a role inside a normal role should fail
+		{
+			Query: `
+			mutation MoveRole($roleUID: ID!, $newParentUID: ID!) {
+				moveRole(roleUID: $roleUID, newParentUID: $newParentUID) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "7mrgT8NECqH3Z57snrzph4",
+				"newParentUID": "sXPck8eJP5jC85jQkmNZVG"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"moveRole": {
+					"genericError": "role with id 51673410-b8ef-5e0f-bac4-cd294758e675 isn't a circle",
+					"hasErrors": true
+				}
+			}
+			`,
+		},
+		// Moving a core role should fail
+		{
+			Query: `
+			mutation MoveRole($roleUID: ID!, $newParentUID: ID!) {
+				moveRole(roleUID: $roleUID, newParentUID: $newParentUID) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "RgSAx9vhDX7WdTa8dAv8LJ",
+				"newParentUID": "TPnWrVQ9M8cBQq95HnLkt7"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"moveRole": {
+					"genericError": "role with id 5bb6dec8-cd8a-5add-951b-45d3abc34f17 is a core role type (not a normal role or a circle)",
+					"hasErrors": true
+				}
+			}
+			`,
+		},
+		// Move a role of rootRole-circle01 to rootRole-circle04
+		{
+			Query: `
+			mutation MoveRole($roleUID: ID!, $newParentUID: ID!) {
+				moveRole(roleUID: $roleUID, newParentUID: $newParentUID) {
+					hasErrors
+					genericError
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "7mrgT8NECqH3Z57snrzph4",
+				"newParentUID": "TPnWrVQ9M8cBQq95HnLkt7"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"moveRole": {
+					"genericError": null,
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		{
+			Query: `
+			query {
+				rootRole {
+					roles {
+						name
+						depth
+						roles {
+							name
+							depth
+						}
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"rootRole": {
+					"roles": [
+						{
+							"depth": 1,
+							"name": "Facilitator",
+							"roles": []
+						},
+						{
+							"depth": 1,
+							"name": "Lead Link",
+							"roles": []
+						},
+						{
+							"depth": 1,
+							"name": "Secretary",
+							"roles": []
+						},
+						{
+							"depth": 1,
+							"name": "rootRole-circle01",
+							"roles": [
+								{
+									"depth": 2,
+									"name": "Facilitator"
+								},
+								{
+									"depth": 2,
+									"name": "Lead Link"
+								},
+								{
+									"depth": 2,
+									"name": "Rep Link"
+								},
+								{
+									"depth": 2,
+									"name": "Secretary"
+								},
+								{
+									"depth": 2,
+									"name": "rootRole-circle01-role02"
+								},
+								{
+									"depth": 2,
+									"name": "rootRole-circle01-role03"
+								},
+								{
+									"depth": 2,
+									"name": "rootRole-circle01-role04"
+								},
+								{
+									"depth": 2,
+									"name": "rootRole-circle02"
+								}
+							]
+						},
+						{
+							"depth": 1,
+							"name": "rootRole-circle03",
+							"roles": [
+								{
+									"depth": 2,
+									"name": "Facilitator"
+								},
+								{
+									"depth": 2,
+									"name": "Lead Link"
+								},
+								{
+									"depth": 2,
+									"name": "Rep Link"
+								},
+								{
+									"depth": 2,
+									"name": "Secretary"
+								},
+								{
+									"depth": 2,
+									"name": "rootRole-circle03-role01"
+								},
+								{
+									"depth": 2,
+									"name": "rootRole-circle03-role02"
+								},
+								{
+									"depth": 2,
+									"name": "rootRole-circle03-role03"
+								},
+								{
+									"depth": 2,
+									"name": "rootRole-circle03-role04"
+								}
+							]
+						},
+						{
+							"depth": 1,
+							"name": "rootRole-circle04",
+							"roles": [
+								{
+									"depth": 2,
+									"name": "Facilitator"
+								},
+								{
+									"depth": 2,
+									"name": "Lead Link"
+								},
+								{
+									"depth": 2,
+									"name": "Rep Link"
+								},
+								{
+									"depth": 2,
+									"name": "Secretary"
+								},
+								{
+									"depth": 2,
+									"name": "rootRole-circle01-role01"
+								},
+								{
+									"depth": 2,
+									"name": "rootRole-circle04-role01"
+								},
+								{
+									"depth": 2,
+									"name": "rootRole-circle04-role02"
+								},
+								{
+									"depth": 2,
+									"name": "rootRole-circle04-role03"
+								},
+								{
+									"depth": 2,
+									"name": "rootRole-circle04-role04"
+								}
+							]
+						},
+						{
+							"depth": 1,
+							"name": "rootRole-role01",
+							"roles": []
+						},
+						{
+							"depth": 1,
+							"name": "rootRole-role02",
+							"roles": []
+						},
+						{
+							"depth": 1,
+							"name": "rootRole-role03",
+							"roles": []
+						},
+						{
+							"depth": 1,
+							"name": "rootRole-role04",
+							"roles": []
+						}
+					]
+				}
+			}
+			`,
+		},
+	})
+}
+
+func TestTimeLineDiff(t *testing.T) {
+	RunTests(t, initBasic, []*Test{
+		{
+			Query: `
+			mutation CircleUpdateChildRole($roleUID: ID!, $updateRoleChange: UpdateRoleChange!) {
+				circleUpdateChildRole(roleUID: $roleUID, updateRoleChange: $updateRoleChange) {
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "FDi26qza4rFLLTLdbqzpsd",
+				"updateRoleChange": {
+					"uid": "66c0cc1f-f608-53dc-88b5-f3afd68a4d6c",
+					"purposeChanged": true,
+					"purpose": "newpurpose01",
+					"createDomainChanges": [
+						{
+							"description": "domain01"
+						}
+					]
+				}
+			}
+			`,
+			ExpectedResult: `
+			{
+				"circleUpdateChildRole": {
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		{
+			Query: `
+			mutation RoleAddMember($roleUID: ID!, $memberUID: ID!) {
+				roleAddMember(roleUID: $roleUID, memberUID: $memberUID) {
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "sXPck8eJP5jC85jQkmNZVG",
+				"memberUID": "t9oc2y8syqYNNLfxfGkXM7"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"roleAddMember": {
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		{
+			Query: `
+			mutation MoveRole($roleUID: ID!, $newParentUID: ID!) {
+				moveRole(roleUID: $roleUID, newParentUID: $newParentUID) {
+					hasErrors
+				}
+			}
+			`,
+			Variables: `
+			{
+				"roleUID": "7mrgT8NECqH3Z57snrzph4",
+				"newParentUID": "TPnWrVQ9M8cBQq95HnLkt7"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"moveRole": {
+					"hasErrors": false
+				}
+			}
+			`,
+		},
+		// All the changes
+		{
+			Query: `
+			query TimeLineDiff($from: TimeLineID!, $to: TimeLineID!, $roleUID: ID) {
+				timeLineDiff(from: $from, to: $to, roleUID: $roleUID) {
+					roles {
+						changeType
+						role {
+							name
+							purpose
+						}
+						previousRole {
+							name
+							purpose
+						}
+						previousParent {
+							name
+						}
+						newParent {
+							name
+						}
+						createdDomains {
+							description
+						}
+						deletedDomains {
+							description
+						}
+						createdAccountabilities {
+							description
+						}
+						addedMembers {
+							userName
+						}
+						removedMembers {
+							userName
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"from": "-3",
+				"to": "0"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"timeLineDiff": {
+					"roles": [
+						{
+							"addedMembers": [],
+							"changeType": "updated",
+							"createdAccountabilities": [],
+							"createdDomains": [
+								{
+									"description": "domain01"
+								}
+							],
+							"deletedDomains": [],
+							"newParent": null,
+							"previousParent": null,
+							"previousRole": {
+								"name": "rootRole-circle01",
+								"purpose": ""
+							},
+							"removedMembers": [],
+							"role": {
+								"name": "rootRole-circle01",
+								"purpose": "newpurpose01"
+							}
+						},
+						{
+							"addedMembers": [],
+							"changeType": "updated",
+							"createdAccountabilities": [],
+							"createdDomains": [],
+							"deletedDomains": [],
+							"newParent": {
+								"name": "rootRole-circle04"
+							},
+							"previousParent": {
+								"name": "rootRole-circle01"
+							},
+							"previousRole": {
+								"name": "rootRole-circle01-role01",
+								"purpose": ""
+							},
+							"removedMembers": [],
+							"role": {
+								"name": "rootRole-circle01-role01",
+								"purpose": ""
+							}
+						},
+						{
+							"addedMembers": [
+								{
+									"userName": "user01"
+								}
+							],
+							"changeType": "updated",
+							"createdAccountabilities": [],
+							"createdDomains": [],
+							"deletedDomains": [],
+							"newParent": null,
+							"previousParent": null,
+							"previousRole": {
+								"name": "rootRole-role01",
+								"purpose": ""
+							},
+							"removedMembers": [],
+							"role": {
+								"name": "rootRole-role01",
+								"purpose": ""
+							}
+						}
+					]
+				}
+			}
+			`,
+		},
+		// Only the changes inside rootRole-circle01
+		{
+			Query: `
+			query TimeLineDiff($from: TimeLineID!, $to: TimeLineID!, $roleUID: ID) {
+				timeLineDiff(from: $from, to: $to, roleUID: $roleUID) {
+					roles {
+						changeType
+						role {
+							name
+							purpose
+						}
+						previousRole {
+							name
+							purpose
+						}
+						previousParent {
+							name
+						}
+						newParent {
+							name
+						}
+						createdDomains {
+							description
+						}
+						deletedDomains {
+							description
+						}
+						createdAccountabilities {
+							description
+						}
+						addedMembers {
+							userName
+						}
+						removedMembers {
+							userName
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"from": "-3",
+				"to": "0",
+				"roleUID": "LUJMgnvykhzsX6Edb656JL"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"timeLineDiff": {
+					"roles": [
+						{
+							"addedMembers": [],
+							"changeType": "updated",
+							"createdAccountabilities": [],
+							"createdDomains": [
+								{
+									"description": "domain01"
+								}
+							],
+							"deletedDomains": [],
+							"newParent": null,
+							"previousParent": null,
+							"previousRole": {
+								"name": "rootRole-circle01",
+								"purpose": ""
+							},
+							"removedMembers": [],
+							"role": {
+								"name": "rootRole-circle01",
+								"purpose": "newpurpose01"
+							}
+						},
+						{
+							"addedMembers": [],
+							"changeType": "updated",
+							"createdAccountabilities": [],
+							"createdDomains": [],
+							"deletedDomains": [],
+							"newParent": {
+								"name": "rootRole-circle04"
+							},
+							"previousParent": {
+								"name": "rootRole-circle01"
+							},
+							"previousRole": {
+								"name": "rootRole-circle01-role01",
+								"purpose": ""
+							},
+							"removedMembers": [],
+							"role": {
+								"name": "rootRole-circle01-role01",
+								"purpose": ""
+							}
+						}
+					]
+				}
+			}
+			`,
+		},
+		// Only the first change
+		{
+			Query: `
+			query TimeLineDiff($from: TimeLineID!, $to: TimeLineID!, $roleUID: ID) {
+				timeLineDiff(from: $from, to: $to, roleUID: $roleUID) {
+					roles {
+						changeType
+						role {
+							name
+							purpose
+						}
+						previousRole {
+							name
+							purpose
+						}
+						previousParent {
+							name
+						}
+						newParent {
+							name
+						}
+						createdDomains {
+							description
+						}
+						deletedDomains {
+							description
+						}
+						createdAccountabilities {
+							description
+						}
+						addedMembers {
+							userName
+						}
+						removedMembers {
+							userName
+						}
+					}
+				}
+			}
+			`,
+			Variables: `
+			{
+				"from": "-3",
+				"to": "-2"
+			}
+			`,
+			ExpectedResult: `
+			{
+				"timeLineDiff": {
+					"roles": [
+						{
+							"addedMembers": [],
+							"changeType": "updated",
+							"createdAccountabilities": [],
+							"createdDomains": [
+								{
+									"description": "domain01"
+								}
+							],
+							"deletedDomains": [],
+							"newParent": null,
+							"previousParent": null,
+							"previousRole": {
+								"name": "rootRole-circle01",
+								"purpose": ""
+							},
+							"removedMembers": [],
+							"role": {
+								"name": "rootRole-circle01",
+								"purpose": "newpurpose01"
+							}
+						}
+					]
+				}
+			}
+			`,
+		},
+	})
+}
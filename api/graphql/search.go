@@ -1,19 +1,20 @@
 package graphql
 
 import (
-	"encoding/json"
+	"context"
+	"sort"
 
 	"github.com/sorintlab/sircles/dataloader"
 	"github.com/sorintlab/sircles/readdb"
-
-	"github.com/blevesearch/bleve"
-	graphql "github.com/neelance/graphql-go"
-	"github.com/pkg/errors"
+	"github.com/sorintlab/sircles/search"
+	"github.com/sorintlab/sircles/util"
 )
 
 type searchResultResolver struct {
-	s   readdb.ReadDBService
-	res *bleve.SearchResult
+	s          readdb.ReadDBService
+	res        *search.SearchResult
+	from       int
+	timeLineID util.TimeLineNumber
 
 	dataLoaders *dataloader.DataLoaders
 }
@@ -24,19 +25,170 @@ func (r *searchResultResolver) TotalHits() int32 {
 	return int32(r.res.Total)
 }
 
-func (r *searchResultResolver) Hits() []graphql.ID {
-	ids := []graphql.ID{}
-	for _, res := range r.res.Hits {
-		ids = append(ids, graphql.ID(res.ID))
+func (r *searchResultResolver) HasMoreData() bool {
+	return uint64(r.from+len(r.res.Hits)) < r.res.Total
+}
+
+func (r *searchResultResolver) Edges() *[]*searchEdgeResolver {
+	l := make([]*searchEdgeResolver, len(r.res.Hits))
+	for i, hit := range r.res.Hits {
+		l[i] = &searchEdgeResolver{r.s, hit, r.from + i + 1, r.timeLineID, r.dataLoaders}
+	}
+	return &l
+}
+
+func (r *searchResultResolver) TypeFacets() []*searchFacetResolver {
+	return searchFacetResolvers(r.res.TypeFacets)
+}
+
+func (r *searchResultResolver) RoleTypeFacets() []*searchFacetResolver {
+	return searchFacetResolvers(r.res.RoleTypeFacets)
+}
+
+type searchEdgeResolver struct {
+	s   readdb.ReadDBService
+	hit *search.SearchHit
+	// offset is the offset of the next hit
+	offset     int
+	timeLineID util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *searchEdgeResolver) Cursor() (string, error) {
+	return marshalSearchCursor(&SearchCursor{Offset: r.offset})
+}
+
+func (r *searchEdgeResolver) Hit() *searchHitResolver {
+	return &searchHitResolver{r.s, r.hit, r.timeLineID, r.dataLoaders}
+}
+
+type searchHitResolver struct {
+	s          readdb.ReadDBService
+	hit        *search.SearchHit
+	timeLineID util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *searchHitResolver) Score() float64 {
+	return r.hit.Score
+}
+
+func (r *searchHitResolver) Fragments() *[]*searchFragmentResolver {
+	fields := []string{}
+	for field := range r.hit.Fragments {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	l := make([]*searchFragmentResolver, len(fields))
+	for i, field := range fields {
+		l[i] = &searchFragmentResolver{field, r.hit.Fragments[field]}
+	}
+	return &l
+}
+
+func (r *searchHitResolver) Node(ctx context.Context) (*searchHitNodeResolver, error) {
+	switch r.hit.Type {
+	case search.RoleType:
+		role, err := r.s.Role(ctx, r.timeLineID, r.hit.ID)
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			return nil, nil
+		}
+		return &searchHitNodeResolver{&roleResolver{r.s, role, r.timeLineID, r.dataLoaders}}, nil
+	case search.MemberType:
+		member, err := r.s.Member(ctx, r.timeLineID, r.hit.ID)
+		if err != nil {
+			return nil, err
+		}
+		if member == nil {
+			return nil, nil
+		}
+		return &searchHitNodeResolver{&memberResolver{r.s, member, r.timeLineID, r.dataLoaders}}, nil
+	case search.TensionType:
+		tension, err := r.s.Tension(ctx, r.timeLineID, r.hit.ID)
+		if err != nil {
+			return nil, err
+		}
+		if tension == nil {
+			return nil, nil
+		}
+		return &searchHitNodeResolver{&tensionResolver{r.s, tension, r.timeLineID, r.dataLoaders}}, nil
 	}
-	return ids
+	return nil, nil
+}
+
+type searchHitNodeResolver struct {
+	node interface{}
+}
+
+func (r *searchHitNodeResolver) ToRole() (*roleResolver, bool) {
+	t, ok := r.node.(*roleResolver)
+	return t, ok
+}
+
+func (r *searchHitNodeResolver) ToMember() (*memberResolver, bool) {
+	t, ok := r.node.(*memberResolver)
+	return t, ok
 }
 
-func (r *searchResultResolver) Result() (string, error) {
-	res, err := json.Marshal(r.res)
-	if err != nil {
-		return "", errors.Wrapf(err, "error marshalling search result")
+func (r *searchHitNodeResolver) ToTension() (*tensionResolver, bool) {
+	t, ok := r.node.(*tensionResolver)
+	return t, ok
+}
+
+type searchFragmentResolver struct {
+	field     string
+	fragments []string
+}
+
+func (r *searchFragmentResolver) Field() string {
+	return r.field
+}
+
+func (r *searchFragmentResolver) Fragments() []string {
+	return r.fragments
+}
+
+type searchFacetResolver struct {
+	facet *search.SearchFacet
+}
+
+func (r *searchFacetResolver) Term() string {
+	return r.facet.Term
+}
+
+func (r *searchFacetResolver) Count() int32 {
+	return int32(r.facet.Count)
+}
+
+func searchFacetResolvers(facets []*search.SearchFacet) []*searchFacetResolver {
+	l := make([]*searchFacetResolver, len(facets))
+	for i, facet := range facets {
+		l[i] = &searchFacetResolver{facet}
 	}
+	return l
+}
+
+// searchIndexStatusResolver returns the sequence numbers as graphql Int
+// (int32)
+type searchIndexStatusResolver struct {
+	indexedSeqNumber int64
+	lastSeqNumber    int64
+}
+
+func (r *searchIndexStatusResolver) LastIndexedSequenceNumber() int32 {
+	return int32(r.indexedSeqNumber)
+}
+
+func (r *searchIndexStatusResolver) LastEventSequenceNumber() int32 {
+	return int32(r.lastSeqNumber)
+}
 
-	return string(res), nil
+func (r *searchIndexStatusResolver) Lag() int32 {
+	return int32(r.lastSeqNumber - r.indexedSeqNumber)
 }
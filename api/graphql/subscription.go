@@ -0,0 +1,230 @@
+package graphql
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/sorintlab/sircles/dataloader"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/util"
+
+	graphql "github.com/neelance/graphql-go"
+	"github.com/pkg/errors"
+)
+
+// NOTE(sgotti) the graphql library parses and validates subscription
+// operations but doesn't execute them. So subscriptions are executed using
+// SubscriptionSchema, a schema with the same types where Subscription is the
+// query entry point, after converting the subscription operation to a query
+// operation with SubscriptionQuery.
+// Every execution reports the changes between the timelines provided in the
+// context with the "subscriptiontimelines" key.
+
+var schemaEntryPointsRegexp = regexp.MustCompile(`schema \{[^}]*\}`)
+
+// SubscriptionSchema is the GraphQL schema used to execute subscriptions
+var SubscriptionSchema = replaceFirst(schemaEntryPointsRegexp, Schema, "schema {\n\t\tquery: Subscription\n\t}")
+
+var subscriptionOperationRegexp = regexp.MustCompile(`(^|\}|\n)([ \t\r\n,]*)subscription\b`)
+
+func replaceFirst(re *regexp.Regexp, s, repl string) string {
+	loc := re.FindStringIndex(s)
+	if loc == nil {
+		return s
+	}
+	return s[:loc[0]] + repl + s[loc[1]:]
+}
+
+// SubscriptionQuery converts the subscription operations of the provided
+// document to query operations to be executed with SubscriptionSchema
+func SubscriptionQuery(query string) (string, error) {
+	if !subscriptionOperationRegexp.MatchString(query) {
+		return "", errors.New("only subscription operations are supported")
+	}
+	return subscriptionOperationRegexp.ReplaceAllString(query, "${1}${2}query"), nil
+}
+
+// SubscriptionTimeLines are the timelines between which the subscription
+// fields report the changes
+type SubscriptionTimeLines struct {
+	From util.TimeLineNumber
+	To   util.TimeLineNumber
+}
+
+type SubscriptionResolver struct {
+	r *Resolver
+}
+
+func NewSubscriptionResolver() *SubscriptionResolver {
+	return &SubscriptionResolver{r: NewResolver()}
+}
+
+func subscriptionTimeLines(ctx context.Context) *SubscriptionTimeLines {
+	return ctx.Value("subscriptiontimelines").(*SubscriptionTimeLines)
+}
+
+func (r *SubscriptionResolver) TimeLines(ctx context.Context) (*[]*timeLineResolver, error) {
+	stls := subscriptionTimeLines(ctx)
+	if stls.From >= stls.To {
+		return nil, nil
+	}
+
+	s, err := r.r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dataLoaders := dataloader.NewDataLoaders(ctx, s)
+
+	l := []*timeLineResolver{}
+	from := stls.From
+	for {
+		timeLines, hasMoreData, err := s.TimeLines(ctx, nil, from, 0, true, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, tl := range timeLines {
+			if tl.Number() > stls.To {
+				hasMoreData = false
+				break
+			}
+			l = append(l, &timeLineResolver{s, tl, dataLoaders})
+			from = tl.Number()
+		}
+		if !hasMoreData {
+			break
+		}
+	}
+
+	if len(l) == 0 {
+		return nil, nil
+	}
+	return &l, nil
+}
+
+func (r *SubscriptionResolver) RoleChanges(ctx context.Context, args *struct {
+	RoleUID *graphql.ID
+}) (*timeLineDiffResolver, error) {
+	stls := subscriptionTimeLines(ctx)
+	if stls.From >= stls.To {
+		return nil, nil
+	}
+
+	s, err := r.r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var roleID *util.ID
+	if args.RoleUID != nil {
+		id, err := unmarshalUID(*args.RoleUID)
+		if err != nil {
+			return nil, err
+		}
+		roleID = &id
+	}
+
+	fromTl, err := s.TimeLine(ctx, stls.From)
+	if err != nil {
+		return nil, err
+	}
+	toTl, err := s.TimeLine(ctx, stls.To)
+	if err != nil {
+		return nil, err
+	}
+	// no timelines before the subscription start
+	if fromTl == nil || toTl == nil {
+		return nil, nil
+	}
+
+	diffs, err := s.RolesDiff(ctx, stls.From, stls.To, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if len(diffs) == 0 {
+		return nil, nil
+	}
+	return &timeLineDiffResolver{s, fromTl, toTl, diffs, dataloader.NewDataLoaders(ctx, s)}, nil
+}
+
+// ViewerTensions returns the tensions of the viewer created or updated
+// (including role and assignee changes) after the from timeline
+func (r *SubscriptionResolver) ViewerTensions(ctx context.Context) (*[]*tensionResolver, error) {
+	stls := subscriptionTimeLines(ctx)
+	if stls.From >= stls.To {
+		return nil, nil
+	}
+
+	s, err := r.r.setupReadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.CallingMember(ctx, stls.To)
+	if err != nil {
+		return nil, err
+	}
+
+	tensionsGroups, err := s.MemberTensions(ctx, stls.To, []util.ID{member.ID})
+	if err != nil {
+		return nil, err
+	}
+	tensions := tensionsGroups[member.ID]
+	if len(tensions) == 0 {
+		return nil, nil
+	}
+
+	tensionsIDs := make([]util.ID, len(tensions))
+	for i, tension := range tensions {
+		tensionsIDs[i] = tension.ID
+	}
+	prevRoles, err := s.TensionRole(ctx, stls.From, tensionsIDs)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.TensionRole(ctx, stls.To, tensionsIDs)
+	if err != nil {
+		return nil, err
+	}
+	prevAssignees, err := s.TensionAssignee(ctx, stls.From, tensionsIDs)
+	if err != nil {
+		return nil, err
+	}
+	assignees, err := s.TensionAssignee(ctx, stls.To, tensionsIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	dataLoaders := dataloader.NewDataLoaders(ctx, s)
+	l := []*tensionResolver{}
+	for _, tension := range tensions {
+		changed := tension.StartTl > int64(stls.From)
+		if prevRole, role := prevRoles[tension.ID], roles[tension.ID]; !sameRole(prevRole, role) {
+			changed = true
+		}
+		if prevAssignee, assignee := prevAssignees[tension.ID], assignees[tension.ID]; !sameMember(prevAssignee, assignee) {
+			changed = true
+		}
+		if changed {
+			l = append(l, &tensionResolver{s, tension, stls.To, dataLoaders})
+		}
+	}
+
+	if len(l) == 0 {
+		return nil, nil
+	}
+	return &l, nil
+}
+
+func sameRole(a, b *models.Role) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.ID == b.ID
+}
+
+func sameMember(a, b *models.Member) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.ID == b.ID
+}
@@ -0,0 +1,71 @@
+package graphql
+
+import (
+	"testing"
+
+	graphql "github.com/neelance/graphql-go"
+)
+
+func TestSubscriptionSchema(t *testing.T) {
+	if _, err := graphql.ParseSchema(SubscriptionSchema, NewSubscriptionResolver()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSubscriptionQuery(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+		err      bool
+	}{
+		{
+			query:    `subscription { timeLines { id } }`,
+			expected: `query { timeLines { id } }`,
+		},
+		{
+			query: `
+			# role changes
+			subscription RoleChanges($roleUID: ID) {
+				roleChanges(roleUID: $roleUID) { roles { changeType } }
+			}`,
+			expected: `
+			# role changes
+			query RoleChanges($roleUID: ID) {
+				roleChanges(roleUID: $roleUID) { roles { changeType } }
+			}`,
+		},
+		{
+			query: `
+			fragment T on Tension { title }
+			subscription { viewerTensions { ...T } }`,
+			expected: `
+			fragment T on Tension { title }
+			query { viewerTensions { ...T } }`,
+		},
+		{
+			query: `query { timeLines { id } }`,
+			err:   true,
+		},
+		{
+			query: `{ subscriptions }`,
+			err:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		out, err := SubscriptionQuery(tt.query)
+		if tt.err {
+			if err == nil {
+				t.Errorf("expected error for query %q", tt.query)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if out != tt.expected {
+			t.Errorf("expected query %q, got %q", tt.expected, out)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package graphql
 
 import (
+	"context"
+
 	"github.com/sorintlab/sircles/change"
 	"github.com/sorintlab/sircles/dataloader"
 	"github.com/sorintlab/sircles/models"
@@ -50,6 +52,51 @@ func (r *tensionResolver) CloseReason() string {
 	return r.t.CloseReason
 }
 
+func (r *tensionResolver) Status() string {
+	return string(r.t.Status)
+}
+
+func (r *tensionResolver) Assignee() (*memberResolver, error) {
+	data, err := r.dataLoaders.Get(r.timeLine).TensionAssignee.Load(r.t.ID.String())()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	member := data.(*models.Member)
+	return &memberResolver{r.s, member, r.timeLine, r.dataLoaders}, nil
+}
+
+func (r *tensionResolver) MeetingUID() *graphql.ID {
+	if r.t.MeetingID == nil {
+		return nil
+	}
+	uid := marshalUID("meeting", *r.t.MeetingID)
+	return &uid
+}
+
+func (r *tensionResolver) Meeting(ctx context.Context) (*meetingResolver, error) {
+	if r.t.MeetingID == nil {
+		return nil, nil
+	}
+	meeting, err := r.s.Meeting(ctx, r.timeLine, *r.t.MeetingID)
+	if err != nil {
+		return nil, err
+	}
+	if meeting == nil {
+		return nil, nil
+	}
+	return &meetingResolver{r.s, meeting, r.timeLine, r.dataLoaders}, nil
+}
+
+func (r *tensionResolver) Outcome() *tensionOutcomeResolver {
+	if r.t.Status != models.TensionStatusResolved {
+		return nil
+	}
+	return &tensionOutcomeResolver{r.t}
+}
+
 func (r *tensionResolver) Member() (*memberResolver, error) {
 	data, err := r.dataLoaders.Get(r.timeLine).TensionMember.Load(r.t.ID.String())()
 	if err != nil {
@@ -62,6 +109,26 @@ func (r *tensionResolver) Member() (*memberResolver, error) {
 	return &memberResolver{r.s, member, r.timeLine, r.dataLoaders}, nil
 }
 
+type tensionOutcomeResolver struct {
+	t *models.Tension
+}
+
+func (r *tensionOutcomeResolver) OutcomeType() string {
+	return string(r.t.OutcomeType)
+}
+
+func (r *tensionOutcomeResolver) ReferenceUID() *graphql.ID {
+	if r.t.OutcomeReferenceID == nil {
+		return nil
+	}
+	uid := marshalUID(string(r.t.OutcomeType), *r.t.OutcomeReferenceID)
+	return &uid
+}
+
+func (r *tensionOutcomeResolver) Description() string {
+	return r.t.OutcomeDescription
+}
+
 type createTensionResultResolver struct {
 	s        readdb.ReadDBService
 	tension  *models.Tension
@@ -157,3 +224,27 @@ func (r *closeTensionResultResolver) HasErrors() bool {
 func (r *closeTensionResultResolver) GenericError() *string {
 	return errorToStringP(r.res.GenericError)
 }
+
+type tensionTriageResultResolver struct {
+	s        readdb.ReadDBService
+	tension  *models.Tension
+	res      *change.GenericResult
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *tensionTriageResultResolver) Tension() *tensionResolver {
+	if r.tension == nil {
+		return nil
+	}
+	return &tensionResolver{r.s, r.tension, r.timeLine, r.dataLoaders}
+}
+
+func (r *tensionTriageResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *tensionTriageResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
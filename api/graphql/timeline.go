@@ -4,6 +4,7 @@ import (
 	"strconv"
 
 	"github.com/sorintlab/sircles/dataloader"
+	"github.com/sorintlab/sircles/models"
 	"github.com/sorintlab/sircles/readdb"
 	"github.com/sorintlab/sircles/util"
 
@@ -63,3 +64,125 @@ func (r *timeLineResolver) ID() util.TimeLineNumber {
 func (r *timeLineResolver) Time() graphql.Time {
 	return graphql.Time{Time: r.timeLine.Timestamp}
 }
+
+type timeLineDiffResolver struct {
+	s      readdb.ReadDBService
+	fromTl *util.TimeLine
+	toTl   *util.TimeLine
+	diffs  []*models.RoleDiff
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *timeLineDiffResolver) From() *timeLineResolver {
+	return &timeLineResolver{r.s, r.fromTl, r.dataLoaders}
+}
+
+func (r *timeLineDiffResolver) To() *timeLineResolver {
+	return &timeLineResolver{r.s, r.toTl, r.dataLoaders}
+}
+
+func (r *timeLineDiffResolver) Roles() *[]*roleDiffResolver {
+	l := make([]*roleDiffResolver, len(r.diffs))
+	for i, diff := range r.diffs {
+		l[i] = &roleDiffResolver{r.s, diff, r.fromTl.Number(), r.toTl.Number(), r.dataLoaders}
+	}
+	return &l
+}
+
+type roleDiffResolver struct {
+	s      readdb.ReadDBService
+	diff   *models.RoleDiff
+	fromTl util.TimeLineNumber
+	toTl   util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+}
+
+func (r *roleDiffResolver) ChangeType() string {
+	return string(r.diff.ChangeType)
+}
+
+func (r *roleDiffResolver) Role() *roleResolver {
+	if r.diff.Role == nil {
+		return nil
+	}
+	return NewRoleResolver(r.s, r.diff.Role, r.toTl, r.dataLoaders)
+}
+
+func (r *roleDiffResolver) PreviousRole() *roleResolver {
+	if r.diff.PreviousRole == nil {
+		return nil
+	}
+	return NewRoleResolver(r.s, r.diff.PreviousRole, r.fromTl, r.dataLoaders)
+}
+
+func (r *roleDiffResolver) PreviousParent() *roleResolver {
+	if r.diff.PreviousParent == nil {
+		return nil
+	}
+	return NewRoleResolver(r.s, r.diff.PreviousParent, r.fromTl, r.dataLoaders)
+}
+
+func (r *roleDiffResolver) NewParent() *roleResolver {
+	if r.diff.NewParent == nil {
+		return nil
+	}
+	return NewRoleResolver(r.s, r.diff.NewParent, r.toTl, r.dataLoaders)
+}
+
+func (r *roleDiffResolver) domains(domains []*models.Domain, tl util.TimeLineNumber) *[]*domainResolver {
+	l := make([]*domainResolver, len(domains))
+	for i, domain := range domains {
+		l[i] = &domainResolver{r.s, domain, tl, r.dataLoaders}
+	}
+	return &l
+}
+
+func (r *roleDiffResolver) CreatedDomains() *[]*domainResolver {
+	return r.domains(r.diff.CreatedDomains, r.toTl)
+}
+
+func (r *roleDiffResolver) UpdatedDomains() *[]*domainResolver {
+	return r.domains(r.diff.UpdatedDomains, r.toTl)
+}
+
+func (r *roleDiffResolver) DeletedDomains() *[]*domainResolver {
+	return r.domains(r.diff.DeletedDomains, r.fromTl)
+}
+
+func (r *roleDiffResolver) accountabilities(accountabilities []*models.Accountability, tl util.TimeLineNumber) *[]*accountabilityResolver {
+	l := make([]*accountabilityResolver, len(accountabilities))
+	for i, accountability := range accountabilities {
+		l[i] = &accountabilityResolver{r.s, accountability, tl, r.dataLoaders}
+	}
+	return &l
+}
+
+func (r *roleDiffResolver) CreatedAccountabilities() *[]*accountabilityResolver {
+	return r.accountabilities(r.diff.CreatedAccountabilities, r.toTl)
+}
+
+func (r *roleDiffResolver) UpdatedAccountabilities() *[]*accountabilityResolver {
+	return r.accountabilities(r.diff.UpdatedAccountabilities, r.toTl)
+}
+
+func (r *roleDiffResolver) DeletedAccountabilities() *[]*accountabilityResolver {
+	return r.accountabilities(r.diff.DeletedAccountabilities, r.fromTl)
+}
+
+func (r *roleDiffResolver) members(members []*models.Member, tl util.TimeLineNumber) *[]*memberResolver {
+	l := make([]*memberResolver, len(members))
+	for i, member := range members {
+		l[i] = &memberResolver{r.s, member, tl, r.dataLoaders}
+	}
+	return &l
+}
+
+func (r *roleDiffResolver) AddedMembers() *[]*memberResolver {
+	return r.members(r.diff.AddedMembers, r.toTl)
+}
+
+func (r *roleDiffResolver) RemovedMembers() *[]*memberResolver {
+	return r.members(r.diff.RemovedMembers, r.fromTl)
+}
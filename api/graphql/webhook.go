@@ -0,0 +1,115 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/sorintlab/sircles/change"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/readdb"
+	"github.com/sorintlab/sircles/util"
+
+	graphql "github.com/neelance/graphql-go"
+)
+
+type webhookResolver struct {
+	s        readdb.ReadDBService
+	w        *models.Webhook
+	timeLine util.TimeLineNumber
+}
+
+func (r *webhookResolver) UID() graphql.ID {
+	return marshalUID("webhook", r.w.ID)
+}
+
+func (r *webhookResolver) URL() string {
+	return r.w.URL
+}
+
+func (r *webhookResolver) EventTypes() []string {
+	return r.w.EventTypes
+}
+
+func (r *webhookResolver) DeadLetters(ctx context.Context) (*[]*webhookDeadLetterResolver, error) {
+	deadLettersGroups, err := r.s.WebhookDeadLetters(ctx, r.timeLine, []util.ID{r.w.ID})
+	if err != nil {
+		return nil, err
+	}
+	deadLetters := deadLettersGroups[r.w.ID]
+	l := make([]*webhookDeadLetterResolver, len(deadLetters))
+	for i, deadLetter := range deadLetters {
+		l[i] = &webhookDeadLetterResolver{deadLetter}
+	}
+	return &l, nil
+}
+
+type webhookDeadLetterResolver struct {
+	dl *models.WebhookDeadLetter
+}
+
+func (r *webhookDeadLetterResolver) EventID() string {
+	return r.dl.EventID.String()
+}
+
+func (r *webhookDeadLetterResolver) SequenceNumber() int32 {
+	return int32(r.dl.SequenceNumber)
+}
+
+func (r *webhookDeadLetterResolver) EventType() string {
+	return r.dl.EventType
+}
+
+func (r *webhookDeadLetterResolver) Attempts() int32 {
+	return int32(r.dl.Attempts)
+}
+
+func (r *webhookDeadLetterResolver) LastError() string {
+	return r.dl.LastError
+}
+
+func (r *webhookDeadLetterResolver) Time() graphql.Time {
+	return graphql.Time{Time: r.dl.Timestamp}
+}
+
+type createWebhookResultResolver struct {
+	s        readdb.ReadDBService
+	webhook  *models.Webhook
+	res      *change.CreateWebhookResult
+	timeLine util.TimeLineNumber
+}
+
+func (r *createWebhookResultResolver) Webhook() *webhookResolver {
+	if r.webhook == nil {
+		return nil
+	}
+	return &webhookResolver{r.s, r.webhook, r.timeLine}
+}
+
+func (r *createWebhookResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *createWebhookResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
+
+type webhookResultResolver struct {
+	s        readdb.ReadDBService
+	webhook  *models.Webhook
+	res      *change.GenericResult
+	timeLine util.TimeLineNumber
+}
+
+func (r *webhookResultResolver) Webhook() *webhookResolver {
+	if r.webhook == nil {
+		return nil
+	}
+	return &webhookResolver{r.s, r.webhook, r.timeLine}
+}
+
+func (r *webhookResultResolver) HasErrors() bool {
+	return r.res.HasErrors
+}
+
+func (r *webhookResultResolver) GenericError() *string {
+	return errorToStringP(r.res.GenericError)
+}
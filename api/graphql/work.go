@@ -0,0 +1,117 @@
+package graphql
+
+import (
+	"time"
+
+	"github.com/sorintlab/sircles/dataloader"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/readdb"
+	"github.com/sorintlab/sircles/util"
+
+	graphql "github.com/neelance/graphql-go"
+)
+
+// workResolver resolves the fields shared by the role projects and actions
+type workResolver struct {
+	s        readdb.ReadDBService
+	timeLine util.TimeLineNumber
+
+	dataLoaders *dataloader.DataLoaders
+
+	// kind is the work kind: "project" or "action"
+	kind        string
+	id          util.ID
+	title       string
+	description string
+	status      models.WorkStatus
+	dueDate     *time.Time
+}
+
+func (r *workResolver) UID() graphql.ID {
+	return marshalUID(r.kind, r.id)
+}
+
+func (r *workResolver) Title() string {
+	return r.title
+}
+
+func (r *workResolver) Description() string {
+	return r.description
+}
+
+func (r *workResolver) Status() string {
+	return r.status.String()
+}
+
+func (r *workResolver) DueDate() *graphql.Time {
+	if r.dueDate == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.dueDate}
+}
+
+func (r *workResolver) Role() (*roleResolver, error) {
+	dls := r.dataLoaders.Get(r.timeLine)
+	loader := dls.ProjectRole
+	if r.kind == "action" {
+		loader = dls.ActionRole
+	}
+	data, err := loader.Load(r.id.String())()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	role := data.(*models.Role)
+	return &roleResolver{r.s, role, r.timeLine, r.dataLoaders}, nil
+}
+
+func (r *workResolver) Member() (*memberResolver, error) {
+	dls := r.dataLoaders.Get(r.timeLine)
+	loader := dls.ProjectMember
+	if r.kind == "action" {
+		loader = dls.ActionMember
+	}
+	data, err := loader.Load(r.id.String())()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	member := data.(*models.Member)
+	return &memberResolver{r.s, member, r.timeLine, r.dataLoaders}, nil
+}
+
+func (r *workResolver) Tension() (*tensionResolver, error) {
+	dls := r.dataLoaders.Get(r.timeLine)
+	loader := dls.ProjectTension
+	if r.kind == "action" {
+		loader = dls.ActionTension
+	}
+	data, err := loader.Load(r.id.String())()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	tension := data.(*models.Tension)
+	return &tensionResolver{r.s, tension, r.timeLine, r.dataLoaders}, nil
+}
+
+// workChangeErrorsResolver resolves the create and update project and action
+// change errors
+type workChangeErrorsResolver struct {
+	title       error
+	description error
+}
+
+func (r *workChangeErrorsResolver) Title() *string {
+	return errorToStringP(r.title)
+}
+
+func (r *workChangeErrorsResolver) Description() *string {
+	return errorToStringP(r.description)
+}
@@ -1,6 +1,8 @@
 package change
 
 import (
+	"time"
+
 	"github.com/sorintlab/sircles/models"
 	"github.com/sorintlab/sircles/util"
 )
@@ -71,6 +73,12 @@ type UpdateRoleChange struct {
 	CreateAccountabilityChanges []CreateAccountabilityChange
 	UpdateAccountabilityChanges []UpdateAccountabilityChange
 	DeleteAccountabilityChanges []DeleteAccountabilityChange
+	CreateChecklistItemChanges  []CreateChecklistItemChange
+	UpdateChecklistItemChanges  []UpdateChecklistItemChange
+	DeleteChecklistItemChanges  []DeleteChecklistItemChange
+	CreateMetricChanges         []CreateMetricChange
+	UpdateMetricChanges         []UpdateMetricChange
+	DeleteMetricChanges         []DeleteMetricChange
 
 	MakeCircle bool
 	MakeRole   bool
@@ -93,6 +101,10 @@ type UpdateRoleChangeErrors struct {
 	UpdateDomainChangesErrors         []UpdateDomainChangeErrors
 	CreateAccountabilityChangesErrors []CreateAccountabilityChangeErrors
 	UpdateAccountabilityChangesErrors []UpdateAccountabilityChangeErrors
+	CreateChecklistItemChangesErrors  []CreateChecklistItemChangeErrors
+	UpdateChecklistItemChangesErrors  []UpdateChecklistItemChangeErrors
+	CreateMetricChangesErrors         []CreateMetricChangeErrors
+	UpdateMetricChangesErrors         []UpdateMetricChangeErrors
 	RolesFromParent                   []error
 }
 
@@ -150,11 +162,60 @@ type DeleteAccountabilityChange struct {
 	ID util.ID
 }
 
+type CreateChecklistItemChange struct {
+	Description string
+}
+
+type CreateChecklistItemChangeErrors struct {
+	Description error
+}
+
+type UpdateChecklistItemChangeErrors struct {
+	Description error
+}
+
+type UpdateChecklistItemChange struct {
+	ID                 util.ID
+	DescriptionChanged bool
+	Description        string
+}
+
+type DeleteChecklistItemChange struct {
+	ID util.ID
+}
+
+type CreateMetricChange struct {
+	Description string
+}
+
+type CreateMetricChangeErrors struct {
+	Description error
+}
+
+type UpdateMetricChangeErrors struct {
+	Description error
+}
+
+type UpdateMetricChange struct {
+	ID                 util.ID
+	DescriptionChanged bool
+	Description        string
+}
+
+type DeleteMetricChange struct {
+	ID util.ID
+}
+
 type SetRoleAdditionalContentResult struct {
 	HasErrors    bool
 	GenericError error
 }
 
+type MoveRoleResult struct {
+	HasErrors    bool
+	GenericError error
+}
+
 type AvatarData struct {
 	Avatar   []byte
 	CropX    int
@@ -163,13 +224,14 @@ type AvatarData struct {
 }
 
 type CreateMemberChange struct {
-	IsAdmin    bool
-	MatchUID   string
-	UserName   string
-	FullName   string
-	Email      string
-	Password   string
-	AvatarData *AvatarData
+	IsAdmin          bool
+	IsServiceAccount bool
+	MatchUID         string
+	UserName         string
+	FullName         string
+	Email            string
+	Password         string
+	AvatarData       *AvatarData
 }
 
 type CreateMemberResult struct {
@@ -212,6 +274,14 @@ type UpdateMemberChangeErrors struct {
 	AvatarData error
 }
 
+// DeactivateMemberResult reports the member assignments at deactivation time
+// (removed when requested)
+type DeactivateMemberResult struct {
+	Assignments  *models.MemberAssignments
+	HasErrors    bool
+	GenericError error
+}
+
 type CreateTensionResult struct {
 	TensionID                 *util.ID
 	HasErrors                 bool
@@ -251,6 +321,8 @@ type UpdateTensionChangeErrors struct {
 type CloseTensionChange struct {
 	ID     util.ID
 	Reason string
+	// MeetingID is the optional meeting where the tension was processed
+	MeetingID *util.ID
 }
 
 type CloseTensionResult struct {
@@ -258,6 +330,287 @@ type CloseTensionResult struct {
 	GenericError error
 }
 
+type ResolveTensionChange struct {
+	ID                 util.ID
+	OutcomeType        models.TensionOutcomeType
+	OutcomeReferenceID *util.ID
+	OutcomeDescription string
+}
+
+type CreateProjectChange struct {
+	RoleID      util.ID
+	MemberID    *util.ID
+	TensionID   *util.ID
+	Title       string
+	Description string
+	DueDate     *time.Time
+}
+
+type CreateProjectResult struct {
+	ProjectID                 *util.ID
+	HasErrors                 bool
+	GenericError              error
+	CreateProjectChangeErrors CreateProjectChangeErrors
+}
+
+type CreateProjectChangeErrors struct {
+	Title       error
+	Description error
+}
+
+type UpdateProjectChange struct {
+	ID          util.ID
+	MemberID    *util.ID
+	Title       string
+	Description string
+	DueDate     *time.Time
+}
+
+type UpdateProjectResult struct {
+	HasErrors                 bool
+	GenericError              error
+	UpdateProjectChangeErrors UpdateProjectChangeErrors
+}
+
+type UpdateProjectChangeErrors struct {
+	Title       error
+	Description error
+}
+
+type CreateActionChange struct {
+	RoleID      util.ID
+	MemberID    *util.ID
+	TensionID   *util.ID
+	Title       string
+	Description string
+	DueDate     *time.Time
+}
+
+type CreateActionResult struct {
+	ActionID                 *util.ID
+	HasErrors                bool
+	GenericError             error
+	CreateActionChangeErrors CreateActionChangeErrors
+}
+
+type CreateActionChangeErrors struct {
+	Title       error
+	Description error
+}
+
+type UpdateActionChange struct {
+	ID          util.ID
+	MemberID    *util.ID
+	Title       string
+	Description string
+	DueDate     *time.Time
+}
+
+type UpdateActionResult struct {
+	HasErrors                bool
+	GenericError             error
+	UpdateActionChangeErrors UpdateActionChangeErrors
+}
+
+type UpdateActionChangeErrors struct {
+	Title       error
+	Description error
+}
+
+type CreateMeetingChange struct {
+	RoleID      util.ID
+	MeetingType models.MeetingType
+	Date        time.Time
+	// AttendeesIDs are the circle members that attended the meeting. When
+	// empty all the circle members are considered attendees
+	AttendeesIDs []util.ID
+}
+
+type CreateMeetingResult struct {
+	MeetingID    *util.ID
+	HasErrors    bool
+	GenericError error
+}
+
+type CreateElectionChange struct {
+	RoleID   util.ID
+	RoleType models.RoleType
+}
+
+type CreateElectionResult struct {
+	ElectionID   *util.ID
+	HasErrors    bool
+	GenericError error
+}
+
+// CreateRoleTemplateChange saves the purpose, domains and accountabilities of
+// an existing role as a named template
+type CreateRoleTemplateChange struct {
+	RoleID util.ID
+	Name   string
+}
+
+type CreateRoleTemplateResult struct {
+	RoleTemplateID *util.ID
+	HasErrors      bool
+	GenericError   error
+}
+
+type UpdateRoleTemplateChange struct {
+	ID               util.ID
+	Name             string
+	Purpose          string
+	Domains          []string
+	Accountabilities []string
+}
+
+// InstantiateRoleTemplateChange creates a new role from a template in the
+// provided circle. When Linked is true the new role is kept linked to the
+// template so template updates can be proposed to it
+type InstantiateRoleTemplateChange struct {
+	RoleTemplateID util.ID
+	RoleID         util.ID
+	// Name overrides the template name as the new role name
+	Name   *string
+	Linked bool
+}
+
+type ProposeRoleTemplateUpdateResult struct {
+	ProposalsIDs []util.ID
+	HasErrors    bool
+	GenericError error
+}
+
+// CreateWebhookChange registers an endpoint receiving the events of the
+// provided types
+type CreateWebhookChange struct {
+	URL        string
+	EventTypes []string
+	Secret     string
+}
+
+type CreateWebhookResult struct {
+	WebhookID    *util.ID
+	HasErrors    bool
+	GenericError error
+}
+
+// UpdateWebhookChange updates a webhook. When Secret is nil the current secret
+// is kept
+type UpdateWebhookChange struct {
+	ID         util.ID
+	URL        string
+	EventTypes []string
+	Secret     *string
+}
+
+// RefreshSessionResult contains the new refresh token of the refreshed
+// session
+type RefreshSessionResult struct {
+	SessionID    util.ID
+	MemberID     util.ID
+	Expiration   time.Time
+	RefreshToken string
+	HasErrors    bool
+	GenericError error
+}
+
+// CreateAPITokenChange creates an api token for the provided member (the
+// calling member or a service account)
+type CreateAPITokenChange struct {
+	MemberID   util.ID
+	Name       string
+	Scope      models.APITokenScope
+	Expiration time.Time
+}
+
+// CreateAPITokenResult contains the generated token. It's available only here
+// since just its hash is saved
+type CreateAPITokenResult struct {
+	APITokenID   *util.ID
+	Token        string
+	HasErrors    bool
+	GenericError error
+}
+
+// ReportMeetingValuesChange records the values reported, during a meeting,
+// for the checklist items and metrics of the circle roles
+type ReportMeetingValuesChange struct {
+	MeetingID            util.ID
+	ChecklistItemReports []ChecklistItemReport
+	MetricReports        []MetricReport
+}
+
+type ChecklistItemReport struct {
+	ChecklistItemID util.ID
+	Checked         bool
+}
+
+type MetricReport struct {
+	MetricID util.ID
+	Value    string
+}
+
+// ProposalChanges are the role changes that a proposal will apply to its
+// circle when accepted
+type ProposalChanges struct {
+	CreateRoleChanges []CreateRoleChange
+	UpdateRoleChanges []UpdateRoleChange
+	DeleteRoleChanges []DeleteRoleChange
+}
+
+type CreateProposalChange struct {
+	RoleID      util.ID
+	Title       string
+	Description string
+	ProposalChanges
+}
+
+type CreateProposalResult struct {
+	ProposalID                 *util.ID
+	HasErrors                  bool
+	GenericError               error
+	CreateProposalChangeErrors CreateProposalChangeErrors
+}
+
+type CreateProposalChangeErrors struct {
+	Title                   error
+	Description             error
+	CreateRoleChangesErrors []CreateRoleChangeErrors
+	UpdateRoleChangesErrors []UpdateRoleChangeErrors
+}
+
+type UpdateProposalChange struct {
+	ID          util.ID
+	Title       string
+	Description string
+	ProposalChanges
+}
+
+type UpdateProposalResult struct {
+	HasErrors                  bool
+	GenericError               error
+	UpdateProposalChangeErrors UpdateProposalChangeErrors
+}
+
+type UpdateProposalChangeErrors struct {
+	Title                   error
+	Description             error
+	CreateRoleChangesErrors []CreateRoleChangeErrors
+	UpdateRoleChangesErrors []UpdateRoleChangeErrors
+}
+
+type ObjectProposalChange struct {
+	ID     util.ID
+	Reason string
+}
+
+type ResolveProposalObjectionChange struct {
+	ID          util.ID
+	ObjectionID util.ID
+	Resolution  string
+}
+
 type GenericResult struct {
 	HasErrors    bool
 	GenericError error
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/sorintlab/sircles/config"
+	"github.com/sorintlab/sircles/db"
+	"github.com/sorintlab/sircles/eventstore"
+	slog "github.com/sorintlab/sircles/log"
+	"github.com/sorintlab/sircles/search"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap/zapcore"
+)
+
+var indexCmd = &cobra.Command{
+	Use: "index",
+}
+
+var indexRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "rebuild the search index from the current read database state. The sircles server must be stopped",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := indexRebuild(cmd, args); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(-1)
+		}
+	},
+}
+
+var indexVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "report the documents missing or stale in the search index. The sircles server must be stopped",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := indexVerify(cmd, args); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(-1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(indexCmd)
+	indexCmd.AddCommand(indexRebuildCmd)
+	indexCmd.AddCommand(indexVerifyCmd)
+}
+
+// indexSetup parses the config and opens the readdb and the event store used
+// by the search engine. The returned func closes them.
+func indexSetup() (*config.Config, *db.DB, *eventstore.EventStore, func(), error) {
+	if configFile == "" {
+		return nil, nil, nil, nil, errors.New("you should provide a config file path (-c option)")
+	}
+
+	c, err := config.Parse(configFile)
+	if err != nil {
+		return nil, nil, nil, nil, errors.WithMessage(err, fmt.Sprintf("error parsing configuration file %s", configFile))
+	}
+
+	if c.Debug {
+		slog.SetLevel(zapcore.DebugLevel)
+	}
+
+	if c.Index.Path == "" {
+		return nil, nil, nil, nil, errors.New("no index path specified")
+	}
+	if c.ReadDB.Type == "" {
+		return nil, nil, nil, nil, errors.New("no read db type specified")
+	}
+	if c.EventStore.Type != "sql" {
+		return nil, nil, nil, nil, errors.Errorf("unknown eventstore type: %q", c.EventStore.Type)
+	}
+	if c.EventStore.DB.Type == "" {
+		return nil, nil, nil, nil, errors.New("no eventstore db type specified")
+	}
+
+	esLnType := getLNtype(&c.EventStore.DB)
+	_, esNf, err := getListenerNotifierFactories(esLnType, &c.EventStore.DB)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	readDB, err := db.NewDB(c.ReadDB.Type, c.ReadDB.ConnString)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	esDB, err := db.NewDB(c.EventStore.DB.Type, c.EventStore.DB.ConnString)
+	if err != nil {
+		readDB.Close()
+		return nil, nil, nil, nil, err
+	}
+
+	closeDBs := func() {
+		readDB.Close()
+		esDB.Close()
+	}
+
+	return c, readDB, eventstore.NewEventStore(esDB, esNf), closeDBs, nil
+}
+
+func indexRebuild(cmd *cobra.Command, args []string) error {
+	c, readDB, es, closeDBs, err := indexSetup()
+	if err != nil {
+		return err
+	}
+	defer closeDBs()
+
+	log.Infof("rebuilding index %q", c.Index.Path)
+	return search.RebuildIndex(context.Background(), readDB, es, c.Index.Path)
+}
+
+func indexVerify(cmd *cobra.Command, args []string) error {
+	c, readDB, es, closeDBs, err := indexSetup()
+	if err != nil {
+		return err
+	}
+	defer closeDBs()
+
+	if _, err := os.Stat(c.Index.Path); err != nil {
+		return errors.Wrapf(err, "cannot open index %q", c.Index.Path)
+	}
+
+	searchEngine, err := search.OpenSearchEngine(readDB, es, c.Index.Path)
+	if err != nil {
+		return err
+	}
+	defer searchEngine.Close()
+
+	res, err := searchEngine.Verify(context.Background())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("index last event sequence number: %d\n", res.IndexSeqNumber)
+	fmt.Printf("readdb last event sequence number: %d\n", res.ReadDBSeqNumber)
+	for _, id := range res.Missing {
+		fmt.Printf("missing: %s\n", id)
+	}
+	for _, id := range res.Stale {
+		fmt.Printf("stale: %s\n", id)
+	}
+
+	if !res.Consistent() {
+		return errors.Errorf("index inconsistent: %d missing, %d stale documents", len(res.Missing), len(res.Stale))
+	}
+	if res.IndexSeqNumber != res.ReadDBSeqNumber {
+		fmt.Println("index consistent but at a different event sequence number than the readdb")
+		return nil
+	}
+	fmt.Println("index consistent")
+	return nil
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/sorintlab/sircles/common"
+	"github.com/sorintlab/sircles/config"
+	"github.com/sorintlab/sircles/db"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/lock"
+	slog "github.com/sorintlab/sircles/log"
+	"github.com/sorintlab/sircles/readdb"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap/zapcore"
+)
+
+var readdbCmd = &cobra.Command{
+	Use: "readdb",
+}
+
+var readdbRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "rebuild the read database replaying all the events",
+	Long:  "rebuild the read database replaying all the events. A postgres readdb is rebuilt in a shadow schema and swapped while the sircles server is running (with a postgres eventstore). A sqlite3 readdb is rebuilt in a shadow file but the sircles server must be stopped since the readdb file is replaced.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := readdbRebuild(cmd, args); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(-1)
+		}
+	},
+}
+
+type readdbRebuildOptions struct {
+	inPlace      bool
+	shadowSchema string
+}
+
+var readdbRebuildOpts readdbRebuildOptions
+
+func init() {
+	rootCmd.AddCommand(readdbCmd)
+	readdbCmd.AddCommand(readdbRebuildCmd)
+
+	readdbRebuildCmd.PersistentFlags().BoolVar(&readdbRebuildOpts.inPlace, "in-place", false, "drop the current readdb and rebuild it in place instead of rebuilding it in a shadow schema (postgres) or file (sqlite3) and then swapping it. The sircles server must be stopped. Without this option a postgres readdb can be rebuilt while the sircles server is running, a sqlite3 readdb cannot")
+	readdbRebuildCmd.PersistentFlags().StringVar(&readdbRebuildOpts.shadowSchema, "shadow-schema", "sircles_readdb_rebuild", "name of the shadow schema where the readdb is rebuilt (postgres only)")
+}
+
+func readdbRebuild(cmd *cobra.Command, args []string) error {
+	if configFile == "" {
+		return errors.New("you should provide a config file path (-c option)")
+	}
+
+	c, err := config.Parse(configFile)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error parsing configuration file %s", configFile))
+	}
+
+	if c.Debug {
+		slog.SetLevel(zapcore.DebugLevel)
+	}
+
+	if c.ReadDB.Type == "" {
+		return errors.New("no read db type specified")
+	}
+
+	if c.EventStore.Type == "" {
+		return errors.New("no eventstore type specified")
+	}
+	if c.EventStore.Type != "sql" {
+		return errors.Errorf("unknown eventstore type: %q", c.EventStore.Type)
+	}
+	if c.EventStore.DB.Type == "" {
+		return errors.New("no eventstore db type specified")
+	}
+
+	switch c.ReadDB.Type {
+	case db.Postgres:
+	case db.Sqlite3:
+	default:
+		return errors.Errorf("unsupported read db type: %s", c.ReadDB.Type)
+	}
+
+	switch c.EventStore.DB.Type {
+	case db.Postgres:
+	case db.Sqlite3:
+	default:
+		return errors.Errorf("unsupported eventstore db type: %s", c.EventStore.DB.Type)
+	}
+
+	esLnType := getLNtype(&c.EventStore.DB)
+	_, esNf, err := getListenerNotifierFactories(esLnType, &c.EventStore.DB)
+	if err != nil {
+		return err
+	}
+
+	esDB, err := db.NewDB(c.EventStore.DB.Type, c.EventStore.DB.ConnString)
+	if err != nil {
+		return err
+	}
+	defer esDB.Close()
+
+	// Populate/migrate esdb
+	if err := esDB.Migrate("eventstore", eventstore.Migrations); err != nil {
+		return err
+	}
+
+	es := eventstore.NewEventStore(esDB, esNf)
+
+	progress := func(sn, lastSn int64) {
+		log.Infof("applied events up to sequence number %d/%d", sn, lastSn)
+	}
+
+	if readdbRebuildOpts.inPlace {
+		return readdbRebuildInPlace(&c.ReadDB, es, progress)
+	}
+
+	switch c.ReadDB.Type {
+	case db.Postgres:
+		// take the same lock used by the server readdb event handler so the
+		// last events are applied and the readdb swapped while the server
+		// isn't updating it. The lock lives in the eventstore db: with a
+		// sqlite3 eventstore the server uses process local locks that cannot
+		// be taken here.
+		if c.EventStore.DB.Type != db.Postgres {
+			return errors.New("a postgres readdb with a sqlite3 eventstore cannot be swapped while the sircles server is running, stop the sircles server and use the --in-place option")
+		}
+		lkf := lock.NewPGLockFactory(common.EventHandlersLockSpace, esDB)
+		return readdbRebuildPostgres(&c.ReadDB, es, lkf, readdbRebuildOpts.shadowSchema, progress)
+	case db.Sqlite3:
+		if c.ReadDB.ConnString == c.EventStore.DB.ConnString {
+			return errors.New("the readdb and the eventstore share the same sqlite3 db, use the --in-place option")
+		}
+		return readdbRebuildSqlite3(&c.ReadDB, es, progress)
+	}
+
+	return nil
+}
+
+func readdbRebuildInPlace(dbConfig *config.DB, es *eventstore.EventStore, progress readdb.RebuildProgressFunc) error {
+	readDB, err := db.NewDB(dbConfig.Type, dbConfig.ConnString)
+	if err != nil {
+		return err
+	}
+	defer readDB.Close()
+
+	if err := readDB.Do(readdb.DropTables); err != nil {
+		return err
+	}
+
+	return readdb.Rebuild(readDB, es, progress)
+}
+
+// readdbRebuildPostgres rebuilds the readdb in a shadow schema and then
+// atomically moves its tables in the current readdb schema
+func readdbRebuildPostgres(dbConfig *config.DB, es *eventstore.EventStore, lkf *lock.PGLockFactory, shadowSchema string, progress readdb.RebuildProgressFunc) error {
+	readDB, err := db.NewDB(dbConfig.Type, dbConfig.ConnString)
+	if err != nil {
+		return err
+	}
+	defer readDB.Close()
+
+	var schema string
+	err = readDB.Do(func(tx *db.Tx) error {
+		return tx.Do(func(tx *db.WrappedTx) error {
+			if err := tx.QueryRow("select current_schema()").Scan(&schema); err != nil {
+				return errors.Wrap(err, "failed to get current schema")
+			}
+			if _, err := tx.Exec(fmt.Sprintf("drop schema if exists %s cascade", shadowSchema)); err != nil {
+				return errors.Wrap(err, "failed to drop shadow schema")
+			}
+			if _, err := tx.Exec(fmt.Sprintf("create schema %s", shadowSchema)); err != nil {
+				return errors.Wrap(err, "failed to create shadow schema")
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+	if schema == shadowSchema {
+		return errors.Errorf("shadow schema %q is the current readdb schema", shadowSchema)
+	}
+
+	shadowConnString, err := pgConnStringWithSearchPath(dbConfig.ConnString, shadowSchema)
+	if err != nil {
+		return err
+	}
+	shadowDB, err := db.NewDB(dbConfig.Type, shadowConnString)
+	if err != nil {
+		return err
+	}
+	defer shadowDB.Close()
+
+	log.Infof("rebuilding readdb in schema %q", shadowSchema)
+	if err := readdb.Rebuild(shadowDB, es, progress); err != nil {
+		return err
+	}
+
+	lk := lkf.NewLock("readdb")
+	if err := lk.Lock(); err != nil {
+		return errors.Wrap(err, "failed to acquire readdb lock")
+	}
+	defer lk.Unlock()
+
+	// apply the events written during the rebuild
+	if err := readdb.Rebuild(shadowDB, es, progress); err != nil {
+		return err
+	}
+
+	log.Infof("swapping rebuilt readdb in schema %q", schema)
+	return readDB.Do(func(tx *db.Tx) error {
+		if err := readdb.MoveTables(tx, shadowSchema, schema); err != nil {
+			return err
+		}
+		return tx.Do(func(tx *db.WrappedTx) error {
+			if _, err := tx.Exec(fmt.Sprintf("drop schema %s", shadowSchema)); err != nil {
+				return errors.Wrap(err, "failed to drop shadow schema")
+			}
+			return nil
+		})
+	})
+}
+
+// readdbRebuildSqlite3 rebuilds the readdb in a shadow db file and then
+// atomically renames it to the readdb file. The sircles server must be stopped
+// since it would keep writing to the replaced readdb file and its wal: the
+// readdb is exclusively locked for the whole rebuild and an error is returned
+// if it's in use.
+func readdbRebuildSqlite3(dbConfig *config.DB, es *eventstore.EventStore, progress readdb.RebuildProgressFunc) error {
+	path := dbConfig.ConnString
+	if strings.HasPrefix(path, "file:") || strings.Contains(path, "?") {
+		return errors.Errorf("unsupported sqlite3 connection string %q, use the --in-place option", path)
+	}
+	shadowPath := path + ".rebuild"
+
+	lockDB, err := lockSqlite3DB(path)
+	if err != nil {
+		return err
+	}
+	defer lockDB.Close()
+
+	for _, p := range []string{shadowPath, shadowPath + "-wal", shadowPath + "-shm"} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return errors.WithStack(err)
+		}
+	}
+
+	shadowDB, err := db.NewDB(dbConfig.Type, shadowPath)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("rebuilding readdb in %q", shadowPath)
+	if err := readdb.Rebuild(shadowDB, es, progress); err != nil {
+		shadowDB.Close()
+		return err
+	}
+	// closing the db will also checkpoint and remove its wal
+	if err := shadowDB.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	log.Infof("swapping rebuilt readdb in %q", path)
+	// remove the previous readdb wal or it'll be applied to the new db
+	for _, p := range []string{path + "-wal", path + "-shm"} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return errors.WithStack(err)
+		}
+	}
+	return errors.WithStack(os.Rename(shadowPath, path))
+}
+
+// lockSqlite3DB takes an exclusive lock on the sqlite3 db, kept until the
+// returned db is closed. It fails if the db is opened by another connection
+// (like a running sircles server)
+func lockSqlite3DB(path string) (*sql.DB, error) {
+	sqldb, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	// the lock is held by a single connection
+	sqldb.SetMaxOpenConns(1)
+
+	// with the exclusive locking mode the locks are never released
+	if _, err := sqldb.Exec("PRAGMA locking_mode = EXCLUSIVE"); err != nil {
+		sqldb.Close()
+		return nil, errors.Wrap(err, "failed to set locking mode")
+	}
+	if _, err := sqldb.Exec("BEGIN EXCLUSIVE"); err != nil {
+		sqldb.Close()
+		return nil, errors.Wrapf(err, "failed to lock readdb %q, the sircles server must be stopped", path)
+	}
+	if _, err := sqldb.Exec("COMMIT"); err != nil {
+		sqldb.Close()
+		return nil, errors.Wrap(err, "failed to commit")
+	}
+	return sqldb, nil
+}
+
+// pgConnStringWithSearchPath returns the postgres connection string setting
+// the search_path connection parameter to the provided schema
+func pgConnStringWithSearchPath(connString, schema string) (string, error) {
+	if strings.HasPrefix(connString, "postgres://") || strings.HasPrefix(connString, "postgresql://") {
+		u, err := url.Parse(connString)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to parse connection string")
+		}
+		q := u.Query()
+		q.Set("search_path", schema)
+		u.RawQuery = q.Encode()
+		return u.String(), nil
+	}
+	return fmt.Sprintf("%s search_path=%s", connString, schema), nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sorintlab/sircles/command"
+	"github.com/sorintlab/sircles/common"
+	"github.com/sorintlab/sircles/config"
+	"github.com/sorintlab/sircles/db"
+	"github.com/sorintlab/sircles/eventstore"
+	ln "github.com/sorintlab/sircles/listennotify"
+	"github.com/sorintlab/sircles/readdb"
+)
+
+func TestReaddbRebuildSqlite3(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	esDB, err := db.NewDB("sqlite3", filepath.Join(tmpDir, "esdb"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer esDB.Close()
+	if err := esDB.Migrate("eventstore", eventstore.Migrations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	localLN := ln.NewLocalListenNotify()
+	es := eventstore.NewEventStore(esDB, ln.NewLocalNotifierFactory(localLN))
+
+	// the current readdb without any applied event since no readdb event
+	// handler is running
+	readDBPath := filepath.Join(tmpDir, "readdb")
+	readDB, err := db.NewDB("sqlite3", readDBPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := readDB.Migrate("readdb", readdb.Migrations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	commandService := command.NewCommandService(tmpDir, readDB, es, &common.DefaultUidGenerator{}, ln.NewLocalListenerFactory(localLN), false)
+	if _, _, err := commandService.SetupRootRole(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the readdb cannot be swapped while in use (like by a running server)
+	if err := readdbRebuildSqlite3(&config.DB{Type: db.Sqlite3, ConnString: readDBPath}, es, nil); err == nil {
+		t.Fatalf("expected error rebuilding a readdb in use")
+	}
+	readDB.Close()
+
+	lastSn, err := es.LastSequenceNumber()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := readdbRebuildSqlite3(&config.DB{Type: db.Sqlite3, ConnString: readDBPath}, es, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the rebuilt readdb has been swapped with the current one
+	if _, err := os.Stat(readDBPath + ".rebuild"); !os.IsNotExist(err) {
+		t.Fatalf("expected shadow readdb removed, got error: %v", err)
+	}
+	readDB, err = db.NewDB("sqlite3", readDBPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer readDB.Close()
+	var sn int64
+	if err := readDB.Do(func(tx *db.Tx) error {
+		var err error
+		sn, err = readdb.LastSequenceNumber(tx)
+		return err
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sn != lastSn {
+		t.Fatalf("expected last sequence number %d, got %d", lastSn, sn)
+	}
+
+	// connection strings that aren't a file path aren't supported
+	if err := readdbRebuildSqlite3(&config.DB{Type: db.Sqlite3, ConnString: "file:" + readDBPath}, es, nil); err == nil {
+		t.Fatalf("expected error with a file uri connection string")
+	}
+}
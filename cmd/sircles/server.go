@@ -2,10 +2,12 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"time"
 
 	graphqlapi "github.com/sorintlab/sircles/api/graphql"
 	"github.com/sorintlab/sircles/auth"
@@ -22,6 +24,7 @@ import (
 	slog "github.com/sorintlab/sircles/log"
 	"github.com/sorintlab/sircles/readdb"
 	"github.com/sorintlab/sircles/search"
+	"github.com/sorintlab/sircles/util"
 
 	jwt "github.com/dgrijalva/jwt-go"
 	ghandlers "github.com/gorilla/handlers"
@@ -134,7 +137,17 @@ func serve(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	tokenSigningData := &handlers.TokenSigningData{Duration: c.TokenSigning.Duration}
+	accessTokenDuration := c.TokenSigning.Lifetimes.AccessToken
+	if accessTokenDuration == 0 {
+		accessTokenDuration = c.TokenSigning.Duration
+	}
+	if accessTokenDuration > c.TokenSigning.Lifetimes.RefreshToken {
+		return errors.Errorf("access token lifetime greater than the refresh token lifetime")
+	}
+	tokenSigningData := &handlers.TokenSigningData{
+		AccessTokenDuration:  accessTokenDuration,
+		RefreshTokenDuration: c.TokenSigning.Lifetimes.RefreshToken,
+	}
 	switch c.TokenSigning.Method {
 	case "hmac":
 		tokenSigningData.Method = jwt.SigningMethodHS256
@@ -143,29 +156,44 @@ func serve(cmd *cobra.Command, args []string) error {
 		}
 		tokenSigningData.Key = []byte(c.TokenSigning.Key)
 	case "rsa":
-		if c.TokenSigning.PrivateKeyPath == "" {
-			return errors.Errorf("token signing private key file for rsa method not defined")
-		}
-		if c.TokenSigning.PublicKeyPath == "" {
-			return errors.Errorf("token signing public key file for rsa method not defined")
-		}
-
 		tokenSigningData.Method = jwt.SigningMethodRS256
-		privateKeyData, err := ioutil.ReadFile(c.TokenSigning.PrivateKeyPath)
-		if err != nil {
-			return errors.Wrapf(err, "error reading token signing private key")
-		}
-		tokenSigningData.PrivateKey, err = jwt.ParseRSAPrivateKeyFromPEM(privateKeyData)
-		if err != nil {
-			return errors.Wrapf(err, "error parsing token signing private key")
-		}
-		publicKeyData, err := ioutil.ReadFile(c.TokenSigning.PublicKeyPath)
-		if err != nil {
-			return errors.Wrapf(err, "error reading token signing public key")
-		}
-		tokenSigningData.PublicKey, err = jwt.ParseRSAPublicKeyFromPEM(publicKeyData)
-		if err != nil {
-			return errors.Wrapf(err, "error parsing token signing public key")
+		switch {
+		case c.TokenSigning.KeysDir != "":
+			if c.TokenSigning.PrivateKeyPath != "" {
+				return errors.Errorf("token signing private key file and keys dir are mutually exclusive")
+			}
+			retention := time.Duration(accessTokenDuration) * time.Second
+			tokenSigningData.Keys, err = handlers.NewSigningKeysFromDir(c.TokenSigning.KeysDir, c.TokenSigning.PrivateKeyPassword, retention, common.DefaultTimeGenerator{})
+			if err != nil {
+				return errors.Wrapf(err, "error loading token signing keys")
+			}
+		case c.TokenSigning.PrivateKeyPath != "":
+			privateKeyData, err := ioutil.ReadFile(c.TokenSigning.PrivateKeyPath)
+			if err != nil {
+				return errors.Wrapf(err, "error reading token signing private key")
+			}
+			signingKey, err := handlers.ParseSigningKey("", privateKeyData, c.TokenSigning.PrivateKeyPassword)
+			if err != nil {
+				return errors.Wrapf(err, "error parsing token signing private key")
+			}
+			// the public key is optional since it's derived from the private
+			// key, if provided it must match the private key
+			if c.TokenSigning.PublicKeyPath != "" {
+				publicKeyData, err := ioutil.ReadFile(c.TokenSigning.PublicKeyPath)
+				if err != nil {
+					return errors.Wrapf(err, "error reading token signing public key")
+				}
+				publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicKeyData)
+				if err != nil {
+					return errors.Wrapf(err, "error parsing token signing public key")
+				}
+				if publicKey.E != signingKey.PublicKey.E || publicKey.N.Cmp(signingKey.PublicKey.N) != 0 {
+					return errors.Errorf("token signing public key doesn't match the private key")
+				}
+			}
+			tokenSigningData.Keys = handlers.NewSigningKeys(signingKey)
+		default:
+			return errors.Errorf("token signing private key file or keys dir for rsa method not defined")
 		}
 	case "":
 		return errors.Errorf("missing token signing method")
@@ -246,7 +274,15 @@ func serve(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	searchEngine := search.NewSearchEngine(readDB, es, c.Index.Path)
+	subscriptionSchema, err := graphql.ParseSchema(graphqlapi.SubscriptionSchema, graphqlapi.NewSubscriptionResolver(), graphql.MaxParallelism(1000))
+	if err != nil {
+		return err
+	}
+
+	searchEngine, err := search.NewSearchEngine(readDB, es, readDBListener, c.Index.Path)
+	if err != nil {
+		return err
+	}
 
 	// noop coors handler
 	corsHandler := func(h http.Handler) http.Handler {
@@ -259,30 +295,34 @@ func serve(cmd *cobra.Command, args []string) error {
 		corsHandler = ghandlers.CORS(corsAllowedHeadersOptions, corsAllowedOriginsOptions)
 	}
 
-	// For the moment, create a different temporary dataDir for every
-	// new process execution.
-	// This will trigger the rebuild from scratch, when loaded, of the
-	// aggregates/handlers snapshot db (rolestree aggregate, deletedroletension
-	// handler).
-	dataDir, err := ioutil.TempDir("", "")
-	if err != nil {
-		return err
+	// The aggregates/handlers snapshot dbs (rolestree aggregate,
+	// deletedroletension handler etc...) are kept in dataDir between restarts
+	// so they'll only apply the events not yet handled. They'll be rebuilt
+	// from scratch if incompatible with the current schema or event store.
+	dataDir := c.DataDir
+	if err := os.MkdirAll(dataDir, 0700); err != nil {
+		return errors.Wrapf(err, "failed to create data dir %q", dataDir)
 	}
-	defer os.RemoveAll(dataDir)
 
-	loginHandler := handlers.NewLoginHandler(c, dataDir, readDB, es, esLf, authenticator, memberProvider, tokenSigningData)
-	refreshTokenHandler := handlers.NewRefreshTokenHandler(tokenSigningData)
+	loginHandler := handlers.NewLoginHandler(c, dataDir, readDB, readDBListener, es, esLf, authenticator, memberProvider, tokenSigningData)
+	refreshTokenHandler := handlers.NewRefreshTokenHandler(dataDir, readDB, readDBListener, es, esLf, tokenSigningData)
+	logoutHandler := handlers.NewLogoutHandler(dataDir, readDB, readDBListener, es, esLf)
 	oidcAuthURLHandler := handlers.NewOIDCAuthURLHandler(authenticator)
 	graphqlHandler := handlers.NewGraphQLHandler(c, dataDir, readDB, readDBListener, es, esLf, searchEngine, s, memberProvider)
+	subscriptionHandler := handlers.NewSubscriptionHandler(c, readDB, readDBLf, subscriptionSchema, tokenSigningData)
 	authHandler := handlers.NewAuthHandler(readDB, tokenSigningData)
 
 	router := mux.NewRouter()
+	router.Handle("/.well-known/jwks.json", handlers.NewJWKSHandler(tokenSigningData)).Methods("GET")
 	apirouter := router.PathPrefix("/api/").Subrouter()
 	apirouter.Handle("/auth/login", loginHandler).Methods("POST")
 	apirouter.Handle("/auth/oidcauthurl", oidcAuthURLHandler).Methods("POST")
-	apirouter.Handle("/auth/refresh", authHandler(refreshTokenHandler)).Methods("POST")
-	apirouter.Handle("/auth/logout", authHandler(http.HandlerFunc(handlers.LogoutHandler))).Methods("POST")
+	apirouter.Handle("/auth/refresh", refreshTokenHandler).Methods("POST")
+	apirouter.Handle("/auth/logout", authHandler(logoutHandler)).Methods("POST")
 	apirouter.Handle("/graphql", authHandler(graphqlHandler))
+	// the subscription handler authenticates the client using the
+	// connection_init message
+	apirouter.Handle("/graphql/ws", subscriptionHandler).Methods("GET")
 	// TODO(sgotti) since we are providing avatars for browser displaying we can't
 	// protect them because the browser img src cannot send the auth token. If
 	// protecting the avatar becomes important there's the need to find a way on
@@ -309,9 +349,18 @@ func serve(cmd *cobra.Command, args []string) error {
 		}()
 	}
 	if c.Web.HTTPS != "" {
+		certificate, err := loadTLSCertificate(c.Web.TLSCert, c.Web.TLSKey, c.Web.TLSKeyPassword)
+		if err != nil {
+			return err
+		}
+		server := &http.Server{
+			Addr:      c.Web.HTTPS,
+			Handler:   mainrouter,
+			TLSConfig: &tls.Config{Certificates: []tls.Certificate{certificate}},
+		}
 		log.Infof("https listening on %s", c.Web.HTTPS)
 		go func() {
-			err := http.ListenAndServeTLS(c.Web.HTTPS, c.Web.TLSCert, c.Web.TLSKey, mainrouter)
+			err := server.ListenAndServeTLS("", "")
 			listenErrChan <- errors.Wrapf(err, "listening on %s failed: %v", c.Web.HTTPS)
 		}()
 	}
@@ -325,8 +374,20 @@ func serve(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	elh, err := eventhandler.NewElectionHandler(dataDir, es, &common.DefaultUidGenerator{}, common.DefaultTimeGenerator{})
+	if err != nil {
+		return err
+	}
+	whh, err := eventhandler.NewWebhookHandler(dataDir, es, &common.DefaultUidGenerator{}, common.DefaultTimeGenerator{})
+	if err != nil {
+		return err
+	}
+
+	if tokenSigningData.Keys != nil {
+		endChs = append(endChs, tokenSigningData.Keys.Run(stop))
+	}
 
-	for _, h := range []eventhandler.EventHandler{readDBh, mrh, drth} {
+	for _, h := range []eventhandler.EventHandler{readDBh, mrh, drth, elh, whh} {
 		endCh, err := eventhandler.RunEventHandler(h, stop, esLf, lkf)
 		if err != nil {
 			return err
@@ -334,6 +395,14 @@ func serve(cmd *cobra.Command, args []string) error {
 		endChs = append(endChs, endCh)
 	}
 
+	// the search index is local to every instance so every instance must
+	// update it: only take a per process lock
+	endCh, err := eventhandler.RunEventHandler(searchEngine, stop, esLf, lock.NewLocalLockFactory(lock.NewLocalLocks()))
+	if err != nil {
+		return err
+	}
+	endChs = append(endChs, endCh)
+
 	if err := initializeSircles(dataDir, readDB, es, readDBLf, esLf, c.CreateInitialAdmin); err != nil {
 		return err
 	}
@@ -341,6 +410,28 @@ func serve(cmd *cobra.Command, args []string) error {
 	return <-listenErrChan
 }
 
+// loadTLSCertificate loads the tls certificate decrypting its private key when
+// encrypted
+func loadTLSCertificate(certFile, keyFile, keyPassword string) (tls.Certificate, error) {
+	certData, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrapf(err, "error reading tls cert")
+	}
+	keyData, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrapf(err, "error reading tls key")
+	}
+	keyData, err = util.DecryptPEMKey(keyData, keyPassword)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrapf(err, "error decrypting tls key")
+	}
+	certificate, err := tls.X509KeyPair(certData, keyData)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrapf(err, "error loading tls cert")
+	}
+	return certificate, nil
+}
+
 func initializeSircles(dataDir string, readDB *db.DB, es *eventstore.EventStore, readDBLf, esLf ln.ListenerFactory, createInitialAdmin bool) error {
 	events, err := es.GetAllEvents(0, 1)
 	if err != nil {
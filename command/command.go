@@ -3,7 +3,9 @@ package command
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"image"
+	"net/url"
 	"regexp"
 	"time"
 
@@ -33,6 +35,8 @@ const (
 	MaxRolePurposeLength           = 1000
 	MaxRoleDomainLength            = 1000
 	MaxRoleAccountabilityLength    = 1000
+	MaxRoleChecklistItemLength     = 1000
+	MaxRoleMetricLength            = 1000
 	MaxRoleAdditionalContentLength = 1000 * 1000 // 1M of chars
 
 	MinMemberUserNameLength = 3
@@ -49,7 +53,27 @@ const (
 	MaxTensionDescriptionLength = 1000 * 1000 // 1M of chars
 	MaxTensionCloseReasonLength = 1000
 
+	MaxTensionOutcomeDescriptionLength = 1000
+
+	MaxProjectTitleLength       = 100
+	MaxProjectDescriptionLength = 1000 * 1000 // 1M of chars
+
+	MaxActionTitleLength       = 100
+	MaxActionDescriptionLength = 1000 * 1000 // 1M of chars
+
+	MaxProposalTitleLength           = 100
+	MaxProposalDescriptionLength     = 1000 * 1000 // 1M of chars
+	MaxProposalObjectionReasonLength = 1000
+
 	MaxRoleAssignmentFocusLength = 30
+
+	MaxMeetingMetricValueLength = 100
+
+	MaxAPITokenNameLength = 100
+
+	MaxWebhookURLLength    = 1000
+	MinWebhookSecretLength = 16
+	MaxWebhookSecretLength = 100
 )
 
 var UserNameRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*([-]?[a-zA-Z0-9]+)+$`)
@@ -88,6 +112,68 @@ func NewCommandService(dataDir string, db *db.DB, es *eventstore.EventStore, uid
 	return s
 }
 
+// execCommand executes the command on the aggregate. When the calling member
+// is authenticated with an api token it checks that the token scope permits
+// the command and records the token id in the command.
+func (s *CommandService) execCommand(ctx context.Context, command *commands.Command, a aggregate.Aggregate) (util.ID, int, error) {
+	if err := s.checkAPIToken(ctx, command, a); err != nil {
+		return util.NilID, 0, err
+	}
+
+	return aggregate.ExecCommand(command, a, s.es, s.uidGenerator)
+}
+
+// execCommands atomically executes multiple commands on different aggregates
+// (see aggregate.ExecCommands) with the same checks of execCommand.
+func (s *CommandService) execCommands(ctx context.Context, acs []*aggregate.AggregateCommand) (util.ID, int, error) {
+	for _, ac := range acs {
+		if err := s.checkAPIToken(ctx, ac.Command, ac.Aggregate); err != nil {
+			return util.NilID, 0, err
+		}
+	}
+
+	return aggregate.ExecCommands(acs, s.es, s.uidGenerator)
+}
+
+func (s *CommandService) checkAPIToken(ctx context.Context, command *commands.Command, a aggregate.Aggregate) error {
+	v, ok := ctx.Value("apitokenid").(string)
+	if !ok {
+		return nil
+	}
+	apiTokenID, err := util.IDFromString(v)
+	if err != nil {
+		return err
+	}
+	scope, _ := ctx.Value("apitokenscope").(string)
+	if !apiTokenScopeAllows(models.APITokenScope(scope), a.AggregateType(), command.CommandType) {
+		return errors.Errorf("api token with scope %q cannot execute command %s", scope, command.CommandType)
+	}
+	command.IssuerTokenID = &apiTokenID
+	return nil
+}
+
+// apiTokenScopeAllows reports whether an api token with the provided scope can
+// execute the command. Members credentials, sessions and api tokens can be
+// managed only by interactively logged in members.
+func apiTokenScopeAllows(scope models.APITokenScope, aggregateType aggregate.AggregateType, commandType commands.CommandType) bool {
+	switch aggregateType {
+	case aggregate.SessionAggregate, aggregate.APITokenAggregate:
+		return false
+	}
+	switch commandType {
+	case commands.CommandTypeSetMemberPassword, commands.CommandTypeRequestSetMemberMatchUID, commands.CommandTypeSetMemberMatchUID:
+		return false
+	}
+
+	switch scope {
+	case models.APITokenScopeTensions:
+		return aggregateType == aggregate.TensionAggregate
+	case models.APITokenScopeGovernance:
+		return true
+	}
+	return false
+}
+
 func (s *CommandService) UpdateRootRole(ctx context.Context, c *change.UpdateRootRoleChange) (*change.UpdateRootRoleResult, util.ID, error) {
 	res := &change.UpdateRootRoleResult{}
 	res.UpdateRootRoleChangeErrors.CreateDomainChangesErrors = make([]change.CreateDomainChangeErrors, len(c.CreateDomainChanges))
@@ -225,7 +311,7 @@ func (s *CommandService) UpdateRootRole(ctx context.Context, c *change.UpdateRoo
 		return nil, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, rt, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, rt)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -235,51 +321,8 @@ func (s *CommandService) UpdateRootRole(ctx context.Context, c *change.UpdateRoo
 
 func (s *CommandService) CircleCreateChildRole(ctx context.Context, roleID util.ID, c *change.CreateRoleChange) (*change.CreateRoleResult, util.ID, error) {
 	res := &change.CreateRoleResult{}
-	res.CreateRoleChangeErrors.CreateDomainChangesErrors = make([]change.CreateDomainChangeErrors, len(c.CreateDomainChanges))
-	res.CreateRoleChangeErrors.CreateAccountabilityChangesErrors = make([]change.CreateAccountabilityChangeErrors, len(c.CreateAccountabilityChanges))
-
-	if c.Name == "" {
-		res.HasErrors = true
-		res.CreateRoleChangeErrors.Name = errors.Errorf("empty role name")
-	}
-	if len([]rune(c.Name)) > MaxRoleNameLength {
-		res.HasErrors = true
-		res.CreateRoleChangeErrors.Name = errors.Errorf("name too long")
-	}
-	if len([]rune(c.Purpose)) > MaxRolePurposeLength {
-		res.HasErrors = true
-		res.CreateRoleChangeErrors.Purpose = errors.Errorf("purpose too long")
-	}
-
-	switch c.RoleType {
-	case models.RoleTypeNormal:
-	case models.RoleTypeCircle:
-	default:
-		res.HasErrors = true
-		res.CreateRoleChangeErrors.RoleType = errors.Errorf("wrong role type: %s", c.RoleType)
-	}
-
-	for i, createDomainChange := range c.CreateDomainChanges {
-		if createDomainChange.Description == "" {
-			res.HasErrors = true
-			res.CreateRoleChangeErrors.CreateDomainChangesErrors[i].Description = errors.Errorf("empty domain")
-		}
-		if len([]rune(createDomainChange.Description)) > MaxRoleDomainLength {
-			res.HasErrors = true
-			res.CreateRoleChangeErrors.CreateDomainChangesErrors[i].Description = errors.Errorf("domain too long")
-		}
-	}
 
-	for i, createAccountabilityChange := range c.CreateAccountabilityChanges {
-		if createAccountabilityChange.Description == "" {
-			res.HasErrors = true
-			res.CreateRoleChangeErrors.CreateAccountabilityChangesErrors[i].Description = errors.Errorf("empty accountability")
-		}
-		if len([]rune(createAccountabilityChange.Description)) > MaxRoleAccountabilityLength {
-			res.HasErrors = true
-			res.CreateRoleChangeErrors.CreateAccountabilityChangesErrors[i].Description = errors.Errorf("accountability too long")
-		}
-	}
+	res.HasErrors = validateCreateRoleChange(c, &res.CreateRoleChangeErrors)
 
 	if res.HasErrors {
 		return res, util.NilID, ErrValidation
@@ -338,7 +381,7 @@ func (s *CommandService) CircleCreateChildRole(ctx context.Context, roleID util.
 		return nil, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, rt, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, rt)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -350,76 +393,8 @@ func (s *CommandService) CircleCreateChildRole(ctx context.Context, roleID util.
 
 func (s *CommandService) CircleUpdateChildRole(ctx context.Context, roleID util.ID, c *change.UpdateRoleChange) (*change.UpdateRoleResult, util.ID, error) {
 	res := &change.UpdateRoleResult{}
-	res.UpdateRoleChangeErrors.CreateDomainChangesErrors = make([]change.CreateDomainChangeErrors, len(c.CreateDomainChanges))
-	res.UpdateRoleChangeErrors.UpdateDomainChangesErrors = make([]change.UpdateDomainChangeErrors, len(c.UpdateDomainChanges))
-	res.UpdateRoleChangeErrors.CreateAccountabilityChangesErrors = make([]change.CreateAccountabilityChangeErrors, len(c.CreateAccountabilityChanges))
-	res.UpdateRoleChangeErrors.UpdateAccountabilityChangesErrors = make([]change.UpdateAccountabilityChangeErrors, len(c.UpdateAccountabilityChanges))
 
-	if c.NameChanged {
-		if c.Name == "" {
-			res.HasErrors = true
-			res.UpdateRoleChangeErrors.Name = errors.Errorf("empty role name")
-		}
-		if len([]rune(c.Name)) > MaxRoleNameLength {
-			res.HasErrors = true
-			res.UpdateRoleChangeErrors.Name = errors.Errorf("name too long")
-		}
-	}
-
-	if c.PurposeChanged {
-		if len([]rune(c.Purpose)) > MaxRolePurposeLength {
-			res.HasErrors = true
-			res.UpdateRoleChangeErrors.Purpose = errors.Errorf("purpose too long")
-		}
-	}
-
-	for i, createDomainChange := range c.CreateDomainChanges {
-		if createDomainChange.Description == "" {
-			res.HasErrors = true
-			res.UpdateRoleChangeErrors.CreateDomainChangesErrors[i].Description = errors.Errorf("empty domain")
-		}
-		if len([]rune(createDomainChange.Description)) > MaxRoleDomainLength {
-			res.HasErrors = true
-			res.UpdateRoleChangeErrors.CreateDomainChangesErrors[i].Description = errors.Errorf("domain too long")
-		}
-	}
-
-	for i, updateDomainChange := range c.UpdateDomainChanges {
-		if updateDomainChange.DescriptionChanged {
-			if updateDomainChange.Description == "" {
-				res.HasErrors = true
-				res.UpdateRoleChangeErrors.UpdateDomainChangesErrors[i].Description = errors.Errorf("empty domain")
-			}
-			if len([]rune(updateDomainChange.Description)) > MaxRoleDomainLength {
-				res.HasErrors = true
-				res.UpdateRoleChangeErrors.UpdateDomainChangesErrors[i].Description = errors.Errorf("domain too long")
-			}
-		}
-	}
-
-	for i, createAccountabilityChange := range c.CreateAccountabilityChanges {
-		if createAccountabilityChange.Description == "" {
-			res.HasErrors = true
-			res.UpdateRoleChangeErrors.CreateAccountabilityChangesErrors[i].Description = errors.Errorf("empty accountability")
-		}
-		if len([]rune(createAccountabilityChange.Description)) > MaxRoleAccountabilityLength {
-			res.HasErrors = true
-			res.UpdateRoleChangeErrors.CreateAccountabilityChangesErrors[i].Description = errors.Errorf("accountability too long")
-		}
-	}
-
-	for i, updateAccountabilityChange := range c.UpdateAccountabilityChanges {
-		if updateAccountabilityChange.DescriptionChanged {
-			if updateAccountabilityChange.Description == "" {
-				res.HasErrors = true
-				res.UpdateRoleChangeErrors.UpdateAccountabilityChangesErrors[i].Description = errors.Errorf("empty accountability")
-			}
-			if len([]rune(updateAccountabilityChange.Description)) > MaxRoleAccountabilityLength {
-				res.HasErrors = true
-				res.UpdateRoleChangeErrors.UpdateAccountabilityChangesErrors[i].Description = errors.Errorf("accountability too long")
-			}
-		}
-	}
+	res.HasErrors = validateUpdateRoleChange(c, &res.UpdateRoleChangeErrors)
 
 	if res.HasErrors {
 		return res, util.NilID, ErrValidation
@@ -494,7 +469,7 @@ func (s *CommandService) CircleUpdateChildRole(ctx context.Context, roleID util.
 		return nil, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, rt, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, rt)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -607,7 +582,7 @@ func (s *CommandService) CircleDeleteChildRole(ctx context.Context, roleID util.
 		return nil, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, rt, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, rt)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -615,6 +590,189 @@ func (s *CommandService) CircleDeleteChildRole(ctx context.Context, roleID util.
 	return res, groupID, nil
 }
 
+// validateCreateRoleChange validates the create role change populating errs.
+// It returns true if there're errors
+func validateCreateRoleChange(c *change.CreateRoleChange, errs *change.CreateRoleChangeErrors) bool {
+	hasErrors := false
+	errs.CreateDomainChangesErrors = make([]change.CreateDomainChangeErrors, len(c.CreateDomainChanges))
+	errs.CreateAccountabilityChangesErrors = make([]change.CreateAccountabilityChangeErrors, len(c.CreateAccountabilityChanges))
+
+	if c.Name == "" {
+		hasErrors = true
+		errs.Name = errors.Errorf("empty role name")
+	}
+	if len([]rune(c.Name)) > MaxRoleNameLength {
+		hasErrors = true
+		errs.Name = errors.Errorf("name too long")
+	}
+	if len([]rune(c.Purpose)) > MaxRolePurposeLength {
+		hasErrors = true
+		errs.Purpose = errors.Errorf("purpose too long")
+	}
+
+	switch c.RoleType {
+	case models.RoleTypeNormal:
+	case models.RoleTypeCircle:
+	default:
+		hasErrors = true
+		errs.RoleType = errors.Errorf("wrong role type: %s", c.RoleType)
+	}
+
+	for i, createDomainChange := range c.CreateDomainChanges {
+		if createDomainChange.Description == "" {
+			hasErrors = true
+			errs.CreateDomainChangesErrors[i].Description = errors.Errorf("empty domain")
+		}
+		if len([]rune(createDomainChange.Description)) > MaxRoleDomainLength {
+			hasErrors = true
+			errs.CreateDomainChangesErrors[i].Description = errors.Errorf("domain too long")
+		}
+	}
+
+	for i, createAccountabilityChange := range c.CreateAccountabilityChanges {
+		if createAccountabilityChange.Description == "" {
+			hasErrors = true
+			errs.CreateAccountabilityChangesErrors[i].Description = errors.Errorf("empty accountability")
+		}
+		if len([]rune(createAccountabilityChange.Description)) > MaxRoleAccountabilityLength {
+			hasErrors = true
+			errs.CreateAccountabilityChangesErrors[i].Description = errors.Errorf("accountability too long")
+		}
+	}
+
+	return hasErrors
+}
+
+// validateUpdateRoleChange validates the update role change populating errs.
+// It returns true if there're errors
+func validateUpdateRoleChange(c *change.UpdateRoleChange, errs *change.UpdateRoleChangeErrors) bool {
+	hasErrors := false
+	errs.CreateDomainChangesErrors = make([]change.CreateDomainChangeErrors, len(c.CreateDomainChanges))
+	errs.UpdateDomainChangesErrors = make([]change.UpdateDomainChangeErrors, len(c.UpdateDomainChanges))
+	errs.CreateAccountabilityChangesErrors = make([]change.CreateAccountabilityChangeErrors, len(c.CreateAccountabilityChanges))
+	errs.UpdateAccountabilityChangesErrors = make([]change.UpdateAccountabilityChangeErrors, len(c.UpdateAccountabilityChanges))
+	errs.CreateChecklistItemChangesErrors = make([]change.CreateChecklistItemChangeErrors, len(c.CreateChecklistItemChanges))
+	errs.UpdateChecklistItemChangesErrors = make([]change.UpdateChecklistItemChangeErrors, len(c.UpdateChecklistItemChanges))
+	errs.CreateMetricChangesErrors = make([]change.CreateMetricChangeErrors, len(c.CreateMetricChanges))
+	errs.UpdateMetricChangesErrors = make([]change.UpdateMetricChangeErrors, len(c.UpdateMetricChanges))
+
+	if c.NameChanged {
+		if c.Name == "" {
+			hasErrors = true
+			errs.Name = errors.Errorf("empty role name")
+		}
+		if len([]rune(c.Name)) > MaxRoleNameLength {
+			hasErrors = true
+			errs.Name = errors.Errorf("name too long")
+		}
+	}
+
+	if c.PurposeChanged {
+		if len([]rune(c.Purpose)) > MaxRolePurposeLength {
+			hasErrors = true
+			errs.Purpose = errors.Errorf("purpose too long")
+		}
+	}
+
+	for i, createDomainChange := range c.CreateDomainChanges {
+		if createDomainChange.Description == "" {
+			hasErrors = true
+			errs.CreateDomainChangesErrors[i].Description = errors.Errorf("empty domain")
+		}
+		if len([]rune(createDomainChange.Description)) > MaxRoleDomainLength {
+			hasErrors = true
+			errs.CreateDomainChangesErrors[i].Description = errors.Errorf("domain too long")
+		}
+	}
+
+	for i, updateDomainChange := range c.UpdateDomainChanges {
+		if updateDomainChange.DescriptionChanged {
+			if updateDomainChange.Description == "" {
+				hasErrors = true
+				errs.UpdateDomainChangesErrors[i].Description = errors.Errorf("empty domain")
+			}
+			if len([]rune(updateDomainChange.Description)) > MaxRoleDomainLength {
+				hasErrors = true
+				errs.UpdateDomainChangesErrors[i].Description = errors.Errorf("domain too long")
+			}
+		}
+	}
+
+	for i, createAccountabilityChange := range c.CreateAccountabilityChanges {
+		if createAccountabilityChange.Description == "" {
+			hasErrors = true
+			errs.CreateAccountabilityChangesErrors[i].Description = errors.Errorf("empty accountability")
+		}
+		if len([]rune(createAccountabilityChange.Description)) > MaxRoleAccountabilityLength {
+			hasErrors = true
+			errs.CreateAccountabilityChangesErrors[i].Description = errors.Errorf("accountability too long")
+		}
+	}
+
+	for i, updateAccountabilityChange := range c.UpdateAccountabilityChanges {
+		if updateAccountabilityChange.DescriptionChanged {
+			if updateAccountabilityChange.Description == "" {
+				hasErrors = true
+				errs.UpdateAccountabilityChangesErrors[i].Description = errors.Errorf("empty accountability")
+			}
+			if len([]rune(updateAccountabilityChange.Description)) > MaxRoleAccountabilityLength {
+				hasErrors = true
+				errs.UpdateAccountabilityChangesErrors[i].Description = errors.Errorf("accountability too long")
+			}
+		}
+	}
+
+	for i, createChecklistItemChange := range c.CreateChecklistItemChanges {
+		if createChecklistItemChange.Description == "" {
+			hasErrors = true
+			errs.CreateChecklistItemChangesErrors[i].Description = errors.Errorf("empty checklist item")
+		}
+		if len([]rune(createChecklistItemChange.Description)) > MaxRoleChecklistItemLength {
+			hasErrors = true
+			errs.CreateChecklistItemChangesErrors[i].Description = errors.Errorf("checklist item too long")
+		}
+	}
+
+	for i, updateChecklistItemChange := range c.UpdateChecklistItemChanges {
+		if updateChecklistItemChange.DescriptionChanged {
+			if updateChecklistItemChange.Description == "" {
+				hasErrors = true
+				errs.UpdateChecklistItemChangesErrors[i].Description = errors.Errorf("empty checklist item")
+			}
+			if len([]rune(updateChecklistItemChange.Description)) > MaxRoleChecklistItemLength {
+				hasErrors = true
+				errs.UpdateChecklistItemChangesErrors[i].Description = errors.Errorf("checklist item too long")
+			}
+		}
+	}
+
+	for i, createMetricChange := range c.CreateMetricChanges {
+		if createMetricChange.Description == "" {
+			hasErrors = true
+			errs.CreateMetricChangesErrors[i].Description = errors.Errorf("empty metric")
+		}
+		if len([]rune(createMetricChange.Description)) > MaxRoleMetricLength {
+			hasErrors = true
+			errs.CreateMetricChangesErrors[i].Description = errors.Errorf("metric too long")
+		}
+	}
+
+	for i, updateMetricChange := range c.UpdateMetricChanges {
+		if updateMetricChange.DescriptionChanged {
+			if updateMetricChange.Description == "" {
+				hasErrors = true
+				errs.UpdateMetricChangesErrors[i].Description = errors.Errorf("empty metric")
+			}
+			if len([]rune(updateMetricChange.Description)) > MaxRoleMetricLength {
+				hasErrors = true
+				errs.UpdateMetricChangesErrors[i].Description = errors.Errorf("metric too long")
+			}
+		}
+	}
+
+	return hasErrors
+}
+
 func (s *CommandService) SetRoleAdditionalContent(ctx context.Context, roleID util.ID, content string) (*change.SetRoleAdditionalContentResult, util.ID, error) {
 	res := &change.SetRoleAdditionalContentResult{}
 	if len([]rune(content)) > MaxRoleAdditionalContentLength {
@@ -676,7 +834,7 @@ func (s *CommandService) SetRoleAdditionalContent(ctx context.Context, roleID ut
 		return nil, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, rt, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, rt)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -684,35 +842,148 @@ func (s *CommandService) SetRoleAdditionalContent(ctx context.Context, roleID ut
 	return res, groupID, nil
 }
 
-func (s *CommandService) CreateMember(ctx context.Context, c *change.CreateMemberChange) (*change.CreateMemberResult, util.ID, error) {
-	return s.createMember(ctx, c, true, true)
-}
+// MoveRole moves a role, with all its sub roles, members and tensions, under
+// another circle. The calling member must be able to manage the child roles of
+// both the current and the new parent circle.
+func (s *CommandService) MoveRole(ctx context.Context, roleID, newParentRoleID util.ID) (*change.MoveRoleResult, util.ID, error) {
+	res := &change.MoveRoleResult{}
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
 
-func (s *CommandService) CreateMemberInternal(ctx context.Context, c *change.CreateMemberChange, checkPassword bool, checkAuth bool) (*change.CreateMemberResult, util.ID, error) {
-	return s.createMember(ctx, c, checkPassword, checkAuth)
-}
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
 
-func (s *CommandService) createMember(ctx context.Context, c *change.CreateMemberChange, checkPassword bool, checkAuth bool) (*change.CreateMemberResult, util.ID, error) {
-	res := &change.CreateMemberResult{}
-	if c.UserName == "" {
-		res.HasErrors = true
-		res.CreateMemberChangeErrors.UserName = errors.Errorf("empty user name")
-	} else {
-		if len([]rune(c.UserName)) < MinMemberUserNameLength {
-			res.HasErrors = true
-			res.CreateMemberChangeErrors.UserName = errors.Errorf("user name too short")
-		} else if len([]rune(c.UserName)) > MaxMemberUserNameLength {
-			res.HasErrors = true
-			res.CreateMemberChangeErrors.UserName = errors.Errorf("user name too long")
-		} else if !isUserNameValidFormat(c.UserName) {
-			res.HasErrors = true
-			res.CreateMemberChangeErrors.UserName = errors.Errorf("invalid user name")
-		}
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
 	}
-	if c.FullName == "" {
-		res.HasErrors = true
-		res.CreateMemberChangeErrors.FullName = errors.Errorf("empty user full name")
-	} else {
+
+	role, err := readDBService.Role(ctx, curTlSeq, roleID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if role == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("role with id %s doesn't exist", roleID)
+		return res, util.NilID, ErrValidation
+	}
+	if role.RoleType.IsCoreRoleType() {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("role with id %s is a core role type (not a normal role or a circle)", roleID)
+		return res, util.NilID, ErrValidation
+	}
+
+	proleGroups, err := readDBService.RoleParent(ctx, curTlSeq, []util.ID{roleID})
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	prole := proleGroups[roleID]
+	if prole == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("role with id %s is the root role", roleID)
+		return res, util.NilID, ErrValidation
+	}
+	if prole.ID == newParentRoleID {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("role with id %s is already a child of role %s", roleID, newParentRoleID)
+		return res, util.NilID, ErrValidation
+	}
+
+	newParentRole, err := readDBService.Role(ctx, curTlSeq, newParentRoleID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if newParentRole == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("role with id %s doesn't exist", newParentRoleID)
+		return res, util.NilID, ErrValidation
+	}
+	if newParentRole.RoleType != models.RoleTypeCircle {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("role with id %s isn't a circle", newParentRoleID)
+		return res, util.NilID, ErrValidation
+	}
+
+	// the new parent must not be the role itself or one of its sub roles
+	newParentRoleParentsGroups, err := readDBService.RoleParents(ctx, curTlSeq, []util.ID{newParentRoleID})
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	newParentRoleParents := append(newParentRoleParentsGroups[newParentRoleID], newParentRole)
+	for _, p := range newParentRoleParents {
+		if p.ID == roleID {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("cannot move role with id %s inside itself", roleID)
+			return res, util.NilID, ErrValidation
+		}
+	}
+
+	for _, circleID := range []util.ID{prole.ID, newParentRoleID} {
+		cp, err := readDBService.MemberCirclePermissions(ctx, curTlSeq, circleID)
+		if err != nil {
+			return nil, util.NilID, err
+		}
+		if !cp.ManageChildRoles {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("member not authorized")
+			return res, util.NilID, ErrValidation
+		}
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeMoveRole, correlationID, causationID, callingMember.ID, &commands.MoveRole{RoleID: roleID, NewParentRoleID: newParentRoleID})
+
+	rtr := aggregate.NewRolesTreeRepository(s.dataDir, s.es, s.uidGenerator)
+	rt, err := rtr.Load(aggregate.RolesTreeAggregateID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, rt)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+func (s *CommandService) CreateMember(ctx context.Context, c *change.CreateMemberChange) (*change.CreateMemberResult, util.ID, error) {
+	return s.createMember(ctx, c, true, true)
+}
+
+func (s *CommandService) CreateMemberInternal(ctx context.Context, c *change.CreateMemberChange, checkPassword bool, checkAuth bool) (*change.CreateMemberResult, util.ID, error) {
+	return s.createMember(ctx, c, checkPassword, checkAuth)
+}
+
+func (s *CommandService) createMember(ctx context.Context, c *change.CreateMemberChange, checkPassword bool, checkAuth bool) (*change.CreateMemberResult, util.ID, error) {
+	res := &change.CreateMemberResult{}
+	if c.UserName == "" {
+		res.HasErrors = true
+		res.CreateMemberChangeErrors.UserName = errors.Errorf("empty user name")
+	} else {
+		if len([]rune(c.UserName)) < MinMemberUserNameLength {
+			res.HasErrors = true
+			res.CreateMemberChangeErrors.UserName = errors.Errorf("user name too short")
+		} else if len([]rune(c.UserName)) > MaxMemberUserNameLength {
+			res.HasErrors = true
+			res.CreateMemberChangeErrors.UserName = errors.Errorf("user name too long")
+		} else if !isUserNameValidFormat(c.UserName) {
+			res.HasErrors = true
+			res.CreateMemberChangeErrors.UserName = errors.Errorf("invalid user name")
+		}
+	}
+	if c.FullName == "" {
+		res.HasErrors = true
+		res.CreateMemberChangeErrors.FullName = errors.Errorf("empty user full name")
+	} else {
 		if len([]rune(c.FullName)) < MinMemberFullNameLength {
 			res.HasErrors = true
 			res.CreateMemberChangeErrors.FullName = errors.Errorf("user full name too short")
@@ -736,7 +1007,7 @@ func (s *CommandService) createMember(ctx context.Context, c *change.CreateMembe
 	}
 
 	if c.Password == "" {
-		if checkPassword {
+		if checkPassword && !c.IsServiceAccount {
 			res.HasErrors = true
 			res.CreateMemberChangeErrors.Password = errors.Errorf("empty password")
 		}
@@ -757,6 +1028,18 @@ func (s *CommandService) createMember(ctx context.Context, c *change.CreateMembe
 		}
 	}
 
+	// service accounts can only authenticate with api tokens
+	if c.IsServiceAccount {
+		if c.Password != "" {
+			res.HasErrors = true
+			res.CreateMemberChangeErrors.Password = errors.Errorf("service accounts cannot have a password")
+		}
+		if c.MatchUID != "" {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("service accounts cannot have a matchUID")
+		}
+	}
+
 	var avatar []byte
 	if c.AvatarData != nil {
 		var err error
@@ -871,7 +1154,7 @@ func (s *CommandService) createMember(ctx context.Context, c *change.CreateMembe
 		return nil, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, mc, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, mc)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -1048,7 +1331,7 @@ func (s *CommandService) UpdateMember(ctx context.Context, c *change.UpdateMembe
 		return nil, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, mc, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, mc)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -1103,6 +1386,21 @@ func (s *CommandService) SetMemberPassword(ctx context.Context, memberID util.ID
 		return res, util.NilID, ErrValidation
 	}
 
+	member, err := readDBService.Member(ctx, curTlSeq, memberID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if member == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member with id %s doesn't exist", memberID)
+		return res, util.NilID, ErrValidation
+	}
+	if member.IsServiceAccount {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("service accounts cannot have a password")
+		return res, util.NilID, ErrValidation
+	}
+
 	// Also admin needs to provide his current password
 	if !callingMember.IsAdmin || callingMember.ID == memberID {
 		if _, err = readDBService.AuthenticateUIDPassword(ctx, memberID, curPassword); err != nil {
@@ -1127,7 +1425,7 @@ func (s *CommandService) SetMemberPassword(ctx context.Context, memberID util.ID
 		return nil, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, m, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, m)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -1180,12 +1478,22 @@ func (s *CommandService) setMemberMatchUID(ctx context.Context, memberID util.ID
 		callingMemberID = callingMember.ID
 	}
 
+	member, err := readDBService.Member(ctx, curTlSeq, memberID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if member != nil && member.IsServiceAccount {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("service accounts cannot have a matchUID")
+		return res, util.NilID, ErrValidation
+	}
+
 	// check that the member matchUID isn't already in use
-	member, err := readDBService.MemberByMatchUID(ctx, matchUID)
+	matchUIDMember, err := readDBService.MemberByMatchUID(ctx, matchUID)
 	if err != nil {
 		return nil, util.NilID, err
 	}
-	if member != nil {
+	if matchUIDMember != nil {
 		res.HasErrors = true
 		res.GenericError = errors.Errorf("matchUID already in use")
 		return res, util.NilID, ErrValidation
@@ -1193,7 +1501,7 @@ func (s *CommandService) setMemberMatchUID(ctx context.Context, memberID util.ID
 
 	correlationID := s.uidGenerator.UUID("")
 	causationID := s.uidGenerator.UUID("")
-	command := commands.NewCommand(commands.CommandTypeRequestSetMemberMatchUID, correlationID, causationID, callingMemberID, &commands.RequestSetMemberMatchUID{member.ID, matchUID})
+	command := commands.NewCommand(commands.CommandTypeRequestSetMemberMatchUID, correlationID, causationID, callingMemberID, &commands.RequestSetMemberMatchUID{memberID, matchUID})
 
 	memberChangeID := s.uidGenerator.UUID("")
 	mcr := aggregate.NewMemberChangeRepository(s.es, s.uidGenerator)
@@ -1202,37 +1510,3744 @@ func (s *CommandService) setMemberMatchUID(ctx context.Context, memberID util.ID
 		return nil, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, mc, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, mc)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	log.Debugf("waiting for request completed event for memberChangeID: %s", memberChangeID)
+	groupID, err = s.waitMemberChangeRequest(ctx, memberChangeID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	log.Debugf("received request completed event for memberChangeID: %s, groupID: %s", memberChangeID, groupID)
+
+	return res, groupID, nil
+}
+
+// DeactivateMember deactivates a member so it cannot login anymore. The
+// returned result reports all the member roles and circles memberships. When
+// removeAssignments is true the member is also removed from all of them.
+func (s *CommandService) DeactivateMember(ctx context.Context, memberID util.ID, removeAssignments bool) (*change.DeactivateMemberResult, util.ID, error) {
+	res := &change.DeactivateMemberResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	// only admin can deactivate a member
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if !callingMember.IsAdmin {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+	if callingMember.ID == memberID {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("cannot deactivate yourself")
+		return res, util.NilID, ErrValidation
+	}
+
+	member, err := readDBService.Member(ctx, curTlSeq, memberID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if member == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member with id %s doesn't exist", memberID)
+		return res, util.NilID, ErrValidation
+	}
+	if member.IsDeactivated {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member already deactivated")
+		return res, util.NilID, ErrValidation
+	}
+
+	res.Assignments, err = readDBService.MemberAssignments(ctx, curTlSeq, memberID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeDeactivateMember, correlationID, causationID, callingMember.ID, &commands.DeactivateMember{})
+
+	mr := aggregate.NewMemberRepository(s.es, s.uidGenerator)
+	m, err := mr.Load(memberID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	acs := []*aggregate.AggregateCommand{{Command: command, Aggregate: m}}
+
+	if removeAssignments {
+		// all the assignments are removed with a single command on the roles
+		// tree executed atomically with the member deactivation
+		command := commands.NewCommand(commands.CommandTypeRemoveMemberAssignments, correlationID, command.ID, callingMember.ID, &commands.RemoveMemberAssignments{MemberID: memberID})
+
+		rtr := aggregate.NewRolesTreeRepository(s.dataDir, s.es, s.uidGenerator)
+		rt, err := rtr.Load(aggregate.RolesTreeAggregateID)
+		if err != nil {
+			return nil, util.NilID, err
+		}
+
+		acs = append(acs, &aggregate.AggregateCommand{Command: command, Aggregate: rt})
+	}
+
+	groupID, _, err := s.execCommands(ctx, acs)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+func (s *CommandService) ReactivateMember(ctx context.Context, memberID util.ID) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	// only admin can reactivate a member
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if !callingMember.IsAdmin {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+
+	member, err := readDBService.Member(ctx, curTlSeq, memberID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if member == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member with id %s doesn't exist", memberID)
+		return res, util.NilID, ErrValidation
+	}
+	if !member.IsDeactivated {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not deactivated")
+		return res, util.NilID, ErrValidation
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeReactivateMember, correlationID, causationID, callingMember.ID, &commands.ReactivateMember{})
+
+	mr := aggregate.NewMemberRepository(s.es, s.uidGenerator)
+	m, err := mr.Load(memberID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, m)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// CreateSession creates a new session for the provided member returning its
+// id, that must be used as the issued access tokens id (jti), and its first
+// refresh token. It's called after a successful authentication so no
+// permission checks are done.
+func (s *CommandService) CreateSession(ctx context.Context, memberID util.ID, expiration time.Time) (util.ID, string, util.ID, error) {
+	sessionID := s.uidGenerator.UUID("")
+
+	refreshToken, err := util.GenerateOpaqueToken(util.RefreshTokenPrefix)
+	if err != nil {
+		return util.NilID, "", util.NilID, err
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeCreateSession, correlationID, causationID, memberID, &commands.CreateSession{
+		MemberID:         memberID,
+		Expiration:       expiration,
+		RefreshTokenID:   s.uidGenerator.UUID(""),
+		RefreshTokenHash: util.TokenHash(refreshToken),
+	})
+
+	sr := aggregate.NewSessionRepository(s.es, s.uidGenerator)
+	se, err := sr.Load(sessionID)
+	if err != nil {
+		return util.NilID, "", util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, se)
+	if err != nil {
+		return util.NilID, "", util.NilID, err
+	}
+
+	return sessionID, refreshToken, groupID, nil
+}
+
+// RefreshSession rotates the session refresh token returning a new one. When
+// an already rotated refresh token is used (it could have been stolen) the
+// whole session is revoked. The refresh token is the only credential so no
+// permission checks are done.
+func (s *CommandService) RefreshSession(ctx context.Context, refreshToken string) (*change.RefreshSessionResult, util.ID, error) {
+	res := &change.RefreshSessionResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	rt, err := readDBService.RefreshTokenByHash(ctx, util.TokenHash(refreshToken))
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if rt == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("invalid refresh token")
+		return res, util.NilID, ErrValidation
+	}
+	session, err := readDBService.Session(ctx, rt.SessionID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if session == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("invalid refresh token")
+		return res, util.NilID, ErrValidation
+	}
+
+	if rt.Used {
+		log.Errorf("reused refresh token %s, revoking session %s", rt.ID, session.ID)
+		if _, err := s.revokeSession(ctx, session.MemberID, session.ID, true); err != nil {
+			return nil, util.NilID, err
+		}
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("refresh token already used, session revoked")
+		return res, util.NilID, ErrValidation
+	}
+
+	if !session.Expiration.After(time.Now()) {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("session expired")
+		return res, util.NilID, ErrValidation
+	}
+
+	member, err := readDBService.Member(ctx, curTlSeq, session.MemberID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if member == nil || member.IsDeactivated {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member with id %s doesn't exist or is deactivated", session.MemberID)
+		return res, util.NilID, ErrValidation
+	}
+
+	newRefreshToken, err := util.GenerateOpaqueToken(util.RefreshTokenPrefix)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeRotateSessionRefreshToken, correlationID, causationID, session.MemberID, &commands.RotateSessionRefreshToken{
+		RefreshTokenID:      rt.ID,
+		NewRefreshTokenID:   s.uidGenerator.UUID(""),
+		NewRefreshTokenHash: util.TokenHash(newRefreshToken),
+	})
+
+	sr := aggregate.NewSessionRepository(s.es, s.uidGenerator)
+	se, err := sr.Load(session.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	// the read db could be behind and not report an already used refresh
+	// token, in this case the session aggregate revokes the session instead
+	// of rotating the refresh token
+	reused := !se.IsCurrentRefreshToken(rt.ID)
+
+	groupID, _, err := s.execCommand(ctx, command, se)
+	if err != nil {
+		// the session has been revoked
+		if _, ok := err.(*aggregate.HandleCommandError); ok {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("invalid refresh token")
+			return res, util.NilID, ErrValidation
+		}
+		return nil, util.NilID, err
+	}
+	if reused {
+		log.Errorf("reused refresh token %s, revoked session %s", rt.ID, session.ID)
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("refresh token already used, session revoked")
+		return res, util.NilID, ErrValidation
+	}
+
+	res.SessionID = session.ID
+	res.MemberID = session.MemberID
+	res.Expiration = session.Expiration
+	res.RefreshToken = newRefreshToken
+
+	return res, groupID, nil
+}
+
+// RevokeSession revokes a session of the calling member. The returned groupID
+// is nil if the session was already revoked.
+func (s *CommandService) RevokeSession(ctx context.Context, sessionID util.ID) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	session, err := readDBService.Session(ctx, sessionID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if session == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("session with id %s doesn't exist", sessionID)
+		return res, util.NilID, ErrValidation
+	}
+	if session.MemberID != callingMember.ID {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+
+	groupID, err := s.revokeSession(ctx, callingMember.ID, sessionID, false)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// RevokeMemberSessions revokes all the sessions of a member (logging it out
+// from everywhere). A member can revoke its own sessions while an admin can
+// revoke the sessions of every member (also deactivated ones). All the sessions
+// are revoked atomically. The returned groupID is nil if there weren't
+// sessions to revoke.
+func (s *CommandService) RevokeMemberSessions(ctx context.Context, memberID util.ID) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if !callingMember.IsAdmin && callingMember.ID != memberID {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+
+	member, err := readDBService.Member(ctx, curTlSeq, memberID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if member == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member with id %s doesn't exist", memberID)
+		return res, util.NilID, ErrValidation
+	}
+
+	sessions, err := readDBService.MemberSessions(ctx, memberID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	// Also the expired sessions are revoked to remove them from the read db
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	sr := aggregate.NewSessionRepository(s.es, s.uidGenerator)
+	acs := []*aggregate.AggregateCommand{}
+	for _, session := range sessions {
+		command := commands.NewCommand(commands.CommandTypeRevokeSession, correlationID, causationID, callingMember.ID, &commands.RevokeSession{})
+
+		se, err := sr.Load(session.ID)
+		if err != nil {
+			return nil, util.NilID, err
+		}
+
+		acs = append(acs, &aggregate.AggregateCommand{Command: command, Aggregate: se})
+	}
+
+	groupID, n, err := s.execCommands(ctx, acs)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	// all the sessions were already revoked
+	if n == 0 {
+		return res, util.NilID, nil
+	}
+
+	return res, groupID, nil
+}
+
+// revokeSession revokes the session returning a nil groupID if it was already
+// revoked
+func (s *CommandService) revokeSession(ctx context.Context, issuerID, sessionID util.ID, refreshTokenReused bool) (util.ID, error) {
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeRevokeSession, correlationID, causationID, issuerID, &commands.RevokeSession{RefreshTokenReused: refreshTokenReused})
+
+	sr := aggregate.NewSessionRepository(s.es, s.uidGenerator)
+	se, err := sr.Load(sessionID)
+	if err != nil {
+		return util.NilID, err
+	}
+
+	groupID, n, err := s.execCommand(ctx, command, se)
+	if err != nil {
+		return util.NilID, err
+	}
+	if n == 0 {
+		return util.NilID, nil
+	}
+
+	return groupID, nil
+}
+
+// CreateAPIToken creates an api token for the calling member (when MemberID is
+// nil) or, if the calling member is an admin, for a service account. The returned token is
+// the only place where it's available since only its hash is saved.
+func (s *CommandService) CreateAPIToken(ctx context.Context, c *change.CreateAPITokenChange) (*change.CreateAPITokenResult, util.ID, error) {
+	res := &change.CreateAPITokenResult{}
+	if c.Name == "" {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("empty api token name")
+	} else if len([]rune(c.Name)) > MaxAPITokenNameLength {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("api token name too long")
+	}
+	if !c.Scope.IsValid() {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("invalid api token scope %q", c.Scope)
+	}
+	if !c.Expiration.After(time.Now()) {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("api token expiration must be in the future")
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	memberID := c.MemberID
+	if memberID == util.NilID {
+		memberID = callingMember.ID
+	}
+	member, err := readDBService.Member(ctx, curTlSeq, memberID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if member == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member with id %s doesn't exist", memberID)
+		return res, util.NilID, ErrValidation
+	}
+	if callingMember.ID != member.ID && !(callingMember.IsAdmin && member.IsServiceAccount) {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+	if member.IsDeactivated {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member is deactivated")
+		return res, util.NilID, ErrValidation
+	}
+
+	token, err := util.GenerateOpaqueToken(util.APITokenPrefix)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	apiTokenID := s.uidGenerator.UUID("")
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeCreateAPIToken, correlationID, causationID, callingMember.ID, &commands.CreateAPIToken{
+		MemberID:   member.ID,
+		Name:       c.Name,
+		Scope:      c.Scope,
+		Expiration: c.Expiration,
+		TokenHash:  util.TokenHash(token),
+	})
+
+	ar := aggregate.NewAPITokenRepository(s.es, s.uidGenerator)
+	a, err := ar.Load(apiTokenID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, a)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	res.APITokenID = &apiTokenID
+	res.Token = token
+
+	return res, groupID, nil
+}
+
+// RevokeAPIToken revokes an api token. A member can revoke its own api tokens
+// while an admin can revoke every api token.
+func (s *CommandService) RevokeAPIToken(ctx context.Context, apiTokenID util.ID) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	apiToken, err := readDBService.APIToken(ctx, curTlSeq, apiTokenID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if apiToken == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("api token with id %s doesn't exist", apiTokenID)
+		return res, util.NilID, ErrValidation
+	}
+	if !callingMember.IsAdmin && apiToken.MemberID != callingMember.ID {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeRevokeAPIToken, correlationID, causationID, callingMember.ID, &commands.RevokeAPIToken{})
+
+	ar := aggregate.NewAPITokenRepository(s.es, s.uidGenerator)
+	a, err := ar.Load(apiTokenID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, a)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+func (s *CommandService) CreateTension(ctx context.Context, c *change.CreateTensionChange) (*change.CreateTensionResult, util.ID, error) {
+	res := &change.CreateTensionResult{}
+	if c.Title == "" {
+		res.HasErrors = true
+		res.CreateTensionChangeErrors.Title = errors.Errorf("empty tension title")
+	}
+	if len([]rune(c.Title)) > MaxTensionTitleLength {
+		res.HasErrors = true
+		res.CreateTensionChangeErrors.Title = errors.Errorf("title too long")
+	}
+	if len([]rune(c.Description)) > MaxTensionDescriptionLength {
+		res.HasErrors = true
+		res.CreateTensionChangeErrors.Description = errors.Errorf("description too long")
+	}
+
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	if c.RoleID != nil {
+		role, err := readDBService.Role(ctx, curTlSeq, *c.RoleID)
+		if err != nil {
+			return nil, util.NilID, err
+		}
+		if role == nil {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("role with id %s doesn't exist", c.RoleID)
+			return res, util.NilID, ErrValidation
+		}
+		if role.RoleType != models.RoleTypeCircle {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("role with id %s is not a circle", c.RoleID)
+			return res, util.NilID, ErrValidation
+		}
+		// Check that the user is a member of the role
+		circleMemberEdgesGroups, err := readDBService.CircleMemberEdges(ctx, curTlSeq, []util.ID{role.ID})
+		if err != nil {
+			return nil, util.NilID, err
+		}
+		isRoleMember := false
+		circleMemberEdges := circleMemberEdgesGroups[role.ID]
+		for _, circleMemberEdge := range circleMemberEdges {
+			if circleMemberEdge.Member.ID == callingMember.ID {
+				isRoleMember = true
+				break
+			}
+		}
+		if !isRoleMember {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("member is not member of role")
+			return res, util.NilID, ErrValidation
+		}
+
+	}
+
+	tensionID := s.uidGenerator.UUID(c.Title)
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeCreateTension, correlationID, causationID, callingMember.ID, commands.NewCommandCreateTension(callingMember.ID, c))
+
+	tr := aggregate.NewTensionRepository(s.es, s.uidGenerator)
+	t, err := tr.Load(tensionID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, t)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	res.TensionID = &tensionID
+
+	return res, groupID, nil
+}
+
+func (s *CommandService) UpdateTension(ctx context.Context, c *change.UpdateTensionChange) (*change.UpdateTensionResult, util.ID, error) {
+	res := &change.UpdateTensionResult{}
+	if c.Title == "" {
+		res.HasErrors = true
+		res.UpdateTensionChangeErrors.Title = errors.Errorf("empty tension title")
+	}
+	if len([]rune(c.Title)) > MaxTensionTitleLength {
+		res.HasErrors = true
+		res.UpdateTensionChangeErrors.Title = errors.Errorf("title too long")
+	}
+	if len([]rune(c.Description)) > MaxTensionDescriptionLength {
+		res.HasErrors = true
+		res.UpdateTensionChangeErrors.Description = errors.Errorf("description too long")
+	}
+
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	tension, err := readDBService.Tension(ctx, curTlSeq, c.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	if tension == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("tension with id %s doesn't exist", c.ID)
+		return res, util.NilID, ErrValidation
+	}
+
+	if tension.Closed {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("tension already closed")
+		return res, util.NilID, ErrValidation
+	}
+
+	tensionMemberGroups, err := readDBService.TensionMember(ctx, curTlSeq, []util.ID{tension.ID})
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	tensionMember := tensionMemberGroups[tension.ID]
+
+	// Assume that a tension always have a member, or something is wrong
+	if !callingMember.IsAdmin && callingMember.ID != tensionMember.ID {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+
+	if c.RoleID != nil {
+		role, err := readDBService.Role(ctx, curTlSeq, *c.RoleID)
+		if err != nil {
+			return nil, util.NilID, err
+		}
+		if role == nil {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("role with id %s doesn't exist", c.RoleID)
+			return res, util.NilID, ErrValidation
+		}
+		if role.RoleType != models.RoleTypeCircle {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("role with id %s is not a circle", c.RoleID)
+			return res, util.NilID, ErrValidation
+		}
+		// Check that the user is a member of the role
+		circleMemberEdgesGroups, err := readDBService.CircleMemberEdges(ctx, curTlSeq, []util.ID{role.ID})
+		if err != nil {
+			return nil, util.NilID, err
+		}
+		isRoleMember := false
+		circleMemberEdges := circleMemberEdgesGroups[role.ID]
+		for _, circleMemberEdge := range circleMemberEdges {
+			if circleMemberEdge.Member.ID == callingMember.ID {
+				isRoleMember = true
+				break
+			}
+		}
+		if !isRoleMember {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("member is not member of role")
+			return res, util.NilID, ErrValidation
+		}
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeUpdateTension, correlationID, causationID, callingMember.ID, commands.NewCommandUpdateTension(c))
+
+	tr := aggregate.NewTensionRepository(s.es, s.uidGenerator)
+	t, err := tr.Load(c.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, t)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+func (s *CommandService) CloseTension(ctx context.Context, c *change.CloseTensionChange) (*change.CloseTensionResult, util.ID, error) {
+	res := &change.CloseTensionResult{}
+
+	if len([]rune(c.Reason)) > MaxTensionCloseReasonLength {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("close reason too long")
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	tension, err := readDBService.Tension(ctx, curTlSeq, c.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	if tension == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("tension with id %s doesn't exist", c.ID)
+		return res, util.NilID, ErrValidation
+	}
+
+	if tension.Closed {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("tension already closed")
+		return res, util.NilID, ErrValidation
+	}
+
+	tensionMemberGroups, err := readDBService.TensionMember(ctx, curTlSeq, []util.ID{tension.ID})
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	tensionMember := tensionMemberGroups[tension.ID]
+
+	// Assume that a tension always have a member, or something is wrong
+	if !callingMember.IsAdmin && callingMember.ID != tensionMember.ID {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+
+	if c.MeetingID != nil {
+		if err := s.checkMeetingExists(ctx, readDBService, curTlSeq, *c.MeetingID, &res.HasErrors, &res.GenericError); err != nil {
+			return nil, util.NilID, err
+		}
+		if res.HasErrors {
+			return res, util.NilID, ErrValidation
+		}
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeCloseTension, correlationID, causationID, callingMember.ID, commands.NewCommandCloseTension(c))
+
+	tr := aggregate.NewTensionRepository(s.es, s.uidGenerator)
+	t, err := tr.Load(c.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, t)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+func (s *CommandService) ChangeTensionStatus(ctx context.Context, tensionID util.ID, status models.TensionStatus) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	if !status.IsValid() {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("invalid tension status %q", status)
+		return res, util.NilID, ErrValidation
+	}
+	if status.IsFinal() {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("tension status %q can be set only resolving or closing the tension", status)
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	tension, err := s.checkTensionTriage(ctx, readDBService, curTlSeq, tensionID, callingMember, false, &res.HasErrors, &res.GenericError)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	if tension.Status == status {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("tension already in status %q", status)
+		return res, util.NilID, ErrValidation
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeChangeTensionStatus, correlationID, causationID, callingMember.ID, &commands.ChangeTensionStatus{Status: status})
+
+	tr := aggregate.NewTensionRepository(s.es, s.uidGenerator)
+	t, err := tr.Load(tensionID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, t)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// ChangeTensionAssignee assigns the tension to the provided member. A nil
+// memberID unassigns the tension
+func (s *CommandService) ChangeTensionAssignee(ctx context.Context, tensionID util.ID, memberID *util.ID) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	tension, err := s.checkTensionTriage(ctx, readDBService, curTlSeq, tensionID, callingMember, false, &res.HasErrors, &res.GenericError)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	if memberID != nil {
+		member, err := readDBService.Member(ctx, curTlSeq, *memberID)
+		if err != nil {
+			return nil, util.NilID, err
+		}
+		if member == nil {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("member with id %s doesn't exist", memberID)
+			return res, util.NilID, ErrValidation
+		}
+		if member.IsDeactivated {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("member with id %s is deactivated", memberID)
+			return res, util.NilID, ErrValidation
+		}
+
+		// the assignee of a tension related to a circle must be a member
+		// of the circle
+		tensionRoleGroups, err := readDBService.TensionRole(ctx, curTlSeq, []util.ID{tension.ID})
+		if err != nil {
+			return nil, util.NilID, err
+		}
+		if tensionRole := tensionRoleGroups[tension.ID]; tensionRole != nil {
+			isRoleMember, err := s.isCircleMember(ctx, readDBService, curTlSeq, tensionRole.ID, member.ID, false)
+			if err != nil {
+				return nil, util.NilID, err
+			}
+			if !isRoleMember {
+				res.HasErrors = true
+				res.GenericError = errors.Errorf("member is not member of role")
+				return res, util.NilID, ErrValidation
+			}
+		}
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeChangeTensionAssignee, correlationID, causationID, callingMember.ID, &commands.ChangeTensionAssignee{MemberID: memberID})
+
+	tr := aggregate.NewTensionRepository(s.es, s.uidGenerator)
+	t, err := tr.Load(tensionID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, t)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// ChangeTensionMeeting links the tension to the meeting where it was
+// processed. A nil meetingID removes the link. Since the meeting can be
+// recorded after the tension processing this is permitted also on closed
+// tensions.
+func (s *CommandService) ChangeTensionMeeting(ctx context.Context, tensionID util.ID, meetingID *util.ID) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	if _, err := s.checkTensionTriage(ctx, readDBService, curTlSeq, tensionID, callingMember, true, &res.HasErrors, &res.GenericError); err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	if meetingID != nil {
+		if err := s.checkMeetingExists(ctx, readDBService, curTlSeq, *meetingID, &res.HasErrors, &res.GenericError); err != nil {
+			return nil, util.NilID, err
+		}
+		if res.HasErrors {
+			return res, util.NilID, ErrValidation
+		}
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeChangeTensionMeeting, correlationID, causationID, callingMember.ID, &commands.ChangeTensionMeeting{MeetingID: meetingID})
+
+	tr := aggregate.NewTensionRepository(s.es, s.uidGenerator)
+	t, err := tr.Load(tensionID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, t)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+func (s *CommandService) ResolveTension(ctx context.Context, c *change.ResolveTensionChange) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	if !c.OutcomeType.IsValid() {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("invalid tension outcome type %q", c.OutcomeType)
+		return res, util.NilID, ErrValidation
+	}
+	if len([]rune(c.OutcomeDescription)) > MaxTensionOutcomeDescriptionLength {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("outcome description too long")
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	if _, err := s.checkTensionTriage(ctx, readDBService, curTlSeq, c.ID, callingMember, false, &res.HasErrors, &res.GenericError); err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeResolveTension, correlationID, causationID, callingMember.ID, commands.NewCommandResolveTension(c))
+
+	tr := aggregate.NewTensionRepository(s.es, s.uidGenerator)
+	t, err := tr.Load(c.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, t)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// checkTensionTriage returns the tension and checks that the calling member
+// can triage it: an admin, the tension author or a core member of the tension
+// circle. If allowClosed is false closed tensions are rejected. On failure
+// hasErrors and genericError are populated.
+func (s *CommandService) checkTensionTriage(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, tensionID util.ID, callingMember *models.Member, allowClosed bool, hasErrors *bool, genericError *error) (*models.Tension, error) {
+	tension, err := readDBService.Tension(ctx, curTlSeq, tensionID)
+	if err != nil {
+		return nil, err
+	}
+	if tension == nil {
+		*hasErrors = true
+		*genericError = errors.Errorf("tension with id %s doesn't exist", tensionID)
+		return nil, nil
+	}
+	if !allowClosed && tension.Closed {
+		*hasErrors = true
+		*genericError = errors.Errorf("tension already closed")
+		return nil, nil
+	}
+
+	if callingMember.IsAdmin {
+		return tension, nil
+	}
+
+	tensionMemberGroups, err := readDBService.TensionMember(ctx, curTlSeq, []util.ID{tension.ID})
+	if err != nil {
+		return nil, err
+	}
+	// Assume that a tension always have a member, or something is wrong
+	if tensionMemberGroups[tension.ID].ID == callingMember.ID {
+		return tension, nil
+	}
+
+	tensionRoleGroups, err := readDBService.TensionRole(ctx, curTlSeq, []util.ID{tension.ID})
+	if err != nil {
+		return nil, err
+	}
+	if tensionRole := tensionRoleGroups[tension.ID]; tensionRole != nil {
+		isCoreMember, err := s.isCircleMember(ctx, readDBService, curTlSeq, tensionRole.ID, callingMember.ID, true)
+		if err != nil {
+			return nil, err
+		}
+		if isCoreMember {
+			return tension, nil
+		}
+	}
+
+	*hasErrors = true
+	*genericError = errors.Errorf("member not authorized")
+	return nil, nil
+}
+
+func (s *CommandService) CreateProject(ctx context.Context, c *change.CreateProjectChange) (*change.CreateProjectResult, util.ID, error) {
+	res := &change.CreateProjectResult{}
+	titleErr, descriptionErr := checkWorkChange("project", c.Title, c.Description, MaxProjectTitleLength, MaxProjectDescriptionLength)
+	if titleErr != nil || descriptionErr != nil {
+		res.HasErrors = true
+		res.CreateProjectChangeErrors.Title = titleErr
+		res.CreateProjectChangeErrors.Description = descriptionErr
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	if err := s.checkWorkCreate(ctx, readDBService, curTlSeq, c.RoleID, c.MemberID, c.TensionID, callingMember, &res.HasErrors, &res.GenericError); err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	projectID := s.uidGenerator.UUID(c.Title)
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeCreateProject, correlationID, causationID, callingMember.ID, commands.NewCommandCreateProject(c))
+
+	vr := aggregate.NewProjectRepository(s.es, s.uidGenerator)
+	v, err := vr.Load(projectID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, v)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	res.ProjectID = &projectID
+
+	return res, groupID, nil
+}
+
+func (s *CommandService) UpdateProject(ctx context.Context, c *change.UpdateProjectChange) (*change.UpdateProjectResult, util.ID, error) {
+	res := &change.UpdateProjectResult{}
+	titleErr, descriptionErr := checkWorkChange("project", c.Title, c.Description, MaxProjectTitleLength, MaxProjectDescriptionLength)
+	if titleErr != nil || descriptionErr != nil {
+		res.HasErrors = true
+		res.UpdateProjectChangeErrors.Title = titleErr
+		res.UpdateProjectChangeErrors.Description = descriptionErr
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	project, err := s.checkProjectOwner(ctx, readDBService, curTlSeq, c.ID, c.MemberID, callingMember, &res.HasErrors, &res.GenericError)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+	if err := checkWorkUpdate("project", project.Status); err != nil {
+		res.HasErrors = true
+		res.GenericError = err
+		return res, util.NilID, ErrValidation
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeUpdateProject, correlationID, causationID, callingMember.ID, commands.NewCommandUpdateProject(c))
+
+	vr := aggregate.NewProjectRepository(s.es, s.uidGenerator)
+	v, err := vr.Load(c.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, v)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+func (s *CommandService) ChangeProjectStatus(ctx context.Context, projectID util.ID, status models.ProjectStatus) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	if !status.IsValid() {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("invalid project status %q", status)
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	project, err := s.checkProjectOwner(ctx, readDBService, curTlSeq, projectID, nil, callingMember, &res.HasErrors, &res.GenericError)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+	if err := checkWorkStatusChange("project", project.Status, status); err != nil {
+		res.HasErrors = true
+		res.GenericError = err
+		return res, util.NilID, ErrValidation
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeChangeProjectStatus, correlationID, causationID, callingMember.ID, &commands.ChangeProjectStatus{Status: status})
+
+	vr := aggregate.NewProjectRepository(s.es, s.uidGenerator)
+	v, err := vr.Load(projectID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, v)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// checkProjectOwner returns the project and checks that the calling member can
+// change it and that the optional member can be assigned to it. On failure
+// hasErrors and genericError are populated.
+func (s *CommandService) checkProjectOwner(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, projectID util.ID, memberID *util.ID, callingMember *models.Member, hasErrors *bool, genericError *error) (*models.Project, error) {
+	project, err := readDBService.Project(ctx, curTlSeq, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		*hasErrors = true
+		*genericError = errors.Errorf("project with id %s doesn't exist", projectID)
+		return nil, nil
+	}
+
+	projectRoleGroups, err := readDBService.ProjectRole(ctx, curTlSeq, []util.ID{project.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkWorkRoleOwner(ctx, readDBService, curTlSeq, "project", projectRoleGroups[project.ID], memberID, callingMember, hasErrors, genericError); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (s *CommandService) CreateAction(ctx context.Context, c *change.CreateActionChange) (*change.CreateActionResult, util.ID, error) {
+	res := &change.CreateActionResult{}
+	titleErr, descriptionErr := checkWorkChange("action", c.Title, c.Description, MaxActionTitleLength, MaxActionDescriptionLength)
+	if titleErr != nil || descriptionErr != nil {
+		res.HasErrors = true
+		res.CreateActionChangeErrors.Title = titleErr
+		res.CreateActionChangeErrors.Description = descriptionErr
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	if err := s.checkWorkCreate(ctx, readDBService, curTlSeq, c.RoleID, c.MemberID, c.TensionID, callingMember, &res.HasErrors, &res.GenericError); err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	actionID := s.uidGenerator.UUID(c.Title)
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeCreateAction, correlationID, causationID, callingMember.ID, commands.NewCommandCreateAction(c))
+
+	vr := aggregate.NewActionRepository(s.es, s.uidGenerator)
+	v, err := vr.Load(actionID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, v)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	res.ActionID = &actionID
+
+	return res, groupID, nil
+}
+
+func (s *CommandService) UpdateAction(ctx context.Context, c *change.UpdateActionChange) (*change.UpdateActionResult, util.ID, error) {
+	res := &change.UpdateActionResult{}
+	titleErr, descriptionErr := checkWorkChange("action", c.Title, c.Description, MaxActionTitleLength, MaxActionDescriptionLength)
+	if titleErr != nil || descriptionErr != nil {
+		res.HasErrors = true
+		res.UpdateActionChangeErrors.Title = titleErr
+		res.UpdateActionChangeErrors.Description = descriptionErr
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	action, err := s.checkActionOwner(ctx, readDBService, curTlSeq, c.ID, c.MemberID, callingMember, &res.HasErrors, &res.GenericError)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+	if err := checkWorkUpdate("action", action.Status); err != nil {
+		res.HasErrors = true
+		res.GenericError = err
+		return res, util.NilID, ErrValidation
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeUpdateAction, correlationID, causationID, callingMember.ID, commands.NewCommandUpdateAction(c))
+
+	vr := aggregate.NewActionRepository(s.es, s.uidGenerator)
+	v, err := vr.Load(c.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, v)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+func (s *CommandService) ChangeActionStatus(ctx context.Context, actionID util.ID, status models.ActionStatus) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	if !status.IsValid() {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("invalid action status %q", status)
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	action, err := s.checkActionOwner(ctx, readDBService, curTlSeq, actionID, nil, callingMember, &res.HasErrors, &res.GenericError)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+	if err := checkWorkStatusChange("action", action.Status, status); err != nil {
+		res.HasErrors = true
+		res.GenericError = err
+		return res, util.NilID, ErrValidation
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeChangeActionStatus, correlationID, causationID, callingMember.ID, &commands.ChangeActionStatus{Status: status})
+
+	vr := aggregate.NewActionRepository(s.es, s.uidGenerator)
+	v, err := vr.Load(actionID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, v)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// checkActionOwner returns the action and checks that the calling member can
+// change it and that the optional member can be assigned to it. On failure
+// hasErrors and genericError are populated.
+func (s *CommandService) checkActionOwner(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, actionID util.ID, memberID *util.ID, callingMember *models.Member, hasErrors *bool, genericError *error) (*models.Action, error) {
+	action, err := readDBService.Action(ctx, curTlSeq, actionID)
+	if err != nil {
+		return nil, err
+	}
+	if action == nil {
+		*hasErrors = true
+		*genericError = errors.Errorf("action with id %s doesn't exist", actionID)
+		return nil, nil
+	}
+
+	actionRoleGroups, err := readDBService.ActionRole(ctx, curTlSeq, []util.ID{action.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkWorkRoleOwner(ctx, readDBService, curTlSeq, "action", actionRoleGroups[action.ID], memberID, callingMember, hasErrors, genericError); err != nil {
+		return nil, err
+	}
+
+	return action, nil
+}
+
+// checkWorkChange validates the title and the description of a project or an
+// action
+func checkWorkChange(kind, title, description string, maxTitleLength, maxDescriptionLength int) (titleErr, descriptionErr error) {
+	if title == "" {
+		titleErr = errors.Errorf("empty %s title", kind)
+	}
+	if len([]rune(title)) > maxTitleLength {
+		titleErr = errors.Errorf("title too long")
+	}
+	if len([]rune(description)) > maxDescriptionLength {
+		descriptionErr = errors.Errorf("description too long")
+	}
+	return titleErr, descriptionErr
+}
+
+// checkWorkUpdate returns an error if a project or an action in the provided
+// status cannot be updated
+func checkWorkUpdate(kind string, status models.WorkStatus) error {
+	if status.IsFinal() {
+		return errors.Errorf("%s in status %q cannot be updated", kind, status)
+	}
+	return nil
+}
+
+// checkWorkStatusChange returns an error if a project or an action cannot
+// change from prevStatus to status
+func checkWorkStatusChange(kind string, prevStatus, status models.WorkStatus) error {
+	if prevStatus.IsFinal() {
+		return errors.Errorf("%s in status %q cannot be changed", kind, prevStatus)
+	}
+	if status.String() == prevStatus.String() {
+		return errors.Errorf("%s already in status %q", kind, status)
+	}
+	return nil
+}
+
+// checkWorkCreate checks that a project or an action can be created on the
+// role, assigned to the optional member and linked to the optional tension. On
+// failure hasErrors and genericError are populated.
+func (s *CommandService) checkWorkCreate(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, roleID util.ID, memberID, tensionID *util.ID, callingMember *models.Member, hasErrors *bool, genericError *error) error {
+	if err := s.checkWorkOwner(ctx, readDBService, curTlSeq, roleID, memberID, callingMember, hasErrors, genericError); err != nil || *hasErrors {
+		return err
+	}
+
+	if tensionID != nil {
+		tension, err := readDBService.Tension(ctx, curTlSeq, *tensionID)
+		if err != nil {
+			return err
+		}
+		if tension == nil {
+			*hasErrors = true
+			*genericError = errors.Errorf("tension with id %s doesn't exist", tensionID)
+		}
+	}
+
+	return nil
+}
+
+// checkWorkRoleOwner checks that the role of an existing project or action,
+// nil if it was deleted, can be changed by the calling member. On failure
+// hasErrors and genericError are populated.
+func (s *CommandService) checkWorkRoleOwner(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, kind string, role *models.Role, memberID *util.ID, callingMember *models.Member, hasErrors *bool, genericError *error) error {
+	if role == nil {
+		*hasErrors = true
+		*genericError = errors.Errorf("%s role doesn't exist anymore", kind)
+		return nil
+	}
+
+	return s.checkWorkOwner(ctx, readDBService, curTlSeq, role.ID, memberID, callingMember, hasErrors, genericError)
+}
+
+// checkWorkOwner checks that the role owning a project or an action exists,
+// that the calling member is an admin or fills the role and that the
+// optional assigned member fills the role. On failure hasErrors and
+// genericError are populated.
+func (s *CommandService) checkWorkOwner(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, roleID util.ID, memberID *util.ID, callingMember *models.Member, hasErrors *bool, genericError *error) error {
+	role, err := readDBService.Role(ctx, curTlSeq, roleID)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		*hasErrors = true
+		*genericError = errors.Errorf("role with id %s doesn't exist", roleID)
+		return nil
+	}
+
+	if !callingMember.IsAdmin {
+		isRoleMember, err := s.isRoleMember(ctx, readDBService, curTlSeq, role, callingMember.ID)
+		if err != nil {
+			return err
+		}
+		if !isRoleMember {
+			*hasErrors = true
+			*genericError = errors.Errorf("member not authorized")
+			return nil
+		}
+	}
+
+	if memberID != nil {
+		if err := s.checkMemberActive(ctx, readDBService, curTlSeq, *memberID, hasErrors, genericError); err != nil || *hasErrors {
+			return err
+		}
+		isRoleMember, err := s.isRoleMember(ctx, readDBService, curTlSeq, role, *memberID)
+		if err != nil {
+			return err
+		}
+		if !isRoleMember {
+			*hasErrors = true
+			*genericError = errors.Errorf("member with id %s is not member of role", memberID)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// checkMemberActive reports an error if the member doesn't exist or is
+// deactivated
+func (s *CommandService) checkMemberActive(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, memberID util.ID, hasErrors *bool, genericError *error) error {
+	member, err := readDBService.Member(ctx, curTlSeq, memberID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		*hasErrors = true
+		*genericError = errors.Errorf("member with id %s doesn't exist", memberID)
+		return nil
+	}
+	if member.IsDeactivated {
+		*hasErrors = true
+		*genericError = errors.Errorf("member with id %s is deactivated", memberID)
+		return nil
+	}
+	return nil
+}
+
+// isRoleMember reports if the member fills the role. For circles all the
+// circle members are considered.
+func (s *CommandService) isRoleMember(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, role *models.Role, memberID util.ID) (bool, error) {
+	if role.RoleType == models.RoleTypeCircle {
+		return s.isCircleMember(ctx, readDBService, curTlSeq, role.ID, memberID, false)
+	}
+
+	roleMemberEdgesGroups, err := readDBService.RoleMemberEdges(ctx, curTlSeq, []util.ID{role.ID}, nil)
+	if err != nil {
+		return false, err
+	}
+	for _, roleMemberEdge := range roleMemberEdgesGroups[role.ID] {
+		if roleMemberEdge.Member.ID == memberID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CreateMeeting records a circle meeting. The facilitator and secretary are
+// the members filling the circle core roles and, if not explicitly provided,
+// the attendees are all the circle members.
+func (s *CommandService) CreateMeeting(ctx context.Context, c *change.CreateMeetingChange) (*change.CreateMeetingResult, util.ID, error) {
+	res := &change.CreateMeetingResult{}
+
+	if !c.MeetingType.IsValid() {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("invalid meeting type %q", c.MeetingType)
+		return res, util.NilID, ErrValidation
+	}
+	if c.Date.IsZero() {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("empty meeting date")
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	role, err := readDBService.Role(ctx, curTlSeq, c.RoleID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if role == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("role with id %s doesn't exist", c.RoleID)
+		return res, util.NilID, ErrValidation
+	}
+	if role.RoleType != models.RoleTypeCircle {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("role is not a circle")
+		return res, util.NilID, ErrValidation
+	}
+
+	if !callingMember.IsAdmin {
+		isCircleMember, err := s.isCircleMember(ctx, readDBService, curTlSeq, role.ID, callingMember.ID, false)
+		if err != nil {
+			return nil, util.NilID, err
+		}
+		if !isCircleMember {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("member not authorized")
+			return res, util.NilID, ErrValidation
+		}
+	}
+
+	facilitatorID, err := s.circleCoreRoleMember(ctx, readDBService, curTlSeq, role.ID, models.RoleTypeFacilitator)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	secretaryID, err := s.circleCoreRoleMember(ctx, readDBService, curTlSeq, role.ID, models.RoleTypeSecretary)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	circleMemberEdgesGroups, err := readDBService.CircleMemberEdges(ctx, curTlSeq, []util.ID{role.ID})
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	circleMembers := map[util.ID]struct{}{}
+	attendeesIDs := []util.ID{}
+	for _, circleMemberEdge := range circleMemberEdgesGroups[role.ID] {
+		circleMembers[circleMemberEdge.Member.ID] = struct{}{}
+		attendeesIDs = append(attendeesIDs, circleMemberEdge.Member.ID)
+	}
+	if len(c.AttendeesIDs) > 0 {
+		attendeesIDs = []util.ID{}
+		seen := map[util.ID]struct{}{}
+		for _, attendeeID := range c.AttendeesIDs {
+			if _, ok := circleMembers[attendeeID]; !ok {
+				res.HasErrors = true
+				res.GenericError = errors.Errorf("member with id %s is not a circle member", attendeeID)
+				return res, util.NilID, ErrValidation
+			}
+			if _, ok := seen[attendeeID]; ok {
+				continue
+			}
+			seen[attendeeID] = struct{}{}
+			attendeesIDs = append(attendeesIDs, attendeeID)
+		}
+	}
+
+	meetingID := s.uidGenerator.UUID(c.MeetingType.String())
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeCreateMeeting, correlationID, causationID, callingMember.ID, &commands.CreateMeeting{
+		RoleID:        role.ID,
+		MeetingType:   c.MeetingType,
+		Date:          c.Date.UTC(),
+		FacilitatorID: facilitatorID,
+		SecretaryID:   secretaryID,
+		AttendeesIDs:  attendeesIDs,
+	})
+
+	mr := aggregate.NewMeetingRepository(s.es, s.uidGenerator)
+	m, err := mr.Load(meetingID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, m)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	res.MeetingID = &meetingID
+
+	return res, groupID, nil
+}
+
+// ReportMeetingValues records the values of the circle checklist items and
+// metrics reported during the meeting. The checklist items and metrics must
+// belong to the meeting circle or to one of its child roles.
+func (s *CommandService) ReportMeetingValues(ctx context.Context, c *change.ReportMeetingValuesChange) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	meeting, err := readDBService.Meeting(ctx, curTlSeq, c.MeetingID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if meeting == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("meeting with id %s doesn't exist", c.MeetingID)
+		return res, util.NilID, ErrValidation
+	}
+	meetingRoleGroups, err := readDBService.MeetingRole(ctx, curTlSeq, []util.ID{c.MeetingID})
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	role := meetingRoleGroups[c.MeetingID]
+	if role == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("meeting circle doesn't exist anymore")
+		return res, util.NilID, ErrValidation
+	}
+
+	if !callingMember.IsAdmin {
+		isCircleMember, err := s.isCircleMember(ctx, readDBService, curTlSeq, role.ID, callingMember.ID, false)
+		if err != nil {
+			return nil, util.NilID, err
+		}
+		if !isCircleMember {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("member not authorized")
+			return res, util.NilID, ErrValidation
+		}
+	}
+
+	// the circle and its child roles
+	rolesIDs := map[util.ID]struct{}{role.ID: {}}
+	childsGroups, err := readDBService.ChildRoles(ctx, curTlSeq, []util.ID{role.ID}, nil)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	for _, child := range childsGroups[role.ID] {
+		rolesIDs[child.ID] = struct{}{}
+	}
+
+	checklistItemsIDs := []util.ID{}
+	seen := map[util.ID]struct{}{}
+	for _, r := range c.ChecklistItemReports {
+		if _, ok := seen[r.ChecklistItemID]; ok {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("checklist item with id %s reported multiple times", r.ChecklistItemID)
+			return res, util.NilID, ErrValidation
+		}
+		seen[r.ChecklistItemID] = struct{}{}
+		checklistItemsIDs = append(checklistItemsIDs, r.ChecklistItemID)
+	}
+	checklistItemRoleGroups, err := readDBService.ChecklistItemRole(ctx, curTlSeq, checklistItemsIDs)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	for _, checklistItemID := range checklistItemsIDs {
+		r, ok := checklistItemRoleGroups[checklistItemID]
+		if !ok {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("checklist item with id %s doesn't exist", checklistItemID)
+			return res, util.NilID, ErrValidation
+		}
+		if _, ok := rolesIDs[r.ID]; !ok {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("checklist item with id %s doesn't belong to the meeting circle", checklistItemID)
+			return res, util.NilID, ErrValidation
+		}
+	}
+
+	metricsIDs := []util.ID{}
+	seen = map[util.ID]struct{}{}
+	for _, r := range c.MetricReports {
+		if _, ok := seen[r.MetricID]; ok {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("metric with id %s reported multiple times", r.MetricID)
+			return res, util.NilID, ErrValidation
+		}
+		if len([]rune(r.Value)) > MaxMeetingMetricValueLength {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("metric value too long")
+			return res, util.NilID, ErrValidation
+		}
+		seen[r.MetricID] = struct{}{}
+		metricsIDs = append(metricsIDs, r.MetricID)
+	}
+	metricRoleGroups, err := readDBService.MetricRole(ctx, curTlSeq, metricsIDs)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	for _, metricID := range metricsIDs {
+		r, ok := metricRoleGroups[metricID]
+		if !ok {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("metric with id %s doesn't exist", metricID)
+			return res, util.NilID, ErrValidation
+		}
+		if _, ok := rolesIDs[r.ID]; !ok {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("metric with id %s doesn't belong to the meeting circle", metricID)
+			return res, util.NilID, ErrValidation
+		}
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeReportMeetingValues, correlationID, causationID, callingMember.ID, &commands.ReportMeetingValues{
+		ChecklistItemReports: c.ChecklistItemReports,
+		MetricReports:        c.MetricReports,
+	})
+
+	mr := aggregate.NewMeetingRepository(s.es, s.uidGenerator)
+	m, err := mr.Load(c.MeetingID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, m)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// CreateElection opens an election for a circle core role
+func (s *CommandService) CreateElection(ctx context.Context, c *change.CreateElectionChange) (*change.CreateElectionResult, util.ID, error) {
+	res := &change.CreateElectionResult{}
+
+	if !c.RoleType.IsElectedRoleType() {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("invalid election role type %q", c.RoleType)
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	role, err := readDBService.Role(ctx, curTlSeq, c.RoleID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if role == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("role with id %s doesn't exist", c.RoleID)
+		return res, util.NilID, ErrValidation
+	}
+	if role.RoleType != models.RoleTypeCircle {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("role is not a circle")
+		return res, util.NilID, ErrValidation
+	}
+
+	if !callingMember.IsAdmin {
+		isCircleMember, err := s.isCircleMember(ctx, readDBService, curTlSeq, role.ID, callingMember.ID, false)
+		if err != nil {
+			return nil, util.NilID, err
+		}
+		if !isCircleMember {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("member not authorized")
+			return res, util.NilID, ErrValidation
+		}
+	}
+
+	electionID := s.uidGenerator.UUID(c.RoleType.String())
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeCreateElection, correlationID, causationID, callingMember.ID, &commands.CreateElection{
+		RoleID:   role.ID,
+		RoleType: c.RoleType,
+		MemberID: callingMember.ID,
+	})
+
+	er := aggregate.NewElectionRepository(s.es, s.uidGenerator)
+	e, err := er.Load(electionID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, e)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	res.ElectionID = &electionID
+
+	return res, groupID, nil
+}
+
+// NominateElectionCandidate nominates a circle member as a candidate of an
+// open election. The nominator is the calling member.
+func (s *CommandService) NominateElectionCandidate(ctx context.Context, electionID, candidateID util.ID) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	_, role, err := s.electionRole(ctx, readDBService, curTlSeq, electionID, &res.HasErrors, &res.GenericError)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	if !callingMember.IsAdmin {
+		isCircleMember, err := s.isCircleMember(ctx, readDBService, curTlSeq, role.ID, callingMember.ID, false)
+		if err != nil {
+			return nil, util.NilID, err
+		}
+		if !isCircleMember {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("member not authorized")
+			return res, util.NilID, ErrValidation
+		}
+	}
+
+	if err := s.checkMemberActive(ctx, readDBService, curTlSeq, candidateID, &res.HasErrors, &res.GenericError); err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	isCircleMember, err := s.isCircleMember(ctx, readDBService, curTlSeq, role.ID, candidateID, false)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if !isCircleMember {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member with id %s is not a circle member", candidateID)
+		return res, util.NilID, ErrValidation
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeNominateElectionCandidate, correlationID, causationID, callingMember.ID, &commands.NominateElectionCandidate{
+		CandidateID: candidateID,
+		NominatorID: callingMember.ID,
+	})
+
+	er := aggregate.NewElectionRepository(s.es, s.uidGenerator)
+	e, err := er.Load(electionID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, e)
+	if err != nil {
+		if _, ok := err.(*aggregate.HandleCommandError); ok {
+			res.HasErrors = true
+			res.GenericError = err
+			return res, util.NilID, ErrValidation
+		}
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// CompleteElection records the election result assigning the circle core
+// role to the elected candidate
+func (s *CommandService) CompleteElection(ctx context.Context, electionID, memberID util.ID, electionExpiration *time.Time) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	election, role, err := s.electionRole(ctx, readDBService, curTlSeq, electionID, &res.HasErrors, &res.GenericError)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	cp, err := readDBService.MemberCirclePermissions(ctx, curTlSeq, role.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if !cp.AssignCircleCoreRoles {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+
+	if election.Status != models.ElectionStatusOpen {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("cannot complete an election in status %q", election.Status)
+		return res, util.NilID, ErrValidation
+	}
+
+	nominationsGroups, err := readDBService.ElectionNominations(ctx, curTlSeq, []util.ID{electionID})
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	nominated := false
+	for _, nomination := range nominationsGroups[electionID] {
+		if nomination.Candidate.ID == memberID {
+			nominated = true
+			break
+		}
+	}
+	if !nominated {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member with id %s isn't a nominated candidate", memberID)
+		return res, util.NilID, ErrValidation
+	}
+
+	// the candidate could have been deactivated after the nomination
+	if err := s.checkMemberActive(ctx, readDBService, curTlSeq, memberID, &res.HasErrors, &res.GenericError); err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	if electionExpiration != nil {
+		t := electionExpiration.UTC()
+		electionExpiration = &t
+	}
+
+	// all the commands are part of the same correlation
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeCircleSetCoreRoleMember, correlationID, causationID, callingMember.ID, &commands.CircleSetCoreRoleMember{RoleType: election.RoleType, RoleID: role.ID, MemberID: memberID, ElectionExpiration: electionExpiration})
+
+	rtr := aggregate.NewRolesTreeRepository(s.dataDir, s.es, s.uidGenerator)
+	rt, err := rtr.Load(aggregate.RolesTreeAggregateID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	if _, _, err := s.execCommand(ctx, command, rt); err != nil {
+		if _, ok := err.(*aggregate.HandleCommandError); ok {
+			res.HasErrors = true
+			res.GenericError = err
+			return res, util.NilID, ErrValidation
+		}
+		return nil, util.NilID, err
+	}
+
+	command = commands.NewCommand(commands.CommandTypeCompleteElection, correlationID, command.ID, callingMember.ID, &commands.CompleteElection{
+		ElectedMemberID:    memberID,
+		ElectionExpiration: electionExpiration,
+	})
+
+	er := aggregate.NewElectionRepository(s.es, s.uidGenerator)
+	e, err := er.Load(electionID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, e)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// electionRole returns the election and its circle
+func (s *CommandService) electionRole(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, electionID util.ID, hasErrors *bool, genericError *error) (*models.Election, *models.Role, error) {
+	election, err := readDBService.Election(ctx, curTlSeq, electionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if election == nil {
+		*hasErrors = true
+		*genericError = errors.Errorf("election with id %s doesn't exist", electionID)
+		return nil, nil, nil
+	}
+	electionRoleGroups, err := readDBService.ElectionRole(ctx, curTlSeq, []util.ID{electionID})
+	if err != nil {
+		return nil, nil, err
+	}
+	role := electionRoleGroups[electionID]
+	if role == nil {
+		*hasErrors = true
+		*genericError = errors.Errorf("election circle doesn't exist anymore")
+		return nil, nil, nil
+	}
+	return election, role, nil
+}
+
+// validateRoleTemplate validates the role template fields, returning the
+// first error found
+func validateRoleTemplate(name, purpose string, domains, accountabilities []string) error {
+	if name == "" {
+		return errors.Errorf("empty role template name")
+	}
+	if len([]rune(name)) > MaxRoleNameLength {
+		return errors.Errorf("name too long")
+	}
+	if len([]rune(purpose)) > MaxRolePurposeLength {
+		return errors.Errorf("purpose too long")
+	}
+	for _, domain := range domains {
+		if domain == "" {
+			return errors.Errorf("empty domain")
+		}
+		if len([]rune(domain)) > MaxRoleDomainLength {
+			return errors.Errorf("domain too long")
+		}
+	}
+	for _, accountability := range accountabilities {
+		if accountability == "" {
+			return errors.Errorf("empty accountability")
+		}
+		if len([]rune(accountability)) > MaxRoleAccountabilityLength {
+			return errors.Errorf("accountability too long")
+		}
+	}
+	return nil
+}
+
+// CreateRoleTemplate saves the purpose, domains and accountabilities of an
+// existing role as a named template. Only admins can manage the templates
+// library.
+func (s *CommandService) CreateRoleTemplate(ctx context.Context, c *change.CreateRoleTemplateChange) (*change.CreateRoleTemplateResult, util.ID, error) {
+	res := &change.CreateRoleTemplateResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if !callingMember.IsAdmin {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+
+	role, err := readDBService.Role(ctx, curTlSeq, c.RoleID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if role == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("role with id %s doesn't exist", c.RoleID)
+		return res, util.NilID, ErrValidation
+	}
+	if role.RoleType.IsCoreRoleType() {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("cannot create a template from a core role")
+		return res, util.NilID, ErrValidation
+	}
+
+	domainsGroups, err := readDBService.RoleDomains(ctx, curTlSeq, []util.ID{role.ID})
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	accountabilitiesGroups, err := readDBService.RoleAccountabilities(ctx, curTlSeq, []util.ID{role.ID})
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	domains := []string{}
+	for _, domain := range domainsGroups[role.ID] {
+		domains = append(domains, domain.Description)
+	}
+	accountabilities := []string{}
+	for _, accountability := range accountabilitiesGroups[role.ID] {
+		accountabilities = append(accountabilities, accountability.Description)
+	}
+
+	if err := validateRoleTemplate(c.Name, role.Purpose, domains, accountabilities); err != nil {
+		res.HasErrors = true
+		res.GenericError = err
+		return res, util.NilID, ErrValidation
+	}
+
+	roleTemplateID := s.uidGenerator.UUID(c.Name)
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeCreateRoleTemplate, correlationID, causationID, callingMember.ID, &commands.CreateRoleTemplate{
+		Name:             c.Name,
+		Purpose:          role.Purpose,
+		Domains:          domains,
+		Accountabilities: accountabilities,
+		SourceRoleID:     &role.ID,
+	})
+
+	rtr := aggregate.NewRoleTemplateRepository(s.es, s.uidGenerator)
+	rt, err := rtr.Load(roleTemplateID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, rt)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	res.RoleTemplateID = &roleTemplateID
+
+	return res, groupID, nil
+}
+
+func (s *CommandService) UpdateRoleTemplate(ctx context.Context, c *change.UpdateRoleTemplateChange) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	if err := validateRoleTemplate(c.Name, c.Purpose, c.Domains, c.Accountabilities); err != nil {
+		res.HasErrors = true
+		res.GenericError = err
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if !callingMember.IsAdmin {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+
+	if _, err := s.roleTemplate(ctx, readDBService, curTlSeq, c.ID, &res.HasErrors, &res.GenericError); err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeUpdateRoleTemplate, correlationID, causationID, callingMember.ID, &commands.UpdateRoleTemplate{
+		Name:             c.Name,
+		Purpose:          c.Purpose,
+		Domains:          c.Domains,
+		Accountabilities: c.Accountabilities,
+	})
+
+	rtr := aggregate.NewRoleTemplateRepository(s.es, s.uidGenerator)
+	rt, err := rtr.Load(c.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, rt)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// DeleteRoleTemplate removes a template from the library. The roles
+// instantiated from it aren't changed.
+func (s *CommandService) DeleteRoleTemplate(ctx context.Context, roleTemplateID util.ID) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if !callingMember.IsAdmin {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+
+	if _, err := s.roleTemplate(ctx, readDBService, curTlSeq, roleTemplateID, &res.HasErrors, &res.GenericError); err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeDeleteRoleTemplate, correlationID, causationID, callingMember.ID, &commands.DeleteRoleTemplate{})
+
+	rtr := aggregate.NewRoleTemplateRepository(s.es, s.uidGenerator)
+	rt, err := rtr.Load(roleTemplateID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, rt)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// InstantiateRoleTemplate creates a new role in a circle from a role
+// template. The calling member must be able to manage the circle child roles.
+func (s *CommandService) InstantiateRoleTemplate(ctx context.Context, c *change.InstantiateRoleTemplateChange) (*change.CreateRoleResult, util.ID, error) {
+	res := &change.CreateRoleResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	roleTemplate, err := s.roleTemplate(ctx, readDBService, curTlSeq, c.RoleTemplateID, &res.HasErrors, &res.GenericError)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	createRoleChange := &change.CreateRoleChange{
+		Name:     roleTemplate.Name,
+		RoleType: models.RoleTypeNormal,
+		Purpose:  roleTemplate.Purpose,
+	}
+	if c.Name != nil {
+		createRoleChange.Name = *c.Name
+	}
+	for _, domain := range roleTemplate.Domains {
+		createRoleChange.CreateDomainChanges = append(createRoleChange.CreateDomainChanges, change.CreateDomainChange{Description: domain})
+	}
+	for _, accountability := range roleTemplate.Accountabilities {
+		createRoleChange.CreateAccountabilityChanges = append(createRoleChange.CreateAccountabilityChanges, change.CreateAccountabilityChange{Description: accountability})
+	}
+
+	res.HasErrors = validateCreateRoleChange(createRoleChange, &res.CreateRoleChangeErrors)
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	prole, err := readDBService.Role(ctx, curTlSeq, c.RoleID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if prole == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("parent role with id %s doesn't exist", c.RoleID)
+		return res, util.NilID, ErrValidation
+	}
+	if prole.RoleType != models.RoleTypeCircle {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("parent role is not a circle")
+		return res, util.NilID, ErrValidation
+	}
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	cp, err := readDBService.MemberCirclePermissions(ctx, curTlSeq, prole.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if !cp.ManageChildRoles {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+
+	newRoleID := s.uidGenerator.UUID(createRoleChange.Name)
+
+	// all the commands are part of the same correlation
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeCircleCreateChildRole, correlationID, causationID, callingMember.ID, &commands.CircleCreateChildRole{RoleID: prole.ID, NewRoleID: newRoleID, CreateRoleChange: *createRoleChange})
+
+	rtr := aggregate.NewRolesTreeRepository(s.dataDir, s.es, s.uidGenerator)
+	rt, err := rtr.Load(aggregate.RolesTreeAggregateID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, rt)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	if c.Linked {
+		command = commands.NewCommand(commands.CommandTypeLinkRoleTemplateInstance, correlationID, command.ID, callingMember.ID, &commands.LinkRoleTemplateInstance{RoleID: newRoleID})
+
+		rtr := aggregate.NewRoleTemplateRepository(s.es, s.uidGenerator)
+		rt, err := rtr.Load(roleTemplate.ID)
+		if err != nil {
+			return nil, util.NilID, err
+		}
+
+		groupID, _, err = s.execCommand(ctx, command, rt)
+		if err != nil {
+			return nil, util.NilID, err
+		}
+	}
+
+	res.RoleID = &newRoleID
+
+	return res, groupID, nil
+}
+
+// UnlinkRoleTemplateInstance detaches a role from the template it was
+// instantiated from. Template updates won't be proposed to it anymore.
+func (s *CommandService) UnlinkRoleTemplateInstance(ctx context.Context, roleID util.ID) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	roleTemplateGroups, err := readDBService.RoleRoleTemplate(ctx, curTlSeq, []util.ID{roleID})
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	roleTemplate := roleTemplateGroups[roleID]
+	if roleTemplate == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("role with id %s isn't linked to a role template", roleID)
+		return res, util.NilID, ErrValidation
+	}
+
+	proleGroups, err := readDBService.RoleParent(ctx, curTlSeq, []util.ID{roleID})
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	prole := proleGroups[roleID]
+	if prole == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("role with id %s doesn't exist", roleID)
+		return res, util.NilID, ErrValidation
+	}
+	cp, err := readDBService.MemberCirclePermissions(ctx, curTlSeq, prole.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if !cp.ManageChildRoles {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeUnlinkRoleTemplateInstance, correlationID, causationID, callingMember.ID, &commands.UnlinkRoleTemplateInstance{RoleID: roleID})
+
+	rtr := aggregate.NewRoleTemplateRepository(s.es, s.uidGenerator)
+	rt, err := rtr.Load(roleTemplate.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, rt)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// ProposeRoleTemplateUpdate creates, in the parent circle of every role
+// linked to the template, a draft proposal aligning the role purpose, domains
+// and accountabilities with the template ones. The roles names aren't changed.
+func (s *CommandService) ProposeRoleTemplateUpdate(ctx context.Context, roleTemplateID util.ID) (*change.ProposeRoleTemplateUpdateResult, util.ID, error) {
+	res := &change.ProposeRoleTemplateUpdateResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if !callingMember.IsAdmin {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+
+	roleTemplate, err := s.roleTemplate(ctx, readDBService, curTlSeq, roleTemplateID, &res.HasErrors, &res.GenericError)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	instancesGroups, err := readDBService.RoleTemplateInstances(ctx, curTlSeq, []util.ID{roleTemplate.ID})
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	instances := instancesGroups[roleTemplate.ID]
+	instancesIDs := make([]util.ID, len(instances))
+	for i, instance := range instances {
+		instancesIDs[i] = instance.ID
+	}
+
+	proleGroups, err := readDBService.RoleParent(ctx, curTlSeq, instancesIDs)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	domainsGroups, err := readDBService.RoleDomains(ctx, curTlSeq, instancesIDs)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	accountabilitiesGroups, err := readDBService.RoleAccountabilities(ctx, curTlSeq, instancesIDs)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	createProposalChanges := []*change.CreateProposalChange{}
+	for _, instance := range instances {
+		prole := proleGroups[instance.ID]
+		if prole == nil {
+			continue
+		}
+		updateRoleChange, changed := roleTemplateUpdateRoleChange(roleTemplate, instance, domainsGroups[instance.ID], accountabilitiesGroups[instance.ID])
+		if !changed {
+			continue
+		}
+		title := fmt.Sprintf("Update role %s from template %s", instance.Name, roleTemplate.Name)
+		if len([]rune(title)) > MaxProposalTitleLength {
+			title = string([]rune(title)[:MaxProposalTitleLength])
+		}
+		createProposalChanges = append(createProposalChanges, &change.CreateProposalChange{
+			RoleID:      prole.ID,
+			Title:       title,
+			Description: fmt.Sprintf("Align the role purpose, domains and accountabilities with the ones of the role template %q", roleTemplate.Name),
+			ProposalChanges: change.ProposalChanges{
+				UpdateRoleChanges: []change.UpdateRoleChange{*updateRoleChange},
+			},
+		})
+	}
+
+	if len(createProposalChanges) == 0 {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("all the linked roles are already aligned with the role template")
+		return res, util.NilID, ErrValidation
+	}
+
+	// all the commands are part of the same correlation
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+
+	var groupID util.ID
+	for _, c := range createProposalChanges {
+		proposalID := s.uidGenerator.UUID(c.Title)
+
+		command := commands.NewCommand(commands.CommandTypeCreateProposal, correlationID, causationID, callingMember.ID, commands.NewCommandCreateProposal(callingMember.ID, c))
+
+		pr := aggregate.NewProposalRepository(s.es, s.uidGenerator)
+		p, err := pr.Load(proposalID)
+		if err != nil {
+			return nil, util.NilID, err
+		}
+
+		groupID, _, err = s.execCommand(ctx, command, p)
+		if err != nil {
+			return nil, util.NilID, err
+		}
+		causationID = command.ID
+
+		res.ProposalsIDs = append(res.ProposalsIDs, proposalID)
+	}
+
+	return res, groupID, nil
+}
+
+// roleTemplateUpdateRoleChange returns the changes needed to align the role
+// purpose, domains and accountabilities with the role template ones and if
+// there's any change
+func roleTemplateUpdateRoleChange(roleTemplate *models.RoleTemplate, role *models.Role, domains []*models.Domain, accountabilities []*models.Accountability) (*change.UpdateRoleChange, bool) {
+	c := &change.UpdateRoleChange{ID: role.ID}
+	changed := false
+
+	if role.Purpose != roleTemplate.Purpose {
+		c.PurposeChanged = true
+		c.Purpose = roleTemplate.Purpose
+		changed = true
+	}
+
+	curDomains := map[string]struct{}{}
+	for _, domain := range domains {
+		curDomains[domain.Description] = struct{}{}
+	}
+	templateDomains := map[string]struct{}{}
+	for _, domain := range roleTemplate.Domains {
+		templateDomains[domain] = struct{}{}
+		if _, ok := curDomains[domain]; !ok {
+			c.CreateDomainChanges = append(c.CreateDomainChanges, change.CreateDomainChange{Description: domain})
+			changed = true
+		}
+	}
+	for _, domain := range domains {
+		if _, ok := templateDomains[domain.Description]; !ok {
+			c.DeleteDomainChanges = append(c.DeleteDomainChanges, change.DeleteDomainChange{ID: domain.ID})
+			changed = true
+		}
+	}
+
+	curAccountabilities := map[string]struct{}{}
+	for _, accountability := range accountabilities {
+		curAccountabilities[accountability.Description] = struct{}{}
+	}
+	templateAccountabilities := map[string]struct{}{}
+	for _, accountability := range roleTemplate.Accountabilities {
+		templateAccountabilities[accountability] = struct{}{}
+		if _, ok := curAccountabilities[accountability]; !ok {
+			c.CreateAccountabilityChanges = append(c.CreateAccountabilityChanges, change.CreateAccountabilityChange{Description: accountability})
+			changed = true
+		}
+	}
+	for _, accountability := range accountabilities {
+		if _, ok := templateAccountabilities[accountability.Description]; !ok {
+			c.DeleteAccountabilityChanges = append(c.DeleteAccountabilityChanges, change.DeleteAccountabilityChange{ID: accountability.ID})
+			changed = true
+		}
+	}
+
+	return c, changed
+}
+
+// validateWebhook validates the webhook fields, returning the first error
+// found
+func validateWebhook(rawURL string, eventTypes []string, secret *string) error {
+	if rawURL == "" {
+		return errors.Errorf("empty webhook url")
+	}
+	if len(rawURL) > MaxWebhookURLLength {
+		return errors.Errorf("url too long")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Errorf("invalid url: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.Errorf("url scheme must be http or https")
+	}
+	if u.Host == "" {
+		return errors.Errorf("url without host")
+	}
+	if len(eventTypes) == 0 {
+		return errors.Errorf("empty webhook event types")
+	}
+	for _, eventType := range eventTypes {
+		if !ep.IsWebhookDeliverable(ep.EventType(eventType)) {
+			return errors.Errorf("event type %q cannot be delivered", eventType)
+		}
+	}
+	if secret != nil {
+		if len(*secret) < MinWebhookSecretLength {
+			return errors.Errorf("secret too short")
+		}
+		if len(*secret) > MaxWebhookSecretLength {
+			return errors.Errorf("secret too long")
+		}
+	}
+	return nil
+}
+
+// CreateWebhook registers an endpoint receiving the events of the provided
+// types. Only admins can manage webhooks.
+func (s *CommandService) CreateWebhook(ctx context.Context, c *change.CreateWebhookChange) (*change.CreateWebhookResult, util.ID, error) {
+	res := &change.CreateWebhookResult{}
+
+	if err := validateWebhook(c.URL, c.EventTypes, &c.Secret); err != nil {
+		res.HasErrors = true
+		res.GenericError = err
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if !callingMember.IsAdmin {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+
+	webhookID := s.uidGenerator.UUID(c.URL)
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeCreateWebhook, correlationID, causationID, callingMember.ID, &commands.CreateWebhook{
+		URL:        c.URL,
+		EventTypes: c.EventTypes,
+		Secret:     c.Secret,
+	})
+
+	wr := aggregate.NewWebhookRepository(s.es, s.uidGenerator)
+	w, err := wr.Load(webhookID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, w)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	res.WebhookID = &webhookID
+
+	return res, groupID, nil
+}
+
+func (s *CommandService) UpdateWebhook(ctx context.Context, c *change.UpdateWebhookChange) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	if err := validateWebhook(c.URL, c.EventTypes, c.Secret); err != nil {
+		res.HasErrors = true
+		res.GenericError = err
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if !callingMember.IsAdmin {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+
+	webhook, err := s.webhook(ctx, readDBService, curTlSeq, c.ID, &res.HasErrors, &res.GenericError)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	secret := webhook.Secret
+	if c.Secret != nil {
+		secret = *c.Secret
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeUpdateWebhook, correlationID, causationID, callingMember.ID, &commands.UpdateWebhook{
+		URL:        c.URL,
+		EventTypes: c.EventTypes,
+		Secret:     secret,
+	})
+
+	wr := aggregate.NewWebhookRepository(s.es, s.uidGenerator)
+	w, err := wr.Load(c.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, w)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// DeleteWebhook removes a webhook. Its pending deliveries are discarded.
+func (s *CommandService) DeleteWebhook(ctx context.Context, webhookID util.ID) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if !callingMember.IsAdmin {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+
+	if _, err := s.webhook(ctx, readDBService, curTlSeq, webhookID, &res.HasErrors, &res.GenericError); err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeDeleteWebhook, correlationID, causationID, callingMember.ID, &commands.DeleteWebhook{})
+
+	wr := aggregate.NewWebhookRepository(s.es, s.uidGenerator)
+	w, err := wr.Load(webhookID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, w)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// roleTemplate returns the role template populating hasErrors and
+// genericError if it doesn't exist
+func (s *CommandService) roleTemplate(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, roleTemplateID util.ID, hasErrors *bool, genericError *error) (*models.RoleTemplate, error) {
+	roleTemplate, err := readDBService.RoleTemplate(ctx, curTlSeq, roleTemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if roleTemplate == nil {
+		*hasErrors = true
+		*genericError = errors.Errorf("role template with id %s doesn't exist", roleTemplateID)
+	}
+	return roleTemplate, nil
+}
+
+// webhook returns the webhook populating hasErrors and genericError if it
+// doesn't exist
+func (s *CommandService) webhook(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, webhookID util.ID, hasErrors *bool, genericError *error) (*models.Webhook, error) {
+	webhook, err := readDBService.Webhook(ctx, curTlSeq, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil {
+		*hasErrors = true
+		*genericError = errors.Errorf("webhook with id %s doesn't exist", webhookID)
+	}
+	return webhook, nil
+}
+
+// circleCoreRoleMember returns the member filling the circle core role of the
+// provided type or nil if the core role isn't assigned
+func (s *CommandService) circleCoreRoleMember(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, roleID util.ID, roleType models.RoleType) (*util.ID, error) {
+	coreRoleGroups, err := readDBService.CircleCoreRole(ctx, curTlSeq, roleType, []util.ID{roleID})
+	if err != nil {
+		return nil, err
+	}
+	coreRole, ok := coreRoleGroups[roleID]
+	if !ok {
+		return nil, nil
+	}
+	roleMemberEdgesGroups, err := readDBService.RoleMemberEdges(ctx, curTlSeq, []util.ID{coreRole.ID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	roleMemberEdges := roleMemberEdgesGroups[coreRole.ID]
+	if len(roleMemberEdges) == 0 {
+		return nil, nil
+	}
+	return &roleMemberEdges[0].Member.ID, nil
+}
+
+// checkMeetingExists populates hasErrors and genericError if the meeting
+// doesn't exist
+func (s *CommandService) checkMeetingExists(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, meetingID util.ID, hasErrors *bool, genericError *error) error {
+	meeting, err := readDBService.Meeting(ctx, curTlSeq, meetingID)
+	if err != nil {
+		return err
+	}
+	if meeting == nil {
+		*hasErrors = true
+		*genericError = errors.Errorf("meeting with id %s doesn't exist", meetingID)
+	}
+	return nil
+}
+
+// validateProposalChanges validates the proposal role changes populating the
+// create and update role changes errors. It returns true if there're errors
+func validateProposalChanges(c *change.ProposalChanges, createRoleChangesErrors *[]change.CreateRoleChangeErrors, updateRoleChangesErrors *[]change.UpdateRoleChangeErrors) bool {
+	hasErrors := false
+
+	*createRoleChangesErrors = make([]change.CreateRoleChangeErrors, len(c.CreateRoleChanges))
+	for i := range c.CreateRoleChanges {
+		if validateCreateRoleChange(&c.CreateRoleChanges[i], &(*createRoleChangesErrors)[i]) {
+			hasErrors = true
+		}
+	}
+	*updateRoleChangesErrors = make([]change.UpdateRoleChangeErrors, len(c.UpdateRoleChanges))
+	for i := range c.UpdateRoleChanges {
+		if validateUpdateRoleChange(&c.UpdateRoleChanges[i], &(*updateRoleChangesErrors)[i]) {
+			hasErrors = true
+		}
+	}
+
+	return hasErrors
+}
+
+func (s *CommandService) CreateProposal(ctx context.Context, c *change.CreateProposalChange) (*change.CreateProposalResult, util.ID, error) {
+	res := &change.CreateProposalResult{}
+	if c.Title == "" {
+		res.HasErrors = true
+		res.CreateProposalChangeErrors.Title = errors.Errorf("empty proposal title")
+	}
+	if len([]rune(c.Title)) > MaxProposalTitleLength {
+		res.HasErrors = true
+		res.CreateProposalChangeErrors.Title = errors.Errorf("title too long")
+	}
+	if len([]rune(c.Description)) > MaxProposalDescriptionLength {
+		res.HasErrors = true
+		res.CreateProposalChangeErrors.Description = errors.Errorf("description too long")
+	}
+	if validateProposalChanges(&c.ProposalChanges, &res.CreateProposalChangeErrors.CreateRoleChangesErrors, &res.CreateProposalChangeErrors.UpdateRoleChangesErrors) {
+		res.HasErrors = true
+	}
+
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	role, err := readDBService.Role(ctx, curTlSeq, c.RoleID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if role == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("role with id %s doesn't exist", c.RoleID)
+		return res, util.NilID, ErrValidation
+	}
+	if role.RoleType != models.RoleTypeCircle {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("role with id %s is not a circle", c.RoleID)
+		return res, util.NilID, ErrValidation
+	}
+
+	// Check that the user is a member of the role
+	isRoleMember, err := s.isCircleMember(ctx, readDBService, curTlSeq, role.ID, callingMember.ID, false)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if !callingMember.IsAdmin && !isRoleMember {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member is not member of role")
+		return res, util.NilID, ErrValidation
+	}
+
+	proposalID := s.uidGenerator.UUID(c.Title)
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeCreateProposal, correlationID, causationID, callingMember.ID, commands.NewCommandCreateProposal(callingMember.ID, c))
+
+	pr := aggregate.NewProposalRepository(s.es, s.uidGenerator)
+	p, err := pr.Load(proposalID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, p)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	res.ProposalID = &proposalID
+
+	return res, groupID, nil
+}
+
+func (s *CommandService) UpdateProposal(ctx context.Context, c *change.UpdateProposalChange) (*change.UpdateProposalResult, util.ID, error) {
+	res := &change.UpdateProposalResult{}
+	if c.Title == "" {
+		res.HasErrors = true
+		res.UpdateProposalChangeErrors.Title = errors.Errorf("empty proposal title")
+	}
+	if len([]rune(c.Title)) > MaxProposalTitleLength {
+		res.HasErrors = true
+		res.UpdateProposalChangeErrors.Title = errors.Errorf("title too long")
+	}
+	if len([]rune(c.Description)) > MaxProposalDescriptionLength {
+		res.HasErrors = true
+		res.UpdateProposalChangeErrors.Description = errors.Errorf("description too long")
+	}
+	if validateProposalChanges(&c.ProposalChanges, &res.UpdateProposalChangeErrors.CreateRoleChangesErrors, &res.UpdateProposalChangeErrors.UpdateRoleChangesErrors) {
+		res.HasErrors = true
+	}
+
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	proposal, err := s.checkProposalMember(ctx, readDBService, curTlSeq, c.ID, callingMember, &res.HasErrors, &res.GenericError)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	if !proposal.Status.IsEditable() {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("proposal in status %q cannot be updated", proposal.Status)
+		return res, util.NilID, ErrValidation
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeUpdateProposal, correlationID, causationID, callingMember.ID, commands.NewCommandUpdateProposal(c))
+
+	pr := aggregate.NewProposalRepository(s.es, s.uidGenerator)
+	p, err := pr.Load(c.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, p)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// SubmitProposal submits a draft proposal to the circle
+func (s *CommandService) SubmitProposal(ctx context.Context, proposalID util.ID) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	proposal, err := s.checkProposalMember(ctx, readDBService, curTlSeq, proposalID, callingMember, &res.HasErrors, &res.GenericError)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	if proposal.Status != models.ProposalStatusDraft {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("proposal in status %q cannot be submitted", proposal.Status)
+		return res, util.NilID, ErrValidation
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeSubmitProposal, correlationID, causationID, callingMember.ID, &commands.SubmitProposal{})
+
+	pr := aggregate.NewProposalRepository(s.es, s.uidGenerator)
+	p, err := pr.Load(proposalID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, p)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// ObjectProposal raises an objection to a submitted proposal. Only the
+// circle core members can object. The proposal cannot be accepted until all
+// its objections are resolved or withdrawn.
+func (s *CommandService) ObjectProposal(ctx context.Context, c *change.ObjectProposalChange) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	if c.Reason == "" {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("empty objection reason")
+		return res, util.NilID, ErrValidation
+	}
+	if len([]rune(c.Reason)) > MaxProposalObjectionReasonLength {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("objection reason too long")
+		return res, util.NilID, ErrValidation
+	}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	proposal, err := readDBService.Proposal(ctx, curTlSeq, c.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if proposal == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("proposal with id %s doesn't exist", c.ID)
+		return res, util.NilID, ErrValidation
+	}
+	if proposal.Status != models.ProposalStatusSubmitted && proposal.Status != models.ProposalStatusObjected {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("proposal in status %q cannot be objected", proposal.Status)
+		return res, util.NilID, ErrValidation
+	}
+
+	if err := s.checkProposalCoreMember(ctx, readDBService, curTlSeq, proposal, callingMember, &res.HasErrors, &res.GenericError); err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	objectionID := s.uidGenerator.UUID(c.Reason)
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeObjectProposal, correlationID, causationID, callingMember.ID, commands.NewCommandObjectProposal(objectionID, callingMember.ID, c))
+
+	pr := aggregate.NewProposalRepository(s.es, s.uidGenerator)
+	p, err := pr.Load(c.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	groupID, _, err := s.execCommand(ctx, command, p)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+// AcceptProposal applies all the proposal changes to the proposal circle and
+// marks the proposal as accepted in a single event store write. If the changes
+// cannot be applied or the proposal has been concurrently changed the
+// proposal is left in its current status.
+func (s *CommandService) AcceptProposal(ctx context.Context, proposalID util.ID) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	proposal, err := readDBService.Proposal(ctx, curTlSeq, proposalID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if proposal == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("proposal with id %s doesn't exist", proposalID)
+		return res, util.NilID, ErrValidation
+	}
+	if proposal.Status == models.ProposalStatusObjected {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("proposal has unresolved objections")
+		return res, util.NilID, ErrValidation
+	}
+	if proposal.Status != models.ProposalStatusSubmitted {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("proposal in status %q cannot be accepted", proposal.Status)
+		return res, util.NilID, ErrValidation
+	}
+
+	proposalRoleGroups, err := readDBService.ProposalRole(ctx, curTlSeq, []util.ID{proposal.ID})
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	proposalRole := proposalRoleGroups[proposal.ID]
+	if proposalRole == nil {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("proposal circle doesn't exist anymore")
+		return res, util.NilID, ErrValidation
+	}
+
+	cp, err := readDBService.MemberCirclePermissions(ctx, curTlSeq, proposalRole.ID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if !cp.ManageChildRoles {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member not authorized")
+		return res, util.NilID, ErrValidation
+	}
+
+	pr := aggregate.NewProposalRepository(s.es, s.uidGenerator)
+	p, err := pr.Load(proposalID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	// apply the changes of the loaded proposal version since the readdb could
+	// be behind. The proposal accept will fail if the proposal has been
+	// changed after being loaded.
+	proposalChanges := p.ProposalChanges()
+
+	newRoleIDs := make([]util.ID, len(proposalChanges.CreateRoleChanges))
+	for i, createRoleChange := range proposalChanges.CreateRoleChanges {
+		newRoleIDs[i] = s.uidGenerator.UUID(createRoleChange.Name)
+	}
+
+	// all the commands are part of the same correlation
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	applyCommand := commands.NewCommand(commands.CommandTypeCircleApplyProposal, correlationID, causationID, callingMember.ID, &commands.CircleApplyProposal{RoleID: p.RoleID(), ProposalID: proposal.ID, NewRoleIDs: newRoleIDs, ProposalChanges: proposalChanges})
+	acceptCommand := commands.NewCommand(commands.CommandTypeAcceptProposal, correlationID, applyCommand.ID, callingMember.ID, &commands.AcceptProposal{})
+
+	rtr := aggregate.NewRolesTreeRepository(s.dataDir, s.es, s.uidGenerator)
+	rt, err := rtr.Load(aggregate.RolesTreeAggregateID)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	// the proposal accept and the changes are written together so the
+	// changes are applied only if the proposal is still submitted
+	groupID, _, err := s.execCommands(ctx, []*aggregate.AggregateCommand{
+		{Command: applyCommand, Aggregate: rt},
+		{Command: acceptCommand, Aggregate: p},
+	})
+	if err != nil {
+		// the proposal has been concurrently accepted or withdrawn or the
+		// changes cannot be applied to the current roles tree
+		if _, ok := err.(*aggregate.HandleCommandError); ok {
+			res.HasErrors = true
+			res.GenericError = err
+			return res, util.NilID, ErrValidation
+		}
+		return nil, util.NilID, err
+	}
+
+	return res, groupID, nil
+}
+
+func (s *CommandService) WithdrawProposal(ctx context.Context, proposalID util.ID) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	defer tx.Rollback()
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	curTl := readDBService.CurTimeLine(ctx)
+	curTlSeq := curTl.Number()
+
+	callingMember, err := readDBService.CallingMember(ctx, curTlSeq)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+
+	proposal, err := s.checkProposalMember(ctx, readDBService, curTlSeq, proposalID, callingMember, &res.HasErrors, &res.GenericError)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	if proposal.Status.IsFinal() {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("proposal in status %q cannot be withdrawn", proposal.Status)
+		return res, util.NilID, ErrValidation
+	}
+
+	correlationID := s.uidGenerator.UUID("")
+	causationID := s.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeWithdrawProposal, correlationID, causationID, callingMember.ID, &commands.WithdrawProposal{})
+
+	pr := aggregate.NewProposalRepository(s.es, s.uidGenerator)
+	p, err := pr.Load(proposalID)
 	if err != nil {
 		return nil, util.NilID, err
 	}
 
-	log.Debugf("waiting for request completed event for memberChangeID: %s", memberChangeID)
-	groupID, err = s.waitMemberChangeRequest(ctx, memberChangeID)
+	groupID, _, err := s.execCommand(ctx, command, p)
 	if err != nil {
 		return nil, util.NilID, err
 	}
-	log.Debugf("received request completed event for memberChangeID: %s, groupID: %s", memberChangeID, groupID)
 
 	return res, groupID, nil
 }
 
-func (s *CommandService) CreateTension(ctx context.Context, c *change.CreateTensionChange) (*change.CreateTensionResult, util.ID, error) {
-	res := &change.CreateTensionResult{}
-	if c.Title == "" {
-		res.HasErrors = true
-		res.CreateTensionChangeErrors.Title = errors.Errorf("empty tension title")
-	}
-	if len([]rune(c.Title)) > MaxTensionTitleLength {
+// ResolveProposalObjection marks an open objection as resolved. An objection
+// can be resolved by its author or by the proposer.
+func (s *CommandService) ResolveProposalObjection(ctx context.Context, c *change.ResolveProposalObjectionChange) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
+
+	if c.Resolution == "" {
 		res.HasErrors = true
-		res.CreateTensionChangeErrors.Title = errors.Errorf("title too long")
+		res.GenericError = errors.Errorf("empty objection resolution")
+		return res, util.NilID, ErrValidation
 	}
-	if len([]rune(c.Description)) > MaxTensionDescriptionLength {
+	if len([]rune(c.Resolution)) > MaxProposalObjectionReasonLength {
 		res.HasErrors = true
-		res.CreateTensionChangeErrors.Description = errors.Errorf("description too long")
-	}
-
-	if res.HasErrors {
+		res.GenericError = errors.Errorf("objection resolution too long")
 		return res, util.NilID, ErrValidation
 	}
 
@@ -1254,82 +5269,46 @@ func (s *CommandService) CreateTension(ctx context.Context, c *change.CreateTens
 		return nil, util.NilID, err
 	}
 
-	if c.RoleID != nil {
-		role, err := readDBService.Role(ctx, curTlSeq, *c.RoleID)
-		if err != nil {
-			return nil, util.NilID, err
-		}
-		if role == nil {
-			res.HasErrors = true
-			res.GenericError = errors.Errorf("role with id %s doesn't exist", c.RoleID)
-			return res, util.NilID, ErrValidation
-		}
-		if role.RoleType != models.RoleTypeCircle {
-			res.HasErrors = true
-			res.GenericError = errors.Errorf("role with id %s is not a circle", c.RoleID)
-			return res, util.NilID, ErrValidation
-		}
-		// Check that the user is a member of the role
-		circleMemberEdgesGroups, err := readDBService.CircleMemberEdges(ctx, curTlSeq, []util.ID{role.ID})
-		if err != nil {
+	_, objectionMember, err := s.checkProposalObjection(ctx, readDBService, curTlSeq, c.ID, c.ObjectionID, &res.HasErrors, &res.GenericError)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	if callingMember.ID != objectionMember.ID {
+		// not the objection author, check that it's the proposer
+		if _, err := s.checkProposalMember(ctx, readDBService, curTlSeq, c.ID, callingMember, &res.HasErrors, &res.GenericError); err != nil {
 			return nil, util.NilID, err
 		}
-		isRoleMember := false
-		circleMemberEdges := circleMemberEdgesGroups[role.ID]
-		for _, circleMemberEdge := range circleMemberEdges {
-			if circleMemberEdge.Member.ID == callingMember.ID {
-				isRoleMember = true
-				break
-			}
-		}
-		if !isRoleMember {
-			res.HasErrors = true
-			res.GenericError = errors.Errorf("member is not member of role")
+		if res.HasErrors {
 			return res, util.NilID, ErrValidation
 		}
-
 	}
 
-	tensionID := s.uidGenerator.UUID(c.Title)
-
 	correlationID := s.uidGenerator.UUID("")
 	causationID := s.uidGenerator.UUID("")
-	command := commands.NewCommand(commands.CommandTypeCreateTension, correlationID, causationID, callingMember.ID, commands.NewCommandCreateTension(callingMember.ID, c))
+	command := commands.NewCommand(commands.CommandTypeResolveProposalObjection, correlationID, causationID, callingMember.ID, commands.NewCommandResolveProposalObjection(callingMember.ID, c))
 
-	tr := aggregate.NewTensionRepository(s.es, s.uidGenerator)
-	t, err := tr.Load(tensionID)
+	pr := aggregate.NewProposalRepository(s.es, s.uidGenerator)
+	p, err := pr.Load(c.ID)
 	if err != nil {
 		return nil, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, t, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, p)
 	if err != nil {
 		return nil, util.NilID, err
 	}
 
-	res.TensionID = &tensionID
-
 	return res, groupID, nil
 }
 
-func (s *CommandService) UpdateTension(ctx context.Context, c *change.UpdateTensionChange) (*change.UpdateTensionResult, util.ID, error) {
-	res := &change.UpdateTensionResult{}
-	if c.Title == "" {
-		res.HasErrors = true
-		res.UpdateTensionChangeErrors.Title = errors.Errorf("empty tension title")
-	}
-	if len([]rune(c.Title)) > MaxTensionTitleLength {
-		res.HasErrors = true
-		res.UpdateTensionChangeErrors.Title = errors.Errorf("title too long")
-	}
-	if len([]rune(c.Description)) > MaxTensionDescriptionLength {
-		res.HasErrors = true
-		res.UpdateTensionChangeErrors.Description = errors.Errorf("description too long")
-	}
-
-	if res.HasErrors {
-		return res, util.NilID, ErrValidation
-	}
+// WithdrawProposalObjection withdraws an open objection. Only the objection
+// author can withdraw it.
+func (s *CommandService) WithdrawProposalObjection(ctx context.Context, proposalID, objectionID util.ID) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
 
 	tx, err := s.db.NewTx()
 	if err != nil {
@@ -1349,82 +5328,31 @@ func (s *CommandService) UpdateTension(ctx context.Context, c *change.UpdateTens
 		return nil, util.NilID, err
 	}
 
-	tension, err := readDBService.Tension(ctx, curTlSeq, c.ID)
+	_, objectionMember, err := s.checkProposalObjection(ctx, readDBService, curTlSeq, proposalID, objectionID, &res.HasErrors, &res.GenericError)
 	if err != nil {
 		return nil, util.NilID, err
 	}
-
-	if tension == nil {
-		res.HasErrors = true
-		res.GenericError = errors.Errorf("tension with id %s doesn't exist", c.ID)
-		return res, util.NilID, ErrValidation
-	}
-
-	if tension.Closed {
-		res.HasErrors = true
-		res.GenericError = errors.Errorf("tension already closed")
+	if res.HasErrors {
 		return res, util.NilID, ErrValidation
 	}
 
-	tensionMemberGroups, err := readDBService.TensionMember(ctx, curTlSeq, []util.ID{tension.ID})
-	if err != nil {
-		return nil, util.NilID, err
-	}
-	tensionMember := tensionMemberGroups[tension.ID]
-
-	// Assume that a tension always have a member, or something is wrong
-	if !callingMember.IsAdmin && callingMember.ID != tensionMember.ID {
+	if callingMember.ID != objectionMember.ID {
 		res.HasErrors = true
 		res.GenericError = errors.Errorf("member not authorized")
 		return res, util.NilID, ErrValidation
 	}
 
-	if c.RoleID != nil {
-		role, err := readDBService.Role(ctx, curTlSeq, *c.RoleID)
-		if err != nil {
-			return nil, util.NilID, err
-		}
-		if role == nil {
-			res.HasErrors = true
-			res.GenericError = errors.Errorf("role with id %s doesn't exist", c.RoleID)
-			return res, util.NilID, ErrValidation
-		}
-		if role.RoleType != models.RoleTypeCircle {
-			res.HasErrors = true
-			res.GenericError = errors.Errorf("role with id %s is not a circle", c.RoleID)
-			return res, util.NilID, ErrValidation
-		}
-		// Check that the user is a member of the role
-		circleMemberEdgesGroups, err := readDBService.CircleMemberEdges(ctx, curTlSeq, []util.ID{role.ID})
-		if err != nil {
-			return nil, util.NilID, err
-		}
-		isRoleMember := false
-		circleMemberEdges := circleMemberEdgesGroups[role.ID]
-		for _, circleMemberEdge := range circleMemberEdges {
-			if circleMemberEdge.Member.ID == callingMember.ID {
-				isRoleMember = true
-				break
-			}
-		}
-		if !isRoleMember {
-			res.HasErrors = true
-			res.GenericError = errors.Errorf("member is not member of role")
-			return res, util.NilID, ErrValidation
-		}
-	}
-
 	correlationID := s.uidGenerator.UUID("")
 	causationID := s.uidGenerator.UUID("")
-	command := commands.NewCommand(commands.CommandTypeUpdateTension, correlationID, causationID, callingMember.ID, commands.NewCommandUpdateTension(c))
+	command := commands.NewCommand(commands.CommandTypeWithdrawProposalObjection, correlationID, causationID, callingMember.ID, &commands.WithdrawProposalObjection{ObjectionID: objectionID, MemberID: callingMember.ID})
 
-	tr := aggregate.NewTensionRepository(s.es, s.uidGenerator)
-	t, err := tr.Load(c.ID)
+	pr := aggregate.NewProposalRepository(s.es, s.uidGenerator)
+	p, err := pr.Load(proposalID)
 	if err != nil {
 		return nil, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, t, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, p)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -1432,14 +5360,10 @@ func (s *CommandService) UpdateTension(ctx context.Context, c *change.UpdateTens
 	return res, groupID, nil
 }
 
-func (s *CommandService) CloseTension(ctx context.Context, c *change.CloseTensionChange) (*change.CloseTensionResult, util.ID, error) {
-	res := &change.CloseTensionResult{}
-
-	if len([]rune(c.Reason)) > MaxTensionCloseReasonLength {
-		res.HasErrors = true
-		res.GenericError = errors.Errorf("close reason too long")
-		return res, util.NilID, ErrValidation
-	}
+// ConsentProposal records the calling member consent to a submitted proposal.
+// Only the circle core members can consent.
+func (s *CommandService) ConsentProposal(ctx context.Context, proposalID util.ID) (*change.GenericResult, util.ID, error) {
+	res := &change.GenericResult{}
 
 	tx, err := s.db.NewTx()
 	if err != nil {
@@ -1459,47 +5383,73 @@ func (s *CommandService) CloseTension(ctx context.Context, c *change.CloseTensio
 		return nil, util.NilID, err
 	}
 
-	tension, err := readDBService.Tension(ctx, curTlSeq, c.ID)
+	proposal, err := readDBService.Proposal(ctx, curTlSeq, proposalID)
 	if err != nil {
 		return nil, util.NilID, err
 	}
-
-	if tension == nil {
+	if proposal == nil {
 		res.HasErrors = true
-		res.GenericError = errors.Errorf("tension with id %s doesn't exist", c.ID)
+		res.GenericError = errors.Errorf("proposal with id %s doesn't exist", proposalID)
 		return res, util.NilID, ErrValidation
 	}
-
-	if tension.Closed {
+	if proposal.Status != models.ProposalStatusSubmitted && proposal.Status != models.ProposalStatusObjected {
 		res.HasErrors = true
-		res.GenericError = errors.Errorf("tension already closed")
+		res.GenericError = errors.Errorf("proposal in status %q cannot be consented", proposal.Status)
 		return res, util.NilID, ErrValidation
 	}
 
-	tensionMemberGroups, err := readDBService.TensionMember(ctx, curTlSeq, []util.ID{tension.ID})
+	if err := s.checkProposalCoreMember(ctx, readDBService, curTlSeq, proposal, callingMember, &res.HasErrors, &res.GenericError); err != nil {
+		return nil, util.NilID, err
+	}
+	if res.HasErrors {
+		return res, util.NilID, ErrValidation
+	}
+
+	consentMembersGroups, err := readDBService.ProposalConsentMembers(ctx, curTlSeq, []util.ID{proposal.ID})
 	if err != nil {
 		return nil, util.NilID, err
 	}
-	tensionMember := tensionMemberGroups[tension.ID]
+	for _, member := range consentMembersGroups[proposal.ID] {
+		if member.ID == callingMember.ID {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("member already consented")
+			return res, util.NilID, ErrValidation
+		}
+	}
 
-	// Assume that a tension always have a member, or something is wrong
-	if !callingMember.IsAdmin && callingMember.ID != tensionMember.ID {
-		res.HasErrors = true
-		res.GenericError = errors.Errorf("member not authorized")
-		return res, util.NilID, ErrValidation
+	objectionsGroups, err := readDBService.ProposalObjections(ctx, curTlSeq, []util.ID{proposal.ID})
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	objectionsIDs := []util.ID{}
+	for _, objection := range objectionsGroups[proposal.ID] {
+		if objection.Status == models.ProposalObjectionStatusOpen {
+			objectionsIDs = append(objectionsIDs, objection.ID)
+		}
+	}
+	objectionMemberGroups, err := readDBService.ProposalObjectionMember(ctx, curTlSeq, objectionsIDs)
+	if err != nil {
+		return nil, util.NilID, err
+	}
+	for _, member := range objectionMemberGroups {
+		if member.ID == callingMember.ID {
+			res.HasErrors = true
+			res.GenericError = errors.Errorf("member has open objections")
+			return res, util.NilID, ErrValidation
+		}
 	}
 
 	correlationID := s.uidGenerator.UUID("")
 	causationID := s.uidGenerator.UUID("")
-	command := commands.NewCommand(commands.CommandTypeCloseTension, correlationID, causationID, callingMember.ID, commands.NewCommandCloseTension(c))
+	command := commands.NewCommand(commands.CommandTypeConsentProposal, correlationID, causationID, callingMember.ID, &commands.ConsentProposal{MemberID: callingMember.ID})
 
-	tr := aggregate.NewTensionRepository(s.es, s.uidGenerator)
-	t, err := tr.Load(c.ID)
+	pr := aggregate.NewProposalRepository(s.es, s.uidGenerator)
+	p, err := pr.Load(proposalID)
 	if err != nil {
 		return nil, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, t, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, p)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -1507,6 +5457,114 @@ func (s *CommandService) CloseTension(ctx context.Context, c *change.CloseTensio
 	return res, groupID, nil
 }
 
+// checkProposalCoreMember checks that the calling member is a core member of
+// the proposal circle. On failure hasErrors and genericError are populated.
+func (s *CommandService) checkProposalCoreMember(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, proposal *models.Proposal, callingMember *models.Member, hasErrors *bool, genericError *error) error {
+	proposalRoleGroups, err := readDBService.ProposalRole(ctx, curTlSeq, []util.ID{proposal.ID})
+	if err != nil {
+		return err
+	}
+	proposalRole := proposalRoleGroups[proposal.ID]
+	if proposalRole == nil {
+		*hasErrors = true
+		*genericError = errors.Errorf("proposal circle doesn't exist anymore")
+		return nil
+	}
+
+	isCoreMember, err := s.isCircleMember(ctx, readDBService, curTlSeq, proposalRole.ID, callingMember.ID, true)
+	if err != nil {
+		return err
+	}
+	if !isCoreMember {
+		*hasErrors = true
+		*genericError = errors.Errorf("member not authorized")
+		return nil
+	}
+
+	return nil
+}
+
+// checkProposalObjection returns the open proposal objection and its author.
+// On failure hasErrors and genericError are populated.
+func (s *CommandService) checkProposalObjection(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, proposalID, objectionID util.ID, hasErrors *bool, genericError *error) (*models.ProposalObjection, *models.Member, error) {
+	objectionsGroups, err := readDBService.ProposalObjections(ctx, curTlSeq, []util.ID{proposalID})
+	if err != nil {
+		return nil, nil, err
+	}
+	var objection *models.ProposalObjection
+	for _, o := range objectionsGroups[proposalID] {
+		if o.ID == objectionID {
+			objection = o
+		}
+	}
+	if objection == nil {
+		*hasErrors = true
+		*genericError = errors.Errorf("proposal objection with id %s doesn't exist", objectionID)
+		return nil, nil, nil
+	}
+	if objection.Status != models.ProposalObjectionStatusOpen {
+		*hasErrors = true
+		*genericError = errors.Errorf("proposal objection in status %q cannot be changed", objection.Status)
+		return nil, nil, nil
+	}
+
+	objectionMemberGroups, err := readDBService.ProposalObjectionMember(ctx, curTlSeq, []util.ID{objectionID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return objection, objectionMemberGroups[objectionID], nil
+}
+
+// checkProposalMember returns the proposal and checks that the calling member
+// is its proposer (or an admin). On failure hasErrors and genericError are
+// populated.
+func (s *CommandService) checkProposalMember(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, proposalID util.ID, callingMember *models.Member, hasErrors *bool, genericError *error) (*models.Proposal, error) {
+	proposal, err := readDBService.Proposal(ctx, curTlSeq, proposalID)
+	if err != nil {
+		return nil, err
+	}
+	if proposal == nil {
+		*hasErrors = true
+		*genericError = errors.Errorf("proposal with id %s doesn't exist", proposalID)
+		return nil, nil
+	}
+
+	proposalMemberGroups, err := readDBService.ProposalMember(ctx, curTlSeq, []util.ID{proposal.ID})
+	if err != nil {
+		return nil, err
+	}
+	proposalMember := proposalMemberGroups[proposal.ID]
+
+	// Assume that a proposal always have a member, or something is wrong
+	if !callingMember.IsAdmin && callingMember.ID != proposalMember.ID {
+		*hasErrors = true
+		*genericError = errors.Errorf("member not authorized")
+		return nil, nil
+	}
+
+	return proposal, nil
+}
+
+// isCircleMember reports if the member is a circle member. If onlyCore is
+// true only the circle core members are considered.
+func (s *CommandService) isCircleMember(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, roleID, memberID util.ID, onlyCore bool) (bool, error) {
+	circleMemberEdgesGroups, err := readDBService.CircleMemberEdges(ctx, curTlSeq, []util.ID{roleID})
+	if err != nil {
+		return false, err
+	}
+	for _, circleMemberEdge := range circleMemberEdgesGroups[roleID] {
+		if circleMemberEdge.Member.ID != memberID {
+			continue
+		}
+		if onlyCore && !circleMemberEdge.IsCoreMember {
+			continue
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
 // CircleAddDirectMember adds a member as a core role member the specified circle
 func (s *CommandService) CircleAddDirectMember(ctx context.Context, roleID util.ID, memberID util.ID) (*change.GenericResult, util.ID, error) {
 	res := &change.GenericResult{}
@@ -1556,6 +5614,11 @@ func (s *CommandService) CircleAddDirectMember(ctx context.Context, roleID util.
 	if member == nil {
 		return nil, util.NilID, errors.Errorf("member with id %s doesn't exist", memberID)
 	}
+	if member.IsDeactivated {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member with id %s is deactivated", memberID)
+		return res, util.NilID, ErrValidation
+	}
 
 	if res.HasErrors {
 		return res, util.NilID, ErrValidation
@@ -1571,7 +5634,7 @@ func (s *CommandService) CircleAddDirectMember(ctx context.Context, roleID util.
 		return res, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, rt, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, rt)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -1646,7 +5709,7 @@ func (s *CommandService) CircleRemoveDirectMember(ctx context.Context, roleID ut
 		return res, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, rt, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, rt)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -1727,6 +5790,11 @@ func (s *CommandService) CircleSetLeadLinkMember(ctx context.Context, roleID, me
 	if member == nil {
 		return nil, util.NilID, errors.Errorf("member with id %s doesn't exist", memberID)
 	}
+	if member.IsDeactivated {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member with id %s is deactivated", memberID)
+		return res, util.NilID, ErrValidation
+	}
 
 	correlationID := s.uidGenerator.UUID("")
 	causationID := s.uidGenerator.UUID("")
@@ -1738,7 +5806,7 @@ func (s *CommandService) CircleSetLeadLinkMember(ctx context.Context, roleID, me
 		return res, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, rt, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, rt)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -1837,7 +5905,7 @@ func (s *CommandService) CircleUnsetLeadLinkMember(ctx context.Context, roleID u
 		return res, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, rt, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, rt)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -1897,6 +5965,11 @@ func (s *CommandService) CircleSetCoreRoleMember(ctx context.Context, roleType m
 	if member == nil {
 		return nil, util.NilID, errors.Errorf("member with id %s doesn't exist", memberID)
 	}
+	if member.IsDeactivated {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member with id %s is deactivated", memberID)
+		return res, util.NilID, ErrValidation
+	}
 
 	correlationID := s.uidGenerator.UUID("")
 	causationID := s.uidGenerator.UUID("")
@@ -1908,7 +5981,7 @@ func (s *CommandService) CircleSetCoreRoleMember(ctx context.Context, roleType m
 		return res, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, rt, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, rt)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -1987,7 +6060,7 @@ func (s *CommandService) CircleUnsetCoreRoleMember(ctx context.Context, roleType
 		return res, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, rt, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, rt)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -2071,6 +6144,11 @@ func (s *CommandService) RoleAddMember(ctx context.Context, roleID util.ID, memb
 	if member == nil {
 		return nil, util.NilID, errors.Errorf("member with id %s doesn't exist", memberID)
 	}
+	if member.IsDeactivated {
+		res.HasErrors = true
+		res.GenericError = errors.Errorf("member with id %s is deactivated", memberID)
+		return res, util.NilID, ErrValidation
+	}
 
 	correlationID := s.uidGenerator.UUID("")
 	causationID := s.uidGenerator.UUID("")
@@ -2082,7 +6160,7 @@ func (s *CommandService) RoleAddMember(ctx context.Context, roleID util.ID, memb
 		return res, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, rt, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, rt)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -2164,7 +6242,7 @@ func (s *CommandService) RoleRemoveMember(ctx context.Context, roleID util.ID, m
 		return res, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, rt, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, rt)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -2255,7 +6333,7 @@ func (s *CommandService) RoleUpdateMember(ctx context.Context, roleID util.ID, m
 		return res, util.NilID, err
 	}
 
-	groupID, _, err := aggregate.ExecCommand(command, rt, s.es, s.uidGenerator)
+	groupID, _, err := s.execCommand(ctx, command, rt)
 	if err != nil {
 		return nil, util.NilID, err
 	}
@@ -1,6 +1,12 @@
 package command
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/sorintlab/sircles/aggregate"
+	"github.com/sorintlab/sircles/command/commands"
+	"github.com/sorintlab/sircles/models"
+)
 
 var (
 	goodUserNames = []string{
@@ -39,3 +45,27 @@ func TestIsUserNameValidFormat(t *testing.T) {
 		}
 	}
 }
+
+func TestAPITokenScopeAllows(t *testing.T) {
+	tests := []struct {
+		scope         models.APITokenScope
+		aggregateType aggregate.AggregateType
+		commandType   commands.CommandType
+		allowed       bool
+	}{
+		{models.APITokenScopeReadOnly, aggregate.TensionAggregate, commands.CommandTypeCreateTension, false},
+		{models.APITokenScopeTensions, aggregate.TensionAggregate, commands.CommandTypeCreateTension, true},
+		{models.APITokenScopeTensions, aggregate.RolesTreeAggregate, commands.CommandTypeCircleCreateChildRole, false},
+		{models.APITokenScopeGovernance, aggregate.RolesTreeAggregate, commands.CommandTypeCircleCreateChildRole, true},
+		{models.APITokenScopeGovernance, aggregate.MemberAggregate, commands.CommandTypeSetMemberPassword, false},
+		{models.APITokenScopeGovernance, aggregate.APITokenAggregate, commands.CommandTypeCreateAPIToken, false},
+		{models.APITokenScopeGovernance, aggregate.SessionAggregate, commands.CommandTypeCreateSession, false},
+		{models.APITokenScope("unknown"), aggregate.TensionAggregate, commands.CommandTypeCreateTension, false},
+	}
+
+	for _, tt := range tests {
+		if allowed := apiTokenScopeAllows(tt.scope, tt.aggregateType, tt.commandType); allowed != tt.allowed {
+			t.Errorf("scope %q, command %s: expected allowed %t, got %t", tt.scope, tt.commandType, tt.allowed, allowed)
+		}
+	}
+}
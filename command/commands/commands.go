@@ -25,8 +25,16 @@ const (
 	CommandTypeCircleUpdateChildRole CommandType = "CircleUpdateChildRole"
 	CommandTypeCircleDeleteChildRole CommandType = "CircleDeleteChildRole"
 
+	// CircleApplyProposal applies all the accepted proposal changes in a single
+	// command
+	CommandTypeCircleApplyProposal CommandType = "CircleApplyProposal"
+
 	CommandTypeSetRoleAdditionalContent CommandType = "SetRoleAdditionalContent"
 
+	// MoveRole moves a role (and all its sub roles) under another circle of
+	// the roles tree
+	CommandTypeMoveRole CommandType = "MoveRole"
+
 	CommandTypeCompleteRequest CommandType = "CompleteRequest"
 
 	CommandTypeRequestCreateMember      CommandType = "RequestCreateMember"
@@ -35,15 +43,65 @@ const (
 
 	CommandTypeCreateMember      CommandType = "CreateMember"
 	CommandTypeUpdateMember      CommandType = "UpdateMember"
-	CommandTypeDeleteMember      CommandType = "DeleteMember"
 	CommandTypeSetMemberPassword CommandType = "SetMemberPassword"
 	CommandTypeSetMemberMatchUID CommandType = "SetMemberMatchUID"
+	CommandTypeDeactivateMember  CommandType = "DeactivateMember"
+	CommandTypeReactivateMember  CommandType = "ReactivateMember"
 
 	CommandTypeCreateTension     CommandType = "CreateTension"
 	CommandTypeUpdateTension     CommandType = "UpdateTension"
 	CommandTypeChangeTensionRole CommandType = "ChangeTensionRole"
 	CommandTypeCloseTension      CommandType = "CloseTension"
 
+	CommandTypeChangeTensionStatus   CommandType = "ChangeTensionStatus"
+	CommandTypeChangeTensionAssignee CommandType = "ChangeTensionAssignee"
+	CommandTypeChangeTensionMeeting  CommandType = "ChangeTensionMeeting"
+	CommandTypeResolveTension        CommandType = "ResolveTension"
+
+	CommandTypeCreateProposal   CommandType = "CreateProposal"
+	CommandTypeUpdateProposal   CommandType = "UpdateProposal"
+	CommandTypeSubmitProposal   CommandType = "SubmitProposal"
+	CommandTypeObjectProposal   CommandType = "ObjectProposal"
+	CommandTypeAcceptProposal   CommandType = "AcceptProposal"
+	CommandTypeWithdrawProposal CommandType = "WithdrawProposal"
+
+	CommandTypeResolveProposalObjection  CommandType = "ResolveProposalObjection"
+	CommandTypeWithdrawProposalObjection CommandType = "WithdrawProposalObjection"
+	CommandTypeConsentProposal           CommandType = "ConsentProposal"
+
+	CommandTypeCreateProject       CommandType = "CreateProject"
+	CommandTypeUpdateProject       CommandType = "UpdateProject"
+	CommandTypeChangeProjectStatus CommandType = "ChangeProjectStatus"
+
+	CommandTypeCreateAction       CommandType = "CreateAction"
+	CommandTypeUpdateAction       CommandType = "UpdateAction"
+	CommandTypeChangeActionStatus CommandType = "ChangeActionStatus"
+
+	CommandTypeCreateMeeting       CommandType = "CreateMeeting"
+	CommandTypeReportMeetingValues CommandType = "ReportMeetingValues"
+
+	CommandTypeCreateElection            CommandType = "CreateElection"
+	CommandTypeNominateElectionCandidate CommandType = "NominateElectionCandidate"
+	CommandTypeCompleteElection          CommandType = "CompleteElection"
+
+	CommandTypeCreateRoleTemplate         CommandType = "CreateRoleTemplate"
+	CommandTypeUpdateRoleTemplate         CommandType = "UpdateRoleTemplate"
+	CommandTypeDeleteRoleTemplate         CommandType = "DeleteRoleTemplate"
+	CommandTypeLinkRoleTemplateInstance   CommandType = "LinkRoleTemplateInstance"
+	CommandTypeUnlinkRoleTemplateInstance CommandType = "UnlinkRoleTemplateInstance"
+
+	CommandTypeCreateWebhook                CommandType = "CreateWebhook"
+	CommandTypeUpdateWebhook                CommandType = "UpdateWebhook"
+	CommandTypeDeleteWebhook                CommandType = "DeleteWebhook"
+	CommandTypeRecordWebhookDeliveryFailure CommandType = "RecordWebhookDeliveryFailure"
+
+	CommandTypeCreateSession             CommandType = "CreateSession"
+	CommandTypeRotateSessionRefreshToken CommandType = "RotateSessionRefreshToken"
+	CommandTypeRevokeSession             CommandType = "RevokeSession"
+
+	CommandTypeCreateAPIToken CommandType = "CreateAPIToken"
+	CommandTypeRevokeAPIToken CommandType = "RevokeAPIToken"
+
 	CommandTypeCircleAddDirectMember    CommandType = "CircleAddDirectMember"
 	CommandTypeCircleRemoveDirectMember CommandType = "CircleRemoveDirectMember"
 
@@ -57,6 +115,10 @@ const (
 	CommandTypeRoleUpdateMember CommandType = "RoleUpdateMember"
 	CommandTypeRoleRemoveMember CommandType = "RoleRemoveMember"
 
+	// RemoveMemberAssignments removes a member from all the roles (also lead
+	// link and core roles) and circles direct memberships in a single command
+	CommandTypeRemoveMemberAssignments CommandType = "RemoveMemberAssignments"
+
 	CommandTypeReserveValue CommandType = "ReserveValue"
 	CommandTypeReleaseValue CommandType = "ReleaseValue"
 )
@@ -67,6 +129,8 @@ type Command struct {
 	CorrelationID util.ID
 	CausationID   util.ID
 	IssuerID      util.ID
+	// IssuerTokenID is the api token used by the issuer
+	IssuerTokenID *util.ID `json:",omitempty"`
 	Data          interface{}
 }
 
@@ -107,61 +171,79 @@ type CircleDeleteChildRole struct {
 	DeleteRoleChange change.DeleteRoleChange
 }
 
+type CircleApplyProposal struct {
+	RoleID     util.ID
+	ProposalID util.ID
+	// NewRoleIDs are the ids of the roles created by the
+	// ProposalChanges.CreateRoleChanges (in the same order)
+	NewRoleIDs      []util.ID
+	ProposalChanges change.ProposalChanges
+}
+
 type SetRoleAdditionalContent struct {
 	RoleID  util.ID
 	Content string
 }
 
+type MoveRole struct {
+	RoleID          util.ID
+	NewParentRoleID util.ID
+}
+
 type CompleteRequest struct {
 	Error  bool
 	Reason string
 }
 
 type RequestCreateMember struct {
-	MemberID     util.ID
-	IsAdmin      bool
-	MatchUID     string
-	UserName     string
-	FullName     string
-	Email        string
-	PasswordHash string
-	Avatar       []byte
+	MemberID         util.ID
+	IsAdmin          bool
+	IsServiceAccount bool
+	MatchUID         string
+	UserName         string
+	FullName         string
+	Email            string
+	PasswordHash     string
+	Avatar           []byte
 }
 
 func NewCommandRequestCreateMember(c *change.CreateMemberChange, memberID util.ID, passwordHash string, avatar []byte) *RequestCreateMember {
 	return &RequestCreateMember{
-		MemberID:     memberID,
-		IsAdmin:      c.IsAdmin,
-		MatchUID:     c.MatchUID,
-		UserName:     c.UserName,
-		FullName:     c.FullName,
-		Email:        c.Email,
-		PasswordHash: passwordHash,
-		Avatar:       avatar,
+		MemberID:         memberID,
+		IsAdmin:          c.IsAdmin,
+		IsServiceAccount: c.IsServiceAccount,
+		MatchUID:         c.MatchUID,
+		UserName:         c.UserName,
+		FullName:         c.FullName,
+		Email:            c.Email,
+		PasswordHash:     passwordHash,
+		Avatar:           avatar,
 	}
 }
 
 type CreateMember struct {
-	IsAdmin        bool
-	MatchUID       string
-	UserName       string
-	FullName       string
-	Email          string
-	PasswordHash   string
-	Avatar         []byte
-	MemberChangeID util.ID
+	IsAdmin          bool
+	IsServiceAccount bool
+	MatchUID         string
+	UserName         string
+	FullName         string
+	Email            string
+	PasswordHash     string
+	Avatar           []byte
+	MemberChangeID   util.ID
 }
 
 func NewCommandCreateMember(c *change.CreateMemberChange, memberChangeID util.ID, passwordHash string, avatar []byte) *CreateMember {
 	return &CreateMember{
-		IsAdmin:        c.IsAdmin,
-		MatchUID:       c.MatchUID,
-		UserName:       c.UserName,
-		FullName:       c.FullName,
-		Email:          c.Email,
-		PasswordHash:   passwordHash,
-		Avatar:         avatar,
-		MemberChangeID: memberChangeID,
+		IsAdmin:          c.IsAdmin,
+		IsServiceAccount: c.IsServiceAccount,
+		MatchUID:         c.MatchUID,
+		UserName:         c.UserName,
+		FullName:         c.FullName,
+		Email:            c.Email,
+		PasswordHash:     passwordHash,
+		Avatar:           avatar,
+		MemberChangeID:   memberChangeID,
 	}
 }
 
@@ -227,6 +309,10 @@ type SetMemberMatchUID struct {
 	MemberChangeID util.ID
 }
 
+type DeactivateMember struct{}
+
+type ReactivateMember struct{}
+
 type CreateTension struct {
 	Title       string
 	Description string
@@ -270,15 +356,310 @@ func NewCommandChangeTensionRole(roleID *util.ID, tensionVersion int64) *ChangeT
 }
 
 type CloseTension struct {
-	Reason string
+	Reason    string
+	MeetingID *util.ID
 }
 
 func NewCommandCloseTension(c *change.CloseTensionChange) *CloseTension {
 	return &CloseTension{
-		Reason: c.Reason,
+		Reason:    c.Reason,
+		MeetingID: c.MeetingID,
+	}
+}
+
+type ChangeTensionStatus struct {
+	Status models.TensionStatus
+}
+
+type ChangeTensionAssignee struct {
+	MemberID *util.ID
+}
+
+type ChangeTensionMeeting struct {
+	MeetingID *util.ID
+}
+
+type ResolveTension struct {
+	OutcomeType        models.TensionOutcomeType
+	OutcomeReferenceID *util.ID
+	OutcomeDescription string
+}
+
+func NewCommandResolveTension(c *change.ResolveTensionChange) *ResolveTension {
+	return &ResolveTension{
+		OutcomeType:        c.OutcomeType,
+		OutcomeReferenceID: c.OutcomeReferenceID,
+		OutcomeDescription: c.OutcomeDescription,
+	}
+}
+
+type CreateProposal struct {
+	RoleID          util.ID
+	MemberID        util.ID
+	Title           string
+	Description     string
+	ProposalChanges change.ProposalChanges
+}
+
+func NewCommandCreateProposal(memberID util.ID, c *change.CreateProposalChange) *CreateProposal {
+	return &CreateProposal{
+		RoleID:          c.RoleID,
+		MemberID:        memberID,
+		Title:           c.Title,
+		Description:     c.Description,
+		ProposalChanges: c.ProposalChanges,
+	}
+}
+
+type UpdateProposal struct {
+	Title           string
+	Description     string
+	ProposalChanges change.ProposalChanges
+}
+
+func NewCommandUpdateProposal(c *change.UpdateProposalChange) *UpdateProposal {
+	return &UpdateProposal{
+		Title:           c.Title,
+		Description:     c.Description,
+		ProposalChanges: c.ProposalChanges,
+	}
+}
+
+type SubmitProposal struct {
+}
+
+type ObjectProposal struct {
+	ObjectionID util.ID
+	MemberID    util.ID
+	Reason      string
+}
+
+func NewCommandObjectProposal(objectionID, memberID util.ID, c *change.ObjectProposalChange) *ObjectProposal {
+	return &ObjectProposal{
+		ObjectionID: objectionID,
+		MemberID:    memberID,
+		Reason:      c.Reason,
+	}
+}
+
+type ResolveProposalObjection struct {
+	ObjectionID util.ID
+	MemberID    util.ID
+	Resolution  string
+}
+
+func NewCommandResolveProposalObjection(memberID util.ID, c *change.ResolveProposalObjectionChange) *ResolveProposalObjection {
+	return &ResolveProposalObjection{
+		ObjectionID: c.ObjectionID,
+		MemberID:    memberID,
+		Resolution:  c.Resolution,
+	}
+}
+
+type WithdrawProposalObjection struct {
+	ObjectionID util.ID
+	MemberID    util.ID
+}
+
+type ConsentProposal struct {
+	MemberID util.ID
+}
+
+type AcceptProposal struct {
+}
+
+type WithdrawProposal struct {
+}
+
+type CreateProject struct {
+	RoleID      util.ID
+	MemberID    *util.ID
+	TensionID   *util.ID
+	Title       string
+	Description string
+	DueDate     *time.Time
+}
+
+func NewCommandCreateProject(c *change.CreateProjectChange) *CreateProject {
+	return &CreateProject{
+		RoleID:      c.RoleID,
+		MemberID:    c.MemberID,
+		TensionID:   c.TensionID,
+		Title:       c.Title,
+		Description: c.Description,
+		DueDate:     c.DueDate,
+	}
+}
+
+type UpdateProject struct {
+	MemberID    *util.ID
+	Title       string
+	Description string
+	DueDate     *time.Time
+}
+
+func NewCommandUpdateProject(c *change.UpdateProjectChange) *UpdateProject {
+	return &UpdateProject{
+		MemberID:    c.MemberID,
+		Title:       c.Title,
+		Description: c.Description,
+		DueDate:     c.DueDate,
+	}
+}
+
+type ChangeProjectStatus struct {
+	Status models.ProjectStatus
+}
+
+type CreateAction struct {
+	RoleID      util.ID
+	MemberID    *util.ID
+	TensionID   *util.ID
+	Title       string
+	Description string
+	DueDate     *time.Time
+}
+
+func NewCommandCreateAction(c *change.CreateActionChange) *CreateAction {
+	return &CreateAction{
+		RoleID:      c.RoleID,
+		MemberID:    c.MemberID,
+		TensionID:   c.TensionID,
+		Title:       c.Title,
+		Description: c.Description,
+		DueDate:     c.DueDate,
 	}
 }
 
+type UpdateAction struct {
+	MemberID    *util.ID
+	Title       string
+	Description string
+	DueDate     *time.Time
+}
+
+func NewCommandUpdateAction(c *change.UpdateActionChange) *UpdateAction {
+	return &UpdateAction{
+		MemberID:    c.MemberID,
+		Title:       c.Title,
+		Description: c.Description,
+		DueDate:     c.DueDate,
+	}
+}
+
+type ChangeActionStatus struct {
+	Status models.ActionStatus
+}
+
+type CreateMeeting struct {
+	RoleID        util.ID
+	MeetingType   models.MeetingType
+	Date          time.Time
+	FacilitatorID *util.ID
+	SecretaryID   *util.ID
+	AttendeesIDs  []util.ID
+}
+
+type ReportMeetingValues struct {
+	ChecklistItemReports []change.ChecklistItemReport
+	MetricReports        []change.MetricReport
+}
+
+type CreateElection struct {
+	RoleID   util.ID
+	RoleType models.RoleType
+	MemberID util.ID
+}
+
+type NominateElectionCandidate struct {
+	CandidateID util.ID
+	NominatorID util.ID
+}
+
+type CompleteElection struct {
+	ElectedMemberID    util.ID
+	ElectionExpiration *time.Time
+}
+
+type CreateRoleTemplate struct {
+	Name             string
+	Purpose          string
+	Domains          []string
+	Accountabilities []string
+	SourceRoleID     *util.ID
+}
+
+type UpdateRoleTemplate struct {
+	Name             string
+	Purpose          string
+	Domains          []string
+	Accountabilities []string
+}
+
+type DeleteRoleTemplate struct {
+}
+
+type LinkRoleTemplateInstance struct {
+	RoleID util.ID
+}
+
+type UnlinkRoleTemplateInstance struct {
+	RoleID util.ID
+}
+
+type CreateWebhook struct {
+	URL        string
+	EventTypes []string
+	Secret     string
+}
+
+type UpdateWebhook struct {
+	URL        string
+	EventTypes []string
+	Secret     string
+}
+
+type DeleteWebhook struct {
+}
+
+type RecordWebhookDeliveryFailure struct {
+	EventID        util.ID
+	SequenceNumber int64
+	EventType      string
+	Attempts       int
+	LastError      string
+}
+
+type CreateSession struct {
+	MemberID         util.ID
+	Expiration       time.Time
+	RefreshTokenID   util.ID
+	RefreshTokenHash string
+}
+
+// RotateSessionRefreshToken replaces the current session refresh token
+// (RefreshTokenID) with a new one
+type RotateSessionRefreshToken struct {
+	RefreshTokenID      util.ID
+	NewRefreshTokenID   util.ID
+	NewRefreshTokenHash string
+}
+
+type RevokeSession struct {
+	RefreshTokenReused bool
+}
+
+type CreateAPIToken struct {
+	MemberID   util.ID
+	Name       string
+	Scope      models.APITokenScope
+	Expiration time.Time
+	TokenHash  string
+}
+
+type RevokeAPIToken struct {
+}
+
 type CircleAddDirectMember struct {
 	RoleID   util.ID
 	MemberID util.ID
@@ -329,6 +710,10 @@ type RoleRemoveMember struct {
 	MemberID util.ID
 }
 
+type RemoveMemberAssignments struct {
+	MemberID util.ID
+}
+
 type ReserveValue struct {
 	Value     string
 	ID        util.ID
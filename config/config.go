@@ -28,6 +28,10 @@ func Parse(configFile string) (*Config, error) {
 type Config struct {
 	Debug bool `json:"debug"`
 
+	// DataDir is the directory where the local aggregates and event handlers
+	// snapshot dbs are saved
+	DataDir string `json:"dataDir"`
+
 	Web        Web        `json:"web"`
 	ReadDB     DB         `json:"readdb"`
 	EventStore EventStore `json:"eventStore"`
@@ -54,11 +58,15 @@ type Config struct {
 
 var defaultConfig = Config{
 	CreateInitialAdmin: true,
+	DataDir:            filepath.Join(os.TempDir(), "sircles-data"),
 	Index: Index{
 		Path: filepath.Join(os.TempDir(), "sircles-index"),
 	},
 	TokenSigning: TokenSigning{
 		Duration: 12 * 3600,
+		Lifetimes: TokenLifetimes{
+			RefreshToken: 30 * 24 * 3600,
+		},
 	},
 }
 
@@ -73,8 +81,9 @@ type Web struct {
 	// CA's certificate.
 	TLSCert string `json:"tlsCert"`
 	// Server cert private key
-	// TODO(sgotti) support encrypted private keys (add a private key password config entry)
 	TLSKey string `json:"tlsKey"`
+	// Password to decrypt the server cert private key when encrypted
+	TLSKeyPassword string `json:"tlsKeyPassword"`
 	// CORS allowed origins
 	AllowedOrigins []string `json:"allowedOrigins"`
 }
@@ -96,7 +105,10 @@ type Index struct {
 
 type TokenSigning struct {
 	// token duration in seconds (defaults to 12 hours)
+	// Deprecated: use Lifetimes.AccessToken
 	Duration uint `json:"duration"`
+	// Lifetimes of the issued tokens
+	Lifetimes TokenLifetimes `json:"lifetimes"`
 	// signing method: "hmac" or "rsa"
 	Method string `json:"method"`
 	// signing key. Used only with HMAC signing method
@@ -105,6 +117,26 @@ type TokenSigning struct {
 	PrivateKeyPath string `json:"privateKeyPath"`
 	// path to a file containing a pem encoded public key. Used only with RSA signing method
 	PublicKeyPath string `json:"publicKeyPath"`
+	// path to a directory containing the pem encoded private keys, alternative
+	// to PrivateKeyPath. Used only with RSA signing method. The file name
+	// without the .pem extension is the key id and the key with the greatest
+	// id signs the new tokens after being published for some minutes. The
+	// directory is periodically reloaded so keys can be rotated without a
+	// restart: removed keys keep verifying the tokens until the access token
+	// lifetime expires.
+	KeysDir string `json:"keysDir"`
+	// password to decrypt the private keys when encrypted. Used only with RSA
+	// signing method
+	PrivateKeyPassword string `json:"privateKeyPassword"`
+}
+
+type TokenLifetimes struct {
+	// access token lifetime in seconds (defaults to Duration)
+	AccessToken uint `json:"accessToken"`
+	// refresh token lifetime in seconds (defaults to 30 days). It's also the
+	// session lifetime: the refresh tokens are rotated on every use but a new
+	// login is required when it expires
+	RefreshToken uint `json:"refreshToken"`
 }
 
 type Authentication struct {
@@ -13,38 +13,108 @@ import (
 )
 
 type tlDataLoaders struct {
-	RoleDomains           dataloader.Interface
-	RoleAccountabilities  dataloader.Interface
-	RoleAdditionalContent dataloader.Interface
-	ChildRole             dataloader.Interface
-	RoleMemberEdges       dataloader.Interface
-	MemberRoleEdges       dataloader.Interface
-	CircleMemberEdges     dataloader.Interface
-	MemberCircleEdges     dataloader.Interface
-	RoleParent            dataloader.Interface
-	RoleParents           dataloader.Interface
-	MemberTensions        dataloader.Interface
-	TensionMember         dataloader.Interface
-	RoleTensions          dataloader.Interface
-	TensionRole           dataloader.Interface
+	RoleDomains                 dataloader.Interface
+	RoleAccountabilities        dataloader.Interface
+	RoleChecklistItems          dataloader.Interface
+	RoleMetrics                 dataloader.Interface
+	ChecklistItemReports        dataloader.Interface
+	MetricReports               dataloader.Interface
+	RoleAdditionalContent       dataloader.Interface
+	ChildRole                   dataloader.Interface
+	RoleMemberEdges             dataloader.Interface
+	MemberRoleEdges             dataloader.Interface
+	CircleMemberEdges           dataloader.Interface
+	MemberCircleEdges           dataloader.Interface
+	RoleParent                  dataloader.Interface
+	RoleParents                 dataloader.Interface
+	MemberTensions              dataloader.Interface
+	TensionMember               dataloader.Interface
+	RoleTensions                dataloader.Interface
+	TensionRole                 dataloader.Interface
+	TensionAssignee             dataloader.Interface
+	RoleProposals               dataloader.Interface
+	ProposalRole                dataloader.Interface
+	ProposalMember              dataloader.Interface
+	ProposalChanges             dataloader.Interface
+	ProposalObjections          dataloader.Interface
+	ObjectionMember             dataloader.Interface
+	ProposalConsents            dataloader.Interface
+	RoleProjects                dataloader.Interface
+	MemberProjects              dataloader.Interface
+	ProjectRole                 dataloader.Interface
+	ProjectMember               dataloader.Interface
+	ProjectTension              dataloader.Interface
+	RoleActions                 dataloader.Interface
+	MemberActions               dataloader.Interface
+	MeetingRole                 dataloader.Interface
+	MeetingFacilitator          dataloader.Interface
+	MeetingSecretary            dataloader.Interface
+	MeetingAttendees            dataloader.Interface
+	MeetingTensions             dataloader.Interface
+	MeetingChecklistItemReports dataloader.Interface
+	MeetingMetricReports        dataloader.Interface
+	RoleElections               dataloader.Interface
+	ElectionRole                dataloader.Interface
+	ElectionNominations         dataloader.Interface
+	ElectionElected             dataloader.Interface
+	RoleTemplateInstances       dataloader.Interface
+	RoleRoleTemplate            dataloader.Interface
+	ActionRole                  dataloader.Interface
+	ActionMember                dataloader.Interface
+	ActionTension               dataloader.Interface
 }
 
 func NewTlDataLoaders(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) *tlDataLoaders {
 	return &tlDataLoaders{
-		RoleDomains:           dataloader.NewBatchedLoader(RoleDomainsBatchFn(ctx, s, timeLine)),
-		RoleAccountabilities:  dataloader.NewBatchedLoader(RoleAccountabilitiesBatchFn(ctx, s, timeLine)),
-		RoleAdditionalContent: dataloader.NewBatchedLoader(RoleAdditionalContentBatchFn(ctx, s, timeLine)),
-		ChildRole:             dataloader.NewBatchedLoader(ChildRoleBatchFn(ctx, s, timeLine)),
-		RoleMemberEdges:       dataloader.NewBatchedLoader(RoleMemberEdgesBatchFn(ctx, s, timeLine)),
-		MemberRoleEdges:       dataloader.NewBatchedLoader(MemberRoleEdgesBatchFn(ctx, s, timeLine)),
-		CircleMemberEdges:     dataloader.NewBatchedLoader(CircleMemberEdgesBatchFn(ctx, s, timeLine)),
-		MemberCircleEdges:     dataloader.NewBatchedLoader(MemberCircleEdgesBatchFn(ctx, s, timeLine)),
-		RoleParent:            dataloader.NewBatchedLoader(RoleParentBatchFn(ctx, s, timeLine)),
-		RoleParents:           dataloader.NewBatchedLoader(RoleParentsBatchFn(ctx, s, timeLine)),
-		MemberTensions:        dataloader.NewBatchedLoader(MemberTensionsBatchFn(ctx, s, timeLine)),
-		TensionMember:         dataloader.NewBatchedLoader(TensionMemberBatchFn(ctx, s, timeLine)),
-		RoleTensions:          dataloader.NewBatchedLoader(RoleTensionsBatchFn(ctx, s, timeLine)),
-		TensionRole:           dataloader.NewBatchedLoader(TensionRoleBatchFn(ctx, s, timeLine)),
+		RoleDomains:                 dataloader.NewBatchedLoader(RoleDomainsBatchFn(ctx, s, timeLine)),
+		RoleAccountabilities:        dataloader.NewBatchedLoader(RoleAccountabilitiesBatchFn(ctx, s, timeLine)),
+		RoleChecklistItems:          dataloader.NewBatchedLoader(RoleChecklistItemsBatchFn(ctx, s, timeLine)),
+		RoleMetrics:                 dataloader.NewBatchedLoader(RoleMetricsBatchFn(ctx, s, timeLine)),
+		ChecklistItemReports:        dataloader.NewBatchedLoader(ChecklistItemReportsBatchFn(ctx, s, timeLine)),
+		MetricReports:               dataloader.NewBatchedLoader(MetricReportsBatchFn(ctx, s, timeLine)),
+		RoleAdditionalContent:       dataloader.NewBatchedLoader(RoleAdditionalContentBatchFn(ctx, s, timeLine)),
+		ChildRole:                   dataloader.NewBatchedLoader(ChildRoleBatchFn(ctx, s, timeLine)),
+		RoleMemberEdges:             dataloader.NewBatchedLoader(RoleMemberEdgesBatchFn(ctx, s, timeLine)),
+		MemberRoleEdges:             dataloader.NewBatchedLoader(MemberRoleEdgesBatchFn(ctx, s, timeLine)),
+		CircleMemberEdges:           dataloader.NewBatchedLoader(CircleMemberEdgesBatchFn(ctx, s, timeLine)),
+		MemberCircleEdges:           dataloader.NewBatchedLoader(MemberCircleEdgesBatchFn(ctx, s, timeLine)),
+		RoleParent:                  dataloader.NewBatchedLoader(RoleParentBatchFn(ctx, s, timeLine)),
+		RoleParents:                 dataloader.NewBatchedLoader(RoleParentsBatchFn(ctx, s, timeLine)),
+		MemberTensions:              dataloader.NewBatchedLoader(MemberTensionsBatchFn(ctx, s, timeLine)),
+		TensionMember:               dataloader.NewBatchedLoader(TensionMemberBatchFn(ctx, s, timeLine)),
+		RoleTensions:                dataloader.NewBatchedLoader(RoleTensionsBatchFn(ctx, s, timeLine)),
+		TensionRole:                 dataloader.NewBatchedLoader(TensionRoleBatchFn(ctx, s, timeLine)),
+		TensionAssignee:             dataloader.NewBatchedLoader(TensionAssigneeBatchFn(ctx, s, timeLine)),
+		RoleProposals:               dataloader.NewBatchedLoader(RoleProposalsBatchFn(ctx, s, timeLine)),
+		ProposalRole:                dataloader.NewBatchedLoader(ProposalRoleBatchFn(ctx, s, timeLine)),
+		ProposalMember:              dataloader.NewBatchedLoader(ProposalMemberBatchFn(ctx, s, timeLine)),
+		ProposalChanges:             dataloader.NewBatchedLoader(ProposalChangesBatchFn(ctx, s, timeLine)),
+		ProposalObjections:          dataloader.NewBatchedLoader(ProposalObjectionsBatchFn(ctx, s, timeLine)),
+		ObjectionMember:             dataloader.NewBatchedLoader(ObjectionMemberBatchFn(ctx, s, timeLine)),
+		ProposalConsents:            dataloader.NewBatchedLoader(ProposalConsentsBatchFn(ctx, s, timeLine)),
+		RoleProjects:                dataloader.NewBatchedLoader(RoleProjectsBatchFn(ctx, s, timeLine)),
+		MemberProjects:              dataloader.NewBatchedLoader(MemberProjectsBatchFn(ctx, s, timeLine)),
+		ProjectRole:                 dataloader.NewBatchedLoader(ProjectRoleBatchFn(ctx, s, timeLine)),
+		ProjectMember:               dataloader.NewBatchedLoader(ProjectMemberBatchFn(ctx, s, timeLine)),
+		ProjectTension:              dataloader.NewBatchedLoader(ProjectTensionBatchFn(ctx, s, timeLine)),
+		RoleActions:                 dataloader.NewBatchedLoader(RoleActionsBatchFn(ctx, s, timeLine)),
+		MemberActions:               dataloader.NewBatchedLoader(MemberActionsBatchFn(ctx, s, timeLine)),
+		MeetingRole:                 dataloader.NewBatchedLoader(MeetingRoleBatchFn(ctx, s, timeLine)),
+		MeetingFacilitator:          dataloader.NewBatchedLoader(MeetingFacilitatorBatchFn(ctx, s, timeLine)),
+		MeetingSecretary:            dataloader.NewBatchedLoader(MeetingSecretaryBatchFn(ctx, s, timeLine)),
+		MeetingAttendees:            dataloader.NewBatchedLoader(MeetingAttendeesBatchFn(ctx, s, timeLine)),
+		MeetingTensions:             dataloader.NewBatchedLoader(MeetingTensionsBatchFn(ctx, s, timeLine)),
+		MeetingChecklistItemReports: dataloader.NewBatchedLoader(MeetingChecklistItemReportsBatchFn(ctx, s, timeLine)),
+		MeetingMetricReports:        dataloader.NewBatchedLoader(MeetingMetricReportsBatchFn(ctx, s, timeLine)),
+		RoleElections:               dataloader.NewBatchedLoader(RoleElectionsBatchFn(ctx, s, timeLine)),
+		ElectionRole:                dataloader.NewBatchedLoader(ElectionRoleBatchFn(ctx, s, timeLine)),
+		ElectionNominations:         dataloader.NewBatchedLoader(ElectionNominationsBatchFn(ctx, s, timeLine)),
+		ElectionElected:             dataloader.NewBatchedLoader(ElectionElectedBatchFn(ctx, s, timeLine)),
+		RoleTemplateInstances:       dataloader.NewBatchedLoader(RoleTemplateInstancesBatchFn(ctx, s, timeLine)),
+		RoleRoleTemplate:            dataloader.NewBatchedLoader(RoleRoleTemplateBatchFn(ctx, s, timeLine)),
+		ActionRole:                  dataloader.NewBatchedLoader(ActionRoleBatchFn(ctx, s, timeLine)),
+		ActionMember:                dataloader.NewBatchedLoader(ActionMemberBatchFn(ctx, s, timeLine)),
+		ActionTension:               dataloader.NewBatchedLoader(ActionTensionBatchFn(ctx, s, timeLine)),
 	}
 }
 
@@ -140,6 +210,114 @@ func RoleAccountabilitiesBatchFn(ctx context.Context, s readdb.ReadDBService, ti
 	}
 }
 
+func RoleChecklistItemsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.RoleChecklistItems(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.ChecklistItem{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func RoleMetricsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.RoleMetrics(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.Metric{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func ChecklistItemReportsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.ChecklistItemReports(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.ChecklistItemReport{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func MetricReportsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.MetricReports(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.MetricReport{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
 func RoleAdditionalContentBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
 	return func(ikeys []string) []*dataloader.Result {
 		var results []*dataloader.Result
@@ -437,6 +615,33 @@ func TensionRoleBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine ut
 	}
 }
 
+func TensionAssigneeBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.TensionAssignee(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: nil}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
 func RoleTensionsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
 	return func(ikeys []string) []*dataloader.Result {
 		var results []*dataloader.Result
@@ -463,3 +668,813 @@ func RoleTensionsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine u
 		return results
 	}
 }
+
+func RoleProposalsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.RoleProposals(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.Proposal{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func ProposalRoleBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.ProposalRole(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: nil}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func ProposalMemberBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.ProposalMember(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: nil}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func ProposalChangesBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.ProposalChanges(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: nil}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func ProposalObjectionsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.ProposalObjections(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.ProposalObjection{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func ObjectionMemberBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.ProposalObjectionMember(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: nil}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func ProposalConsentsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.ProposalConsentMembers(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.Member{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func RoleProjectsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.RoleProjects(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.Project{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func MemberProjectsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.MemberProjects(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.Project{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func ProjectRoleBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.ProjectRole(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: nil}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func ProjectMemberBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.ProjectMember(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: nil}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func ProjectTensionBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.ProjectTension(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: nil}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func RoleActionsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.RoleActions(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.Action{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func MemberActionsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.MemberActions(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.Action{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func ActionRoleBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.ActionRole(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: nil}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func ActionMemberBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.ActionMember(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: nil}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func ActionTensionBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.ActionTension(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: nil}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func MeetingRoleBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.MeetingRole(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: nil}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func MeetingFacilitatorBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.MeetingFacilitator(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: nil}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func MeetingSecretaryBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.MeetingSecretary(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: nil}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func MeetingAttendeesBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.MeetingAttendees(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.Member{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func MeetingTensionsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.MeetingTensions(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.Tension{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func MeetingChecklistItemReportsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.MeetingChecklistItemReports(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.ChecklistItemReport{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func MeetingMetricReportsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.MeetingMetricReports(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.MetricReport{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func RoleElectionsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.RoleElections(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.Election{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func ElectionRoleBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.ElectionRole(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: nil}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func ElectionNominationsBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.ElectionNominations(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.ElectionNomination{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func ElectionElectedBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.ElectionElected(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: nil}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func RoleTemplateInstancesBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.RoleTemplateInstances(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: []*models.Role{}}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
+
+func RoleRoleTemplateBatchFn(ctx context.Context, s readdb.ReadDBService, timeLine util.TimeLineNumber) func(ikeys []string) []*dataloader.Result {
+	return func(ikeys []string) []*dataloader.Result {
+		var results []*dataloader.Result
+
+		keys := keysToIDs(ikeys)
+
+		groups, err := s.RoleRoleTemplate(ctx, timeLine, keys)
+		if err != nil {
+			for _ = range keys {
+				results = append(results, &dataloader.Result{Error: err})
+				return results
+			}
+		}
+
+		for _, key := range keys {
+			var result dataloader.Result
+			if group, ok := groups[key]; ok {
+				result = dataloader.Result{Data: group}
+			} else {
+				result = dataloader.Result{Data: nil}
+			}
+			results = append(results, &result)
+		}
+		return results
+	}
+}
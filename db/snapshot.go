@@ -0,0 +1,165 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+	"github.com/sorintlab/sircles/util"
+)
+
+// Snapshot dbs are local sqlite dbs used by aggregates and event handlers to
+// keep their state, built applying the events from the event store. Since
+// they're kept between restarts, they save some metadata to detect when they
+// were created with a different schema or from a different event store and
+// must be rebuilt from scratch.
+
+const snapshotMetaCreateStmt = "create table if not exists snapshotmeta (schemaversion bigint, streamid varchar, lasteventid uuid)"
+
+// SnapshotMeta contains the snapshot db metadata
+type SnapshotMeta struct {
+	// SchemaVersion is the snapshot db schema version
+	SchemaVersion int64
+	// StreamID is the event store stream used to build the snapshot (empty
+	// when built from all the events)
+	StreamID string
+	// LastEventID is the id of the last applied event
+	LastEventID *util.ID
+}
+
+var (
+	sb = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	snapshotMetaSelect = sb.Select("schemaversion", "streamid", "lasteventid").From("snapshotmeta")
+	snapshotMetaInsert = sb.Insert("snapshotmeta").Columns("schemaversion", "streamid", "lasteventid")
+	snapshotMetaUpdate = sb.Update("snapshotmeta")
+)
+
+// InitSnapshot creates the snapshot db tables and its metadata. If the
+// snapshot db was created with another schema version or from another stream
+// (or without metadata) all its tables are dropped and recreated.
+func InitSnapshot(tx *Tx, schemaVersion int64, streamID string, createStmts []string) error {
+	meta, err := ReadSnapshotMeta(tx)
+	if err != nil {
+		return err
+	}
+	if meta == nil || meta.SchemaVersion != schemaVersion || meta.StreamID != streamID {
+		if meta != nil {
+			log.Infof("snapshot db schema version %d (stream %q) incompatible with version %d (stream %q), rebuilding it", meta.SchemaVersion, meta.StreamID, schemaVersion, streamID)
+		}
+		if err := ResetSnapshot(tx); err != nil {
+			return err
+		}
+	}
+
+	err = tx.Do(func(tx *WrappedTx) error {
+		for _, stmt := range append([]string{snapshotMetaCreateStmt}, createStmts...) {
+			if _, err := tx.Exec(stmt); err != nil {
+				return errors.Wrapf(err, "create failed")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if meta != nil && meta.SchemaVersion == schemaVersion && meta.StreamID == streamID {
+		return nil
+	}
+
+	q, args, err := snapshotMetaInsert.Values(schemaVersion, streamID, nil).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+	err = tx.Do(func(tx *WrappedTx) error {
+		_, err := tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to insert snapshot meta")
+	}
+	return nil
+}
+
+// ResetSnapshot drops all the snapshot db tables
+func ResetSnapshot(tx *Tx) error {
+	return tx.Do(func(tx *WrappedTx) error {
+		rows, err := tx.Query("select name from sqlite_master where type = 'table'")
+		if err != nil {
+			return errors.Wrap(err, "failed to execute query")
+		}
+		tables := []string{}
+		for rows.Next() {
+			var table string
+			if err := rows.Scan(&table); err != nil {
+				rows.Close()
+				return errors.Wrap(err, "failed to scan rows")
+			}
+			tables = append(tables, table)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		for _, table := range tables {
+			if _, err := tx.Exec(fmt.Sprintf("drop table if exists %s", table)); err != nil {
+				return errors.Wrapf(err, "failed to drop table %s", table)
+			}
+		}
+		return nil
+	})
+}
+
+// ReadSnapshotMeta returns the snapshot db metadata or nil if the snapshot db
+// doesn't have it
+func ReadSnapshotMeta(tx *Tx) (*SnapshotMeta, error) {
+	var hasMeta bool
+	err := tx.Do(func(tx *WrappedTx) error {
+		var n int
+		if err := tx.QueryRow("select count(*) from sqlite_master where type = 'table' and name = 'snapshotmeta'").Scan(&n); err != nil {
+			return err
+		}
+		hasMeta = n > 0
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check snapshot meta table")
+	}
+	if !hasMeta {
+		return nil, nil
+	}
+
+	q, args, err := snapshotMetaSelect.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+	var meta SnapshotMeta
+	err = tx.Do(func(tx *WrappedTx) error {
+		return tx.QueryRow(q, args...).Scan(&meta.SchemaVersion, &meta.StreamID, &meta.LastEventID)
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read snapshot meta")
+	}
+	return &meta, nil
+}
+
+// UpdateSnapshotLastEventID saves the id of the last event applied to the
+// snapshot db
+func UpdateSnapshotLastEventID(tx *Tx, eventID util.ID) error {
+	q, args, err := snapshotMetaUpdate.Set("lasteventid", eventID).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+	err = tx.Do(func(tx *WrappedTx) error {
+		_, err := tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update snapshot last event id: %s", eventID)
+	}
+	return nil
+}
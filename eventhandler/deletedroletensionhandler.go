@@ -19,6 +19,11 @@ import (
 
 const (
 	dbName = "drth.db"
+
+	// drthSnapshotSchemaVersion must be increased when changing the snapshot
+	// db schema or how the events are handled so the snapshot db will be
+	// rebuilt
+	drthSnapshotSchemaVersion = 1
 )
 
 func newDB(dataDir string) (*db.DB, error) {
@@ -38,22 +43,17 @@ func NewDeletedRoleTensionHandler(dataDir string, es *eventstore.EventStore, uid
 	}
 	defer ldb.Close()
 
-	err = ldb.Do(func(tx *db.Tx) error {
-		return tx.Do(func(tx *db.WrappedTx) error {
-			for _, stmt := range drthDBCreateStmts {
-				if _, err := tx.Exec(stmt); err != nil {
-					return errors.WithMessage(err, "create failed")
-				}
-			}
-			return nil
-		})
-	})
-
-	return &DeletedRoleTensionHandler{
+	h := &DeletedRoleTensionHandler{
 		dataDir:      dataDir,
 		es:           es,
 		uidGenerator: uidGenerator,
-	}, err
+	}
+
+	if err := initSnapshot(ldb, es, drthSnapshotSchemaVersion, drthDBCreateStmts, h.SequenceNumber); err != nil {
+		return nil, err
+	}
+
+	return h, nil
 }
 
 func (h *DeletedRoleTensionHandler) Name() string {
@@ -86,7 +86,7 @@ func (h *DeletedRoleTensionHandler) updateTensions(ldb *db.DB) error {
 		}
 
 		groupID := h.uidGenerator.UUID("")
-		eventsData, err := ep.GenEventData(events, &correlationID, &causationID, &groupID, nil)
+		eventsData, err := ep.GenEventData(events, &correlationID, &causationID, &groupID, nil, nil)
 		if err != nil {
 			return err
 		}
@@ -202,7 +202,7 @@ func (h *DeletedRoleTensionHandler) handleEvent(tx *db.Tx, event *eventstore.Sto
 			return err
 		}
 
-	case ep.EventTypeTensionClosed:
+	case ep.EventTypeTensionClosed, ep.EventTypeTensionResolved:
 		tensionID, err := util.IDFromString(event.StreamID)
 		if err != nil {
 			return err
@@ -226,6 +226,9 @@ func (h *DeletedRoleTensionHandler) handleEvent(tx *db.Tx, event *eventstore.Sto
 	if err := h.updateSequenceNumber(tx, event.SequenceNumber); err != nil {
 		return err
 	}
+	if err := db.UpdateSnapshotLastEventID(tx, event.ID); err != nil {
+		return err
+	}
 
 	return nil
 }
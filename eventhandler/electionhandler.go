@@ -0,0 +1,435 @@
+package eventhandler
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+	"github.com/sorintlab/sircles/aggregate"
+	"github.com/sorintlab/sircles/command/commands"
+	"github.com/sorintlab/sircles/common"
+	"github.com/sorintlab/sircles/db"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/util"
+)
+
+const (
+	elhDBName = "elh.db"
+
+	// elhSnapshotSchemaVersion must be increased when changing the snapshot
+	// db schema or how the events are handled so the snapshot db will be
+	// rebuilt
+	elhSnapshotSchemaVersion = 1
+
+	// DefaultElectionReminderInterval is how long before the election
+	// expiration the circle is reminded to hold a new election
+	DefaultElectionReminderInterval = 14 * 24 * time.Hour
+)
+
+func newElectionHandlerDB(dataDir string) (*db.DB, error) {
+	return db.NewDB("sqlite3", filepath.Join(dataDir, elhDBName))
+}
+
+// ElectionHandler tracks the circles core roles assignments and, when an
+// election expiration passed or is approaching, creates a tension for the
+// circle secretary (or the lead link when there's no secretary) as a reminder
+// to hold a new election.
+type ElectionHandler struct {
+	dataDir          string
+	es               *eventstore.EventStore
+	uidGenerator     common.UIDGenerator
+	timeGenerator    common.TimeGenerator
+	reminderInterval time.Duration
+}
+
+func NewElectionHandler(dataDir string, es *eventstore.EventStore, uidGenerator common.UIDGenerator, timeGenerator common.TimeGenerator) (*ElectionHandler, error) {
+	ldb, err := newElectionHandlerDB(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	defer ldb.Close()
+
+	h := &ElectionHandler{
+		dataDir:          dataDir,
+		es:               es,
+		uidGenerator:     uidGenerator,
+		timeGenerator:    timeGenerator,
+		reminderInterval: DefaultElectionReminderInterval,
+	}
+
+	if err := initSnapshot(ldb, es, elhSnapshotSchemaVersion, elhDBCreateStmts, h.SequenceNumber); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+func (h *ElectionHandler) Name() string {
+	return "electionHandler"
+}
+
+type expiringCoreRoleMember struct {
+	coreRoleID         util.ID
+	circleID           util.ID
+	roleType           models.RoleType
+	electionExpiration time.Time
+}
+
+func (h *ElectionHandler) remindElections(ldb *db.DB) error {
+	limit := h.timeGenerator.Now().Add(h.reminderInterval)
+
+	var crms []*expiringCoreRoleMember
+	err := ldb.Do(func(tx *db.Tx) error {
+		var err error
+		crms, err = h.findExpiringCoreRoleMembers(tx, limit)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, crm := range crms {
+		var memberID *util.ID
+		err := ldb.Do(func(tx *db.Tx) error {
+			var err error
+			memberID, err = h.circleReminderMember(tx, crm.circleID)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		// nobody to remind, retry when a secretary or a lead link is assigned
+		if memberID == nil {
+			continue
+		}
+
+		title := fmt.Sprintf("%s election", crm.roleType)
+		description := fmt.Sprintf("The %s election expires on %s, a new election should be held.", crm.roleType, crm.electionExpiration.UTC().Format("2006-01-02"))
+
+		tensionID := h.uidGenerator.UUID(title)
+		tr := aggregate.NewTensionRepository(h.es, h.uidGenerator)
+		t, err := tr.Load(tensionID)
+		if err != nil {
+			return err
+		}
+
+		circleID := crm.circleID
+		correlationID := h.uidGenerator.UUID("")
+		causationID := h.uidGenerator.UUID("")
+		command := commands.NewCommand(commands.CommandTypeCreateTension, correlationID, causationID, util.NilID, &commands.CreateTension{
+			Title:       title,
+			Description: description,
+			MemberID:    *memberID,
+			RoleID:      &circleID,
+		})
+
+		events, err := t.HandleCommand(command)
+		if err != nil {
+			return err
+		}
+
+		groupID := h.uidGenerator.UUID("")
+		eventsData, err := ep.GenEventData(events, &correlationID, &causationID, &groupID, nil, nil)
+		if err != nil {
+			return err
+		}
+		if _, err = h.es.WriteEvents(eventsData, t.AggregateType().String(), t.ID(), t.Version()); err != nil {
+			return err
+		}
+
+		err = ldb.Do(func(tx *db.Tx) error {
+			return h.setCoreRoleMemberReminded(tx, crm.coreRoleID)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *ElectionHandler) HandleEvents() error {
+	log.Debugf("eh handleEvents")
+	ldb, err := newElectionHandlerDB(h.dataDir)
+	if err != nil {
+		return err
+	}
+	defer ldb.Close()
+
+	for {
+		var n int
+		err := ldb.Do(func(tx *db.Tx) error {
+			var err error
+			n, err = h.updateSnapshot(tx)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			break
+		}
+	}
+
+	return h.remindElections(ldb)
+}
+
+func (h *ElectionHandler) updateSnapshot(tx *db.Tx) (int, error) {
+	log.Debugf("updateSnapshot")
+
+	sn, err := h.SequenceNumber(tx)
+	if err != nil {
+		return 0, err
+	}
+	log.Debugf("sn: %d", sn)
+
+	events, err := h.es.GetAllEvents(sn+1, 100)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, e := range events {
+		if err := h.handleEvent(tx, e); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(events), nil
+}
+
+func (h *ElectionHandler) handleEvent(tx *db.Tx, event *eventstore.StoredEvent) error {
+	log.Debugf("event: %v", event)
+
+	data, err := ep.UnmarshalData(event)
+	if err != nil {
+		return err
+	}
+
+	switch ep.EventType(event.EventType) {
+	case ep.EventTypeCircleLeadLinkMemberSet:
+		data := data.(*ep.EventCircleLeadLinkMemberSet)
+		if err := h.insertCoreRoleMember(tx, data.LeadLinkRoleID, data.RoleID, models.RoleTypeLeadLink, data.MemberID, nil); err != nil {
+			return err
+		}
+
+	case ep.EventTypeCircleLeadLinkMemberUnset:
+		data := data.(*ep.EventCircleLeadLinkMemberUnset)
+		if err := h.deleteCoreRoleMember(tx, data.LeadLinkRoleID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeCircleCoreRoleMemberSet:
+		data := data.(*ep.EventCircleCoreRoleMemberSet)
+		if err := h.insertCoreRoleMember(tx, data.CoreRoleID, data.RoleID, data.RoleType, data.MemberID, data.ElectionExpiration); err != nil {
+			return err
+		}
+
+	case ep.EventTypeCircleCoreRoleMemberUnset:
+		data := data.(*ep.EventCircleCoreRoleMemberUnset)
+		if err := h.deleteCoreRoleMember(tx, data.CoreRoleID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleDeleted:
+		data := data.(*ep.EventRoleDeleted)
+		if err := h.deleteCoreRoleMember(tx, data.RoleID); err != nil {
+			return err
+		}
+	}
+
+	if err := h.updateSequenceNumber(tx, event.SequenceNumber); err != nil {
+		return err
+	}
+	if err := db.UpdateSnapshotLastEventID(tx, event.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ElectionHandler snapshot db
+var elhDBCreateStmts = []string{
+	// electionexpiration is saved as unix time
+	"create table if not exists corerolemember (coreroleid uuid, circleid uuid, roletype varchar, memberid uuid, electionexpiration bigint, reminded boolean, PRIMARY KEY (coreroleid))",
+	"create table if not exists sequencenumber (sequencenumber bigint)",
+}
+
+var (
+	coreRoleMemberInsert = sb.Insert("corerolemember").Columns("coreroleid", "circleid", "roletype", "memberid", "electionexpiration", "reminded")
+	coreRoleMemberDelete = sb.Delete("corerolemember")
+)
+
+func (h *ElectionHandler) findExpiringCoreRoleMembers(tx *db.Tx, limit time.Time) ([]*expiringCoreRoleMember, error) {
+	q, args, err := sb.Select("coreroleid", "circleid", "roletype", "electionexpiration").From("corerolemember").Where(sq.Eq{"reminded": false}).Where(sq.LtOrEq{"electionexpiration": limit.Unix()}).OrderBy("electionexpiration").ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	crms := []*expiringCoreRoleMember{}
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		rows, err := tx.Query(q, args...)
+		if err != nil {
+			return errors.Wrap(err, "failed to execute query")
+		}
+		for rows.Next() {
+			var crm expiringCoreRoleMember
+			// To make sqlite3 happy
+			var roleType string
+			var electionExpiration int64
+			if err := rows.Scan(&crm.coreRoleID, &crm.circleID, &roleType, &electionExpiration); err != nil {
+				return errors.Wrap(err, "failed to scan rows")
+			}
+			crm.roleType = models.RoleType(roleType)
+			crm.electionExpiration = time.Unix(electionExpiration, 0)
+			crms = append(crms, &crm)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find expiring core role members")
+	}
+	return crms, nil
+}
+
+// circleReminderMember returns the member that should be reminded of the
+// circle elections: the secretary or, if not assigned, the lead link
+func (h *ElectionHandler) circleReminderMember(tx *db.Tx, circleID util.ID) (*util.ID, error) {
+	q, args, err := sb.Select("roletype", "memberid").From("corerolemember").Where(sq.Eq{"circleid": circleID, "roletype": []string{models.RoleTypeSecretary.String(), models.RoleTypeLeadLink.String()}}).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	members := map[models.RoleType]util.ID{}
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		rows, err := tx.Query(q, args...)
+		if err != nil {
+			return errors.Wrap(err, "failed to execute query")
+		}
+		for rows.Next() {
+			var roleType string
+			var memberID util.ID
+			if err := rows.Scan(&roleType, &memberID); err != nil {
+				return errors.Wrap(err, "failed to scan rows")
+			}
+			members[models.RoleType(roleType)] = memberID
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to find circle %s secretary", circleID))
+	}
+
+	for _, roleType := range []models.RoleType{models.RoleTypeSecretary, models.RoleTypeLeadLink} {
+		if memberID, ok := members[roleType]; ok {
+			return &memberID, nil
+		}
+	}
+	return nil, nil
+}
+
+func (h *ElectionHandler) insertCoreRoleMember(tx *db.Tx, coreRoleID, circleID util.ID, roleType models.RoleType, memberID util.ID, electionExpiration *time.Time) error {
+	if err := h.deleteCoreRoleMember(tx, coreRoleID); err != nil {
+		return err
+	}
+
+	var expiration *int64
+	if electionExpiration != nil {
+		e := electionExpiration.Unix()
+		expiration = &e
+	}
+
+	q, args, err := coreRoleMemberInsert.Values(coreRoleID, circleID, roleType, memberID, expiration, false).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to insert core role %s member", coreRoleID))
+	}
+	return nil
+}
+
+func (h *ElectionHandler) deleteCoreRoleMember(tx *db.Tx, coreRoleID util.ID) error {
+	q, args, err := coreRoleMemberDelete.Where(sq.Eq{"coreroleid": coreRoleID}).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to delete core role %s member", coreRoleID))
+	}
+	return nil
+}
+
+func (h *ElectionHandler) setCoreRoleMemberReminded(tx *db.Tx, coreRoleID util.ID) error {
+	q, args, err := sb.Update("corerolemember").Set("reminded", true).Where(sq.Eq{"coreroleid": coreRoleID}).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to update core role %s member", coreRoleID))
+	}
+	return nil
+}
+
+func (h *ElectionHandler) SequenceNumber(tx *db.Tx) (int64, error) {
+	var sn int64
+	err := tx.Do(func(tx *db.WrappedTx) error {
+		return tx.QueryRow("select sequencenumber from sequencenumber limit 1").Scan(&sn)
+	})
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return sn, nil
+}
+
+func (h *ElectionHandler) updateSequenceNumber(tx *db.Tx, sn int64) error {
+	q, args, err := sequenceNumberDelete.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to delete sequencenumber: %v", sn))
+	}
+
+	q, args, err = sequenceNumberInsert.Values(sn).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to insert sequencenumber: %v", sn))
+	}
+	return nil
+}
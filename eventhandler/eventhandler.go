@@ -3,6 +3,8 @@ package eventhandler
 import (
 	"time"
 
+	"github.com/sorintlab/sircles/db"
+	"github.com/sorintlab/sircles/eventstore"
 	ln "github.com/sorintlab/sircles/listennotify"
 	"github.com/sorintlab/sircles/lock"
 )
@@ -57,3 +59,41 @@ func RunEventHandler(eh EventHandler, stop chan struct{}, lnf ln.ListenerFactory
 
 	return endCh, nil
 }
+
+// initSnapshot creates the event handler snapshot db. Since the snapshot db is
+// kept between restarts, it's rebuilt from scratch when its schema version
+// changed or when the last handled event doesn't match the event at the same
+// sequence number in the event store (i.e. the event store was recreated).
+func initSnapshot(ldb *db.DB, es *eventstore.EventStore, schemaVersion int64, createStmts []string, sequenceNumber func(tx *db.Tx) (int64, error)) error {
+	return ldb.Do(func(tx *db.Tx) error {
+		if err := db.InitSnapshot(tx, schemaVersion, "", createStmts); err != nil {
+			return err
+		}
+
+		meta, err := db.ReadSnapshotMeta(tx)
+		if err != nil {
+			return err
+		}
+		sn, err := sequenceNumber(tx)
+		if err != nil {
+			return err
+		}
+		if sn == 0 {
+			return nil
+		}
+
+		events, err := es.GetAllEvents(sn, 1)
+		if err != nil {
+			return err
+		}
+		if len(events) > 0 && events[0].SequenceNumber == sn && meta.LastEventID != nil && events[0].ID == *meta.LastEventID {
+			return nil
+		}
+
+		log.Infof("snapshot db at sequence number %d incompatible with the event store, rebuilding it", sn)
+		if err := db.ResetSnapshot(tx); err != nil {
+			return err
+		}
+		return db.InitSnapshot(tx, schemaVersion, "", createStmts)
+	})
+}
@@ -88,7 +88,7 @@ func (r *MemberRequestHandler) HandleEvents() error {
 		if mcSn != curMCSn || mSn != curMSn {
 			stateEvent := ep.NewEventMemberRequestHandlerStateUpdated(mcSn, mSn)
 
-			eventsData, err := ep.GenEventData([]ep.Event{stateEvent}, nil, nil, nil, nil)
+			eventsData, err := ep.GenEventData([]ep.Event{stateEvent}, nil, nil, nil, nil, nil)
 			if err != nil {
 				return err
 			}
@@ -177,7 +177,7 @@ func (r *MemberRequestHandler) callSaga(memberChangeID util.ID, event *eventstor
 	causationID := event.ID
 	correlationID := *metaData.CorrelationID
 	groupID := r.uidGenerator.UUID("")
-	eventsData, err := ep.GenEventData(events, &correlationID, &causationID, &groupID, nil)
+	eventsData, err := ep.GenEventData(events, &correlationID, &causationID, &groupID, nil, nil)
 	if err != nil {
 		return err
 	}
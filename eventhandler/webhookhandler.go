@@ -0,0 +1,796 @@
+package eventhandler
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+	"github.com/sorintlab/sircles/aggregate"
+	"github.com/sorintlab/sircles/command/commands"
+	"github.com/sorintlab/sircles/common"
+	"github.com/sorintlab/sircles/db"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/util"
+)
+
+const (
+	whhDBName = "whh.db"
+
+	// whhSnapshotSchemaVersion must be increased when changing the snapshot
+	// db schema or how the events are handled so the snapshot db will be
+	// rebuilt
+	whhSnapshotSchemaVersion = 1
+
+	// DefaultWebhookInitialBackoff is the wait before retrying a failed
+	// delivery, doubled at every failed attempt
+	DefaultWebhookInitialBackoff = 30 * time.Second
+	// DefaultWebhookMaxBackoff is the max wait between delivery attempts
+	DefaultWebhookMaxBackoff = 1 * time.Hour
+	// DefaultWebhookMaxAttempts is the number of delivery attempts before an
+	// event is recorded as a webhook dead letter
+	DefaultWebhookMaxAttempts = 10
+
+	webhookDeliveryTimeout = 10 * time.Second
+
+	// WebhookSignatureHeader contains the hex encoded HMAC-SHA256 of the
+	// payload computed with the webhook secret, prefixed by "sha256="
+	WebhookSignatureHeader = "X-Sircles-Signature"
+	WebhookEventHeader     = "X-Sircles-Event"
+	WebhookDeliveryHeader  = "X-Sircles-Delivery"
+)
+
+func newWebhookHandlerDB(dataDir string) (*db.DB, error) {
+	return db.NewDB("sqlite3", filepath.Join(dataDir, whhDBName))
+}
+
+// WebhookPayload is the JSON payload POSTed to the webhooks
+type WebhookPayload struct {
+	ID             util.ID     `json:"id"`
+	SequenceNumber int64       `json:"sequenceNumber"`
+	EventType      string      `json:"eventType"`
+	AggregateType  string      `json:"aggregateType"`
+	AggregateID    string      `json:"aggregateID"`
+	Timestamp      time.Time   `json:"timestamp"`
+	IssuerID       *util.ID    `json:"issuerID,omitempty"`
+	IssuerTokenID  *util.ID    `json:"issuerTokenID,omitempty"`
+	Data           interface{} `json:"data"`
+}
+
+// WebhookHandler delivers the events to the registered webhooks.
+//
+// Every event matching a webhook event types is queued as a delivery in the
+// snapshot db. The deliveries of a webhook are done in events order: a failed
+// delivery is retried with an exponential backoff and blocks the next
+// deliveries of the same webhook. After maxAttempts failed attempts the
+// delivery is recorded as a webhook dead letter.
+//
+// The event store is the source of truth of the delivery state: the snapshot
+// db only keeps the pending deliveries derived from the events. An abandoned
+// delivery is removed from the snapshot db only when handling its dead letter
+// event, so a failure between the dead letter write and the snapshot db update
+// won't record the dead letter twice.
+//
+// Only the events written after the snapshot db creation are delivered, so
+// the events history isn't delivered again when the snapshot db is rebuilt.
+type WebhookHandler struct {
+	dataDir       string
+	es            *eventstore.EventStore
+	uidGenerator  common.UIDGenerator
+	timeGenerator common.TimeGenerator
+	client        *http.Client
+
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	maxAttempts    int
+}
+
+func NewWebhookHandler(dataDir string, es *eventstore.EventStore, uidGenerator common.UIDGenerator, timeGenerator common.TimeGenerator) (*WebhookHandler, error) {
+	ldb, err := newWebhookHandlerDB(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	defer ldb.Close()
+
+	h := &WebhookHandler{
+		dataDir:        dataDir,
+		es:             es,
+		uidGenerator:   uidGenerator,
+		timeGenerator:  timeGenerator,
+		client:         &http.Client{Timeout: webhookDeliveryTimeout},
+		initialBackoff: DefaultWebhookInitialBackoff,
+		maxBackoff:     DefaultWebhookMaxBackoff,
+		maxAttempts:    DefaultWebhookMaxAttempts,
+	}
+
+	if err := initSnapshot(ldb, es, whhSnapshotSchemaVersion, whhDBCreateStmts, h.SequenceNumber); err != nil {
+		return nil, err
+	}
+
+	err = ldb.Do(func(tx *db.Tx) error {
+		return h.initDeliverFrom(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+func (h *WebhookHandler) Name() string {
+	return "webhookHandler"
+}
+
+func (h *WebhookHandler) HandleEvents() error {
+	log.Debugf("whh handleEvents")
+	ldb, err := newWebhookHandlerDB(h.dataDir)
+	if err != nil {
+		return err
+	}
+	defer ldb.Close()
+
+	for {
+		for {
+			var n int
+			err := ldb.Do(func(tx *db.Tx) error {
+				var err error
+				n, err = h.updateSnapshot(tx)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			if n == 0 {
+				break
+			}
+		}
+
+		// when dead letters were recorded, handle their events to remove the
+		// abandoned deliveries and continue with the next ones
+		deadLetters, err := h.deliver(ldb)
+		if err != nil {
+			return err
+		}
+		if !deadLetters {
+			return nil
+		}
+	}
+}
+
+func (h *WebhookHandler) updateSnapshot(tx *db.Tx) (int, error) {
+	log.Debugf("updateSnapshot")
+
+	sn, err := h.SequenceNumber(tx)
+	if err != nil {
+		return 0, err
+	}
+	log.Debugf("sn: %d", sn)
+
+	deliverFrom, err := h.deliverFrom(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	events, err := h.es.GetAllEvents(sn+1, 100)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, e := range events {
+		if err := h.handleEvent(tx, e, deliverFrom); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(events), nil
+}
+
+func (h *WebhookHandler) handleEvent(tx *db.Tx, event *eventstore.StoredEvent, deliverFrom int64) error {
+	log.Debugf("event: %v", event)
+
+	data, err := ep.UnmarshalData(event)
+	if err != nil {
+		return err
+	}
+
+	switch ep.EventType(event.EventType) {
+	case ep.EventTypeWebhookCreated:
+		data := data.(*ep.EventWebhookCreated)
+		webhookID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+		if err := h.insertWebhook(tx, &webhook{id: webhookID, url: data.URL, eventTypes: data.EventTypes, secret: data.Secret}); err != nil {
+			return err
+		}
+
+	case ep.EventTypeWebhookUpdated:
+		data := data.(*ep.EventWebhookUpdated)
+		webhookID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+		if err := h.insertWebhook(tx, &webhook{id: webhookID, url: data.URL, eventTypes: data.EventTypes, secret: data.Secret}); err != nil {
+			return err
+		}
+
+	case ep.EventTypeWebhookDeleted:
+		webhookID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+		if err := h.deleteWebhook(tx, webhookID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeWebhookDeliveryFailed:
+		data := data.(*ep.EventWebhookDeliveryFailed)
+		webhookID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+		if err := h.deleteWebhookEventDelivery(tx, webhookID, data.EventID); err != nil {
+			return err
+		}
+
+	default:
+		if event.SequenceNumber >= deliverFrom && ep.IsWebhookDeliverable(ep.EventType(event.EventType)) {
+			if err := h.queueDeliveries(tx, event, data); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := h.updateSequenceNumber(tx, event.SequenceNumber); err != nil {
+		return err
+	}
+	if err := db.UpdateSnapshotLastEventID(tx, event.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// queueDeliveries queues a delivery of the event for every webhook
+// subscribed to its type
+func (h *WebhookHandler) queueDeliveries(tx *db.Tx, event *eventstore.StoredEvent, data interface{}) error {
+	webhooks, err := h.webhooks(tx)
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	for _, w := range webhooks {
+		if !w.hasEventType(event.EventType) {
+			continue
+		}
+
+		if payload == nil {
+			md, err := ep.UnmarshalMetaData(event)
+			if err != nil {
+				return err
+			}
+			payload, err = json.Marshal(&WebhookPayload{
+				ID:             event.ID,
+				SequenceNumber: event.SequenceNumber,
+				EventType:      event.EventType,
+				AggregateType:  event.Category,
+				AggregateID:    event.StreamID,
+				Timestamp:      event.Timestamp,
+				IssuerID:       md.CommandIssuerID,
+				IssuerTokenID:  md.CommandIssuerTokenID,
+				Data:           data,
+			})
+			if err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
+		d := &delivery{
+			id:             h.uidGenerator.UUID(""),
+			webhookID:      w.id,
+			eventID:        event.ID,
+			sequenceNumber: event.SequenceNumber,
+			eventType:      event.EventType,
+			payload:        payload,
+		}
+		if err := h.insertDelivery(tx, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliver executes the pending deliveries of every webhook. It reports if
+// some dead letters were recorded.
+func (h *WebhookHandler) deliver(ldb *db.DB) (bool, error) {
+	var webhooks []*webhook
+	err := ldb.Do(func(tx *db.Tx) error {
+		var err error
+		webhooks, err = h.webhooks(tx)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	deadLetters := false
+	for _, w := range webhooks {
+		deadLetter, err := h.deliverWebhook(ldb, w)
+		if err != nil {
+			return false, err
+		}
+		deadLetters = deadLetters || deadLetter
+	}
+	return deadLetters, nil
+}
+
+// deliverWebhook executes the webhook pending deliveries in order, stopping
+// at the first failed delivery or at the first delivery recorded as a dead
+// letter. It reports if a dead letter was recorded.
+func (h *WebhookHandler) deliverWebhook(ldb *db.DB, w *webhook) (bool, error) {
+	for {
+		var d *delivery
+		err := ldb.Do(func(tx *db.Tx) error {
+			var err error
+			d, err = h.nextDelivery(tx, w.id)
+			return err
+		})
+		if err != nil {
+			return false, err
+		}
+		if d == nil {
+			return false, nil
+		}
+
+		// a previous dead letter recording failed
+		if d.attempts >= h.maxAttempts {
+			if err := h.recordDeliveryFailure(w, d); err != nil {
+				return false, err
+			}
+			return true, nil
+		}
+
+		now := h.timeGenerator.Now()
+		if d.nextAttempt.After(now) {
+			return false, nil
+		}
+
+		derr := h.post(w, d)
+		if derr == nil {
+			err := ldb.Do(func(tx *db.Tx) error {
+				return h.deleteDelivery(tx, d.id)
+			})
+			if err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		d.attempts++
+		d.lastError = derr.Error()
+		log.Infof("webhook %s delivery of event %s failed (attempt %d): %v", w.id, d.eventID, d.attempts, derr)
+
+		d.nextAttempt = now.Add(h.backoff(d.attempts))
+		err = ldb.Do(func(tx *db.Tx) error {
+			return h.updateDelivery(tx, d)
+		})
+		if err != nil {
+			return false, err
+		}
+
+		if d.attempts >= h.maxAttempts {
+			// the delivery will be removed when handling the dead letter
+			// event
+			if err := h.recordDeliveryFailure(w, d); err != nil {
+				return false, err
+			}
+			return true, nil
+		}
+		return false, nil
+	}
+}
+
+// backoff returns the wait before the next attempt after the provided number
+// of failed attempts
+func (h *WebhookHandler) backoff(attempts int) time.Duration {
+	backoff := h.initialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= h.maxBackoff {
+			return h.maxBackoff
+		}
+	}
+	return backoff
+}
+
+// WebhookSignature returns the signature of the payload computed with the
+// webhook secret as reported in the WebhookSignatureHeader
+func WebhookSignature(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (h *WebhookHandler) post(w *webhook, d *delivery) error {
+	req, err := http.NewRequest("POST", w.url, bytes.NewReader(d.payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, d.eventType)
+	req.Header.Set(WebhookDeliveryHeader, d.id.String())
+	req.Header.Set(WebhookSignatureHeader, WebhookSignature(w.secret, d.payload))
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// drain the body to reuse the connection
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// recordDeliveryFailure records the failed delivery as a webhook dead letter
+func (h *WebhookHandler) recordDeliveryFailure(w *webhook, d *delivery) error {
+	wr := aggregate.NewWebhookRepository(h.es, h.uidGenerator)
+	wa, err := wr.Load(w.id)
+	if err != nil {
+		return err
+	}
+
+	correlationID := h.uidGenerator.UUID("")
+	causationID := h.uidGenerator.UUID("")
+	command := commands.NewCommand(commands.CommandTypeRecordWebhookDeliveryFailure, correlationID, causationID, util.NilID, &commands.RecordWebhookDeliveryFailure{
+		EventID:        d.eventID,
+		SequenceNumber: d.sequenceNumber,
+		EventType:      d.eventType,
+		Attempts:       d.attempts,
+		LastError:      d.lastError,
+	})
+
+	events, err := wa.HandleCommand(command)
+	if err != nil {
+		return err
+	}
+
+	groupID := h.uidGenerator.UUID("")
+	eventsData, err := ep.GenEventData(events, &correlationID, &causationID, &groupID, nil, nil)
+	if err != nil {
+		return err
+	}
+	_, err = h.es.WriteEvents(eventsData, wa.AggregateType().String(), wa.ID(), wa.Version())
+	return err
+}
+
+// WebhookHandler snapshot db
+var whhDBCreateStmts = []string{
+	// eventtypes is a json array
+	"create table if not exists webhook (id uuid, url varchar, eventtypes varchar, secret varchar, PRIMARY KEY (id))",
+	// nextattempt is saved as unix time in nanoseconds
+	"create table if not exists delivery (id uuid, webhookid uuid, eventid uuid, sequencenumber bigint, eventtype varchar, payload bytea, attempts int, nextattempt bigint, lasterror varchar, PRIMARY KEY (id))",
+	"create index if not exists delivery_webhookid on delivery(webhookid, sequencenumber)",
+	// deliverfrom is the sequence number of the first event to deliver
+	"create table if not exists deliverfrom (sequencenumber bigint)",
+	"create table if not exists sequencenumber (sequencenumber bigint)",
+}
+
+var (
+	webhookInsert  = sb.Insert("webhook").Columns("id", "url", "eventtypes", "secret")
+	webhookDelete  = sb.Delete("webhook")
+	deliveryInsert = sb.Insert("delivery").Columns("id", "webhookid", "eventid", "sequencenumber", "eventtype", "payload", "attempts", "nextattempt", "lasterror")
+	deliveryDelete = sb.Delete("delivery")
+)
+
+type webhook struct {
+	id         util.ID
+	url        string
+	eventTypes []string
+	secret     string
+}
+
+func (w *webhook) hasEventType(eventType string) bool {
+	for _, et := range w.eventTypes {
+		if et == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type delivery struct {
+	id             util.ID
+	webhookID      util.ID
+	eventID        util.ID
+	sequenceNumber int64
+	eventType      string
+	payload        []byte
+	attempts       int
+	nextAttempt    time.Time
+	lastError      string
+}
+
+// initDeliverFrom sets, on a new snapshot db, the first event to deliver to
+// the event following the current last event
+func (h *WebhookHandler) initDeliverFrom(tx *db.Tx) error {
+	var n int
+	err := tx.Do(func(tx *db.WrappedTx) error {
+		return tx.QueryRow("select count(*) from deliverfrom").Scan(&n)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to execute query")
+	}
+	if n > 0 {
+		return nil
+	}
+
+	lastSn, err := h.es.LastSequenceNumber()
+	if err != nil {
+		return err
+	}
+
+	q, args, err := sb.Insert("deliverfrom").Columns("sequencenumber").Values(lastSn + 1).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to insert deliverfrom: %v", lastSn+1))
+	}
+	return nil
+}
+
+func (h *WebhookHandler) deliverFrom(tx *db.Tx) (int64, error) {
+	var sn int64
+	err := tx.Do(func(tx *db.WrappedTx) error {
+		return tx.QueryRow("select sequencenumber from deliverfrom limit 1").Scan(&sn)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read deliverfrom")
+	}
+	return sn, nil
+}
+
+func (h *WebhookHandler) webhooks(tx *db.Tx) ([]*webhook, error) {
+	q, args, err := sb.Select("id", "url", "eventtypes", "secret").From("webhook").OrderBy("id").ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	webhooks := []*webhook{}
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		rows, err := tx.Query(q, args...)
+		if err != nil {
+			return errors.Wrap(err, "failed to execute query")
+		}
+		for rows.Next() {
+			var w webhook
+			var eventTypes string
+			if err := rows.Scan(&w.id, &w.url, &eventTypes, &w.secret); err != nil {
+				rows.Close()
+				return errors.Wrap(err, "failed to scan rows")
+			}
+			if err := json.Unmarshal([]byte(eventTypes), &w.eventTypes); err != nil {
+				rows.Close()
+				return errors.Wrap(err, "failed to unmarshal webhook event types")
+			}
+			webhooks = append(webhooks, &w)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get webhooks")
+	}
+	return webhooks, nil
+}
+
+func (h *WebhookHandler) insertWebhook(tx *db.Tx, w *webhook) error {
+	eventTypes, err := json.Marshal(w.eventTypes)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal webhook event types")
+	}
+
+	q, args, err := webhookDelete.Where(sq.Eq{"id": w.id}).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to delete webhook %s", w.id))
+	}
+
+	q, args, err = webhookInsert.Values(w.id, w.url, string(eventTypes), w.secret).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to insert webhook %s", w.id))
+	}
+	return nil
+}
+
+// deleteWebhook removes the webhook and discards its pending deliveries
+func (h *WebhookHandler) deleteWebhook(tx *db.Tx, webhookID util.ID) error {
+	q, args, err := webhookDelete.Where(sq.Eq{"id": webhookID}).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to delete webhook %s", webhookID))
+	}
+
+	q, args, err = deliveryDelete.Where(sq.Eq{"webhookid": webhookID}).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to delete webhook %s deliveries", webhookID))
+	}
+	return nil
+}
+
+// nextDelivery returns the webhook pending delivery of the oldest event
+func (h *WebhookHandler) nextDelivery(tx *db.Tx, webhookID util.ID) (*delivery, error) {
+	q, args, err := sb.Select("id", "webhookid", "eventid", "sequencenumber", "eventtype", "payload", "attempts", "nextattempt", "lasterror").From("delivery").Where(sq.Eq{"webhookid": webhookID}).OrderBy("sequencenumber").Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	var d *delivery
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		rows, err := tx.Query(q, args...)
+		if err != nil {
+			return errors.Wrap(err, "failed to execute query")
+		}
+		for rows.Next() {
+			d = &delivery{}
+			var nextAttempt int64
+			if err := rows.Scan(&d.id, &d.webhookID, &d.eventID, &d.sequenceNumber, &d.eventType, &d.payload, &d.attempts, &nextAttempt, &d.lastError); err != nil {
+				rows.Close()
+				return errors.Wrap(err, "failed to scan rows")
+			}
+			d.nextAttempt = time.Unix(0, nextAttempt)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to get webhook %s deliveries", webhookID))
+	}
+	return d, nil
+}
+
+func (h *WebhookHandler) insertDelivery(tx *db.Tx, d *delivery) error {
+	q, args, err := deliveryInsert.Values(d.id, d.webhookID, d.eventID, d.sequenceNumber, d.eventType, d.payload, d.attempts, d.nextAttempt.UnixNano(), d.lastError).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to insert delivery %s", d.id))
+	}
+	return nil
+}
+
+func (h *WebhookHandler) updateDelivery(tx *db.Tx, d *delivery) error {
+	q, args, err := sb.Update("delivery").Set("attempts", d.attempts).Set("nextattempt", d.nextAttempt.UnixNano()).Set("lasterror", d.lastError).Where(sq.Eq{"id": d.id}).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to update delivery %s", d.id))
+	}
+	return nil
+}
+
+func (h *WebhookHandler) deleteDelivery(tx *db.Tx, deliveryID util.ID) error {
+	q, args, err := deliveryDelete.Where(sq.Eq{"id": deliveryID}).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to delete delivery %s", deliveryID))
+	}
+	return nil
+}
+
+func (h *WebhookHandler) deleteWebhookEventDelivery(tx *db.Tx, webhookID, eventID util.ID) error {
+	q, args, err := deliveryDelete.Where(sq.Eq{"webhookid": webhookID, "eventid": eventID}).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to delete webhook %s delivery of event %s", webhookID, eventID))
+	}
+	return nil
+}
+
+func (h *WebhookHandler) SequenceNumber(tx *db.Tx) (int64, error) {
+	var sn int64
+	err := tx.Do(func(tx *db.WrappedTx) error {
+		return tx.QueryRow("select sequencenumber from sequencenumber limit 1").Scan(&sn)
+	})
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return sn, nil
+}
+
+func (h *WebhookHandler) updateSequenceNumber(tx *db.Tx, sn int64) error {
+	q, args, err := sequenceNumberDelete.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to delete sequencenumber: %v", sn))
+	}
+
+	q, args, err = sequenceNumberInsert.Values(sn).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	err = tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to insert sequencenumber: %v", sn))
+	}
+	return nil
+}
@@ -0,0 +1,397 @@
+package eventhandler
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sorintlab/sircles/aggregate"
+	"github.com/sorintlab/sircles/command/commands"
+	"github.com/sorintlab/sircles/common"
+	"github.com/sorintlab/sircles/db"
+	ep "github.com/sorintlab/sircles/events"
+	"github.com/sorintlab/sircles/eventstore"
+	ln "github.com/sorintlab/sircles/listennotify"
+	"github.com/sorintlab/sircles/util"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+type testTimeGenerator struct {
+	m   sync.Mutex
+	now time.Time
+}
+
+func (tg *testTimeGenerator) Now() time.Time {
+	tg.m.Lock()
+	defer tg.m.Unlock()
+	return tg.now
+}
+
+func (tg *testTimeGenerator) Add(d time.Duration) {
+	tg.m.Lock()
+	defer tg.m.Unlock()
+	tg.now = tg.now.Add(d)
+}
+
+type testRequest struct {
+	header  http.Header
+	body    []byte
+	payload *WebhookPayload
+}
+
+// testWebhookServer records the received requests, replying with the provided
+// status codes (200 when there're no more status codes)
+type testWebhookServer struct {
+	*httptest.Server
+
+	m           sync.Mutex
+	requests    []*testRequest
+	statusCodes []int
+}
+
+func newTestWebhookServer(statusCodes ...int) *testWebhookServer {
+	s := &testWebhookServer{statusCodes: statusCodes}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var payload WebhookPayload
+		json.Unmarshal(body, &payload)
+
+		s.m.Lock()
+		defer s.m.Unlock()
+		s.requests = append(s.requests, &testRequest{header: r.Header, body: body, payload: &payload})
+		statusCode := http.StatusOK
+		if len(s.statusCodes) > 0 {
+			statusCode = s.statusCodes[0]
+			s.statusCodes = s.statusCodes[1:]
+		}
+		w.WriteHeader(statusCode)
+	}))
+	return s
+}
+
+func (s *testWebhookServer) Requests() []*testRequest {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return append([]*testRequest{}, s.requests...)
+}
+
+type webhookTestEnv struct {
+	es           *eventstore.EventStore
+	tg           *testTimeGenerator
+	uidGenerator common.UIDGenerator
+	dataDir      string
+}
+
+func setupWebhookTest(t *testing.T) (*webhookTestEnv, func()) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	esDB, err := db.NewDB("sqlite3", filepath.Join(tmpDir, "esdb"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := esDB.Migrate("eventstore", eventstore.Migrations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tg := &testTimeGenerator{now: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)}
+	es := eventstore.NewEventStore(esDB, ln.NewLocalNotifierFactory(ln.NewLocalListenNotify()))
+	es.SetTimeGenerator(tg)
+
+	env := &webhookTestEnv{
+		es:           es,
+		tg:           tg,
+		uidGenerator: &common.DefaultUidGenerator{},
+		dataDir:      tmpDir,
+	}
+	return env, func() {
+		esDB.Close()
+		os.RemoveAll(tmpDir)
+	}
+}
+
+func (env *webhookTestEnv) newHandler(t *testing.T) *WebhookHandler {
+	h, err := NewWebhookHandler(env.dataDir, env.es, env.uidGenerator, env.tg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h.initialBackoff = time.Minute
+	h.maxBackoff = 10 * time.Minute
+	h.maxAttempts = 3
+	return h
+}
+
+func (env *webhookTestEnv) createWebhook(t *testing.T, url string, eventTypes ...string) util.ID {
+	webhookID := env.uidGenerator.UUID("")
+	w := aggregate.NewWebhook(env.uidGenerator, webhookID)
+	command := commands.NewCommand(commands.CommandTypeCreateWebhook, env.uidGenerator.UUID(""), env.uidGenerator.UUID(""), util.NilID, &commands.CreateWebhook{
+		URL:        url,
+		EventTypes: eventTypes,
+		Secret:     testWebhookSecret,
+	})
+	if _, _, err := aggregate.ExecCommand(command, w, env.es, env.uidGenerator); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return webhookID
+}
+
+func (env *webhookTestEnv) createTension(t *testing.T, title string) *eventstore.StoredEvent {
+	issuerID := env.uidGenerator.UUID("")
+	eventsData, err := ep.GenEventData([]ep.Event{&ep.EventTensionCreated{Title: title}}, nil, nil, nil, &issuerID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	storedEvents, err := env.es.WriteEvents(eventsData, aggregate.TensionAggregate.String(), env.uidGenerator.UUID("").String(), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return storedEvents[0]
+}
+
+func (env *webhookTestEnv) deliveryFailures(t *testing.T, webhookID util.ID) []*ep.EventWebhookDeliveryFailed {
+	events, err := env.es.GetEvents(webhookID.String(), 0, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var failures []*ep.EventWebhookDeliveryFailed
+	for _, e := range events {
+		if ep.EventType(e.EventType) != ep.EventTypeWebhookDeliveryFailed {
+			continue
+		}
+		data, err := ep.UnmarshalData(e)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		failures = append(failures, data.(*ep.EventWebhookDeliveryFailed))
+	}
+	return failures
+}
+
+func handleEvents(t *testing.T, h *WebhookHandler) {
+	if err := h.HandleEvents(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWebhookHandlerDelivery(t *testing.T) {
+	env, cleanup := setupWebhookTest(t)
+	defer cleanup()
+
+	ts := newTestWebhookServer()
+	defer ts.Close()
+
+	// events written before the handler creation aren't delivered
+	env.createWebhook(t, ts.URL, ep.EventTypeTensionCreated.String())
+	env.createTension(t, "old tension")
+
+	h := env.newHandler(t)
+
+	otherTs := newTestWebhookServer()
+	defer otherTs.Close()
+	env.createWebhook(t, otherTs.URL, ep.EventTypeRoleCreated.String())
+
+	event := env.createTension(t, "tension01")
+
+	handleEvents(t, h)
+
+	if n := len(otherTs.Requests()); n != 0 {
+		t.Fatalf("expected 0 requests, got %d", n)
+	}
+	requests := ts.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+	req := requests[0]
+
+	if eventType := req.header.Get(WebhookEventHeader); eventType != ep.EventTypeTensionCreated.String() {
+		t.Fatalf("expected event type header %q, got %q", ep.EventTypeTensionCreated, eventType)
+	}
+	if req.header.Get(WebhookDeliveryHeader) == "" {
+		t.Fatalf("empty delivery header")
+	}
+	if signature := req.header.Get(WebhookSignatureHeader); signature != WebhookSignature(testWebhookSecret, req.body) {
+		t.Fatalf("wrong signature %q", signature)
+	}
+
+	payload := req.payload
+	if payload.ID != event.ID || payload.SequenceNumber == 0 || payload.AggregateType != aggregate.TensionAggregate.String() || payload.AggregateID != event.StreamID {
+		t.Fatalf("unexpected payload: %s", req.body)
+	}
+	if payload.IssuerID == nil {
+		t.Fatalf("expected issuer id in payload: %s", req.body)
+	}
+	data, ok := payload.Data.(map[string]interface{})
+	if !ok || data["Title"] != "tension01" {
+		t.Fatalf("unexpected payload data: %s", req.body)
+	}
+
+	// already delivered events aren't delivered again
+	handleEvents(t, h)
+	if n := len(ts.Requests()); n != 1 {
+		t.Fatalf("expected 1 request, got %d", n)
+	}
+}
+
+func TestWebhookHandlerRetries(t *testing.T) {
+	env, cleanup := setupWebhookTest(t)
+	defer cleanup()
+
+	ts := newTestWebhookServer(http.StatusInternalServerError, http.StatusServiceUnavailable)
+	defer ts.Close()
+
+	h := env.newHandler(t)
+	env.createWebhook(t, ts.URL, ep.EventTypeTensionCreated.String())
+	event01 := env.createTension(t, "tension01")
+	event02 := env.createTension(t, "tension02")
+
+	// the failed delivery blocks the next one
+	handleEvents(t, h)
+	if n := len(ts.Requests()); n != 1 {
+		t.Fatalf("expected 1 request, got %d", n)
+	}
+
+	// not retried before the backoff
+	env.tg.Add(59 * time.Second)
+	handleEvents(t, h)
+	if n := len(ts.Requests()); n != 1 {
+		t.Fatalf("expected 1 request, got %d", n)
+	}
+
+	env.tg.Add(time.Second)
+	handleEvents(t, h)
+	if n := len(ts.Requests()); n != 2 {
+		t.Fatalf("expected 2 requests, got %d", n)
+	}
+
+	// the backoff is doubled
+	env.tg.Add(time.Minute)
+	handleEvents(t, h)
+	if n := len(ts.Requests()); n != 2 {
+		t.Fatalf("expected 2 requests, got %d", n)
+	}
+
+	env.tg.Add(time.Minute)
+	handleEvents(t, h)
+	requests := ts.Requests()
+	if len(requests) != 4 {
+		t.Fatalf("expected 4 requests, got %d", len(requests))
+	}
+	expectedEventIDs := []util.ID{event01.ID, event01.ID, event01.ID, event02.ID}
+	for i, req := range requests {
+		if req.payload.ID != expectedEventIDs[i] {
+			t.Fatalf("expected event %s in request %d, got %s", expectedEventIDs[i], i, req.payload.ID)
+		}
+	}
+}
+
+func TestWebhookHandlerDeadLetter(t *testing.T) {
+	env, cleanup := setupWebhookTest(t)
+	defer cleanup()
+
+	ts := newTestWebhookServer(http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+	defer ts.Close()
+
+	h := env.newHandler(t)
+	webhookID := env.createWebhook(t, ts.URL, ep.EventTypeTensionCreated.String())
+	event01 := env.createTension(t, "tension01")
+	event02 := env.createTension(t, "tension02")
+
+	handleEvents(t, h)
+	env.tg.Add(time.Minute)
+	handleEvents(t, h)
+	env.tg.Add(2 * time.Minute)
+	// the third failed attempt records the dead letter and the next event is
+	// delivered
+	handleEvents(t, h)
+
+	requests := ts.Requests()
+	if len(requests) != 4 {
+		t.Fatalf("expected 4 requests, got %d", len(requests))
+	}
+	if requests[3].payload.ID != event02.ID {
+		t.Fatalf("expected event %s in last request, got %s", event02.ID, requests[3].payload.ID)
+	}
+
+	failures := env.deliveryFailures(t, webhookID)
+	if len(failures) != 1 {
+		t.Fatalf("expected 1 delivery failure, got %d", len(failures))
+	}
+	f := failures[0]
+	if f.EventID != event01.ID || f.SequenceNumber != requests[0].payload.SequenceNumber || f.DeliveryEventType != ep.EventTypeTensionCreated.String() || f.Attempts != 3 || f.LastError != "unexpected status code 500" {
+		t.Fatalf("unexpected delivery failure: %#v", f)
+	}
+
+	// the dead letter event isn't delivered
+	handleEvents(t, h)
+	if n := len(ts.Requests()); n != 4 {
+		t.Fatalf("expected 4 requests, got %d", n)
+	}
+}
+
+func TestWebhookHandlerDeadLetterAlreadyRecorded(t *testing.T) {
+	env, cleanup := setupWebhookTest(t)
+	defer cleanup()
+
+	ts := newTestWebhookServer(http.StatusInternalServerError)
+	defer ts.Close()
+
+	h := env.newHandler(t)
+	webhookID := env.createWebhook(t, ts.URL, ep.EventTypeTensionCreated.String())
+	event01 := env.createTension(t, "tension01")
+	event02 := env.createTension(t, "tension02")
+
+	handleEvents(t, h)
+	// the stored events returned by WriteEvents don't have the sequence number
+	sequenceNumber := ts.Requests()[0].payload.SequenceNumber
+
+	// simulate a dead letter written to the event store without the snapshot
+	// db being updated
+	recordFailure := func() {
+		command := commands.NewCommand(commands.CommandTypeRecordWebhookDeliveryFailure, env.uidGenerator.UUID(""), env.uidGenerator.UUID(""), util.NilID, &commands.RecordWebhookDeliveryFailure{
+			EventID:        event01.ID,
+			SequenceNumber: sequenceNumber,
+			EventType:      event01.EventType,
+			Attempts:       3,
+			LastError:      "unexpected status code 500",
+		})
+		wr := aggregate.NewWebhookRepository(env.es, env.uidGenerator)
+		w, err := wr.Load(webhookID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, _, err := aggregate.ExecCommand(command, w, env.es, env.uidGenerator); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	recordFailure()
+	// the same dead letter isn't recorded twice
+	recordFailure()
+	if n := len(env.deliveryFailures(t, webhookID)); n != 1 {
+		t.Fatalf("expected 1 delivery failure, got %d", n)
+	}
+
+	// the abandoned delivery is removed from the snapshot db without other
+	// attempts and the next event is delivered
+	env.tg.Add(time.Minute)
+	handleEvents(t, h)
+
+	requests := ts.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	if requests[1].payload.ID != event02.ID {
+		t.Fatalf("expected event %s in last request, got %s", event02.ID, requests[1].payload.ID)
+	}
+	if n := len(env.deliveryFailures(t, webhookID)); n != 1 {
+		t.Fatalf("expected 1 delivery failure, got %d", n)
+	}
+}
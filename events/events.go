@@ -7,6 +7,7 @@ import (
 
 	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
+	"github.com/sorintlab/sircles/change"
 	"github.com/sorintlab/sircles/eventstore"
 	"github.com/sorintlab/sircles/models"
 	"github.com/sorintlab/sircles/util"
@@ -22,9 +23,29 @@ type Event interface {
 	EventType() EventType
 }
 
+// UnmarshalData decodes the event data in the current event data type,
+// upcasting it if it was written with an older schema version
 func UnmarshalData(e *eventstore.StoredEvent) (interface{}, error) {
-	d := GetEventDataType(EventType(e.EventType))
-	if err := json.Unmarshal(e.Data, &d); err != nil {
+	eventType := EventType(e.EventType)
+
+	schemaVersion := 1
+	if len(e.MetaData) > 0 {
+		md, err := UnmarshalMetaData(e)
+		if err != nil {
+			return nil, err
+		}
+		if md.SchemaVersion > 0 {
+			schemaVersion = md.SchemaVersion
+		}
+	}
+
+	data, err := upcasters.Upcast(eventType, schemaVersion, e.Data)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to upcast event %s", e.ID))
+	}
+
+	d := GetEventDataType(eventType)
+	if err := json.Unmarshal(data, &d); err != nil {
 		return nil, errors.WithStack(err)
 	}
 
@@ -40,7 +61,7 @@ func UnmarshalMetaData(e *eventstore.StoredEvent) (*eventstore.EventMetaData, er
 	return md, nil
 }
 
-func GenEventData(events []Event, correlationID, causationID, groupID, issuerID *util.ID) ([]*eventstore.EventData, error) {
+func GenEventData(events []Event, correlationID, causationID, groupID, issuerID, issuerTokenID *util.ID) ([]*eventstore.EventData, error) {
 	eventsData := make([]*eventstore.EventData, len(events))
 	for i, e := range events {
 		data, err := json.Marshal(e)
@@ -50,10 +71,12 @@ func GenEventData(events []Event, correlationID, causationID, groupID, issuerID
 
 		// augment events with common metadata
 		md := &eventstore.EventMetaData{
-			CorrelationID:   correlationID,
-			CausationID:     causationID,
-			GroupID:         groupID,
-			CommandIssuerID: issuerID,
+			CorrelationID:        correlationID,
+			CausationID:          causationID,
+			GroupID:              groupID,
+			CommandIssuerID:      issuerID,
+			CommandIssuerTokenID: issuerTokenID,
+			SchemaVersion:        EventSchemaVersion(e.EventType()),
 		}
 		metaData, err := json.Marshal(md)
 		if err != nil {
@@ -90,6 +113,14 @@ const (
 	EventTypeRoleAccountabilityUpdated EventType = "RoleAccountabilityUpdated"
 	EventTypeRoleAccountabilityDeleted EventType = "RoleAccountabilityDeleted"
 
+	EventTypeRoleChecklistItemCreated EventType = "RoleChecklistItemCreated"
+	EventTypeRoleChecklistItemUpdated EventType = "RoleChecklistItemUpdated"
+	EventTypeRoleChecklistItemDeleted EventType = "RoleChecklistItemDeleted"
+
+	EventTypeRoleMetricCreated EventType = "RoleMetricCreated"
+	EventTypeRoleMetricUpdated EventType = "RoleMetricUpdated"
+	EventTypeRoleMetricDeleted EventType = "RoleMetricDeleted"
+
 	EventTypeRoleAdditionalContentSet EventType = "RoleAdditionalContentSet"
 
 	EventTypeRoleMemberAdded   EventType = "RoleMemberAdded"
@@ -114,10 +145,11 @@ const (
 	// Member Aggregate
 	EventTypeMemberCreated     EventType = "MemberCreated"
 	EventTypeMemberUpdated     EventType = "MemberUpdated"
-	EventTypeMemberDeleted     EventType = "MemberDeleted"
 	EventTypeMemberPasswordSet EventType = "MemberPasswordSet"
 	EventTypeMemberAvatarSet   EventType = "MemberAvatarSet"
 	EventTypeMemberMatchUIDSet EventType = "MemberMatchUIDSet"
+	EventTypeMemberDeactivated EventType = "MemberDeactivated"
+	EventTypeMemberReactivated EventType = "MemberReactivated"
 
 	// Tension Aggregate
 	EventTypeTensionCreated     EventType = "TensionCreated"
@@ -125,6 +157,67 @@ const (
 	EventTypeTensionRoleChanged EventType = "TensionRoleChanged"
 	EventTypeTensionClosed      EventType = "TensionClosed"
 
+	EventTypeTensionStatusChanged   EventType = "TensionStatusChanged"
+	EventTypeTensionAssigneeChanged EventType = "TensionAssigneeChanged"
+	EventTypeTensionMeetingChanged  EventType = "TensionMeetingChanged"
+	EventTypeTensionResolved        EventType = "TensionResolved"
+
+	// Proposal Aggregate
+	EventTypeProposalCreated   EventType = "ProposalCreated"
+	EventTypeProposalUpdated   EventType = "ProposalUpdated"
+	EventTypeProposalSubmitted EventType = "ProposalSubmitted"
+	EventTypeProposalObjected  EventType = "ProposalObjected"
+	EventTypeProposalAccepted  EventType = "ProposalAccepted"
+	EventTypeProposalWithdrawn EventType = "ProposalWithdrawn"
+
+	EventTypeProposalObjectionResolved  EventType = "ProposalObjectionResolved"
+	EventTypeProposalObjectionWithdrawn EventType = "ProposalObjectionWithdrawn"
+	EventTypeProposalConsented          EventType = "ProposalConsented"
+
+	// Project Aggregate
+	EventTypeProjectCreated       EventType = "ProjectCreated"
+	EventTypeProjectUpdated       EventType = "ProjectUpdated"
+	EventTypeProjectMemberChanged EventType = "ProjectMemberChanged"
+	EventTypeProjectStatusChanged EventType = "ProjectStatusChanged"
+
+	// Action Aggregate
+	EventTypeActionCreated       EventType = "ActionCreated"
+	EventTypeActionUpdated       EventType = "ActionUpdated"
+	EventTypeActionMemberChanged EventType = "ActionMemberChanged"
+	EventTypeActionStatusChanged EventType = "ActionStatusChanged"
+
+	// Meeting Aggregate
+	EventTypeMeetingCreated               EventType = "MeetingCreated"
+	EventTypeMeetingChecklistItemReported EventType = "MeetingChecklistItemReported"
+	EventTypeMeetingMetricReported        EventType = "MeetingMetricReported"
+
+	// Election Aggregate
+	EventTypeElectionCreated            EventType = "ElectionCreated"
+	EventTypeElectionCandidateNominated EventType = "ElectionCandidateNominated"
+	EventTypeElectionCompleted          EventType = "ElectionCompleted"
+
+	// RoleTemplate Aggregate
+	EventTypeRoleTemplateCreated          EventType = "RoleTemplateCreated"
+	EventTypeRoleTemplateUpdated          EventType = "RoleTemplateUpdated"
+	EventTypeRoleTemplateDeleted          EventType = "RoleTemplateDeleted"
+	EventTypeRoleTemplateInstanceLinked   EventType = "RoleTemplateInstanceLinked"
+	EventTypeRoleTemplateInstanceUnlinked EventType = "RoleTemplateInstanceUnlinked"
+
+	// Webhook Aggregate
+	EventTypeWebhookCreated        EventType = "WebhookCreated"
+	EventTypeWebhookUpdated        EventType = "WebhookUpdated"
+	EventTypeWebhookDeleted        EventType = "WebhookDeleted"
+	EventTypeWebhookDeliveryFailed EventType = "WebhookDeliveryFailed"
+
+	// Session Aggregate
+	EventTypeSessionCreated            EventType = "SessionCreated"
+	EventTypeSessionRefreshTokenIssued EventType = "SessionRefreshTokenIssued"
+	EventTypeSessionRevoked            EventType = "SessionRevoked"
+
+	// APIToken Aggregate
+	EventTypeAPITokenCreated EventType = "APITokenCreated"
+	EventTypeAPITokenRevoked EventType = "APITokenRevoked"
+
 	EventTypeMemberRequestHandlerStateUpdated EventType = "MemberRequestHandlerStateUpdated"
 
 	// MemberRequest Saga
@@ -136,101 +229,308 @@ const (
 )
 
 func GetEventDataType(eventType EventType) interface{} {
+	d, ok := newEventData(eventType)
+	if !ok {
+		panic(fmt.Errorf("unknown event type: %q", eventType))
+	}
+	return d
+}
+
+// newEventData returns a new event data for the provided event type and false
+// if the event type doesn't exist
+func newEventData(eventType EventType) (interface{}, bool) {
 	switch eventType {
 	case EventTypeRoleCreated:
-		return &EventRoleCreated{}
+		return &EventRoleCreated{}, true
 	case EventTypeRoleUpdated:
-		return &EventRoleUpdated{}
+		return &EventRoleUpdated{}, true
 	case EventTypeRoleDeleted:
-		return &EventRoleDeleted{}
+		return &EventRoleDeleted{}, true
 
 	case EventTypeRoleAdditionalContentSet:
-		return &EventRoleAdditionalContentSet{}
+		return &EventRoleAdditionalContentSet{}, true
 
 	case EventTypeRoleChangedParent:
-		return &EventRoleChangedParent{}
+		return &EventRoleChangedParent{}, true
 
 	case EventTypeRoleDomainCreated:
-		return &EventRoleDomainCreated{}
+		return &EventRoleDomainCreated{}, true
 	case EventTypeRoleDomainUpdated:
-		return &EventRoleDomainUpdated{}
+		return &EventRoleDomainUpdated{}, true
 	case EventTypeRoleDomainDeleted:
-		return &EventRoleDomainDeleted{}
+		return &EventRoleDomainDeleted{}, true
 
 	case EventTypeRoleAccountabilityCreated:
-		return &EventRoleAccountabilityCreated{}
+		return &EventRoleAccountabilityCreated{}, true
 	case EventTypeRoleAccountabilityUpdated:
-		return &EventRoleAccountabilityUpdated{}
+		return &EventRoleAccountabilityUpdated{}, true
 	case EventTypeRoleAccountabilityDeleted:
-		return &EventRoleAccountabilityDeleted{}
+		return &EventRoleAccountabilityDeleted{}, true
+
+	case EventTypeRoleChecklistItemCreated:
+		return &EventRoleChecklistItemCreated{}, true
+	case EventTypeRoleChecklistItemUpdated:
+		return &EventRoleChecklistItemUpdated{}, true
+	case EventTypeRoleChecklistItemDeleted:
+		return &EventRoleChecklistItemDeleted{}, true
+
+	case EventTypeRoleMetricCreated:
+		return &EventRoleMetricCreated{}, true
+	case EventTypeRoleMetricUpdated:
+		return &EventRoleMetricUpdated{}, true
+	case EventTypeRoleMetricDeleted:
+		return &EventRoleMetricDeleted{}, true
 
 	case EventTypeRoleMemberAdded:
-		return &EventRoleMemberAdded{}
+		return &EventRoleMemberAdded{}, true
 	case EventTypeRoleMemberUpdated:
-		return &EventRoleMemberUpdated{}
+		return &EventRoleMemberUpdated{}, true
 	case EventTypeRoleMemberRemoved:
-		return &EventRoleMemberRemoved{}
+		return &EventRoleMemberRemoved{}, true
 
 	case EventTypeCircleDirectMemberAdded:
-		return &EventCircleDirectMemberAdded{}
+		return &EventCircleDirectMemberAdded{}, true
 	case EventTypeCircleDirectMemberRemoved:
-		return &EventCircleDirectMemberRemoved{}
+		return &EventCircleDirectMemberRemoved{}, true
 
 	case EventTypeCircleLeadLinkMemberSet:
-		return &EventCircleLeadLinkMemberSet{}
+		return &EventCircleLeadLinkMemberSet{}, true
 	case EventTypeCircleLeadLinkMemberUnset:
-		return &EventCircleLeadLinkMemberUnset{}
+		return &EventCircleLeadLinkMemberUnset{}, true
 
 	case EventTypeCircleCoreRoleMemberSet:
-		return &EventCircleCoreRoleMemberSet{}
+		return &EventCircleCoreRoleMemberSet{}, true
 	case EventTypeCircleCoreRoleMemberUnset:
-		return &EventCircleCoreRoleMemberUnset{}
+		return &EventCircleCoreRoleMemberUnset{}, true
 
 	case EventTypeMemberChangeCreateRequested:
-		return &EventMemberChangeCreateRequested{}
+		return &EventMemberChangeCreateRequested{}, true
 	case EventTypeMemberChangeUpdateRequested:
-		return &EventMemberChangeUpdateRequested{}
+		return &EventMemberChangeUpdateRequested{}, true
 	case EventTypeMemberChangeSetMatchUIDRequested:
-		return &EventMemberChangeSetMatchUIDRequested{}
+		return &EventMemberChangeSetMatchUIDRequested{}, true
 	case EventTypeMemberChangeCompleted:
-		return &EventMemberChangeCompleted{}
+		return &EventMemberChangeCompleted{}, true
 
 	case EventTypeMemberCreated:
-		return &EventMemberCreated{}
+		return &EventMemberCreated{}, true
 	case EventTypeMemberUpdated:
-		return &EventMemberUpdated{}
+		return &EventMemberUpdated{}, true
 	case EventTypeMemberPasswordSet:
-		return &EventMemberPasswordSet{}
+		return &EventMemberPasswordSet{}, true
 	case EventTypeMemberAvatarSet:
-		return &EventMemberAvatarSet{}
+		return &EventMemberAvatarSet{}, true
 	case EventTypeMemberMatchUIDSet:
-		return &EventMemberMatchUIDSet{}
+		return &EventMemberMatchUIDSet{}, true
+	case EventTypeMemberDeactivated:
+		return &EventMemberDeactivated{}, true
+	case EventTypeMemberReactivated:
+		return &EventMemberReactivated{}, true
 
 	case EventTypeTensionCreated:
-		return &EventTensionCreated{}
+		return &EventTensionCreated{}, true
 	case EventTypeTensionUpdated:
-		return &EventTensionUpdated{}
+		return &EventTensionUpdated{}, true
 	case EventTypeTensionRoleChanged:
-		return &EventTensionRoleChanged{}
+		return &EventTensionRoleChanged{}, true
 	case EventTypeTensionClosed:
-		return &EventTensionClosed{}
+		return &EventTensionClosed{}, true
+	case EventTypeTensionStatusChanged:
+		return &EventTensionStatusChanged{}, true
+	case EventTypeTensionAssigneeChanged:
+		return &EventTensionAssigneeChanged{}, true
+	case EventTypeTensionMeetingChanged:
+		return &EventTensionMeetingChanged{}, true
+	case EventTypeTensionResolved:
+		return &EventTensionResolved{}, true
+
+	case EventTypeProposalCreated:
+		return &EventProposalCreated{}, true
+	case EventTypeProposalUpdated:
+		return &EventProposalUpdated{}, true
+	case EventTypeProposalSubmitted:
+		return &EventProposalSubmitted{}, true
+	case EventTypeProposalObjected:
+		return &EventProposalObjected{}, true
+	case EventTypeProposalAccepted:
+		return &EventProposalAccepted{}, true
+	case EventTypeProposalWithdrawn:
+		return &EventProposalWithdrawn{}, true
+	case EventTypeProposalObjectionResolved:
+		return &EventProposalObjectionResolved{}, true
+	case EventTypeProposalObjectionWithdrawn:
+		return &EventProposalObjectionWithdrawn{}, true
+	case EventTypeProposalConsented:
+		return &EventProposalConsented{}, true
+
+	case EventTypeProjectCreated:
+		return &EventProjectCreated{}, true
+	case EventTypeProjectUpdated:
+		return &EventProjectUpdated{}, true
+	case EventTypeProjectMemberChanged:
+		return &EventProjectMemberChanged{}, true
+	case EventTypeProjectStatusChanged:
+		return &EventProjectStatusChanged{}, true
+
+	case EventTypeActionCreated:
+		return &EventActionCreated{}, true
+	case EventTypeActionUpdated:
+		return &EventActionUpdated{}, true
+	case EventTypeActionMemberChanged:
+		return &EventActionMemberChanged{}, true
+	case EventTypeActionStatusChanged:
+		return &EventActionStatusChanged{}, true
+
+	case EventTypeMeetingCreated:
+		return &EventMeetingCreated{}, true
+	case EventTypeMeetingChecklistItemReported:
+		return &EventMeetingChecklistItemReported{}, true
+	case EventTypeMeetingMetricReported:
+		return &EventMeetingMetricReported{}, true
+
+	case EventTypeElectionCreated:
+		return &EventElectionCreated{}, true
+	case EventTypeElectionCandidateNominated:
+		return &EventElectionCandidateNominated{}, true
+	case EventTypeElectionCompleted:
+		return &EventElectionCompleted{}, true
+
+	case EventTypeRoleTemplateCreated:
+		return &EventRoleTemplateCreated{}, true
+	case EventTypeRoleTemplateUpdated:
+		return &EventRoleTemplateUpdated{}, true
+	case EventTypeRoleTemplateDeleted:
+		return &EventRoleTemplateDeleted{}, true
+	case EventTypeRoleTemplateInstanceLinked:
+		return &EventRoleTemplateInstanceLinked{}, true
+	case EventTypeRoleTemplateInstanceUnlinked:
+		return &EventRoleTemplateInstanceUnlinked{}, true
+
+	case EventTypeWebhookCreated:
+		return &EventWebhookCreated{}, true
+	case EventTypeWebhookUpdated:
+		return &EventWebhookUpdated{}, true
+	case EventTypeWebhookDeleted:
+		return &EventWebhookDeleted{}, true
+	case EventTypeWebhookDeliveryFailed:
+		return &EventWebhookDeliveryFailed{}, true
+
+	case EventTypeSessionCreated:
+		return &EventSessionCreated{}, true
+	case EventTypeSessionRefreshTokenIssued:
+		return &EventSessionRefreshTokenIssued{}, true
+	case EventTypeSessionRevoked:
+		return &EventSessionRevoked{}, true
+
+	case EventTypeAPITokenCreated:
+		return &EventAPITokenCreated{}, true
+	case EventTypeAPITokenRevoked:
+		return &EventAPITokenRevoked{}, true
 
 	case EventTypeMemberRequestHandlerStateUpdated:
-		return &EventMemberRequestHandlerStateUpdated{}
+		return &EventMemberRequestHandlerStateUpdated{}, true
 
 	case EventTypeMemberRequestSagaCompleted:
-		return &EventMemberRequestSagaCompleted{}
+		return &EventMemberRequestSagaCompleted{}, true
 
 	case EventTypeUniqueRegistryValueReserved:
-		return &EventUniqueRegistryValueReserved{}
+		return &EventUniqueRegistryValueReserved{}, true
 	case EventTypeUniqueRegistryValueReleased:
-		return &EventUniqueRegistryValueReleased{}
+		return &EventUniqueRegistryValueReleased{}, true
 
 	default:
-		panic(fmt.Errorf("unknown event type: %q", eventType))
+		return nil, false
 	}
 }
 
+// deliverableEventTypes are the domain event types that can be delivered to
+// webhooks. Events containing secrets (password hashes, webhook secrets,
+// sessions and refresh tokens, api tokens hashes) and internal events (member
+// change requests, sagas and unique registry state) must not be added.
+var deliverableEventTypes = map[EventType]struct{}{
+	EventTypeRoleCreated:                  {},
+	EventTypeRoleUpdated:                  {},
+	EventTypeRoleDeleted:                  {},
+	EventTypeRoleChangedParent:            {},
+	EventTypeRoleDomainCreated:            {},
+	EventTypeRoleDomainUpdated:            {},
+	EventTypeRoleDomainDeleted:            {},
+	EventTypeRoleAccountabilityCreated:    {},
+	EventTypeRoleAccountabilityUpdated:    {},
+	EventTypeRoleAccountabilityDeleted:    {},
+	EventTypeRoleChecklistItemCreated:     {},
+	EventTypeRoleChecklistItemUpdated:     {},
+	EventTypeRoleChecklistItemDeleted:     {},
+	EventTypeRoleMetricCreated:            {},
+	EventTypeRoleMetricUpdated:            {},
+	EventTypeRoleMetricDeleted:            {},
+	EventTypeRoleAdditionalContentSet:     {},
+	EventTypeRoleMemberAdded:              {},
+	EventTypeRoleMemberUpdated:            {},
+	EventTypeRoleMemberRemoved:            {},
+	EventTypeCircleDirectMemberAdded:      {},
+	EventTypeCircleDirectMemberRemoved:    {},
+	EventTypeCircleLeadLinkMemberSet:      {},
+	EventTypeCircleLeadLinkMemberUnset:    {},
+	EventTypeCircleCoreRoleMemberSet:      {},
+	EventTypeCircleCoreRoleMemberUnset:    {},
+	EventTypeMemberCreated:                {},
+	EventTypeMemberUpdated:                {},
+	EventTypeMemberAvatarSet:              {},
+	EventTypeMemberMatchUIDSet:            {},
+	EventTypeMemberDeactivated:            {},
+	EventTypeMemberReactivated:            {},
+	EventTypeTensionCreated:               {},
+	EventTypeTensionUpdated:               {},
+	EventTypeTensionRoleChanged:           {},
+	EventTypeTensionClosed:                {},
+	EventTypeTensionStatusChanged:         {},
+	EventTypeTensionAssigneeChanged:       {},
+	EventTypeTensionMeetingChanged:        {},
+	EventTypeTensionResolved:              {},
+	EventTypeProposalCreated:              {},
+	EventTypeProposalUpdated:              {},
+	EventTypeProposalSubmitted:            {},
+	EventTypeProposalObjected:             {},
+	EventTypeProposalAccepted:             {},
+	EventTypeProposalWithdrawn:            {},
+	EventTypeProposalObjectionResolved:    {},
+	EventTypeProposalObjectionWithdrawn:   {},
+	EventTypeProposalConsented:            {},
+	EventTypeProjectCreated:               {},
+	EventTypeProjectUpdated:               {},
+	EventTypeProjectMemberChanged:         {},
+	EventTypeProjectStatusChanged:         {},
+	EventTypeActionCreated:                {},
+	EventTypeActionUpdated:                {},
+	EventTypeActionMemberChanged:          {},
+	EventTypeActionStatusChanged:          {},
+	EventTypeMeetingCreated:               {},
+	EventTypeMeetingChecklistItemReported: {},
+	EventTypeMeetingMetricReported:        {},
+	EventTypeElectionCreated:              {},
+	EventTypeElectionCandidateNominated:   {},
+	EventTypeElectionCompleted:            {},
+	EventTypeRoleTemplateCreated:          {},
+	EventTypeRoleTemplateUpdated:          {},
+	EventTypeRoleTemplateDeleted:          {},
+	EventTypeRoleTemplateInstanceLinked:   {},
+	EventTypeRoleTemplateInstanceUnlinked: {},
+}
+
+// IsWebhookDeliverable reports if events of the provided type can be
+// delivered to webhooks
+func IsWebhookDeliverable(eventType EventType) bool {
+	_, ok := deliverableEventTypes[eventType]
+	return ok
+}
+
+// IsKnownEventType reports if the provided event type exists
+func IsKnownEventType(eventType EventType) bool {
+	_, ok := newEventData(eventType)
+	return ok
+}
+
 type EventRoleCreated struct {
 	RoleID       util.ID
 	RoleType     models.RoleType
@@ -407,6 +707,110 @@ func (e *EventRoleAccountabilityDeleted) EventType() EventType {
 	return EventTypeRoleAccountabilityDeleted
 }
 
+type EventRoleChecklistItemCreated struct {
+	ChecklistItemID util.ID
+	RoleID          util.ID
+	Description     string
+}
+
+func NewEventRoleChecklistItemCreated(roleID util.ID, checklistItem *models.ChecklistItem) *EventRoleChecklistItemCreated {
+	return &EventRoleChecklistItemCreated{
+		ChecklistItemID: checklistItem.ID,
+		RoleID:          roleID,
+		Description:     checklistItem.Description,
+	}
+}
+
+func (e *EventRoleChecklistItemCreated) EventType() EventType {
+	return EventTypeRoleChecklistItemCreated
+}
+
+type EventRoleChecklistItemUpdated struct {
+	ChecklistItemID util.ID
+	RoleID          util.ID
+	Description     string
+}
+
+func NewEventRoleChecklistItemUpdated(roleID util.ID, checklistItem *models.ChecklistItem) *EventRoleChecklistItemUpdated {
+	return &EventRoleChecklistItemUpdated{
+		ChecklistItemID: checklistItem.ID,
+		RoleID:          roleID,
+		Description:     checklistItem.Description,
+	}
+}
+
+func (e *EventRoleChecklistItemUpdated) EventType() EventType {
+	return EventTypeRoleChecklistItemUpdated
+}
+
+type EventRoleChecklistItemDeleted struct {
+	ChecklistItemID util.ID
+	RoleID          util.ID
+}
+
+func NewEventRoleChecklistItemDeleted(roleID, checklistItemID util.ID) *EventRoleChecklistItemDeleted {
+	return &EventRoleChecklistItemDeleted{
+		ChecklistItemID: checklistItemID,
+		RoleID:          roleID,
+	}
+}
+
+func (e *EventRoleChecklistItemDeleted) EventType() EventType {
+	return EventTypeRoleChecklistItemDeleted
+}
+
+type EventRoleMetricCreated struct {
+	MetricID    util.ID
+	RoleID      util.ID
+	Description string
+}
+
+func NewEventRoleMetricCreated(roleID util.ID, metric *models.Metric) *EventRoleMetricCreated {
+	return &EventRoleMetricCreated{
+		MetricID:    metric.ID,
+		RoleID:      roleID,
+		Description: metric.Description,
+	}
+}
+
+func (e *EventRoleMetricCreated) EventType() EventType {
+	return EventTypeRoleMetricCreated
+}
+
+type EventRoleMetricUpdated struct {
+	MetricID    util.ID
+	RoleID      util.ID
+	Description string
+}
+
+func NewEventRoleMetricUpdated(roleID util.ID, metric *models.Metric) *EventRoleMetricUpdated {
+	return &EventRoleMetricUpdated{
+		MetricID:    metric.ID,
+		RoleID:      roleID,
+		Description: metric.Description,
+	}
+}
+
+func (e *EventRoleMetricUpdated) EventType() EventType {
+	return EventTypeRoleMetricUpdated
+}
+
+type EventRoleMetricDeleted struct {
+	MetricID util.ID
+	RoleID   util.ID
+}
+
+func NewEventRoleMetricDeleted(roleID, metricID util.ID) *EventRoleMetricDeleted {
+	return &EventRoleMetricDeleted{
+		MetricID: metricID,
+		RoleID:   roleID,
+	}
+}
+
+func (e *EventRoleMetricDeleted) EventType() EventType {
+	return EventTypeRoleMetricDeleted
+}
+
 type EventRoleAdditionalContentSet struct {
 	RoleID  util.ID
 	Content string
@@ -655,11 +1059,14 @@ func (e *EventTensionRoleChanged) EventType() EventType {
 
 type EventTensionClosed struct {
 	Reason string
+	// MeetingID is the meeting where the tension was processed
+	MeetingID *util.ID
 }
 
-func NewEventTensionClosed(tensionID util.ID, reason string) *EventTensionClosed {
+func NewEventTensionClosed(tensionID util.ID, reason string, meetingID *util.ID) *EventTensionClosed {
 	return &EventTensionClosed{
-		Reason: reason,
+		Reason:    reason,
+		MeetingID: meetingID,
 	}
 }
 
@@ -667,27 +1074,735 @@ func (e *EventTensionClosed) EventType() EventType {
 	return EventTypeTensionClosed
 }
 
+type EventTensionStatusChanged struct {
+	PrevStatus models.TensionStatus
+	Status     models.TensionStatus
+}
+
+func NewEventTensionStatusChanged(tensionID util.ID, prevStatus, status models.TensionStatus) *EventTensionStatusChanged {
+	return &EventTensionStatusChanged{
+		PrevStatus: prevStatus,
+		Status:     status,
+	}
+}
+
+func (e *EventTensionStatusChanged) EventType() EventType {
+	return EventTypeTensionStatusChanged
+}
+
+type EventTensionAssigneeChanged struct {
+	PrevMemberID *util.ID
+	MemberID     *util.ID
+}
+
+func NewEventTensionAssigneeChanged(tensionID util.ID, prevMemberID, memberID *util.ID) *EventTensionAssigneeChanged {
+	return &EventTensionAssigneeChanged{
+		PrevMemberID: prevMemberID,
+		MemberID:     memberID,
+	}
+}
+
+func (e *EventTensionAssigneeChanged) EventType() EventType {
+	return EventTypeTensionAssigneeChanged
+}
+
+type EventTensionMeetingChanged struct {
+	MeetingID *util.ID
+}
+
+func NewEventTensionMeetingChanged(tensionID util.ID, meetingID *util.ID) *EventTensionMeetingChanged {
+	return &EventTensionMeetingChanged{
+		MeetingID: meetingID,
+	}
+}
+
+func (e *EventTensionMeetingChanged) EventType() EventType {
+	return EventTypeTensionMeetingChanged
+}
+
+type EventTensionResolved struct {
+	OutcomeType        models.TensionOutcomeType
+	OutcomeReferenceID *util.ID
+	OutcomeDescription string
+}
+
+func NewEventTensionResolved(tensionID util.ID, outcomeType models.TensionOutcomeType, outcomeReferenceID *util.ID, outcomeDescription string) *EventTensionResolved {
+	return &EventTensionResolved{
+		OutcomeType:        outcomeType,
+		OutcomeReferenceID: outcomeReferenceID,
+		OutcomeDescription: outcomeDescription,
+	}
+}
+
+func (e *EventTensionResolved) EventType() EventType {
+	return EventTypeTensionResolved
+}
+
+type EventProposalCreated struct {
+	Title           string
+	Description     string
+	RoleID          util.ID
+	MemberID        util.ID
+	ProposalChanges change.ProposalChanges
+}
+
+func NewEventProposalCreated(proposal *models.Proposal, roleID, memberID util.ID, proposalChanges change.ProposalChanges) *EventProposalCreated {
+	return &EventProposalCreated{
+		Title:           proposal.Title,
+		Description:     proposal.Description,
+		RoleID:          roleID,
+		MemberID:        memberID,
+		ProposalChanges: proposalChanges,
+	}
+}
+
+func (e *EventProposalCreated) EventType() EventType {
+	return EventTypeProposalCreated
+}
+
+type EventProposalUpdated struct {
+	Title           string
+	Description     string
+	ProposalChanges change.ProposalChanges
+}
+
+func NewEventProposalUpdated(proposal *models.Proposal, proposalChanges change.ProposalChanges) *EventProposalUpdated {
+	return &EventProposalUpdated{
+		Title:           proposal.Title,
+		Description:     proposal.Description,
+		ProposalChanges: proposalChanges,
+	}
+}
+
+func (e *EventProposalUpdated) EventType() EventType {
+	return EventTypeProposalUpdated
+}
+
+type EventProposalSubmitted struct {
+}
+
+func NewEventProposalSubmitted(proposalID util.ID) *EventProposalSubmitted {
+	return &EventProposalSubmitted{}
+}
+
+func (e *EventProposalSubmitted) EventType() EventType {
+	return EventTypeProposalSubmitted
+}
+
+type EventProposalObjected struct {
+	ObjectionID util.ID
+	MemberID    util.ID
+	Reason      string
+}
+
+func NewEventProposalObjected(proposalID, objectionID, memberID util.ID, reason string) *EventProposalObjected {
+	return &EventProposalObjected{
+		ObjectionID: objectionID,
+		MemberID:    memberID,
+		Reason:      reason,
+	}
+}
+
+func (e *EventProposalObjected) EventType() EventType {
+	return EventTypeProposalObjected
+}
+
+type EventProposalObjectionResolved struct {
+	ObjectionID util.ID
+	MemberID    util.ID
+	Resolution  string
+}
+
+func NewEventProposalObjectionResolved(proposalID, objectionID, memberID util.ID, resolution string) *EventProposalObjectionResolved {
+	return &EventProposalObjectionResolved{
+		ObjectionID: objectionID,
+		MemberID:    memberID,
+		Resolution:  resolution,
+	}
+}
+
+func (e *EventProposalObjectionResolved) EventType() EventType {
+	return EventTypeProposalObjectionResolved
+}
+
+type EventProposalObjectionWithdrawn struct {
+	ObjectionID util.ID
+	MemberID    util.ID
+}
+
+func NewEventProposalObjectionWithdrawn(proposalID, objectionID, memberID util.ID) *EventProposalObjectionWithdrawn {
+	return &EventProposalObjectionWithdrawn{
+		ObjectionID: objectionID,
+		MemberID:    memberID,
+	}
+}
+
+func (e *EventProposalObjectionWithdrawn) EventType() EventType {
+	return EventTypeProposalObjectionWithdrawn
+}
+
+type EventProposalConsented struct {
+	MemberID util.ID
+}
+
+func NewEventProposalConsented(proposalID, memberID util.ID) *EventProposalConsented {
+	return &EventProposalConsented{
+		MemberID: memberID,
+	}
+}
+
+func (e *EventProposalConsented) EventType() EventType {
+	return EventTypeProposalConsented
+}
+
+type EventProjectCreated struct {
+	Title       string
+	Description string
+	DueDate     *time.Time
+	RoleID      util.ID
+	MemberID    *util.ID
+	TensionID   *util.ID
+}
+
+func NewEventProjectCreated(project *models.Project, roleID util.ID, memberID, tensionID *util.ID) *EventProjectCreated {
+	return &EventProjectCreated{
+		Title:       project.Title,
+		Description: project.Description,
+		DueDate:     project.DueDate,
+		RoleID:      roleID,
+		MemberID:    memberID,
+		TensionID:   tensionID,
+	}
+}
+
+func (e *EventProjectCreated) EventType() EventType {
+	return EventTypeProjectCreated
+}
+
+type EventProjectUpdated struct {
+	Title       string
+	Description string
+	DueDate     *time.Time
+}
+
+func NewEventProjectUpdated(project *models.Project) *EventProjectUpdated {
+	return &EventProjectUpdated{
+		Title:       project.Title,
+		Description: project.Description,
+		DueDate:     project.DueDate,
+	}
+}
+
+func (e *EventProjectUpdated) EventType() EventType {
+	return EventTypeProjectUpdated
+}
+
+type EventProjectMemberChanged struct {
+	PrevMemberID *util.ID
+	MemberID     *util.ID
+}
+
+func NewEventProjectMemberChanged(projectID util.ID, prevMemberID, memberID *util.ID) *EventProjectMemberChanged {
+	return &EventProjectMemberChanged{
+		PrevMemberID: prevMemberID,
+		MemberID:     memberID,
+	}
+}
+
+func (e *EventProjectMemberChanged) EventType() EventType {
+	return EventTypeProjectMemberChanged
+}
+
+type EventProjectStatusChanged struct {
+	PrevStatus models.ProjectStatus
+	Status     models.ProjectStatus
+}
+
+func NewEventProjectStatusChanged(projectID util.ID, prevStatus, status models.ProjectStatus) *EventProjectStatusChanged {
+	return &EventProjectStatusChanged{
+		PrevStatus: prevStatus,
+		Status:     status,
+	}
+}
+
+func (e *EventProjectStatusChanged) EventType() EventType {
+	return EventTypeProjectStatusChanged
+}
+
+type EventActionCreated struct {
+	Title       string
+	Description string
+	DueDate     *time.Time
+	RoleID      util.ID
+	MemberID    *util.ID
+	TensionID   *util.ID
+}
+
+func NewEventActionCreated(action *models.Action, roleID util.ID, memberID, tensionID *util.ID) *EventActionCreated {
+	return &EventActionCreated{
+		Title:       action.Title,
+		Description: action.Description,
+		DueDate:     action.DueDate,
+		RoleID:      roleID,
+		MemberID:    memberID,
+		TensionID:   tensionID,
+	}
+}
+
+func (e *EventActionCreated) EventType() EventType {
+	return EventTypeActionCreated
+}
+
+type EventActionUpdated struct {
+	Title       string
+	Description string
+	DueDate     *time.Time
+}
+
+func NewEventActionUpdated(action *models.Action) *EventActionUpdated {
+	return &EventActionUpdated{
+		Title:       action.Title,
+		Description: action.Description,
+		DueDate:     action.DueDate,
+	}
+}
+
+func (e *EventActionUpdated) EventType() EventType {
+	return EventTypeActionUpdated
+}
+
+type EventActionMemberChanged struct {
+	PrevMemberID *util.ID
+	MemberID     *util.ID
+}
+
+func NewEventActionMemberChanged(actionID util.ID, prevMemberID, memberID *util.ID) *EventActionMemberChanged {
+	return &EventActionMemberChanged{
+		PrevMemberID: prevMemberID,
+		MemberID:     memberID,
+	}
+}
+
+func (e *EventActionMemberChanged) EventType() EventType {
+	return EventTypeActionMemberChanged
+}
+
+type EventActionStatusChanged struct {
+	PrevStatus models.ActionStatus
+	Status     models.ActionStatus
+}
+
+func NewEventActionStatusChanged(actionID util.ID, prevStatus, status models.ActionStatus) *EventActionStatusChanged {
+	return &EventActionStatusChanged{
+		PrevStatus: prevStatus,
+		Status:     status,
+	}
+}
+
+func (e *EventActionStatusChanged) EventType() EventType {
+	return EventTypeActionStatusChanged
+}
+
+// EventMeetingCreated records a circle meeting. The facilitator, secretary
+// and attendees are the ones at the meeting timeline.
+type EventMeetingCreated struct {
+	RoleID        util.ID
+	MeetingType   models.MeetingType
+	Date          time.Time
+	FacilitatorID *util.ID
+	SecretaryID   *util.ID
+	AttendeesIDs  []util.ID
+}
+
+func NewEventMeetingCreated(meeting *models.Meeting, roleID util.ID, facilitatorID, secretaryID *util.ID, attendeesIDs []util.ID) *EventMeetingCreated {
+	return &EventMeetingCreated{
+		RoleID:        roleID,
+		MeetingType:   meeting.MeetingType,
+		Date:          meeting.Date,
+		FacilitatorID: facilitatorID,
+		SecretaryID:   secretaryID,
+		AttendeesIDs:  attendeesIDs,
+	}
+}
+
+func (e *EventMeetingCreated) EventType() EventType {
+	return EventTypeMeetingCreated
+}
+
+type EventMeetingChecklistItemReported struct {
+	ChecklistItemID util.ID
+	Checked         bool
+}
+
+func NewEventMeetingChecklistItemReported(meetingID, checklistItemID util.ID, checked bool) *EventMeetingChecklistItemReported {
+	return &EventMeetingChecklistItemReported{
+		ChecklistItemID: checklistItemID,
+		Checked:         checked,
+	}
+}
+
+func (e *EventMeetingChecklistItemReported) EventType() EventType {
+	return EventTypeMeetingChecklistItemReported
+}
+
+type EventMeetingMetricReported struct {
+	MetricID util.ID
+	Value    string
+}
+
+func NewEventMeetingMetricReported(meetingID, metricID util.ID, value string) *EventMeetingMetricReported {
+	return &EventMeetingMetricReported{
+		MetricID: metricID,
+		Value:    value,
+	}
+}
+
+func (e *EventMeetingMetricReported) EventType() EventType {
+	return EventTypeMeetingMetricReported
+}
+
+// EventElectionCreated records the opening of an election for a circle core
+// role. MemberID is the member that opened the election
+type EventElectionCreated struct {
+	RoleID   util.ID
+	RoleType models.RoleType
+	MemberID util.ID
+}
+
+func NewEventElectionCreated(election *models.Election, roleID, memberID util.ID) *EventElectionCreated {
+	return &EventElectionCreated{
+		RoleID:   roleID,
+		RoleType: election.RoleType,
+		MemberID: memberID,
+	}
+}
+
+func (e *EventElectionCreated) EventType() EventType {
+	return EventTypeElectionCreated
+}
+
+type EventElectionCandidateNominated struct {
+	CandidateID util.ID
+	NominatorID util.ID
+}
+
+func NewEventElectionCandidateNominated(electionID, candidateID, nominatorID util.ID) *EventElectionCandidateNominated {
+	return &EventElectionCandidateNominated{
+		CandidateID: candidateID,
+		NominatorID: nominatorID,
+	}
+}
+
+func (e *EventElectionCandidateNominated) EventType() EventType {
+	return EventTypeElectionCandidateNominated
+}
+
+// EventElectionCompleted records the election result. The core role
+// assignment is recorded by the related CircleCoreRoleMemberSet event
+type EventElectionCompleted struct {
+	ElectedMemberID    util.ID
+	ElectionExpiration *time.Time
+}
+
+func NewEventElectionCompleted(electionID, electedMemberID util.ID, electionExpiration *time.Time) *EventElectionCompleted {
+	return &EventElectionCompleted{
+		ElectedMemberID:    electedMemberID,
+		ElectionExpiration: electionExpiration,
+	}
+}
+
+func (e *EventElectionCompleted) EventType() EventType {
+	return EventTypeElectionCompleted
+}
+
+// EventRoleTemplateCreated records the creation of a role template.
+// SourceRoleID is the role the template was saved from (if any)
+type EventRoleTemplateCreated struct {
+	Name             string
+	Purpose          string
+	Domains          []string
+	Accountabilities []string
+	SourceRoleID     *util.ID
+}
+
+func NewEventRoleTemplateCreated(roleTemplate *models.RoleTemplate, sourceRoleID *util.ID) *EventRoleTemplateCreated {
+	return &EventRoleTemplateCreated{
+		Name:             roleTemplate.Name,
+		Purpose:          roleTemplate.Purpose,
+		Domains:          roleTemplate.Domains,
+		Accountabilities: roleTemplate.Accountabilities,
+		SourceRoleID:     sourceRoleID,
+	}
+}
+
+func (e *EventRoleTemplateCreated) EventType() EventType {
+	return EventTypeRoleTemplateCreated
+}
+
+type EventRoleTemplateUpdated struct {
+	Name             string
+	Purpose          string
+	Domains          []string
+	Accountabilities []string
+}
+
+func NewEventRoleTemplateUpdated(roleTemplate *models.RoleTemplate) *EventRoleTemplateUpdated {
+	return &EventRoleTemplateUpdated{
+		Name:             roleTemplate.Name,
+		Purpose:          roleTemplate.Purpose,
+		Domains:          roleTemplate.Domains,
+		Accountabilities: roleTemplate.Accountabilities,
+	}
+}
+
+func (e *EventRoleTemplateUpdated) EventType() EventType {
+	return EventTypeRoleTemplateUpdated
+}
+
+type EventRoleTemplateDeleted struct {
+}
+
+func NewEventRoleTemplateDeleted(roleTemplateID util.ID) *EventRoleTemplateDeleted {
+	return &EventRoleTemplateDeleted{}
+}
+
+func (e *EventRoleTemplateDeleted) EventType() EventType {
+	return EventTypeRoleTemplateDeleted
+}
+
+// EventRoleTemplateInstanceLinked records that a role instantiated from the
+// template is kept linked to it, so template updates can be proposed to it
+type EventRoleTemplateInstanceLinked struct {
+	RoleID util.ID
+}
+
+func NewEventRoleTemplateInstanceLinked(roleTemplateID, roleID util.ID) *EventRoleTemplateInstanceLinked {
+	return &EventRoleTemplateInstanceLinked{
+		RoleID: roleID,
+	}
+}
+
+func (e *EventRoleTemplateInstanceLinked) EventType() EventType {
+	return EventTypeRoleTemplateInstanceLinked
+}
+
+type EventRoleTemplateInstanceUnlinked struct {
+	RoleID util.ID
+}
+
+func NewEventRoleTemplateInstanceUnlinked(roleTemplateID, roleID util.ID) *EventRoleTemplateInstanceUnlinked {
+	return &EventRoleTemplateInstanceUnlinked{
+		RoleID: roleID,
+	}
+}
+
+func (e *EventRoleTemplateInstanceUnlinked) EventType() EventType {
+	return EventTypeRoleTemplateInstanceUnlinked
+}
+
+type EventWebhookCreated struct {
+	URL        string
+	EventTypes []string
+	Secret     string
+}
+
+func NewEventWebhookCreated(webhook *models.Webhook) *EventWebhookCreated {
+	return &EventWebhookCreated{
+		URL:        webhook.URL,
+		EventTypes: webhook.EventTypes,
+		Secret:     webhook.Secret,
+	}
+}
+
+func (e *EventWebhookCreated) EventType() EventType {
+	return EventTypeWebhookCreated
+}
+
+type EventWebhookUpdated struct {
+	URL        string
+	EventTypes []string
+	Secret     string
+}
+
+func NewEventWebhookUpdated(webhook *models.Webhook) *EventWebhookUpdated {
+	return &EventWebhookUpdated{
+		URL:        webhook.URL,
+		EventTypes: webhook.EventTypes,
+		Secret:     webhook.Secret,
+	}
+}
+
+func (e *EventWebhookUpdated) EventType() EventType {
+	return EventTypeWebhookUpdated
+}
+
+type EventWebhookDeleted struct {
+}
+
+func NewEventWebhookDeleted(webhookID util.ID) *EventWebhookDeleted {
+	return &EventWebhookDeleted{}
+}
+
+func (e *EventWebhookDeleted) EventType() EventType {
+	return EventTypeWebhookDeleted
+}
+
+// EventWebhookDeliveryFailed records that an event couldn't be delivered to
+// the webhook after all the retries. DeliveryEventType is the type of the
+// undelivered event
+type EventWebhookDeliveryFailed struct {
+	EventID           util.ID
+	SequenceNumber    int64
+	DeliveryEventType string
+	Attempts          int
+	LastError         string
+}
+
+func NewEventWebhookDeliveryFailed(webhookID, eventID util.ID, sequenceNumber int64, eventType string, attempts int, lastError string) *EventWebhookDeliveryFailed {
+	return &EventWebhookDeliveryFailed{
+		EventID:           eventID,
+		SequenceNumber:    sequenceNumber,
+		DeliveryEventType: eventType,
+		Attempts:          attempts,
+		LastError:         lastError,
+	}
+}
+
+func (e *EventWebhookDeliveryFailed) EventType() EventType {
+	return EventTypeWebhookDeliveryFailed
+}
+
+type EventSessionCreated struct {
+	MemberID   util.ID
+	Expiration time.Time
+}
+
+func NewEventSessionCreated(sessionID, memberID util.ID, expiration time.Time) *EventSessionCreated {
+	return &EventSessionCreated{
+		MemberID:   memberID,
+		Expiration: expiration,
+	}
+}
+
+func (e *EventSessionCreated) EventType() EventType {
+	return EventTypeSessionCreated
+}
+
+// EventSessionRefreshTokenIssued reports a new session refresh token. When
+// PrevRefreshTokenID is defined the refresh token has been rotated and the
+// previous one cannot be used anymore
+type EventSessionRefreshTokenIssued struct {
+	RefreshTokenID     util.ID
+	RefreshTokenHash   string
+	PrevRefreshTokenID *util.ID
+}
+
+func NewEventSessionRefreshTokenIssued(sessionID, refreshTokenID util.ID, refreshTokenHash string, prevRefreshTokenID *util.ID) *EventSessionRefreshTokenIssued {
+	return &EventSessionRefreshTokenIssued{
+		RefreshTokenID:     refreshTokenID,
+		RefreshTokenHash:   refreshTokenHash,
+		PrevRefreshTokenID: prevRefreshTokenID,
+	}
+}
+
+func (e *EventSessionRefreshTokenIssued) EventType() EventType {
+	return EventTypeSessionRefreshTokenIssued
+}
+
+type EventSessionRevoked struct {
+	// RefreshTokenReused reports that the session has been revoked since an
+	// already rotated refresh token has been used
+	RefreshTokenReused bool `json:",omitempty"`
+}
+
+func NewEventSessionRevoked(sessionID util.ID, refreshTokenReused bool) *EventSessionRevoked {
+	return &EventSessionRevoked{
+		RefreshTokenReused: refreshTokenReused,
+	}
+}
+
+func (e *EventSessionRevoked) EventType() EventType {
+	return EventTypeSessionRevoked
+}
+
+type EventAPITokenCreated struct {
+	MemberID   util.ID
+	Name       string
+	Scope      models.APITokenScope
+	Expiration time.Time
+	TokenHash  string
+}
+
+func NewEventAPITokenCreated(apiToken *models.APIToken) *EventAPITokenCreated {
+	return &EventAPITokenCreated{
+		MemberID:   apiToken.MemberID,
+		Name:       apiToken.Name,
+		Scope:      apiToken.Scope,
+		Expiration: apiToken.Expiration,
+		TokenHash:  apiToken.TokenHash,
+	}
+}
+
+func (e *EventAPITokenCreated) EventType() EventType {
+	return EventTypeAPITokenCreated
+}
+
+type EventAPITokenRevoked struct {
+}
+
+func NewEventAPITokenRevoked(apiTokenID util.ID) *EventAPITokenRevoked {
+	return &EventAPITokenRevoked{}
+}
+
+func (e *EventAPITokenRevoked) EventType() EventType {
+	return EventTypeAPITokenRevoked
+}
+
+type EventProposalAccepted struct {
+}
+
+func NewEventProposalAccepted(proposalID util.ID) *EventProposalAccepted {
+	return &EventProposalAccepted{}
+}
+
+func (e *EventProposalAccepted) EventType() EventType {
+	return EventTypeProposalAccepted
+}
+
+type EventProposalWithdrawn struct {
+}
+
+func NewEventProposalWithdrawn(proposalID util.ID) *EventProposalWithdrawn {
+	return &EventProposalWithdrawn{}
+}
+
+func (e *EventProposalWithdrawn) EventType() EventType {
+	return EventTypeProposalWithdrawn
+}
+
 type EventMemberChangeCreateRequested struct {
-	MemberID     util.ID
-	IsAdmin      bool
-	MatchUID     string
-	UserName     string
-	FullName     string
-	Email        string
-	PasswordHash string
-	Avatar       []byte
+	MemberID         util.ID
+	IsAdmin          bool
+	IsServiceAccount bool
+	MatchUID         string
+	UserName         string
+	FullName         string
+	Email            string
+	PasswordHash     string
+	Avatar           []byte
 }
 
 func NewEventMemberChangeCreateRequested(memberChangeID util.ID, member *models.Member, matchUID, passwordHash string, avatar []byte) *EventMemberChangeCreateRequested {
 	return &EventMemberChangeCreateRequested{
-		MemberID:     member.ID,
-		IsAdmin:      member.IsAdmin,
-		MatchUID:     matchUID,
-		UserName:     member.UserName,
-		FullName:     member.FullName,
-		Email:        member.Email,
-		PasswordHash: passwordHash,
-		Avatar:       avatar,
+		MemberID:         member.ID,
+		IsAdmin:          member.IsAdmin,
+		IsServiceAccount: member.IsServiceAccount,
+		MatchUID:         matchUID,
+		UserName:         member.UserName,
+		FullName:         member.FullName,
+		Email:            member.Email,
+		PasswordHash:     passwordHash,
+		Avatar:           avatar,
 	}
 }
 
@@ -757,21 +1872,23 @@ func (e *EventMemberChangeCompleted) EventType() EventType {
 }
 
 type EventMemberCreated struct {
-	IsAdmin  bool
-	UserName string
-	FullName string
-	Email    string
+	IsAdmin          bool
+	IsServiceAccount bool
+	UserName         string
+	FullName         string
+	Email            string
 
 	MemberChangeID util.ID
 }
 
 func NewEventMemberCreated(member *models.Member, memberChangeID util.ID) *EventMemberCreated {
 	return &EventMemberCreated{
-		IsAdmin:        member.IsAdmin,
-		UserName:       member.UserName,
-		FullName:       member.FullName,
-		Email:          member.Email,
-		MemberChangeID: memberChangeID,
+		IsAdmin:          member.IsAdmin,
+		IsServiceAccount: member.IsServiceAccount,
+		UserName:         member.UserName,
+		FullName:         member.FullName,
+		Email:            member.Email,
+		MemberChangeID:   memberChangeID,
 	}
 }
 
@@ -853,6 +1970,26 @@ func (e *EventMemberMatchUIDSet) EventType() EventType {
 	return EventTypeMemberMatchUIDSet
 }
 
+type EventMemberDeactivated struct{}
+
+func NewEventMemberDeactivated(memberID util.ID) *EventMemberDeactivated {
+	return &EventMemberDeactivated{}
+}
+
+func (e *EventMemberDeactivated) EventType() EventType {
+	return EventTypeMemberDeactivated
+}
+
+type EventMemberReactivated struct{}
+
+func NewEventMemberReactivated(memberID util.ID) *EventMemberReactivated {
+	return &EventMemberReactivated{}
+}
+
+func (e *EventMemberReactivated) EventType() EventType {
+	return EventTypeMemberReactivated
+}
+
 type EventMemberRequestHandlerStateUpdated struct {
 	MemberChangeSequenceNumber int64
 	MemberSequenceNumber       int64
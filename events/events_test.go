@@ -0,0 +1,66 @@
+package events
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// secretFields returns the names of the fields of t (and of its nested
+// structs) that could contain secrets
+func secretFields(t reflect.Type, seen map[reflect.Type]bool) []string {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return secretFields(t.Elem(), seen)
+	case reflect.Struct:
+	default:
+		return nil
+	}
+	if seen[t] {
+		return nil
+	}
+	seen[t] = true
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		if strings.HasSuffix(f.Name, "Hash") || strings.HasSuffix(f.Name, "Token") || strings.HasSuffix(f.Name, "Secret") || strings.Contains(f.Name, "Password") {
+			fields = append(fields, t.Name()+"."+f.Name)
+		}
+		fields = append(fields, secretFields(f.Type, seen)...)
+	}
+	return fields
+}
+
+func TestDeliverableEventTypesWithoutSecrets(t *testing.T) {
+	for eventType := range deliverableEventTypes {
+		ed, ok := newEventData(eventType)
+		if !ok {
+			t.Errorf("deliverable event type %q is not a known event type", eventType)
+			continue
+		}
+		if fields := secretFields(reflect.TypeOf(ed), map[reflect.Type]bool{}); len(fields) > 0 {
+			t.Errorf("deliverable event type %q has secret fields: %v", eventType, fields)
+		}
+	}
+}
+
+func TestUndeliverableEventTypes(t *testing.T) {
+	for _, eventType := range []EventType{
+		EventTypeMemberChangeCreateRequested,
+		EventTypeMemberPasswordSet,
+		EventTypeWebhookCreated,
+		EventTypeWebhookUpdated,
+		EventTypeSessionRefreshTokenIssued,
+		EventTypeAPITokenCreated,
+		EventTypeUniqueRegistryValueReserved,
+		"UnknownEvent",
+	} {
+		if IsWebhookDeliverable(eventType) {
+			t.Errorf("event type %q must not be deliverable", eventType)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package events
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Upcaster transforms the data of an event from a schema version to the next
+// one
+type Upcaster func(data []byte) ([]byte, error)
+
+// UpcasterRegistry contains, for every event type, the upcasters to transform
+// the event data from an older schema version to the current one.
+// The current schema version of an event type is the one after its latest
+// registered upcaster (event types without upcasters are at version 1).
+type UpcasterRegistry struct {
+	// upcasters[eventType][i] upcasts from schema version i+1 to i+2
+	upcasters map[EventType][]Upcaster
+}
+
+func NewUpcasterRegistry() *UpcasterRegistry {
+	return &UpcasterRegistry{upcasters: make(map[EventType][]Upcaster)}
+}
+
+// Register adds the upcaster from schemaVersion to schemaVersion+1 for the
+// provided event type. Upcasters must be registered in schema version order
+// starting from version 1.
+func (r *UpcasterRegistry) Register(eventType EventType, schemaVersion int, upcaster Upcaster) {
+	if schemaVersion != len(r.upcasters[eventType])+1 {
+		panic(errors.Errorf("wrong upcaster schema version %d for event type %s, expected %d", schemaVersion, eventType, len(r.upcasters[eventType])+1))
+	}
+	r.upcasters[eventType] = append(r.upcasters[eventType], upcaster)
+}
+
+// SchemaVersion returns the current schema version of the provided event type
+func (r *UpcasterRegistry) SchemaVersion(eventType EventType) int {
+	return len(r.upcasters[eventType]) + 1
+}
+
+// Upcast transforms the event data from the provided schema version to the
+// current one
+func (r *UpcasterRegistry) Upcast(eventType EventType, schemaVersion int, data []byte) ([]byte, error) {
+	curSchemaVersion := r.SchemaVersion(eventType)
+	if schemaVersion < 1 || schemaVersion > curSchemaVersion {
+		return nil, errors.Errorf("unsupported schema version %d for event type %s, current version is %d", schemaVersion, eventType, curSchemaVersion)
+	}
+
+	for v := schemaVersion; v < curSchemaVersion; v++ {
+		var err error
+		data, err = r.upcasters[eventType][v-1](data)
+		if err != nil {
+			return nil, errors.WithMessage(err, "upcast failed")
+		}
+	}
+	return data, nil
+}
+
+// upcasters is the registry of the upcasters for the events defined in this
+// package. When an event data struct changes in a non backward compatible way
+// (renamed or removed fields, changed types) an upcaster from the previous
+// schema version must be registered here.
+var upcasters = NewUpcasterRegistry()
+
+// EventSchemaVersion returns the current schema version of the provided event
+// type
+func EventSchemaVersion(eventType EventType) int {
+	return upcasters.SchemaVersion(eventType)
+}
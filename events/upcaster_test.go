@@ -0,0 +1,156 @@
+package events
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/sorintlab/sircles/eventstore"
+)
+
+func renameField(from, to string) Upcaster {
+	return func(data []byte) ([]byte, error) {
+		m := map[string]interface{}{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, err
+		}
+		m[to] = m[from]
+		delete(m, from)
+		return json.Marshal(m)
+	}
+}
+
+func TestUpcasterRegistry(t *testing.T) {
+	var eventType EventType = "TestEvent"
+
+	r := NewUpcasterRegistry()
+	if v := r.SchemaVersion(eventType); v != 1 {
+		t.Fatalf("expected schema version 1, got %d", v)
+	}
+
+	r.Register(eventType, 1, renameField("Name", "FullName"))
+	r.Register(eventType, 2, renameField("FullName", "DisplayName"))
+	if v := r.SchemaVersion(eventType); v != 3 {
+		t.Fatalf("expected schema version 3, got %d", v)
+	}
+
+	tests := []struct {
+		schemaVersion int
+		data          string
+		out           string
+		err           bool
+	}{
+		{schemaVersion: 1, data: `{"Name":"name01"}`, out: `{"DisplayName":"name01"}`},
+		{schemaVersion: 2, data: `{"FullName":"name01"}`, out: `{"DisplayName":"name01"}`},
+		{schemaVersion: 3, data: `{"DisplayName":"name01"}`, out: `{"DisplayName":"name01"}`},
+		{schemaVersion: 0, data: `{"Name":"name01"}`, err: true},
+		{schemaVersion: 4, data: `{"DisplayName":"name01"}`, err: true},
+	}
+
+	for i, tt := range tests {
+		out, err := r.Upcast(eventType, tt.schemaVersion, []byte(tt.data))
+		if tt.err {
+			if err == nil {
+				t.Fatalf("#%d: expected error", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("#%d: unexpected error: %v", i, err)
+		}
+		if string(out) != tt.out {
+			t.Fatalf("#%d: expected data %s, got %s", i, tt.out, out)
+		}
+	}
+}
+
+func TestUnmarshalDataUpcast(t *testing.T) {
+	prevUpcasters := upcasters
+	defer func() { upcasters = prevUpcasters }()
+
+	// simulate an old TensionClosed event schema where Reason was called
+	// Motivation
+	upcasters = NewUpcasterRegistry()
+	upcasters.Register(EventTypeTensionClosed, 1, renameField("Motivation", "Reason"))
+
+	e := &eventstore.StoredEvent{
+		EventType: EventTypeTensionClosed.String(),
+		Data:      []byte(`{"Motivation":"fixed"}`),
+		MetaData:  []byte(`{}`),
+	}
+	data, err := UnmarshalData(e)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &EventTensionClosed{Reason: "fixed"}
+	if !reflect.DeepEqual(data, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, data)
+	}
+
+	// new events are written with the current schema version and not
+	// upcasted
+	eventsData, err := GenEventData([]Event{expected}, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	e = &eventstore.StoredEvent{
+		EventType: eventsData[0].EventType,
+		Data:      eventsData[0].Data,
+		MetaData:  eventsData[0].MetaData,
+	}
+	md, err := UnmarshalMetaData(e)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if md.SchemaVersion != 2 {
+		t.Fatalf("expected schema version 2, got %d", md.SchemaVersion)
+	}
+	data, err = UnmarshalData(e)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, data)
+	}
+}
+
+// Test that all the events of a dump created before event schema versioning
+// was introduced are correctly decoded
+func TestUnmarshalDataDumpV1(t *testing.T) {
+	f, err := os.Open("testdata/dump-v1.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(f)
+	n := 0
+	for dec.More() {
+		var e *eventstore.StoredEvent
+		if err := dec.Decode(&e); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		md, err := UnmarshalMetaData(e)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if md.SchemaVersion != 0 {
+			t.Fatalf("expected no schema version, got %d", md.SchemaVersion)
+		}
+
+		data, err := UnmarshalData(e)
+		if err != nil {
+			t.Fatalf("event %s: unexpected error: %v", e.EventType, err)
+		}
+		expectedType := reflect.TypeOf(GetEventDataType(EventType(e.EventType)))
+		if reflect.TypeOf(data) != expectedType {
+			t.Fatalf("event %s: expected data type %s, got %T", e.EventType, expectedType, data)
+		}
+		n++
+	}
+	if n == 0 {
+		t.Fatalf("no events in dump")
+	}
+}
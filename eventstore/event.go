@@ -35,6 +35,14 @@ type EventMetaData struct {
 	CausationID     *util.ID // event ID causing this event
 	GroupID         *util.ID // event group ID
 	CommandIssuerID *util.ID // issuer of the command generating this event
+	// CommandIssuerTokenID is the api token used by the command issuer. It's
+	// nil if the command hasn't been issued using an api token. The issuer
+	// (CommandIssuerID) is always a member, also when using an api token
+	CommandIssuerTokenID *util.ID `json:",omitempty"`
+	// SchemaVersion is the version of the event data schema. Events written
+	// before schema versioning was introduced don't have it and are at
+	// version 1
+	SchemaVersion int `json:",omitempty"`
 }
 
 type EventData struct {
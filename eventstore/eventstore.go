@@ -228,6 +228,94 @@ func (s *EventStore) WriteEvents(eventsData []*EventData, category string, strea
 	return storedEvents, nil
 }
 
+// StreamEventsData are the events to write to a stream. Version is the
+// expected current stream version.
+type StreamEventsData struct {
+	EventsData []*EventData
+	Category   string
+	StreamID   string
+	Version    int64
+}
+
+// WriteStreamsEvents atomically writes the events of multiple streams in a
+// single transaction: if the events of one stream cannot be written (i.e. its
+// version has been concurrently updated) no event is written.
+func (s *EventStore) WriteStreamsEvents(streamsEventsData []*StreamEventsData) ([]*StoredEvent, error) {
+	notifier := s.nf.NewNotifier()
+	hasTxNotifier := false
+	txNotifier, ok := notifier.(ln.TxNotifier)
+	if ok {
+		hasTxNotifier = true
+	}
+
+	// use the same timestamps for all these events since they represents the
+	// same transaction
+	timestamp := s.tg.Now()
+
+	var storedEvents []*StoredEvent
+
+	err := s.db.Do(func(tx *db.Tx) error {
+		storedEvents = nil
+		for _, sed := range streamsEventsData {
+			if len(sed.EventsData) == 0 {
+				continue
+			}
+			events, err := s.writeEvents(tx, timestamp, sed.EventsData, sed.Category, sed.StreamID, sed.Version)
+			if err != nil {
+				return err
+			}
+			storedEvents = append(storedEvents, events...)
+		}
+
+		if hasTxNotifier {
+			txNotifier.BindTx(tx)
+			return txNotifier.Notify("event", "")
+		}
+		return nil
+	})
+	if err != nil {
+		// like in WriteEvents, report a concurrent update error if a stream
+		// version has changed
+		for _, sed := range streamsEventsData {
+			curVersion, nerr := s.streamVersion(sed.StreamID)
+			if nerr != nil {
+				// return the previous error
+				return nil, err
+			}
+			if sed.Version != curVersion {
+				return nil, errors.WithStack(&concurrentUpdateError{providedVersion: sed.Version, currentVersion: curVersion})
+			}
+		}
+		return nil, err
+	}
+
+	if !hasTxNotifier {
+		if err := notifier.Notify("event", ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return storedEvents, nil
+}
+
+func (s *EventStore) streamVersion(streamID string) (int64, error) {
+	var version int64
+	err := s.db.Do(func(tx *db.Tx) error {
+		return tx.Do(func(tx *db.WrappedTx) error {
+			q, args, err := sb.Select("version").From("streamversion").Where(sq.Eq{"streamid": streamID}).ToSql()
+			if err != nil {
+				return errors.Wrap(err, "failed to build query")
+			}
+			err = tx.QueryRow(q, args...).Scan(&version)
+			if err != nil && err != sql.ErrNoRows {
+				return errors.WithMessage(err, "failed to execute query")
+			}
+			return nil
+		})
+	})
+	return version, err
+}
+
 func (s *EventStore) writeEvents(tx *db.Tx, timestamp time.Time, eventsData []*EventData, category string, streamID string, version int64) ([]*StoredEvent, error) {
 	sb := sb.Select("category", "version").From("streamversion").Where(sq.Eq{"streamid": streamID})
 	q, args, err := sb.ToSql()
@@ -195,3 +195,71 @@ func TestRestoreEvents(t *testing.T) {
 		}
 	}
 }
+
+func TestWriteStreamsEvents(t *testing.T) {
+	events := []*EventData{
+		&EventData{
+			EventType: "eventtype01",
+		},
+		&EventData{
+			EventType: "eventtype01",
+		},
+	}
+
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(%q, %q) got error %q", "", "", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	dbpath := filepath.Join(tmpDir, "db")
+
+	db, err := db.NewDB("sqlite3", dbpath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.Migrate("eventstore", Migrations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	localln := ln.NewLocalListenNotify()
+	nf := ln.NewLocalNotifierFactory(localln)
+	es := NewEventStore(db, nf)
+
+	if _, err := es.WriteEvents(events, "category01", "b1399c23-5b50-4c72-b803-804efaba0cb1", 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	writtenEvents, err := es.WriteStreamsEvents([]*StreamEventsData{
+		{EventsData: events, Category: "category01", StreamID: "b1399c23-5b50-4c72-b803-804efaba0cb1", Version: 2},
+		{EventsData: events, Category: "category02", StreamID: "65c4dce5-2935-46eb-a71e-3ea1cb4b970c", Version: 0},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(writtenEvents) != 4 {
+		t.Fatalf("expected %d written events, got %d", 4, len(writtenEvents))
+	}
+
+	// no event is written when a stream has a different version than the
+	// current one
+	expectedErr := fmt.Errorf("current version %d different than provided version %d", 4, 3)
+	_, err = es.WriteStreamsEvents([]*StreamEventsData{
+		{EventsData: events, Category: "category02", StreamID: "65c4dce5-2935-46eb-a71e-3ea1cb4b970c", Version: 2},
+		{EventsData: events, Category: "category01", StreamID: "b1399c23-5b50-4c72-b803-804efaba0cb1", Version: 3},
+	})
+	if err == nil {
+		t.Fatalf("expected error %q, got no error", expectedErr)
+	}
+	if err.Error() != expectedErr.Error() {
+		t.Fatalf("expected error %q, got error %q", expectedErr, err)
+	}
+
+	allEvents, err := es.GetAllEvents(0, 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(allEvents) != 6 {
+		t.Fatalf("expected %d events, got %d", 6, len(allEvents))
+	}
+}
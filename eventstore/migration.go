@@ -20,4 +20,10 @@ var Migrations = []db.Migration{
 			"create table streamversion (streamid varchar not null, category varchar not null, version bigint not null, PRIMARY KEY(streamid))",
 		},
 	},
+	{
+		Stmts: []string{
+			// stores the latest aggregate snapshot for every stream
+			"create table snapshot (streamid varchar not null, category varchar not null, version bigint not null, schemaversion bigint not null, timestamp timestamptz not null, data bytea, PRIMARY KEY(streamid))",
+		},
+	},
 }
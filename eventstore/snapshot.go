@@ -0,0 +1,71 @@
+package eventstore
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/sorintlab/sircles/db"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var (
+	snapshotSelect = sb.Select("streamid", "category", "version", "schemaversion", "timestamp", "data").From("snapshot")
+	snapshotInsert = sb.Insert("snapshot").Columns("streamid", "category", "version", "schemaversion", "timestamp", "data")
+)
+
+// Snapshot is the state of a stream aggregate at a specific stream version
+type Snapshot struct {
+	StreamID string
+	Category string
+	Version  int64 // Version of the last event applied to the aggregate
+	// SchemaVersion is the version of the snapshot data format
+	SchemaVersion int64
+	Timestamp     time.Time
+	Data          []byte
+}
+
+// WriteSnapshot saves the snapshot replacing the previous stream snapshot
+func (s *EventStore) WriteSnapshot(snapshot *Snapshot) error {
+	q, args, err := snapshotInsert.Values(snapshot.StreamID, snapshot.Category, snapshot.Version, snapshot.SchemaVersion, s.tg.Now(), snapshot.Data).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+
+	return s.db.Do(func(tx *db.Tx) error {
+		return tx.Do(func(tx *db.WrappedTx) error {
+			// poor man insert or update...
+			if _, err := tx.Exec("delete from snapshot where streamid = $1", snapshot.StreamID); err != nil {
+				return errors.WithMessage(err, "failed to delete snapshot")
+			}
+			if _, err := tx.Exec(q, args...); err != nil {
+				return errors.WithMessage(err, "failed to execute query")
+			}
+			return nil
+		})
+	})
+}
+
+// GetSnapshot returns the latest stream snapshot or nil if the stream doesn't
+// have a snapshot
+func (s *EventStore) GetSnapshot(streamID string) (*Snapshot, error) {
+	q, args, err := snapshotSelect.Where(sq.Eq{"streamid": streamID}).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	var snapshot Snapshot
+	err = s.db.Do(func(tx *db.Tx) error {
+		return tx.Do(func(tx *db.WrappedTx) error {
+			return tx.QueryRow(q, args...).Scan(&snapshot.StreamID, &snapshot.Category, &snapshot.Version, &snapshot.SchemaVersion, &snapshot.Timestamp, &snapshot.Data)
+		})
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get snapshot")
+	}
+	return &snapshot, nil
+}
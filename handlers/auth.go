@@ -2,9 +2,9 @@ package handlers
 
 import (
 	"context"
-	"crypto/rsa"
 	"encoding/json"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/sorintlab/sircles/auth"
@@ -14,6 +14,7 @@ import (
 	"github.com/sorintlab/sircles/db"
 	"github.com/sorintlab/sircles/eventstore"
 	ln "github.com/sorintlab/sircles/listennotify"
+	"github.com/sorintlab/sircles/models"
 	"github.com/sorintlab/sircles/readdb"
 	"github.com/sorintlab/sircles/util"
 
@@ -25,11 +26,15 @@ import (
 )
 
 type TokenSigningData struct {
-	Duration   uint
-	Method     jwt.SigningMethod
-	PrivateKey *rsa.PrivateKey
-	PublicKey  *rsa.PublicKey
-	Key        []byte
+	// access and refresh tokens durations in seconds
+	AccessTokenDuration  uint
+	RefreshTokenDuration uint
+
+	Method jwt.SigningMethod
+	// rsa signing keys
+	Keys *SigningKeys
+	// hmac signing key
+	Key []byte
 }
 
 type loginRequest struct {
@@ -37,36 +42,71 @@ type loginRequest struct {
 	Password string
 }
 type loginResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
 }
 
 type oidAuthURLResponse struct {
 	URL string `json:"url"`
 }
 
-func generateToken(sd *TokenSigningData, userid string) (string, error) {
+func generateToken(sd *TokenSigningData, userid, sessionid string, expiration time.Time) (string, error) {
 	token := jwt.NewWithClaims(sd.Method, jwt.MapClaims{
 		"sub": userid,
-		"exp": time.Now().Add(time.Duration(sd.Duration) * time.Second).Unix(),
+		"jti": sessionid,
+		"exp": expiration.Unix(),
 	})
 
 	var key interface{}
 	switch sd.Method {
 	case jwt.SigningMethodRS256:
-		key = sd.PrivateKey
+		signingKey := sd.Keys.SigningKey()
+		token.Header["kid"] = signingKey.ID
+		key = signingKey.PrivateKey
 	case jwt.SigningMethodHS256:
 		key = sd.Key
 	default:
-		errors.Errorf("unsupported signing method %q", sd.Method.Alg())
+		return "", errors.Errorf("unsupported signing method %q", sd.Method.Alg())
 	}
 	// Sign and get the complete encoded token as a string
 	return token.SignedString(key)
 }
 
+// generateAccessToken returns an access token for the session. It expires
+// after the access token duration but not after the session
+func generateAccessToken(sd *TokenSigningData, memberID, sessionID util.ID, sessionExpiration time.Time) (string, error) {
+	expiration := time.Now().Add(time.Duration(sd.AccessTokenDuration) * time.Second)
+	if expiration.After(sessionExpiration) {
+		expiration = sessionExpiration
+	}
+	return generateToken(sd, memberID.String(), sessionID.String(), expiration)
+}
+
+// newSessionToken creates a new member session, lasting the refresh token
+// duration, and returns an access token with the session id as its id (jti)
+// and the session refresh token. It waits for the session to be applied to
+// the read db so the tokens can be immediately used
+func newSessionToken(ctx context.Context, commandService *command.CommandService, readDBListener readdb.ReadDBListener, sd *TokenSigningData, memberID util.ID) (*loginResponse, error) {
+	sessionExpiration := time.Now().Add(time.Duration(sd.RefreshTokenDuration) * time.Second)
+	sessionID, refreshToken, groupID, err := commandService.CreateSession(ctx, memberID, sessionExpiration)
+	if err != nil {
+		return nil, err
+	}
+	if err := readDBListener.WaitGroupID(ctx, groupID); err != nil {
+		return nil, err
+	}
+	tokenString, err := generateAccessToken(sd, memberID, sessionID, sessionExpiration)
+	if err != nil {
+		return nil, err
+	}
+	return &loginResponse{Token: tokenString, RefreshToken: refreshToken}, nil
+}
+
 type loginHandler struct {
 	config           *config.Config
 	dataDir          string
 	readDB           *db.DB
+	readDBListener   readdb.ReadDBListener
 	es               *eventstore.EventStore
 	lnf              ln.ListenerFactory
 	authenticator    auth.Authenticator
@@ -74,11 +114,12 @@ type loginHandler struct {
 	tokenSigningData *TokenSigningData
 }
 
-func NewLoginHandler(config *config.Config, dataDir string, readDB *db.DB, es *eventstore.EventStore, lnf ln.ListenerFactory, authenticator auth.Authenticator, memberProvider auth.MemberProvider, tokenSigningData *TokenSigningData) *loginHandler {
+func NewLoginHandler(config *config.Config, dataDir string, readDB *db.DB, readDBListener readdb.ReadDBListener, es *eventstore.EventStore, lnf ln.ListenerFactory, authenticator auth.Authenticator, memberProvider auth.MemberProvider, tokenSigningData *TokenSigningData) *loginHandler {
 	return &loginHandler{
 		config:           config,
 		dataDir:          dataDir,
 		readDB:           readDB,
+		readDBListener:   readDBListener,
 		es:               es,
 		lnf:              lnf,
 		authenticator:    authenticator,
@@ -211,19 +252,29 @@ func (h *loginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if member.IsDeactivated {
+		log.Errorf("auth err: member with id %s is deactivated", member.ID)
+		http.Error(w, "authentication failed", http.StatusUnauthorized)
+		return
+	}
+	if member.IsServiceAccount {
+		log.Errorf("auth err: member with id %s is a service account", member.ID)
+		http.Error(w, "authentication failed", http.StatusUnauthorized)
+		return
+	}
+
 	if err := tx.Commit(); err != nil {
 		http.Error(w, "", http.StatusInternalServerError)
 		return
 	}
 
-	tokenString, err := generateToken(h.tokenSigningData, member.ID.String())
+	lres, err := newSessionToken(ctx, commandService, h.readDBListener, h.tokenSigningData, member.ID)
 	if err != nil {
 		log.Errorf("err: %+v", err)
 		http.Error(w, "", http.StatusInternalServerError)
 		return
 	}
 
-	lres := loginResponse{tokenString}
 	lresj, err := json.Marshal(lres)
 	if err != nil {
 		http.Error(w, "", http.StatusInternalServerError)
@@ -280,26 +331,68 @@ func (h *oidcAuthURLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 type refreshTokenHandler struct {
+	dataDir          string
+	readDB           *db.DB
+	readDBListener   readdb.ReadDBListener
+	es               *eventstore.EventStore
+	lnf              ln.ListenerFactory
 	tokenSigningData *TokenSigningData
 }
 
-func NewRefreshTokenHandler(tokenSigningData *TokenSigningData) *refreshTokenHandler {
+func NewRefreshTokenHandler(dataDir string, readDB *db.DB, readDBListener readdb.ReadDBListener, es *eventstore.EventStore, lnf ln.ListenerFactory, tokenSigningData *TokenSigningData) *refreshTokenHandler {
 	return &refreshTokenHandler{
+		dataDir:          dataDir,
+		readDB:           readDB,
+		readDBListener:   readDBListener,
+		es:               es,
+		lnf:              lnf,
 		tokenSigningData: tokenSigningData,
 	}
 }
 
+// ServeHTTP rotates the provided refresh token returning a new access token
+// and refresh token. Reusing an already rotated refresh token revokes its
+// session.
 func (h *refreshTokenHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// use the provided token username for generating a new token
-	userid := r.Context().Value("userid").(string)
-	tokenString, err := generateToken(h.tokenSigningData, userid)
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	refreshToken := r.Form.Get("refresh_token")
+	if refreshToken == "" {
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	commandService := command.NewCommandService(h.dataDir, h.readDB, h.es, nil, h.lnf, false)
+	res, groupID, err := commandService.RefreshSession(ctx, refreshToken)
+	if err != nil && err != command.ErrValidation {
+		log.Errorf("err: %+v", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	if err == command.ErrValidation {
+		log.Errorf("refresh err: %v", res.GenericError)
+		// mask reported error
+		http.Error(w, "authentication failed", http.StatusUnauthorized)
+		return
+	}
+	if err := h.readDBListener.WaitGroupID(ctx, groupID); err != nil {
+		log.Errorf("err: %+v", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	tokenString, err := generateAccessToken(h.tokenSigningData, res.MemberID, res.SessionID, res.Expiration)
 	if err != nil {
+		log.Errorf("err: %+v", err)
 		http.Error(w, "", http.StatusInternalServerError)
 		return
 	}
-	log.Debugf("tokenString: %s\n", tokenString)
 
-	lres := loginResponse{tokenString}
+	lres := loginResponse{Token: tokenString, RefreshToken: res.RefreshToken}
 	lresj, err := json.Marshal(lres)
 	if err != nil {
 		http.Error(w, "", http.StatusInternalServerError)
@@ -310,10 +403,70 @@ func (h *refreshTokenHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	w.Write(lresj)
 }
 
-func LogoutHandler(w http.ResponseWriter, r *http.Request) {
+type logoutHandler struct {
+	dataDir        string
+	readDB         *db.DB
+	readDBListener readdb.ReadDBListener
+	es             *eventstore.EventStore
+	lnf            ln.ListenerFactory
+}
+
+func NewLogoutHandler(dataDir string, readDB *db.DB, readDBListener readdb.ReadDBListener, es *eventstore.EventStore, lnf ln.ListenerFactory) *logoutHandler {
+	return &logoutHandler{
+		dataDir:        dataDir,
+		readDB:         readDB,
+		readDBListener: readDBListener,
+		es:             es,
+		lnf:            lnf,
+	}
+}
+
+// ServeHTTP revokes the session of the provided token
+func (h *logoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// api tokens have no session, they must be revoked
+	sessionIDString, ok := ctx.Value("sessionid").(string)
+	if !ok {
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	sessionID, err := util.IDFromString(sessionIDString)
+	if err != nil {
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	commandService := command.NewCommandService(h.dataDir, h.readDB, h.es, nil, h.lnf, false)
+	res, groupID, err := commandService.RevokeSession(ctx, sessionID)
+	if err != nil && err != command.ErrValidation {
+		log.Errorf("err: %+v", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	if err == command.ErrValidation {
+		// the session doesn't exist anymore (revoked or expired) or isn't of
+		// the calling member
+		log.Errorf("failed to revoke session: %v", res.GenericError)
+		http.Error(w, "", http.StatusUnauthorized)
+		return
+	}
+	// groupID is nil when the session has been concurrently revoked
+	if groupID != util.NilID {
+		if err := h.readDBListener.WaitGroupID(ctx, groupID); err != nil {
+			log.Errorf("err: %+v", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+
 	w.WriteHeader(http.StatusOK)
 }
 
+// errAuthenticationFailed is returned when the token isn't valid. The real
+// cause is only logged and masked to the client.
+var errAuthenticationFailed = errors.New("authentication failed")
+
 type AuthHandler struct {
 	db               *db.DB
 	tokenSigningData *TokenSigningData
@@ -331,18 +484,54 @@ func NewAuthHandler(db *db.DB, sd *TokenSigningData) func(http.Handler) http.Han
 }
 
 func (h *AuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	tokenString, err := jwtrequest.AuthorizationHeaderExtractor.ExtractToken(r)
+	if err != nil {
+		log.Errorf("err: %+v", err)
+		http.Error(w, "", http.StatusUnauthorized)
+		return
+	}
 
-	token, err := jwtrequest.ParseFromRequest(r, jwtrequest.AuthorizationHeaderExtractor, func(token *jwt.Token) (interface{}, error) {
+	ctx, err := authenticateToken(r.Context(), h.db, h.tokenSigningData, tokenString)
+	if err == errAuthenticationFailed {
+		http.Error(w, "authentication failed", http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		log.Errorf("err: %+v", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	h.next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// authenticateToken checks that the token (an access token or an api token)
+// is valid and that its session or api token and its member still exist and
+// are active. It returns the context with the authenticated member data or
+// errAuthenticationFailed.
+func authenticateToken(ctx context.Context, readDB *db.DB, sd *TokenSigningData, tokenString string) (context.Context, error) {
+	if strings.HasPrefix(tokenString, util.APITokenPrefix) {
+		return authenticateAPIToken(ctx, readDB, tokenString)
+	}
+	return authenticateAccessToken(ctx, readDB, sd, tokenString)
+}
+
+func authenticateAccessToken(ctx context.Context, readDB *db.DB, sd *TokenSigningData, tokenString string) (context.Context, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		// Validate the alg
-		sd := h.tokenSigningData
 		if token.Method != sd.Method {
 			return nil, errors.Errorf("Unexpected signing method: %v", token.Header["alg"])
 		}
 		var key interface{}
 		switch sd.Method {
 		case jwt.SigningMethodRS256:
-			key = sd.PrivateKey
+			// tokens are verified with the key reported in their kid header
+			// that could be a retired key
+			kid, _ := token.Header["kid"].(string)
+			signingKey := sd.Keys.Key(kid)
+			if signingKey == nil {
+				return nil, errors.Errorf("unknown signing key %q", kid)
+			}
+			key = signingKey.PublicKey
 		case jwt.SigningMethodHS256:
 			key = sd.Key
 		default:
@@ -352,61 +541,123 @@ func (h *AuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		log.Errorf("err: %+v", err)
-		http.Error(w, "", http.StatusUnauthorized)
-		return
+		return nil, errAuthenticationFailed
 	}
 	if !token.Valid {
-		http.Error(w, "", http.StatusUnauthorized)
-		return
+		return nil, errAuthenticationFailed
 	}
-	// Set username in the request context
 	claims := token.Claims.(jwt.MapClaims)
 
-	tx, err := h.db.NewTx()
-	if err != nil {
-		log.Errorf("err: %+v", err)
-		http.Error(w, "", http.StatusInternalServerError)
-		return
+	userIDString, ok := claims["sub"].(string)
+	if !ok {
+		return nil, errAuthenticationFailed
 	}
-	defer tx.Commit()
-
-	readDBService, err := readdb.NewReadDBService(tx)
+	userID, err := uuid.FromString(userIDString)
 	if err != nil {
 		log.Errorf("err: %+v", err)
-		http.Error(w, "", http.StatusInternalServerError)
-		return
+		return nil, errAuthenticationFailed
 	}
-
-	userIDString, ok := claims["sub"].(string)
+	sessionIDString, ok := claims["jti"].(string)
 	if !ok {
-		http.Error(w, "", http.StatusUnauthorized)
-		return
+		return nil, errAuthenticationFailed
 	}
-	userID, err := uuid.FromString(userIDString)
+	sessionID, err := util.IDFromString(sessionIDString)
 	if err != nil {
 		log.Errorf("err: %+v", err)
-		http.Error(w, "", http.StatusUnauthorized)
-		return
+		return nil, errAuthenticationFailed
 	}
 
-	member, err := readDBService.Member(ctx, readDBService.CurTimeLine(ctx).Number(), util.NewFromUUID(userID))
+	err = readDB.Do(func(tx *db.Tx) error {
+		readDBService, err := readdb.NewReadDBService(tx)
+		if err != nil {
+			return err
+		}
+
+		member, err := readDBService.Member(ctx, readDBService.CurTimeLine(ctx).Number(), util.NewFromUUID(userID))
+		if err != nil {
+			log.Errorf("auth err: %+v", err)
+			return errAuthenticationFailed
+		}
+		if member == nil {
+			log.Errorf("member with id %s doesn't exist", userID)
+			return errAuthenticationFailed
+		}
+		if member.IsDeactivated {
+			log.Errorf("member with id %s is deactivated", userID)
+			return errAuthenticationFailed
+		}
+
+		// check that the token session exists (not revoked)
+		session, err := readDBService.Session(ctx, sessionID)
+		if err != nil {
+			log.Errorf("auth err: %+v", err)
+			return errAuthenticationFailed
+		}
+		if session == nil || session.MemberID != member.ID {
+			log.Errorf("session with id %s doesn't exist or has been revoked", sessionID)
+			return errAuthenticationFailed
+		}
+		return nil
+	})
 	if err != nil {
-		log.Errorf("auth err: %+v", err)
-		// mask reported error
-		http.Error(w, "authentication failed", http.StatusUnauthorized)
-		return
-	}
-	if member == nil {
-		log.Errorf("member with id %s doesn't exist", userID)
-		// mask reported error
-		http.Error(w, "authentication failed", http.StatusUnauthorized)
-		return
-	}
-	if err := tx.Commit(); err != nil {
-		http.Error(w, "", http.StatusInternalServerError)
-		return
+		return nil, err
 	}
+
 	ctx = context.WithValue(ctx, "userid", userIDString)
+	ctx = context.WithValue(ctx, "sessionid", sessionIDString)
 	log.Debugf("userid: %s", ctx.Value("userid"))
-	h.next.ServeHTTP(w, r.WithContext(ctx))
+	return ctx, nil
+}
+
+// authenticateAPIToken authenticates using an api token. The token id and
+// scope are saved in the context so the command service can check the
+// permitted commands. Since there's no session the token cannot be refreshed
+// or logged out.
+func authenticateAPIToken(ctx context.Context, readDB *db.DB, tokenString string) (context.Context, error) {
+	var apiToken *models.APIToken
+	err := readDB.Do(func(tx *db.Tx) error {
+		readDBService, err := readdb.NewReadDBService(tx)
+		if err != nil {
+			return err
+		}
+		curTlSeq := readDBService.CurTimeLine(ctx).Number()
+
+		apiToken, err = readDBService.APITokenByHash(ctx, curTlSeq, util.TokenHash(tokenString))
+		if err != nil {
+			log.Errorf("auth err: %+v", err)
+			return errAuthenticationFailed
+		}
+		if apiToken == nil {
+			log.Errorf("api token doesn't exist or has been revoked")
+			return errAuthenticationFailed
+		}
+		if !apiToken.Expiration.After(time.Now()) {
+			log.Errorf("api token with id %s is expired", apiToken.ID)
+			return errAuthenticationFailed
+		}
+
+		member, err := readDBService.Member(ctx, curTlSeq, apiToken.MemberID)
+		if err != nil {
+			log.Errorf("auth err: %+v", err)
+			return errAuthenticationFailed
+		}
+		if member == nil {
+			log.Errorf("member with id %s doesn't exist", apiToken.MemberID)
+			return errAuthenticationFailed
+		}
+		if member.IsDeactivated {
+			log.Errorf("member with id %s is deactivated", apiToken.MemberID)
+			return errAuthenticationFailed
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, "userid", apiToken.MemberID.String())
+	ctx = context.WithValue(ctx, "apitokenid", apiToken.ID.String())
+	ctx = context.WithValue(ctx, "apitokenscope", apiToken.Scope.String())
+	log.Debugf("userid: %s, apitokenid: %s", ctx.Value("userid"), ctx.Value("apitokenid"))
+	return ctx, nil
 }
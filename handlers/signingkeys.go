@@ -0,0 +1,322 @@
+package handlers
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sorintlab/sircles/common"
+	"github.com/sorintlab/sircles/util"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/pkg/errors"
+)
+
+const (
+	signingKeysReloadInterval = 1 * time.Minute
+	// jwksMaxAge is how long the verifiers can cache the json web key set
+	jwksMaxAge = 5 * time.Minute
+	// signingKeyPublishDelay is how long a new key must be published before
+	// signing the tokens: every instance reloads it and the verifiers refetch
+	// the json web key set
+	signingKeyPublishDelay = jwksMaxAge + signingKeysReloadInterval
+)
+
+// SigningKey is a rsa key pair used to sign and verify the tokens. Its ID is
+// set as the token kid header
+type SigningKey struct {
+	ID         string
+	PrivateKey *rsa.PrivateKey
+	PublicKey  *rsa.PublicKey
+}
+
+// SigningKeyID returns the RFC 7638 thumbprint of the public key. It's used as
+// the key id of keys without an explicit id
+func SigningKeyID(publicKey *rsa.PublicKey) string {
+	// the members must be in lexicographic order without whitespaces
+	thumbprint := `{"e":"` + base64BigInt(big.NewInt(int64(publicKey.E))) + `","kty":"RSA","n":"` + base64BigInt(publicKey.N) + `"}`
+	sum := sha256.Sum256([]byte(thumbprint))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// ParseSigningKey parses a pem encoded, optionally encrypted, rsa private key
+func ParseSigningKey(id string, privateKeyData []byte, password string) (*SigningKey, error) {
+	privateKeyData, err := util.DecryptPEMKey(privateKeyData, password)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyData)
+	if err != nil {
+		return nil, err
+	}
+	if id == "" {
+		id = SigningKeyID(&privateKey.PublicKey)
+	}
+	return &SigningKey{ID: id, PrivateKey: privateKey, PublicKey: &privateKey.PublicKey}, nil
+}
+
+// SigningKeys is the set of rsa keys used to sign and verify the tokens. The
+// new tokens are signed with the signing key while all the keys verify them.
+//
+// When created from a keys directory every pem file in it is a private key
+// with the file name (without the .pem extension) as its id. A key is published
+// as soon as it's loaded but it signs the tokens only when its file is older
+// than signingKeyPublishDelay, so the other instances and the verifiers know it
+// before receiving its tokens. The signing key is the published key with the
+// greatest id. If no key is published since enough time (like at the first
+// start) the key with the greatest id is used.
+//
+// The directory is reloaded by Reload so the keys can be rotated without a
+// restart: a removed key is retired and keeps verifying the tokens it signed
+// until the retention (the access token lifetime) expires.
+type SigningKeys struct {
+	dir       string
+	password  string
+	retention time.Duration
+	tg        common.TimeGenerator
+
+	m          sync.RWMutex
+	signingKey *SigningKey
+	keys       map[string]*SigningKey
+	// retired keys with their removal time
+	retired map[string]time.Time
+}
+
+// NewSigningKeys returns a signing keys set made of a single key
+func NewSigningKeys(key *SigningKey) *SigningKeys {
+	return &SigningKeys{
+		signingKey: key,
+		keys:       map[string]*SigningKey{key.ID: key},
+		retired:    map[string]time.Time{},
+	}
+}
+
+// NewSigningKeysFromDir returns a signing keys set loaded from the keys
+// directory
+func NewSigningKeysFromDir(dir, password string, retention time.Duration, tg common.TimeGenerator) (*SigningKeys, error) {
+	s := &SigningKeys{
+		dir:       dir,
+		password:  password,
+		retention: retention,
+		tg:        tg,
+		keys:      map[string]*SigningKey{},
+		retired:   map[string]time.Time{},
+	}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// readDir returns the keys in the keys dir and their publication time (the
+// key file modification time)
+func (s *SigningKeys) readDir() (map[string]*SigningKey, map[string]time.Time, error) {
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read keys dir")
+	}
+	keys := map[string]*SigningKey{}
+	published := map[string]time.Time{}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".pem" {
+			continue
+		}
+		id := strings.TrimSuffix(f.Name(), ".pem")
+		data, err := ioutil.ReadFile(filepath.Join(s.dir, f.Name()))
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to read key %q", f.Name())
+		}
+		key, err := ParseSigningKey(id, data, s.password)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to parse key %q", f.Name())
+		}
+		keys[id] = key
+		published[id] = f.ModTime()
+	}
+	return keys, published, nil
+}
+
+// Reload reloads the keys directory. On errors the current keys are kept. It's
+// a noop when the keys aren't loaded from a directory
+func (s *SigningKeys) Reload() error {
+	if s.dir == "" {
+		return nil
+	}
+	dirKeys, published, err := s.readDir()
+	if err != nil {
+		return err
+	}
+	if len(dirKeys) == 0 {
+		return errors.Errorf("no keys in keys dir %q", s.dir)
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	now := s.tg.Now()
+	keys := map[string]*SigningKey{}
+	retired := map[string]time.Time{}
+	for id, key := range s.keys {
+		if _, ok := dirKeys[id]; ok {
+			continue
+		}
+		removalTime, ok := s.retired[id]
+		if !ok {
+			removalTime = now
+			log.Infof("retiring token signing key %q", id)
+		}
+		if now.Sub(removalTime) >= s.retention {
+			log.Infof("removing retired token signing key %q", id)
+			continue
+		}
+		keys[id] = key
+		retired[id] = removalTime
+	}
+
+	var signingKey, lastKey *SigningKey
+	for id, key := range dirKeys {
+		if _, ok := s.keys[id]; !ok {
+			log.Infof("adding token signing key %q", id)
+		}
+		keys[id] = key
+		if lastKey == nil || id > lastKey.ID {
+			lastKey = key
+		}
+		if now.Sub(published[id]) < signingKeyPublishDelay {
+			continue
+		}
+		if signingKey == nil || id > signingKey.ID {
+			signingKey = key
+		}
+	}
+	if signingKey == nil {
+		signingKey = lastKey
+	}
+	if s.signingKey != nil && s.signingKey.ID != signingKey.ID {
+		log.Infof("token signing key changed from %q to %q", s.signingKey.ID, signingKey.ID)
+	}
+
+	s.signingKey = signingKey
+	s.keys = keys
+	s.retired = retired
+	return nil
+}
+
+// Run periodically reloads the keys until stop is closed
+func (s *SigningKeys) Run(stop chan struct{}) chan struct{} {
+	endCh := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-time.After(signingKeysReloadInterval):
+				if err := s.Reload(); err != nil {
+					log.Errorf("failed to reload token signing keys: %+v", err)
+				}
+
+			case <-stop:
+				close(endCh)
+				return
+			}
+		}
+	}()
+
+	return endCh
+}
+
+// SigningKey returns the key used to sign the new tokens
+func (s *SigningKeys) SigningKey() *SigningKey {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	return s.signingKey
+}
+
+// Key returns the verification key with the provided id. An empty id, used by
+// tokens issued without a kid header, returns the signing key
+func (s *SigningKeys) Key(id string) *SigningKey {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	if id == "" {
+		return s.signingKey
+	}
+	return s.keys[id]
+}
+
+// Keys returns all the verification keys ordered by id
+func (s *SigningKeys) Keys() []*SigningKey {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	keys := make([]*SigningKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	return keys
+}
+
+func base64BigInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+// JSONWebKey is a RFC 7517 rsa public json web key
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jwksResponse struct {
+	Keys []*JSONWebKey `json:"keys"`
+}
+
+type jwksHandler struct {
+	tokenSigningData *TokenSigningData
+}
+
+// NewJWKSHandler returns a handler serving the public keys verifying the
+// tokens as a json web key set. The set is empty when the tokens are signed
+// with hmac since its key is a secret
+func NewJWKSHandler(tokenSigningData *TokenSigningData) *jwksHandler {
+	return &jwksHandler{
+		tokenSigningData: tokenSigningData,
+	}
+}
+
+func (h *jwksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	res := &jwksResponse{Keys: []*JSONWebKey{}}
+	if h.tokenSigningData.Keys != nil {
+		for _, key := range h.tokenSigningData.Keys.Keys() {
+			res.Keys = append(res.Keys, &JSONWebKey{
+				Kty: "RSA",
+				Use: "sig",
+				Alg: jwt.SigningMethodRS256.Alg(),
+				Kid: key.ID,
+				N:   base64BigInt(key.PublicKey.N),
+				E:   base64BigInt(big.NewInt(int64(key.PublicKey.E))),
+			})
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	// let verifiers cache the keys only for a short time since they can be
+	// rotated
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(jwksMaxAge.Seconds())))
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		log.Errorf("err: %+v", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+}
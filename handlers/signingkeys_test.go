@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testTimeGenerator struct {
+	now time.Time
+}
+
+func (tg *testTimeGenerator) Now() time.Time {
+	return tg.now
+}
+
+// writeTestKey writes a new key to the keys dir with the provided
+// modification time, that is its publication time
+func writeTestKey(t *testing.T, dir, id, password string, modTime time.Time) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	if password != "" {
+		block, err = x509.EncryptPEMBlock(rand.Reader, block.Type, block.Bytes, []byte(password), x509.PEMCipherAES256)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	keyPath := filepath.Join(dir, id+".pem")
+	if err := ioutil.WriteFile(keyPath, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.Chtimes(keyPath, modTime, modTime); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return key
+}
+
+func checkKeyIDs(t *testing.T, s *SigningKeys, signingKeyID string, keyIDs ...string) {
+	if id := s.SigningKey().ID; id != signingKeyID {
+		t.Fatalf("expected signing key %q, got %q", signingKeyID, id)
+	}
+	keys := s.Keys()
+	if len(keys) != len(keyIDs) {
+		t.Fatalf("expected %d keys, got %d", len(keyIDs), len(keys))
+	}
+	for i, key := range keys {
+		if key.ID != keyIDs[i] {
+			t.Fatalf("expected key %q, got %q", keyIDs[i], key.ID)
+		}
+	}
+}
+
+func TestSigningKeysRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tg := &testTimeGenerator{now: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	writeTestKey(t, dir, "key01", "", tg.now.Add(-24*time.Hour))
+	// not pem files are ignored
+	if err := ioutil.WriteFile(filepath.Join(dir, "README"), []byte("keys"), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s, err := NewSigningKeysFromDir(dir, "", time.Hour, tg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkKeyIDs(t, s, "key01", "key01")
+
+	// the new key with the greatest id is published and becomes the signing
+	// key after the publish delay
+	writeTestKey(t, dir, "key02", "", tg.now)
+	if err := s.Reload(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkKeyIDs(t, s, "key01", "key01", "key02")
+
+	tg.now = tg.now.Add(signingKeyPublishDelay)
+	if err := s.Reload(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkKeyIDs(t, s, "key02", "key01", "key02")
+
+	// the removed key keeps verifying until the retention expires
+	if err := os.Remove(filepath.Join(dir, "key01.pem")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Reload(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkKeyIDs(t, s, "key02", "key01", "key02")
+	if s.Key("key01") == nil {
+		t.Fatalf("expected retired key key01")
+	}
+
+	tg.now = tg.now.Add(59 * time.Minute)
+	if err := s.Reload(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkKeyIDs(t, s, "key02", "key01", "key02")
+
+	tg.now = tg.now.Add(time.Minute)
+	if err := s.Reload(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkKeyIDs(t, s, "key02", "key02")
+	if s.Key("key01") != nil {
+		t.Fatalf("unexpected removed key key01")
+	}
+
+	// on errors the current keys are kept
+	if err := os.Remove(filepath.Join(dir, "key02.pem")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Reload(); err == nil {
+		t.Fatalf("expected error reloading an empty keys dir")
+	}
+	checkKeyIDs(t, s, "key02", "key02")
+}
+
+func TestSigningKeysEncrypted(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tg := &testTimeGenerator{now: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	key := writeTestKey(t, dir, "key01", "password", tg.now)
+	if _, err := NewSigningKeysFromDir(dir, "", time.Hour, tg); err == nil {
+		t.Fatalf("expected error loading an encrypted key without password")
+	}
+	if _, err := NewSigningKeysFromDir(dir, "wrongpassword", time.Hour, tg); err == nil {
+		t.Fatalf("expected error loading an encrypted key with a wrong password")
+	}
+	s, err := NewSigningKeysFromDir(dir, "password", time.Hour, tg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.SigningKey().PublicKey.N.Cmp(key.N) != 0 {
+		t.Fatalf("wrong decrypted key")
+	}
+}
+
+func TestSigningKeysPublishedBeforeSigning(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tg := &testTimeGenerator{now: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	// at the first start the only key signs the tokens
+	writeTestKey(t, dir, "key01", "", tg.now)
+	s, err := NewSigningKeysFromDir(dir, "", time.Hour, tg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkKeyIDs(t, s, "key01", "key01")
+
+	// rotate the keys reloading them every reload interval and check that a
+	// key signs the tokens only when published since at least the publish
+	// delay
+	publishedAt := map[string]time.Time{"key01": tg.now.Add(-signingKeyPublishDelay)}
+	for i := 0; i < 60; i++ {
+		switch i {
+		case 10:
+			writeTestKey(t, dir, "key02", "", tg.now)
+		case 13:
+			// a new key added before the previous one signs the tokens
+			writeTestKey(t, dir, "key03", "", tg.now)
+		case 30:
+			if err := os.Remove(filepath.Join(dir, "key01.pem")); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		if err := s.Reload(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, key := range s.Keys() {
+			if _, ok := publishedAt[key.ID]; !ok {
+				publishedAt[key.ID] = tg.now
+			}
+		}
+
+		signingKeyID := s.SigningKey().ID
+		if d := tg.now.Sub(publishedAt[signingKeyID]); d < signingKeyPublishDelay {
+			t.Fatalf("key %q used for signing after being published for %s", signingKeyID, d)
+		}
+
+		tg.now = tg.now.Add(signingKeysReloadInterval)
+	}
+
+	if id := s.SigningKey().ID; id != "key03" {
+		t.Fatalf("expected signing key %q, got %q", "key03", id)
+	}
+}
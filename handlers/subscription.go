@@ -0,0 +1,474 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	graphqlapi "github.com/sorintlab/sircles/api/graphql"
+	"github.com/sorintlab/sircles/config"
+	"github.com/sorintlab/sircles/db"
+	ln "github.com/sorintlab/sircles/listennotify"
+	"github.com/sorintlab/sircles/readdb"
+	"github.com/sorintlab/sircles/util"
+
+	"github.com/gorilla/websocket"
+	"github.com/neelance/graphql-go"
+	"github.com/pkg/errors"
+)
+
+const (
+	// graphqlWSProtocol is the websocket subprotocol used by the apollo
+	// subscriptions-transport-ws clients
+	graphqlWSProtocol = "graphql-ws"
+
+	subscriptionKeepAliveInterval = 20 * time.Second
+	// subscriptionCheckInterval is the interval of the timeline and
+	// authentication checks done also without readdb notifications
+	subscriptionCheckInterval = 10 * time.Second
+	// connectionInitTimeout is the time a client has to send the
+	// connection_init message
+	connectionInitTimeout = 10 * time.Second
+
+	maxMessageSize = 1024 * 1024
+
+	maxConnectionSubscriptions = 100
+)
+
+// graphql-ws protocol message types
+const (
+	gqlConnectionInit      = "connection_init"
+	gqlConnectionAck       = "connection_ack"
+	gqlConnectionError     = "connection_error"
+	gqlConnectionKeepAlive = "ka"
+	gqlConnectionTerminate = "connection_terminate"
+	gqlStart               = "start"
+	gqlStop                = "stop"
+	gqlData                = "data"
+	gqlError               = "error"
+	gqlComplete            = "complete"
+)
+
+// errConnClosed is returned when the connection has been closed by the server
+var errConnClosed = errors.New("connection closed")
+
+type gqlMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type gqlConnectionInitPayload struct {
+	AuthToken string `json:"authToken"`
+}
+
+type gqlStartPayload struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type gqlErrorPayload struct {
+	Message string `json:"message"`
+}
+
+type subscriptionHandler struct {
+	config           *config.Config
+	readDB           *db.DB
+	readDBLf         ln.ListenerFactory
+	schema           *graphql.Schema
+	tokenSigningData *TokenSigningData
+	upgrader         *websocket.Upgrader
+}
+
+// NewSubscriptionHandler returns the handler executing the graphql
+// subscriptions over websocket. The subscriptions are executed with the
+// provided schema (created from graphqlapi.SubscriptionSchema) every time the
+// readdb applies new events.
+//
+// The client is authenticated with the token provided in the authToken field
+// of the connection_init payload (browsers cannot set the Authorization header
+// of websocket requests). The token is checked again on every readdb
+// notification and every subscriptionCheckInterval and the connection is
+// closed when it isn't valid anymore (expired, session revoked, member
+// deactivated etc...).
+func NewSubscriptionHandler(config *config.Config, readDB *db.DB, readDBLf ln.ListenerFactory, schema *graphql.Schema, sd *TokenSigningData) *subscriptionHandler {
+	upgrader := &websocket.Upgrader{
+		Subprotocols: []string{graphqlWSProtocol},
+	}
+	if len(config.Web.AllowedOrigins) > 0 {
+		upgrader.CheckOrigin = func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				return true
+			}
+			for _, o := range config.Web.AllowedOrigins {
+				if o == "*" || o == origin {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	return &subscriptionHandler{
+		config:           config,
+		readDB:           readDB,
+		readDBLf:         readDBLf,
+		schema:           schema,
+		tokenSigningData: sd,
+		upgrader:         upgrader,
+	}
+}
+
+func (h *subscriptionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// the upgrader already replied with an http error
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Errorf("err: %+v", err)
+		return
+	}
+	defer conn.Close()
+	conn.SetReadLimit(maxMessageSize)
+
+	c := &subscriptionConn{
+		h:             h,
+		conn:          conn,
+		reqCtx:        r.Context(),
+		subscriptions: map[string]*subscription{},
+	}
+	if err := c.run(); err != nil && err != errConnClosed {
+		log.Errorf("err: %+v", err)
+	}
+}
+
+type subscription struct {
+	query         string
+	operationName string
+	variables     map[string]interface{}
+	// lastTl is the timeline up to which the changes have been reported
+	lastTl util.TimeLineNumber
+}
+
+// subscriptionConn handles the graphql-ws protocol on a websocket connection
+type subscriptionConn struct {
+	h    *subscriptionHandler
+	conn *websocket.Conn
+	// reqCtx is the websocket request context
+	reqCtx context.Context
+	// authToken is the token provided in the connection_init message
+	authToken string
+	// ctx is the request context containing the authenticated member data,
+	// set when the connection is initialized
+	ctx context.Context
+
+	subscriptions map[string]*subscription
+}
+
+func (c *subscriptionConn) run() error {
+	l := c.h.readDBLf.NewListener()
+	if err := l.Listen("readdb"); err != nil {
+		return err
+	}
+	defer l.Close()
+
+	msgCh := make(chan []byte)
+	errCh := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for {
+			_, msg, err := c.conn.ReadMessage()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			select {
+			case msgCh <- msg:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	keepAliveTicker := time.NewTicker(subscriptionKeepAliveInterval)
+	defer keepAliveTicker.Stop()
+	checkTicker := time.NewTicker(subscriptionCheckInterval)
+	defer checkTicker.Stop()
+	initTimer := time.NewTimer(connectionInitTimeout)
+	defer initTimer.Stop()
+
+	for {
+		select {
+		case msg := <-msgCh:
+			terminate, err := c.handleMessage(msg)
+			if err != nil {
+				return err
+			}
+			if terminate {
+				return c.close(websocket.CloseNormalClosure, "")
+			}
+
+		case err := <-errCh:
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				return nil
+			}
+			return err
+
+		case <-l.NotificationChannel():
+			if err := c.check(); err != nil {
+				return err
+			}
+
+		case <-checkTicker.C:
+			if err := c.check(); err != nil {
+				return err
+			}
+
+		case <-initTimer.C:
+			if c.ctx == nil {
+				return c.close(websocket.ClosePolicyViolation, "connection not initialized")
+			}
+
+		case <-keepAliveTicker.C:
+			if c.ctx == nil {
+				continue
+			}
+			if err := c.send(&gqlMessage{Type: gqlConnectionKeepAlive}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handleMessage handles a client message and reports if the client asked to
+// terminate the connection
+func (c *subscriptionConn) handleMessage(data []byte) (bool, error) {
+	var msg gqlMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return false, c.sendError(gqlConnectionError, "", errors.New("invalid message"))
+	}
+
+	switch msg.Type {
+	case gqlConnectionInit:
+		if c.ctx != nil {
+			return false, c.sendError(gqlConnectionError, "", errors.New("connection already initialized"))
+		}
+		var params gqlConnectionInitPayload
+		if len(msg.Payload) > 0 {
+			if err := json.Unmarshal(msg.Payload, &params); err != nil {
+				return false, c.sendError(gqlConnectionError, "", errors.New("invalid connection init payload"))
+			}
+		}
+		ctx, err := authenticateToken(c.reqCtx, c.h.readDB, c.h.tokenSigningData, params.AuthToken)
+		if err == errAuthenticationFailed {
+			if err := c.sendError(gqlConnectionError, "", err); err != nil {
+				return false, err
+			}
+			return false, c.close(websocket.ClosePolicyViolation, err.Error())
+		}
+		if err != nil {
+			return false, err
+		}
+		c.authToken = params.AuthToken
+		c.ctx = ctx
+		if err := c.send(&gqlMessage{Type: gqlConnectionAck}); err != nil {
+			return false, err
+		}
+		return false, c.send(&gqlMessage{Type: gqlConnectionKeepAlive})
+
+	case gqlConnectionTerminate:
+		return true, nil
+
+	case gqlStart:
+		if c.ctx == nil {
+			return false, c.sendError(gqlError, msg.ID, errors.New("connection not initialized"))
+		}
+		return false, c.start(msg.ID, msg.Payload)
+
+	case gqlStop:
+		delete(c.subscriptions, msg.ID)
+		return false, c.send(&gqlMessage{ID: msg.ID, Type: gqlComplete})
+
+	default:
+		return false, c.sendError(gqlError, msg.ID, errors.Errorf("unknown message type %q", msg.Type))
+	}
+}
+
+func (c *subscriptionConn) start(id string, payload json.RawMessage) error {
+	if id == "" {
+		return c.sendError(gqlError, id, errors.New("missing subscription id"))
+	}
+	if _, ok := c.subscriptions[id]; ok {
+		return c.sendError(gqlError, id, errors.Errorf("subscription %q already started", id))
+	}
+	if len(c.subscriptions) >= maxConnectionSubscriptions {
+		return c.sendError(gqlError, id, errors.New("too many subscriptions"))
+	}
+
+	var params gqlStartPayload
+	if err := json.Unmarshal(payload, &params); err != nil {
+		return c.sendError(gqlError, id, errors.New("invalid start payload"))
+	}
+	query, err := graphqlapi.SubscriptionQuery(params.Query)
+	if err != nil {
+		return c.sendError(gqlError, id, err)
+	}
+
+	curTl, err := c.curTimeLine()
+	if err != nil {
+		return err
+	}
+
+	s := &subscription{
+		query:         query,
+		operationName: params.OperationName,
+		variables:     params.Variables,
+		lastTl:        curTl,
+	}
+
+	// execute the subscription to validate it, without changes all the fields
+	// are null
+	response := c.exec(s, curTl)
+	if len(response.Errors) > 0 {
+		for _, err := range response.Errors {
+			log.Errorf("err: %+v", err.ResolverError)
+		}
+		// report the first error like the other errors, an object with a message
+		errorJSON, err := json.Marshal(response.Errors[0])
+		if err != nil {
+			return err
+		}
+		return c.send(&gqlMessage{ID: id, Type: gqlError, Payload: errorJSON})
+	}
+
+	c.subscriptions[id] = s
+	return nil
+}
+
+// check checks that the connection token is still valid, closing the
+// connection if not, and notifies the subscriptions
+func (c *subscriptionConn) check() error {
+	if c.ctx == nil {
+		return nil
+	}
+	if _, err := authenticateToken(c.reqCtx, c.h.readDB, c.h.tokenSigningData, c.authToken); err != nil {
+		if err == errAuthenticationFailed {
+			return c.close(websocket.ClosePolicyViolation, err.Error())
+		}
+		return err
+	}
+	return c.notify()
+}
+
+// notify executes the subscriptions not yet at the current timeline and
+// sends the non empty results
+func (c *subscriptionConn) notify() error {
+	if len(c.subscriptions) == 0 {
+		return nil
+	}
+
+	curTl, err := c.curTimeLine()
+	if err != nil {
+		return err
+	}
+
+	for id, s := range c.subscriptions {
+		if s.lastTl >= curTl {
+			continue
+		}
+		response := c.exec(s, curTl)
+		s.lastTl = curTl
+
+		if len(response.Errors) > 0 {
+			for _, err := range response.Errors {
+				log.Errorf("err: %+v", err.ResolverError)
+			}
+		} else {
+			changed, err := hasChanges(response.Data)
+			if err != nil {
+				return err
+			}
+			if !changed {
+				continue
+			}
+		}
+
+		responseJSON, err := json.Marshal(response)
+		if err != nil {
+			return err
+		}
+		if err := c.send(&gqlMessage{ID: id, Type: gqlData, Payload: responseJSON}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exec executes the subscription reporting the changes from its last
+// timeline to the provided one
+func (c *subscriptionConn) exec(s *subscription, tl util.TimeLineNumber) *graphql.Response {
+	utx := c.h.readDB.NewUnstartedTx()
+	defer utx.Rollback()
+
+	ctx := c.ctx
+	ctx = context.WithValue(ctx, "utx", utx)
+	ctx = context.WithValue(ctx, "config", c.h.config)
+	ctx = context.WithValue(ctx, "subscriptiontimelines", &graphqlapi.SubscriptionTimeLines{From: s.lastTl, To: tl})
+
+	return c.h.schema.Exec(ctx, s.query, s.operationName, s.variables)
+}
+
+func (c *subscriptionConn) curTimeLine() (util.TimeLineNumber, error) {
+	var tl util.TimeLineNumber
+	err := c.h.readDB.Do(func(tx *db.Tx) error {
+		readDBService, err := readdb.NewReadDBService(tx)
+		if err != nil {
+			return err
+		}
+		tl = readDBService.CurTimeLine(c.ctx).Number()
+		return nil
+	})
+	return tl, err
+}
+
+func (c *subscriptionConn) send(msg *gqlMessage) error {
+	msgJSON, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return c.conn.WriteMessage(websocket.TextMessage, msgJSON)
+}
+
+// close sends a close message and returns errConnClosed to stop handling the
+// connection
+func (c *subscriptionConn) close(code int, text string) error {
+	if err := c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, text)); err != nil {
+		return err
+	}
+	return errConnClosed
+}
+
+func (c *subscriptionConn) sendError(msgType, id string, err error) error {
+	payload, merr := json.Marshal(&gqlErrorPayload{Message: err.Error()})
+	if merr != nil {
+		return merr
+	}
+	return c.send(&gqlMessage{ID: id, Type: msgType, Payload: payload})
+}
+
+// hasChanges reports if at least one of the subscription fields isn't null
+func hasChanges(data json.RawMessage) (bool, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return false, err
+	}
+	for _, v := range fields {
+		if string(v) != "null" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,389 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	graphqlapi "github.com/sorintlab/sircles/api/graphql"
+	"github.com/sorintlab/sircles/change"
+	"github.com/sorintlab/sircles/command"
+	"github.com/sorintlab/sircles/common"
+	"github.com/sorintlab/sircles/config"
+	"github.com/sorintlab/sircles/db"
+	"github.com/sorintlab/sircles/eventhandler"
+	"github.com/sorintlab/sircles/eventstore"
+	ln "github.com/sorintlab/sircles/listennotify"
+	"github.com/sorintlab/sircles/lock"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/readdb"
+	"github.com/sorintlab/sircles/util"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/gorilla/websocket"
+	"github.com/neelance/graphql-go"
+)
+
+type subscriptionTestEnv struct {
+	tmpDir         string
+	readDB         *db.DB
+	esDB           *db.DB
+	readDBListener *readdb.DBListener
+	commandService *command.CommandService
+	sd             *TokenSigningData
+	server         *httptest.Server
+	rootRoleID     util.ID
+	// adminCtx is the context of the admin member
+	adminCtx context.Context
+
+	stop   chan struct{}
+	endChs []chan struct{}
+}
+
+// setupSubscriptionTestEnv creates a sqlite readdb and event store with the
+// readdb event handler running, the root role, an admin member and a test
+// server serving the subscription handler
+func setupSubscriptionTestEnv(t *testing.T) *subscriptionTestEnv {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	readDB, err := db.NewDB("sqlite3", filepath.Join(tmpDir, "readdb"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	esDB, err := db.NewDB("sqlite3", filepath.Join(tmpDir, "esdb"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := readDB.Migrate("readdb", readdb.Migrations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := esDB.Migrate("eventstore", eventstore.Migrations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	localLN := ln.NewLocalListenNotify()
+	lnf := ln.NewLocalListenerFactory(localLN)
+	nf := ln.NewLocalNotifierFactory(localLN)
+
+	es := eventstore.NewEventStore(esDB, nf)
+	uidGenerator := &common.DefaultUidGenerator{}
+
+	env := &subscriptionTestEnv{
+		tmpDir:         tmpDir,
+		readDB:         readDB,
+		esDB:           esDB,
+		readDBListener: readdb.NewDBListener(readDB, lnf),
+		commandService: command.NewCommandService(tmpDir, readDB, es, uidGenerator, lnf, false),
+		sd: &TokenSigningData{
+			Method: jwt.SigningMethodHS256,
+			Key:    []byte("signingkey"),
+		},
+		stop: make(chan struct{}),
+	}
+
+	for _, h := range []eventhandler.EventHandler{readdb.NewDBEventHandler(readDB, es, nf), eventhandler.NewMemberRequestHandler(es, uidGenerator)} {
+		endCh, err := eventhandler.RunEventHandler(h, env.stop, lnf, lock.NewLocalLockFactory(lock.NewLocalLocks()))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		env.endChs = append(env.endChs, endCh)
+	}
+
+	rootRoleID, groupID, err := env.commandService.SetupRootRole()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	env.waitGroupID(t, groupID)
+	env.rootRoleID = rootRoleID
+
+	adminID := env.createMember(t, "admin", true)
+	env.adminCtx = context.WithValue(context.Background(), "userid", adminID.String())
+
+	schema, err := graphql.ParseSchema(graphqlapi.SubscriptionSchema, graphqlapi.NewSubscriptionResolver())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	env.server = httptest.NewServer(NewSubscriptionHandler(&config.Config{}, readDB, lnf, schema, env.sd))
+
+	return env
+}
+
+func (env *subscriptionTestEnv) close() {
+	env.server.Close()
+	close(env.stop)
+	for _, endCh := range env.endChs {
+		<-endCh
+	}
+	env.readDB.Close()
+	env.esDB.Close()
+	os.RemoveAll(env.tmpDir)
+}
+
+func (env *subscriptionTestEnv) waitGroupID(t *testing.T, groupID util.ID) {
+	if err := env.readDBListener.WaitGroupID(context.Background(), groupID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func (env *subscriptionTestEnv) createMember(t *testing.T, userName string, isAdmin bool) util.ID {
+	res, groupID, err := env.commandService.CreateMemberInternal(context.Background(), &change.CreateMemberChange{
+		IsAdmin:  isAdmin,
+		UserName: userName,
+		FullName: userName,
+		Email:    fmt.Sprintf("%s@example.com", userName),
+		Password: "password",
+	}, false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	env.waitGroupID(t, groupID)
+	return *res.MemberID
+}
+
+func (env *subscriptionTestEnv) createRole(t *testing.T, name string) {
+	res, groupID, err := env.commandService.CircleCreateChildRole(env.adminCtx, env.rootRoleID, &change.CreateRoleChange{
+		RoleType: models.RoleTypeNormal,
+		Name:     name,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.HasErrors {
+		t.Fatalf("unexpected error: %v", res.GenericError)
+	}
+	env.waitGroupID(t, groupID)
+}
+
+// createSession creates a session for the member and returns its id and an
+// access token
+func (env *subscriptionTestEnv) createSession(t *testing.T, memberID util.ID, expiration time.Time) (util.ID, string) {
+	sessionID, _, groupID, err := env.commandService.CreateSession(env.adminCtx, memberID, time.Now().Add(1*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	env.waitGroupID(t, groupID)
+
+	token, err := generateToken(env.sd, memberID.String(), sessionID.String(), expiration)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return sessionID, token
+}
+
+// dial opens a websocket connection and sends the connection_init message
+// with the provided token
+func (env *subscriptionTestEnv) dial(t *testing.T, token string) *websocket.Conn {
+	dialer := &websocket.Dialer{Subprotocols: []string{graphqlWSProtocol}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(env.server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sendMessage(t, conn, &gqlMessage{Type: gqlConnectionInit, Payload: []byte(fmt.Sprintf(`{"authToken": %q}`, token))})
+	return conn
+}
+
+func sendMessage(t *testing.T, conn *websocket.Conn, msg *gqlMessage) {
+	if err := conn.WriteJSON(msg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// startSubscription starts the subscription and waits for the handler to
+// process it
+func startSubscription(t *testing.T, conn *websocket.Conn, id, query string) {
+	sendMessage(t, conn, &gqlMessage{ID: id, Type: gqlStart, Payload: []byte(fmt.Sprintf(`{"query": %q}`, query))})
+	// the messages are handled in order, the stop reply means that the start
+	// message has been handled
+	sendMessage(t, conn, &gqlMessage{ID: "sync", Type: gqlStop})
+	waitMessage(t, conn, gqlComplete)
+}
+
+func readMessage(conn *websocket.Conn) (*gqlMessage, error) {
+	var msg gqlMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// waitMessage waits for a message of the provided type skipping the keep
+// alive messages
+func waitMessage(t *testing.T, conn *websocket.Conn, msgType string) *gqlMessage {
+	conn.SetReadDeadline(time.Now().Add(30 * time.Second))
+	for {
+		msg, err := readMessage(conn)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if msg.Type == gqlConnectionKeepAlive {
+			continue
+		}
+		if msg.Type != msgType {
+			t.Fatalf("expected message of type %q, got %q", msgType, msg.Type)
+		}
+		return msg
+	}
+}
+
+// waitClosed waits for the connection to be closed with a policy violation
+// skipping the other messages
+func waitClosed(t *testing.T, conn *websocket.Conn) {
+	conn.SetReadDeadline(time.Now().Add(30 * time.Second))
+	for {
+		_, err := readMessage(conn)
+		if err == nil {
+			continue
+		}
+		if !websocket.IsCloseError(err, websocket.ClosePolicyViolation) {
+			t.Fatalf("expected close error with code %d, got error: %v", websocket.ClosePolicyViolation, err)
+		}
+		return
+	}
+}
+
+func TestSubscriptionAuthentication(t *testing.T) {
+	env := setupSubscriptionTestEnv(t)
+	defer env.close()
+
+	memberID := env.createMember(t, "user01", false)
+	_, token := env.createSession(t, memberID, time.Now().Add(1*time.Hour))
+
+	t.Run("valid token", func(t *testing.T) {
+		conn := env.dial(t, token)
+		defer conn.Close()
+
+		waitMessage(t, conn, gqlConnectionAck)
+
+		startSubscription(t, conn, "1", "subscription { timeLines { id } }")
+		env.createRole(t, "role01")
+		msg := waitMessage(t, conn, gqlData)
+		if msg.ID != "1" {
+			t.Fatalf("expected message for subscription %q, got %q", "1", msg.ID)
+		}
+	})
+
+	for _, token := range []string{"", "invalidtoken", token + "invalid"} {
+		t.Run(fmt.Sprintf("invalid token %q", token), func(t *testing.T) {
+			conn := env.dial(t, token)
+			defer conn.Close()
+
+			waitMessage(t, conn, gqlConnectionError)
+			waitClosed(t, conn)
+		})
+	}
+
+	t.Run("start without connection init", func(t *testing.T) {
+		dialer := &websocket.Dialer{Subprotocols: []string{graphqlWSProtocol}}
+		conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(env.server.URL, "http"), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer conn.Close()
+
+		sendMessage(t, conn, &gqlMessage{ID: "1", Type: gqlStart, Payload: []byte(`{"query": "subscription { timeLines { id } }"}`)})
+		waitMessage(t, conn, gqlError)
+	})
+}
+
+func TestSubscriptionTokenInvalidated(t *testing.T) {
+	env := setupSubscriptionTestEnv(t)
+	defer env.close()
+
+	tests := []struct {
+		name string
+		// expiration is the access token expiration
+		expiration time.Duration
+		// invalidate invalidates the token of the member session
+		invalidate func(t *testing.T, memberID, sessionID util.ID)
+	}{
+		{
+			name:       "logout",
+			expiration: 1 * time.Hour,
+			invalidate: func(t *testing.T, memberID, sessionID util.ID) {
+				ctx := context.WithValue(context.Background(), "userid", memberID.String())
+				_, groupID, err := env.commandService.RevokeSession(ctx, sessionID)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				env.waitGroupID(t, groupID)
+			},
+		},
+		{
+			name:       "all member sessions revoked",
+			expiration: 1 * time.Hour,
+			invalidate: func(t *testing.T, memberID, sessionID util.ID) {
+				// another session revoked with the same write
+				env.createSession(t, memberID, time.Now().Add(1*time.Hour))
+
+				_, groupID, err := env.commandService.RevokeMemberSessions(env.adminCtx, memberID)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				env.waitGroupID(t, groupID)
+
+				err = env.readDB.Do(func(tx *db.Tx) error {
+					readDBService, err := readdb.NewReadDBService(tx)
+					if err != nil {
+						return err
+					}
+					sessions, err := readDBService.MemberSessions(env.adminCtx, memberID)
+					if err != nil {
+						return err
+					}
+					if len(sessions) != 0 {
+						t.Fatalf("expected all sessions revoked, got %d sessions", len(sessions))
+					}
+					return nil
+				})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			},
+		},
+		{
+			name:       "member deactivated",
+			expiration: 1 * time.Hour,
+			invalidate: func(t *testing.T, memberID, sessionID util.ID) {
+				_, groupID, err := env.commandService.DeactivateMember(env.adminCtx, memberID, false)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				env.waitGroupID(t, groupID)
+			},
+		},
+		{
+			name:       "token expired",
+			expiration: 2 * time.Second,
+			invalidate: func(t *testing.T, memberID, sessionID util.ID) {
+				time.Sleep(3 * time.Second)
+				// the token is checked on readdb changes
+				env.createRole(t, "role02")
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memberID := env.createMember(t, fmt.Sprintf("user%02d", i), false)
+			sessionID, token := env.createSession(t, memberID, time.Now().Add(tt.expiration))
+
+			conn := env.dial(t, token)
+			defer conn.Close()
+
+			waitMessage(t, conn, gqlConnectionAck)
+			startSubscription(t, conn, "1", "subscription { timeLines { id } }")
+
+			tt.invalidate(t, memberID, sessionID)
+			waitClosed(t, conn)
+		})
+	}
+}
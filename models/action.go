@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+type ActionStatus string
+
+// Don't change the names since these values are usually saved in the
+// database
+const (
+	ActionStatusOpen    ActionStatus = "open"
+	ActionStatusDone    ActionStatus = "done"
+	ActionStatusDropped ActionStatus = "dropped"
+)
+
+func (s ActionStatus) String() string {
+	return string(s)
+}
+
+// IsValid reports if the status is a known action status
+func (s ActionStatus) IsValid() bool {
+	switch s {
+	case ActionStatusOpen, ActionStatusDone, ActionStatusDropped:
+		return true
+	}
+	return false
+}
+
+// IsFinal reports if the action cannot change anymore
+func (s ActionStatus) IsFinal() bool {
+	return s == ActionStatusDone || s == ActionStatusDropped
+}
+
+// Action is a next action a role has to do
+type Action struct {
+	Vertex
+	Title       string
+	Description string
+	Status      ActionStatus
+	DueDate     *time.Time
+}
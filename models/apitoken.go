@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/sorintlab/sircles/util"
+)
+
+type APITokenScope string
+
+// Don't change the names since these values are usually saved in the
+// database
+const (
+	// read only access
+	APITokenScopeReadOnly APITokenScope = "readonly"
+	// read access and tensions management
+	APITokenScopeTensions APITokenScope = "tensions"
+	// read access and all the changes to the organization (roles, circles,
+	// members, tensions etc...) except the members credentials, sessions and
+	// api tokens
+	APITokenScopeGovernance APITokenScope = "governance"
+)
+
+func (s APITokenScope) String() string {
+	return string(s)
+}
+
+// IsValid reports if the scope is a known api token scope
+func (s APITokenScope) IsValid() bool {
+	return s == APITokenScopeReadOnly || s == APITokenScopeTensions || s == APITokenScopeGovernance
+}
+
+// APIToken is a named, scoped and expiring token used to access the api
+// without an interactive login. Only the token hash is saved. A revoked api
+// token is removed
+type APIToken struct {
+	Vertex
+	MemberID     util.ID
+	Name         string
+	Scope        APITokenScope
+	CreationTime time.Time
+	Expiration   time.Time
+	TokenHash    string
+}
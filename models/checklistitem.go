@@ -0,0 +1,23 @@
+package models
+
+// ChecklistItem is a recurring action that a role reports as done or not done
+// during the circle tactical meetings
+type ChecklistItem struct {
+	Vertex
+	Description string
+}
+
+type ChecklistItems []*ChecklistItem
+
+func (c ChecklistItems) Len() int           { return len(c) }
+func (c ChecklistItems) Less(i, j int) bool { return c[i].Description < c[j].Description }
+func (c ChecklistItems) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+
+// ChecklistItemReport is the value reported for a checklist item in a
+// meeting. Only one of ChecklistItem and Meeting is populated, the other one
+// is the entity used to query the reports.
+type ChecklistItemReport struct {
+	ChecklistItem *ChecklistItem
+	Meeting       *Meeting
+	Checked       bool
+}
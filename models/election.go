@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/sorintlab/sircles/util"
+)
+
+type ElectionStatus string
+
+// Don't change the names since these values are usually saved in the
+// database
+const (
+	ElectionStatusOpen      ElectionStatus = "open"
+	ElectionStatusCompleted ElectionStatus = "completed"
+)
+
+func (s ElectionStatus) String() string {
+	return string(s)
+}
+
+// IsElectedRoleType reports if the core role type is assigned by an election.
+// The lead link is assigned by the parent circle and isn't elected.
+func (r RoleType) IsElectedRoleType() bool {
+	return r == RoleTypeFacilitator ||
+		r == RoleTypeSecretary ||
+		r == RoleTypeRepLink
+}
+
+// Election is the election of a circle core role member
+type Election struct {
+	Vertex
+	RoleType RoleType
+	Status   ElectionStatus
+	// ElectionExpiration is the expiration of the elected member term
+	ElectionExpiration *time.Time
+}
+
+// ElectionNomination is a candidate nominated in an election
+type ElectionNomination struct {
+	Candidate   *Member
+	NominatorID util.ID
+}
+
+// CoreRoleTerm is the current assignment of a core role member with an
+// election expiration
+type CoreRoleTerm struct {
+	CoreRole           *Role
+	Member             *Member
+	ElectionExpiration time.Time
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type MeetingType string
+
+// Don't change the names since these values are usually saved in the
+// database
+const (
+	MeetingTypeTactical   MeetingType = "tactical"
+	MeetingTypeGovernance MeetingType = "governance"
+)
+
+func (t MeetingType) String() string {
+	return string(t)
+}
+
+// IsValid reports if the type is a known meeting type
+func (t MeetingType) IsValid() bool {
+	return t == MeetingTypeTactical || t == MeetingTypeGovernance
+}
+
+// Meeting is a tactical or governance meeting held by a circle
+type Meeting struct {
+	Vertex
+	MeetingType MeetingType
+	Date        time.Time
+}
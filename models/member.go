@@ -8,6 +8,12 @@ type Member struct {
 	UserName string
 	FullName string
 	Email    string
+	// a service account is a non human member used for api automation. It
+	// cannot login interactively
+	IsServiceAccount bool
+	// a deactivated member cannot login but is kept (also in the current
+	// timeline) since it's referenced by other entities
+	IsDeactivated bool
 }
 
 type Avatar struct {
@@ -85,6 +91,19 @@ type MemberCircleEdge struct {
 	RepLink []*Role
 }
 
+// MemberAssignments reports all the roles and circle memberships held by a
+// member
+type MemberAssignments struct {
+	// filled normal roles
+	Roles []*MemberRoleEdge
+	// circles where the member is the lead link
+	LeadLinkCircles []*Role
+	// filled core roles (except the lead link)
+	CoreRoles []*MemberRoleEdge
+	// circles where the member is a direct member
+	DirectCircles []*Role
+}
+
 type MemberCircleEdges []*MemberCircleEdge
 
 func (p MemberCircleEdges) Len() int           { return len(p) }
@@ -0,0 +1,23 @@
+package models
+
+// Metric is a recurring measure that a role reports during the circle
+// tactical meetings
+type Metric struct {
+	Vertex
+	Description string
+}
+
+type Metrics []*Metric
+
+func (m Metrics) Len() int           { return len(m) }
+func (m Metrics) Less(i, j int) bool { return m[i].Description < m[j].Description }
+func (m Metrics) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
+
+// MetricReport is the value reported for a metric in a meeting. Only one of
+// Metric and Meeting is populated, the other one is the entity used to query
+// the reports.
+type MetricReport struct {
+	Metric  *Metric
+	Meeting *Meeting
+	Value   string
+}
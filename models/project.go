@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+type ProjectStatus string
+
+// Don't change the names since these values are usually saved in the
+// database
+const (
+	ProjectStatusActive  ProjectStatus = "active"
+	ProjectStatusWaiting ProjectStatus = "waiting"
+	ProjectStatusDone    ProjectStatus = "done"
+	ProjectStatusDropped ProjectStatus = "dropped"
+)
+
+func (s ProjectStatus) String() string {
+	return string(s)
+}
+
+// IsValid reports if the status is a known project status
+func (s ProjectStatus) IsValid() bool {
+	switch s {
+	case ProjectStatusActive, ProjectStatusWaiting, ProjectStatusDone, ProjectStatusDropped:
+		return true
+	}
+	return false
+}
+
+// IsFinal reports if the project cannot change anymore
+func (s ProjectStatus) IsFinal() bool {
+	return s == ProjectStatusDone || s == ProjectStatusDropped
+}
+
+// Project is an outcome a role is working on
+type Project struct {
+	Vertex
+	Title       string
+	Description string
+	Status      ProjectStatus
+	DueDate     *time.Time
+}
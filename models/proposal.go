@@ -0,0 +1,58 @@
+package models
+
+type ProposalStatus string
+
+// Don't change the names since these values are usually saved in the
+// database
+const (
+	ProposalStatusDraft     ProposalStatus = "draft"
+	ProposalStatusSubmitted ProposalStatus = "submitted"
+	ProposalStatusObjected  ProposalStatus = "objected"
+	ProposalStatusAccepted  ProposalStatus = "accepted"
+	ProposalStatusWithdrawn ProposalStatus = "withdrawn"
+)
+
+func (s ProposalStatus) String() string {
+	return string(s)
+}
+
+// IsFinal reports if the proposal cannot change anymore
+func (s ProposalStatus) IsFinal() bool {
+	return s == ProposalStatusAccepted || s == ProposalStatusWithdrawn
+}
+
+// IsEditable reports if the proposal title, description and changes can be
+// updated
+func (s ProposalStatus) IsEditable() bool {
+	return s == ProposalStatusDraft || s == ProposalStatusObjected
+}
+
+type Proposal struct {
+	Vertex
+	Title       string
+	Description string
+	Status      ProposalStatus
+}
+
+type ProposalObjectionStatus string
+
+// Don't change the names since these values are usually saved in the
+// database
+const (
+	ProposalObjectionStatusOpen      ProposalObjectionStatus = "open"
+	ProposalObjectionStatusResolved  ProposalObjectionStatus = "resolved"
+	ProposalObjectionStatusWithdrawn ProposalObjectionStatus = "withdrawn"
+)
+
+func (s ProposalObjectionStatus) String() string {
+	return string(s)
+}
+
+// ProposalObjection is an objection raised by a circle core member to a
+// submitted proposal
+type ProposalObjection struct {
+	Vertex
+	Reason     string
+	Resolution string
+	Status     ProposalObjectionStatus
+}
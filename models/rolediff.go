@@ -0,0 +1,28 @@
+package models
+
+// RoleDiff reports how a role changed between two timelines
+type RoleDiff struct {
+	ChangeType ChangeType
+	// PreviousRole is the role at the starting timeline, nil if the role was
+	// created
+	PreviousRole *Role
+	// Role is the role at the ending timeline, nil if the role was deleted
+	Role *Role
+
+	// PreviousParent and NewParent are set when the role was moved
+	PreviousParent *Role
+	NewParent      *Role
+
+	// created and updated items are taken at the ending timeline, deleted
+	// ones at the starting timeline
+	CreatedDomains          []*Domain
+	UpdatedDomains          []*Domain
+	DeletedDomains          []*Domain
+	CreatedAccountabilities []*Accountability
+	UpdatedAccountabilities []*Accountability
+	DeletedAccountabilities []*Accountability
+
+	// members assigned to the role (or directly to the circle)
+	AddedMembers   []*Member
+	RemovedMembers []*Member
+}
@@ -11,6 +11,7 @@ type RoleEventType string
 
 const (
 	RoleEventTypeCircleChangesApplied RoleEventType = "CircleChangesApplied"
+	RoleEventTypeProposalReviewed     RoleEventType = "ProposalReviewed"
 )
 
 type RoleEvent struct {
@@ -25,6 +26,8 @@ func GetRoleEventDataType(eventType RoleEventType) interface{} {
 	switch eventType {
 	case RoleEventTypeCircleChangesApplied:
 		return &RoleEventCircleChangesApplied{}
+	case RoleEventTypeProposalReviewed:
+		return &RoleEventProposalReviewed{}
 	default:
 		panic(fmt.Errorf("unknown role event type: %q", eventType))
 	}
@@ -82,3 +85,38 @@ func NewRoleEventCircleChangesApplied(timeLineID util.TimeLineNumber, roleID, is
 		},
 	)
 }
+
+type ProposalReviewType string
+
+const (
+	ProposalReviewTypeConsent            ProposalReviewType = "consent"
+	ProposalReviewTypeObjection          ProposalReviewType = "objection"
+	ProposalReviewTypeObjectionResolved  ProposalReviewType = "objectionresolved"
+	ProposalReviewTypeObjectionWithdrawn ProposalReviewType = "objectionwithdrawn"
+)
+
+// RoleEventProposalReviewed records a circle core member consent or
+// objection (and its resolution) to a circle proposal
+type RoleEventProposalReviewed struct {
+	ProposalID  util.ID
+	MemberID    util.ID
+	ReviewType  ProposalReviewType
+	ObjectionID *util.ID
+	// the objection reason or resolution
+	Text string
+}
+
+func NewRoleEventProposalReviewed(timeLineID util.TimeLineNumber, roleID, proposalID, memberID util.ID, reviewType ProposalReviewType, objectionID *util.ID, text string) *RoleEvent {
+	return newRoleEvent(
+		timeLineID,
+		roleID,
+		RoleEventTypeProposalReviewed,
+		&RoleEventProposalReviewed{
+			ProposalID:  proposalID,
+			MemberID:    memberID,
+			ReviewType:  reviewType,
+			ObjectionID: objectionID,
+			Text:        text,
+		},
+	)
+}
@@ -0,0 +1,11 @@
+package models
+
+// RoleTemplate is a named role shape (purpose, domains and accountabilities)
+// that can be instantiated as a role in any circle
+type RoleTemplate struct {
+	Vertex
+	Name             string
+	Purpose          string
+	Domains          []string
+	Accountabilities []string
+}
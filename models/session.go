@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/sorintlab/sircles/util"
+)
+
+// Session is a member login session. Its id is the id (jti) of the issued
+// token. A revoked session is removed. Sessions aren't part of the
+// organization history so they aren't versioned by timeline
+type Session struct {
+	ID           util.ID
+	MemberID     util.ID
+	CreationTime time.Time
+	Expiration   time.Time
+}
+
+// RefreshToken is a session refresh token. Only the token hash is saved. A
+// used refresh token is kept to detect its reuse and is removed with its
+// session
+type RefreshToken struct {
+	ID        util.ID
+	SessionID util.ID
+	TokenHash string
+	Used      bool
+}
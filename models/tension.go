@@ -1,9 +1,75 @@
 package models
 
+import "github.com/sorintlab/sircles/util"
+
+type TensionStatus string
+
+// Don't change the names since these values are usually saved in the
+// database
+const (
+	TensionStatusNew        TensionStatus = "new"
+	TensionStatusInAgenda   TensionStatus = "inagenda"
+	TensionStatusProcessing TensionStatus = "processing"
+	TensionStatusResolved   TensionStatus = "resolved"
+	TensionStatusDropped    TensionStatus = "dropped"
+)
+
+func (s TensionStatus) String() string {
+	return string(s)
+}
+
+// IsValid reports if the status is a known tension status
+func (s TensionStatus) IsValid() bool {
+	switch s {
+	case TensionStatusNew, TensionStatusInAgenda, TensionStatusProcessing, TensionStatusResolved, TensionStatusDropped:
+		return true
+	}
+	return false
+}
+
+// IsFinal reports if the tension has been closed (resolved or dropped)
+func (s TensionStatus) IsFinal() bool {
+	return s == TensionStatusResolved || s == TensionStatusDropped
+}
+
+// TensionOutcomeType defines what a tension became when resolved
+type TensionOutcomeType string
+
+// Don't change the names since these values are usually saved in the
+// database
+const (
+	TensionOutcomeTypeProject    TensionOutcomeType = "project"
+	TensionOutcomeTypeAction     TensionOutcomeType = "action"
+	TensionOutcomeTypeGovernance TensionOutcomeType = "governance"
+)
+
+func (t TensionOutcomeType) String() string {
+	return string(t)
+}
+
+// IsValid reports if the outcome type is a known tension outcome type
+func (t TensionOutcomeType) IsValid() bool {
+	switch t {
+	case TensionOutcomeTypeProject, TensionOutcomeTypeAction, TensionOutcomeTypeGovernance:
+		return true
+	}
+	return false
+}
+
 type Tension struct {
 	Vertex
 	Title       string
 	Description string
 	Closed      bool
 	CloseReason string
+	Status      TensionStatus
+	// MeetingID is the meeting where the tension was processed
+	MeetingID *util.ID
+	// OutcomeType, OutcomeReferenceID and OutcomeDescription are the
+	// structured outcome of a resolved tension. OutcomeReferenceID is the
+	// optional id of the entity (project, action, proposal) created to
+	// resolve the tension
+	OutcomeType        TensionOutcomeType
+	OutcomeReferenceID *util.ID
+	OutcomeDescription string
 }
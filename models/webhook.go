@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/sorintlab/sircles/util"
+)
+
+// Webhook is an endpoint receiving the events of the provided types. The
+// delivered payloads are signed with the webhook secret
+type Webhook struct {
+	Vertex
+	URL        string
+	EventTypes []string
+	Secret     string
+}
+
+// WebhookDeadLetter records an event that couldn't be delivered to a webhook
+// after all the retries
+type WebhookDeadLetter struct {
+	TimeLineID     util.TimeLineNumber
+	WebhookID      util.ID
+	EventID        util.ID
+	SequenceNumber int64
+	EventType      string
+	Attempts       int
+	LastError      string
+	Timestamp      time.Time
+}
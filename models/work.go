@@ -0,0 +1,10 @@
+package models
+
+// WorkStatus is the status of a role work item: a project or an action
+type WorkStatus interface {
+	String() string
+	// IsValid reports if the status is a known status of the work kind
+	IsValid() bool
+	// IsFinal reports if the work cannot change anymore
+	IsFinal() bool
+}
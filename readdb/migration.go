@@ -103,4 +103,213 @@ var Migrations = []db.Migration{
 			"create table membermatch (memberid uuid, matchuid varchar)",
 		},
 	},
+	{
+		Stmts: []string{
+			"create table proposal (id uuid, start_tl bigint, end_tl bigint, title varchar, description varchar, status varchar, PRIMARY KEY (id, start_tl))",
+			"create unique index proposal_tl on proposal(id, start_tl, end_tl DESC)",
+
+			// id is the proposalid
+			"create table proposalchanges (id uuid, start_tl bigint, end_tl bigint, changes bytea, PRIMARY KEY (id, start_tl))",
+			"create unique index proposalchanges_tl on proposalchanges(id, start_tl, end_tl DESC)",
+
+			"create table roleproposal (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: proposal id, y: role id
+			"create index roleproposal_x_start_tl on roleproposal(x, start_tl, end_tl DESC)",
+			"create index roleproposal_y_start_tl on roleproposal(y, start_tl, end_tl DESC)",
+
+			"create table memberproposal (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: proposal id, y: member id
+			"create index memberproposal_x_start_tl on memberproposal(x, start_tl, end_tl DESC)",
+			"create index memberproposal_y_start_tl on memberproposal(y, start_tl, end_tl DESC)",
+		},
+	},
+	{
+		Stmts: []string{
+			"create table proposalobjection (id uuid, start_tl bigint, end_tl bigint, reason varchar, resolution varchar, status varchar, PRIMARY KEY (id, start_tl))",
+			"create unique index proposalobjection_tl on proposalobjection(id, start_tl, end_tl DESC)",
+
+			"create table proposalproposalobjection (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: objection id, y: proposal id
+			"create index proposalproposalobjection_x_start_tl on proposalproposalobjection(x, start_tl, end_tl DESC)",
+			"create index proposalproposalobjection_y_start_tl on proposalproposalobjection(y, start_tl, end_tl DESC)",
+
+			"create table memberproposalobjection (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: objection id, y: member id
+			"create index memberproposalobjection_x_start_tl on memberproposalobjection(x, start_tl, end_tl DESC)",
+			"create index memberproposalobjection_y_start_tl on memberproposalobjection(y, start_tl, end_tl DESC)",
+
+			"create table proposalconsent (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: member id, y: proposal id
+			"create index proposalconsent_x_start_tl on proposalconsent(x, start_tl, end_tl DESC)",
+			"create index proposalconsent_y_start_tl on proposalconsent(y, start_tl, end_tl DESC)",
+		},
+	},
+	{
+		Stmts: []string{
+			"alter table tension add column status varchar",
+			"alter table tension add column meetingid uuid",
+			"alter table tension add column outcometype varchar",
+			"alter table tension add column outcomereferenceid uuid",
+			"alter table tension add column outcomedescription varchar",
+			"update tension set status = 'new', outcometype = '', outcomedescription = ''",
+			// tensions closed before the introduction of statuses are considered dropped
+			"update tension set status = 'dropped' where closed",
+
+			"create table tensionassignee (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: tension id, y: member id
+			"create index tensionassignee_x_start_tl on tensionassignee(x, start_tl, end_tl DESC)",
+			"create index tensionassignee_y_start_tl on tensionassignee(y, start_tl, end_tl DESC)",
+		},
+	},
+	{
+		Stmts: []string{
+			"create table project (id uuid, start_tl bigint, end_tl bigint, title varchar, description varchar, status varchar, duedate timestamptz, PRIMARY KEY (id, start_tl))",
+			"create unique index project_tl on project(id, start_tl, end_tl DESC)",
+
+			"create table roleproject (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: project id, y: role id
+			"create index roleproject_x_start_tl on roleproject(x, start_tl, end_tl DESC)",
+			"create index roleproject_y_start_tl on roleproject(y, start_tl, end_tl DESC)",
+
+			"create table memberproject (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: project id, y: member id
+			"create index memberproject_x_start_tl on memberproject(x, start_tl, end_tl DESC)",
+			"create index memberproject_y_start_tl on memberproject(y, start_tl, end_tl DESC)",
+
+			"create table tensionproject (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: project id, y: tension id
+			"create index tensionproject_x_start_tl on tensionproject(x, start_tl, end_tl DESC)",
+			"create index tensionproject_y_start_tl on tensionproject(y, start_tl, end_tl DESC)",
+
+			"create table action (id uuid, start_tl bigint, end_tl bigint, title varchar, description varchar, status varchar, duedate timestamptz, PRIMARY KEY (id, start_tl))",
+			"create unique index action_tl on action(id, start_tl, end_tl DESC)",
+
+			"create table roleaction (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: action id, y: role id
+			"create index roleaction_x_start_tl on roleaction(x, start_tl, end_tl DESC)",
+			"create index roleaction_y_start_tl on roleaction(y, start_tl, end_tl DESC)",
+
+			"create table memberaction (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: action id, y: member id
+			"create index memberaction_x_start_tl on memberaction(x, start_tl, end_tl DESC)",
+			"create index memberaction_y_start_tl on memberaction(y, start_tl, end_tl DESC)",
+
+			"create table tensionaction (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: action id, y: tension id
+			"create index tensionaction_x_start_tl on tensionaction(x, start_tl, end_tl DESC)",
+			"create index tensionaction_y_start_tl on tensionaction(y, start_tl, end_tl DESC)",
+		},
+	},
+	{
+		Stmts: []string{
+			"create table meeting (id uuid, start_tl bigint, end_tl bigint, meetingtype varchar, date timestamptz, PRIMARY KEY (id, start_tl))",
+			"create unique index meeting_tl on meeting(id, start_tl, end_tl DESC)",
+			"create index meeting_date on meeting(date)",
+
+			"create table rolemeeting (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: meeting id, y: role id
+			"create index rolemeeting_x_start_tl on rolemeeting(x, start_tl, end_tl DESC)",
+			"create index rolemeeting_y_start_tl on rolemeeting(y, start_tl, end_tl DESC)",
+
+			"create table meetingfacilitator (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: meeting id, y: member id
+			"create index meetingfacilitator_x_start_tl on meetingfacilitator(x, start_tl, end_tl DESC)",
+			"create index meetingfacilitator_y_start_tl on meetingfacilitator(y, start_tl, end_tl DESC)",
+
+			"create table meetingsecretary (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: meeting id, y: member id
+			"create index meetingsecretary_x_start_tl on meetingsecretary(x, start_tl, end_tl DESC)",
+			"create index meetingsecretary_y_start_tl on meetingsecretary(y, start_tl, end_tl DESC)",
+
+			"create table meetingattendee (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: member id, y: meeting id
+			"create index meetingattendee_x_start_tl on meetingattendee(x, start_tl, end_tl DESC)",
+			"create index meetingattendee_y_start_tl on meetingattendee(y, start_tl, end_tl DESC)",
+
+			"create index tension_meetingid on tension(meetingid)",
+		},
+	},
+	{
+		Stmts: []string{
+			"create table checklistitem (id uuid, start_tl bigint, end_tl bigint, description varchar, PRIMARY KEY (id, start_tl))",
+			"create unique index checklistitem_tl on checklistitem(id, start_tl, end_tl DESC)",
+
+			"create table metric (id uuid, start_tl bigint, end_tl bigint, description varchar, PRIMARY KEY (id, start_tl))",
+			"create unique index metric_tl on metric(id, start_tl, end_tl DESC)",
+
+			"create table rolechecklistitem (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: checklistitem id, y: role id
+			"create index rolechecklistitem_x_start_tl on rolechecklistitem(x, start_tl, end_tl DESC)",
+			"create index rolechecklistitem_y_start_tl on rolechecklistitem(y, start_tl, end_tl DESC)",
+
+			"create table rolemetric (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: metric id, y: role id
+			"create index rolemetric_x_start_tl on rolemetric(x, start_tl, end_tl DESC)",
+			"create index rolemetric_y_start_tl on rolemetric(y, start_tl, end_tl DESC)",
+
+			"create table meetingchecklistitem (start_tl bigint, end_tl bigint, x uuid, y uuid, checked boolean)", // x: checklistitem id, y: meeting id
+			"create index meetingchecklistitem_x_start_tl on meetingchecklistitem(x, start_tl, end_tl DESC)",
+			"create index meetingchecklistitem_y_start_tl on meetingchecklistitem(y, start_tl, end_tl DESC)",
+
+			"create table meetingmetric (start_tl bigint, end_tl bigint, x uuid, y uuid, value varchar)", // x: metric id, y: meeting id
+			"create index meetingmetric_x_start_tl on meetingmetric(x, start_tl, end_tl DESC)",
+			"create index meetingmetric_y_start_tl on meetingmetric(y, start_tl, end_tl DESC)",
+		},
+	},
+	{
+		Stmts: []string{
+			"create table election (id uuid, start_tl bigint, end_tl bigint, roletype varchar, status varchar, electionexpiration timestamptz, PRIMARY KEY (id, start_tl))",
+			"create unique index election_tl on election(id, start_tl, end_tl DESC)",
+
+			"create table roleelection (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: election id, y: role id
+			"create index roleelection_x_start_tl on roleelection(x, start_tl, end_tl DESC)",
+			"create index roleelection_y_start_tl on roleelection(y, start_tl, end_tl DESC)",
+
+			"create table electioncandidate (start_tl bigint, end_tl bigint, x uuid, y uuid, nominatorid uuid)", // x: member id, y: election id
+			"create index electioncandidate_x_start_tl on electioncandidate(x, start_tl, end_tl DESC)",
+			"create index electioncandidate_y_start_tl on electioncandidate(y, start_tl, end_tl DESC)",
+
+			"create table electionelected (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: election id, y: member id
+			"create index electionelected_x_start_tl on electionelected(x, start_tl, end_tl DESC)",
+			"create index electionelected_y_start_tl on electionelected(y, start_tl, end_tl DESC)",
+
+			"create index rolemember_electionexpiration on rolemember(electionexpiration)",
+		},
+	},
+	{
+		Stmts: []string{
+			"create table roletemplate (id uuid, start_tl bigint, end_tl bigint, name varchar, purpose varchar, domains bytea, accountabilities bytea, PRIMARY KEY (id, start_tl))",
+			"create unique index roletemplate_tl on roletemplate(id, start_tl, end_tl DESC)",
+
+			"create table roletemplateinstance (start_tl bigint, end_tl bigint, x uuid, y uuid)", // x: role id, y: role template id
+			"create index roletemplateinstance_x_start_tl on roletemplateinstance(x, start_tl, end_tl DESC)",
+			"create index roletemplateinstance_y_start_tl on roletemplateinstance(y, start_tl, end_tl DESC)",
+		},
+	},
+	{
+		Stmts: []string{
+			"create table webhook (id uuid, start_tl bigint, end_tl bigint, url varchar, eventtypes bytea, secret varchar, PRIMARY KEY (id, start_tl))",
+			"create unique index webhook_tl on webhook(id, start_tl, end_tl DESC)",
+
+			"create table webhookdeadletter (timeline bigint, webhookid uuid, eventid uuid, sequencenumber bigint, eventtype varchar, attempts int, lasterror varchar, timestamp timestamptz)",
+			"create index webhookdeadletter_webhookid on webhookdeadletter(webhookid, timeline)",
+		},
+	},
+	{
+		Stmts: []string{
+			"alter table member add column isdeactivated bool not null default false",
+		},
+	},
+	{
+		Stmts: []string{
+			// sessions aren't versioned by timeline
+			"create table session (id uuid, memberid uuid, creationtime timestamptz, expiration timestamptz, PRIMARY KEY (id))",
+			"create index session_memberid on session(memberid)",
+
+			// processed events groupids, used to wait for events that don't
+			// create a timeline
+			"alter table sequencenumber add column groupid uuid",
+			"create index sequencenumber_groupid on sequencenumber(groupid)",
+		},
+	},
+	{
+		Stmts: []string{
+			"alter table member add column isserviceaccount bool not null default false",
+
+			"create table apitoken (id uuid, start_tl bigint, end_tl bigint, memberid uuid, name varchar, scope varchar, creationtime timestamptz, expiration timestamptz, tokenhash varchar, PRIMARY KEY (id, start_tl))",
+			"create unique index apitoken_tl on apitoken(id, start_tl, end_tl DESC)",
+			"create index apitoken_memberid on apitoken(memberid, start_tl, end_tl DESC)",
+			"create index apitoken_tokenhash on apitoken(tokenhash, start_tl, end_tl DESC)",
+		},
+	},
+	{
+		Stmts: []string{
+			// refresh tokens, like sessions, aren't versioned by timeline
+			"create table refreshtoken (id uuid, sessionid uuid, tokenhash varchar, used bool, PRIMARY KEY (id))",
+			"create index refreshtoken_sessionid on refreshtoken(sessionid)",
+			"create index refreshtoken_tokenhash on refreshtoken(tokenhash)",
+		},
+	},
 }
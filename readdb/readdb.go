@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/sorintlab/sircles/change"
 	"github.com/sorintlab/sircles/db"
 	ep "github.com/sorintlab/sircles/events"
 	"github.com/sorintlab/sircles/eventstore"
@@ -30,6 +31,8 @@ const (
 
 type ReadDBListener interface {
 	WaitTimeLineForGroupID(ctx context.Context, groupID util.ID) (*util.TimeLine, error)
+	WaitSequenceNumber(ctx context.Context, sn int64) error
+	WaitGroupID(ctx context.Context, groupID util.ID) error
 }
 
 type ReadDBService interface {
@@ -50,6 +53,7 @@ type ReadDBService interface {
 	Member(ctx context.Context, tl util.TimeLineNumber, id util.ID) (*models.Member, error)
 	MemberAvatar(ctx context.Context, tl util.TimeLineNumber, id util.ID) (*models.Avatar, error)
 	Tension(ctx context.Context, tl util.TimeLineNumber, id util.ID) (*models.Tension, error)
+	Tensions(ctx context.Context, tl util.TimeLineNumber, tensionsIDs []util.ID) ([]*models.Tension, error)
 	MembersByIDs(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) ([]*models.Member, error)
 	Members(ctx context.Context, tl util.TimeLineNumber, searchString string, first int, after *string) ([]*models.Member, bool, error)
 	Roles(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) ([]*models.Role, error)
@@ -60,6 +64,7 @@ type ReadDBService interface {
 	ChildRoles(ctx context.Context, tl util.TimeLineNumber, parentsIDs []util.ID, orderBys []string) (map[util.ID][]*models.Role, error)
 	MemberCircleEdges(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) (map[util.ID][]*models.MemberCircleEdge, error)
 	MemberRoleEdges(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) (map[util.ID][]*models.MemberRoleEdge, error)
+	MemberAssignments(ctx context.Context, tl util.TimeLineNumber, memberID util.ID) (*models.MemberAssignments, error)
 	MemberTensions(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) (map[util.ID][]*models.Tension, error)
 	TensionMember(ctx context.Context, tl util.TimeLineNumber, tensionsIDs []util.ID) (map[util.ID]*models.Member, error)
 	RoleMemberEdges(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID, orderBys []string) (map[util.ID][]*models.RoleMemberEdge, error)
@@ -67,9 +72,68 @@ type ReadDBService interface {
 	CircleDirectMembers(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Member, error)
 	CircleCoreRole(ctx context.Context, tl util.TimeLineNumber, roleType models.RoleType, rolesIDs []util.ID) (map[util.ID]*models.Role, error)
 	RoleDomains(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Domain, error)
+	RoleChecklistItems(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.ChecklistItem, error)
+	RoleMetrics(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Metric, error)
+	ChecklistItemRole(ctx context.Context, tl util.TimeLineNumber, checklistItemsIDs []util.ID) (map[util.ID]*models.Role, error)
+	MetricRole(ctx context.Context, tl util.TimeLineNumber, metricsIDs []util.ID) (map[util.ID]*models.Role, error)
+	ChecklistItemReports(ctx context.Context, tl util.TimeLineNumber, checklistItemsIDs []util.ID) (map[util.ID][]*models.ChecklistItemReport, error)
+	MetricReports(ctx context.Context, tl util.TimeLineNumber, metricsIDs []util.ID) (map[util.ID][]*models.MetricReport, error)
+	MeetingChecklistItemReports(ctx context.Context, tl util.TimeLineNumber, meetingsIDs []util.ID) (map[util.ID][]*models.ChecklistItemReport, error)
+	MeetingMetricReports(ctx context.Context, tl util.TimeLineNumber, meetingsIDs []util.ID) (map[util.ID][]*models.MetricReport, error)
 	RoleAccountabilities(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Accountability, error)
 	RoleTensions(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Tension, error)
 	TensionRole(ctx context.Context, tl util.TimeLineNumber, tensionsIDs []util.ID) (map[util.ID]*models.Role, error)
+	TensionAssignee(ctx context.Context, tl util.TimeLineNumber, tensionsIDs []util.ID) (map[util.ID]*models.Member, error)
+	Proposal(ctx context.Context, tl util.TimeLineNumber, id util.ID) (*models.Proposal, error)
+	ProposalChanges(ctx context.Context, tl util.TimeLineNumber, proposalsIDs []util.ID) (map[util.ID]*change.ProposalChanges, error)
+	ProposalMember(ctx context.Context, tl util.TimeLineNumber, proposalsIDs []util.ID) (map[util.ID]*models.Member, error)
+	ProposalRole(ctx context.Context, tl util.TimeLineNumber, proposalsIDs []util.ID) (map[util.ID]*models.Role, error)
+	RoleProposals(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Proposal, error)
+	ProposalObjection(ctx context.Context, tl util.TimeLineNumber, id util.ID) (*models.ProposalObjection, error)
+	ProposalObjections(ctx context.Context, tl util.TimeLineNumber, proposalsIDs []util.ID) (map[util.ID][]*models.ProposalObjection, error)
+	ProposalObjectionMember(ctx context.Context, tl util.TimeLineNumber, objectionsIDs []util.ID) (map[util.ID]*models.Member, error)
+	ProposalConsentMembers(ctx context.Context, tl util.TimeLineNumber, proposalsIDs []util.ID) (map[util.ID][]*models.Member, error)
+	Project(ctx context.Context, tl util.TimeLineNumber, id util.ID) (*models.Project, error)
+	ProjectRole(ctx context.Context, tl util.TimeLineNumber, projectsIDs []util.ID) (map[util.ID]*models.Role, error)
+	ProjectMember(ctx context.Context, tl util.TimeLineNumber, projectsIDs []util.ID) (map[util.ID]*models.Member, error)
+	ProjectTension(ctx context.Context, tl util.TimeLineNumber, projectsIDs []util.ID) (map[util.ID]*models.Tension, error)
+	RoleProjects(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Project, error)
+	MemberProjects(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) (map[util.ID][]*models.Project, error)
+	Action(ctx context.Context, tl util.TimeLineNumber, id util.ID) (*models.Action, error)
+	ActionRole(ctx context.Context, tl util.TimeLineNumber, actionsIDs []util.ID) (map[util.ID]*models.Role, error)
+	ActionMember(ctx context.Context, tl util.TimeLineNumber, actionsIDs []util.ID) (map[util.ID]*models.Member, error)
+	ActionTension(ctx context.Context, tl util.TimeLineNumber, actionsIDs []util.ID) (map[util.ID]*models.Tension, error)
+	RoleActions(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Action, error)
+	MemberActions(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) (map[util.ID][]*models.Action, error)
+	Meeting(ctx context.Context, tl util.TimeLineNumber, id util.ID) (*models.Meeting, error)
+	RoleMeetings(ctx context.Context, tl util.TimeLineNumber, roleID util.ID, first int, afterDate *time.Time, afterID *util.ID) ([]*models.Meeting, bool, error)
+	MeetingRole(ctx context.Context, tl util.TimeLineNumber, meetingsIDs []util.ID) (map[util.ID]*models.Role, error)
+	MeetingFacilitator(ctx context.Context, tl util.TimeLineNumber, meetingsIDs []util.ID) (map[util.ID]*models.Member, error)
+	MeetingSecretary(ctx context.Context, tl util.TimeLineNumber, meetingsIDs []util.ID) (map[util.ID]*models.Member, error)
+	MeetingAttendees(ctx context.Context, tl util.TimeLineNumber, meetingsIDs []util.ID) (map[util.ID][]*models.Member, error)
+	MeetingTensions(ctx context.Context, tl util.TimeLineNumber, meetingsIDs []util.ID) (map[util.ID][]*models.Tension, error)
+	Election(ctx context.Context, tl util.TimeLineNumber, id util.ID) (*models.Election, error)
+	RoleElections(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Election, error)
+	ElectionRole(ctx context.Context, tl util.TimeLineNumber, electionsIDs []util.ID) (map[util.ID]*models.Role, error)
+	ElectionNominations(ctx context.Context, tl util.TimeLineNumber, electionsIDs []util.ID) (map[util.ID][]*models.ElectionNomination, error)
+	ElectionElected(ctx context.Context, tl util.TimeLineNumber, electionsIDs []util.ID) (map[util.ID]*models.Member, error)
+	CoreRoleTerms(ctx context.Context, tl util.TimeLineNumber, after, before *time.Time) ([]*models.CoreRoleTerm, error)
+	RolesDiff(ctx context.Context, fromTl, toTl util.TimeLineNumber, roleID *util.ID) ([]*models.RoleDiff, error)
+	RoleTemplate(ctx context.Context, tl util.TimeLineNumber, id util.ID) (*models.RoleTemplate, error)
+	RoleTemplates(ctx context.Context, tl util.TimeLineNumber) ([]*models.RoleTemplate, error)
+	RoleTemplateInstances(ctx context.Context, tl util.TimeLineNumber, roleTemplatesIDs []util.ID) (map[util.ID][]*models.Role, error)
+	RoleRoleTemplate(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID]*models.RoleTemplate, error)
+	Webhook(ctx context.Context, tl util.TimeLineNumber, id util.ID) (*models.Webhook, error)
+	Webhooks(ctx context.Context, tl util.TimeLineNumber) ([]*models.Webhook, error)
+	WebhookDeadLetters(ctx context.Context, tl util.TimeLineNumber, webhooksIDs []util.ID) (map[util.ID][]*models.WebhookDeadLetter, error)
+
+	Session(ctx context.Context, id util.ID) (*models.Session, error)
+	MemberSessions(ctx context.Context, memberID util.ID) ([]*models.Session, error)
+	RefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+
+	APIToken(ctx context.Context, tl util.TimeLineNumber, id util.ID) (*models.APIToken, error)
+	APITokenByHash(ctx context.Context, tl util.TimeLineNumber, tokenHash string) (*models.APIToken, error)
+	MemberAPITokens(ctx context.Context, tl util.TimeLineNumber, memberID util.ID) ([]*models.APIToken, error)
 
 	// Auth
 	AuthenticateUIDPassword(ctx context.Context, memberID util.ID, password string) (*models.Member, error)
@@ -126,6 +190,24 @@ var (
 	accountabilitySelect = sb.Select(tableColumns(vertexClassAccountability.String(), accountabilityAllColumns)...).From(vertexClassAccountability.String())
 	accountabilityInsert = sb.Insert(vertexClassAccountability.String()).Columns(accountabilityAllColumns...)
 
+	checklistItemColumns = []string{
+		"description",
+	}
+
+	checklistItemAllColumns = append(vertexColumns, checklistItemColumns...)
+
+	checklistItemSelect = sb.Select(tableColumns(vertexClassChecklistItem.String(), checklistItemAllColumns)...).From(vertexClassChecklistItem.String())
+	checklistItemInsert = sb.Insert(vertexClassChecklistItem.String()).Columns(checklistItemAllColumns...)
+
+	metricColumns = []string{
+		"description",
+	}
+
+	metricAllColumns = append(vertexColumns, metricColumns...)
+
+	metricSelect = sb.Select(tableColumns(vertexClassMetric.String(), metricAllColumns)...).From(vertexClassMetric.String())
+	metricInsert = sb.Insert(vertexClassMetric.String()).Columns(metricAllColumns...)
+
 	roleAdditionalContentColumns = []string{
 		"content",
 	}
@@ -140,6 +222,8 @@ var (
 		"username",
 		"fullname",
 		"email",
+		"isdeactivated",
+		"isserviceaccount",
 	}
 
 	memberAllColumns = append(vertexColumns, memberColumns...)
@@ -169,11 +253,28 @@ var (
 		"electionexpiration",
 	}
 
+	checklistItemReportColumns = []string{
+		"checked",
+	}
+
+	metricReportColumns = []string{
+		"value",
+	}
+
+	electionNominationColumns = []string{
+		"nominatorid",
+	}
+
 	tensionColumns = []string{
 		"title",
 		"description",
 		"closed",
 		"closereason",
+		"status",
+		"meetingid",
+		"outcometype",
+		"outcomereferenceid",
+		"outcomedescription",
 	}
 
 	tensionAllColumns = append(vertexColumns, tensionColumns...)
@@ -181,6 +282,128 @@ var (
 	tensionSelect = sb.Select(tableColumns(vertexClassTension.String(), tensionAllColumns)...).From(vertexClassTension.String())
 	tensionInsert = sb.Insert(vertexClassTension.String()).Columns(tensionAllColumns...)
 
+	proposalColumns = []string{
+		"title",
+		"description",
+		"status",
+	}
+
+	proposalAllColumns = append(vertexColumns, proposalColumns...)
+
+	proposalSelect = sb.Select(tableColumns(vertexClassProposal.String(), proposalAllColumns)...).From(vertexClassProposal.String())
+	proposalInsert = sb.Insert(vertexClassProposal.String()).Columns(proposalAllColumns...)
+
+	proposalChangesColumns = []string{
+		"changes",
+	}
+
+	proposalChangesAllColumns = append(vertexColumns, proposalChangesColumns...)
+
+	proposalChangesSelect = sb.Select(tableColumns(vertexClassProposalChanges.String(), proposalChangesAllColumns)...).From(vertexClassProposalChanges.String())
+	proposalChangesInsert = sb.Insert(vertexClassProposalChanges.String()).Columns(proposalChangesAllColumns...)
+
+	proposalObjectionColumns = []string{
+		"reason",
+		"resolution",
+		"status",
+	}
+
+	proposalObjectionAllColumns = append(vertexColumns, proposalObjectionColumns...)
+
+	proposalObjectionSelect = sb.Select(tableColumns(vertexClassProposalObjection.String(), proposalObjectionAllColumns)...).From(vertexClassProposalObjection.String())
+	proposalObjectionInsert = sb.Insert(vertexClassProposalObjection.String()).Columns(proposalObjectionAllColumns...)
+
+	projectColumns = []string{
+		"title",
+		"description",
+		"status",
+		"duedate",
+	}
+
+	projectAllColumns = append(vertexColumns, projectColumns...)
+
+	projectSelect = sb.Select(tableColumns(vertexClassProject.String(), projectAllColumns)...).From(vertexClassProject.String())
+	projectInsert = sb.Insert(vertexClassProject.String()).Columns(projectAllColumns...)
+
+	actionColumns = []string{
+		"title",
+		"description",
+		"status",
+		"duedate",
+	}
+
+	actionAllColumns = append(vertexColumns, actionColumns...)
+
+	actionSelect = sb.Select(tableColumns(vertexClassAction.String(), actionAllColumns)...).From(vertexClassAction.String())
+	actionInsert = sb.Insert(vertexClassAction.String()).Columns(actionAllColumns...)
+
+	meetingColumns = []string{
+		"meetingtype",
+		"date",
+	}
+
+	meetingAllColumns = append(vertexColumns, meetingColumns...)
+
+	meetingSelect = sb.Select(tableColumns(vertexClassMeeting.String(), meetingAllColumns)...).From(vertexClassMeeting.String())
+	meetingInsert = sb.Insert(vertexClassMeeting.String()).Columns(meetingAllColumns...)
+
+	electionColumns = []string{
+		"roletype",
+		"status",
+		"electionexpiration",
+	}
+
+	electionAllColumns = append(vertexColumns, electionColumns...)
+
+	electionSelect = sb.Select(tableColumns(vertexClassElection.String(), electionAllColumns)...).From(vertexClassElection.String())
+	electionInsert = sb.Insert(vertexClassElection.String()).Columns(electionAllColumns...)
+
+	roleTemplateColumns = []string{
+		"name",
+		"purpose",
+		"domains",
+		"accountabilities",
+	}
+
+	roleTemplateAllColumns = append(vertexColumns, roleTemplateColumns...)
+
+	roleTemplateSelect = sb.Select(tableColumns(vertexClassRoleTemplate.String(), roleTemplateAllColumns)...).From(vertexClassRoleTemplate.String())
+	roleTemplateInsert = sb.Insert(vertexClassRoleTemplate.String()).Columns(roleTemplateAllColumns...)
+
+	webhookColumns = []string{
+		"url",
+		"eventtypes",
+		"secret",
+	}
+
+	webhookAllColumns = append(vertexColumns, webhookColumns...)
+
+	webhookSelect = sb.Select(tableColumns(vertexClassWebhook.String(), webhookAllColumns)...).From(vertexClassWebhook.String())
+	webhookInsert = sb.Insert(vertexClassWebhook.String()).Columns(webhookAllColumns...)
+
+	sessionSelect = sb.Select("id", "memberid", "creationtime", "expiration").From("session")
+	sessionInsert = sb.Insert("session").Columns("id", "memberid", "creationtime", "expiration")
+
+	refreshTokenSelect = sb.Select("id", "sessionid", "tokenhash", "used").From("refreshtoken")
+	refreshTokenInsert = sb.Insert("refreshtoken").Columns("id", "sessionid", "tokenhash", "used")
+
+	apiTokenColumns = []string{
+		"memberid",
+		"name",
+		"scope",
+		"creationtime",
+		"expiration",
+		"tokenhash",
+	}
+
+	apiTokenAllColumns = append(vertexColumns, apiTokenColumns...)
+
+	apiTokenSelect = sb.Select(tableColumns(vertexClassAPIToken.String(), apiTokenAllColumns)...).From(vertexClassAPIToken.String())
+	apiTokenInsert = sb.Insert(vertexClassAPIToken.String()).Columns(apiTokenAllColumns...)
+
+	webhookDeadLetterSelect = sb.Select("timeline", "webhookid", "eventid", "sequencenumber", "eventtype", "attempts", "lasterror", "timestamp").From("webhookdeadletter")
+	webhookDeadLetterInsert = sb.Insert("webhookdeadletter").Columns("timeline", "webhookid", "eventid", "sequencenumber", "eventtype", "attempts", "lasterror", "timestamp")
+
 	roleEventSelect = sb.Select("timeline", "id", "roleid", "eventtype", "data").From("roleevent")
 	roleEventInsert = sb.Insert("roleevent").Columns("timeline", "id", "roleid", "eventtype", "data")
 )
@@ -221,12 +444,27 @@ const (
 	vertexClassRole                  vertexClass = "role"
 	vertexClassDomain                vertexClass = "domain"
 	vertexClassAccountability        vertexClass = "accountability"
+	vertexClassChecklistItem         vertexClass = "checklistitem"
+	vertexClassMetric                vertexClass = "metric"
 	vertexClassRoleAdditionalContent vertexClass = "roleadditionalcontent"
 	vertexClassMember                vertexClass = "member"
 	vertexClassMemberAvatar          vertexClass = "memberavatar"
 	vertexClassRoleMemberEdge        vertexClass = "rolememberedge"
 	vertexClassMemberRoleEdge        vertexClass = "memberroleedge"
 	vertexClassTension               vertexClass = "tension"
+	vertexClassProposal              vertexClass = "proposal"
+	vertexClassProposalChanges       vertexClass = "proposalchanges"
+	vertexClassProposalObjection     vertexClass = "proposalobjection"
+	vertexClassProject               vertexClass = "project"
+	vertexClassAction                vertexClass = "action"
+	vertexClassMeeting               vertexClass = "meeting"
+	vertexClassChecklistItemReport   vertexClass = "checklistitemreport"
+	vertexClassMetricReport          vertexClass = "metricreport"
+	vertexClassElection              vertexClass = "election"
+	vertexClassElectionNomination    vertexClass = "electionnomination"
+	vertexClassRoleTemplate          vertexClass = "roletemplate"
+	vertexClassWebhook               vertexClass = "webhook"
+	vertexClassAPIToken              vertexClass = "apitoken"
 )
 
 func (vc vertexClass) String() string {
@@ -247,19 +485,54 @@ var (
 	edgeClassCircleDirectMember = edgeClass{Name: "circledirectmember", X: vertexClassMember, Y: vertexClassRole}
 	edgeClassMemberTension      = edgeClass{Name: "membertension", X: vertexClassTension, Y: vertexClassMember}
 	edgeClassRoleTension        = edgeClass{Name: "roletension", X: vertexClassTension, Y: vertexClassRole}
+	edgeClassTensionAssignee    = edgeClass{Name: "tensionassignee", X: vertexClassTension, Y: vertexClassMember}
+	edgeClassRoleProposal       = edgeClass{Name: "roleproposal", X: vertexClassProposal, Y: vertexClassRole}
+	edgeClassMemberProposal     = edgeClass{Name: "memberproposal", X: vertexClassProposal, Y: vertexClassMember}
+	edgeClassProposalObjection  = edgeClass{Name: "proposalproposalobjection", X: vertexClassProposalObjection, Y: vertexClassProposal}
+	edgeClassMemberObjection    = edgeClass{Name: "memberproposalobjection", X: vertexClassProposalObjection, Y: vertexClassMember}
+	edgeClassProposalConsent    = edgeClass{Name: "proposalconsent", X: vertexClassMember, Y: vertexClassProposal}
+	edgeClassRoleProject        = edgeClass{Name: "roleproject", X: vertexClassProject, Y: vertexClassRole}
+	edgeClassMemberProject      = edgeClass{Name: "memberproject", X: vertexClassProject, Y: vertexClassMember}
+	edgeClassTensionProject     = edgeClass{Name: "tensionproject", X: vertexClassProject, Y: vertexClassTension}
+	edgeClassRoleAction         = edgeClass{Name: "roleaction", X: vertexClassAction, Y: vertexClassRole}
+	edgeClassMemberAction       = edgeClass{Name: "memberaction", X: vertexClassAction, Y: vertexClassMember}
+	edgeClassTensionAction      = edgeClass{Name: "tensionaction", X: vertexClassAction, Y: vertexClassTension}
+	edgeClassRoleMeeting        = edgeClass{Name: "rolemeeting", X: vertexClassMeeting, Y: vertexClassRole}
+	edgeClassMeetingFacilitator = edgeClass{Name: "meetingfacilitator", X: vertexClassMeeting, Y: vertexClassMember}
+	edgeClassMeetingSecretary   = edgeClass{Name: "meetingsecretary", X: vertexClassMeeting, Y: vertexClassMember}
+	edgeClassMeetingAttendee    = edgeClass{Name: "meetingattendee", X: vertexClassMember, Y: vertexClassMeeting}
+	edgeClassRoleChecklistItem  = edgeClass{Name: "rolechecklistitem", X: vertexClassChecklistItem, Y: vertexClassRole}
+	edgeClassRoleMetric         = edgeClass{Name: "rolemetric", X: vertexClassMetric, Y: vertexClassRole}
+	// reported values of checklist items and metrics in a meeting
+	edgeClassMeetingChecklistItem = edgeClass{Name: "meetingchecklistitem", X: vertexClassChecklistItem, Y: vertexClassMeeting}
+	edgeClassMeetingMetric        = edgeClass{Name: "meetingmetric", X: vertexClassMetric, Y: vertexClassMeeting}
+	edgeClassRoleElection         = edgeClass{Name: "roleelection", X: vertexClassElection, Y: vertexClassRole}
+	edgeClassElectionCandidate    = edgeClass{Name: "electioncandidate", X: vertexClassMember, Y: vertexClassElection}
+	edgeClassElectionElected      = edgeClass{Name: "electionelected", X: vertexClassElection, Y: vertexClassMember}
+	// roles instantiated from a template and kept linked to it
+	edgeClassRoleTemplateInstance = edgeClass{Name: "roletemplateinstance", X: vertexClassRole, Y: vertexClassRoleTemplate}
 )
 
 func (ec edgeClass) String() string {
 	return ec.Name
 }
 
-var edgeClasses = []edgeClass{edgeClassRoleRole, edgeClassRoleDomain, edgeClassRoleAccountability, edgeClassRoleMember, edgeClassCircleDirectMember, edgeClassMemberTension, edgeClassRoleTension}
+var edgeClasses = []edgeClass{edgeClassRoleRole, edgeClassRoleDomain, edgeClassRoleAccountability, edgeClassRoleMember, edgeClassCircleDirectMember, edgeClassMemberTension, edgeClassRoleTension, edgeClassTensionAssignee, edgeClassRoleProposal, edgeClassMemberProposal, edgeClassProposalObjection, edgeClassMemberObjection, edgeClassProposalConsent, edgeClassRoleProject, edgeClassMemberProject, edgeClassTensionProject, edgeClassRoleAction, edgeClassMemberAction, edgeClassTensionAction, edgeClassRoleMeeting, edgeClassMeetingFacilitator, edgeClassMeetingSecretary, edgeClassMeetingAttendee, edgeClassRoleChecklistItem, edgeClassRoleMetric, edgeClassMeetingChecklistItem, edgeClassMeetingMetric, edgeClassRoleElection, edgeClassElectionCandidate, edgeClassElectionElected, edgeClassRoleTemplateInstance}
 
-var roleEdges = []edgeClass{edgeClassRoleRole, edgeClassRoleDomain, edgeClassRoleAccountability, edgeClassRoleMember, edgeClassRoleTension}
+var roleEdges = []edgeClass{edgeClassRoleRole, edgeClassRoleDomain, edgeClassRoleAccountability, edgeClassRoleMember, edgeClassRoleTension, edgeClassRoleProposal, edgeClassRoleProject, edgeClassRoleAction, edgeClassRoleMeeting, edgeClassRoleChecklistItem, edgeClassRoleMetric, edgeClassRoleElection, edgeClassRoleTemplateInstance}
 var domainEdges = []edgeClass{edgeClassRoleDomain}
 var accountabilityEdges = []edgeClass{edgeClassRoleAccountability}
-var memberEdges = []edgeClass{edgeClassRoleMember, edgeClassCircleDirectMember, edgeClassMemberTension}
-var tensionEdges = []edgeClass{edgeClassMemberTension, edgeClassRoleTension}
+var checklistItemEdges = []edgeClass{edgeClassRoleChecklistItem, edgeClassMeetingChecklistItem}
+var metricEdges = []edgeClass{edgeClassRoleMetric, edgeClassMeetingMetric}
+var memberEdges = []edgeClass{edgeClassRoleMember, edgeClassCircleDirectMember, edgeClassMemberTension, edgeClassTensionAssignee, edgeClassMemberProposal, edgeClassMemberObjection, edgeClassProposalConsent, edgeClassMemberProject, edgeClassMemberAction, edgeClassMeetingFacilitator, edgeClassMeetingSecretary, edgeClassMeetingAttendee, edgeClassElectionCandidate, edgeClassElectionElected}
+var tensionEdges = []edgeClass{edgeClassMemberTension, edgeClassRoleTension, edgeClassTensionAssignee, edgeClassTensionProject, edgeClassTensionAction}
+var proposalEdges = []edgeClass{edgeClassMemberProposal, edgeClassRoleProposal, edgeClassProposalObjection, edgeClassProposalConsent}
+var proposalObjectionEdges = []edgeClass{edgeClassProposalObjection, edgeClassMemberObjection}
+var projectEdges = []edgeClass{edgeClassRoleProject, edgeClassMemberProject, edgeClassTensionProject}
+var actionEdges = []edgeClass{edgeClassRoleAction, edgeClassMemberAction, edgeClassTensionAction}
+var meetingEdges = []edgeClass{edgeClassRoleMeeting, edgeClassMeetingFacilitator, edgeClassMeetingSecretary, edgeClassMeetingAttendee, edgeClassMeetingChecklistItem, edgeClassMeetingMetric}
+var electionEdges = []edgeClass{edgeClassRoleElection, edgeClassElectionCandidate, edgeClassElectionElected}
+var roleTemplateEdges = []edgeClass{edgeClassRoleTemplateInstance}
 
 func (s *readDBService) vertices(tl util.TimeLineNumber, vertexClass vertexClass, limit uint64, condition interface{}, orderBys []string) (interface{}, error) {
 	if tl <= 0 {
@@ -282,6 +555,30 @@ func (s *readDBService) vertices(tl util.TimeLineNumber, vertexClass vertexClass
 		sb = memberAvatarSelect
 	case vertexClassTension:
 		sb = tensionSelect
+	case vertexClassProposal:
+		sb = proposalSelect
+	case vertexClassProposalChanges:
+		sb = proposalChangesSelect
+	case vertexClassProposalObjection:
+		sb = proposalObjectionSelect
+	case vertexClassProject:
+		sb = projectSelect
+	case vertexClassAction:
+		sb = actionSelect
+	case vertexClassMeeting:
+		sb = meetingSelect
+	case vertexClassChecklistItem:
+		sb = checklistItemSelect
+	case vertexClassMetric:
+		sb = metricSelect
+	case vertexClassElection:
+		sb = electionSelect
+	case vertexClassRoleTemplate:
+		sb = roleTemplateSelect
+	case vertexClassWebhook:
+		sb = webhookSelect
+	case vertexClassAPIToken:
+		sb = apiTokenSelect
 	default:
 		return nil, errors.Errorf("unknown vertex class: %q", vertexClass)
 	}
@@ -325,6 +622,30 @@ func (s *readDBService) vertices(tl util.TimeLineNumber, vertexClass vertexClass
 			res, err = scanAvatars(rows)
 		case vertexClassTension:
 			res, err = scanTensions(rows)
+		case vertexClassProposal:
+			res, err = scanProposals(rows)
+		case vertexClassProposalChanges:
+			res, err = scanProposalsChanges(rows)
+		case vertexClassProposalObjection:
+			res, err = scanProposalObjections(rows)
+		case vertexClassProject:
+			res, err = scanProjects(rows)
+		case vertexClassAction:
+			res, err = scanActions(rows)
+		case vertexClassMeeting:
+			res, err = scanMeetings(rows)
+		case vertexClassChecklistItem:
+			res, err = scanChecklistItems(rows)
+		case vertexClassMetric:
+			res, err = scanMetrics(rows)
+		case vertexClassElection:
+			res, err = scanElections(rows)
+		case vertexClassRoleTemplate:
+			res, err = scanRoleTemplates(rows)
+		case vertexClassWebhook:
+			res, err = scanWebhooks(rows)
+		case vertexClassAPIToken:
+			res, err = scanAPITokens(rows)
 		default:
 			return errors.Errorf("unknown vertex class: %q", vertexClass)
 		}
@@ -362,6 +683,54 @@ func (s *readDBService) connectedVertices(tl util.TimeLineNumber, vertexID []uti
 			sb = memberSelect
 		case edgeClassRoleTension:
 			sb = roleSelect
+		case edgeClassTensionAssignee:
+			sb = memberSelect
+		case edgeClassRoleProposal:
+			sb = roleSelect
+		case edgeClassMemberProposal:
+			sb = memberSelect
+		case edgeClassProposalObjection:
+			sb = proposalSelect
+		case edgeClassMemberObjection:
+			sb = memberSelect
+		case edgeClassProposalConsent:
+			sb = proposalSelect
+		case edgeClassRoleProject:
+			sb = roleSelect
+		case edgeClassMemberProject:
+			sb = memberSelect
+		case edgeClassTensionProject:
+			sb = tensionSelect
+		case edgeClassRoleAction:
+			sb = roleSelect
+		case edgeClassMemberAction:
+			sb = memberSelect
+		case edgeClassTensionAction:
+			sb = tensionSelect
+		case edgeClassRoleMeeting:
+			sb = roleSelect
+		case edgeClassMeetingFacilitator:
+			sb = memberSelect
+		case edgeClassMeetingSecretary:
+			sb = memberSelect
+		case edgeClassMeetingAttendee:
+			sb = meetingSelect
+		case edgeClassRoleChecklistItem:
+			sb = roleSelect
+		case edgeClassRoleMetric:
+			sb = roleSelect
+		case edgeClassMeetingChecklistItem:
+			sb = meetingSelect
+		case edgeClassMeetingMetric:
+			sb = meetingSelect
+		case edgeClassRoleElection:
+			sb = roleSelect
+		case edgeClassElectionCandidate:
+			sb = electionSelect
+		case edgeClassElectionElected:
+			sb = memberSelect
+		case edgeClassRoleTemplateInstance:
+			sb = roleTemplateSelect
 		default:
 			panic(fmt.Sprintf("unknown edgeClass: %s", ec))
 		}
@@ -384,6 +753,54 @@ func (s *readDBService) connectedVertices(tl util.TimeLineNumber, vertexID []uti
 			sb = tensionSelect
 		case edgeClassRoleTension:
 			sb = tensionSelect
+		case edgeClassTensionAssignee:
+			sb = tensionSelect
+		case edgeClassRoleProposal:
+			sb = proposalSelect
+		case edgeClassMemberProposal:
+			sb = proposalSelect
+		case edgeClassProposalObjection:
+			sb = proposalObjectionSelect
+		case edgeClassMemberObjection:
+			sb = proposalObjectionSelect
+		case edgeClassProposalConsent:
+			sb = memberSelect
+		case edgeClassRoleProject:
+			sb = projectSelect
+		case edgeClassMemberProject:
+			sb = projectSelect
+		case edgeClassTensionProject:
+			sb = projectSelect
+		case edgeClassRoleAction:
+			sb = actionSelect
+		case edgeClassMemberAction:
+			sb = actionSelect
+		case edgeClassTensionAction:
+			sb = actionSelect
+		case edgeClassRoleMeeting:
+			sb = meetingSelect
+		case edgeClassMeetingFacilitator:
+			sb = meetingSelect
+		case edgeClassMeetingSecretary:
+			sb = meetingSelect
+		case edgeClassMeetingAttendee:
+			sb = memberSelect
+		case edgeClassRoleChecklistItem:
+			sb = checklistItemSelect
+		case edgeClassRoleMetric:
+			sb = metricSelect
+		case edgeClassMeetingChecklistItem:
+			sb = checklistItemSelect
+		case edgeClassMeetingMetric:
+			sb = metricSelect
+		case edgeClassRoleElection:
+			sb = electionSelect
+		case edgeClassElectionCandidate:
+			sb = memberSelect
+		case edgeClassElectionElected:
+			sb = electionSelect
+		case edgeClassRoleTemplateInstance:
+			sb = roleSelect
 		default:
 			panic(fmt.Sprintf("unknown edgeClass: %s", ec))
 		}
@@ -399,6 +816,12 @@ func (s *readDBService) connectedVertices(tl util.TimeLineNumber, vertexID []uti
 	switch outputVertexClass {
 	case vertexClassRoleMemberEdge, vertexClassMemberRoleEdge:
 		sb = sb.Columns(tableColumns(ecs, rolememberColumns)...)
+	case vertexClassChecklistItemReport:
+		sb = sb.Columns(tableColumns(ecs, checklistItemReportColumns)...)
+	case vertexClassMetricReport:
+		sb = sb.Columns(tableColumns(ecs, metricReportColumns)...)
+	case vertexClassElectionNomination:
+		sb = sb.Columns(tableColumns(ecs, electionNominationColumns)...)
 	}
 
 	sb = sb.Columns(ecs + "." + startEdgePoint)
@@ -440,6 +863,30 @@ func (s *readDBService) connectedVertices(tl util.TimeLineNumber, vertexID []uti
 			}
 		case vertexClassTension:
 			res, err = scanTensionsGroups(rows)
+		case vertexClassProposal:
+			res, err = scanProposalsGroups(rows)
+		case vertexClassProposalObjection:
+			res, err = scanProposalObjectionsGroups(rows)
+		case vertexClassProject:
+			res, err = scanProjectsGroups(rows)
+		case vertexClassAction:
+			res, err = scanActionsGroups(rows)
+		case vertexClassMeeting:
+			res, err = scanMeetingsGroups(rows)
+		case vertexClassChecklistItem:
+			res, err = scanChecklistItemsGroups(rows)
+		case vertexClassMetric:
+			res, err = scanMetricsGroups(rows)
+		case vertexClassChecklistItemReport:
+			res, err = scanChecklistItemReportsGroups(rows, vc)
+		case vertexClassMetricReport:
+			res, err = scanMetricReportsGroups(rows, vc)
+		case vertexClassElection:
+			res, err = scanElectionsGroups(rows)
+		case vertexClassElectionNomination:
+			res, err = scanElectionNominationsGroups(rows)
+		case vertexClassRoleTemplate:
+			res, err = scanRoleTemplatesGroups(rows)
 		default:
 			return errors.Errorf("unknown vertex class: %q", vc)
 		}
@@ -467,6 +914,22 @@ func (s *readDBService) verticesFiltered(tl util.TimeLineNumber, vc vertexClass,
 		sb = memberSelect
 	case vertexClassTension:
 		sb = tensionSelect
+	case vertexClassProposal:
+		sb = proposalSelect
+	case vertexClassProject:
+		sb = projectSelect
+	case vertexClassAction:
+		sb = actionSelect
+	case vertexClassMeeting:
+		sb = meetingSelect
+	case vertexClassChecklistItem:
+		sb = checklistItemSelect
+	case vertexClassMetric:
+		sb = metricSelect
+	case vertexClassElection:
+		sb = electionSelect
+	case vertexClassRoleTemplate:
+		sb = roleTemplateSelect
 	default:
 		return nil, errors.Errorf("unknown vertex class: %q", vc)
 	}
@@ -517,6 +980,22 @@ func (s *readDBService) verticesFiltered(tl util.TimeLineNumber, vc vertexClass,
 			res, err = scanMembers(rows)
 		case vertexClassTension:
 			res, err = scanTensions(rows)
+		case vertexClassProposal:
+			res, err = scanProposals(rows)
+		case vertexClassProject:
+			res, err = scanProjects(rows)
+		case vertexClassAction:
+			res, err = scanActions(rows)
+		case vertexClassMeeting:
+			res, err = scanMeetings(rows)
+		case vertexClassChecklistItem:
+			res, err = scanChecklistItems(rows)
+		case vertexClassMetric:
+			res, err = scanMetrics(rows)
+		case vertexClassElection:
+			res, err = scanElections(rows)
+		case vertexClassRoleTemplate:
+			res, err = scanRoleTemplates(rows)
 		default:
 			return errors.Errorf("unknown vertex class: %q", vc)
 		}
@@ -618,6 +1097,30 @@ func (s *readDBService) insertVertex(tl util.TimeLineNumber, vc vertexClass, id
 		return s.insertMemberAvatar(tl, id, vertex.(*models.Avatar))
 	case vertexClassTension:
 		return s.insertTension(tl, id, vertex.(*models.Tension))
+	case vertexClassProposal:
+		return s.insertProposal(tl, id, vertex.(*models.Proposal))
+	case vertexClassProposalChanges:
+		return s.insertProposalChanges(tl, id, vertex.(*change.ProposalChanges))
+	case vertexClassProposalObjection:
+		return s.insertProposalObjection(tl, id, vertex.(*models.ProposalObjection))
+	case vertexClassProject:
+		return s.insertProject(tl, id, vertex.(*models.Project))
+	case vertexClassAction:
+		return s.insertAction(tl, id, vertex.(*models.Action))
+	case vertexClassMeeting:
+		return s.insertMeeting(tl, id, vertex.(*models.Meeting))
+	case vertexClassChecklistItem:
+		return s.insertChecklistItem(tl, id, vertex.(*models.ChecklistItem))
+	case vertexClassMetric:
+		return s.insertMetric(tl, id, vertex.(*models.Metric))
+	case vertexClassElection:
+		return s.insertElection(tl, id, vertex.(*models.Election))
+	case vertexClassRoleTemplate:
+		return s.insertRoleTemplate(tl, id, vertex.(*models.RoleTemplate))
+	case vertexClassWebhook:
+		return s.insertWebhook(tl, id, vertex.(*models.Webhook))
+	case vertexClassAPIToken:
+		return s.insertAPIToken(tl, id, vertex.(*models.APIToken))
 	default:
 		return errors.Errorf("unknown vertex class: %q", vc)
 	}
@@ -726,6 +1229,12 @@ func (s *readDBService) addEdge(tl util.TimeLineNumber, ec edgeClass, x, y util.
 	switch ec {
 	case edgeClassRoleMember:
 		columns = append(columns, rolememberColumns...)
+	case edgeClassMeetingChecklistItem:
+		columns = append(columns, checklistItemReportColumns...)
+	case edgeClassMeetingMetric:
+		columns = append(columns, metricReportColumns...)
+	case edgeClassElectionCandidate:
+		columns = append(columns, electionNominationColumns...)
 	}
 
 	values = append([]interface{}{tl, nil, x, y}, values...)
@@ -924,7 +1433,7 @@ func scanRolesAdditionalContent(rows *sql.Rows) ([]*models.RoleAdditionalContent
 
 func scanMember(rows *sql.Rows, additionalFields ...interface{}) (*models.Member, error) {
 	m := models.Member{}
-	fields := append([]interface{}{&m.ID, &m.StartTl, &m.EndTl, &m.IsAdmin, &m.UserName, &m.FullName, &m.Email}, additionalFields...)
+	fields := append([]interface{}{&m.ID, &m.StartTl, &m.EndTl, &m.IsAdmin, &m.UserName, &m.FullName, &m.Email, &m.IsDeactivated, &m.IsServiceAccount}, additionalFields...)
 	if err := rows.Scan(fields...); err != nil {
 		return nil, errors.Wrap(err, "failed to scan member rows")
 	}
@@ -1009,7 +1518,7 @@ func scanAvatarsGroups(rows *sql.Rows) (map[util.ID][]*models.Avatar, error) {
 func scanRoleMemberEdge(rows *sql.Rows, additionalFields ...interface{}) (*models.RoleMemberEdge, error) {
 	r := models.RoleMemberEdge{}
 	r.Member = &models.Member{}
-	fields := append([]interface{}{&r.Member.ID, &r.Member.StartTl, &r.Member.EndTl, &r.Member.IsAdmin, &r.Member.UserName, &r.Member.FullName, &r.Member.Email, &r.Focus, &r.NoCoreMember, &r.ElectionExpiration}, additionalFields...)
+	fields := append([]interface{}{&r.Member.ID, &r.Member.StartTl, &r.Member.EndTl, &r.Member.IsAdmin, &r.Member.UserName, &r.Member.FullName, &r.Member.Email, &r.Member.IsDeactivated, &r.Member.IsServiceAccount, &r.Focus, &r.NoCoreMember, &r.ElectionExpiration}, additionalFields...)
 	if err := rows.Scan(fields...); err != nil {
 		return nil, errors.Wrap(err, "failed to scan rolememberedge rows")
 	}
@@ -1095,7 +1604,7 @@ func scanMemberRoleEdgesGroups(rows *sql.Rows) (map[util.ID][]*models.MemberRole
 
 func scanTension(rows *sql.Rows, additionalFields ...interface{}) (*models.Tension, error) {
 	t := models.Tension{}
-	fields := append([]interface{}{&t.ID, &t.StartTl, &t.EndTl, &t.Title, &t.Description, &t.Closed, &t.CloseReason}, additionalFields...)
+	fields := append([]interface{}{&t.ID, &t.StartTl, &t.EndTl, &t.Title, &t.Description, &t.Closed, &t.CloseReason, &t.Status, &t.MeetingID, &t.OutcomeType, &t.OutcomeReferenceID, &t.OutcomeDescription}, additionalFields...)
 	if err := rows.Scan(fields...); err != nil {
 		return nil, errors.Wrap(err, "failed to scan tension rows")
 	}
@@ -1135,1706 +1644,4664 @@ func scanTensionsGroups(rows *sql.Rows) (map[util.ID][]*models.Tension, error) {
 	return tensionsGroups, nil
 }
 
-func scanRoleEvent(rows *sql.Rows) (*models.RoleEvent, error) {
-	e := models.RoleEvent{}
-	var rawData []byte
+func scanProposal(rows *sql.Rows, additionalFields ...interface{}) (*models.Proposal, error) {
+	p := models.Proposal{}
 	// To make sqlite3 happy
-	var eventType string
-	fields := []interface{}{&e.TimeLineID, &e.ID, &e.RoleID, &eventType, &rawData}
+	var status string
+	fields := append([]interface{}{&p.ID, &p.StartTl, &p.EndTl, &p.Title, &p.Description, &status}, additionalFields...)
 	if err := rows.Scan(fields...); err != nil {
-		return nil, errors.Wrap(err, "error scanning event")
-	}
-	e.EventType = models.RoleEventType(eventType)
-
-	data := models.GetRoleEventDataType(e.EventType)
-	if err := json.Unmarshal(rawData, &data); err != nil {
-		return nil, errors.Wrap(err, "failed to unmarshal event")
+		return nil, errors.Wrap(err, "failed to scan proposal rows")
 	}
-	e.Data = data
-
-	return &e, nil
+	p.Status = models.ProposalStatus(status)
+	return &p, nil
 }
 
-func scanRoleEvents(rows *sql.Rows) ([]*models.RoleEvent, error) {
-	roleEvents := []*models.RoleEvent{}
+func scanProposals(rows *sql.Rows) ([]*models.Proposal, error) {
+	proposals := []*models.Proposal{}
 	for rows.Next() {
-		e, err := scanRoleEvent(rows)
+		p, err := scanProposal(rows)
 		if err != nil {
 			rows.Close()
 			return nil, err
 		}
-		roleEvents = append(roleEvents, e)
+		proposals = append(proposals, p)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	return roleEvents, nil
+	return proposals, nil
 }
 
-func (s *readDBService) insertRole(tl util.TimeLineNumber, id util.ID, role *models.Role) error {
-	q, args, err := roleInsert.Values(id, tl, nil, role.RoleType, role.Depth, role.Name, role.Purpose).ToSql()
-	if err != nil {
-		return errors.Wrap(err, "failed to build query")
+func scanProposalsGroups(rows *sql.Rows) (map[util.ID][]*models.Proposal, error) {
+	proposalsGroups := map[util.ID][]*models.Proposal{}
+	for rows.Next() {
+		var group util.ID
+		p, err := scanProposal(rows, &group)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		proposalsGroups[group] = append(proposalsGroups[group], p)
 	}
-	err = s.tx.Do(func(tx *db.WrappedTx) error {
-		_, err = tx.Exec(q, args...)
-		return err
-	})
-	if err != nil {
-		return errors.WithMessage(err, "failed to execute query")
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	return nil
+	return proposalsGroups, nil
 }
 
-func (s *readDBService) insertDomain(tl util.TimeLineNumber, id util.ID, domain *models.Domain) error {
-	q, args, err := domainInsert.Values(id, tl, nil, domain.Description).ToSql()
-	if err != nil {
-		return errors.Wrap(err, "failed to build query")
-	}
-	err = s.tx.Do(func(tx *db.WrappedTx) error {
-		_, err = tx.Exec(q, args...)
-		return err
-	})
-	if err != nil {
-		return errors.WithMessage(err, "failed to execute query")
+func scanProject(rows *sql.Rows, additionalFields ...interface{}) (*models.Project, error) {
+	v := models.Project{}
+	// To make sqlite3 happy
+	var status string
+	fields := append([]interface{}{&v.ID, &v.StartTl, &v.EndTl, &v.Title, &v.Description, &status, &v.DueDate}, additionalFields...)
+	if err := rows.Scan(fields...); err != nil {
+		return nil, errors.Wrap(err, "failed to scan project rows")
 	}
-	return nil
+	v.Status = models.ProjectStatus(status)
+	return &v, nil
 }
 
-func (s *readDBService) insertAccountability(tl util.TimeLineNumber, id util.ID, accountability *models.Accountability) error {
-	q, args, err := accountabilityInsert.Values(id, tl, nil, accountability.Description).ToSql()
-	if err != nil {
-		return errors.Wrap(err, "failed to build query")
+func scanProjects(rows *sql.Rows) ([]*models.Project, error) {
+	projects := []*models.Project{}
+	for rows.Next() {
+		v, err := scanProject(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		projects = append(projects, v)
 	}
-	err = s.tx.Do(func(tx *db.WrappedTx) error {
-		_, err = tx.Exec(q, args...)
-		return err
-	})
-	if err != nil {
-		return errors.WithMessage(err, "failed to execute query")
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	return nil
+	return projects, nil
 }
 
-func (s *readDBService) insertRoleAdditionalContent(tl util.TimeLineNumber, id util.ID, roleAdditionalContent *models.RoleAdditionalContent) error {
-	q, args, err := roleAdditionalContentInsert.Values(id, tl, nil, roleAdditionalContent.Content).ToSql()
-	if err != nil {
-		return errors.Wrap(err, "failed to build query")
+func scanProjectsGroups(rows *sql.Rows) (map[util.ID][]*models.Project, error) {
+	projectsGroups := map[util.ID][]*models.Project{}
+	for rows.Next() {
+		var group util.ID
+		v, err := scanProject(rows, &group)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		projectsGroups[group] = append(projectsGroups[group], v)
 	}
-	err = s.tx.Do(func(tx *db.WrappedTx) error {
-		_, err = tx.Exec(q, args...)
-		return err
-	})
-	if err != nil {
-		return errors.WithMessage(err, "failed to execute query")
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	return nil
+	return projectsGroups, nil
 }
 
-func (s *readDBService) insertMember(tl util.TimeLineNumber, id util.ID, member *models.Member) error {
-	q, args, err := memberInsert.Values(id, tl, nil, member.IsAdmin, member.UserName, member.FullName, member.Email).ToSql()
-	if err != nil {
-		return errors.Wrap(err, "failed to build query")
-	}
-	err = s.tx.Do(func(tx *db.WrappedTx) error {
-		_, err = tx.Exec(q, args...)
-		return err
-	})
-	if err != nil {
-		return errors.WithMessage(err, "failed to execute query")
+func scanAction(rows *sql.Rows, additionalFields ...interface{}) (*models.Action, error) {
+	v := models.Action{}
+	// To make sqlite3 happy
+	var status string
+	fields := append([]interface{}{&v.ID, &v.StartTl, &v.EndTl, &v.Title, &v.Description, &status, &v.DueDate}, additionalFields...)
+	if err := rows.Scan(fields...); err != nil {
+		return nil, errors.Wrap(err, "failed to scan action rows")
 	}
-	return nil
+	v.Status = models.ActionStatus(status)
+	return &v, nil
 }
 
-func (s *readDBService) insertMemberAvatar(tl util.TimeLineNumber, id util.ID, avatar *models.Avatar) error {
-	q, args, err := memberAvatarInsert.Values(id, tl, nil, avatar.Image).ToSql()
-	if err != nil {
-		return errors.Wrap(err, "failed to build query")
+func scanActions(rows *sql.Rows) ([]*models.Action, error) {
+	actions := []*models.Action{}
+	for rows.Next() {
+		v, err := scanAction(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		actions = append(actions, v)
 	}
-	err = s.tx.Do(func(tx *db.WrappedTx) error {
-		_, err = tx.Exec(q, args...)
-		return err
-	})
-	if err != nil {
-		return errors.WithMessage(err, "failed to execute query")
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	return nil
+	return actions, nil
 }
 
-func (s *readDBService) insertTension(tl util.TimeLineNumber, id util.ID, tension *models.Tension) error {
-	q, args, err := tensionInsert.Values(id, tl, nil, tension.Title, tension.Description, tension.Closed, tension.CloseReason).ToSql()
-	if err != nil {
-		return errors.Wrap(err, "failed to build query")
+func scanActionsGroups(rows *sql.Rows) (map[util.ID][]*models.Action, error) {
+	actionsGroups := map[util.ID][]*models.Action{}
+	for rows.Next() {
+		var group util.ID
+		v, err := scanAction(rows, &group)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		actionsGroups[group] = append(actionsGroups[group], v)
 	}
-	err = s.tx.Do(func(tx *db.WrappedTx) error {
-		_, err = tx.Exec(q, args...)
-		return err
-	})
-	if err != nil {
-		return errors.WithMessage(err, "failed to execute query")
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	return nil
+	return actionsGroups, nil
 }
 
-// insertRoleEvent inserts or update a role event
-func (s *readDBService) insertRoleEvent(roleEvent *models.RoleEvent) error {
-	data, err := json.Marshal(roleEvent.Data)
-	if err != nil {
-		return errors.Wrap(err, "failed to marshal event")
-	}
-	q, args, err := roleEventInsert.Values(roleEvent.TimeLineID, roleEvent.ID, roleEvent.RoleID, roleEvent.EventType, data).ToSql()
-	if err != nil {
-		return errors.Wrap(err, "failed to build query")
-	}
-	err = s.tx.Do(func(tx *db.WrappedTx) error {
-		// poor man insert or update...
-		if _, err := tx.Exec("delete from roleevent where id = $1", roleEvent.ID); err != nil {
-			return errors.Wrap(err, "failed to delete roleevent")
-		}
-		_, err = tx.Exec(q, args...)
-		return err
-	})
-	if err != nil {
-		return errors.WithMessage(err, "failed to execute query")
+func scanMeeting(rows *sql.Rows, additionalFields ...interface{}) (*models.Meeting, error) {
+	v := models.Meeting{}
+	// To make sqlite3 happy
+	var meetingType string
+	fields := append([]interface{}{&v.ID, &v.StartTl, &v.EndTl, &meetingType, &v.Date}, additionalFields...)
+	if err := rows.Scan(fields...); err != nil {
+		return nil, errors.Wrap(err, "failed to scan meeting rows")
 	}
-	return nil
+	v.MeetingType = models.MeetingType(meetingType)
+	return &v, nil
 }
 
-type readDBService struct {
-	tx                        *db.Tx
-	forcedAdminMemberUserName string
-
-	// cached curTl to not query every time
-	// the DBService lives inside a repreatable read/serializable transaction so
-	// curTl could change only if we are chaning it in this transaction
-	curTl     *util.TimeLine
-	curTlLock sync.Mutex
-}
-
-func NewReadDBService(tx *db.Tx) (*readDBService, error) {
-	s := &readDBService{tx: tx}
-
-	curTl, err := s.curTimeLineFromDB()
-	if err != nil {
-		return nil, err
+func scanMeetings(rows *sql.Rows) ([]*models.Meeting, error) {
+	meetings := []*models.Meeting{}
+	for rows.Next() {
+		v, err := scanMeeting(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		meetings = append(meetings, v)
 	}
-	if curTl == nil {
-		curTl = &util.TimeLine{}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-
-	s.curTl = curTl
-
-	return s, nil
-}
-
-func (s *readDBService) SetForceAdminMemberUserName(u string) {
-	s.forcedAdminMemberUserName = u
+	return meetings, nil
 }
 
-func (s *readDBService) curTimeLineFromDB() (*util.TimeLine, error) {
-	// zeroed timeline, also valid if there're no rows
-	var tl util.TimeLine
-
-	err := s.tx.Do(func(tx *db.WrappedTx) error {
-		return tx.QueryRow("select timestamp from timeline order by timestamp desc limit 1").Scan(&tl.Timestamp)
-	})
-	if err == sql.ErrNoRows {
-		return nil, nil
+func scanMeetingsGroups(rows *sql.Rows) (map[util.ID][]*models.Meeting, error) {
+	meetingsGroups := map[util.ID][]*models.Meeting{}
+	for rows.Next() {
+		var group util.ID
+		v, err := scanMeeting(rows, &group)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		meetingsGroups[group] = append(meetingsGroups[group], v)
 	}
-
-	return &tl, err
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return meetingsGroups, nil
 }
 
-func (s *readDBService) CurTimeLine(ctx context.Context) *util.TimeLine {
-	s.curTlLock.Lock()
-	defer s.curTlLock.Unlock()
-
-	if s.curTl == nil {
-		return &util.TimeLine{}
+func scanChecklistItem(rows *sql.Rows, additionalFields ...interface{}) (*models.ChecklistItem, error) {
+	c := models.ChecklistItem{}
+	fields := append([]interface{}{&c.ID, &c.StartTl, &c.EndTl, &c.Description}, additionalFields...)
+	if err := rows.Scan(fields...); err != nil {
+		return nil, errors.Wrap(err, "failed to scan checklistitem rows")
 	}
-
-	// take a copy of curTl
-	c := *s.curTl
-
-	//log.Debugf("curTl: %s", c)
-	return &c
+	return &c, nil
 }
 
-func (s *readDBService) TimeLine(ctx context.Context, sn util.TimeLineNumber) (*util.TimeLine, error) {
-	var tl util.TimeLine
-
-	err := s.tx.Do(func(tx *db.WrappedTx) error {
-		if err := tx.QueryRow("select timestamp from timeline where timestamp = $1", time.Unix(0, int64(sn))).Scan(&tl.Timestamp); err != nil {
-			return err
+func scanChecklistItems(rows *sql.Rows) ([]*models.ChecklistItem, error) {
+	checklistItems := []*models.ChecklistItem{}
+	for rows.Next() {
+		c, err := scanChecklistItem(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
 		}
-		return nil
-	})
-	if err == sql.ErrNoRows {
-		return nil, errors.Errorf("timeline %d doesn't exists", sn)
+		checklistItems = append(checklistItems, c)
 	}
-
-	return &tl, err
-}
-
-func (s *readDBService) TimeLineForGroupID(ctx context.Context, groupID util.ID) (*util.TimeLine, error) {
-	var tl util.TimeLine
-
-	err := s.tx.Do(func(tx *db.WrappedTx) error {
-		return tx.QueryRow("select timestamp from timeline where groupid = $1", groupID).Scan(&tl.Timestamp)
-	})
-	if err == sql.ErrNoRows {
-		return nil, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-
-	return &tl, err
+	return checklistItems, nil
 }
 
-func (s *readDBService) TimeLines(ctx context.Context, ts *time.Time, sn util.TimeLineNumber, limit int, after bool, aggregateType string, aggregateID *util.ID) ([]*util.TimeLine, bool, error) {
-	var tls []*util.TimeLine
-	if limit <= 0 {
-		limit = MaxFetchSize
-	}
-	if ts == nil {
-		t := time.Unix(0, int64(sn)).UTC()
-		ts = &t
+func scanChecklistItemsGroups(rows *sql.Rows) (map[util.ID][]*models.ChecklistItem, error) {
+	checklistItemsGroups := map[util.ID][]*models.ChecklistItem{}
+	for rows.Next() {
+		var group util.ID
+		c, err := scanChecklistItem(rows, &group)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		checklistItemsGroups[group] = append(checklistItemsGroups[group], c)
 	}
-	sb := sb.Select("timestamp").From("timeline")
-	// ask for limit + 1 rows to know if there's more data
-	if after {
-		sb = sb.Where(sq.Gt{"timestamp": ts}).OrderBy("timestamp asc")
-	} else {
-		sb = sb.Where(sq.Lt{"timestamp": ts}).OrderBy("timestamp desc")
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	sb = sb.Limit(uint64(limit + 1))
+	return checklistItemsGroups, nil
+}
 
-	if aggregateType != "" {
-		sb = sb.Where(sq.Eq{"aggregatetype": aggregateType})
-	}
-	if aggregateID != nil {
-		sb = sb.Where(sq.Eq{"aggregateid": aggregateID})
+func scanMetric(rows *sql.Rows, additionalFields ...interface{}) (*models.Metric, error) {
+	m := models.Metric{}
+	fields := append([]interface{}{&m.ID, &m.StartTl, &m.EndTl, &m.Description}, additionalFields...)
+	if err := rows.Scan(fields...); err != nil {
+		return nil, errors.Wrap(err, "failed to scan metric rows")
 	}
+	return &m, nil
+}
 
-	q, args, err := sb.ToSql()
-	if err != nil {
-		return nil, false, errors.Wrap(err, "failed to build query")
-	}
-	err = s.tx.Do(func(tx *db.WrappedTx) error {
-		rows, err := tx.Query(q, args...)
+func scanMetrics(rows *sql.Rows) ([]*models.Metric, error) {
+	metrics := []*models.Metric{}
+	for rows.Next() {
+		m, err := scanMetric(rows)
 		if err != nil {
-			return err
+			rows.Close()
+			return nil, err
 		}
-		tls, err = scanTimeLines(rows)
+		metrics = append(metrics, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+func scanMetricsGroups(rows *sql.Rows) (map[util.ID][]*models.Metric, error) {
+	metricsGroups := map[util.ID][]*models.Metric{}
+	for rows.Next() {
+		var group util.ID
+		m, err := scanMetric(rows, &group)
 		if err != nil {
-			return err
+			rows.Close()
+			return nil, err
 		}
-		return nil
-	})
-	if err != nil {
-		return nil, false, err
+		metricsGroups[group] = append(metricsGroups[group], m)
 	}
-
-	size := len(tls)
-	if len(tls) > limit {
-		size = limit
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	return tls[:size], len(tls) > limit, err
+	return metricsGroups, nil
 }
 
-func (s *readDBService) TimeLineAtTimeStamp(ctx context.Context, ts time.Time) (*util.TimeLine, error) {
-	var tl util.TimeLine
-
-	err := s.tx.Do(func(tx *db.WrappedTx) error {
-		return tx.QueryRow("select timestamp from timeline where timestamp >= $1 order by timestamp asc limit 1", ts).Scan(&tl.Timestamp)
-	})
-	if err == sql.ErrNoRows {
-		return nil, nil
+// scanChecklistItemReport scans a checklist item report. vc is the class of
+// the scanned vertex: the checklist item when querying the reports of a
+// meeting, the meeting when querying the reports of a checklist item
+func scanChecklistItemReport(rows *sql.Rows, vc vertexClass, additionalFields ...interface{}) (*models.ChecklistItemReport, error) {
+	r := models.ChecklistItemReport{}
+	var fields []interface{}
+	// To make sqlite3 happy
+	var meetingType string
+	if vc == vertexClassChecklistItem {
+		r.ChecklistItem = &models.ChecklistItem{}
+		fields = []interface{}{&r.ChecklistItem.ID, &r.ChecklistItem.StartTl, &r.ChecklistItem.EndTl, &r.ChecklistItem.Description}
+	} else {
+		r.Meeting = &models.Meeting{}
+		fields = []interface{}{&r.Meeting.ID, &r.Meeting.StartTl, &r.Meeting.EndTl, &meetingType, &r.Meeting.Date}
 	}
-
-	return &tl, err
+	fields = append(fields, &r.Checked)
+	fields = append(fields, additionalFields...)
+	if err := rows.Scan(fields...); err != nil {
+		return nil, errors.Wrap(err, "failed to scan checklistitemreport rows")
+	}
+	if r.Meeting != nil {
+		r.Meeting.MeetingType = models.MeetingType(meetingType)
+	}
+	return &r, nil
 }
 
-func (s *readDBService) RootRole(ctx context.Context, tl util.TimeLineNumber) (*models.Role, error) {
-	vs, err := s.vertices(tl, vertexClassRole, 0, sq.Eq{"role.depth": 0}, nil)
-	if err != nil {
+func scanChecklistItemReportsGroups(rows *sql.Rows, vc vertexClass) (map[util.ID][]*models.ChecklistItemReport, error) {
+	checklistItemReportsGroups := map[util.ID][]*models.ChecklistItemReport{}
+	for rows.Next() {
+		var group util.ID
+		r, err := scanChecklistItemReport(rows, vc, &group)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		checklistItemReportsGroups[group] = append(checklistItemReportsGroups[group], r)
+	}
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	roles := vs.([]*models.Role)
+	return checklistItemReportsGroups, nil
+}
 
-	if len(roles) == 0 {
-		return nil, nil
+// scanMetricReport scans a metric report. vc is the class of the scanned
+// vertex: the metric when querying the reports of a meeting, the meeting when
+// querying the reports of a metric
+func scanMetricReport(rows *sql.Rows, vc vertexClass, additionalFields ...interface{}) (*models.MetricReport, error) {
+	r := models.MetricReport{}
+	var fields []interface{}
+	// To make sqlite3 happy
+	var meetingType string
+	if vc == vertexClassMetric {
+		r.Metric = &models.Metric{}
+		fields = []interface{}{&r.Metric.ID, &r.Metric.StartTl, &r.Metric.EndTl, &r.Metric.Description}
+	} else {
+		r.Meeting = &models.Meeting{}
+		fields = []interface{}{&r.Meeting.ID, &r.Meeting.StartTl, &r.Meeting.EndTl, &meetingType, &r.Meeting.Date}
 	}
-	if len(roles) > 1 {
-		return nil, errors.Errorf("too many root roles. This shouldn't happen!")
+	fields = append(fields, &r.Value)
+	fields = append(fields, additionalFields...)
+	if err := rows.Scan(fields...); err != nil {
+		return nil, errors.Wrap(err, "failed to scan metricreport rows")
 	}
-	return roles[0], nil
+	if r.Meeting != nil {
+		r.Meeting.MeetingType = models.MeetingType(meetingType)
+	}
+	return &r, nil
 }
 
-func (s *readDBService) Role(ctx context.Context, tl util.TimeLineNumber, id util.ID) (*models.Role, error) {
-	var err error
-
-	vs, err := s.vertices(tl, vertexClassRole, 0, sq.Eq{"role.id": id}, nil)
-	if err != nil {
+func scanMetricReportsGroups(rows *sql.Rows, vc vertexClass) (map[util.ID][]*models.MetricReport, error) {
+	metricReportsGroups := map[util.ID][]*models.MetricReport{}
+	for rows.Next() {
+		var group util.ID
+		r, err := scanMetricReport(rows, vc, &group)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		metricReportsGroups[group] = append(metricReportsGroups[group], r)
+	}
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	roles := vs.([]*models.Role)
+	return metricReportsGroups, nil
+}
 
-	if len(roles) < 1 {
-		return nil, nil
+func scanElection(rows *sql.Rows, additionalFields ...interface{}) (*models.Election, error) {
+	e := models.Election{}
+	// To make sqlite3 happy
+	var roleType, status string
+	fields := append([]interface{}{&e.ID, &e.StartTl, &e.EndTl, &roleType, &status, &e.ElectionExpiration}, additionalFields...)
+	if err := rows.Scan(fields...); err != nil {
+		return nil, errors.Wrap(err, "failed to scan election rows")
 	}
-	return roles[0], nil
+	e.RoleType = models.RoleType(roleType)
+	e.Status = models.ElectionStatus(status)
+	return &e, nil
 }
 
-func (s *readDBService) Roles(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) ([]*models.Role, error) {
-	var condition interface{}
-	if len(rolesIDs) > 0 {
-		condition = sq.Eq{"role.id": rolesIDs}
+func scanElections(rows *sql.Rows) ([]*models.Election, error) {
+	elections := []*models.Election{}
+	for rows.Next() {
+		e, err := scanElection(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		elections = append(elections, e)
 	}
-	vs, err := s.vertices(tl, vertexClassRole, 0, condition, []string{"role.name"})
-	if err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	roles := vs.([]*models.Role)
-
-	return roles, nil
+	return elections, nil
 }
 
-func (s *readDBService) ChildRoles(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID, orderBys []string) (map[util.ID][]*models.Role, error) {
-	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleRole, edgeDirectionOut, "", nil, orderBys)
-	if err != nil {
+func scanElectionsGroups(rows *sql.Rows) (map[util.ID][]*models.Election, error) {
+	electionsGroups := map[util.ID][]*models.Election{}
+	for rows.Next() {
+		var group util.ID
+		e, err := scanElection(rows, &group)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		electionsGroups[group] = append(electionsGroups[group], e)
+	}
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	roles := vs.(map[util.ID][]*models.Role)
-	return roles, nil
+	return electionsGroups, nil
 }
 
-func (s *readDBService) RoleEventsByType(ctx context.Context, roleID util.ID, tl util.TimeLineNumber, eventType models.RoleEventType) ([]*models.RoleEvent, error) {
-
-	sb := roleEventSelect.Where(sq.Eq{"roleid": roleID, "timeline": tl, "eventtype": string(eventType)})
-
-	q, args, err := sb.ToSql()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to build query")
+func scanElectionNomination(rows *sql.Rows, additionalFields ...interface{}) (*models.ElectionNomination, error) {
+	n := models.ElectionNomination{}
+	n.Candidate = &models.Member{}
+	fields := append([]interface{}{&n.Candidate.ID, &n.Candidate.StartTl, &n.Candidate.EndTl, &n.Candidate.IsAdmin, &n.Candidate.UserName, &n.Candidate.FullName, &n.Candidate.Email, &n.Candidate.IsDeactivated, &n.Candidate.IsServiceAccount, &n.NominatorID}, additionalFields...)
+	if err := rows.Scan(fields...); err != nil {
+		return nil, errors.Wrap(err, "failed to scan electionnomination rows")
 	}
+	return &n, nil
+}
 
-	var events []*models.RoleEvent
-	err = s.tx.Do(func(tx *db.WrappedTx) error {
-		rows, err := tx.Query(q, args...)
+func scanElectionNominationsGroups(rows *sql.Rows) (map[util.ID][]*models.ElectionNomination, error) {
+	nominationsGroups := map[util.ID][]*models.ElectionNomination{}
+	for rows.Next() {
+		var group util.ID
+		n, err := scanElectionNomination(rows, &group)
 		if err != nil {
-			return errors.WithMessage(err, "failed to execute query")
+			rows.Close()
+			return nil, err
 		}
-		events, err = scanRoleEvents(rows)
-		return err
-	})
-	if err != nil {
+		nominationsGroups[group] = append(nominationsGroups[group], n)
+	}
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-
-	return events, nil
+	return nominationsGroups, nil
 }
 
-func (s *readDBService) RoleEvents(ctx context.Context, roleID util.ID, first int, start, after util.TimeLineNumber) ([]*models.RoleEvent, bool, error) {
-	var condition sq.Sqlizer
-
-	if start != 0 {
-		condition = sq.LtOrEq{"roleevent.timeline": start}
+func scanRoleTemplate(rows *sql.Rows, additionalFields ...interface{}) (*models.RoleTemplate, error) {
+	r := models.RoleTemplate{}
+	var rawDomains, rawAccountabilities []byte
+	fields := append([]interface{}{&r.ID, &r.StartTl, &r.EndTl, &r.Name, &r.Purpose, &rawDomains, &rawAccountabilities}, additionalFields...)
+	if err := rows.Scan(fields...); err != nil {
+		return nil, errors.Wrap(err, "failed to scan role template rows")
 	}
-	if after != 0 {
-		condition = sq.Lt{"roleevent.timeline": after}
+	if err := json.Unmarshal(rawDomains, &r.Domains); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal role template domains")
 	}
-
-	sb := roleEventSelect.Where(sq.Eq{"roleid": roleID})
-	sb = sb.OrderBy("timeline desc")
-
-	if condition != nil {
-		sb = sb.Where(condition)
+	if err := json.Unmarshal(rawAccountabilities, &r.Accountabilities); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal role template accountabilities")
 	}
+	return &r, nil
+}
 
-	if first != 0 {
-		sb = sb.Limit(uint64(first))
+func scanRoleTemplates(rows *sql.Rows) ([]*models.RoleTemplate, error) {
+	roleTemplates := []*models.RoleTemplate{}
+	for rows.Next() {
+		r, err := scanRoleTemplate(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		roleTemplates = append(roleTemplates, r)
 	}
-
-	q, args, err := sb.ToSql()
-	if err != nil {
-		return nil, false, errors.Wrap(err, "failed to build query")
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
+	return roleTemplates, nil
+}
 
-	var events []*models.RoleEvent
-	err = s.tx.Do(func(tx *db.WrappedTx) error {
-		rows, err := tx.Query(q, args...)
+func scanRoleTemplatesGroups(rows *sql.Rows) (map[util.ID][]*models.RoleTemplate, error) {
+	roleTemplatesGroups := map[util.ID][]*models.RoleTemplate{}
+	for rows.Next() {
+		var group util.ID
+		r, err := scanRoleTemplate(rows, &group)
 		if err != nil {
-			return errors.WithMessage(err, "failed to execute query")
+			rows.Close()
+			return nil, err
 		}
-		events, err = scanRoleEvents(rows)
-		return err
-	})
-	if err != nil {
-		return nil, false, err
+		roleTemplatesGroups[group] = append(roleTemplatesGroups[group], r)
 	}
-
-	size := len(events)
-	if len(events) > first {
-		size = first
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	return events[:size], len(events) > first, nil
+	return roleTemplatesGroups, nil
 }
 
-func (s *readDBService) Member(ctx context.Context, tl util.TimeLineNumber, memberID util.ID) (*models.Member, error) {
-	vs, err := s.vertices(tl, vertexClassMember, 0, sq.Eq{"member.id": memberID}, nil)
-	if err != nil {
-		return nil, err
+func scanWebhook(rows *sql.Rows, additionalFields ...interface{}) (*models.Webhook, error) {
+	w := models.Webhook{}
+	var rawEventTypes []byte
+	fields := append([]interface{}{&w.ID, &w.StartTl, &w.EndTl, &w.URL, &rawEventTypes, &w.Secret}, additionalFields...)
+	if err := rows.Scan(fields...); err != nil {
+		return nil, errors.Wrap(err, "failed to scan webhook rows")
 	}
-	members := vs.([]*models.Member)
-	if len(members) == 0 {
-		return nil, nil
+	if err := json.Unmarshal(rawEventTypes, &w.EventTypes); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal webhook event types")
 	}
-	return members[0], nil
+	return &w, nil
 }
 
-func (s *readDBService) MemberAvatar(ctx context.Context, tl util.TimeLineNumber, id util.ID) (*models.Avatar, error) {
-	vs, err := s.vertices(tl, vertexClassMemberAvatar, 0, sq.Eq{"memberavatar.id": id}, nil)
-	if err != nil {
-		return nil, err
+func scanWebhooks(rows *sql.Rows) ([]*models.Webhook, error) {
+	webhooks := []*models.Webhook{}
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		webhooks = append(webhooks, w)
 	}
-	avatars := vs.([]*models.Avatar)
-	if len(avatars) == 0 {
-		return nil, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	return avatars[0], nil
+	return webhooks, nil
 }
 
-func (s *readDBService) MemberMatchUID(ctx context.Context, memberID util.ID) (string, error) {
-	sb := sb.Select("matchUID").From("membermatch").Where(sq.Eq{"memberid": memberID})
-	q, args, err := sb.ToSql()
-	if err != nil {
-		return "", err
-	}
-
-	var matchUID string
-	err = s.tx.Do(func(tx *db.WrappedTx) error {
-		return tx.QueryRow(q, args...).Scan(&matchUID)
-	})
-	if err != nil && err != sql.ErrNoRows {
-		return "", err
+func scanSession(rows *sql.Rows, additionalFields ...interface{}) (*models.Session, error) {
+	se := models.Session{}
+	fields := append([]interface{}{&se.ID, &se.MemberID, &se.CreationTime, &se.Expiration}, additionalFields...)
+	if err := rows.Scan(fields...); err != nil {
+		return nil, errors.Wrap(err, "failed to scan session rows")
 	}
-
-	return matchUID, nil
+	return &se, nil
 }
 
-func (s *readDBService) MemberByMatchUID(ctx context.Context, matchUID string) (*models.Member, error) {
-	sb := sb.Select("memberid").From("membermatch").Where(sq.Eq{"matchUID": matchUID})
-	q, args, err := sb.ToSql()
-	if err != nil {
-		return nil, err
+func scanSessions(rows *sql.Rows) ([]*models.Session, error) {
+	sessions := []*models.Session{}
+	for rows.Next() {
+		se, err := scanSession(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		sessions = append(sessions, se)
 	}
-
-	var memberID util.ID
-	err = s.tx.Do(func(tx *db.WrappedTx) error {
-		return tx.QueryRow(q, args...).Scan(&memberID)
-	})
-	if err != nil && err != sql.ErrNoRows {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	return s.Member(ctx, s.CurTimeLine(ctx).Number(), memberID)
+	return sessions, nil
 }
 
-func (s *readDBService) MemberByUserName(ctx context.Context, tl util.TimeLineNumber, userName string) (*models.Member, error) {
-	vs, err := s.vertices(tl, vertexClassMember, 0, sq.Eq{"member.username": userName}, nil)
-	if err != nil {
-		return nil, err
-	}
-	members := vs.([]*models.Member)
-	if len(members) == 0 {
-		return nil, nil
+func scanRefreshToken(rows *sql.Rows, additionalFields ...interface{}) (*models.RefreshToken, error) {
+	rt := models.RefreshToken{}
+	fields := append([]interface{}{&rt.ID, &rt.SessionID, &rt.TokenHash, &rt.Used}, additionalFields...)
+	if err := rows.Scan(fields...); err != nil {
+		return nil, errors.Wrap(err, "failed to scan refreshtoken rows")
 	}
-	return members[0], nil
+	return &rt, nil
 }
 
-func (s *readDBService) MemberByEmail(ctx context.Context, tl util.TimeLineNumber, email string) (*models.Member, error) {
-	vs, err := s.vertices(tl, vertexClassMember, 0, sq.Eq{"member.email": email}, nil)
-	if err != nil {
+func scanRefreshTokens(rows *sql.Rows) ([]*models.RefreshToken, error) {
+	refreshTokens := []*models.RefreshToken{}
+	for rows.Next() {
+		rt, err := scanRefreshToken(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		refreshTokens = append(refreshTokens, rt)
+	}
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	members := vs.([]*models.Member)
-	if len(members) == 0 {
-		return nil, nil
+	return refreshTokens, nil
+}
+
+func scanAPIToken(rows *sql.Rows, additionalFields ...interface{}) (*models.APIToken, error) {
+	a := models.APIToken{}
+	fields := append([]interface{}{&a.ID, &a.StartTl, &a.EndTl, &a.MemberID, &a.Name, &a.Scope, &a.CreationTime, &a.Expiration, &a.TokenHash}, additionalFields...)
+	if err := rows.Scan(fields...); err != nil {
+		return nil, errors.Wrap(err, "failed to scan apitoken rows")
 	}
-	return members[0], nil
+	return &a, nil
 }
 
-func (s *readDBService) MembersByIDs(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) ([]*models.Member, error) {
-	var condition interface{}
-	if len(membersIDs) > 0 {
-		condition = sq.Eq{"member.id": membersIDs}
+func scanAPITokens(rows *sql.Rows) ([]*models.APIToken, error) {
+	apiTokens := []*models.APIToken{}
+	for rows.Next() {
+		a, err := scanAPIToken(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		apiTokens = append(apiTokens, a)
 	}
-	vs, err := s.vertices(tl, vertexClassMember, 0, condition, []string{"member.fullname"})
-	if err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	members := vs.([]*models.Member)
-
-	return members, nil
+	return apiTokens, nil
 }
 
-func (s *readDBService) members(tl util.TimeLineNumber, searchString string, first int, after *string) ([]*models.Member, error) {
-	var condition sq.Sqlizer
-	if after != nil {
-		condition = sq.Gt{"member.fullname": after}
-	}
-	if searchString != "" {
-		likeCondition := sq.Or{GenericSqlizer(fmt.Sprintf(`lower(member.fullname) LIKE lower('%%%s%%')`, searchString)), GenericSqlizer(fmt.Sprintf(`lower(member.UserName) LIKE lower('%%%s%%')`, searchString))}
-		if condition == nil {
-			condition = likeCondition
-		} else {
-			condition = sq.And{condition, likeCondition}
+// scanWebhookDeadLetters returns the dead letters grouped by webhook id
+func scanWebhookDeadLetters(rows *sql.Rows) (map[util.ID][]*models.WebhookDeadLetter, error) {
+	deadLettersGroups := map[util.ID][]*models.WebhookDeadLetter{}
+	for rows.Next() {
+		var dl models.WebhookDeadLetter
+		if err := rows.Scan(&dl.TimeLineID, &dl.WebhookID, &dl.EventID, &dl.SequenceNumber, &dl.EventType, &dl.Attempts, &dl.LastError, &dl.Timestamp); err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, "failed to scan webhook dead letters rows")
 		}
+		deadLettersGroups[dl.WebhookID] = append(deadLettersGroups[dl.WebhookID], &dl)
 	}
-
-	vs, err := s.vertices(tl, vertexClassMember, uint64(first), condition, []string{"member.fullname"})
-	if err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	members := vs.([]*models.Member)
-
-	return members, nil
+	return deadLettersGroups, nil
 }
 
-func (s *readDBService) Members(ctx context.Context, tl util.TimeLineNumber, searchString string, first int, after *string) ([]*models.Member, bool, error) {
-	if first == 0 {
-		first = MaxFetchSize
-	}
-
-	// ask for first + 1 members to know if there're more members
-	members, err := s.members(tl, searchString, first+1, after)
-	if err != nil {
-		return nil, false, err
+// scanProposalsChanges returns the proposals changes grouped by proposal id
+func scanProposalsChanges(rows *sql.Rows) (map[util.ID]*change.ProposalChanges, error) {
+	proposalsChanges := map[util.ID]*change.ProposalChanges{}
+	for rows.Next() {
+		var v models.Vertex
+		var rawData []byte
+		if err := rows.Scan(&v.ID, &v.StartTl, &v.EndTl, &rawData); err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, "failed to scan proposal changes rows")
+		}
+		var pc *change.ProposalChanges
+		if err := json.Unmarshal(rawData, &pc); err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, "failed to unmarshal proposal changes")
+		}
+		proposalsChanges[v.ID] = pc
 	}
-
-	size := len(members)
-	if len(members) > first {
-		size = first
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	return members[:size], len(members) > first, nil
+	return proposalsChanges, nil
 }
 
-func (s *readDBService) DirectMemberCircles(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) (map[util.ID][]*models.Role, error) {
-	vs, err := s.connectedVertices(tl, membersIDs, edgeClassCircleDirectMember, edgeDirectionOut, "", nil, nil)
-	if err != nil {
-		return nil, err
+func scanProposalObjection(rows *sql.Rows, additionalFields ...interface{}) (*models.ProposalObjection, error) {
+	o := models.ProposalObjection{}
+	// To make sqlite3 happy
+	var status string
+	fields := append([]interface{}{&o.ID, &o.StartTl, &o.EndTl, &o.Reason, &o.Resolution, &status}, additionalFields...)
+	if err := rows.Scan(fields...); err != nil {
+		return nil, errors.Wrap(err, "failed to scan proposal objection rows")
 	}
-	return vs.(map[util.ID][]*models.Role), nil
+	o.Status = models.ProposalObjectionStatus(status)
+	return &o, nil
 }
 
-func (s *readDBService) MemberCircleEdges(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) (map[util.ID][]*models.MemberCircleEdge, error) {
-	memberCircleEdges := map[util.ID][]*models.MemberCircleEdge{}
-
-	memberCircleEdgesMap := map[util.ID]map[util.ID]*models.MemberCircleEdge{}
-	for _, memberID := range membersIDs {
-		memberCircleEdgesMap[memberID] = map[util.ID]*models.MemberCircleEdge{}
+func scanProposalObjections(rows *sql.Rows) ([]*models.ProposalObjection, error) {
+	objections := []*models.ProposalObjection{}
+	for rows.Next() {
+		o, err := scanProposalObjection(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		objections = append(objections, o)
 	}
-
-	// Add directly defined circles
-	rolesGroups, err := s.DirectMemberCircles(ctx, tl, membersIDs)
-	if err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	for _, memberID := range membersIDs {
-		for _, role := range rolesGroups[memberID] {
-			if memberCircleEdge, ok := memberCircleEdgesMap[memberID][role.ID]; ok {
-				memberCircleEdge.IsDirectMember = true
-			} else {
-				memberCircleEdgesMap[memberID][role.ID] = &models.MemberCircleEdge{Role: role, IsDirectMember: true}
-			}
+	return objections, nil
+}
+
+func scanProposalObjectionsGroups(rows *sql.Rows) (map[util.ID][]*models.ProposalObjection, error) {
+	objectionsGroups := map[util.ID][]*models.ProposalObjection{}
+	for rows.Next() {
+		var group util.ID
+		o, err := scanProposalObjection(rows, &group)
+		if err != nil {
+			rows.Close()
+			return nil, err
 		}
+		objectionsGroups[group] = append(objectionsGroups[group], o)
 	}
-
-	// get filled roles
-	memberRoleEdgesGroups, err := s.MemberRoleEdges(ctx, tl, membersIDs)
-	if err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	return objectionsGroups, nil
+}
 
-	roleIDsMap := map[util.ID]struct{}{}
-	for _, memberID := range membersIDs {
-		for _, memberRoleEdge := range memberRoleEdgesGroups[memberID] {
-			roleIDsMap[memberRoleEdge.Role.ID] = struct{}{}
-		}
-	}
-	roleIDs := []util.ID{}
-	for k := range roleIDsMap {
-		roleIDs = append(roleIDs, k)
+func scanRoleEvent(rows *sql.Rows) (*models.RoleEvent, error) {
+	e := models.RoleEvent{}
+	var rawData []byte
+	// To make sqlite3 happy
+	var eventType string
+	fields := []interface{}{&e.TimeLineID, &e.ID, &e.RoleID, &eventType, &rawData}
+	if err := rows.Scan(fields...); err != nil {
+		return nil, errors.Wrap(err, "error scanning event")
 	}
+	e.EventType = models.RoleEventType(eventType)
 
-	parentMap := map[util.ID]*models.Role{}
-	// We need roles parents
-	parentGroups, err := s.RoleParent(ctx, tl, roleIDs)
-	if err != nil {
-		return nil, err
-	}
-	parentIDs := []util.ID{}
-	for roleID, parent := range parentGroups {
-		parentIDs = append(parentIDs, parent.ID)
-		parentMap[roleID] = parent
+	data := models.GetRoleEventDataType(e.EventType)
+	if err := json.Unmarshal(rawData, &data); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal event")
 	}
+	e.Data = data
 
-	// We also need parent of parent to get the replink fillers
-	subParentGroups, err := s.RoleParent(ctx, tl, parentIDs)
-	if err != nil {
-		return nil, err
-	}
-	for parentID, parentParent := range subParentGroups {
-		parentIDs = append(parentIDs, parentParent.ID)
-		parentMap[parentID] = parentParent
-	}
+	return &e, nil
+}
 
-	// Add role fillers (except the ones set as nocoremember)
-	for _, memberID := range membersIDs {
-		for _, memberRoleEdge := range memberRoleEdgesGroups[memberID] {
-			if memberRoleEdge.NoCoreMember {
-				continue
-			}
-			role := memberRoleEdge.Role
-			parent := parentMap[role.ID]
-			if memberCircleEdge, ok := memberCircleEdgesMap[memberID][parent.ID]; ok {
-				memberCircleEdge.FilledRoles = append(memberCircleEdge.FilledRoles, role)
-			} else {
-				memberCircleEdgesMap[memberID][parent.ID] = &models.MemberCircleEdge{Role: parent, FilledRoles: []*models.Role{role}}
-			}
-			if role.RoleType == models.RoleTypeLeadLink {
-				memberCircleEdgesMap[memberID][parent.ID].IsLeadLink = true
-			}
+func scanRoleEvents(rows *sql.Rows) ([]*models.RoleEvent, error) {
+	roleEvents := []*models.RoleEvent{}
+	for rows.Next() {
+		e, err := scanRoleEvent(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
 		}
+		roleEvents = append(roleEvents, e)
 	}
-
-	// Add sub circles replink fillers
-	for _, memberID := range membersIDs {
-		for _, memberRoleEdge := range memberRoleEdgesGroups[memberID] {
-			if memberRoleEdge.Role.RoleType != models.RoleTypeRepLink {
-				continue
-			}
-			role := memberRoleEdge.Role
-			parent := parentMap[role.ID]
-			parentParent := parentMap[parent.ID]
-			if memberCircleEdge, ok := memberCircleEdgesMap[memberID][parentParent.ID]; ok {
-				memberCircleEdge.RepLink = append(memberCircleEdge.RepLink, parent)
-			} else {
-				memberCircleEdgesMap[memberID][parentParent.ID] = &models.MemberCircleEdge{Role: parentParent, RepLink: []*models.Role{parent}}
-			}
-		}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
+	return roleEvents, nil
+}
 
-	for _, memberID := range membersIDs {
-		for _, memberCircleEdge := range memberCircleEdgesMap[memberID] {
-			memberCircleEdge.IsCoreMember = memberCircleEdge.IsDirectMember
-			if len(memberCircleEdge.FilledRoles) > 0 {
-				memberCircleEdge.IsCoreMember = true
-			}
-			if len(memberCircleEdge.RepLink) > 0 {
-				memberCircleEdge.IsCoreMember = true
-			}
-			memberCircleEdges[memberID] = append(memberCircleEdges[memberID], memberCircleEdge)
-		}
-
-		// sort memberCircleEdge by role to get repeatable ordered results
-		sort.Sort(models.MemberCircleEdges(memberCircleEdges[memberID]))
+func (s *readDBService) insertRole(tl util.TimeLineNumber, id util.ID, role *models.Role) error {
+	q, args, err := roleInsert.Values(id, tl, nil, role.RoleType, role.Depth, role.Name, role.Purpose).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
 	}
-
-	return memberCircleEdges, nil
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+	return nil
 }
 
-func (s *readDBService) MemberRoleEdges(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) (map[util.ID][]*models.MemberRoleEdge, error) {
-	vs, err := s.connectedVertices(tl, membersIDs, edgeClassRoleMember, edgeDirectionOut, vertexClassRoleMemberEdge, nil, nil)
+func (s *readDBService) insertDomain(tl util.TimeLineNumber, id util.ID, domain *models.Domain) error {
+	q, args, err := domainInsert.Values(id, tl, nil, domain.Description).ToSql()
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to build query")
 	}
-
-	return vs.(map[util.ID][]*models.MemberRoleEdge), nil
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+	return nil
 }
 
-func (s *readDBService) Tension(ctx context.Context, tl util.TimeLineNumber, tensionID util.ID) (*models.Tension, error) {
-	vs, err := s.vertices(tl, vertexClassTension, 0, sq.Eq{"tension.id": tensionID}, nil)
+func (s *readDBService) insertAccountability(tl util.TimeLineNumber, id util.ID, accountability *models.Accountability) error {
+	q, args, err := accountabilityInsert.Values(id, tl, nil, accountability.Description).ToSql()
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to build query")
 	}
-	tensions := vs.([]*models.Tension)
-	if len(tensions) == 0 {
-		return nil, nil
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
 	}
-	return tensions[0], nil
+	return nil
 }
 
-func (s *readDBService) MemberTensions(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) (map[util.ID][]*models.Tension, error) {
-	// Only the member itself can see its tensions
-	member, err := s.CallingMember(ctx, tl)
+func (s *readDBService) insertRoleAdditionalContent(tl util.TimeLineNumber, id util.ID, roleAdditionalContent *models.RoleAdditionalContent) error {
+	q, args, err := roleAdditionalContentInsert.Values(id, tl, nil, roleAdditionalContent.Content).ToSql()
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to build query")
 	}
-
-	for _, memberID := range membersIDs {
-		if member.ID == memberID {
-			break
-		}
-		return nil, nil
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
 	}
+	return nil
+}
 
-	vs, err := s.connectedVertices(tl, membersIDs, edgeClassMemberTension, edgeDirectionIn, "", nil, nil)
+func (s *readDBService) insertMember(tl util.TimeLineNumber, id util.ID, member *models.Member) error {
+	q, args, err := memberInsert.Values(id, tl, nil, member.IsAdmin, member.UserName, member.FullName, member.Email, member.IsDeactivated, member.IsServiceAccount).ToSql()
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to build query")
 	}
-	tensionsGroups := vs.(map[util.ID][]*models.Tension)
-
-	return tensionsGroups, nil
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+	return nil
 }
 
-func (s *readDBService) TensionMember(ctx context.Context, tl util.TimeLineNumber, tensionsIDs []util.ID) (map[util.ID]*models.Member, error) {
-	vs, err := s.connectedVertices(tl, tensionsIDs, edgeClassMemberTension, edgeDirectionOut, "", nil, nil)
+func (s *readDBService) insertMemberAvatar(tl util.TimeLineNumber, id util.ID, avatar *models.Avatar) error {
+	q, args, err := memberAvatarInsert.Values(id, tl, nil, avatar.Image).ToSql()
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to build query")
 	}
-	membersGroups := vs.(map[util.ID][]*models.Member)
-
-	mg := map[util.ID]*models.Member{}
-	for k, v := range membersGroups {
-		mg[k] = v[0]
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
 	}
-
-	return mg, nil
+	return nil
 }
 
-func (s *readDBService) RoleTensions(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Tension, error) {
-	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleTension, edgeDirectionIn, "", nil, nil)
+func (s *readDBService) insertTension(tl util.TimeLineNumber, id util.ID, tension *models.Tension) error {
+	q, args, err := tensionInsert.Values(id, tl, nil, tension.Title, tension.Description, tension.Closed, tension.CloseReason, tension.Status, tension.MeetingID, tension.OutcomeType, tension.OutcomeReferenceID, tension.OutcomeDescription).ToSql()
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to build query")
 	}
-	return vs.(map[util.ID][]*models.Tension), nil
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+	return nil
 }
 
-func (s *readDBService) TensionRole(ctx context.Context, tl util.TimeLineNumber, tensionsIDs []util.ID) (map[util.ID]*models.Role, error) {
-	vs, err := s.connectedVertices(tl, tensionsIDs, edgeClassRoleTension, edgeDirectionOut, "", nil, nil)
+func (s *readDBService) insertProposal(tl util.TimeLineNumber, id util.ID, proposal *models.Proposal) error {
+	q, args, err := proposalInsert.Values(id, tl, nil, proposal.Title, proposal.Description, proposal.Status).ToSql()
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to build query")
 	}
-	rolesGroups := vs.(map[util.ID][]*models.Role)
-
-	mg := map[util.ID]*models.Role{}
-	for k, v := range rolesGroups {
-		mg[k] = v[0]
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
 	}
-
-	return mg, nil
+	return nil
 }
 
-func (s *readDBService) RoleParent(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID]*models.Role, error) {
-	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleRole, edgeDirectionIn, "", nil, nil)
+func (s *readDBService) insertProject(tl util.TimeLineNumber, id util.ID, project *models.Project) error {
+	q, args, err := projectInsert.Values(id, tl, nil, project.Title, project.Description, project.Status, project.DueDate).ToSql()
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to build query")
 	}
-	rolesGroups := vs.(map[util.ID][]*models.Role)
-
-	rg := map[util.ID]*models.Role{}
-	for k, v := range rolesGroups {
-		rg[k] = v[0]
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
 	}
-
-	return rg, nil
+	return nil
 }
 
-func (s *readDBService) RoleParents(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Role, error) {
-	roleParentsGroups := map[util.ID][]*models.Role{}
-	// TODO(sgotti) use sql WITH RECURSIVE where supported? (postgres)
-	roleParentGroups, err := s.RoleParent(ctx, tl, rolesIDs)
+func (s *readDBService) insertAction(tl util.TimeLineNumber, id util.ID, action *models.Action) error {
+	q, args, err := actionInsert.Values(id, tl, nil, action.Title, action.Description, action.Status, action.DueDate).ToSql()
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to build query")
 	}
-	parents := map[util.ID]*models.Role{}
-	for id, roleParent := range roleParentGroups {
-		parents[id] = roleParent
-	}
-	for {
-		// collect role with unknown parent
-		neededMap := map[util.ID]struct{}{}
-		for _, parent := range parents {
-			if _, ok := parents[parent.ID]; !ok {
-				neededMap[parent.ID] = struct{}{}
-			}
-		}
-		needed := []util.ID{}
-		for id := range neededMap {
-			needed = append(needed, id)
-		}
-		roleParentGroups, err = s.RoleParent(ctx, tl, needed)
-		if err != nil {
-			return nil, err
-		}
-		if len(roleParentGroups) == 0 {
-			break
-		}
-		for id, roleParent := range roleParentGroups {
-			parents[id] = roleParent
-		}
-	}
-
-	for _, id := range rolesIDs {
-		roleParentsGroups[id] = []*models.Role{}
-		curID := id
-		for {
-			if parent, ok := parents[curID]; ok {
-				roleParentsGroups[id] = append(roleParentsGroups[id], parent)
-				curID = parent.ID
-			} else {
-				break
-			}
-		}
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
 	}
-
-	return roleParentsGroups, nil
+	return nil
 }
 
-func (s *readDBService) CircleCoreRole(ctx context.Context, tl util.TimeLineNumber, roleType models.RoleType, rolesIDs []util.ID) (map[util.ID]*models.Role, error) {
-	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleRole, edgeDirectionOut, "", sq.Eq{"role.roletype": roleType}, nil)
+func (s *readDBService) insertMeeting(tl util.TimeLineNumber, id util.ID, meeting *models.Meeting) error {
+	q, args, err := meetingInsert.Values(id, tl, nil, meeting.MeetingType, meeting.Date).ToSql()
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to build query")
 	}
-	rolesGroups := vs.(map[util.ID][]*models.Role)
-
-	rg := map[util.ID]*models.Role{}
-	for k, v := range rolesGroups {
-		rg[k] = v[0]
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
 	}
-
-	return rg, nil
+	return nil
 }
 
-func (s *readDBService) CircleDirectMembers(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Member, error) {
-	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassCircleDirectMember, edgeDirectionIn, "", nil, nil)
+func (s *readDBService) insertElection(tl util.TimeLineNumber, id util.ID, election *models.Election) error {
+	q, args, err := electionInsert.Values(id, tl, nil, election.RoleType, election.Status, election.ElectionExpiration).ToSql()
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to build query")
 	}
-
-	return vs.(map[util.ID][]*models.Member), nil
-}
-
-func (s *readDBService) CircleMemberEdges(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.CircleMemberEdge, error) {
-	circleMemberEdges := map[util.ID][]*models.CircleMemberEdge{}
-
-	circleMemberEdgesMap := map[util.ID]map[util.ID]*models.CircleMemberEdge{}
-	for _, roleID := range rolesIDs {
-		circleMemberEdgesMap[roleID] = map[util.ID]*models.CircleMemberEdge{}
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
 	}
+	return nil
+}
 
-	// Add directly defined circle members
-	membersGroups, err := s.CircleDirectMembers(ctx, tl, rolesIDs)
+func (s *readDBService) insertRoleTemplate(tl util.TimeLineNumber, id util.ID, roleTemplate *models.RoleTemplate) error {
+	domains, err := json.Marshal(roleTemplate.Domains)
 	if err != nil {
-		return nil, err
-	}
-	for _, roleID := range rolesIDs {
-		for _, member := range membersGroups[roleID] {
-			if circleMemberEdge, ok := circleMemberEdgesMap[roleID][member.ID]; ok {
-				circleMemberEdge.IsDirectMember = true
-			} else {
-				circleMemberEdgesMap[roleID][member.ID] = &models.CircleMemberEdge{Member: member, IsDirectMember: true}
-			}
-		}
+		return errors.Wrap(err, "failed to marshal role template domains")
 	}
-
-	childsGroups, err := s.ChildRoles(ctx, tl, rolesIDs, nil)
+	accountabilities, err := json.Marshal(roleTemplate.Accountabilities)
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to marshal role template accountabilities")
 	}
-	childsIDsMap := map[util.ID]struct{}{}
-	for _, roleID := range rolesIDs {
-		childs := childsGroups[roleID]
-		for _, child := range childs {
-			childsIDsMap[child.ID] = struct{}{}
-		}
+	q, args, err := roleTemplateInsert.Values(id, tl, nil, roleTemplate.Name, roleTemplate.Purpose, domains, accountabilities).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
 	}
-	childsIDs := []util.ID{}
-	for k := range childsIDsMap {
-		childsIDs = append(childsIDs, k)
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
 	}
+	return nil
+}
 
-	// We also need child of child to get the replink fillers
-	subChildsGroups, err := s.ChildRoles(ctx, tl, childsIDs, nil)
+func (s *readDBService) insertChecklistItem(tl util.TimeLineNumber, id util.ID, checklistItem *models.ChecklistItem) error {
+	q, args, err := checklistItemInsert.Values(id, tl, nil, checklistItem.Description).ToSql()
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to build query")
 	}
-	for _, childID := range childsIDs {
-		subChilds := subChildsGroups[childID]
-		for _, subChild := range subChilds {
-			childsIDsMap[subChild.ID] = struct{}{}
-		}
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
 	}
+	return nil
+}
 
-	// Merge childs and subchilds in the same list to do just one call to s.getRoleMemberEdges
-	childsIDs = []util.ID{}
-	for k := range childsIDsMap {
-		childsIDs = append(childsIDs, k)
+func (s *readDBService) insertMetric(tl util.TimeLineNumber, id util.ID, metric *models.Metric) error {
+	q, args, err := metricInsert.Values(id, tl, nil, metric.Description).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
 	}
-
-	roleMemberEdgesGroups, err := s.RoleMemberEdges(ctx, tl, childsIDs, nil)
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
 	if err != nil {
-		return nil, err
+		return errors.WithMessage(err, "failed to execute query")
 	}
+	return nil
+}
 
-	// Add role fillers (except the ones set as nocoremember)
-	for _, roleID := range rolesIDs {
-		for _, child := range childsGroups[roleID] {
-			for _, roleMemberEdge := range roleMemberEdgesGroups[child.ID] {
-				if roleMemberEdge.NoCoreMember {
-					continue
-				}
-				if circleMemberEdge, ok := circleMemberEdgesMap[roleID][roleMemberEdge.Member.ID]; ok {
-					circleMemberEdge.FilledRoles = append(circleMemberEdge.FilledRoles, child)
-				} else {
-					circleMemberEdgesMap[roleID][roleMemberEdge.Member.ID] = &models.CircleMemberEdge{Member: roleMemberEdge.Member, FilledRoles: []*models.Role{child}}
-				}
-				if child.RoleType == models.RoleTypeLeadLink {
-					circleMemberEdgesMap[roleID][roleMemberEdge.Member.ID].IsLeadLink = true
-				}
-			}
-		}
+func (s *readDBService) insertProposalChanges(tl util.TimeLineNumber, id util.ID, proposalChanges *change.ProposalChanges) error {
+	data, err := json.Marshal(proposalChanges)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal proposal changes")
 	}
-
-	// Add sub circles replink fillers
-	for _, roleID := range rolesIDs {
-		for _, child := range childsGroups[roleID] {
-			for _, subChild := range subChildsGroups[child.ID] {
-				if subChild.RoleType != models.RoleTypeRepLink {
-					continue
-				}
-				for _, roleMemberEdge := range roleMemberEdgesGroups[subChild.ID] {
-					// NOTE(sgotti): there must be only one member filling the replink
-					if circleMemberEdge, ok := circleMemberEdgesMap[roleID][roleMemberEdge.Member.ID]; ok {
-						circleMemberEdge.RepLink = append(circleMemberEdge.RepLink, child)
-					} else {
-						circleMemberEdgesMap[roleID][roleMemberEdge.Member.ID] = &models.CircleMemberEdge{Member: roleMemberEdge.Member, RepLink: []*models.Role{child}}
-					}
-				}
-			}
-		}
+	q, args, err := proposalChangesInsert.Values(id, tl, nil, data).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
 	}
-
-	for _, roleID := range rolesIDs {
-		for _, circleMemberEdge := range circleMemberEdgesMap[roleID] {
-			circleMemberEdge.IsCoreMember = circleMemberEdge.IsDirectMember
-			if len(circleMemberEdge.FilledRoles) > 0 {
-				circleMemberEdge.IsCoreMember = true
-			}
-			if len(circleMemberEdge.RepLink) > 0 {
-				circleMemberEdge.IsCoreMember = true
-			}
-			circleMemberEdges[roleID] = append(circleMemberEdges[roleID], circleMemberEdge)
-		}
-
-		// sort circleMemberEdges by member to get repeatable ordered results
-		sort.Sort(models.CircleMemberEdges(circleMemberEdges[roleID]))
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
 	}
-
-	return circleMemberEdges, nil
+	return nil
 }
 
-func (s *readDBService) RoleMemberEdges(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID, orderBys []string) (map[util.ID][]*models.RoleMemberEdge, error) {
-	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleMember, edgeDirectionIn, vertexClassRoleMemberEdge, nil, nil)
+func (s *readDBService) insertProposalObjection(tl util.TimeLineNumber, id util.ID, objection *models.ProposalObjection) error {
+	q, args, err := proposalObjectionInsert.Values(id, tl, nil, objection.Reason, objection.Resolution, objection.Status).ToSql()
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to build query")
 	}
-
-	return vs.(map[util.ID][]*models.RoleMemberEdge), nil
-}
-
-func (s *readDBService) RoleDomains(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Domain, error) {
-	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleDomain, edgeDirectionIn, "", nil, nil)
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
 	if err != nil {
-		return nil, err
+		return errors.WithMessage(err, "failed to execute query")
 	}
-
-	return vs.(map[util.ID][]*models.Domain), nil
+	return nil
 }
 
-func (s *readDBService) RoleAccountabilities(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Accountability, error) {
-	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleAccountability, edgeDirectionIn, "", nil, nil)
+// insertRoleEvent inserts or update a role event
+func (s *readDBService) insertWebhook(tl util.TimeLineNumber, id util.ID, webhook *models.Webhook) error {
+	eventTypes, err := json.Marshal(webhook.EventTypes)
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to marshal webhook event types")
 	}
-
-	return vs.(map[util.ID][]*models.Accountability), nil
+	q, args, err := webhookInsert.Values(id, tl, nil, webhook.URL, eventTypes, webhook.Secret).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query")
+	}
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+	return nil
 }
 
-func (s *readDBService) RolesAdditionalContent(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID]*models.RoleAdditionalContent, error) {
-	condition := sq.Eq{"roleadditionalcontent.id": rolesIDs}
-	vs, err := s.vertices(tl, vertexClassRoleAdditionalContent, 0, condition, nil)
+func (s *readDBService) insertSession(session *models.Session) error {
+	q, args, err := sessionInsert.Values(session.ID, session.MemberID, session.CreationTime, session.Expiration).ToSql()
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to build query")
 	}
-	rolesAdditionalContent := vs.([]*models.RoleAdditionalContent)
-
-	rolesAdditionalContentMap := map[util.ID]*models.RoleAdditionalContent{}
-	for _, r := range rolesAdditionalContent {
-		rolesAdditionalContentMap[r.ID] = r
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
 	}
-	return rolesAdditionalContentMap, nil
+	return nil
 }
 
-func (s *readDBService) MemberPassword(ctx context.Context, memberID util.ID) (string, error) {
-	sb := sb.Select("password").From("password").Where(sq.Eq{"memberid": memberID})
-	q, args, err := sb.ToSql()
+func (s *readDBService) insertRefreshToken(refreshToken *models.RefreshToken) error {
+	q, args, err := refreshTokenInsert.Values(refreshToken.ID, refreshToken.SessionID, refreshToken.TokenHash, refreshToken.Used).ToSql()
 	if err != nil {
-		return "", err
+		return errors.Wrap(err, "failed to build query")
 	}
-
-	var password string
 	err = s.tx.Do(func(tx *db.WrappedTx) error {
-		return tx.QueryRow(q, args...).Scan(&password)
+		_, err = tx.Exec(q, args...)
+		return err
 	})
 	if err != nil {
-		return "", err
+		return errors.WithMessage(err, "failed to execute query")
 	}
-
-	return password, nil
+	return nil
 }
 
-func (s *readDBService) AuthenticateUIDPassword(ctx context.Context, memberID util.ID, password string) (*models.Member, error) {
-	tl := s.CurTimeLine(ctx)
-
-	member, err := s.Member(ctx, tl.Number(), memberID)
+func (s *readDBService) insertAPIToken(tl util.TimeLineNumber, id util.ID, apiToken *models.APIToken) error {
+	q, args, err := apiTokenInsert.Values(id, tl, nil, apiToken.MemberID, apiToken.Name, apiToken.Scope, apiToken.CreationTime, apiToken.Expiration, apiToken.TokenHash).ToSql()
 	if err != nil {
-		return nil, err
-	}
-	if member == nil {
-		return nil, errors.Errorf("no member with id: %s", memberID)
+		return errors.Wrap(err, "failed to build query")
 	}
-
-	curPasswordHash, err := s.MemberPassword(ctx, memberID)
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
 	if err != nil {
-		return nil, err
+		return errors.WithMessage(err, "failed to execute query")
 	}
+	return nil
+}
 
-	ok, err := util.CompareHashAndPassword(curPasswordHash, password)
+func (s *readDBService) insertWebhookDeadLetter(dl *models.WebhookDeadLetter) error {
+	q, args, err := webhookDeadLetterInsert.Values(dl.TimeLineID, dl.WebhookID, dl.EventID, dl.SequenceNumber, dl.EventType, dl.Attempts, dl.LastError, dl.Timestamp).ToSql()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to check password")
+		return errors.Wrap(err, "failed to build query")
 	}
-	if !ok {
-		return nil, errors.Errorf("invalid password")
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		_, err = tx.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
 	}
-
-	return member, nil
+	return nil
 }
 
-func (s *readDBService) AuthenticateUserNamePassword(ctx context.Context, userName string, password string) (*models.Member, error) {
-	tl := s.CurTimeLine(ctx)
-
-	member, err := s.MemberByUserName(ctx, tl.Number(), userName)
+func (s *readDBService) insertRoleEvent(roleEvent *models.RoleEvent) error {
+	data, err := json.Marshal(roleEvent.Data)
 	if err != nil {
-		return nil, err
-	}
-	if member == nil {
-		return nil, errors.Errorf("no member with username: %s", userName)
+		return errors.Wrap(err, "failed to marshal event")
 	}
-
-	curPasswordHash, err := s.MemberPassword(ctx, member.ID)
+	q, args, err := roleEventInsert.Values(roleEvent.TimeLineID, roleEvent.ID, roleEvent.RoleID, roleEvent.EventType, data).ToSql()
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to build query")
 	}
-
-	ok, err := util.CompareHashAndPassword(curPasswordHash, password)
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		// poor man insert or update...
+		if _, err := tx.Exec("delete from roleevent where id = $1", roleEvent.ID); err != nil {
+			return errors.Wrap(err, "failed to delete roleevent")
+		}
+		_, err = tx.Exec(q, args...)
+		return err
+	})
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to check password")
-	}
-	if !ok {
-		return nil, errors.Errorf("invalid password")
+		return errors.WithMessage(err, "failed to execute query")
 	}
+	return nil
+}
 
-	return member, nil
+type readDBService struct {
+	tx                        *db.Tx
+	forcedAdminMemberUserName string
+
+	// cached curTl to not query every time
+	// the DBService lives inside a repreatable read/serializable transaction so
+	// curTl could change only if we are chaning it in this transaction
+	curTl     *util.TimeLine
+	curTlLock sync.Mutex
 }
 
-func (s *readDBService) AuthenticateEmailPassword(ctx context.Context, email string, password string) (*models.Member, error) {
-	tl := s.CurTimeLine(ctx)
+func NewReadDBService(tx *db.Tx) (*readDBService, error) {
+	s := &readDBService{tx: tx}
 
-	member, err := s.MemberByEmail(ctx, tl.Number(), email)
+	curTl, err := s.curTimeLineFromDB()
 	if err != nil {
 		return nil, err
 	}
-	if member == nil {
-		return nil, errors.Errorf("no member with email: %s", email)
+	if curTl == nil {
+		curTl = &util.TimeLine{}
 	}
 
-	curPasswordHash, err := s.MemberPassword(ctx, member.ID)
-	if err != nil {
-		return nil, err
-	}
+	s.curTl = curTl
 
-	ok, err := util.CompareHashAndPassword(curPasswordHash, password)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to check password")
-	}
-	if !ok {
-		return nil, errors.Errorf("invalid password")
-	}
+	return s, nil
+}
 
-	return member, nil
+func (s *readDBService) SetForceAdminMemberUserName(u string) {
+	s.forcedAdminMemberUserName = u
 }
 
-func (s *readDBService) CallingMember(ctx context.Context, curTl util.TimeLineNumber) (*models.Member, error) {
-	useridString, ok := ctx.Value("userid").(string)
-	if !ok || useridString == "" {
-		return nil, errors.Errorf("empty userid")
-	}
-	userid, err := uuid.FromString(useridString)
-	if err != nil {
-		return nil, err
-	}
+func (s *readDBService) curTimeLineFromDB() (*util.TimeLine, error) {
+	// zeroed timeline, also valid if there're no rows
+	var tl util.TimeLine
 
-	// check that the member is valid
-	// TODO(sgotti) check disabled members when implemented
-	member, err := s.Member(ctx, curTl, util.NewFromUUID(userid))
-	if err != nil {
-		return nil, err
-	}
-	if member == nil {
-		return nil, errors.Errorf("unexistent member with id: %s", userid)
+	err := s.tx.Do(func(tx *db.WrappedTx) error {
+		return tx.QueryRow("select timestamp from timeline order by timestamp desc limit 1").Scan(&tl.Timestamp)
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
 	}
 
-	// Set member as admin if defined as forcedAdminMemberUserName
-	if s.forcedAdminMemberUserName == member.UserName {
-		member.IsAdmin = true
+	return &tl, err
+}
+
+func (s *readDBService) CurTimeLine(ctx context.Context) *util.TimeLine {
+	s.curTlLock.Lock()
+	defer s.curTlLock.Unlock()
+
+	if s.curTl == nil {
+		return &util.TimeLine{}
 	}
 
-	return member, nil
+	// take a copy of curTl
+	c := *s.curTl
+
+	//log.Debugf("curTl: %s", c)
+	return &c
 }
 
-func (s *readDBService) memberIsLeadLink(ctx context.Context, curTl util.TimeLineNumber, memberID, roleID util.ID) (bool, error) {
-	childsGroups, err := s.ChildRoles(ctx, curTl, []util.ID{roleID}, nil)
-	if err != nil {
-		return false, err
-	}
-	childs := childsGroups[roleID]
+func (s *readDBService) TimeLine(ctx context.Context, sn util.TimeLineNumber) (*util.TimeLine, error) {
+	var tl util.TimeLine
 
-	var leadLinkRole *models.Role
-	for _, child := range childs {
-		if child.RoleType == models.RoleTypeLeadLink {
-			leadLinkRole = child
-			break
+	err := s.tx.Do(func(tx *db.WrappedTx) error {
+		if err := tx.QueryRow("select timestamp from timeline where timestamp = $1", time.Unix(0, int64(sn))).Scan(&tl.Timestamp); err != nil {
+			return err
 		}
+		return nil
+	})
+	if err == sql.ErrNoRows {
+		return nil, errors.Errorf("timeline %d doesn't exists", sn)
 	}
-	if leadLinkRole == nil {
-		return false, nil
-	}
-
-	roleMemberEdgesGroups, err := s.RoleMemberEdges(ctx, curTl, []util.ID{leadLinkRole.ID}, nil)
-	if err != nil {
-		return false, err
-	}
-	roleMemberEdges := roleMemberEdgesGroups[leadLinkRole.ID]
 
-	// lead link must have at max one assigned member
-	if len(roleMemberEdges) == 0 {
-		return false, nil
-	}
-	return roleMemberEdges[0].Member.ID == memberID, nil
+	return &tl, err
 }
 
-// retrieve permission at the circle level
-func (s *readDBService) MemberCirclePermissions(ctx context.Context, tl util.TimeLineNumber, roleID util.ID) (*models.MemberCirclePermissions, error) {
-	cp := &models.MemberCirclePermissions{}
+func (s *readDBService) TimeLineForGroupID(ctx context.Context, groupID util.ID) (*util.TimeLine, error) {
+	var tl util.TimeLine
 
-	callingMember, err := s.CallingMember(ctx, tl)
-	if err != nil {
-		return nil, err
+	err := s.tx.Do(func(tx *db.WrappedTx) error {
+		return tx.QueryRow("select timestamp from timeline where groupid = $1", groupID).Scan(&tl.Timestamp)
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
 	}
 
-	role, err := s.Role(ctx, tl, roleID)
-	if err != nil {
-		return nil, err
+	return &tl, err
+}
+
+func (s *readDBService) TimeLines(ctx context.Context, ts *time.Time, sn util.TimeLineNumber, limit int, after bool, aggregateType string, aggregateID *util.ID) ([]*util.TimeLine, bool, error) {
+	var tls []*util.TimeLine
+	if limit <= 0 {
+		limit = MaxFetchSize
 	}
-	if role == nil {
-		return nil, errors.Errorf("role with id %s doesn't exist", roleID)
+	if ts == nil {
+		t := time.Unix(0, int64(sn)).UTC()
+		ts = &t
 	}
-	if role.RoleType != models.RoleTypeCircle {
-		// don't return an error since the caller can't know if this is a circle
-		// or another kind of role
-		return nil, nil
+	sb := sb.Select("timestamp").From("timeline")
+	// ask for limit + 1 rows to know if there's more data
+	if after {
+		sb = sb.Where(sq.Gt{"timestamp": ts}).OrderBy("timestamp asc")
+	} else {
+		sb = sb.Where(sq.Lt{"timestamp": ts}).OrderBy("timestamp desc")
 	}
+	sb = sb.Limit(uint64(limit + 1))
 
-	proleGroups, err := s.RoleParent(ctx, tl, []util.ID{roleID})
-	if err != nil {
-		return nil, err
+	if aggregateType != "" {
+		sb = sb.Where(sq.Eq{"aggregatetype": aggregateType})
 	}
-	prole := proleGroups[role.ID]
-
-	isLeadLink, err := s.memberIsLeadLink(ctx, tl, callingMember.ID, roleID)
+	if aggregateID != nil {
+		sb = sb.Where(sq.Eq{"aggregateid": aggregateID})
+	}
+
+	q, args, err := sb.ToSql()
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to build query")
+	}
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		rows, err := tx.Query(q, args...)
+		if err != nil {
+			return err
+		}
+		tls, err = scanTimeLines(rows)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	size := len(tls)
+	if len(tls) > limit {
+		size = limit
+	}
+	return tls[:size], len(tls) > limit, err
+}
+
+func (s *readDBService) TimeLineAtTimeStamp(ctx context.Context, ts time.Time) (*util.TimeLine, error) {
+	var tl util.TimeLine
+
+	err := s.tx.Do(func(tx *db.WrappedTx) error {
+		return tx.QueryRow("select timestamp from timeline where timestamp >= $1 order by timestamp asc limit 1", ts).Scan(&tl.Timestamp)
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return &tl, err
+}
+
+func (s *readDBService) RootRole(ctx context.Context, tl util.TimeLineNumber) (*models.Role, error) {
+	vs, err := s.vertices(tl, vertexClassRole, 0, sq.Eq{"role.depth": 0}, nil)
 	if err != nil {
 		return nil, err
 	}
+	roles := vs.([]*models.Role)
 
-	// Only the circle lead link can assign child circles lead links
-	if callingMember.IsAdmin || isLeadLink {
-		cp.AssignChildCircleLeadLink = true
+	if len(roles) == 0 {
+		return nil, nil
 	}
+	if len(roles) > 1 {
+		return nil, errors.Errorf("too many root roles. This shouldn't happen!")
+	}
+	return roles[0], nil
+}
 
-	// Only the circle lead link can assign member to core roles
-	if callingMember.IsAdmin || isLeadLink {
-		cp.AssignCircleCoreRoles = true
+func (s *readDBService) Role(ctx context.Context, tl util.TimeLineNumber, id util.ID) (*models.Role, error) {
+	var err error
+
+	vs, err := s.vertices(tl, vertexClassRole, 0, sq.Eq{"role.id": id}, nil)
+	if err != nil {
+		return nil, err
 	}
+	roles := vs.([]*models.Role)
 
-	// Only the circle lead link can assign member assignment to child roles
-	if callingMember.IsAdmin || isLeadLink {
-		cp.AssignChildRoleMembers = true
+	if len(roles) < 1 {
+		return nil, nil
 	}
+	return roles[0], nil
+}
 
-	// Only the circle lead link can assign direct member
-	if callingMember.IsAdmin || isLeadLink {
-		cp.AssignCircleDirectMembers = true
+func (s *readDBService) Roles(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) ([]*models.Role, error) {
+	var condition interface{}
+	if len(rolesIDs) > 0 {
+		condition = sq.Eq{"role.id": rolesIDs}
+	}
+	vs, err := s.vertices(tl, vertexClassRole, 0, condition, []string{"role.name"})
+	if err != nil {
+		return nil, err
 	}
+	roles := vs.([]*models.Role)
 
-	// Only the circle lead link can manage roles
-	if callingMember.IsAdmin || isLeadLink {
-		cp.ManageChildRoles = true
+	return roles, nil
+}
+
+func (s *readDBService) ChildRoles(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID, orderBys []string) (map[util.ID][]*models.Role, error) {
+	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleRole, edgeDirectionOut, "", nil, orderBys)
+	if err != nil {
+		return nil, err
 	}
+	roles := vs.(map[util.ID][]*models.Role)
+	return roles, nil
+}
 
-	// Only the circle lead link can manage additional content
-	if callingMember.IsAdmin || isLeadLink {
-		cp.ManageRoleAdditionalContent = true
+func (s *readDBService) RoleEventsByType(ctx context.Context, roleID util.ID, tl util.TimeLineNumber, eventType models.RoleEventType) ([]*models.RoleEvent, error) {
+
+	sb := roleEventSelect.Where(sq.Eq{"roleid": roleID, "timeline": tl, "eventtype": string(eventType)})
+
+	q, args, err := sb.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
 	}
 
-	// As a special case, on the root role(circle), its lead link can manage the
-	// circle data and its lead link
-	if prole == nil {
-		if callingMember.IsAdmin || isLeadLink {
-			cp.AssignRootCircleLeadLink = true
-			cp.ManageRootCircle = true
+	var events []*models.RoleEvent
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		rows, err := tx.Query(q, args...)
+		if err != nil {
+			return errors.WithMessage(err, "failed to execute query")
 		}
+		events, err = scanRoleEvents(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return cp, nil
+	return events, nil
 }
 
-type DBEventHandler struct {
-	db *db.DB
-	es *eventstore.EventStore
-	nf ln.NotifierFactory
+func (s *readDBService) RoleEvents(ctx context.Context, roleID util.ID, first int, start, after util.TimeLineNumber) ([]*models.RoleEvent, bool, error) {
+	var condition sq.Sqlizer
+
+	if start != 0 {
+		condition = sq.LtOrEq{"roleevent.timeline": start}
+	}
+	if after != 0 {
+		condition = sq.Lt{"roleevent.timeline": after}
+	}
+
+	sb := roleEventSelect.Where(sq.Eq{"roleid": roleID})
+	sb = sb.OrderBy("timeline desc")
+
+	if condition != nil {
+		sb = sb.Where(condition)
+	}
+
+	if first != 0 {
+		sb = sb.Limit(uint64(first))
+	}
+
+	q, args, err := sb.ToSql()
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to build query")
+	}
+
+	var events []*models.RoleEvent
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		rows, err := tx.Query(q, args...)
+		if err != nil {
+			return errors.WithMessage(err, "failed to execute query")
+		}
+		events, err = scanRoleEvents(rows)
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	size := len(events)
+	if len(events) > first {
+		size = first
+	}
+	return events[:size], len(events) > first, nil
 }
 
-func NewDBEventHandler(db *db.DB, es *eventstore.EventStore, nf ln.NotifierFactory) *DBEventHandler {
-	return &DBEventHandler{
-		db: db,
-		es: es,
-		nf: nf,
+func (s *readDBService) Member(ctx context.Context, tl util.TimeLineNumber, memberID util.ID) (*models.Member, error) {
+	vs, err := s.vertices(tl, vertexClassMember, 0, sq.Eq{"member.id": memberID}, nil)
+	if err != nil {
+		return nil, err
 	}
+	members := vs.([]*models.Member)
+	if len(members) == 0 {
+		return nil, nil
+	}
+	return members[0], nil
 }
 
-func (h *DBEventHandler) Name() string {
-	return "readdb"
+func (s *readDBService) MemberAvatar(ctx context.Context, tl util.TimeLineNumber, id util.ID) (*models.Avatar, error) {
+	vs, err := s.vertices(tl, vertexClassMemberAvatar, 0, sq.Eq{"memberavatar.id": id}, nil)
+	if err != nil {
+		return nil, err
+	}
+	avatars := vs.([]*models.Avatar)
+	if len(avatars) == 0 {
+		return nil, nil
+	}
+	return avatars[0], nil
 }
 
-func (h *DBEventHandler) HandleEvents() error {
-	var sn int64
-	err := h.db.Do(func(tx *db.Tx) error {
-		err := tx.Do(func(tx *db.WrappedTx) error {
-			return tx.QueryRow("select sequencenumber from sequencenumber order by sequencenumber desc limit 1").Scan(&sn)
-		})
-		if err != nil {
-			if err == sql.ErrNoRows {
-				sn = 0
-			} else {
+func (s *readDBService) MemberMatchUID(ctx context.Context, memberID util.ID) (string, error) {
+	sb := sb.Select("matchUID").From("membermatch").Where(sq.Eq{"memberid": memberID})
+	q, args, err := sb.ToSql()
+	if err != nil {
+		return "", err
+	}
+
+	var matchUID string
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		return tx.QueryRow(q, args...).Scan(&matchUID)
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return "", err
+	}
+
+	return matchUID, nil
+}
+
+func (s *readDBService) MemberByMatchUID(ctx context.Context, matchUID string) (*models.Member, error) {
+	sb := sb.Select("memberid").From("membermatch").Where(sq.Eq{"matchUID": matchUID})
+	q, args, err := sb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var memberID util.ID
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		return tx.QueryRow(q, args...).Scan(&memberID)
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	return s.Member(ctx, s.CurTimeLine(ctx).Number(), memberID)
+}
+
+func (s *readDBService) MemberByUserName(ctx context.Context, tl util.TimeLineNumber, userName string) (*models.Member, error) {
+	vs, err := s.vertices(tl, vertexClassMember, 0, sq.Eq{"member.username": userName}, nil)
+	if err != nil {
+		return nil, err
+	}
+	members := vs.([]*models.Member)
+	if len(members) == 0 {
+		return nil, nil
+	}
+	return members[0], nil
+}
+
+func (s *readDBService) MemberByEmail(ctx context.Context, tl util.TimeLineNumber, email string) (*models.Member, error) {
+	vs, err := s.vertices(tl, vertexClassMember, 0, sq.Eq{"member.email": email}, nil)
+	if err != nil {
+		return nil, err
+	}
+	members := vs.([]*models.Member)
+	if len(members) == 0 {
+		return nil, nil
+	}
+	return members[0], nil
+}
+
+func (s *readDBService) MembersByIDs(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) ([]*models.Member, error) {
+	var condition interface{}
+	if len(membersIDs) > 0 {
+		condition = sq.Eq{"member.id": membersIDs}
+	}
+	vs, err := s.vertices(tl, vertexClassMember, 0, condition, []string{"member.fullname"})
+	if err != nil {
+		return nil, err
+	}
+	members := vs.([]*models.Member)
+
+	return members, nil
+}
+
+func (s *readDBService) members(tl util.TimeLineNumber, searchString string, first int, after *string) ([]*models.Member, error) {
+	var condition sq.Sqlizer
+	if after != nil {
+		condition = sq.Gt{"member.fullname": after}
+	}
+	if searchString != "" {
+		likeCondition := sq.Or{GenericSqlizer(fmt.Sprintf(`lower(member.fullname) LIKE lower('%%%s%%')`, searchString)), GenericSqlizer(fmt.Sprintf(`lower(member.UserName) LIKE lower('%%%s%%')`, searchString))}
+		if condition == nil {
+			condition = likeCondition
+		} else {
+			condition = sq.And{condition, likeCondition}
+		}
+	}
+
+	vs, err := s.vertices(tl, vertexClassMember, uint64(first), condition, []string{"member.fullname"})
+	if err != nil {
+		return nil, err
+	}
+	members := vs.([]*models.Member)
+
+	return members, nil
+}
+
+func (s *readDBService) Members(ctx context.Context, tl util.TimeLineNumber, searchString string, first int, after *string) ([]*models.Member, bool, error) {
+	if first == 0 {
+		first = MaxFetchSize
+	}
+
+	// ask for first + 1 members to know if there're more members
+	members, err := s.members(tl, searchString, first+1, after)
+	if err != nil {
+		return nil, false, err
+	}
+
+	size := len(members)
+	if len(members) > first {
+		size = first
+	}
+	return members[:size], len(members) > first, nil
+}
+
+func (s *readDBService) DirectMemberCircles(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) (map[util.ID][]*models.Role, error) {
+	vs, err := s.connectedVertices(tl, membersIDs, edgeClassCircleDirectMember, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return vs.(map[util.ID][]*models.Role), nil
+}
+
+func (s *readDBService) MemberCircleEdges(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) (map[util.ID][]*models.MemberCircleEdge, error) {
+	memberCircleEdges := map[util.ID][]*models.MemberCircleEdge{}
+
+	memberCircleEdgesMap := map[util.ID]map[util.ID]*models.MemberCircleEdge{}
+	for _, memberID := range membersIDs {
+		memberCircleEdgesMap[memberID] = map[util.ID]*models.MemberCircleEdge{}
+	}
+
+	// Add directly defined circles
+	rolesGroups, err := s.DirectMemberCircles(ctx, tl, membersIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, memberID := range membersIDs {
+		for _, role := range rolesGroups[memberID] {
+			if memberCircleEdge, ok := memberCircleEdgesMap[memberID][role.ID]; ok {
+				memberCircleEdge.IsDirectMember = true
+			} else {
+				memberCircleEdgesMap[memberID][role.ID] = &models.MemberCircleEdge{Role: role, IsDirectMember: true}
+			}
+		}
+	}
+
+	// get filled roles
+	memberRoleEdgesGroups, err := s.MemberRoleEdges(ctx, tl, membersIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	roleIDsMap := map[util.ID]struct{}{}
+	for _, memberID := range membersIDs {
+		for _, memberRoleEdge := range memberRoleEdgesGroups[memberID] {
+			roleIDsMap[memberRoleEdge.Role.ID] = struct{}{}
+		}
+	}
+	roleIDs := []util.ID{}
+	for k := range roleIDsMap {
+		roleIDs = append(roleIDs, k)
+	}
+
+	parentMap := map[util.ID]*models.Role{}
+	// We need roles parents
+	parentGroups, err := s.RoleParent(ctx, tl, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+	parentIDs := []util.ID{}
+	for roleID, parent := range parentGroups {
+		parentIDs = append(parentIDs, parent.ID)
+		parentMap[roleID] = parent
+	}
+
+	// We also need parent of parent to get the replink fillers
+	subParentGroups, err := s.RoleParent(ctx, tl, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	for parentID, parentParent := range subParentGroups {
+		parentIDs = append(parentIDs, parentParent.ID)
+		parentMap[parentID] = parentParent
+	}
+
+	// Add role fillers (except the ones set as nocoremember)
+	for _, memberID := range membersIDs {
+		for _, memberRoleEdge := range memberRoleEdgesGroups[memberID] {
+			if memberRoleEdge.NoCoreMember {
+				continue
+			}
+			role := memberRoleEdge.Role
+			parent := parentMap[role.ID]
+			if memberCircleEdge, ok := memberCircleEdgesMap[memberID][parent.ID]; ok {
+				memberCircleEdge.FilledRoles = append(memberCircleEdge.FilledRoles, role)
+			} else {
+				memberCircleEdgesMap[memberID][parent.ID] = &models.MemberCircleEdge{Role: parent, FilledRoles: []*models.Role{role}}
+			}
+			if role.RoleType == models.RoleTypeLeadLink {
+				memberCircleEdgesMap[memberID][parent.ID].IsLeadLink = true
+			}
+		}
+	}
+
+	// Add sub circles replink fillers
+	for _, memberID := range membersIDs {
+		for _, memberRoleEdge := range memberRoleEdgesGroups[memberID] {
+			if memberRoleEdge.Role.RoleType != models.RoleTypeRepLink {
+				continue
+			}
+			role := memberRoleEdge.Role
+			parent := parentMap[role.ID]
+			parentParent := parentMap[parent.ID]
+			if memberCircleEdge, ok := memberCircleEdgesMap[memberID][parentParent.ID]; ok {
+				memberCircleEdge.RepLink = append(memberCircleEdge.RepLink, parent)
+			} else {
+				memberCircleEdgesMap[memberID][parentParent.ID] = &models.MemberCircleEdge{Role: parentParent, RepLink: []*models.Role{parent}}
+			}
+		}
+	}
+
+	for _, memberID := range membersIDs {
+		for _, memberCircleEdge := range memberCircleEdgesMap[memberID] {
+			memberCircleEdge.IsCoreMember = memberCircleEdge.IsDirectMember
+			if len(memberCircleEdge.FilledRoles) > 0 {
+				memberCircleEdge.IsCoreMember = true
+			}
+			if len(memberCircleEdge.RepLink) > 0 {
+				memberCircleEdge.IsCoreMember = true
+			}
+			memberCircleEdges[memberID] = append(memberCircleEdges[memberID], memberCircleEdge)
+		}
+
+		// sort memberCircleEdge by role to get repeatable ordered results
+		sort.Sort(models.MemberCircleEdges(memberCircleEdges[memberID]))
+	}
+
+	return memberCircleEdges, nil
+}
+
+func (s *readDBService) MemberRoleEdges(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) (map[util.ID][]*models.MemberRoleEdge, error) {
+	vs, err := s.connectedVertices(tl, membersIDs, edgeClassRoleMember, edgeDirectionOut, vertexClassRoleMemberEdge, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return vs.(map[util.ID][]*models.MemberRoleEdge), nil
+}
+
+func (s *readDBService) MemberAssignments(ctx context.Context, tl util.TimeLineNumber, memberID util.ID) (*models.MemberAssignments, error) {
+	assignments := &models.MemberAssignments{
+		Roles:           []*models.MemberRoleEdge{},
+		LeadLinkCircles: []*models.Role{},
+		CoreRoles:       []*models.MemberRoleEdge{},
+	}
+
+	memberRoleEdgesGroups, err := s.MemberRoleEdges(ctx, tl, []util.ID{memberID})
+	if err != nil {
+		return nil, err
+	}
+	leadLinkRolesIDs := []util.ID{}
+	for _, memberRoleEdge := range memberRoleEdgesGroups[memberID] {
+		switch {
+		case memberRoleEdge.Role.RoleType == models.RoleTypeLeadLink:
+			leadLinkRolesIDs = append(leadLinkRolesIDs, memberRoleEdge.Role.ID)
+		case memberRoleEdge.Role.RoleType.IsCoreRoleType():
+			assignments.CoreRoles = append(assignments.CoreRoles, memberRoleEdge)
+		default:
+			assignments.Roles = append(assignments.Roles, memberRoleEdge)
+		}
+	}
+
+	// report the circles instead of their lead link roles
+	parentGroups, err := s.RoleParent(ctx, tl, leadLinkRolesIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, leadLinkRoleID := range leadLinkRolesIDs {
+		if parent, ok := parentGroups[leadLinkRoleID]; ok {
+			assignments.LeadLinkCircles = append(assignments.LeadLinkCircles, parent)
+		}
+	}
+	sort.Sort(models.Roles(assignments.LeadLinkCircles))
+
+	rolesGroups, err := s.DirectMemberCircles(ctx, tl, []util.ID{memberID})
+	if err != nil {
+		return nil, err
+	}
+	assignments.DirectCircles = rolesGroups[memberID]
+	if assignments.DirectCircles == nil {
+		assignments.DirectCircles = []*models.Role{}
+	}
+
+	return assignments, nil
+}
+
+func (s *readDBService) Tension(ctx context.Context, tl util.TimeLineNumber, tensionID util.ID) (*models.Tension, error) {
+	vs, err := s.vertices(tl, vertexClassTension, 0, sq.Eq{"tension.id": tensionID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	tensions := vs.([]*models.Tension)
+	if len(tensions) == 0 {
+		return nil, nil
+	}
+	return tensions[0], nil
+}
+
+// Tensions returns the provided tensions or all the tensions when
+// tensionsIDs is empty
+func (s *readDBService) Tensions(ctx context.Context, tl util.TimeLineNumber, tensionsIDs []util.ID) ([]*models.Tension, error) {
+	var condition interface{}
+	if len(tensionsIDs) > 0 {
+		condition = sq.Eq{"tension.id": tensionsIDs}
+	}
+	vs, err := s.vertices(tl, vertexClassTension, 0, condition, nil)
+	if err != nil {
+		return nil, err
+	}
+	return vs.([]*models.Tension), nil
+}
+
+func (s *readDBService) MemberTensions(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) (map[util.ID][]*models.Tension, error) {
+	// Only the member itself can see its tensions
+	member, err := s.CallingMember(ctx, tl)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, memberID := range membersIDs {
+		if member.ID == memberID {
+			break
+		}
+		return nil, nil
+	}
+
+	vs, err := s.connectedVertices(tl, membersIDs, edgeClassMemberTension, edgeDirectionIn, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	tensionsGroups := vs.(map[util.ID][]*models.Tension)
+
+	return tensionsGroups, nil
+}
+
+func (s *readDBService) TensionMember(ctx context.Context, tl util.TimeLineNumber, tensionsIDs []util.ID) (map[util.ID]*models.Member, error) {
+	vs, err := s.connectedVertices(tl, tensionsIDs, edgeClassMemberTension, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	membersGroups := vs.(map[util.ID][]*models.Member)
+
+	mg := map[util.ID]*models.Member{}
+	for k, v := range membersGroups {
+		mg[k] = v[0]
+	}
+
+	return mg, nil
+}
+
+func (s *readDBService) RoleTensions(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Tension, error) {
+	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleTension, edgeDirectionIn, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return vs.(map[util.ID][]*models.Tension), nil
+}
+
+func (s *readDBService) TensionRole(ctx context.Context, tl util.TimeLineNumber, tensionsIDs []util.ID) (map[util.ID]*models.Role, error) {
+	vs, err := s.connectedVertices(tl, tensionsIDs, edgeClassRoleTension, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	rolesGroups := vs.(map[util.ID][]*models.Role)
+
+	mg := map[util.ID]*models.Role{}
+	for k, v := range rolesGroups {
+		mg[k] = v[0]
+	}
+
+	return mg, nil
+}
+
+func (s *readDBService) TensionAssignee(ctx context.Context, tl util.TimeLineNumber, tensionsIDs []util.ID) (map[util.ID]*models.Member, error) {
+	vs, err := s.connectedVertices(tl, tensionsIDs, edgeClassTensionAssignee, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	membersGroups := vs.(map[util.ID][]*models.Member)
+
+	mg := map[util.ID]*models.Member{}
+	for k, v := range membersGroups {
+		mg[k] = v[0]
+	}
+
+	return mg, nil
+}
+
+func (s *readDBService) Proposal(ctx context.Context, tl util.TimeLineNumber, proposalID util.ID) (*models.Proposal, error) {
+	vs, err := s.vertices(tl, vertexClassProposal, 0, sq.Eq{"proposal.id": proposalID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	proposals := vs.([]*models.Proposal)
+	if len(proposals) == 0 {
+		return nil, nil
+	}
+	return proposals[0], nil
+}
+
+func (s *readDBService) ProposalChanges(ctx context.Context, tl util.TimeLineNumber, proposalsIDs []util.ID) (map[util.ID]*change.ProposalChanges, error) {
+	vs, err := s.vertices(tl, vertexClassProposalChanges, 0, sq.Eq{"proposalchanges.id": proposalsIDs}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return vs.(map[util.ID]*change.ProposalChanges), nil
+}
+
+func (s *readDBService) ProposalMember(ctx context.Context, tl util.TimeLineNumber, proposalsIDs []util.ID) (map[util.ID]*models.Member, error) {
+	vs, err := s.connectedVertices(tl, proposalsIDs, edgeClassMemberProposal, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	membersGroups := vs.(map[util.ID][]*models.Member)
+
+	mg := map[util.ID]*models.Member{}
+	for k, v := range membersGroups {
+		mg[k] = v[0]
+	}
+
+	return mg, nil
+}
+
+func (s *readDBService) ProposalRole(ctx context.Context, tl util.TimeLineNumber, proposalsIDs []util.ID) (map[util.ID]*models.Role, error) {
+	vs, err := s.connectedVertices(tl, proposalsIDs, edgeClassRoleProposal, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	rolesGroups := vs.(map[util.ID][]*models.Role)
+
+	rg := map[util.ID]*models.Role{}
+	for k, v := range rolesGroups {
+		rg[k] = v[0]
+	}
+
+	return rg, nil
+}
+
+func (s *readDBService) RoleProposals(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Proposal, error) {
+	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleProposal, edgeDirectionIn, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return vs.(map[util.ID][]*models.Proposal), nil
+}
+
+func (s *readDBService) ProposalObjection(ctx context.Context, tl util.TimeLineNumber, objectionID util.ID) (*models.ProposalObjection, error) {
+	vs, err := s.vertices(tl, vertexClassProposalObjection, 0, sq.Eq{"proposalobjection.id": objectionID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	objections := vs.([]*models.ProposalObjection)
+	if len(objections) == 0 {
+		return nil, nil
+	}
+	return objections[0], nil
+}
+
+func (s *readDBService) ProposalObjections(ctx context.Context, tl util.TimeLineNumber, proposalsIDs []util.ID) (map[util.ID][]*models.ProposalObjection, error) {
+	vs, err := s.connectedVertices(tl, proposalsIDs, edgeClassProposalObjection, edgeDirectionIn, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return vs.(map[util.ID][]*models.ProposalObjection), nil
+}
+
+func (s *readDBService) ProposalObjectionMember(ctx context.Context, tl util.TimeLineNumber, objectionsIDs []util.ID) (map[util.ID]*models.Member, error) {
+	vs, err := s.connectedVertices(tl, objectionsIDs, edgeClassMemberObjection, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	membersGroups := vs.(map[util.ID][]*models.Member)
+
+	mg := map[util.ID]*models.Member{}
+	for k, v := range membersGroups {
+		mg[k] = v[0]
+	}
+
+	return mg, nil
+}
+
+func (s *readDBService) ProposalConsentMembers(ctx context.Context, tl util.TimeLineNumber, proposalsIDs []util.ID) (map[util.ID][]*models.Member, error) {
+	vs, err := s.connectedVertices(tl, proposalsIDs, edgeClassProposalConsent, edgeDirectionIn, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return vs.(map[util.ID][]*models.Member), nil
+}
+
+func (s *readDBService) Project(ctx context.Context, tl util.TimeLineNumber, projectID util.ID) (*models.Project, error) {
+	vs, err := s.vertices(tl, vertexClassProject, 0, sq.Eq{"project.id": projectID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	projects := vs.([]*models.Project)
+	if len(projects) == 0 {
+		return nil, nil
+	}
+	return projects[0], nil
+}
+
+func (s *readDBService) ProjectRole(ctx context.Context, tl util.TimeLineNumber, projectsIDs []util.ID) (map[util.ID]*models.Role, error) {
+	vs, err := s.connectedVertices(tl, projectsIDs, edgeClassRoleProject, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	groups := vs.(map[util.ID][]*models.Role)
+
+	mg := map[util.ID]*models.Role{}
+	for k, v := range groups {
+		mg[k] = v[0]
+	}
+
+	return mg, nil
+}
+
+func (s *readDBService) ProjectMember(ctx context.Context, tl util.TimeLineNumber, projectsIDs []util.ID) (map[util.ID]*models.Member, error) {
+	vs, err := s.connectedVertices(tl, projectsIDs, edgeClassMemberProject, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	groups := vs.(map[util.ID][]*models.Member)
+
+	mg := map[util.ID]*models.Member{}
+	for k, v := range groups {
+		mg[k] = v[0]
+	}
+
+	return mg, nil
+}
+
+func (s *readDBService) ProjectTension(ctx context.Context, tl util.TimeLineNumber, projectsIDs []util.ID) (map[util.ID]*models.Tension, error) {
+	vs, err := s.connectedVertices(tl, projectsIDs, edgeClassTensionProject, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	groups := vs.(map[util.ID][]*models.Tension)
+
+	mg := map[util.ID]*models.Tension{}
+	for k, v := range groups {
+		mg[k] = v[0]
+	}
+
+	return mg, nil
+}
+
+func (s *readDBService) RoleProjects(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Project, error) {
+	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleProject, edgeDirectionIn, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return vs.(map[util.ID][]*models.Project), nil
+}
+
+func (s *readDBService) MemberProjects(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) (map[util.ID][]*models.Project, error) {
+	vs, err := s.connectedVertices(tl, membersIDs, edgeClassMemberProject, edgeDirectionIn, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return vs.(map[util.ID][]*models.Project), nil
+}
+
+func (s *readDBService) Action(ctx context.Context, tl util.TimeLineNumber, actionID util.ID) (*models.Action, error) {
+	vs, err := s.vertices(tl, vertexClassAction, 0, sq.Eq{"action.id": actionID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	actions := vs.([]*models.Action)
+	if len(actions) == 0 {
+		return nil, nil
+	}
+	return actions[0], nil
+}
+
+func (s *readDBService) ActionRole(ctx context.Context, tl util.TimeLineNumber, actionsIDs []util.ID) (map[util.ID]*models.Role, error) {
+	vs, err := s.connectedVertices(tl, actionsIDs, edgeClassRoleAction, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	groups := vs.(map[util.ID][]*models.Role)
+
+	mg := map[util.ID]*models.Role{}
+	for k, v := range groups {
+		mg[k] = v[0]
+	}
+
+	return mg, nil
+}
+
+func (s *readDBService) ActionMember(ctx context.Context, tl util.TimeLineNumber, actionsIDs []util.ID) (map[util.ID]*models.Member, error) {
+	vs, err := s.connectedVertices(tl, actionsIDs, edgeClassMemberAction, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	groups := vs.(map[util.ID][]*models.Member)
+
+	mg := map[util.ID]*models.Member{}
+	for k, v := range groups {
+		mg[k] = v[0]
+	}
+
+	return mg, nil
+}
+
+func (s *readDBService) ActionTension(ctx context.Context, tl util.TimeLineNumber, actionsIDs []util.ID) (map[util.ID]*models.Tension, error) {
+	vs, err := s.connectedVertices(tl, actionsIDs, edgeClassTensionAction, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	groups := vs.(map[util.ID][]*models.Tension)
+
+	mg := map[util.ID]*models.Tension{}
+	for k, v := range groups {
+		mg[k] = v[0]
+	}
+
+	return mg, nil
+}
+
+func (s *readDBService) RoleActions(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Action, error) {
+	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleAction, edgeDirectionIn, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return vs.(map[util.ID][]*models.Action), nil
+}
+
+func (s *readDBService) MemberActions(ctx context.Context, tl util.TimeLineNumber, membersIDs []util.ID) (map[util.ID][]*models.Action, error) {
+	vs, err := s.connectedVertices(tl, membersIDs, edgeClassMemberAction, edgeDirectionIn, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return vs.(map[util.ID][]*models.Action), nil
+}
+
+func (s *readDBService) Meeting(ctx context.Context, tl util.TimeLineNumber, meetingID util.ID) (*models.Meeting, error) {
+	vs, err := s.vertices(tl, vertexClassMeeting, 0, sq.Eq{"meeting.id": meetingID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	meetings := vs.([]*models.Meeting)
+	if len(meetings) == 0 {
+		return nil, nil
+	}
+	return meetings[0], nil
+}
+
+// RoleMeetings returns the circle meetings from the most recent. afterDate and
+// afterID are the date and id of the last meeting of the previous page.
+func (s *readDBService) RoleMeetings(ctx context.Context, tl util.TimeLineNumber, roleID util.ID, first int, afterDate *time.Time, afterID *util.ID) ([]*models.Meeting, bool, error) {
+	if first == 0 {
+		first = MaxFetchSize
+	}
+
+	// the subquery uses the ? placeholder since it'll be converted by the
+	// main query builder
+	rq, rargs, err := sq.Select("rolemeeting.x").From("rolemeeting").Where(sq.Eq{"rolemeeting.y": roleID}).Where(s.timeLineCond("rolemeeting", tl)).ToSql()
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to build query")
+	}
+	condition := sq.And{sq.Expr("meeting.id IN ("+rq+")", rargs...)}
+	if afterDate != nil && afterID != nil {
+		condition = append(condition, sq.Or{sq.Lt{"meeting.date": *afterDate}, sq.And{sq.Eq{"meeting.date": *afterDate}, sq.Gt{"meeting.id": *afterID}}})
+	}
+
+	// ask for first + 1 meetings to know if there're more meetings
+	vs, err := s.vertices(tl, vertexClassMeeting, uint64(first+1), condition, []string{"meeting.date desc", "meeting.id"})
+	if err != nil {
+		return nil, false, err
+	}
+	meetings := vs.([]*models.Meeting)
+
+	size := len(meetings)
+	if len(meetings) > first {
+		size = first
+	}
+	return meetings[:size], len(meetings) > first, nil
+}
+
+func (s *readDBService) MeetingRole(ctx context.Context, tl util.TimeLineNumber, meetingsIDs []util.ID) (map[util.ID]*models.Role, error) {
+	vs, err := s.connectedVertices(tl, meetingsIDs, edgeClassRoleMeeting, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	groups := vs.(map[util.ID][]*models.Role)
+
+	mg := map[util.ID]*models.Role{}
+	for k, v := range groups {
+		mg[k] = v[0]
+	}
+
+	return mg, nil
+}
+
+func (s *readDBService) MeetingFacilitator(ctx context.Context, tl util.TimeLineNumber, meetingsIDs []util.ID) (map[util.ID]*models.Member, error) {
+	vs, err := s.connectedVertices(tl, meetingsIDs, edgeClassMeetingFacilitator, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	groups := vs.(map[util.ID][]*models.Member)
+
+	mg := map[util.ID]*models.Member{}
+	for k, v := range groups {
+		mg[k] = v[0]
+	}
+
+	return mg, nil
+}
+
+func (s *readDBService) MeetingSecretary(ctx context.Context, tl util.TimeLineNumber, meetingsIDs []util.ID) (map[util.ID]*models.Member, error) {
+	vs, err := s.connectedVertices(tl, meetingsIDs, edgeClassMeetingSecretary, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	groups := vs.(map[util.ID][]*models.Member)
+
+	mg := map[util.ID]*models.Member{}
+	for k, v := range groups {
+		mg[k] = v[0]
+	}
+
+	return mg, nil
+}
+
+func (s *readDBService) MeetingAttendees(ctx context.Context, tl util.TimeLineNumber, meetingsIDs []util.ID) (map[util.ID][]*models.Member, error) {
+	vs, err := s.connectedVertices(tl, meetingsIDs, edgeClassMeetingAttendee, edgeDirectionIn, "", nil, []string{"member.fullname"})
+	if err != nil {
+		return nil, err
+	}
+	return vs.(map[util.ID][]*models.Member), nil
+}
+
+// MeetingTensions returns the tensions processed in the meetings
+func (s *readDBService) MeetingTensions(ctx context.Context, tl util.TimeLineNumber, meetingsIDs []util.ID) (map[util.ID][]*models.Tension, error) {
+	vs, err := s.vertices(tl, vertexClassTension, 0, sq.Eq{"tension.meetingid": meetingsIDs}, nil)
+	if err != nil {
+		return nil, err
+	}
+	tensions := vs.([]*models.Tension)
+
+	tg := map[util.ID][]*models.Tension{}
+	for _, tension := range tensions {
+		tg[*tension.MeetingID] = append(tg[*tension.MeetingID], tension)
+	}
+
+	return tg, nil
+}
+
+func (s *readDBService) Election(ctx context.Context, tl util.TimeLineNumber, electionID util.ID) (*models.Election, error) {
+	vs, err := s.vertices(tl, vertexClassElection, 0, sq.Eq{"election.id": electionID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	elections := vs.([]*models.Election)
+	if len(elections) == 0 {
+		return nil, nil
+	}
+	return elections[0], nil
+}
+
+// RoleElections returns the circle elections, the open ones first
+func (s *readDBService) RoleElections(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Election, error) {
+	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleElection, edgeDirectionIn, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	electionsGroups := vs.(map[util.ID][]*models.Election)
+	for _, elections := range electionsGroups {
+		sort.Slice(elections, func(i, j int) bool {
+			if elections[i].Status != elections[j].Status {
+				return elections[i].Status == models.ElectionStatusOpen
+			}
+			return elections[i].ID.String() < elections[j].ID.String()
+		})
+	}
+	return electionsGroups, nil
+}
+
+func (s *readDBService) ElectionRole(ctx context.Context, tl util.TimeLineNumber, electionsIDs []util.ID) (map[util.ID]*models.Role, error) {
+	vs, err := s.connectedVertices(tl, electionsIDs, edgeClassRoleElection, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	rolesGroups := vs.(map[util.ID][]*models.Role)
+	res := make(map[util.ID]*models.Role)
+	for id, roles := range rolesGroups {
+		if len(roles) > 0 {
+			res[id] = roles[0]
+		}
+	}
+	return res, nil
+}
+
+// ElectionNominations returns the election candidates ordered by full name
+func (s *readDBService) ElectionNominations(ctx context.Context, tl util.TimeLineNumber, electionsIDs []util.ID) (map[util.ID][]*models.ElectionNomination, error) {
+	vs, err := s.connectedVertices(tl, electionsIDs, edgeClassElectionCandidate, edgeDirectionIn, vertexClassElectionNomination, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	nominationsGroups := vs.(map[util.ID][]*models.ElectionNomination)
+	for _, nominations := range nominationsGroups {
+		sort.SliceStable(nominations, func(i, j int) bool { return nominations[i].Candidate.FullName < nominations[j].Candidate.FullName })
+	}
+	return nominationsGroups, nil
+}
+
+func (s *readDBService) ElectionElected(ctx context.Context, tl util.TimeLineNumber, electionsIDs []util.ID) (map[util.ID]*models.Member, error) {
+	vs, err := s.connectedVertices(tl, electionsIDs, edgeClassElectionElected, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	membersGroups := vs.(map[util.ID][]*models.Member)
+	res := make(map[util.ID]*models.Member)
+	for id, members := range membersGroups {
+		if len(members) > 0 {
+			res[id] = members[0]
+		}
+	}
+	return res, nil
+}
+
+// CoreRoleTerms returns the core roles assignments with an election
+// expiration, ordered by expiration. When provided, after and before limit
+// the returned assignments to the ones expiring in the provided interval.
+func (s *readDBService) CoreRoleTerms(ctx context.Context, tl util.TimeLineNumber, after, before *time.Time) ([]*models.CoreRoleTerm, error) {
+	// the subquery uses the ? placeholder since it'll be converted by the
+	// main query builder
+	rq, rargs, err := sq.Select("rolemember.y").From("rolemember").Where(sq.NotEq{"rolemember.electionexpiration": nil}).Where(s.timeLineCond("rolemember", tl)).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+	vs, err := s.vertices(tl, vertexClassRole, 0, sq.Expr("role.id IN ("+rq+")", rargs...), nil)
+	if err != nil {
+		return nil, err
+	}
+	roles := vs.([]*models.Role)
+
+	rolesIDs := make([]util.ID, len(roles))
+	for i, role := range roles {
+		rolesIDs[i] = role.ID
+	}
+	roleMemberEdgesGroups, err := s.RoleMemberEdges(ctx, tl, rolesIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := []*models.CoreRoleTerm{}
+	for _, role := range roles {
+		for _, roleMemberEdge := range roleMemberEdgesGroups[role.ID] {
+			if roleMemberEdge.ElectionExpiration == nil {
+				continue
+			}
+			electionExpiration := *roleMemberEdge.ElectionExpiration
+			if after != nil && !electionExpiration.After(*after) {
+				continue
+			}
+			if before != nil && !electionExpiration.Before(*before) {
+				continue
+			}
+			terms = append(terms, &models.CoreRoleTerm{
+				CoreRole:           role,
+				Member:             roleMemberEdge.Member,
+				ElectionExpiration: electionExpiration,
+			})
+		}
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if !terms[i].ElectionExpiration.Equal(terms[j].ElectionExpiration) {
+			return terms[i].ElectionExpiration.Before(terms[j].ElectionExpiration)
+		}
+		return terms[i].CoreRole.ID.String() < terms[j].CoreRole.ID.String()
+	})
+
+	return terms, nil
+}
+
+// rolesDiffState is the state of a set of roles at a timeline
+type rolesDiffState struct {
+	roles            map[util.ID]*models.Role
+	parents          map[util.ID]*models.Role
+	domains          map[util.ID][]*models.Domain
+	accountabilities map[util.ID][]*models.Accountability
+	members          map[util.ID][]*models.Member
+}
+
+func (s *readDBService) rolesDiffState(ctx context.Context, tl util.TimeLineNumber, roles []*models.Role) (*rolesDiffState, error) {
+	st := &rolesDiffState{roles: map[util.ID]*models.Role{}, members: map[util.ID][]*models.Member{}}
+	rolesIDs := make([]util.ID, len(roles))
+	for i, role := range roles {
+		st.roles[role.ID] = role
+		rolesIDs[i] = role.ID
+	}
+
+	var err error
+	if st.parents, err = s.RoleParent(ctx, tl, rolesIDs); err != nil {
+		return nil, err
+	}
+	if st.domains, err = s.RoleDomains(ctx, tl, rolesIDs); err != nil {
+		return nil, err
+	}
+	if st.accountabilities, err = s.RoleAccountabilities(ctx, tl, rolesIDs); err != nil {
+		return nil, err
+	}
+	roleMemberEdgesGroups, err := s.RoleMemberEdges(ctx, tl, rolesIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	for roleID, roleMemberEdges := range roleMemberEdgesGroups {
+		for _, roleMemberEdge := range roleMemberEdges {
+			st.members[roleID] = append(st.members[roleID], roleMemberEdge.Member)
+		}
+	}
+	circleDirectMembersGroups, err := s.CircleDirectMembers(ctx, tl, rolesIDs)
+	if err != nil {
+		return nil, err
+	}
+	for roleID, members := range circleDirectMembersGroups {
+		st.members[roleID] = append(st.members[roleID], members...)
+	}
+
+	return st, nil
+}
+
+// subTreeRoles returns the role and all its sub roles. If roleID is nil all
+// the roles are returned
+func (s *readDBService) subTreeRoles(ctx context.Context, tl util.TimeLineNumber, roleID *util.ID) ([]*models.Role, error) {
+	if roleID == nil {
+		return s.Roles(ctx, tl, nil)
+	}
+	role, err := s.Role(ctx, tl, *roleID)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return []*models.Role{}, nil
+	}
+	roles := []*models.Role{role}
+	parentsIDs := []util.ID{role.ID}
+	for len(parentsIDs) > 0 {
+		childsGroups, err := s.ChildRoles(ctx, tl, parentsIDs, nil)
+		if err != nil {
+			return nil, err
+		}
+		parentsIDs = []util.ID{}
+		for _, childs := range childsGroups {
+			for _, child := range childs {
+				roles = append(roles, child)
+				parentsIDs = append(parentsIDs, child.ID)
+			}
+		}
+	}
+	return roles, nil
+}
+
+// RolesDiff returns the roles created, deleted or changed between the from and
+// to timelines, ordered by name. When roleID is provided only the role and its
+// sub roles (at one of the two timelines) are compared.
+func (s *readDBService) RolesDiff(ctx context.Context, fromTl, toTl util.TimeLineNumber, roleID *util.ID) ([]*models.RoleDiff, error) {
+	fromRoles, err := s.subTreeRoles(ctx, fromTl, roleID)
+	if err != nil {
+		return nil, err
+	}
+	toRoles, err := s.subTreeRoles(ctx, toTl, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// roles moved inside or outside the subtree exist at both timelines
+	fromIDs := map[util.ID]struct{}{}
+	for _, role := range fromRoles {
+		fromIDs[role.ID] = struct{}{}
+	}
+	toIDs := map[util.ID]struct{}{}
+	for _, role := range toRoles {
+		toIDs[role.ID] = struct{}{}
+	}
+	missingFromIDs := []util.ID{}
+	for _, role := range toRoles {
+		if _, ok := fromIDs[role.ID]; !ok {
+			missingFromIDs = append(missingFromIDs, role.ID)
+		}
+	}
+	missingToIDs := []util.ID{}
+	for _, role := range fromRoles {
+		if _, ok := toIDs[role.ID]; !ok {
+			missingToIDs = append(missingToIDs, role.ID)
+		}
+	}
+	if roleID != nil && len(missingFromIDs) > 0 {
+		roles, err := s.Roles(ctx, fromTl, missingFromIDs)
+		if err != nil {
+			return nil, err
+		}
+		fromRoles = append(fromRoles, roles...)
+	}
+	if roleID != nil && len(missingToIDs) > 0 {
+		roles, err := s.Roles(ctx, toTl, missingToIDs)
+		if err != nil {
+			return nil, err
+		}
+		toRoles = append(toRoles, roles...)
+	}
+
+	from, err := s.rolesDiffState(ctx, fromTl, fromRoles)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.rolesDiffState(ctx, toTl, toRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	rolesIDs := []util.ID{}
+	for id := range from.roles {
+		rolesIDs = append(rolesIDs, id)
+	}
+	for id := range to.roles {
+		if _, ok := from.roles[id]; !ok {
+			rolesIDs = append(rolesIDs, id)
+		}
+	}
+
+	diffs := []*models.RoleDiff{}
+	for _, id := range rolesIDs {
+		diff := &models.RoleDiff{
+			PreviousRole: from.roles[id],
+			Role:         to.roles[id],
+		}
+		changed := true
+		switch {
+		case diff.PreviousRole == nil:
+			diff.ChangeType = models.ChangeTypeNew
+		case diff.Role == nil:
+			diff.ChangeType = models.ChangeTypeDeleted
+		default:
+			diff.ChangeType = models.ChangeTypeUpdated
+			changed = diff.PreviousRole.Name != diff.Role.Name || diff.PreviousRole.Purpose != diff.Role.Purpose || diff.PreviousRole.RoleType != diff.Role.RoleType
+
+			previousParent := from.parents[id]
+			newParent := to.parents[id]
+			if previousParent != nil && newParent != nil && previousParent.ID != newParent.ID {
+				diff.PreviousParent = previousParent
+				diff.NewParent = newParent
+				changed = true
+			}
+		}
+
+		fromDomains := map[util.ID]*models.Domain{}
+		for _, d := range from.domains[id] {
+			fromDomains[d.ID] = d
+		}
+		toDomains := map[util.ID]*models.Domain{}
+		for _, d := range to.domains[id] {
+			toDomains[d.ID] = d
+			fd, ok := fromDomains[d.ID]
+			if !ok {
+				diff.CreatedDomains = append(diff.CreatedDomains, d)
+			} else if fd.Description != d.Description {
+				diff.UpdatedDomains = append(diff.UpdatedDomains, d)
+			}
+		}
+		for _, d := range from.domains[id] {
+			if _, ok := toDomains[d.ID]; !ok {
+				diff.DeletedDomains = append(diff.DeletedDomains, d)
+			}
+		}
+
+		fromAccountabilities := map[util.ID]*models.Accountability{}
+		for _, a := range from.accountabilities[id] {
+			fromAccountabilities[a.ID] = a
+		}
+		toAccountabilities := map[util.ID]*models.Accountability{}
+		for _, a := range to.accountabilities[id] {
+			toAccountabilities[a.ID] = a
+			fa, ok := fromAccountabilities[a.ID]
+			if !ok {
+				diff.CreatedAccountabilities = append(diff.CreatedAccountabilities, a)
+			} else if fa.Description != a.Description {
+				diff.UpdatedAccountabilities = append(diff.UpdatedAccountabilities, a)
+			}
+		}
+		for _, a := range from.accountabilities[id] {
+			if _, ok := toAccountabilities[a.ID]; !ok {
+				diff.DeletedAccountabilities = append(diff.DeletedAccountabilities, a)
+			}
+		}
+
+		fromMembers := map[util.ID]struct{}{}
+		for _, m := range from.members[id] {
+			fromMembers[m.ID] = struct{}{}
+		}
+		toMembers := map[util.ID]struct{}{}
+		for _, m := range to.members[id] {
+			toMembers[m.ID] = struct{}{}
+			if _, ok := fromMembers[m.ID]; !ok {
+				diff.AddedMembers = append(diff.AddedMembers, m)
+			}
+		}
+		for _, m := range from.members[id] {
+			if _, ok := toMembers[m.ID]; !ok {
+				diff.RemovedMembers = append(diff.RemovedMembers, m)
+			}
+		}
+
+		if len(diff.CreatedDomains)+len(diff.UpdatedDomains)+len(diff.DeletedDomains) > 0 ||
+			len(diff.CreatedAccountabilities)+len(diff.UpdatedAccountabilities)+len(diff.DeletedAccountabilities) > 0 ||
+			len(diff.AddedMembers)+len(diff.RemovedMembers) > 0 {
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+
+		// sort to get repeatable ordered results
+		for _, domains := range [][]*models.Domain{diff.CreatedDomains, diff.UpdatedDomains, diff.DeletedDomains} {
+			sort.Slice(domains, func(i, j int) bool { return domains[i].Description < domains[j].Description })
+		}
+		for _, accountabilities := range [][]*models.Accountability{diff.CreatedAccountabilities, diff.UpdatedAccountabilities, diff.DeletedAccountabilities} {
+			sort.Slice(accountabilities, func(i, j int) bool { return accountabilities[i].Description < accountabilities[j].Description })
+		}
+		for _, members := range [][]*models.Member{diff.AddedMembers, diff.RemovedMembers} {
+			sort.Slice(members, func(i, j int) bool { return members[i].UserName < members[j].UserName })
+		}
+
+		diffs = append(diffs, diff)
+	}
+
+	diffRole := func(diff *models.RoleDiff) *models.Role {
+		if diff.Role != nil {
+			return diff.Role
+		}
+		return diff.PreviousRole
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		ri := diffRole(diffs[i])
+		rj := diffRole(diffs[j])
+		if ri.Name != rj.Name {
+			return ri.Name < rj.Name
+		}
+		return ri.ID.String() < rj.ID.String()
+	})
+
+	return diffs, nil
+}
+
+func (s *readDBService) RoleTemplate(ctx context.Context, tl util.TimeLineNumber, roleTemplateID util.ID) (*models.RoleTemplate, error) {
+	vs, err := s.vertices(tl, vertexClassRoleTemplate, 0, sq.Eq{"roletemplate.id": roleTemplateID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	roleTemplates := vs.([]*models.RoleTemplate)
+	if len(roleTemplates) == 0 {
+		return nil, nil
+	}
+	return roleTemplates[0], nil
+}
+
+// RoleTemplates returns all the role templates ordered by name
+func (s *readDBService) RoleTemplates(ctx context.Context, tl util.TimeLineNumber) ([]*models.RoleTemplate, error) {
+	vs, err := s.vertices(tl, vertexClassRoleTemplate, 0, nil, []string{"roletemplate.name", "roletemplate.id"})
+	if err != nil {
+		return nil, err
+	}
+	return vs.([]*models.RoleTemplate), nil
+}
+
+// RoleTemplateInstances returns the roles linked to the templates
+func (s *readDBService) RoleTemplateInstances(ctx context.Context, tl util.TimeLineNumber, roleTemplatesIDs []util.ID) (map[util.ID][]*models.Role, error) {
+	vs, err := s.connectedVertices(tl, roleTemplatesIDs, edgeClassRoleTemplateInstance, edgeDirectionIn, "", nil, []string{"role.name"})
+	if err != nil {
+		return nil, err
+	}
+	return vs.(map[util.ID][]*models.Role), nil
+}
+
+// RoleRoleTemplate returns the template the roles are linked to
+func (s *readDBService) RoleRoleTemplate(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID]*models.RoleTemplate, error) {
+	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleTemplateInstance, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	roleTemplatesGroups := vs.(map[util.ID][]*models.RoleTemplate)
+	res := make(map[util.ID]*models.RoleTemplate)
+	for id, roleTemplates := range roleTemplatesGroups {
+		if len(roleTemplates) > 0 {
+			res[id] = roleTemplates[0]
+		}
+	}
+	return res, nil
+}
+
+func (s *readDBService) Webhook(ctx context.Context, tl util.TimeLineNumber, webhookID util.ID) (*models.Webhook, error) {
+	vs, err := s.vertices(tl, vertexClassWebhook, 0, sq.Eq{"webhook.id": webhookID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	webhooks := vs.([]*models.Webhook)
+	if len(webhooks) == 0 {
+		return nil, nil
+	}
+	return webhooks[0], nil
+}
+
+// Webhooks returns all the webhooks ordered by url
+func (s *readDBService) Webhooks(ctx context.Context, tl util.TimeLineNumber) ([]*models.Webhook, error) {
+	vs, err := s.vertices(tl, vertexClassWebhook, 0, nil, []string{"webhook.url", "webhook.id"})
+	if err != nil {
+		return nil, err
+	}
+	return vs.([]*models.Webhook), nil
+}
+
+func (s *readDBService) sessions(where sq.Sqlizer) ([]*models.Session, error) {
+	q, args, err := sessionSelect.Where(where).OrderBy("creationtime", "id").ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	var sessions []*models.Session
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		rows, err := tx.Query(q, args...)
+		if err != nil {
+			return errors.WithMessage(err, "failed to execute query")
+		}
+		sessions, err = scanSessions(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (s *readDBService) refreshTokens(where sq.Sqlizer) ([]*models.RefreshToken, error) {
+	q, args, err := refreshTokenSelect.Where(where).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	var refreshTokens []*models.RefreshToken
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		rows, err := tx.Query(q, args...)
+		if err != nil {
+			return errors.WithMessage(err, "failed to execute query")
+		}
+		refreshTokens, err = scanRefreshTokens(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return refreshTokens, nil
+}
+
+// Session returns the session with the provided id. The sessions aren't
+// versioned by timeline so the current state is always returned
+func (s *readDBService) Session(ctx context.Context, sessionID util.ID) (*models.Session, error) {
+	sessions, err := s.sessions(sq.Eq{"id": sessionID})
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+	return sessions[0], nil
+}
+
+// MemberSessions returns the member sessions (also the expired ones) ordered
+// by creation time
+func (s *readDBService) MemberSessions(ctx context.Context, memberID util.ID) ([]*models.Session, error) {
+	return s.sessions(sq.Eq{"memberid": memberID})
+}
+
+// RefreshTokenByHash returns the refresh token with the provided token hash
+func (s *readDBService) RefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	refreshTokens, err := s.refreshTokens(sq.Eq{"tokenhash": tokenHash})
+	if err != nil {
+		return nil, err
+	}
+	if len(refreshTokens) == 0 {
+		return nil, nil
+	}
+	return refreshTokens[0], nil
+}
+
+func (s *readDBService) APIToken(ctx context.Context, tl util.TimeLineNumber, apiTokenID util.ID) (*models.APIToken, error) {
+	vs, err := s.vertices(tl, vertexClassAPIToken, 0, sq.Eq{"apitoken.id": apiTokenID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	apiTokens := vs.([]*models.APIToken)
+	if len(apiTokens) == 0 {
+		return nil, nil
+	}
+	return apiTokens[0], nil
+}
+
+// APITokenByHash returns the api token with the provided token hash
+func (s *readDBService) APITokenByHash(ctx context.Context, tl util.TimeLineNumber, tokenHash string) (*models.APIToken, error) {
+	vs, err := s.vertices(tl, vertexClassAPIToken, 0, sq.Eq{"apitoken.tokenhash": tokenHash}, nil)
+	if err != nil {
+		return nil, err
+	}
+	apiTokens := vs.([]*models.APIToken)
+	if len(apiTokens) == 0 {
+		return nil, nil
+	}
+	return apiTokens[0], nil
+}
+
+// MemberAPITokens returns the member api tokens (also the expired ones)
+// ordered by name
+func (s *readDBService) MemberAPITokens(ctx context.Context, tl util.TimeLineNumber, memberID util.ID) ([]*models.APIToken, error) {
+	vs, err := s.vertices(tl, vertexClassAPIToken, 0, sq.Eq{"apitoken.memberid": memberID}, []string{"apitoken.name", "apitoken.id"})
+	if err != nil {
+		return nil, err
+	}
+	return vs.([]*models.APIToken), nil
+}
+
+// WebhookDeadLetters returns the webhooks dead letters recorded up to the
+// provided timeline, the most recent first
+func (s *readDBService) WebhookDeadLetters(ctx context.Context, tl util.TimeLineNumber, webhooksIDs []util.ID) (map[util.ID][]*models.WebhookDeadLetter, error) {
+	sb := webhookDeadLetterSelect.Where(sq.Eq{"webhookid": webhooksIDs}).Where(sq.LtOrEq{"timeline": tl}).OrderBy("timeline desc", "sequencenumber desc")
+
+	q, args, err := sb.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	var deadLettersGroups map[util.ID][]*models.WebhookDeadLetter
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		rows, err := tx.Query(q, args...)
+		if err != nil {
+			return errors.WithMessage(err, "failed to execute query")
+		}
+		deadLettersGroups, err = scanWebhookDeadLetters(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deadLettersGroups, nil
+}
+
+func (s *readDBService) RoleParent(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID]*models.Role, error) {
+	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleRole, edgeDirectionIn, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	rolesGroups := vs.(map[util.ID][]*models.Role)
+
+	rg := map[util.ID]*models.Role{}
+	for k, v := range rolesGroups {
+		rg[k] = v[0]
+	}
+
+	return rg, nil
+}
+
+func (s *readDBService) RoleParents(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Role, error) {
+	roleParentsGroups := map[util.ID][]*models.Role{}
+	// TODO(sgotti) use sql WITH RECURSIVE where supported? (postgres)
+	roleParentGroups, err := s.RoleParent(ctx, tl, rolesIDs)
+	if err != nil {
+		return nil, err
+	}
+	parents := map[util.ID]*models.Role{}
+	for id, roleParent := range roleParentGroups {
+		parents[id] = roleParent
+	}
+	for {
+		// collect role with unknown parent
+		neededMap := map[util.ID]struct{}{}
+		for _, parent := range parents {
+			if _, ok := parents[parent.ID]; !ok {
+				neededMap[parent.ID] = struct{}{}
+			}
+		}
+		needed := []util.ID{}
+		for id := range neededMap {
+			needed = append(needed, id)
+		}
+		roleParentGroups, err = s.RoleParent(ctx, tl, needed)
+		if err != nil {
+			return nil, err
+		}
+		if len(roleParentGroups) == 0 {
+			break
+		}
+		for id, roleParent := range roleParentGroups {
+			parents[id] = roleParent
+		}
+	}
+
+	for _, id := range rolesIDs {
+		roleParentsGroups[id] = []*models.Role{}
+		curID := id
+		for {
+			if parent, ok := parents[curID]; ok {
+				roleParentsGroups[id] = append(roleParentsGroups[id], parent)
+				curID = parent.ID
+			} else {
+				break
+			}
+		}
+	}
+
+	return roleParentsGroups, nil
+}
+
+func (s *readDBService) CircleCoreRole(ctx context.Context, tl util.TimeLineNumber, roleType models.RoleType, rolesIDs []util.ID) (map[util.ID]*models.Role, error) {
+	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleRole, edgeDirectionOut, "", sq.Eq{"role.roletype": roleType}, nil)
+	if err != nil {
+		return nil, err
+	}
+	rolesGroups := vs.(map[util.ID][]*models.Role)
+
+	rg := map[util.ID]*models.Role{}
+	for k, v := range rolesGroups {
+		rg[k] = v[0]
+	}
+
+	return rg, nil
+}
+
+func (s *readDBService) CircleDirectMembers(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Member, error) {
+	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassCircleDirectMember, edgeDirectionIn, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return vs.(map[util.ID][]*models.Member), nil
+}
+
+func (s *readDBService) CircleMemberEdges(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.CircleMemberEdge, error) {
+	circleMemberEdges := map[util.ID][]*models.CircleMemberEdge{}
+
+	circleMemberEdgesMap := map[util.ID]map[util.ID]*models.CircleMemberEdge{}
+	for _, roleID := range rolesIDs {
+		circleMemberEdgesMap[roleID] = map[util.ID]*models.CircleMemberEdge{}
+	}
+
+	// Add directly defined circle members
+	membersGroups, err := s.CircleDirectMembers(ctx, tl, rolesIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, roleID := range rolesIDs {
+		for _, member := range membersGroups[roleID] {
+			if circleMemberEdge, ok := circleMemberEdgesMap[roleID][member.ID]; ok {
+				circleMemberEdge.IsDirectMember = true
+			} else {
+				circleMemberEdgesMap[roleID][member.ID] = &models.CircleMemberEdge{Member: member, IsDirectMember: true}
+			}
+		}
+	}
+
+	childsGroups, err := s.ChildRoles(ctx, tl, rolesIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	childsIDsMap := map[util.ID]struct{}{}
+	for _, roleID := range rolesIDs {
+		childs := childsGroups[roleID]
+		for _, child := range childs {
+			childsIDsMap[child.ID] = struct{}{}
+		}
+	}
+	childsIDs := []util.ID{}
+	for k := range childsIDsMap {
+		childsIDs = append(childsIDs, k)
+	}
+
+	// We also need child of child to get the replink fillers
+	subChildsGroups, err := s.ChildRoles(ctx, tl, childsIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, childID := range childsIDs {
+		subChilds := subChildsGroups[childID]
+		for _, subChild := range subChilds {
+			childsIDsMap[subChild.ID] = struct{}{}
+		}
+	}
+
+	// Merge childs and subchilds in the same list to do just one call to s.getRoleMemberEdges
+	childsIDs = []util.ID{}
+	for k := range childsIDsMap {
+		childsIDs = append(childsIDs, k)
+	}
+
+	roleMemberEdgesGroups, err := s.RoleMemberEdges(ctx, tl, childsIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add role fillers (except the ones set as nocoremember)
+	for _, roleID := range rolesIDs {
+		for _, child := range childsGroups[roleID] {
+			for _, roleMemberEdge := range roleMemberEdgesGroups[child.ID] {
+				if roleMemberEdge.NoCoreMember {
+					continue
+				}
+				if circleMemberEdge, ok := circleMemberEdgesMap[roleID][roleMemberEdge.Member.ID]; ok {
+					circleMemberEdge.FilledRoles = append(circleMemberEdge.FilledRoles, child)
+				} else {
+					circleMemberEdgesMap[roleID][roleMemberEdge.Member.ID] = &models.CircleMemberEdge{Member: roleMemberEdge.Member, FilledRoles: []*models.Role{child}}
+				}
+				if child.RoleType == models.RoleTypeLeadLink {
+					circleMemberEdgesMap[roleID][roleMemberEdge.Member.ID].IsLeadLink = true
+				}
+			}
+		}
+	}
+
+	// Add sub circles replink fillers
+	for _, roleID := range rolesIDs {
+		for _, child := range childsGroups[roleID] {
+			for _, subChild := range subChildsGroups[child.ID] {
+				if subChild.RoleType != models.RoleTypeRepLink {
+					continue
+				}
+				for _, roleMemberEdge := range roleMemberEdgesGroups[subChild.ID] {
+					// NOTE(sgotti): there must be only one member filling the replink
+					if circleMemberEdge, ok := circleMemberEdgesMap[roleID][roleMemberEdge.Member.ID]; ok {
+						circleMemberEdge.RepLink = append(circleMemberEdge.RepLink, child)
+					} else {
+						circleMemberEdgesMap[roleID][roleMemberEdge.Member.ID] = &models.CircleMemberEdge{Member: roleMemberEdge.Member, RepLink: []*models.Role{child}}
+					}
+				}
+			}
+		}
+	}
+
+	for _, roleID := range rolesIDs {
+		for _, circleMemberEdge := range circleMemberEdgesMap[roleID] {
+			circleMemberEdge.IsCoreMember = circleMemberEdge.IsDirectMember
+			if len(circleMemberEdge.FilledRoles) > 0 {
+				circleMemberEdge.IsCoreMember = true
+			}
+			if len(circleMemberEdge.RepLink) > 0 {
+				circleMemberEdge.IsCoreMember = true
+			}
+			circleMemberEdges[roleID] = append(circleMemberEdges[roleID], circleMemberEdge)
+		}
+
+		// sort circleMemberEdges by member to get repeatable ordered results
+		sort.Sort(models.CircleMemberEdges(circleMemberEdges[roleID]))
+	}
+
+	return circleMemberEdges, nil
+}
+
+func (s *readDBService) RoleMemberEdges(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID, orderBys []string) (map[util.ID][]*models.RoleMemberEdge, error) {
+	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleMember, edgeDirectionIn, vertexClassRoleMemberEdge, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return vs.(map[util.ID][]*models.RoleMemberEdge), nil
+}
+
+func (s *readDBService) RoleDomains(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Domain, error) {
+	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleDomain, edgeDirectionIn, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return vs.(map[util.ID][]*models.Domain), nil
+}
+
+func (s *readDBService) RoleAccountabilities(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Accountability, error) {
+	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleAccountability, edgeDirectionIn, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return vs.(map[util.ID][]*models.Accountability), nil
+}
+
+func (s *readDBService) RoleChecklistItems(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.ChecklistItem, error) {
+	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleChecklistItem, edgeDirectionIn, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return vs.(map[util.ID][]*models.ChecklistItem), nil
+}
+
+func (s *readDBService) RoleMetrics(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID][]*models.Metric, error) {
+	vs, err := s.connectedVertices(tl, rolesIDs, edgeClassRoleMetric, edgeDirectionIn, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return vs.(map[util.ID][]*models.Metric), nil
+}
+
+func (s *readDBService) ChecklistItemRole(ctx context.Context, tl util.TimeLineNumber, checklistItemsIDs []util.ID) (map[util.ID]*models.Role, error) {
+	vs, err := s.connectedVertices(tl, checklistItemsIDs, edgeClassRoleChecklistItem, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	rolesGroups := vs.(map[util.ID][]*models.Role)
+
+	rg := map[util.ID]*models.Role{}
+	for k, v := range rolesGroups {
+		rg[k] = v[0]
+	}
+
+	return rg, nil
+}
+
+func (s *readDBService) MetricRole(ctx context.Context, tl util.TimeLineNumber, metricsIDs []util.ID) (map[util.ID]*models.Role, error) {
+	vs, err := s.connectedVertices(tl, metricsIDs, edgeClassRoleMetric, edgeDirectionOut, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	rolesGroups := vs.(map[util.ID][]*models.Role)
+
+	rg := map[util.ID]*models.Role{}
+	for k, v := range rolesGroups {
+		rg[k] = v[0]
+	}
+
+	return rg, nil
+}
+
+// ChecklistItemReports returns the reports of the checklist items ordered by
+// meeting date, from the most recent
+func (s *readDBService) ChecklistItemReports(ctx context.Context, tl util.TimeLineNumber, checklistItemsIDs []util.ID) (map[util.ID][]*models.ChecklistItemReport, error) {
+	vs, err := s.connectedVertices(tl, checklistItemsIDs, edgeClassMeetingChecklistItem, edgeDirectionOut, vertexClassChecklistItemReport, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	reportsGroups := vs.(map[util.ID][]*models.ChecklistItemReport)
+
+	for _, reports := range reportsGroups {
+		sort.SliceStable(reports, func(i, j int) bool { return reports[i].Meeting.Date.After(reports[j].Meeting.Date) })
+	}
+
+	return reportsGroups, nil
+}
+
+// MetricReports returns the reports of the metrics ordered by meeting date,
+// from the most recent
+func (s *readDBService) MetricReports(ctx context.Context, tl util.TimeLineNumber, metricsIDs []util.ID) (map[util.ID][]*models.MetricReport, error) {
+	vs, err := s.connectedVertices(tl, metricsIDs, edgeClassMeetingMetric, edgeDirectionOut, vertexClassMetricReport, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	reportsGroups := vs.(map[util.ID][]*models.MetricReport)
+
+	for _, reports := range reportsGroups {
+		sort.SliceStable(reports, func(i, j int) bool { return reports[i].Meeting.Date.After(reports[j].Meeting.Date) })
+	}
+
+	return reportsGroups, nil
+}
+
+func (s *readDBService) MeetingChecklistItemReports(ctx context.Context, tl util.TimeLineNumber, meetingsIDs []util.ID) (map[util.ID][]*models.ChecklistItemReport, error) {
+	vs, err := s.connectedVertices(tl, meetingsIDs, edgeClassMeetingChecklistItem, edgeDirectionIn, vertexClassChecklistItemReport, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return vs.(map[util.ID][]*models.ChecklistItemReport), nil
+}
+
+func (s *readDBService) MeetingMetricReports(ctx context.Context, tl util.TimeLineNumber, meetingsIDs []util.ID) (map[util.ID][]*models.MetricReport, error) {
+	vs, err := s.connectedVertices(tl, meetingsIDs, edgeClassMeetingMetric, edgeDirectionIn, vertexClassMetricReport, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return vs.(map[util.ID][]*models.MetricReport), nil
+}
+
+func (s *readDBService) RolesAdditionalContent(ctx context.Context, tl util.TimeLineNumber, rolesIDs []util.ID) (map[util.ID]*models.RoleAdditionalContent, error) {
+	condition := sq.Eq{"roleadditionalcontent.id": rolesIDs}
+	vs, err := s.vertices(tl, vertexClassRoleAdditionalContent, 0, condition, nil)
+	if err != nil {
+		return nil, err
+	}
+	rolesAdditionalContent := vs.([]*models.RoleAdditionalContent)
+
+	rolesAdditionalContentMap := map[util.ID]*models.RoleAdditionalContent{}
+	for _, r := range rolesAdditionalContent {
+		rolesAdditionalContentMap[r.ID] = r
+	}
+	return rolesAdditionalContentMap, nil
+}
+
+func (s *readDBService) MemberPassword(ctx context.Context, memberID util.ID) (string, error) {
+	sb := sb.Select("password").From("password").Where(sq.Eq{"memberid": memberID})
+	q, args, err := sb.ToSql()
+	if err != nil {
+		return "", err
+	}
+
+	var password string
+	err = s.tx.Do(func(tx *db.WrappedTx) error {
+		return tx.QueryRow(q, args...).Scan(&password)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return password, nil
+}
+
+func (s *readDBService) AuthenticateUIDPassword(ctx context.Context, memberID util.ID, password string) (*models.Member, error) {
+	tl := s.CurTimeLine(ctx)
+
+	member, err := s.Member(ctx, tl.Number(), memberID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, errors.Errorf("no member with id: %s", memberID)
+	}
+
+	curPasswordHash, err := s.MemberPassword(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := util.CompareHashAndPassword(curPasswordHash, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check password")
+	}
+	if !ok {
+		return nil, errors.Errorf("invalid password")
+	}
+
+	return member, nil
+}
+
+func (s *readDBService) AuthenticateUserNamePassword(ctx context.Context, userName string, password string) (*models.Member, error) {
+	tl := s.CurTimeLine(ctx)
+
+	member, err := s.MemberByUserName(ctx, tl.Number(), userName)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, errors.Errorf("no member with username: %s", userName)
+	}
+
+	curPasswordHash, err := s.MemberPassword(ctx, member.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := util.CompareHashAndPassword(curPasswordHash, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check password")
+	}
+	if !ok {
+		return nil, errors.Errorf("invalid password")
+	}
+
+	return member, nil
+}
+
+func (s *readDBService) AuthenticateEmailPassword(ctx context.Context, email string, password string) (*models.Member, error) {
+	tl := s.CurTimeLine(ctx)
+
+	member, err := s.MemberByEmail(ctx, tl.Number(), email)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, errors.Errorf("no member with email: %s", email)
+	}
+
+	curPasswordHash, err := s.MemberPassword(ctx, member.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := util.CompareHashAndPassword(curPasswordHash, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check password")
+	}
+	if !ok {
+		return nil, errors.Errorf("invalid password")
+	}
+
+	return member, nil
+}
+
+func (s *readDBService) CallingMember(ctx context.Context, curTl util.TimeLineNumber) (*models.Member, error) {
+	useridString, ok := ctx.Value("userid").(string)
+	if !ok || useridString == "" {
+		return nil, errors.Errorf("empty userid")
+	}
+	userid, err := uuid.FromString(useridString)
+	if err != nil {
+		return nil, err
+	}
+
+	// check that the member is valid
+	member, err := s.Member(ctx, curTl, util.NewFromUUID(userid))
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, errors.Errorf("unexistent member with id: %s", userid)
+	}
+	if member.IsDeactivated {
+		return nil, errors.Errorf("member with id %s is deactivated", userid)
+	}
+
+	// Set member as admin if defined as forcedAdminMemberUserName
+	if s.forcedAdminMemberUserName == member.UserName {
+		member.IsAdmin = true
+	}
+
+	return member, nil
+}
+
+func (s *readDBService) memberIsLeadLink(ctx context.Context, curTl util.TimeLineNumber, memberID, roleID util.ID) (bool, error) {
+	childsGroups, err := s.ChildRoles(ctx, curTl, []util.ID{roleID}, nil)
+	if err != nil {
+		return false, err
+	}
+	childs := childsGroups[roleID]
+
+	var leadLinkRole *models.Role
+	for _, child := range childs {
+		if child.RoleType == models.RoleTypeLeadLink {
+			leadLinkRole = child
+			break
+		}
+	}
+	if leadLinkRole == nil {
+		return false, nil
+	}
+
+	roleMemberEdgesGroups, err := s.RoleMemberEdges(ctx, curTl, []util.ID{leadLinkRole.ID}, nil)
+	if err != nil {
+		return false, err
+	}
+	roleMemberEdges := roleMemberEdgesGroups[leadLinkRole.ID]
+
+	// lead link must have at max one assigned member
+	if len(roleMemberEdges) == 0 {
+		return false, nil
+	}
+	return roleMemberEdges[0].Member.ID == memberID, nil
+}
+
+// retrieve permission at the circle level
+func (s *readDBService) MemberCirclePermissions(ctx context.Context, tl util.TimeLineNumber, roleID util.ID) (*models.MemberCirclePermissions, error) {
+	cp := &models.MemberCirclePermissions{}
+
+	callingMember, err := s.CallingMember(ctx, tl)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.Role(ctx, tl, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, errors.Errorf("role with id %s doesn't exist", roleID)
+	}
+	if role.RoleType != models.RoleTypeCircle {
+		// don't return an error since the caller can't know if this is a circle
+		// or another kind of role
+		return nil, nil
+	}
+
+	proleGroups, err := s.RoleParent(ctx, tl, []util.ID{roleID})
+	if err != nil {
+		return nil, err
+	}
+	prole := proleGroups[role.ID]
+
+	isLeadLink, err := s.memberIsLeadLink(ctx, tl, callingMember.ID, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the circle lead link can assign child circles lead links
+	if callingMember.IsAdmin || isLeadLink {
+		cp.AssignChildCircleLeadLink = true
+	}
+
+	// Only the circle lead link can assign member to core roles
+	if callingMember.IsAdmin || isLeadLink {
+		cp.AssignCircleCoreRoles = true
+	}
+
+	// Only the circle lead link can assign member assignment to child roles
+	if callingMember.IsAdmin || isLeadLink {
+		cp.AssignChildRoleMembers = true
+	}
+
+	// Only the circle lead link can assign direct member
+	if callingMember.IsAdmin || isLeadLink {
+		cp.AssignCircleDirectMembers = true
+	}
+
+	// Only the circle lead link can manage roles
+	if callingMember.IsAdmin || isLeadLink {
+		cp.ManageChildRoles = true
+	}
+
+	// Only the circle lead link can manage additional content
+	if callingMember.IsAdmin || isLeadLink {
+		cp.ManageRoleAdditionalContent = true
+	}
+
+	// As a special case, on the root role(circle), its lead link can manage the
+	// circle data and its lead link
+	if prole == nil {
+		if callingMember.IsAdmin || isLeadLink {
+			cp.AssignRootCircleLeadLink = true
+			cp.ManageRootCircle = true
+		}
+	}
+
+	return cp, nil
+}
+
+type DBEventHandler struct {
+	db *db.DB
+	es *eventstore.EventStore
+	nf ln.NotifierFactory
+}
+
+func NewDBEventHandler(db *db.DB, es *eventstore.EventStore, nf ln.NotifierFactory) *DBEventHandler {
+	return &DBEventHandler{
+		db: db,
+		es: es,
+		nf: nf,
+	}
+}
+
+func (h *DBEventHandler) Name() string {
+	return "readdb"
+}
+
+func (h *DBEventHandler) HandleEvents() error {
+	var sn int64
+	err := h.db.Do(func(tx *db.Tx) error {
+		err := tx.Do(func(tx *db.WrappedTx) error {
+			return tx.QueryRow("select sequencenumber from sequencenumber order by sequencenumber desc limit 1").Scan(&sn)
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				sn = 0
+			} else {
+				return err
+			}
+		}
+		return nil
+
+	})
+	if err != nil {
+		return err
+	}
+
+	notifier := h.nf.NewNotifier()
+	hasTxNotifier := false
+	txNotifier, ok := notifier.(ln.TxNotifier)
+	if ok {
+		hasTxNotifier = true
+	}
+
+	for {
+		events, err := h.es.GetAllEvents(sn+1, 100)
+		if err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		err = h.db.Do(func(tx *db.Tx) error {
+			if err := h.applyEvents(tx, events); err != nil {
+				return err
+			}
+
+			if hasTxNotifier {
+				txNotifier.BindTx(tx)
+				return txNotifier.Notify("readdb", "")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if !hasTxNotifier {
+			if err := notifier.Notify("readdb", ""); err != nil {
+				return err
+			}
+		}
+
+		sn = events[len(events)-1].SequenceNumber
+	}
+
+	return nil
+}
+
+func (h *DBEventHandler) applyEvents(tx *db.Tx, events []*eventstore.StoredEvent) error {
+	readDBService, err := NewReadDBService(tx)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		if err := h.handleEvent(e, tx, readDBService); err != nil {
+			return err
+		}
+
+		metaData, err := ep.UnmarshalMetaData(e)
+		if err != nil {
+			return err
+		}
+		var groupID interface{}
+		if metaData.GroupID != nil {
+			groupID = metaData.GroupID.UUID
+		}
+
+		err = tx.Do(func(tx *db.WrappedTx) error {
+			if _, err := tx.Exec("insert into sequencenumber (sequencenumber, groupid) values ($1, $2)", e.SequenceNumber, groupID); err != nil {
+				return errors.Wrap(err, "failed to save eventstate")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *DBEventHandler) handleSessionEvent(event *eventstore.StoredEvent, tx *db.Tx, s *readDBService) error {
+	data, err := ep.UnmarshalData(event)
+	if err != nil {
+		return err
+	}
+
+	sessionID, err := util.IDFromString(event.StreamID)
+	if err != nil {
+		return err
+	}
+
+	switch ep.EventType(event.EventType) {
+	case ep.EventTypeSessionCreated:
+		data := data.(*ep.EventSessionCreated)
+		session := &models.Session{
+			ID:           sessionID,
+			MemberID:     data.MemberID,
+			CreationTime: event.Timestamp,
+			Expiration:   data.Expiration,
+		}
+		if err := s.insertSession(session); err != nil {
+			return err
+		}
+
+	case ep.EventTypeSessionRefreshTokenIssued:
+		data := data.(*ep.EventSessionRefreshTokenIssued)
+		if data.PrevRefreshTokenID != nil {
+			err := tx.Do(func(tx *db.WrappedTx) error {
+				res, err := tx.Exec("update refreshtoken set used = $1 where id = $2", true, *data.PrevRefreshTokenID)
+				if err != nil {
+					return errors.Wrap(err, "failed to update refresh token")
+				}
+				n, err := res.RowsAffected()
+				if err != nil {
+					return errors.WithStack(err)
+				}
+				if n == 0 {
+					return errors.Errorf("refresh token with id %s doesn't exist", *data.PrevRefreshTokenID)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		refreshToken := &models.RefreshToken{
+			ID:        data.RefreshTokenID,
+			SessionID: sessionID,
+			TokenHash: data.RefreshTokenHash,
+		}
+		if err := s.insertRefreshToken(refreshToken); err != nil {
+			return err
+		}
+
+	case ep.EventTypeSessionRevoked:
+		// remove the session with all its refresh tokens (the whole token
+		// family)
+		err := tx.Do(func(tx *db.WrappedTx) error {
+			if _, err := tx.Exec("delete from refreshtoken where sessionid = $1", sessionID); err != nil {
+				return errors.Wrap(err, "failed to delete refresh tokens")
+			}
+			if _, err := tx.Exec("delete from session where id = $1", sessionID); err != nil {
+				return errors.Wrap(err, "failed to delete session")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *DBEventHandler) handleEvent(event *eventstore.StoredEvent, tx *db.Tx, s *readDBService) error {
+	log.Debugf("event: %v", event)
+
+	metaData, err := ep.UnmarshalMetaData(event)
+	if err != nil {
+		return err
+	}
+
+	if metaData.GroupID == nil {
+		return nil
+	}
+
+	// sessions aren't part of the organization history and aren't versioned
+	// by timeline, or every login would create a new timeline
+	switch ep.EventType(event.EventType) {
+	case ep.EventTypeSessionCreated, ep.EventTypeSessionRefreshTokenIssued, ep.EventTypeSessionRevoked:
+		return h.handleSessionEvent(event, tx, s)
+	}
+
+	ctx := context.Background()
+
+	tl, err := s.TimeLineForGroupID(ctx, *metaData.GroupID)
+	if err != nil {
+		return err
+	}
+	if tl == nil {
+		log.Debugf("no tl with groupID: %s", metaData.GroupID)
+		tl = &util.TimeLine{
+			Timestamp: event.Timestamp,
+		}
+
+		err := tx.Do(func(tx *db.WrappedTx) error {
+			if _, err := tx.Exec("insert into timeline (timestamp, groupid, aggregatetype, aggregateid) values ($1, $2, $3, $4)", tl.Timestamp, metaData.GroupID.UUID, event.Category, event.StreamID); err != nil {
+				return errors.Wrap(err, "failed to insert timeline")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		// Reread to inserted timeline since postgres has a microsecond resolution
+		// so the nanosecond will be lost
+		tl, err = s.TimeLineForGroupID(ctx, *metaData.GroupID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+	log.Debugf("tl: %d", tl)
+
+	data, err := ep.UnmarshalData(event)
+	if err != nil {
+		return err
+	}
+
+	switch ep.EventType(event.EventType) {
+	case ep.EventTypeRoleCreated:
+		data := data.(*ep.EventRoleCreated)
+		// We have to calculate the role depth
+		depth := int32(0)
+		if data.ParentRoleID != nil {
+			prole, err := s.Role(ctx, tl.Number(), *data.ParentRoleID)
+			if err != nil {
+				return err
+			}
+			if prole == nil {
+				return errors.Errorf("role with id %s doesn't exist", *data.ParentRoleID)
+			}
+			depth = prole.Depth + 1
+		}
+		role := &models.Role{
+			RoleType: data.RoleType,
+			Depth:    depth,
+			Name:     data.Name,
+			Purpose:  data.Purpose,
+		}
+		if err := s.newVertex(tl.Number(), data.RoleID, vertexClassRole, role); err != nil {
+			return err
+		}
+		if data.ParentRoleID != nil {
+			if err := s.addEdge(tl.Number(), edgeClassRoleRole, *data.ParentRoleID, data.RoleID); err != nil {
+				return err
+			}
+		}
+
+	case ep.EventTypeRoleDeleted:
+		data := data.(*ep.EventRoleDeleted)
+		proleGroups, err := s.RoleParent(ctx, tl.Number(), []util.ID{data.RoleID})
+		if err != nil {
+			return err
+		}
+		prole := proleGroups[data.RoleID]
+		if err := s.deleteVertex(tl.Number(), vertexClassRole, data.RoleID); err != nil {
+			return err
+		}
+		if prole != nil {
+			if err := s.deleteEdge(tl.Number(), edgeClassRoleRole, prole.ID, data.RoleID); err != nil {
+				return err
+			}
+		}
+
+	case ep.EventTypeRoleUpdated:
+		data := data.(*ep.EventRoleUpdated)
+		// We have to retrieve the current role depth
+		crole, err := s.Role(ctx, tl.Number(), data.RoleID)
+		if err != nil {
+			return err
+		}
+		if crole == nil {
+			return errors.Errorf("role with id %s doesn't exist", data.RoleID)
+		}
+		role := &models.Role{
+			RoleType: data.RoleType,
+			Depth:    crole.Depth,
+			Name:     data.Name,
+			Purpose:  data.Purpose,
+		}
+		if err := s.updateVertex(tl.Number(), vertexClassRole, data.RoleID, role); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleDomainCreated:
+		data := data.(*ep.EventRoleDomainCreated)
+		domainID := data.DomainID
+		domain := &models.Domain{
+			Description: data.Description,
+		}
+		if err := s.newVertex(tl.Number(), domainID, vertexClassDomain, domain); err != nil {
+			return err
+		}
+		if err := s.addEdge(tl.Number(), edgeClassRoleDomain, domainID, data.RoleID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleDomainUpdated:
+		data := data.(*ep.EventRoleDomainUpdated)
+		domainID := data.DomainID
+		domain := &models.Domain{
+			Description: data.Description,
+		}
+		if err := s.updateVertex(tl.Number(), vertexClassDomain, domainID, domain); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleDomainDeleted:
+		data := data.(*ep.EventRoleDomainDeleted)
+		domainID := data.DomainID
+		if err := s.deleteVertex(tl.Number(), vertexClassDomain, domainID); err != nil {
+			return err
+		}
+		if err := s.deleteEdge(tl.Number(), edgeClassRoleDomain, domainID, data.RoleID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleAccountabilityCreated:
+		data := data.(*ep.EventRoleAccountabilityCreated)
+		accountabilityID := data.AccountabilityID
+		accountability := &models.Accountability{
+			Description: data.Description,
+		}
+		if err := s.newVertex(tl.Number(), accountabilityID, vertexClassAccountability, accountability); err != nil {
+			return err
+		}
+		if err := s.addEdge(tl.Number(), edgeClassRoleAccountability, accountabilityID, data.RoleID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleAccountabilityUpdated:
+		data := data.(*ep.EventRoleAccountabilityUpdated)
+		accountabilityID := data.AccountabilityID
+		accountability := &models.Accountability{
+			Description: data.Description,
+		}
+		if err := s.updateVertex(tl.Number(), vertexClassAccountability, accountabilityID, accountability); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleAccountabilityDeleted:
+		data := data.(*ep.EventRoleAccountabilityDeleted)
+		accountabilityID := data.AccountabilityID
+		if err := s.deleteVertex(tl.Number(), vertexClassAccountability, accountabilityID); err != nil {
+			return err
+		}
+		if err := s.deleteEdge(tl.Number(), edgeClassRoleAccountability, accountabilityID, data.RoleID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleChecklistItemCreated:
+		data := data.(*ep.EventRoleChecklistItemCreated)
+		checklistItemID := data.ChecklistItemID
+		checklistItem := &models.ChecklistItem{
+			Description: data.Description,
+		}
+		if err := s.newVertex(tl.Number(), checklistItemID, vertexClassChecklistItem, checklistItem); err != nil {
+			return err
+		}
+		if err := s.addEdge(tl.Number(), edgeClassRoleChecklistItem, checklistItemID, data.RoleID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleChecklistItemUpdated:
+		data := data.(*ep.EventRoleChecklistItemUpdated)
+		checklistItemID := data.ChecklistItemID
+		checklistItem := &models.ChecklistItem{
+			Description: data.Description,
+		}
+		if err := s.updateVertex(tl.Number(), vertexClassChecklistItem, checklistItemID, checklistItem); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleChecklistItemDeleted:
+		data := data.(*ep.EventRoleChecklistItemDeleted)
+		checklistItemID := data.ChecklistItemID
+		// also close the values reported in the meetings
+		reportsGroups, err := s.ChecklistItemReports(ctx, tl.Number(), []util.ID{checklistItemID})
+		if err != nil {
+			return err
+		}
+		for _, report := range reportsGroups[checklistItemID] {
+			if err := s.deleteEdge(tl.Number(), edgeClassMeetingChecklistItem, checklistItemID, report.Meeting.ID); err != nil {
+				return err
+			}
+		}
+		if err := s.deleteVertex(tl.Number(), vertexClassChecklistItem, checklistItemID); err != nil {
+			return err
+		}
+		if err := s.deleteEdge(tl.Number(), edgeClassRoleChecklistItem, checklistItemID, data.RoleID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleMetricCreated:
+		data := data.(*ep.EventRoleMetricCreated)
+		metricID := data.MetricID
+		metric := &models.Metric{
+			Description: data.Description,
+		}
+		if err := s.newVertex(tl.Number(), metricID, vertexClassMetric, metric); err != nil {
+			return err
+		}
+		if err := s.addEdge(tl.Number(), edgeClassRoleMetric, metricID, data.RoleID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleMetricUpdated:
+		data := data.(*ep.EventRoleMetricUpdated)
+		metricID := data.MetricID
+		metric := &models.Metric{
+			Description: data.Description,
+		}
+		if err := s.updateVertex(tl.Number(), vertexClassMetric, metricID, metric); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleMetricDeleted:
+		data := data.(*ep.EventRoleMetricDeleted)
+		metricID := data.MetricID
+		// also close the values reported in the meetings
+		reportsGroups, err := s.MetricReports(ctx, tl.Number(), []util.ID{metricID})
+		if err != nil {
+			return err
+		}
+		for _, report := range reportsGroups[metricID] {
+			if err := s.deleteEdge(tl.Number(), edgeClassMeetingMetric, metricID, report.Meeting.ID); err != nil {
+				return err
+			}
+		}
+		if err := s.deleteVertex(tl.Number(), vertexClassMetric, metricID); err != nil {
+			return err
+		}
+		if err := s.deleteEdge(tl.Number(), edgeClassRoleMetric, metricID, data.RoleID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleAdditionalContentSet:
+		data := data.(*ep.EventRoleAdditionalContentSet)
+		roleAdditionalContent := &models.RoleAdditionalContent{
+			Content: data.Content,
+		}
+		if err := s.updateVertex(tl.Number(), vertexClassRoleAdditionalContent, data.RoleID, roleAdditionalContent); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleChangedParent:
+		data := data.(*ep.EventRoleChangedParent)
+		if err := s.changeRoleParent(ctx, tl.Number(), data.RoleID, data.ParentRoleID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleMemberAdded:
+		data := data.(*ep.EventRoleMemberAdded)
+		if err := s.roleAddMember(tl.Number(), data.RoleID, data.MemberID, data.Focus, data.NoCoreMember); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleMemberUpdated:
+		data := data.(*ep.EventRoleMemberUpdated)
+		if err := s.roleUpdateMember(tl.Number(), data.RoleID, data.MemberID, data.Focus, data.NoCoreMember); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleMemberRemoved:
+		data := data.(*ep.EventRoleMemberRemoved)
+		if err := s.roleRemoveMember(tl.Number(), data.RoleID, data.MemberID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeCircleDirectMemberAdded:
+		data := data.(*ep.EventCircleDirectMemberAdded)
+		if err := s.addEdge(tl.Number(), edgeClassCircleDirectMember, data.MemberID, data.RoleID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeCircleDirectMemberRemoved:
+		data := data.(*ep.EventCircleDirectMemberRemoved)
+		if err := s.circleRemoveDirectMember(tl.Number(), data.RoleID, data.MemberID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeCircleLeadLinkMemberSet:
+		data := data.(*ep.EventCircleLeadLinkMemberSet)
+		if err := s.addEdge(tl.Number(), edgeClassRoleMember, data.MemberID, data.LeadLinkRoleID, nil, false, nil); err != nil {
+			return err
+		}
+
+	case ep.EventTypeCircleLeadLinkMemberUnset:
+		data := data.(*ep.EventCircleLeadLinkMemberUnset)
+		if err := s.deleteEdge(tl.Number(), edgeClassRoleMember, data.MemberID, data.LeadLinkRoleID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeCircleCoreRoleMemberSet:
+		data := data.(*ep.EventCircleCoreRoleMemberSet)
+		if err := s.addEdge(tl.Number(), edgeClassRoleMember, data.MemberID, data.CoreRoleID, nil, false, data.ElectionExpiration); err != nil {
+			return err
+		}
+
+	case ep.EventTypeCircleCoreRoleMemberUnset:
+		data := data.(*ep.EventCircleCoreRoleMemberUnset)
+		if err := s.deleteEdge(tl.Number(), edgeClassRoleMember, data.MemberID, data.CoreRoleID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeTensionCreated:
+		data := data.(*ep.EventTensionCreated)
+		tensionID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		tension := &models.Tension{
+			Title:       data.Title,
+			Description: data.Description,
+			Closed:      false,
+			Status:      models.TensionStatusNew,
+		}
+		if err := s.newVertex(tl.Number(), tensionID, vertexClassTension, tension); err != nil {
+			return err
+		}
+		if err := s.addEdge(tl.Number(), edgeClassMemberTension, tensionID, data.MemberID); err != nil {
+			return err
+		}
+		if data.RoleID != nil {
+			if err := s.addEdge(tl.Number(), edgeClassRoleTension, tensionID, *data.RoleID); err != nil {
+				return err
+			}
+		}
+
+	case ep.EventTypeTensionUpdated:
+		data := data.(*ep.EventTensionUpdated)
+		tensionID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		tension, err := s.Tension(ctx, tl.Number(), tensionID)
+		if err != nil {
+			return err
+		}
+		if tension == nil {
+			return errors.Errorf("tension with id %s doesn't exist", tensionID)
+		}
+
+		tension.Title = data.Title
+		tension.Description = data.Description
+		if err := s.updateVertex(tl.Number(), vertexClassTension, tensionID, tension); err != nil {
+			return err
+		}
+
+	case ep.EventTypeTensionRoleChanged:
+		data := data.(*ep.EventTensionRoleChanged)
+		tensionID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		if data.PrevRoleID != nil {
+			if err := s.deleteEdge(tl.Number(), edgeClassRoleTension, tensionID, *data.PrevRoleID); err != nil {
+				return err
+			}
+		}
+		if data.RoleID != nil {
+			if err := s.addEdge(tl.Number(), edgeClassRoleTension, tensionID, *data.RoleID); err != nil {
+				return err
+			}
+		}
+
+	case ep.EventTypeTensionClosed:
+		data := data.(*ep.EventTensionClosed)
+		tensionID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		tension, err := s.Tension(ctx, tl.Number(), tensionID)
+		if err != nil {
+			return err
+		}
+		if tension == nil {
+			return errors.Errorf("tension with id %s doesn't exist", tensionID)
+		}
+
+		tension.Closed = true
+		tension.CloseReason = data.Reason
+		tension.Status = models.TensionStatusDropped
+		if data.MeetingID != nil {
+			tension.MeetingID = data.MeetingID
+		}
+		if err := s.updateVertex(tl.Number(), vertexClassTension, tensionID, tension); err != nil {
+			return err
+		}
+
+	case ep.EventTypeTensionStatusChanged:
+		data := data.(*ep.EventTensionStatusChanged)
+		tensionID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		tension, err := s.Tension(ctx, tl.Number(), tensionID)
+		if err != nil {
+			return err
+		}
+		if tension == nil {
+			return errors.Errorf("tension with id %s doesn't exist", tensionID)
+		}
+
+		tension.Status = data.Status
+		if err := s.updateVertex(tl.Number(), vertexClassTension, tensionID, tension); err != nil {
+			return err
+		}
+
+	case ep.EventTypeTensionAssigneeChanged:
+		data := data.(*ep.EventTensionAssigneeChanged)
+		tensionID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		if data.PrevMemberID != nil {
+			if err := s.deleteEdge(tl.Number(), edgeClassTensionAssignee, tensionID, *data.PrevMemberID); err != nil {
+				return err
+			}
+		}
+		if data.MemberID != nil {
+			if err := s.addEdge(tl.Number(), edgeClassTensionAssignee, tensionID, *data.MemberID); err != nil {
+				return err
+			}
+		}
+
+	case ep.EventTypeTensionMeetingChanged:
+		data := data.(*ep.EventTensionMeetingChanged)
+		tensionID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		tension, err := s.Tension(ctx, tl.Number(), tensionID)
+		if err != nil {
+			return err
+		}
+		if tension == nil {
+			return errors.Errorf("tension with id %s doesn't exist", tensionID)
+		}
+
+		tension.MeetingID = data.MeetingID
+		if err := s.updateVertex(tl.Number(), vertexClassTension, tensionID, tension); err != nil {
+			return err
+		}
+
+	case ep.EventTypeTensionResolved:
+		data := data.(*ep.EventTensionResolved)
+		tensionID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		tension, err := s.Tension(ctx, tl.Number(), tensionID)
+		if err != nil {
+			return err
+		}
+		if tension == nil {
+			return errors.Errorf("tension with id %s doesn't exist", tensionID)
+		}
+
+		tension.Closed = true
+		tension.Status = models.TensionStatusResolved
+		tension.OutcomeType = data.OutcomeType
+		tension.OutcomeReferenceID = data.OutcomeReferenceID
+		tension.OutcomeDescription = data.OutcomeDescription
+		if err := s.updateVertex(tl.Number(), vertexClassTension, tensionID, tension); err != nil {
+			return err
+		}
+
+	case ep.EventTypeProjectCreated:
+		data := data.(*ep.EventProjectCreated)
+		projectID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		project := &models.Project{
+			Title:       data.Title,
+			Description: data.Description,
+			Status:      models.ProjectStatusActive,
+			DueDate:     data.DueDate,
+		}
+		if err := s.newVertex(tl.Number(), projectID, vertexClassProject, project); err != nil {
+			return err
+		}
+		if err := s.addEdge(tl.Number(), edgeClassRoleProject, projectID, data.RoleID); err != nil {
+			return err
+		}
+		if data.MemberID != nil {
+			if err := s.addEdge(tl.Number(), edgeClassMemberProject, projectID, *data.MemberID); err != nil {
+				return err
+			}
+		}
+		if data.TensionID != nil {
+			if err := s.addEdge(tl.Number(), edgeClassTensionProject, projectID, *data.TensionID); err != nil {
 				return err
 			}
 		}
-		return nil
-
-	})
-	if err != nil {
-		return err
-	}
 
-	notifier := h.nf.NewNotifier()
-	hasTxNotifier := false
-	txNotifier, ok := notifier.(ln.TxNotifier)
-	if ok {
-		hasTxNotifier = true
-	}
+	case ep.EventTypeProjectUpdated:
+		data := data.(*ep.EventProjectUpdated)
+		projectID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
 
-	for {
-		events, err := h.es.GetAllEvents(sn+1, 100)
+		project, err := s.Project(ctx, tl.Number(), projectID)
 		if err != nil {
 			return err
 		}
+		if project == nil {
+			return errors.Errorf("project with id %s doesn't exist", projectID)
+		}
 
-		if len(events) == 0 {
-			return nil
+		project.Title = data.Title
+		project.Description = data.Description
+		project.DueDate = data.DueDate
+		if err := s.updateVertex(tl.Number(), vertexClassProject, projectID, project); err != nil {
+			return err
 		}
 
-		err = h.db.Do(func(tx *db.Tx) error {
-			readDBService, err := NewReadDBService(tx)
-			if err != nil {
+	case ep.EventTypeProjectMemberChanged:
+		data := data.(*ep.EventProjectMemberChanged)
+		projectID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		if data.PrevMemberID != nil {
+			if err := s.deleteEdge(tl.Number(), edgeClassMemberProject, projectID, *data.PrevMemberID); err != nil {
 				return err
 			}
-
-			for _, e := range events {
-				if err := h.handleEvent(e, tx, readDBService); err != nil {
-					return err
-				}
-
-				err = tx.Do(func(tx *db.WrappedTx) error {
-					if _, err := tx.Exec("insert into sequencenumber (sequencenumber) values ($1)", e.SequenceNumber); err != nil {
-						return errors.Wrap(err, "failed to save eventstate")
-					}
-					return nil
-				})
-				if err != nil {
-					return err
-				}
+		}
+		if data.MemberID != nil {
+			if err := s.addEdge(tl.Number(), edgeClassMemberProject, projectID, *data.MemberID); err != nil {
+				return err
 			}
+		}
 
-			if hasTxNotifier {
-				txNotifier.BindTx(tx)
-				return txNotifier.Notify("readdb", "")
-			}
-			return nil
-		})
+	case ep.EventTypeProjectStatusChanged:
+		data := data.(*ep.EventProjectStatusChanged)
+		projectID, err := util.IDFromString(event.StreamID)
 		if err != nil {
 			return err
 		}
 
-		if !hasTxNotifier {
-			if err := notifier.Notify("readdb", ""); err != nil {
-				return err
-			}
+		project, err := s.Project(ctx, tl.Number(), projectID)
+		if err != nil {
+			return err
+		}
+		if project == nil {
+			return errors.Errorf("project with id %s doesn't exist", projectID)
 		}
 
-		sn = events[len(events)-1].SequenceNumber
-	}
+		project.Status = data.Status
+		if err := s.updateVertex(tl.Number(), vertexClassProject, projectID, project); err != nil {
+			return err
+		}
 
-	return nil
-}
+	case ep.EventTypeActionCreated:
+		data := data.(*ep.EventActionCreated)
+		actionID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
 
-func (h *DBEventHandler) handleEvent(event *eventstore.StoredEvent, tx *db.Tx, s *readDBService) error {
-	log.Debugf("event: %v", event)
+		action := &models.Action{
+			Title:       data.Title,
+			Description: data.Description,
+			Status:      models.ActionStatusOpen,
+			DueDate:     data.DueDate,
+		}
+		if err := s.newVertex(tl.Number(), actionID, vertexClassAction, action); err != nil {
+			return err
+		}
+		if err := s.addEdge(tl.Number(), edgeClassRoleAction, actionID, data.RoleID); err != nil {
+			return err
+		}
+		if data.MemberID != nil {
+			if err := s.addEdge(tl.Number(), edgeClassMemberAction, actionID, *data.MemberID); err != nil {
+				return err
+			}
+		}
+		if data.TensionID != nil {
+			if err := s.addEdge(tl.Number(), edgeClassTensionAction, actionID, *data.TensionID); err != nil {
+				return err
+			}
+		}
 
-	metaData, err := ep.UnmarshalMetaData(event)
-	if err != nil {
-		return err
-	}
+	case ep.EventTypeActionUpdated:
+		data := data.(*ep.EventActionUpdated)
+		actionID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
 
-	if metaData.GroupID == nil {
-		return nil
-	}
+		action, err := s.Action(ctx, tl.Number(), actionID)
+		if err != nil {
+			return err
+		}
+		if action == nil {
+			return errors.Errorf("action with id %s doesn't exist", actionID)
+		}
 
-	ctx := context.Background()
+		action.Title = data.Title
+		action.Description = data.Description
+		action.DueDate = data.DueDate
+		if err := s.updateVertex(tl.Number(), vertexClassAction, actionID, action); err != nil {
+			return err
+		}
 
-	tl, err := s.TimeLineForGroupID(ctx, *metaData.GroupID)
-	if err != nil {
-		return err
-	}
-	if tl == nil {
-		log.Debugf("no tl with groupID: %s", metaData.GroupID)
-		tl = &util.TimeLine{
-			Timestamp: event.Timestamp,
+	case ep.EventTypeActionMemberChanged:
+		data := data.(*ep.EventActionMemberChanged)
+		actionID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
 		}
 
-		err := tx.Do(func(tx *db.WrappedTx) error {
-			if _, err := tx.Exec("insert into timeline (timestamp, groupid, aggregatetype, aggregateid) values ($1, $2, $3, $4)", tl.Timestamp, metaData.GroupID.UUID, event.Category, event.StreamID); err != nil {
-				return errors.Wrap(err, "failed to insert timeline")
+		if data.PrevMemberID != nil {
+			if err := s.deleteEdge(tl.Number(), edgeClassMemberAction, actionID, *data.PrevMemberID); err != nil {
+				return err
 			}
-			return nil
-		})
+		}
+		if data.MemberID != nil {
+			if err := s.addEdge(tl.Number(), edgeClassMemberAction, actionID, *data.MemberID); err != nil {
+				return err
+			}
+		}
+
+	case ep.EventTypeActionStatusChanged:
+		data := data.(*ep.EventActionStatusChanged)
+		actionID, err := util.IDFromString(event.StreamID)
 		if err != nil {
 			return err
 		}
-		// Reread to inserted timeline since postgres has a microsecond resolution
-		// so the nanosecond will be lost
-		tl, err = s.TimeLineForGroupID(ctx, *metaData.GroupID)
+
+		action, err := s.Action(ctx, tl.Number(), actionID)
 		if err != nil {
 			return err
 		}
-	}
+		if action == nil {
+			return errors.Errorf("action with id %s doesn't exist", actionID)
+		}
 
-	if err != nil {
-		return err
-	}
-	log.Debugf("tl: %d", tl)
+		action.Status = data.Status
+		if err := s.updateVertex(tl.Number(), vertexClassAction, actionID, action); err != nil {
+			return err
+		}
 
-	data, err := ep.UnmarshalData(event)
-	if err != nil {
-		return err
-	}
+	case ep.EventTypeMeetingCreated:
+		data := data.(*ep.EventMeetingCreated)
+		meetingID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
 
-	switch ep.EventType(event.EventType) {
-	case ep.EventTypeRoleCreated:
-		data := data.(*ep.EventRoleCreated)
-		// We have to calculate the role depth
-		depth := int32(0)
-		if data.ParentRoleID != nil {
-			prole, err := s.Role(ctx, tl.Number(), *data.ParentRoleID)
-			if err != nil {
+		meeting := &models.Meeting{
+			MeetingType: data.MeetingType,
+			Date:        data.Date,
+		}
+		if err := s.newVertex(tl.Number(), meetingID, vertexClassMeeting, meeting); err != nil {
+			return err
+		}
+		if err := s.addEdge(tl.Number(), edgeClassRoleMeeting, meetingID, data.RoleID); err != nil {
+			return err
+		}
+		if data.FacilitatorID != nil {
+			if err := s.addEdge(tl.Number(), edgeClassMeetingFacilitator, meetingID, *data.FacilitatorID); err != nil {
 				return err
 			}
-			if prole == nil {
-				return errors.Errorf("role with id %s doesn't exist", *data.ParentRoleID)
+		}
+		if data.SecretaryID != nil {
+			if err := s.addEdge(tl.Number(), edgeClassMeetingSecretary, meetingID, *data.SecretaryID); err != nil {
+				return err
 			}
-			depth = prole.Depth + 1
 		}
-		role := &models.Role{
+		for _, attendeeID := range data.AttendeesIDs {
+			if err := s.addEdge(tl.Number(), edgeClassMeetingAttendee, attendeeID, meetingID); err != nil {
+				return err
+			}
+		}
+
+	case ep.EventTypeMeetingChecklistItemReported:
+		data := data.(*ep.EventMeetingChecklistItemReported)
+		meetingID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		// a new report replaces the previous one
+		if err := s.deleteEdge(tl.Number(), edgeClassMeetingChecklistItem, data.ChecklistItemID, meetingID); err != nil {
+			return err
+		}
+		if err := s.addEdge(tl.Number(), edgeClassMeetingChecklistItem, data.ChecklistItemID, meetingID, data.Checked); err != nil {
+			return err
+		}
+
+	case ep.EventTypeMeetingMetricReported:
+		data := data.(*ep.EventMeetingMetricReported)
+		meetingID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		// a new report replaces the previous one
+		if err := s.deleteEdge(tl.Number(), edgeClassMeetingMetric, data.MetricID, meetingID); err != nil {
+			return err
+		}
+		if err := s.addEdge(tl.Number(), edgeClassMeetingMetric, data.MetricID, meetingID, data.Value); err != nil {
+			return err
+		}
+
+	case ep.EventTypeElectionCreated:
+		data := data.(*ep.EventElectionCreated)
+		electionID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		election := &models.Election{
 			RoleType: data.RoleType,
-			Depth:    depth,
-			Name:     data.Name,
-			Purpose:  data.Purpose,
+			Status:   models.ElectionStatusOpen,
 		}
-		if err := s.newVertex(tl.Number(), data.RoleID, vertexClassRole, role); err != nil {
+		if err := s.newVertex(tl.Number(), electionID, vertexClassElection, election); err != nil {
 			return err
 		}
-		if data.ParentRoleID != nil {
-			if err := s.addEdge(tl.Number(), edgeClassRoleRole, *data.ParentRoleID, data.RoleID); err != nil {
-				return err
-			}
+		if err := s.addEdge(tl.Number(), edgeClassRoleElection, electionID, data.RoleID); err != nil {
+			return err
 		}
 
-	case ep.EventTypeRoleDeleted:
-		data := data.(*ep.EventRoleDeleted)
-		proleGroups, err := s.RoleParent(ctx, tl.Number(), []util.ID{data.RoleID})
+	case ep.EventTypeElectionCandidateNominated:
+		data := data.(*ep.EventElectionCandidateNominated)
+		electionID, err := util.IDFromString(event.StreamID)
 		if err != nil {
 			return err
 		}
-		prole := proleGroups[data.RoleID]
-		if err := s.deleteVertex(tl.Number(), vertexClassRole, data.RoleID); err != nil {
+
+		if err := s.addEdge(tl.Number(), edgeClassElectionCandidate, data.CandidateID, electionID, data.NominatorID); err != nil {
 			return err
 		}
-		if prole != nil {
-			if err := s.deleteEdge(tl.Number(), edgeClassRoleRole, prole.ID, data.RoleID); err != nil {
-				return err
-			}
+
+	case ep.EventTypeElectionCompleted:
+		data := data.(*ep.EventElectionCompleted)
+		electionID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
 		}
 
-	case ep.EventTypeRoleUpdated:
-		data := data.(*ep.EventRoleUpdated)
-		// We have to retrieve the current role depth
-		crole, err := s.Role(ctx, tl.Number(), data.RoleID)
+		election, err := s.Election(ctx, tl.Number(), electionID)
 		if err != nil {
 			return err
 		}
-		if crole == nil {
-			return errors.Errorf("role with id %s doesn't exist", data.RoleID)
+		if election == nil {
+			return errors.Errorf("election with id %s doesn't exist", electionID)
 		}
-		role := &models.Role{
-			RoleType: data.RoleType,
-			Depth:    crole.Depth,
-			Name:     data.Name,
-			Purpose:  data.Purpose,
+		election.Status = models.ElectionStatusCompleted
+		election.ElectionExpiration = data.ElectionExpiration
+		if err := s.updateVertex(tl.Number(), vertexClassElection, electionID, election); err != nil {
+			return err
 		}
-		if err := s.updateVertex(tl.Number(), vertexClassRole, data.RoleID, role); err != nil {
+		if err := s.addEdge(tl.Number(), edgeClassElectionElected, electionID, data.ElectedMemberID); err != nil {
 			return err
 		}
 
-	case ep.EventTypeRoleDomainCreated:
-		data := data.(*ep.EventRoleDomainCreated)
-		domainID := data.DomainID
-		domain := &models.Domain{
-			Description: data.Description,
+	case ep.EventTypeRoleTemplateCreated:
+		data := data.(*ep.EventRoleTemplateCreated)
+		roleTemplateID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
 		}
-		if err := s.newVertex(tl.Number(), domainID, vertexClassDomain, domain); err != nil {
+
+		roleTemplate := &models.RoleTemplate{
+			Name:             data.Name,
+			Purpose:          data.Purpose,
+			Domains:          data.Domains,
+			Accountabilities: data.Accountabilities,
+		}
+		if err := s.newVertex(tl.Number(), roleTemplateID, vertexClassRoleTemplate, roleTemplate); err != nil {
 			return err
 		}
-		if err := s.addEdge(tl.Number(), edgeClassRoleDomain, domainID, data.RoleID); err != nil {
+
+	case ep.EventTypeRoleTemplateUpdated:
+		data := data.(*ep.EventRoleTemplateUpdated)
+		roleTemplateID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		roleTemplate := &models.RoleTemplate{
+			Name:             data.Name,
+			Purpose:          data.Purpose,
+			Domains:          data.Domains,
+			Accountabilities: data.Accountabilities,
+		}
+		if err := s.updateVertex(tl.Number(), vertexClassRoleTemplate, roleTemplateID, roleTemplate); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleTemplateDeleted:
+		roleTemplateID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.deleteVertex(tl.Number(), vertexClassRoleTemplate, roleTemplateID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleTemplateInstanceLinked:
+		data := data.(*ep.EventRoleTemplateInstanceLinked)
+		roleTemplateID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.addEdge(tl.Number(), edgeClassRoleTemplateInstance, data.RoleID, roleTemplateID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeRoleTemplateInstanceUnlinked:
+		data := data.(*ep.EventRoleTemplateInstanceUnlinked)
+		roleTemplateID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.deleteEdge(tl.Number(), edgeClassRoleTemplateInstance, data.RoleID, roleTemplateID); err != nil {
 			return err
 		}
 
-	case ep.EventTypeRoleDomainUpdated:
-		data := data.(*ep.EventRoleDomainUpdated)
-		domainID := data.DomainID
-		domain := &models.Domain{
-			Description: data.Description,
-		}
-		if err := s.updateVertex(tl.Number(), vertexClassDomain, domainID, domain); err != nil {
+	case ep.EventTypeWebhookCreated:
+		data := data.(*ep.EventWebhookCreated)
+		webhookID, err := util.IDFromString(event.StreamID)
+		if err != nil {
 			return err
 		}
 
-	case ep.EventTypeRoleDomainDeleted:
-		data := data.(*ep.EventRoleDomainDeleted)
-		domainID := data.DomainID
-		if err := s.deleteVertex(tl.Number(), vertexClassDomain, domainID); err != nil {
+		webhook := &models.Webhook{
+			URL:        data.URL,
+			EventTypes: data.EventTypes,
+			Secret:     data.Secret,
+		}
+		if err := s.newVertex(tl.Number(), webhookID, vertexClassWebhook, webhook); err != nil {
 			return err
 		}
-		if err := s.deleteEdge(tl.Number(), edgeClassRoleDomain, domainID, data.RoleID); err != nil {
+
+	case ep.EventTypeWebhookUpdated:
+		data := data.(*ep.EventWebhookUpdated)
+		webhookID, err := util.IDFromString(event.StreamID)
+		if err != nil {
 			return err
 		}
 
-	case ep.EventTypeRoleAccountabilityCreated:
-		data := data.(*ep.EventRoleAccountabilityCreated)
-		accountabilityID := data.AccountabilityID
-		accountability := &models.Accountability{
-			Description: data.Description,
+		webhook := &models.Webhook{
+			URL:        data.URL,
+			EventTypes: data.EventTypes,
+			Secret:     data.Secret,
 		}
-		if err := s.newVertex(tl.Number(), accountabilityID, vertexClassAccountability, accountability); err != nil {
+		if err := s.updateVertex(tl.Number(), vertexClassWebhook, webhookID, webhook); err != nil {
 			return err
 		}
-		if err := s.addEdge(tl.Number(), edgeClassRoleAccountability, accountabilityID, data.RoleID); err != nil {
+
+	case ep.EventTypeWebhookDeleted:
+		webhookID, err := util.IDFromString(event.StreamID)
+		if err != nil {
 			return err
 		}
 
-	case ep.EventTypeRoleAccountabilityUpdated:
-		data := data.(*ep.EventRoleAccountabilityUpdated)
-		accountabilityID := data.AccountabilityID
-		accountability := &models.Accountability{
-			Description: data.Description,
+		if err := s.deleteVertex(tl.Number(), vertexClassWebhook, webhookID); err != nil {
+			return err
 		}
-		if err := s.updateVertex(tl.Number(), vertexClassAccountability, accountabilityID, accountability); err != nil {
+
+	case ep.EventTypeWebhookDeliveryFailed:
+		data := data.(*ep.EventWebhookDeliveryFailed)
+		webhookID, err := util.IDFromString(event.StreamID)
+		if err != nil {
 			return err
 		}
 
-	case ep.EventTypeRoleAccountabilityDeleted:
-		data := data.(*ep.EventRoleAccountabilityDeleted)
-		accountabilityID := data.AccountabilityID
-		if err := s.deleteVertex(tl.Number(), vertexClassAccountability, accountabilityID); err != nil {
+		deadLetter := &models.WebhookDeadLetter{
+			TimeLineID:     tl.Number(),
+			WebhookID:      webhookID,
+			EventID:        data.EventID,
+			SequenceNumber: data.SequenceNumber,
+			EventType:      data.DeliveryEventType,
+			Attempts:       data.Attempts,
+			LastError:      data.LastError,
+			Timestamp:      event.Timestamp,
+		}
+		if err := s.insertWebhookDeadLetter(deadLetter); err != nil {
 			return err
 		}
-		if err := s.deleteEdge(tl.Number(), edgeClassRoleAccountability, accountabilityID, data.RoleID); err != nil {
+
+	case ep.EventTypeAPITokenCreated:
+		data := data.(*ep.EventAPITokenCreated)
+		apiTokenID, err := util.IDFromString(event.StreamID)
+		if err != nil {
 			return err
 		}
 
-	case ep.EventTypeRoleAdditionalContentSet:
-		data := data.(*ep.EventRoleAdditionalContentSet)
-		roleAdditionalContent := &models.RoleAdditionalContent{
-			Content: data.Content,
+		apiToken := &models.APIToken{
+			MemberID:     data.MemberID,
+			Name:         data.Name,
+			Scope:        data.Scope,
+			CreationTime: event.Timestamp,
+			Expiration:   data.Expiration,
+			TokenHash:    data.TokenHash,
 		}
-		if err := s.updateVertex(tl.Number(), vertexClassRoleAdditionalContent, data.RoleID, roleAdditionalContent); err != nil {
+		if err := s.newVertex(tl.Number(), apiTokenID, vertexClassAPIToken, apiToken); err != nil {
 			return err
 		}
 
-	case ep.EventTypeRoleChangedParent:
-		data := data.(*ep.EventRoleChangedParent)
-		if err := s.changeRoleParent(ctx, tl.Number(), data.RoleID, data.ParentRoleID); err != nil {
+	case ep.EventTypeAPITokenRevoked:
+		apiTokenID, err := util.IDFromString(event.StreamID)
+		if err != nil {
 			return err
 		}
 
-	case ep.EventTypeRoleMemberAdded:
-		data := data.(*ep.EventRoleMemberAdded)
-		if err := s.roleAddMember(tl.Number(), data.RoleID, data.MemberID, data.Focus, data.NoCoreMember); err != nil {
+		if err := s.deleteVertex(tl.Number(), vertexClassAPIToken, apiTokenID); err != nil {
 			return err
 		}
 
-	case ep.EventTypeRoleMemberUpdated:
-		data := data.(*ep.EventRoleMemberUpdated)
-		if err := s.roleUpdateMember(tl.Number(), data.RoleID, data.MemberID, data.Focus, data.NoCoreMember); err != nil {
+	case ep.EventTypeProposalCreated:
+		data := data.(*ep.EventProposalCreated)
+		proposalID, err := util.IDFromString(event.StreamID)
+		if err != nil {
 			return err
 		}
 
-	case ep.EventTypeRoleMemberRemoved:
-		data := data.(*ep.EventRoleMemberRemoved)
-		if err := s.roleRemoveMember(tl.Number(), data.RoleID, data.MemberID); err != nil {
+		proposal := &models.Proposal{
+			Title:       data.Title,
+			Description: data.Description,
+			Status:      models.ProposalStatusDraft,
+		}
+		if err := s.newVertex(tl.Number(), proposalID, vertexClassProposal, proposal); err != nil {
 			return err
 		}
-
-	case ep.EventTypeCircleDirectMemberAdded:
-		data := data.(*ep.EventCircleDirectMemberAdded)
-		if err := s.addEdge(tl.Number(), edgeClassCircleDirectMember, data.MemberID, data.RoleID); err != nil {
+		if err := s.newVertex(tl.Number(), proposalID, vertexClassProposalChanges, &data.ProposalChanges); err != nil {
+			return err
+		}
+		if err := s.addEdge(tl.Number(), edgeClassMemberProposal, proposalID, data.MemberID); err != nil {
+			return err
+		}
+		if err := s.addEdge(tl.Number(), edgeClassRoleProposal, proposalID, data.RoleID); err != nil {
 			return err
 		}
 
-	case ep.EventTypeCircleDirectMemberRemoved:
-		data := data.(*ep.EventCircleDirectMemberRemoved)
-		if err := s.circleRemoveDirectMember(tl.Number(), data.RoleID, data.MemberID); err != nil {
+	case ep.EventTypeProposalUpdated:
+		data := data.(*ep.EventProposalUpdated)
+		proposalID, err := util.IDFromString(event.StreamID)
+		if err != nil {
 			return err
 		}
 
-	case ep.EventTypeCircleLeadLinkMemberSet:
-		data := data.(*ep.EventCircleLeadLinkMemberSet)
-		if err := s.addEdge(tl.Number(), edgeClassRoleMember, data.MemberID, data.LeadLinkRoleID, nil, false, nil); err != nil {
+		proposal, err := s.Proposal(ctx, tl.Number(), proposalID)
+		if err != nil {
 			return err
 		}
+		if proposal == nil {
+			return errors.Errorf("proposal with id %s doesn't exist", proposalID)
+		}
 
-	case ep.EventTypeCircleLeadLinkMemberUnset:
-		data := data.(*ep.EventCircleLeadLinkMemberUnset)
-		if err := s.deleteEdge(tl.Number(), edgeClassRoleMember, data.MemberID, data.LeadLinkRoleID); err != nil {
+		proposal.Title = data.Title
+		proposal.Description = data.Description
+		if err := s.updateVertex(tl.Number(), vertexClassProposal, proposalID, proposal); err != nil {
+			return err
+		}
+		if err := s.updateVertex(tl.Number(), vertexClassProposalChanges, proposalID, &data.ProposalChanges); err != nil {
 			return err
 		}
 
-	case ep.EventTypeCircleCoreRoleMemberSet:
-		data := data.(*ep.EventCircleCoreRoleMemberSet)
-		if err := s.addEdge(tl.Number(), edgeClassRoleMember, data.MemberID, data.CoreRoleID, nil, false, data.ElectionExpiration); err != nil {
+		// updated changes must be consented again
+		consentMembersGroups, err := s.ProposalConsentMembers(ctx, tl.Number(), []util.ID{proposalID})
+		if err != nil {
 			return err
 		}
+		for _, member := range consentMembersGroups[proposalID] {
+			if err := s.deleteEdge(tl.Number(), edgeClassProposalConsent, member.ID, proposalID); err != nil {
+				return err
+			}
+		}
 
-	case ep.EventTypeCircleCoreRoleMemberUnset:
-		data := data.(*ep.EventCircleCoreRoleMemberUnset)
-		if err := s.deleteEdge(tl.Number(), edgeClassRoleMember, data.MemberID, data.CoreRoleID); err != nil {
+	case ep.EventTypeProposalObjected:
+		data := data.(*ep.EventProposalObjected)
+		proposalID, err := util.IDFromString(event.StreamID)
+		if err != nil {
 			return err
 		}
 
-	case ep.EventTypeTensionCreated:
-		data := data.(*ep.EventTensionCreated)
-		tensionID, err := util.IDFromString(event.StreamID)
+		proposal, err := s.Proposal(ctx, tl.Number(), proposalID)
 		if err != nil {
 			return err
 		}
+		if proposal == nil {
+			return errors.Errorf("proposal with id %s doesn't exist", proposalID)
+		}
 
-		tension := &models.Tension{
-			Title:       data.Title,
-			Description: data.Description,
-			Closed:      false,
+		objection := &models.ProposalObjection{
+			Reason: data.Reason,
+			Status: models.ProposalObjectionStatusOpen,
 		}
-		if err := s.newVertex(tl.Number(), tensionID, vertexClassTension, tension); err != nil {
+		if err := s.newVertex(tl.Number(), data.ObjectionID, vertexClassProposalObjection, objection); err != nil {
 			return err
 		}
-		if err := s.addEdge(tl.Number(), edgeClassMemberTension, tensionID, data.MemberID); err != nil {
+		if err := s.addEdge(tl.Number(), edgeClassProposalObjection, data.ObjectionID, proposalID); err != nil {
 			return err
 		}
-		if data.RoleID != nil {
-			if err := s.addEdge(tl.Number(), edgeClassRoleTension, tensionID, *data.RoleID); err != nil {
+		if err := s.addEdge(tl.Number(), edgeClassMemberObjection, data.ObjectionID, data.MemberID); err != nil {
+			return err
+		}
+		// an objecting member doesn't consent anymore
+		if err := s.deleteEdge(tl.Number(), edgeClassProposalConsent, data.MemberID, proposalID); err != nil {
+			return err
+		}
+
+		if proposal.Status != models.ProposalStatusObjected {
+			proposal.Status = models.ProposalStatusObjected
+			if err := s.updateVertex(tl.Number(), vertexClassProposal, proposalID, proposal); err != nil {
 				return err
 			}
 		}
 
-	case ep.EventTypeTensionUpdated:
-		data := data.(*ep.EventTensionUpdated)
-		tensionID, err := util.IDFromString(event.StreamID)
+	case ep.EventTypeProposalObjectionResolved, ep.EventTypeProposalObjectionWithdrawn:
+		proposalID, err := util.IDFromString(event.StreamID)
 		if err != nil {
 			return err
 		}
 
-		tension := &models.Tension{
-			Title:       data.Title,
-			Description: data.Description,
-			Closed:      false,
+		var objectionID util.ID
+		var resolution string
+		objectionStatus := models.ProposalObjectionStatusWithdrawn
+		switch data := data.(type) {
+		case *ep.EventProposalObjectionResolved:
+			objectionID = data.ObjectionID
+			resolution = data.Resolution
+			objectionStatus = models.ProposalObjectionStatusResolved
+		case *ep.EventProposalObjectionWithdrawn:
+			objectionID = data.ObjectionID
 		}
 
-		if err := s.updateVertex(tl.Number(), vertexClassTension, tensionID, tension); err != nil {
+		objection, err := s.ProposalObjection(ctx, tl.Number(), objectionID)
+		if err != nil {
 			return err
 		}
+		if objection == nil {
+			return errors.Errorf("proposal objection with id %s doesn't exist", objectionID)
+		}
 
-	case ep.EventTypeTensionRoleChanged:
-		data := data.(*ep.EventTensionRoleChanged)
-		tensionID, err := util.IDFromString(event.StreamID)
-		if err != nil {
+		objection.Resolution = resolution
+		objection.Status = objectionStatus
+		if err := s.updateVertex(tl.Number(), vertexClassProposalObjection, objectionID, objection); err != nil {
 			return err
 		}
 
-		if data.PrevRoleID != nil {
-			if err := s.deleteEdge(tl.Number(), edgeClassRoleTension, tensionID, *data.PrevRoleID); err != nil {
-				return err
+		// when all the objections are closed the proposal returns submitted
+		objectionsGroups, err := s.ProposalObjections(ctx, tl.Number(), []util.ID{proposalID})
+		if err != nil {
+			return err
+		}
+		open := false
+		for _, o := range objectionsGroups[proposalID] {
+			if o.Status == models.ProposalObjectionStatusOpen {
+				open = true
 			}
 		}
-		if data.RoleID != nil {
-			if err := s.addEdge(tl.Number(), edgeClassRoleTension, tensionID, *data.RoleID); err != nil {
+		if !open {
+			proposal, err := s.Proposal(ctx, tl.Number(), proposalID)
+			if err != nil {
+				return err
+			}
+			if proposal == nil {
+				return errors.Errorf("proposal with id %s doesn't exist", proposalID)
+			}
+			proposal.Status = models.ProposalStatusSubmitted
+			if err := s.updateVertex(tl.Number(), vertexClassProposal, proposalID, proposal); err != nil {
 				return err
 			}
 		}
 
-	case ep.EventTypeTensionClosed:
-		data := data.(*ep.EventTensionClosed)
-		tensionID, err := util.IDFromString(event.StreamID)
+	case ep.EventTypeProposalConsented:
+		data := data.(*ep.EventProposalConsented)
+		proposalID, err := util.IDFromString(event.StreamID)
 		if err != nil {
 			return err
 		}
 
-		tension, err := s.Tension(ctx, tl.Number(), tensionID)
+		if err := s.addEdge(tl.Number(), edgeClassProposalConsent, data.MemberID, proposalID); err != nil {
+			return err
+		}
+
+	case ep.EventTypeProposalSubmitted, ep.EventTypeProposalAccepted, ep.EventTypeProposalWithdrawn:
+		proposalID, err := util.IDFromString(event.StreamID)
 		if err != nil {
 			return err
 		}
-		if tension == nil {
-			return errors.Errorf("tension with id %s doesn't exist", tensionID)
+
+		proposal, err := s.Proposal(ctx, tl.Number(), proposalID)
+		if err != nil {
+			return err
+		}
+		if proposal == nil {
+			return errors.Errorf("proposal with id %s doesn't exist", proposalID)
 		}
 
-		tension.Closed = true
-		tension.CloseReason = data.Reason
-		if err := s.updateVertex(tl.Number(), vertexClassTension, tensionID, tension); err != nil {
+		switch ep.EventType(event.EventType) {
+		case ep.EventTypeProposalSubmitted:
+			proposal.Status = models.ProposalStatusSubmitted
+		case ep.EventTypeProposalAccepted:
+			proposal.Status = models.ProposalStatusAccepted
+		case ep.EventTypeProposalWithdrawn:
+			proposal.Status = models.ProposalStatusWithdrawn
+		}
+		if err := s.updateVertex(tl.Number(), vertexClassProposal, proposalID, proposal); err != nil {
 			return err
 		}
 
@@ -2846,10 +6313,11 @@ func (h *DBEventHandler) handleEvent(event *eventstore.StoredEvent, tx *db.Tx, s
 		}
 
 		member := &models.Member{
-			IsAdmin:  data.IsAdmin,
-			UserName: data.UserName,
-			FullName: data.FullName,
-			Email:    data.Email,
+			IsAdmin:          data.IsAdmin,
+			UserName:         data.UserName,
+			FullName:         data.FullName,
+			Email:            data.Email,
+			IsServiceAccount: data.IsServiceAccount,
 		}
 		if err := s.newVertex(tl.Number(), memberID, vertexClassMember, member); err != nil {
 			return err
@@ -2862,12 +6330,41 @@ func (h *DBEventHandler) handleEvent(event *eventstore.StoredEvent, tx *db.Tx, s
 			return err
 		}
 
+		curMember, err := s.Member(ctx, tl.Number(), memberID)
+		if err != nil {
+			return err
+		}
+		if curMember == nil {
+			return errors.Errorf("member with id %s doesn't exist", memberID)
+		}
+
 		member := &models.Member{
-			IsAdmin:  data.IsAdmin,
-			UserName: data.UserName,
-			FullName: data.FullName,
-			Email:    data.Email,
+			IsAdmin:          data.IsAdmin,
+			UserName:         data.UserName,
+			FullName:         data.FullName,
+			Email:            data.Email,
+			IsDeactivated:    curMember.IsDeactivated,
+			IsServiceAccount: curMember.IsServiceAccount,
+		}
+		if err := s.updateVertex(tl.Number(), vertexClassMember, memberID, member); err != nil {
+			return err
+		}
+
+	case ep.EventTypeMemberDeactivated, ep.EventTypeMemberReactivated:
+		memberID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		member, err := s.Member(ctx, tl.Number(), memberID)
+		if err != nil {
+			return err
 		}
+		if member == nil {
+			return errors.Errorf("member with id %s doesn't exist", memberID)
+		}
+
+		member.IsDeactivated = ep.EventType(event.EventType) == ep.EventTypeMemberDeactivated
 		if err := s.updateVertex(tl.Number(), vertexClassMember, memberID, member); err != nil {
 			return err
 		}
@@ -3079,6 +6576,14 @@ func (h *DBEventHandler) handleEvent(event *eventstore.StoredEvent, tx *db.Tx, s
 	case ep.EventTypeRoleAccountabilityDeleted:
 		//data := data.(*ep.EventRoleAccountabilityDeleted)
 
+	case ep.EventTypeRoleChecklistItemCreated:
+	case ep.EventTypeRoleChecklistItemUpdated:
+	case ep.EventTypeRoleChecklistItemDeleted:
+
+	case ep.EventTypeRoleMetricCreated:
+	case ep.EventTypeRoleMetricUpdated:
+	case ep.EventTypeRoleMetricDeleted:
+
 	case ep.EventTypeRoleAdditionalContentSet:
 		//data := data.(*ep.EventRoleAdditionalContentSet)
 
@@ -3216,6 +6721,85 @@ func (h *DBEventHandler) handleEvent(event *eventstore.StoredEvent, tx *db.Tx, s
 	case ep.EventTypeTensionClosed:
 		//data := data.(*ep.EventTensionClosed)
 
+	case ep.EventTypeTensionStatusChanged:
+	case ep.EventTypeTensionAssigneeChanged:
+	case ep.EventTypeTensionMeetingChanged:
+	case ep.EventTypeTensionResolved:
+
+	case ep.EventTypeProjectCreated:
+	case ep.EventTypeProjectUpdated:
+	case ep.EventTypeProjectMemberChanged:
+	case ep.EventTypeProjectStatusChanged:
+
+	case ep.EventTypeActionCreated:
+	case ep.EventTypeActionUpdated:
+	case ep.EventTypeActionMemberChanged:
+	case ep.EventTypeActionStatusChanged:
+
+	case ep.EventTypeMeetingCreated:
+	case ep.EventTypeMeetingChecklistItemReported:
+	case ep.EventTypeMeetingMetricReported:
+
+	case ep.EventTypeElectionCreated:
+	case ep.EventTypeElectionCandidateNominated:
+	case ep.EventTypeElectionCompleted:
+
+	case ep.EventTypeRoleTemplateCreated:
+	case ep.EventTypeRoleTemplateUpdated:
+	case ep.EventTypeRoleTemplateDeleted:
+	case ep.EventTypeRoleTemplateInstanceLinked:
+	case ep.EventTypeRoleTemplateInstanceUnlinked:
+
+	case ep.EventTypeWebhookCreated:
+	case ep.EventTypeWebhookUpdated:
+	case ep.EventTypeWebhookDeleted:
+	case ep.EventTypeWebhookDeliveryFailed:
+
+	case ep.EventTypeSessionCreated:
+	case ep.EventTypeSessionRefreshTokenIssued:
+	case ep.EventTypeSessionRevoked:
+
+	case ep.EventTypeAPITokenCreated:
+	case ep.EventTypeAPITokenRevoked:
+
+	case ep.EventTypeProposalCreated:
+	case ep.EventTypeProposalUpdated:
+	case ep.EventTypeProposalSubmitted:
+	case ep.EventTypeProposalAccepted:
+	case ep.EventTypeProposalWithdrawn:
+
+	case ep.EventTypeProposalObjected, ep.EventTypeProposalObjectionResolved, ep.EventTypeProposalObjectionWithdrawn, ep.EventTypeProposalConsented:
+		proposalID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+
+		proposalRoleGroups, err := s.ProposalRole(ctx, tl.Number(), []util.ID{proposalID})
+		if err != nil {
+			return err
+		}
+		proposalRole := proposalRoleGroups[proposalID]
+		// skip proposals whose circle doesn't exist anymore
+		if proposalRole == nil {
+			break
+		}
+
+		var roleEvent *models.RoleEvent
+		switch data := data.(type) {
+		case *ep.EventProposalObjected:
+			roleEvent = models.NewRoleEventProposalReviewed(tl.Number(), proposalRole.ID, proposalID, data.MemberID, models.ProposalReviewTypeObjection, &data.ObjectionID, data.Reason)
+		case *ep.EventProposalObjectionResolved:
+			roleEvent = models.NewRoleEventProposalReviewed(tl.Number(), proposalRole.ID, proposalID, data.MemberID, models.ProposalReviewTypeObjectionResolved, &data.ObjectionID, data.Resolution)
+		case *ep.EventProposalObjectionWithdrawn:
+			roleEvent = models.NewRoleEventProposalReviewed(tl.Number(), proposalRole.ID, proposalID, data.MemberID, models.ProposalReviewTypeObjectionWithdrawn, &data.ObjectionID, "")
+		case *ep.EventProposalConsented:
+			roleEvent = models.NewRoleEventProposalReviewed(tl.Number(), proposalRole.ID, proposalID, data.MemberID, models.ProposalReviewTypeConsent, nil, "")
+		}
+
+		if err := s.insertRoleEvent(roleEvent); err != nil {
+			return err
+		}
+
 	case ep.EventTypeMemberCreated:
 		//data := data.(*ep.EventMemberCreated)
 
@@ -3228,6 +6812,9 @@ func (h *DBEventHandler) handleEvent(event *eventstore.StoredEvent, tx *db.Tx, s
 	case ep.EventTypeMemberAvatarSet:
 		//data := data.(*ep.EventMemberAvatarSet)
 
+	case ep.EventTypeMemberDeactivated:
+	case ep.EventTypeMemberReactivated:
+
 	case ep.EventTypeMemberChangeCreateRequested:
 	case ep.EventTypeMemberChangeUpdateRequested:
 	case ep.EventTypeMemberChangeSetMatchUIDRequested:
@@ -3399,6 +6986,96 @@ func (s *DBListener) WaitTimeLineForGroupID(ctx context.Context, groupID util.ID
 	}
 }
 
+// WaitSequenceNumber waits for the readdb to apply the events up to the
+// provided sequence number
+func (s *DBListener) WaitSequenceNumber(ctx context.Context, sn int64) error {
+	l := s.lnf.NewListener()
+
+	if err := l.Listen("readdb"); err != nil {
+		return err
+	}
+	defer l.Close()
+
+	timeout := time.After(60 * time.Second)
+	for {
+		var curSn int64
+		err := s.db.Do(func(tx *db.Tx) error {
+			var err error
+			curSn, err = LastSequenceNumber(tx)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if curSn >= sn {
+			return nil
+		}
+		select {
+		case <-l.NotificationChannel():
+			continue
+
+		case <-time.After(1 * time.Second):
+			continue
+
+		case <-timeout:
+			return errors.Errorf("timeout waiting for sequence number: %d", sn)
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// WaitGroupID waits for the readdb to apply the events of the provided group.
+// Unlike WaitTimeLineForGroupID it also works for the events that don't create
+// a timeline (like the session ones)
+func (s *DBListener) WaitGroupID(ctx context.Context, groupID util.ID) error {
+	l := s.lnf.NewListener()
+
+	if err := l.Listen("readdb"); err != nil {
+		return err
+	}
+	defer l.Close()
+
+	timeout := time.After(60 * time.Second)
+	for {
+		var applied bool
+		err := s.db.Do(func(tx *db.Tx) error {
+			return tx.Do(func(tx *db.WrappedTx) error {
+				var sn int64
+				err := tx.QueryRow("select sequencenumber from sequencenumber where groupid = $1 limit 1", groupID.UUID).Scan(&sn)
+				if err == sql.ErrNoRows {
+					return nil
+				}
+				if err != nil {
+					return errors.Wrap(err, "failed to get group sequence number")
+				}
+				applied = true
+				return nil
+			})
+		})
+		if err != nil {
+			return err
+		}
+		if applied {
+			return nil
+		}
+		select {
+		case <-l.NotificationChannel():
+			continue
+
+		case <-time.After(1 * time.Second):
+			continue
+
+		case <-timeout:
+			return errors.Errorf("timeout waiting for groupID: %s", groupID)
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 func (s *DBListener) timeLineForGroupID(ctx context.Context, groupID util.ID) (*util.TimeLine, error) {
 	var tl *util.TimeLine
 	err := s.db.Do(func(tx *db.Tx) error {
@@ -0,0 +1,468 @@
+package readdb_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sorintlab/sircles/change"
+	"github.com/sorintlab/sircles/command"
+	"github.com/sorintlab/sircles/common"
+	"github.com/sorintlab/sircles/db"
+	"github.com/sorintlab/sircles/eventhandler"
+	"github.com/sorintlab/sircles/eventstore"
+	ln "github.com/sorintlab/sircles/listennotify"
+	"github.com/sorintlab/sircles/lock"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/readdb"
+	"github.com/sorintlab/sircles/util"
+)
+
+type testEnv struct {
+	tmpDir         string
+	readDB         *db.DB
+	esDB           *db.DB
+	es             *eventstore.EventStore
+	lnf            ln.ListenerFactory
+	nf             ln.NotifierFactory
+	readDBListener *readdb.DBListener
+	commandService *command.CommandService
+	rootRoleID     util.ID
+
+	stop   chan struct{}
+	endChs []chan struct{}
+}
+
+// setupTestEnv creates a sqlite readdb and event store with the readdb event
+// handler running and the root role
+func setupTestEnv(t *testing.T) *testEnv {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	readDB, err := db.NewDB("sqlite3", filepath.Join(tmpDir, "readdb"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	esDB, err := db.NewDB("sqlite3", filepath.Join(tmpDir, "esdb"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := readDB.Migrate("readdb", readdb.Migrations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := esDB.Migrate("eventstore", eventstore.Migrations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	localLN := ln.NewLocalListenNotify()
+	lnf := ln.NewLocalListenerFactory(localLN)
+	nf := ln.NewLocalNotifierFactory(localLN)
+
+	es := eventstore.NewEventStore(esDB, nf)
+	uidGenerator := &common.DefaultUidGenerator{}
+
+	env := &testEnv{
+		tmpDir:         tmpDir,
+		readDB:         readDB,
+		esDB:           esDB,
+		es:             es,
+		lnf:            lnf,
+		nf:             nf,
+		readDBListener: readdb.NewDBListener(readDB, lnf),
+		commandService: command.NewCommandService(tmpDir, readDB, es, uidGenerator, lnf, false),
+		stop:           make(chan struct{}),
+	}
+
+	for _, h := range []eventhandler.EventHandler{readdb.NewDBEventHandler(readDB, es, nf), eventhandler.NewMemberRequestHandler(es, uidGenerator)} {
+		endCh, err := eventhandler.RunEventHandler(h, env.stop, lnf, lock.NewLocalLockFactory(lock.NewLocalLocks()))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		env.endChs = append(env.endChs, endCh)
+	}
+
+	rootRoleID, groupID, err := env.commandService.SetupRootRole()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	env.rootRoleID = rootRoleID
+	if _, err := env.readDBListener.WaitTimeLineForGroupID(context.Background(), groupID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return env
+}
+
+func (env *testEnv) close() {
+	close(env.stop)
+	for _, endCh := range env.endChs {
+		<-endCh
+	}
+	env.readDB.Close()
+	env.esDB.Close()
+	os.RemoveAll(env.tmpDir)
+}
+
+// createMember creates a member without auth checks and waits for the readdb
+// to apply it
+func (env *testEnv) createMember(t *testing.T, userName string, isAdmin bool) util.ID {
+	ctx := context.Background()
+	res, groupID, err := env.commandService.CreateMemberInternal(ctx, &change.CreateMemberChange{
+		IsAdmin:  isAdmin,
+		UserName: userName,
+		FullName: userName,
+		Email:    fmt.Sprintf("%s@example.com", userName),
+		Password: "password",
+	}, false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := env.readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return *res.MemberID
+}
+
+// createRole creates a role in the root role as the provided admin member and
+// waits for the readdb to apply it
+func (env *testEnv) createRole(t *testing.T, adminID util.ID, name string) {
+	ctx := context.WithValue(context.Background(), "userid", adminID.String())
+	res, groupID, err := env.commandService.CircleCreateChildRole(ctx, env.rootRoleID, &change.CreateRoleChange{
+		RoleType:                    models.RoleTypeNormal,
+		Name:                        name,
+		Purpose:                     name + " purpose",
+		CreateDomainChanges:         []change.CreateDomainChange{{Description: name + " domain"}},
+		CreateAccountabilityChanges: []change.CreateAccountabilityChange{{Description: name + " accountability"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.HasErrors {
+		t.Fatalf("unexpected error: %v", res.GenericError)
+	}
+	if _, err := env.readDBListener.WaitTimeLineForGroupID(ctx, groupID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// waitApplied waits for the readdb to apply all the events in the event store
+// and returns the last event sequence number
+func (env *testEnv) waitApplied(t *testing.T) int64 {
+	lastSn, err := env.es.LastSequenceNumber()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := env.readDBListener.WaitSequenceNumber(context.Background(), lastSn); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return lastSn
+}
+
+// ignoredColumns are the columns with values generated when applying the
+// events that will differ between readdbs. The role events ids are internal
+// ids not reported by the api.
+var ignoredColumns = map[string]string{
+	"roleevent": "id",
+}
+
+// tableRows returns the sorted rows of all the readdb tables
+func tableRows(t *testing.T, rdb *db.DB) map[string][]string {
+	tables := map[string][]string{}
+	err := rdb.Do(func(tx *db.Tx) error {
+		return tx.Do(func(tx *db.WrappedTx) error {
+			for _, table := range readdb.Tables() {
+				rows, err := tx.Query(fmt.Sprintf("select * from %s", table))
+				if err != nil {
+					return err
+				}
+				cols, err := rows.Columns()
+				if err != nil {
+					rows.Close()
+					return err
+				}
+				tableRows := []string{}
+				for rows.Next() {
+					values := make([]interface{}, len(cols))
+					dest := make([]interface{}, len(cols))
+					for i := range values {
+						dest[i] = &values[i]
+					}
+					if err := rows.Scan(dest...); err != nil {
+						rows.Close()
+						return err
+					}
+					row := []string{}
+					for i, v := range values {
+						if ignoredColumns[table] == cols[i] {
+							continue
+						}
+						if b, ok := v.([]byte); ok {
+							v = string(b)
+						}
+						row = append(row, fmt.Sprintf("%s=%v", cols[i], v))
+					}
+					tableRows = append(tableRows, strings.Join(row, ","))
+				}
+				if err := rows.Err(); err != nil {
+					rows.Close()
+					return err
+				}
+				rows.Close()
+				sort.Strings(tableRows)
+				tables[table] = tableRows
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return tables
+}
+
+// checkSameReadDB checks that the two readdbs have the same tables contents
+func checkSameReadDB(t *testing.T, expectedDB, rdb *db.DB) {
+	expectedTables := tableRows(t, expectedDB)
+	tables := tableRows(t, rdb)
+	for _, table := range readdb.Tables() {
+		if !reflect.DeepEqual(expectedTables[table], tables[table]) {
+			t.Fatalf("table %s: expected rows:\n%s\ngot rows:\n%s", table, strings.Join(expectedTables[table], "\n"), strings.Join(tables[table], "\n"))
+		}
+	}
+}
+
+func TestWaitSequenceNumber(t *testing.T) {
+	env := setupTestEnv(t)
+	defer env.close()
+
+	lastSn, err := env.es.LastSequenceNumber()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lastSn == 0 {
+		t.Fatalf("expected some events in the event store")
+	}
+
+	// already applied sequence numbers return immediately
+	for _, sn := range []int64{1, lastSn} {
+		if err := env.readDBListener.WaitSequenceNumber(context.Background(), sn); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// not yet existing sequence numbers wait until the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := env.readDBListener.WaitSequenceNumber(ctx, lastSn+1); err != context.DeadlineExceeded {
+		t.Fatalf("expected error %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	// the wait ends when the readdb applies the event
+	errCh := make(chan error)
+	go func() {
+		errCh <- env.readDBListener.WaitSequenceNumber(context.Background(), lastSn+1)
+	}()
+
+	env.createMember(t, "user01", false)
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(30 * time.Second):
+		t.Fatalf("timeout waiting for sequence number %d", lastSn+1)
+	}
+}
+
+func TestRebuild(t *testing.T) {
+	env := setupTestEnv(t)
+	defer env.close()
+
+	adminID := env.createMember(t, "admin", true)
+	env.createMember(t, "user01", false)
+	env.createRole(t, adminID, "role01")
+	lastSn := env.waitApplied(t)
+
+	rebuiltDB, err := db.NewDB("sqlite3", filepath.Join(env.tmpDir, "rebuiltdb"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer rebuiltDB.Close()
+
+	var progressSn, progressLastSn int64
+	progress := func(sn, lastSn int64) {
+		if sn <= progressSn {
+			t.Fatalf("expected increasing sequence numbers, got %d after %d", sn, progressSn)
+		}
+		progressSn, progressLastSn = sn, lastSn
+	}
+
+	if err := readdb.Rebuild(rebuiltDB, env.es, progress); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if progressSn != lastSn || progressLastSn != lastSn {
+		t.Fatalf("expected progress %d/%d, got %d/%d", lastSn, lastSn, progressSn, progressLastSn)
+	}
+
+	checkSameReadDB(t, env.readDB, rebuiltDB)
+}
+
+func TestRebuildResume(t *testing.T) {
+	env := setupTestEnv(t)
+	defer env.close()
+
+	adminID := env.createMember(t, "admin", true)
+	prevLastSn := env.waitApplied(t)
+
+	rebuiltDB, err := db.NewDB("sqlite3", filepath.Join(env.tmpDir, "rebuiltdb"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer rebuiltDB.Close()
+
+	if err := readdb.Rebuild(rebuiltDB, env.es, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// events written after the rebuild
+	env.createMember(t, "user01", false)
+	env.createRole(t, adminID, "role01")
+	lastSn := env.waitApplied(t)
+
+	// calling rebuild again applies only the new events
+	appliedSns := []int64{}
+	progress := func(sn, lastSn int64) {
+		appliedSns = append(appliedSns, sn)
+	}
+	if err := readdb.Rebuild(rebuiltDB, env.es, progress); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(appliedSns) != 1 || appliedSns[0] != lastSn {
+		t.Fatalf("expected only events after sequence number %d applied up to %d, got progress %v", prevLastSn, lastSn, appliedSns)
+	}
+
+	checkSameReadDB(t, env.readDB, rebuiltDB)
+
+	// nothing to apply
+	appliedSns = []int64{}
+	if err := readdb.Rebuild(rebuiltDB, env.es, progress); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(appliedSns) != 0 {
+		t.Fatalf("expected no applied events, got progress %v", appliedSns)
+	}
+}
+
+// pgConnStringWithSearchPath returns the postgres connection string setting
+// the search_path connection parameter to the provided schema
+func pgConnStringWithSearchPath(connString, schema string) string {
+	if strings.Contains(connString, "://") {
+		sep := "?"
+		if strings.Contains(connString, "?") {
+			sep = "&"
+		}
+		return connString + sep + "search_path=" + schema
+	}
+	return connString + " search_path=" + schema
+}
+
+// TestMoveTables is executed only with DB_TYPE=postgres (with PG_CONNSTRING
+// like the graphql tests) since moving tables between schemas is postgres only
+func TestMoveTables(t *testing.T) {
+	if os.Getenv("DB_TYPE") != "postgres" {
+		t.Skip("skipping since DB_TYPE isn't postgres")
+	}
+	pgConnString := os.Getenv("PG_CONNSTRING")
+
+	env := setupTestEnv(t)
+	defer env.close()
+
+	env.createMember(t, "user01", false)
+	lastSn := env.waitApplied(t)
+
+	dbName := "movetables" + filepath.Base(env.tmpDir)
+	pgdb, err := sql.Open("postgres", fmt.Sprintf(pgConnString, "postgres"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer pgdb.Close()
+	if _, err := pgdb.Exec(fmt.Sprintf("create database %s", dbName)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() {
+		if _, err := pgdb.Exec(fmt.Sprintf("drop database %s", dbName)); err != nil {
+			t.Logf("unexpected error: %v", err)
+		}
+	}()
+
+	connString := fmt.Sprintf(pgConnString, dbName)
+	readDB, err := db.NewDB("postgres", connString)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer readDB.Close()
+
+	// the current readdb with only the first event applied
+	if err := readDB.Migrate("readdb", readdb.Migrations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = readDB.Do(func(tx *db.Tx) error {
+		return tx.Do(func(tx *db.WrappedTx) error {
+			if _, err := tx.Exec("insert into sequencenumber (sequencenumber) values (1)"); err != nil {
+				return err
+			}
+			_, err := tx.Exec("create schema shadow")
+			return err
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	shadowDB, err := db.NewDB("postgres", pgConnStringWithSearchPath(connString, "shadow"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer shadowDB.Close()
+	if err := readdb.Rebuild(shadowDB, env.es, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := readDB.Do(func(tx *db.Tx) error {
+		return readdb.MoveTables(tx, "shadow", "public")
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var sn int64
+	var shadowTables int
+	err = readDB.Do(func(tx *db.Tx) error {
+		var err error
+		if sn, err = readdb.LastSequenceNumber(tx); err != nil {
+			return err
+		}
+		return tx.Do(func(tx *db.WrappedTx) error {
+			return tx.QueryRow("select count(*) from information_schema.tables where table_schema = 'shadow'").Scan(&shadowTables)
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sn != lastSn {
+		t.Fatalf("expected last sequence number %d, got %d", lastSn, sn)
+	}
+	if shadowTables != 0 {
+		t.Fatalf("expected no tables in the shadow schema, got %d", shadowTables)
+	}
+}
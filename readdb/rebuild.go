@@ -0,0 +1,134 @@
+package readdb
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"github.com/sorintlab/sircles/db"
+	"github.com/sorintlab/sircles/eventstore"
+)
+
+const rebuildBatchSize = 100
+
+var createTableRegexp = regexp.MustCompile(`^\s*create table (?:if not exists )?([a-z_]+)`)
+
+// Tables returns the names of all the readdb tables (including the migration
+// table)
+func Tables() []string {
+	tables := []string{"migration_readdb"}
+	for _, m := range Migrations {
+		for _, stmt := range m.Stmts {
+			if match := createTableRegexp.FindStringSubmatch(stmt); match != nil {
+				tables = append(tables, match[1])
+			}
+		}
+	}
+	return tables
+}
+
+// RebuildProgressFunc is called after every applied events batch with the
+// sequence number of the last applied event and the event store last
+// sequence number when the rebuild started
+type RebuildProgressFunc func(sn, lastSn int64)
+
+// Rebuild creates the readdb schema in the provided db, that must not contain
+// a populated readdb, and populates it replaying all the events in the event
+// store. When called again on the same db it resumes from the last applied
+// event.
+func Rebuild(rdb *db.DB, es *eventstore.EventStore, progress RebuildProgressFunc) error {
+	if err := rdb.Migrate("readdb", Migrations); err != nil {
+		return errors.WithMessage(err, "failed to create readdb schema")
+	}
+
+	lastSn, err := es.LastSequenceNumber()
+	if err != nil {
+		return err
+	}
+
+	var sn int64
+	err = rdb.Do(func(tx *db.Tx) error {
+		var err error
+		sn, err = LastSequenceNumber(tx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	h := NewDBEventHandler(rdb, es, nil)
+
+	for {
+		events, err := es.GetAllEvents(sn+1, rebuildBatchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		err = rdb.Do(func(tx *db.Tx) error {
+			return h.applyEvents(tx, events)
+		})
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed to apply events after sequence number %d", sn))
+		}
+
+		sn = events[len(events)-1].SequenceNumber
+		if progress != nil {
+			progress(sn, lastSn)
+		}
+	}
+}
+
+// LastSequenceNumber returns the sequence number of the last event applied to
+// the readdb (0 if no event was applied)
+func LastSequenceNumber(tx *db.Tx) (int64, error) {
+	var sn int64
+	err := tx.Do(func(tx *db.WrappedTx) error {
+		return tx.QueryRow("select coalesce(max(sequencenumber), 0) from sequencenumber").Scan(&sn)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get last applied sequence number")
+	}
+	return sn, nil
+}
+
+// DropTables removes all the readdb tables
+func DropTables(tx *db.Tx) error {
+	return tx.Do(func(tx *db.WrappedTx) error {
+		for _, table := range Tables() {
+			if _, err := tx.Exec(fmt.Sprintf("drop table if exists %s", table)); err != nil {
+				return errors.Wrapf(err, "failed to drop table %s", table)
+			}
+		}
+		return nil
+	})
+}
+
+// MoveTables replaces the readdb tables in the toSchema postgres schema with
+// the ones in the fromSchema. Since postgres supports transactional ddl
+// statements, executing it inside a transaction will atomically swap the
+// tables.
+func MoveTables(tx *db.Tx, fromSchema, toSchema string) error {
+	if err := tx.Do(func(tx *db.WrappedTx) error {
+		_, err := tx.Exec(fmt.Sprintf("set local search_path to %s", toSchema))
+		return err
+	}); err != nil {
+		return errors.Wrap(err, "failed to set search path")
+	}
+
+	if err := DropTables(tx); err != nil {
+		return err
+	}
+
+	return tx.Do(func(tx *db.WrappedTx) error {
+		for _, table := range Tables() {
+			if _, err := tx.Exec(fmt.Sprintf("alter table %s.%s set schema %s", fromSchema, table, toSchema)); err != nil {
+				return errors.Wrapf(err, "failed to move table %s", table)
+			}
+		}
+		return nil
+	})
+}
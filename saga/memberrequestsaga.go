@@ -175,14 +175,15 @@ func (s *MemberRequestSaga) HandleEvent(event *eventstore.StoredEvent) ([]ep.Eve
 
 		log.Debugf("creating memberID %s", data.MemberID)
 		command := commands.NewCommand(commands.CommandTypeCreateMember, correlationID, causationID, util.NilID, &commands.CreateMember{
-			IsAdmin:        data.IsAdmin,
-			MatchUID:       data.MatchUID,
-			UserName:       data.UserName,
-			FullName:       data.FullName,
-			Email:          data.Email,
-			PasswordHash:   data.PasswordHash,
-			Avatar:         data.Avatar,
-			MemberChangeID: memberChangeID,
+			IsAdmin:          data.IsAdmin,
+			IsServiceAccount: data.IsServiceAccount,
+			MatchUID:         data.MatchUID,
+			UserName:         data.UserName,
+			FullName:         data.FullName,
+			Email:            data.Email,
+			PasswordHash:     data.PasswordHash,
+			Avatar:           data.Avatar,
+			MemberChangeID:   memberChangeID,
 		})
 
 		if _, _, err := aggregate.ExecCommand(command, m, s.es, s.uidGenerator); err != nil {
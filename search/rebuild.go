@@ -0,0 +1,240 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+
+	"github.com/sorintlab/sircles/db"
+	"github.com/sorintlab/sircles/eventstore"
+	"github.com/sorintlab/sircles/readdb"
+	"github.com/sorintlab/sircles/util"
+
+	"github.com/blevesearch/bleve"
+	"github.com/pkg/errors"
+)
+
+// RebuildIndex reindexes all the current members, roles and tensions from the
+// readdb.
+// The index is built in a new directory and then swapped with the one at
+// indexPath. The index is locked while opened so the sircles server must be
+// stopped.
+func RebuildIndex(ctx context.Context, db *db.DB, es *eventstore.EventStore, indexPath string) error {
+	newIndexPath := indexPath + ".rebuild"
+	oldIndexPath := indexPath + ".old"
+
+	for _, p := range []string{newIndexPath, oldIndexPath} {
+		if err := os.RemoveAll(p); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	s, err := OpenSearchEngine(db, es, newIndexPath)
+	if err != nil {
+		return err
+	}
+	if err := s.indexAll(ctx); err != nil {
+		s.Close()
+		return err
+	}
+	if err := s.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	log.Infof("swapping rebuilt index in %q", indexPath)
+	if err := os.Rename(indexPath, oldIndexPath); err != nil && !os.IsNotExist(err) {
+		return errors.WithStack(err)
+	}
+	if err := os.Rename(newIndexPath, indexPath); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.RemoveAll(oldIndexPath))
+}
+
+// indexAll indexes all the members, roles and tensions and saves the readdb
+// last applied event sequence number so the event handler will start from the
+// next event
+func (s *SearchEngine) indexAll(ctx context.Context) error {
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return errors.Wrap(err, "cannot create db transaction")
+	}
+	defer tx.Rollback()
+
+	eventSeqNumber, docs, err := s.documents(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	batch := s.index.NewBatch()
+	for id, doc := range docs {
+		log.Debugf("indexing document: %s", id)
+		batch.Index(id.String(), doc)
+		docJson, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		batch.SetInternal([]byte(id.String()), docJson)
+	}
+	if err := s.index.Batch(batch); err != nil {
+		return err
+	}
+
+	if err := s.setIndexVersion(indexVersion); err != nil {
+		return err
+	}
+	return s.setLastEventSeqNumber(eventSeqNumber)
+}
+
+// documents returns the readdb last applied event sequence number and the
+// search documents of all the members, roles and tensions at the current
+// timeline
+func (s *SearchEngine) documents(ctx context.Context, tx *db.Tx) (int64, map[util.ID]interface{}, error) {
+	eventSeqNumber, err := readdb.LastSequenceNumber(tx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "cannot create db transaction")
+	}
+
+	docs := map[util.ID]interface{}{}
+
+	curTlSeq := readDBService.CurTimeLine(ctx).Number()
+	if curTlSeq < 0 {
+		return eventSeqNumber, docs, nil
+	}
+
+	searchMembers, err := s.memberDocuments(ctx, readDBService, curTlSeq, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	for id, searchMember := range searchMembers {
+		docs[id] = searchMember
+	}
+
+	searchRoles, err := s.roleDocuments(ctx, readDBService, curTlSeq, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	for id, searchRole := range searchRoles {
+		docs[id] = searchRole
+	}
+
+	searchTensions, err := s.tensionDocuments(ctx, readDBService, curTlSeq, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	for id, searchTension := range searchTensions {
+		docs[id] = searchTension
+	}
+
+	return eventSeqNumber, docs, nil
+}
+
+// VerifyResult reports the differences between the index and the readdb
+type VerifyResult struct {
+	// IndexSeqNumber is the sequence number of the last event applied to the
+	// index
+	IndexSeqNumber int64
+	// ReadDBSeqNumber is the sequence number of the last event applied to the
+	// readdb
+	ReadDBSeqNumber int64
+	// Missing contains the ids of the members, roles and tensions not indexed
+	Missing []util.ID
+	// Stale contains the ids of the indexed documents that are different from
+	// the readdb state or don't exist anymore
+	Stale []util.ID
+}
+
+// Consistent reports if the index reflects the readdb state
+func (r *VerifyResult) Consistent() bool {
+	return len(r.Missing) == 0 && len(r.Stale) == 0
+}
+
+// Verify compares the indexed documents with the current readdb members,
+// roles and tensions
+func (s *SearchEngine) Verify(ctx context.Context) (*VerifyResult, error) {
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create db transaction")
+	}
+	defer tx.Rollback()
+
+	readDBSeqNumber, docs, err := s.documents(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	indexSeqNumber, err := s.lastEventSeqNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	indexedIDs, err := s.indexedIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &VerifyResult{
+		IndexSeqNumber:  indexSeqNumber,
+		ReadDBSeqNumber: readDBSeqNumber,
+	}
+
+	for id, doc := range docs {
+		if _, ok := indexedIDs[id.String()]; !ok {
+			res.Missing = append(res.Missing, id)
+			continue
+		}
+		docJson, err := json.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+		indexedDocJson, err := s.index.GetInternal([]byte(id.String()))
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(docJson, indexedDocJson) {
+			res.Stale = append(res.Stale, id)
+		}
+	}
+
+	for indexedID := range indexedIDs {
+		id, err := util.IDFromString(indexedID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "wrong indexed document id %q", indexedID)
+		}
+		if _, ok := docs[id]; !ok {
+			res.Stale = append(res.Stale, id)
+		}
+	}
+
+	sort.Sort(util.IDs(res.Missing))
+	sort.Sort(util.IDs(res.Stale))
+
+	return res, nil
+}
+
+// indexedIDs returns the ids of all the indexed documents
+func (s *SearchEngine) indexedIDs() (map[string]struct{}, error) {
+	count, err := s.index.DocCount()
+	if err != nil {
+		return nil, err
+	}
+
+	req := bleve.NewSearchRequestOptions(bleve.NewMatchAllQuery(), int(count), 0, false)
+	searchResults, err := s.index.Search(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]struct{}, len(searchResults.Hits))
+	for _, hit := range searchResults.Hits {
+		ids[hit.ID] = struct{}{}
+	}
+	return ids, nil
+}
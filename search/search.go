@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/binary"
 	"encoding/json"
-	"time"
+	"os"
 
 	"github.com/sorintlab/sircles/db"
 	ep "github.com/sorintlab/sircles/events"
@@ -18,40 +18,71 @@ import (
 	"github.com/blevesearch/bleve/analysis/token/lowercase"
 	regexpTokenizer "github.com/blevesearch/bleve/analysis/tokenizer/regexp"
 	"github.com/blevesearch/bleve/mapping"
+	bsearch "github.com/blevesearch/bleve/search"
 	"github.com/pkg/errors"
 )
 
 var log = slog.S()
 
+// indexVersion is the version of the indexed documents format. When it
+// changes the existing index documents are reindexed.
+const indexVersion = 3
+
+const (
+	// VisibilityPublic is the visibility of the documents visible to all the
+	// members. Private documents have the ids of the members that can see them
+	// as visibility.
+	VisibilityPublic = "public"
+)
+
+// SearchEngine keeps an index of the readdb members, roles and tensions. It's
+// an event handler that updates the index when new events are applied to the
+// readdb.
 type SearchEngine struct {
-	db *db.DB
-	es *eventstore.EventStore
+	db             *db.DB
+	es             *eventstore.EventStore
+	readDBListener readdb.ReadDBListener
 
 	index bleve.Index
 }
 
-func NewSearchEngine(db *db.DB, es *eventstore.EventStore, indexPath string) *SearchEngine {
+// NewSearchEngine opens (creating it if not existing) the index at indexPath.
+// The index must be kept updated running the search engine as an event
+// handler.
+func NewSearchEngine(db *db.DB, es *eventstore.EventStore, readDBListener readdb.ReadDBListener, indexPath string) (*SearchEngine, error) {
+	s, err := OpenSearchEngine(db, es, indexPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkIndexVersion(indexPath); err != nil {
+		s.Close()
+		return nil, err
+	}
+	s.readDBListener = readDBListener
+
+	return s, nil
+}
+
+// OpenSearchEngine opens (creating it if not existing) the index at
+// indexPath. It's used to directly operate on the index (rebuild, verify) and
+// cannot be used as an event handler.
+func OpenSearchEngine(db *db.DB, es *eventstore.EventStore, indexPath string) (*SearchEngine, error) {
 	mapping := buildIndexMapping()
 
 	index, err := createOpenIndex(indexPath, mapping)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	s := &SearchEngine{
+	return &SearchEngine{
 		db:    db,
 		es:    es,
 		index: index,
-	}
-
-	go func() {
-		for {
-			s.eventsPoller()
-			time.Sleep(10 * time.Second)
-		}
-	}()
+	}, nil
+}
 
-	return s
+func (s *SearchEngine) Close() error {
+	return s.index.Close()
 }
 
 func buildIndexMapping() mapping.IndexMapping {
@@ -82,11 +113,37 @@ func buildIndexMapping() mapping.IndexMapping {
 		panic(err)
 	}
 
+	err = indexMapping.AddCustomTokenizer("keyword",
+		map[string]interface{}{
+			"type":   regexpTokenizer.Name,
+			"regexp": `.+`,
+		})
+	if err != nil {
+		panic(err)
+	}
+
+	err = indexMapping.AddCustomAnalyzer("keyword",
+		map[string]interface{}{
+			"type":      custom.Name,
+			"tokenizer": "keyword",
+		})
+	if err != nil {
+		panic(err)
+	}
+
 	indexMapping.DefaultAnalyzer = "analyzer"
 
+	// Type, RoleType and Visibility are only used to filter results and
+	// for facets and must not be matched by the user provided query
+	keywordMapping := bleve.NewTextFieldMapping()
+	keywordMapping.Analyzer = "keyword"
+	keywordMapping.IncludeInAll = false
+
 	// ID is considered a document as it conta
-	indexMapping.DefaultMapping.AddFieldMappingsAt("Type", noIndexMapping)
-	indexMapping.DefaultMapping.AddFieldMappingsAt("RoleType", noIndexMapping)
+	indexMapping.DefaultMapping.AddFieldMappingsAt("Type", keywordMapping)
+	indexMapping.DefaultMapping.AddFieldMappingsAt("RoleType", keywordMapping)
+	indexMapping.DefaultMapping.AddFieldMappingsAt("Status", noIndexMapping)
+	indexMapping.DefaultMapping.AddFieldMappingsAt("Visibility", keywordMapping)
 
 	return indexMapping
 }
@@ -107,81 +164,166 @@ func createOpenIndex(path string, mapping mapping.IndexMapping) (bleve.Index, er
 	return index, nil
 }
 
-func (s *SearchEngine) eventsPoller() {
+func (s *SearchEngine) lastEventSeqNumber() (int64, error) {
 	eventSeqNumberBytes, err := s.index.GetInternal([]byte("lasteventseqnumber"))
 	if err != nil {
-		log.Errorf("cannot get last event sequence number: %+v", err)
-		return
+		return 0, err
 	}
 
 	eventSeqNumber := int64(0)
 	if eventSeqNumberBytes != nil {
 		eventSeqNumber = int64(binary.LittleEndian.Uint64(eventSeqNumberBytes))
 	}
+	return eventSeqNumber, nil
+}
+
+func (s *SearchEngine) setLastEventSeqNumber(eventSeqNumber int64) error {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, uint64(eventSeqNumber))
+	return s.index.SetInternal([]byte("lasteventseqnumber"), b)
+}
+
+func (s *SearchEngine) indexVersion() (int64, error) {
+	versionBytes, err := s.index.GetInternal([]byte("indexversion"))
+	if err != nil {
+		return 0, err
+	}
+
+	// indexes without a version were created with the first documents format
+	version := int64(1)
+	if versionBytes != nil {
+		version = int64(binary.LittleEndian.Uint64(versionBytes))
+	}
+	return version, nil
+}
+
+func (s *SearchEngine) setIndexVersion(version int64) error {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, uint64(version))
+	return s.index.SetInternal([]byte("indexversion"), b)
+}
+
+// checkIndexVersion recreates an empty index when the existing one was created
+// with a different documents format (and mapping, that is saved inside the
+// index) so the event handler will reindex everything
+func (s *SearchEngine) checkIndexVersion(indexPath string) error {
+	eventSeqNumber, err := s.lastEventSeqNumber()
+	if err != nil {
+		return err
+	}
+	version, err := s.indexVersion()
+	if err != nil {
+		return err
+	}
+	if eventSeqNumber == 0 || version == indexVersion {
+		return nil
+	}
+
+	log.Infof("index version %d different than current version %d, recreating it", version, indexVersion)
+	if err := s.index.Close(); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(indexPath); err != nil {
+		return errors.WithStack(err)
+	}
+	s.index, err = createOpenIndex(indexPath, buildIndexMapping())
+	return err
+}
+
+// Name returns the search engine event handler name. Since every sircles
+// instance keeps its own index, the search engine must be run with a per
+// process lock factory and not with the distributed one.
+func (s *SearchEngine) Name() string {
+	return "search"
+}
+
+// HandleEvents indexes the changes caused by the events not yet indexed. Since
+// the documents are built from the readdb state, the events are indexed only
+// after the readdb applied them.
+func (s *SearchEngine) HandleEvents() error {
+	if s.readDBListener == nil {
+		return errors.New("search engine not created as an event handler")
+	}
+
+	eventSeqNumber, err := s.lastEventSeqNumber()
+	if err != nil {
+		return errors.Wrap(err, "cannot get last event sequence number")
+	}
 
 	ctx := context.Background()
 	// if empty index, index the current state and start from the last sequence number
 	if eventSeqNumber == 0 {
-		eventSeqNumber, err = s.es.LastSequenceNumber()
+		if err := s.indexAll(ctx); err != nil {
+			return errors.Wrap(err, "failed to index")
+		}
+		eventSeqNumber, err = s.lastEventSeqNumber()
 		if err != nil {
-			log.Errorf("err: %+v", err)
-			return
+			return errors.Wrap(err, "cannot get last event sequence number")
 		}
-		s.indexMembers(ctx, nil)
-		s.indexRoles(ctx, nil)
 	}
 
 	for {
 		events, err := s.es.GetAllEvents(eventSeqNumber+1, 100)
 		if err != nil {
-			log.Errorf("cannot get events: %+v", err)
-			return
+			return errors.Wrap(err, "cannot get events")
 		}
 		if len(events) == 0 {
 			log.Debugf("no new events")
-			break
+			return nil
+		}
+
+		lastEventSeqNumber := events[len(events)-1].SequenceNumber
+		if err := s.readDBListener.WaitSequenceNumber(ctx, lastEventSeqNumber); err != nil {
+			return err
 		}
 
 		for _, event := range events {
 			log.Debugf("sequencenumber: %d", event.SequenceNumber)
-			eventj, err := json.Marshal(event)
-			if err != nil {
-				log.Errorf("failed to unmarshall events: %+v", err)
-				return
-			}
-			log.Debugf("eventj: %s", eventj)
-			eventSeqNumber = event.SequenceNumber
-
 			if err := s.HandlEvent(event); err != nil {
-				log.Errorf("failed to handle event: %+v", err)
-				return
+				return errors.Wrap(err, "failed to handle event")
 			}
 		}
+
+		eventSeqNumber = lastEventSeqNumber
+		if err := s.setLastEventSeqNumber(eventSeqNumber); err != nil {
+			return errors.Wrap(err, "failed to save last event sequence number")
+		}
 	}
+}
 
-	b := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b, uint64(eventSeqNumber))
-	if err := s.index.SetInternal([]byte("lasteventseqnumber"), b); err != nil {
-		log.Errorf("failed to save last event sequence number: %+v", err)
+// Lag returns the sequence number of the last indexed event and of the last
+// event in the event store
+func (s *SearchEngine) Lag() (int64, int64, error) {
+	indexedSeqNumber, err := s.lastEventSeqNumber()
+	if err != nil {
+		return 0, 0, err
+	}
+	lastSeqNumber, err := s.es.LastSequenceNumber()
+	if err != nil {
+		return 0, 0, err
 	}
+	return indexedSeqNumber, lastSeqNumber, nil
 }
 
 const (
-	RoleType   = "role"
-	MemberType = "member"
+	RoleType    = "role"
+	MemberType  = "member"
+	TensionType = "tension"
 )
 
 type Role struct {
-	Type             string
-	RoleType         string
-	Name             string
-	Purpose          string
-	Domains          []string
-	Accountabilities []string
-	RoleMemberEdge   struct {
+	Type              string
+	RoleType          string
+	Name              string
+	Purpose           string
+	Domains           []string
+	Accountabilities  []string
+	AdditionalContent string
+	RoleMemberEdge    struct {
 		Member Member
 		Focus  *string
 	}
+	Visibility []string
 }
 
 type Member struct {
@@ -191,6 +333,18 @@ type Member struct {
 	Email             string
 	MemberRoleEdges   []*MemberRoleEdge
 	MemberCircleEdges []*MemberCircleEdge
+	Visibility        []string
+}
+
+type Tension struct {
+	Type               string
+	Title              string
+	Description        string
+	Status             string
+	CloseReason        string
+	OutcomeDescription string
+	Role               *Role
+	Visibility         []string
 }
 
 type MemberRoleEdge struct {
@@ -221,6 +375,7 @@ func (s *SearchEngine) HandlEvent(event *eventstore.StoredEvent) error {
 	deleteRoles := []util.ID{}
 	reindexMembers := []util.ID{}
 	deleteMembers := []util.ID{}
+	reindexTensions := []util.ID{}
 
 	data, err := ep.UnmarshalData(event)
 	if err != nil {
@@ -241,18 +396,44 @@ func (s *SearchEngine) HandlEvent(event *eventstore.StoredEvent) error {
 		reindexRoles = append(reindexRoles, data.RoleID)
 
 	case ep.EventTypeRoleDomainCreated:
+		data := data.(*ep.EventRoleDomainCreated)
+		reindexRoles = append(reindexRoles, data.RoleID)
 
 	case ep.EventTypeRoleDomainUpdated:
+		data := data.(*ep.EventRoleDomainUpdated)
+		reindexRoles = append(reindexRoles, data.RoleID)
 
 	case ep.EventTypeRoleDomainDeleted:
+		data := data.(*ep.EventRoleDomainDeleted)
+		reindexRoles = append(reindexRoles, data.RoleID)
 
 	case ep.EventTypeRoleAccountabilityCreated:
+		data := data.(*ep.EventRoleAccountabilityCreated)
+		reindexRoles = append(reindexRoles, data.RoleID)
 
 	case ep.EventTypeRoleAccountabilityUpdated:
+		data := data.(*ep.EventRoleAccountabilityUpdated)
+		reindexRoles = append(reindexRoles, data.RoleID)
 
 	case ep.EventTypeRoleAccountabilityDeleted:
+		data := data.(*ep.EventRoleAccountabilityDeleted)
+		reindexRoles = append(reindexRoles, data.RoleID)
+
+	case ep.EventTypeRoleChecklistItemCreated:
+
+	case ep.EventTypeRoleChecklistItemUpdated:
+
+	case ep.EventTypeRoleChecklistItemDeleted:
+
+	case ep.EventTypeRoleMetricCreated:
+
+	case ep.EventTypeRoleMetricUpdated:
+
+	case ep.EventTypeRoleMetricDeleted:
 
 	case ep.EventTypeRoleAdditionalContentSet:
+		data := data.(*ep.EventRoleAdditionalContentSet)
+		reindexRoles = append(reindexRoles, data.RoleID)
 
 	case ep.EventTypeRoleChangedParent:
 
@@ -292,13 +473,61 @@ func (s *SearchEngine) HandlEvent(event *eventstore.StoredEvent) error {
 		data := data.(*ep.EventCircleCoreRoleMemberUnset)
 		reindexMembers = append(reindexMembers, data.MemberID)
 
-	case ep.EventTypeTensionCreated:
-
-	case ep.EventTypeTensionUpdated:
-
-	case ep.EventTypeTensionRoleChanged:
-
-	case ep.EventTypeTensionClosed:
+	case ep.EventTypeTensionCreated, ep.EventTypeTensionUpdated, ep.EventTypeTensionRoleChanged, ep.EventTypeTensionClosed, ep.EventTypeTensionStatusChanged, ep.EventTypeTensionResolved:
+		tensionID, err := util.IDFromString(event.StreamID)
+		if err != nil {
+			return err
+		}
+		reindexTensions = append(reindexTensions, tensionID)
+
+	case ep.EventTypeTensionAssigneeChanged:
+	case ep.EventTypeTensionMeetingChanged:
+
+	case ep.EventTypeProjectCreated:
+	case ep.EventTypeProjectUpdated:
+	case ep.EventTypeProjectMemberChanged:
+	case ep.EventTypeProjectStatusChanged:
+
+	case ep.EventTypeActionCreated:
+	case ep.EventTypeActionUpdated:
+	case ep.EventTypeActionMemberChanged:
+	case ep.EventTypeActionStatusChanged:
+
+	case ep.EventTypeMeetingCreated:
+	case ep.EventTypeMeetingChecklistItemReported:
+	case ep.EventTypeMeetingMetricReported:
+
+	case ep.EventTypeElectionCreated:
+	case ep.EventTypeElectionCandidateNominated:
+	case ep.EventTypeElectionCompleted:
+
+	case ep.EventTypeRoleTemplateCreated:
+	case ep.EventTypeRoleTemplateUpdated:
+	case ep.EventTypeRoleTemplateDeleted:
+	case ep.EventTypeRoleTemplateInstanceLinked:
+	case ep.EventTypeRoleTemplateInstanceUnlinked:
+
+	case ep.EventTypeWebhookCreated:
+	case ep.EventTypeWebhookUpdated:
+	case ep.EventTypeWebhookDeleted:
+	case ep.EventTypeWebhookDeliveryFailed:
+
+	case ep.EventTypeSessionCreated:
+	case ep.EventTypeSessionRefreshTokenIssued:
+	case ep.EventTypeSessionRevoked:
+
+	case ep.EventTypeAPITokenCreated:
+	case ep.EventTypeAPITokenRevoked:
+
+	case ep.EventTypeProposalCreated:
+	case ep.EventTypeProposalUpdated:
+	case ep.EventTypeProposalSubmitted:
+	case ep.EventTypeProposalObjected:
+	case ep.EventTypeProposalAccepted:
+	case ep.EventTypeProposalWithdrawn:
+	case ep.EventTypeProposalObjectionResolved:
+	case ep.EventTypeProposalObjectionWithdrawn:
+	case ep.EventTypeProposalConsented:
 
 	case ep.EventTypeMemberCreated:
 		memberID, err := util.IDFromString(event.StreamID)
@@ -318,6 +547,9 @@ func (s *SearchEngine) HandlEvent(event *eventstore.StoredEvent) error {
 
 	case ep.EventTypeMemberAvatarSet:
 
+	case ep.EventTypeMemberDeactivated:
+	case ep.EventTypeMemberReactivated:
+
 	case ep.EventTypeMemberChangeCreateRequested:
 	case ep.EventTypeMemberChangeUpdateRequested:
 	case ep.EventTypeMemberChangeSetMatchUIDRequested:
@@ -342,6 +574,11 @@ func (s *SearchEngine) HandlEvent(event *eventstore.StoredEvent) error {
 			return errors.Wrap(err, "indexing error")
 		}
 	}
+	if len(reindexTensions) > 0 {
+		if err := s.indexTensions(ctx, reindexTensions); err != nil {
+			return errors.Wrap(err, "indexing error")
+		}
+	}
 	if err := s.delete(deleteMembers); err != nil {
 		return errors.Wrap(err, "indexing error")
 	}
@@ -371,30 +608,55 @@ func (s *SearchEngine) indexMembers(ctx context.Context, ids []util.ID) error {
 		return nil
 	}
 
+	searchMembers, err := s.memberDocuments(ctx, readDBService, curTlSeq, ids)
+	if err != nil {
+		return err
+	}
+
+	batch := s.index.NewBatch()
+	for id, searchMember := range searchMembers {
+		log.Debugf("indexing member: %s", id)
+		batch.Index(id.String(), searchMember)
+		searchMemberJson, err := json.Marshal(searchMember)
+		if err != nil {
+			return err
+		}
+		batch.SetInternal([]byte(id.String()), searchMemberJson)
+	}
+	if err := s.index.Batch(batch); err != nil {
+		return err
+	}
+	return nil
+}
+
+// memberDocuments returns the search documents of the provided members (all
+// the members when ids is empty)
+func (s *SearchEngine) memberDocuments(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, ids []util.ID) (map[util.ID]*Member, error) {
 	searchMembers := map[util.ID]*Member{}
 
-	members, err := readDBService.MembersByIDs(context.Background(), curTlSeq, ids)
+	members, err := readDBService.MembersByIDs(ctx, curTlSeq, ids)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	memberIDs := []util.ID{}
 	for _, member := range members {
 		memberIDs = append(memberIDs, member.ID)
 
 		searchMembers[member.ID] = &Member{
-			Type:     MemberType,
-			UserName: member.UserName,
-			FullName: member.FullName,
-			Email:    member.Email,
+			Type:       MemberType,
+			UserName:   member.UserName,
+			FullName:   member.FullName,
+			Email:      member.Email,
+			Visibility: []string{VisibilityPublic},
 		}
 	}
 	memberRoleEdgeGroups, err := readDBService.MemberRoleEdges(ctx, curTlSeq, memberIDs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	memberCircleEdgeGroups, err := readDBService.MemberCircleEdges(ctx, curTlSeq, memberIDs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for id, searchMember := range searchMembers {
@@ -428,20 +690,7 @@ func (s *SearchEngine) indexMembers(ctx context.Context, ids []util.ID) error {
 		searchMember.MemberCircleEdges = mces
 	}
 
-	batch := s.index.NewBatch()
-	for id, searchMember := range searchMembers {
-		log.Debugf("indexing member: %s", id)
-		batch.Index(id.String(), searchMember)
-		searchMemberJson, err := json.Marshal(searchMember)
-		if err != nil {
-			return err
-		}
-		batch.SetInternal([]byte(id.String()), searchMemberJson)
-	}
-	if err := s.index.Batch(batch); err != nil {
-		return err
-	}
-	return nil
+	return searchMembers, nil
 }
 
 func (s *SearchEngine) indexRoles(ctx context.Context, ids []util.ID) error {
@@ -461,12 +710,37 @@ func (s *SearchEngine) indexRoles(ctx context.Context, ids []util.ID) error {
 		return nil
 	}
 
+	searchRoles, err := s.roleDocuments(ctx, readDBService, curTlSeq, ids)
+	if err != nil {
+		return err
+	}
+
+	batch := s.index.NewBatch()
+	for id, searchRole := range searchRoles {
+		log.Debugf("indexing role: %s", id)
+		batch.Index(id.String(), searchRole)
+
+		searchRoleJson, err := json.Marshal(searchRole)
+		if err != nil {
+			return err
+		}
+		batch.SetInternal([]byte(id.String()), searchRoleJson)
+	}
+	if err := s.index.Batch(batch); err != nil {
+		return err
+	}
+	return nil
+}
+
+// roleDocuments returns the search documents of the provided roles (all the
+// roles when ids is empty). Core roles aren't indexed.
+func (s *SearchEngine) roleDocuments(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, ids []util.ID) (map[util.ID]*Role, error) {
 	searchRoles := map[util.ID]*Role{}
 
 	// TODO(sgotti) retrieve roles in batches
-	roles, err := readDBService.Roles(context.Background(), curTlSeq, ids)
+	roles, err := readDBService.Roles(ctx, curTlSeq, ids)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	rolesIDs := []util.ID{}
@@ -476,11 +750,15 @@ func (s *SearchEngine) indexRoles(ctx context.Context, ids []util.ID) error {
 
 	rolesDomainsGroups, err := readDBService.RoleDomains(ctx, curTlSeq, rolesIDs)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	rolesAccountabilitiesGroups, err := readDBService.RoleDomains(ctx, curTlSeq, rolesIDs)
+	rolesAccountabilitiesGroups, err := readDBService.RoleAccountabilities(ctx, curTlSeq, rolesIDs)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	rolesAdditionalContent, err := readDBService.RolesAdditionalContent(ctx, curTlSeq, rolesIDs)
+	if err != nil {
+		return nil, err
 	}
 
 	for _, role := range roles {
@@ -489,10 +767,14 @@ func (s *SearchEngine) indexRoles(ctx context.Context, ids []util.ID) error {
 			continue
 		}
 		searchRoles[role.ID] = &Role{
-			Type:     RoleType,
-			RoleType: role.RoleType.String(),
-			Name:     role.Name,
-			Purpose:  role.Purpose,
+			Type:       RoleType,
+			RoleType:   role.RoleType.String(),
+			Name:       role.Name,
+			Purpose:    role.Purpose,
+			Visibility: []string{VisibilityPublic},
+		}
+		if additionalContent, ok := rolesAdditionalContent[role.ID]; ok {
+			searchRoles[role.ID].AdditionalContent = additionalContent.Content
 		}
 
 		domains := []string{}
@@ -507,16 +789,43 @@ func (s *SearchEngine) indexRoles(ctx context.Context, ids []util.ID) error {
 		}
 		searchRoles[role.ID].Accountabilities = accountabilities
 	}
+
+	return searchRoles, nil
+}
+
+func (s *SearchEngine) indexTensions(ctx context.Context, ids []util.ID) error {
+	log.Debugf("indexing tensions: %s", ids)
+	tx, err := s.db.NewTx()
+	if err != nil {
+		return errors.Wrap(err, "cannot create db transaction")
+	}
+	defer tx.Rollback()
+
+	readDBService, err := readdb.NewReadDBService(tx)
+	if err != nil {
+		return errors.Wrap(err, "cannot create db transaction")
+	}
+
+	curTlSeq := readDBService.CurTimeLine(ctx).Number()
+	if curTlSeq < 0 {
+		return nil
+	}
+
+	searchTensions, err := s.tensionDocuments(ctx, readDBService, curTlSeq, ids)
+	if err != nil {
+		return err
+	}
+
 	batch := s.index.NewBatch()
-	for id, searchRole := range searchRoles {
-		log.Debugf("indexing role: %s", id)
-		batch.Index(id.String(), searchRole)
+	for id, searchTension := range searchTensions {
+		log.Debugf("indexing tension: %s", id)
+		batch.Index(id.String(), searchTension)
 
-		searchRoleJson, err := json.Marshal(searchRole)
+		searchTensionJson, err := json.Marshal(searchTension)
 		if err != nil {
 			return err
 		}
-		batch.SetInternal([]byte(id.String()), searchRoleJson)
+		batch.SetInternal([]byte(id.String()), searchTensionJson)
 	}
 	if err := s.index.Batch(batch); err != nil {
 		return err
@@ -524,18 +833,143 @@ func (s *SearchEngine) indexRoles(ctx context.Context, ids []util.ID) error {
 	return nil
 }
 
-func (s *SearchEngine) Search(searchString string) (*bleve.SearchResult, error) {
+// tensionDocuments returns the search documents of the provided tensions (all
+// the tensions when ids is empty).
+// Like in the readdb, a tension associated with a role is visible to all the
+// members while a tension without a role is visible only to the member that
+// created it.
+func (s *SearchEngine) tensionDocuments(ctx context.Context, readDBService readdb.ReadDBService, curTlSeq util.TimeLineNumber, ids []util.ID) (map[util.ID]*Tension, error) {
+	searchTensions := map[util.ID]*Tension{}
+
+	tensions, err := readDBService.Tensions(ctx, curTlSeq, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	tensionsIDs := []util.ID{}
+	for _, t := range tensions {
+		tensionsIDs = append(tensionsIDs, t.ID)
+	}
+
+	tensionMemberGroups, err := readDBService.TensionMember(ctx, curTlSeq, tensionsIDs)
+	if err != nil {
+		return nil, err
+	}
+	tensionRoleGroups, err := readDBService.TensionRole(ctx, curTlSeq, tensionsIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tension := range tensions {
+		searchTension := &Tension{
+			Type:               TensionType,
+			Title:              tension.Title,
+			Description:        tension.Description,
+			Status:             tension.Status.String(),
+			CloseReason:        tension.CloseReason,
+			OutcomeDescription: tension.OutcomeDescription,
+		}
+
+		if role, ok := tensionRoleGroups[tension.ID]; ok {
+			searchTension.Role = &Role{
+				Type:     RoleType,
+				RoleType: role.RoleType.String(),
+				Name:     role.Name,
+			}
+			searchTension.Visibility = []string{VisibilityPublic}
+		} else if member, ok := tensionMemberGroups[tension.ID]; ok {
+			searchTension.Visibility = []string{member.ID.String()}
+		} else {
+			// not visible to anyone
+			searchTension.Visibility = []string{}
+		}
+
+		searchTensions[tension.ID] = searchTension
+	}
+
+	return searchTensions, nil
+}
+
+// defaultSearchSize is the default number of returned hits
+const defaultSearchSize = 10
+
+// SearchOptions are the search options
+type SearchOptions struct {
+	// Types restricts the search to the provided document types (RoleType,
+	// MemberType, TensionType). All the types when empty.
+	Types []string
+	// From is the number of hits to skip
+	From int
+	// Size is the max number of hits to return (defaultSearchSize when 0)
+	Size int
+}
+
+// SearchResult is the result of a search
+type SearchResult struct {
+	// Total is the total number of matching documents
+	Total uint64
+	Hits  []*SearchHit
+
+	// TypeFacets are the matching documents counts by document type
+	TypeFacets []*SearchFacet
+	// RoleTypeFacets are the matching roles counts by role type
+	RoleTypeFacets []*SearchFacet
+}
+
+// SearchHit is a matching document
+type SearchHit struct {
+	ID util.ID
+	// Type is the document type (RoleType, MemberType or TensionType)
+	Type  string
+	Score float64
+	// Fragments are the highlighted fragments of the matching fields
+	Fragments map[string][]string
+}
+
+// SearchFacet is the count of the matching documents with a field term
+type SearchFacet struct {
+	Term  string
+	Count int
+}
+
+// Search searches the documents matching searchString and visible to the
+// provided member
+func (s *SearchEngine) Search(searchString string, memberID util.ID, opts *SearchOptions) (*SearchResult, error) {
+	if opts == nil {
+		opts = &SearchOptions{}
+	}
+	size := opts.Size
+	if size <= 0 {
+		size = defaultSearchSize
+	}
+
 	pquery := bleve.NewPrefixQuery(searchString)
 	mquery := bleve.NewMatchQuery(searchString)
 	mquery.SetFuzziness(1)
 
+	publicQuery := bleve.NewTermQuery(VisibilityPublic)
+	publicQuery.SetField("Visibility")
+	memberQuery := bleve.NewTermQuery(memberID.String())
+	memberQuery.SetField("Visibility")
+
 	cq := bleve.NewBooleanQuery()
-	cq.AddShould(pquery, mquery)
+	cq.AddMust(bleve.NewDisjunctionQuery(pquery, mquery))
+	cq.AddMust(bleve.NewDisjunctionQuery(publicQuery, memberQuery))
+
+	if len(opts.Types) > 0 {
+		tq := bleve.NewDisjunctionQuery()
+		for _, t := range opts.Types {
+			typeQuery := bleve.NewTermQuery(t)
+			typeQuery.SetField("Type")
+			tq.AddQuery(typeQuery)
+		}
+		cq.AddMust(tq)
+	}
 
-	req := bleve.NewSearchRequest(cq)
-	req.Fields = []string{"*"}
+	req := bleve.NewSearchRequestOptions(cq, size, opts.From, false)
 	req.Highlight = bleve.NewHighlight()
-	req.IncludeLocations = true
+	req.AddFacet("types", bleve.NewFacetRequest("Type", 10))
+	req.AddFacet("roletypes", bleve.NewFacetRequest("RoleType", 10))
 
 	searchResults, err := s.index.Search(req)
 	if err != nil {
@@ -543,18 +977,57 @@ func (s *SearchEngine) Search(searchString string) (*bleve.SearchResult, error)
 	}
 	log.Debugf("searchResult: %s", searchResults)
 
+	res := &SearchResult{
+		Total:          searchResults.Total,
+		TypeFacets:     searchFacets(searchResults.Facets["types"]),
+		RoleTypeFacets: searchFacets(searchResults.Facets["roletypes"]),
+	}
+
 	for _, hit := range searchResults.Hits {
-		_, err := s.index.GetInternal([]byte(hit.ID))
-		if err != nil {
+		docJson, err := s.index.GetInternal([]byte(hit.ID))
+		if err != nil || docJson == nil {
 			log.Errorf("failed to get source doc, skipping hit")
 			continue
 		}
-		for field, termLoc := range hit.Locations {
-			for term, locs := range termLoc {
-				log.Debugf("field: %s, term: %s, loc: %+#v", field, term, locs)
+		var doc struct {
+			Type string
+		}
+		if err := json.Unmarshal(docJson, &doc); err != nil {
+			log.Errorf("failed to unmarshal source doc, skipping hit: %+v", err)
+			continue
+		}
+		id, err := util.IDFromString(hit.ID)
+		if err != nil {
+			log.Errorf("wrong document id, skipping hit: %+v", err)
+			continue
+		}
+		// remove the fragments of the fields used only for filtering
+		fragments := map[string][]string{}
+		for field, fieldFragments := range hit.Fragments {
+			switch field {
+			case "Type", "RoleType", "Visibility":
+				continue
 			}
+			fragments[field] = fieldFragments
 		}
+		res.Hits = append(res.Hits, &SearchHit{
+			ID:        id,
+			Type:      doc.Type,
+			Score:     hit.Score,
+			Fragments: fragments,
+		})
 	}
 
-	return searchResults, nil
+	return res, nil
+}
+
+func searchFacets(facetResult *bsearch.FacetResult) []*SearchFacet {
+	facets := []*SearchFacet{}
+	if facetResult == nil {
+		return facets
+	}
+	for _, term := range facetResult.Terms {
+		facets = append(facets, &SearchFacet{Term: term.Term, Count: term.Count})
+	}
+	return facets
 }
@@ -0,0 +1,599 @@
+package search
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sorintlab/sircles/change"
+	"github.com/sorintlab/sircles/command"
+	"github.com/sorintlab/sircles/common"
+	"github.com/sorintlab/sircles/db"
+	"github.com/sorintlab/sircles/eventhandler"
+	"github.com/sorintlab/sircles/eventstore"
+	ln "github.com/sorintlab/sircles/listennotify"
+	"github.com/sorintlab/sircles/lock"
+	"github.com/sorintlab/sircles/models"
+	"github.com/sorintlab/sircles/readdb"
+	"github.com/sorintlab/sircles/util"
+
+	"github.com/satori/go.uuid"
+)
+
+type testEnv struct {
+	ctx            context.Context
+	tmpDir         string
+	readDB         *db.DB
+	esDB           *db.DB
+	es             *eventstore.EventStore
+	lnf            ln.ListenerFactory
+	readDBListener readdb.ReadDBListener
+	commandService *command.CommandService
+	rootRoleID     util.ID
+	// adminID is the id of the admin member used as the calling member
+	adminID util.ID
+
+	stop   chan struct{}
+	endChs []chan struct{}
+	// closers are called after the event handlers are stopped
+	closers []func() error
+}
+
+// setupTestEnv creates a sqlite readdb and event store with the readdb event
+// handler running, the root role and an admin member used as the calling
+// member
+func setupTestEnv(t *testing.T) *testEnv {
+	ctx := context.Background()
+
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	readDB, err := db.NewDB("sqlite3", filepath.Join(tmpDir, "readdb"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	esDB, err := db.NewDB("sqlite3", filepath.Join(tmpDir, "esdb"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := readDB.Migrate("readdb", readdb.Migrations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := esDB.Migrate("eventstore", eventstore.Migrations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	localLN := ln.NewLocalListenNotify()
+	lnf := ln.NewLocalListenerFactory(localLN)
+	nf := ln.NewLocalNotifierFactory(localLN)
+
+	es := eventstore.NewEventStore(esDB, nf)
+	uidGenerator := &common.DefaultUidGenerator{}
+
+	env := &testEnv{
+		ctx:            ctx,
+		tmpDir:         tmpDir,
+		readDB:         readDB,
+		esDB:           esDB,
+		es:             es,
+		lnf:            lnf,
+		readDBListener: readdb.NewDBListener(readDB, lnf),
+		commandService: command.NewCommandService(tmpDir, readDB, es, uidGenerator, lnf, false),
+		stop:           make(chan struct{}),
+	}
+
+	env.runEventHandler(t, readdb.NewDBEventHandler(readDB, es, nf))
+	env.runEventHandler(t, eventhandler.NewMemberRequestHandler(es, uidGenerator))
+
+	rootRoleID, groupID, err := env.commandService.SetupRootRole()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	env.waitGroupID(t, groupID)
+	env.rootRoleID = rootRoleID
+
+	res, groupID, err := env.commandService.CreateMemberInternal(ctx, &change.CreateMemberChange{
+		IsAdmin:  true,
+		UserName: "admin",
+		FullName: "Admin",
+		Email:    "admin@example.com",
+		Password: "password",
+	}, false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	env.waitGroupID(t, groupID)
+	env.adminID = *res.MemberID
+	env.ctx = context.WithValue(ctx, "userid", res.MemberID.String())
+
+	return env
+}
+
+func (env *testEnv) close() {
+	close(env.stop)
+	for _, endCh := range env.endChs {
+		<-endCh
+	}
+	for _, closer := range env.closers {
+		closer()
+	}
+	env.readDB.Close()
+	env.esDB.Close()
+	os.RemoveAll(env.tmpDir)
+}
+
+// runEventHandler runs the event handler with its own local lock like the
+// server does for the search engine
+func (env *testEnv) runEventHandler(t *testing.T, h eventhandler.EventHandler) {
+	endCh, err := eventhandler.RunEventHandler(h, env.stop, env.lnf, lock.NewLocalLockFactory(lock.NewLocalLocks()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	env.endChs = append(env.endChs, endCh)
+}
+
+func (env *testEnv) waitGroupID(t *testing.T, groupID util.ID) {
+	if _, err := env.readDBListener.WaitTimeLineForGroupID(env.ctx, groupID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func (env *testEnv) createRole(t *testing.T, parentRoleID util.ID, c *change.CreateRoleChange) util.ID {
+	res, groupID, err := env.commandService.CircleCreateChildRole(env.ctx, parentRoleID, c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	env.waitGroupID(t, groupID)
+	return *res.RoleID
+}
+
+func (env *testEnv) createMember(t *testing.T, userName string) util.ID {
+	res, groupID, err := env.commandService.CreateMemberInternal(env.ctx, &change.CreateMemberChange{
+		UserName: userName,
+		FullName: userName,
+		Email:    userName + "@example.com",
+		Password: "password",
+	}, false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	env.waitGroupID(t, groupID)
+	return *res.MemberID
+}
+
+func (env *testEnv) createTension(t *testing.T, c *change.CreateTensionChange) util.ID {
+	res, groupID, err := env.commandService.CreateTension(env.ctx, c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.HasErrors {
+		t.Fatalf("unexpected error: %v", res.GenericError)
+	}
+	env.waitGroupID(t, groupID)
+	return *res.TensionID
+}
+
+// waitIndexed waits for the search engine to index all the events in the
+// event store
+func waitIndexed(t *testing.T, s *SearchEngine) {
+	timeout := time.After(30 * time.Second)
+	for {
+		indexedSeqNumber, lastSeqNumber, err := s.Lag()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if indexedSeqNumber >= lastSeqNumber {
+			return
+		}
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-timeout:
+			t.Fatalf("timeout waiting for the search engine to index event %d (indexed: %d)", lastSeqNumber, indexedSeqNumber)
+		}
+	}
+}
+
+func searchIDs(t *testing.T, s *SearchEngine, searchString string, opts *SearchOptions) []util.ID {
+	res, err := s.Search(searchString, util.NilID, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ids := []util.ID{}
+	for _, hit := range res.Hits {
+		ids = append(ids, hit.ID)
+	}
+	return ids
+}
+
+// checkIDs checks that ids contains all and only the expected ids in any
+// order
+func checkIDs(t *testing.T, ids []util.ID, expectedIDs ...util.ID) {
+	if len(ids) != len(expectedIDs) {
+		t.Fatalf("expected ids %v, got %v", expectedIDs, ids)
+	}
+	ids = append([]util.ID{}, ids...)
+	expectedIDs = append([]util.ID{}, expectedIDs...)
+	sort.Sort(util.IDs(ids))
+	sort.Sort(util.IDs(expectedIDs))
+	for i, id := range ids {
+		if id != expectedIDs[i] {
+			t.Fatalf("expected ids %v, got %v", expectedIDs, ids)
+		}
+	}
+}
+
+func TestSearchEngineHandleEvents(t *testing.T) {
+	env := setupTestEnv(t)
+	defer env.close()
+
+	// every instance keeps its own index and must update it
+	engines := []*SearchEngine{}
+	for _, name := range []string{"index01", "index02"} {
+		s, err := NewSearchEngine(env.readDB, env.es, env.readDBListener, filepath.Join(env.tmpDir, name))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		env.closers = append(env.closers, s.Close)
+		env.runEventHandler(t, s)
+		engines = append(engines, s)
+	}
+
+	roleID := env.createRole(t, env.rootRoleID, &change.CreateRoleChange{
+		RoleType: models.RoleTypeNormal,
+		Name:     "marketing",
+		Purpose:  "grow the brand",
+	})
+
+	for _, s := range engines {
+		waitIndexed(t, s)
+		checkIDs(t, searchIDs(t, s, "marketing", nil), roleID)
+		checkIDs(t, searchIDs(t, s, "brand", nil), roleID)
+	}
+
+	res, groupID, err := env.commandService.CircleDeleteChildRole(env.ctx, env.rootRoleID, &change.DeleteRoleChange{ID: roleID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.HasErrors {
+		t.Fatalf("unexpected error: %v", res.GenericError)
+	}
+	env.waitGroupID(t, groupID)
+
+	for _, s := range engines {
+		waitIndexed(t, s)
+		checkIDs(t, searchIDs(t, s, "marketing", nil))
+	}
+}
+
+func TestSearchEngineHandleEventsNoListener(t *testing.T) {
+	env := setupTestEnv(t)
+	defer env.close()
+
+	s, err := OpenSearchEngine(env.readDB, env.es, filepath.Join(env.tmpDir, "index"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer s.Close()
+
+	if err := s.HandleEvents(); err == nil {
+		t.Fatalf("expected error handling events without a readdb listener")
+	}
+}
+
+func TestSearchEngineIndexRoleContent(t *testing.T) {
+	env := setupTestEnv(t)
+	defer env.close()
+
+	s, err := NewSearchEngine(env.readDB, env.es, env.readDBListener, filepath.Join(env.tmpDir, "index"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	env.closers = append(env.closers, s.Close)
+	env.runEventHandler(t, s)
+
+	// additional content can be set only on circles
+	roleID := env.createRole(t, env.rootRoleID, &change.CreateRoleChange{
+		RoleType:                    models.RoleTypeCircle,
+		Name:                        "office",
+		Purpose:                     "a working office",
+		CreateDomainChanges:         []change.CreateDomainChange{{Description: "coffee machine"}},
+		CreateAccountabilityChanges: []change.CreateAccountabilityChange{{Description: "ordering stationery"}},
+	})
+
+	res, groupID, err := env.commandService.SetRoleAdditionalContent(env.ctx, roleID, "printers troubleshooting")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.HasErrors {
+		t.Fatalf("unexpected error: %v", res.GenericError)
+	}
+	env.waitGroupID(t, groupID)
+
+	waitIndexed(t, s)
+	for _, searchString := range []string{"coffee", "stationery", "printers"} {
+		checkIDs(t, searchIDs(t, s, searchString, nil), roleID)
+	}
+
+	// the role is reindexed when its content changes
+	res, groupID, err = env.commandService.SetRoleAdditionalContent(env.ctx, roleID, "meeting rooms booking")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.HasErrors {
+		t.Fatalf("unexpected error: %v", res.GenericError)
+	}
+	env.waitGroupID(t, groupID)
+
+	waitIndexed(t, s)
+	checkIDs(t, searchIDs(t, s, "printers", nil))
+	checkIDs(t, searchIDs(t, s, "booking", nil), roleID)
+}
+
+func TestSearchEngineIndexTensions(t *testing.T) {
+	env := setupTestEnv(t)
+	defer env.close()
+
+	s, err := NewSearchEngine(env.readDB, env.es, env.readDBListener, filepath.Join(env.tmpDir, "index"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	env.closers = append(env.closers, s.Close)
+	env.runEventHandler(t, s)
+
+	memberID := env.createMember(t, "user01")
+
+	// the admin must be a circle member to create a tension in the circle
+	res, groupID, err := env.commandService.CircleAddDirectMember(env.ctx, env.rootRoleID, env.adminID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.HasErrors {
+		t.Fatalf("unexpected error: %v", res.GenericError)
+	}
+	env.waitGroupID(t, groupID)
+
+	roleTensionID := env.createTension(t, &change.CreateTensionChange{
+		Title:       "broken printer",
+		Description: "the printer is jammed",
+		RoleID:      &env.rootRoleID,
+	})
+	// tensions without a role are visible only to their member
+	memberTensionID := env.createTension(t, &change.CreateTensionChange{
+		Title:       "printer budget",
+		Description: "buy a new one",
+	})
+
+	waitIndexed(t, s)
+
+	opts := &SearchOptions{Types: []string{TensionType}}
+	for _, tt := range []struct {
+		memberID    util.ID
+		expectedIDs []util.ID
+	}{
+		{memberID: env.adminID, expectedIDs: []util.ID{roleTensionID, memberTensionID}},
+		{memberID: memberID, expectedIDs: []util.ID{roleTensionID}},
+	} {
+		res, err := s.Search("printer", tt.memberID, opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids := []util.ID{}
+		for _, hit := range res.Hits {
+			if hit.Type != TensionType {
+				t.Fatalf("expected hit of type %q, got %q", TensionType, hit.Type)
+			}
+			ids = append(ids, hit.ID)
+		}
+		checkIDs(t, ids, tt.expectedIDs...)
+	}
+
+	// the tension is reindexed when updated
+	ures, groupID, err := env.commandService.UpdateTension(env.ctx, &change.UpdateTensionChange{
+		ID:          roleTensionID,
+		Title:       "broken scanner",
+		Description: "the scanner is jammed",
+		RoleID:      &env.rootRoleID,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ures.HasErrors {
+		t.Fatalf("unexpected error: %v", ures.GenericError)
+	}
+	env.waitGroupID(t, groupID)
+
+	waitIndexed(t, s)
+	checkIDs(t, searchIDs(t, s, "scanner", opts), roleTensionID)
+	res2, err := s.Search("jammed", memberID, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res2.Hits) != 1 || res2.Hits[0].ID != roleTensionID {
+		t.Fatalf("expected tension %s, got hits: %v", roleTensionID, res2.Hits)
+	}
+}
+
+func TestSearchTypedResults(t *testing.T) {
+	env := setupTestEnv(t)
+	defer env.close()
+
+	s, err := NewSearchEngine(env.readDB, env.es, env.readDBListener, filepath.Join(env.tmpDir, "index"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	env.closers = append(env.closers, s.Close)
+	env.runEventHandler(t, s)
+
+	roleID := env.createRole(t, env.rootRoleID, &change.CreateRoleChange{
+		RoleType: models.RoleTypeNormal,
+		Name:     "marketing",
+		Purpose:  "brand awareness",
+	})
+	circleID := env.createRole(t, env.rootRoleID, &change.CreateRoleChange{
+		RoleType: models.RoleTypeCircle,
+		Name:     "marketing circle",
+		Purpose:  "growth",
+	})
+	tensionID := env.createTension(t, &change.CreateTensionChange{
+		Title: "marketing budget",
+	})
+
+	waitIndexed(t, s)
+
+	res, err := s.Search("marketing", env.adminID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Total != 3 {
+		t.Fatalf("expected %d total hits, got %d", 3, res.Total)
+	}
+	hitTypes := map[util.ID]string{}
+	for _, hit := range res.Hits {
+		hitTypes[hit.ID] = hit.Type
+		if hit.Score <= 0 {
+			t.Fatalf("expected positive score for hit %s, got %f", hit.ID, hit.Score)
+		}
+		// only the user visible fields are highlighted
+		if len(hit.Fragments) == 0 {
+			t.Fatalf("expected fragments for hit %s", hit.ID)
+		}
+		for field, fragments := range hit.Fragments {
+			switch field {
+			case "Type", "RoleType", "Visibility":
+				t.Fatalf("unexpected fragments for field %q", field)
+			}
+			for _, fragment := range fragments {
+				if !strings.Contains(fragment, "<mark>marketing</mark>") {
+					t.Fatalf("expected highlighted fragment, got %q", fragment)
+				}
+			}
+		}
+	}
+	expectedHitTypes := map[util.ID]string{roleID: RoleType, circleID: RoleType, tensionID: TensionType}
+	if !reflect.DeepEqual(hitTypes, expectedHitTypes) {
+		t.Fatalf("expected hits %v, got %v", expectedHitTypes, hitTypes)
+	}
+	checkFacets(t, res.TypeFacets, map[string]int{RoleType: 2, TensionType: 1})
+	checkFacets(t, res.RoleTypeFacets, map[string]int{"normal": 1, "circle": 1})
+
+	// restrict to roles and paginate
+	res, err = s.Search("marketing", env.adminID, &SearchOptions{Types: []string{RoleType}, From: 1, Size: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Total != 2 {
+		t.Fatalf("expected %d total hits, got %d", 2, res.Total)
+	}
+	if len(res.Hits) != 1 || res.Hits[0].Type != RoleType {
+		t.Fatalf("expected one role hit, got %v", res.Hits)
+	}
+	checkFacets(t, res.TypeFacets, map[string]int{RoleType: 2})
+
+	// the tension without a role isn't visible to other members
+	memberID := env.createMember(t, "user01")
+	waitIndexed(t, s)
+	res, err = s.Search("marketing", memberID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkFacets(t, res.TypeFacets, map[string]int{RoleType: 2})
+}
+
+func checkFacets(t *testing.T, facets []*SearchFacet, expected map[string]int) {
+	counts := map[string]int{}
+	for _, f := range facets {
+		counts[f.Term] = f.Count
+	}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Fatalf("expected facets %v, got %v", expected, counts)
+	}
+}
+
+func TestRebuildIndexVerify(t *testing.T) {
+	env := setupTestEnv(t)
+	defer env.close()
+
+	roleID := env.createRole(t, env.rootRoleID, &change.CreateRoleChange{
+		RoleType: models.RoleTypeNormal,
+		Name:     "marketing",
+	})
+	memberID := env.createMember(t, "user01")
+
+	indexPath := filepath.Join(env.tmpDir, "index")
+
+	verify := func() *VerifyResult {
+		s, err := OpenSearchEngine(env.readDB, env.es, indexPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer s.Close()
+		res, err := s.Verify(env.ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return res
+	}
+
+	if err := RebuildIndex(env.ctx, env.readDB, env.es, indexPath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res := verify()
+	if !res.Consistent() {
+		t.Fatalf("expected consistent index, got missing: %v, stale: %v", res.Missing, res.Stale)
+	}
+	lastSn, err := env.es.LastSequenceNumber()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.IndexSeqNumber != lastSn || res.ReadDBSeqNumber != lastSn {
+		t.Fatalf("expected index and readdb sequence numbers %d, got %d and %d", lastSn, res.IndexSeqNumber, res.ReadDBSeqNumber)
+	}
+
+	// alter the index: remove the role, change the member and add a not
+	// existing document
+	unknownID := util.NewFromUUID(uuid.NewV4())
+	s, err := OpenSearchEngine(env.readDB, env.es, indexPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.delete([]util.ID{roleID}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.index.SetInternal([]byte(memberID.String()), []byte(`{"Type": "member"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	unknownDoc := &Role{Type: RoleType, Name: "unknown"}
+	if err := s.index.Index(unknownID.String(), unknownDoc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Close()
+
+	res = verify()
+	checkIDs(t, res.Missing, roleID)
+	checkIDs(t, res.Stale, memberID, unknownID)
+
+	// rebuilding over an existing index restores it
+	if err := RebuildIndex(env.ctx, env.readDB, env.es, indexPath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res = verify()
+	if !res.Consistent() {
+		t.Fatalf("expected consistent index, got missing: %v, stale: %v", res.Missing, res.Stale)
+	}
+
+	s, err = OpenSearchEngine(env.readDB, env.es, indexPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer s.Close()
+	checkIDs(t, searchIDs(t, s, "marketing", nil), roleID)
+	checkIDs(t, searchIDs(t, s, "unknown", nil))
+}
@@ -0,0 +1,33 @@
+package util
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/pkg/errors"
+)
+
+// DecryptPEMKey returns the pem encoded private key with its block decrypted
+// using the provided password. Unencrypted keys are returned as is. Only the
+// traditional openssl pem encryption (the Proc-Type and DEK-Info headers) is
+// supported, encrypted PKCS#8 keys aren't.
+func DecryptPEMKey(data []byte, password string) ([]byte, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no pem encoded data")
+	}
+	if block.Type == "ENCRYPTED PRIVATE KEY" {
+		return nil, errors.New("encrypted PKCS#8 private keys aren't supported, use the traditional pem encryption")
+	}
+	if !x509.IsEncryptedPEMBlock(block) {
+		return data, nil
+	}
+	if password == "" {
+		return nil, errors.New("encrypted private key but no password provided")
+	}
+	der, err := x509.DecryptPEMBlock(block, []byte(password))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decrypt private key")
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: der}), nil
+}
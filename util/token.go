@@ -0,0 +1,32 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// APITokenPrefix is the prefix of the api tokens, used to distinguish them from
+// the jwt tokens. It must not be a prefix of the other opaque token prefixes
+// (and the reverse) or they won't be distinguishable.
+const APITokenPrefix = "sircles_at_"
+
+// RefreshTokenPrefix is the prefix of the session refresh tokens
+const RefreshTokenPrefix = "sircles_rt_"
+
+// GenerateOpaqueToken returns a random token with the provided prefix
+func GenerateOpaqueToken(prefix string) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return prefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// TokenHash returns the hex encoded sha256 hash of an opaque token. Opaque
+// tokens are random so there's no need to use a slow hash like for passwords
+func TokenHash(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,29 @@
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOpaqueTokenPrefixes(t *testing.T) {
+	// api tokens are distinguished from the other tokens by their prefix
+	prefixes := []string{APITokenPrefix, RefreshTokenPrefix}
+	for i, p1 := range prefixes {
+		for j, p2 := range prefixes {
+			if i == j {
+				continue
+			}
+			if strings.HasPrefix(p1, p2) {
+				t.Fatalf("token prefix %q starts with token prefix %q", p1, p2)
+			}
+		}
+	}
+
+	token, err := GenerateOpaqueToken(RefreshTokenPrefix)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.HasPrefix(token, APITokenPrefix) {
+		t.Fatalf("refresh token %q starts with api token prefix %q", token, APITokenPrefix)
+	}
+}
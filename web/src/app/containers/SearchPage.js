@@ -42,40 +42,49 @@ class SearchPage extends React.Component {
     }
 
     const searchResult = searchQuery.search
-    const result = JSON.parse(searchResult.result)
-
-    console.log('result', result)
 
     return (
       <Container>
         <p>There were {searchResult.totalHits} results</p>
 
-        { result.hits.map(hit => {
-          if (hit.fields.Type === 'role') {
-            console.log('roletype', hit.fields.RoleType)
-            const roleLink = `/role/${hit.id}`
+        { searchResult.edges.map(edge => {
+          const node = edge.hit.node
+          if (!node) {
+            return null
+          }
+          if (node.__typename === 'Role') {
+            const roleLink = `/role/${node.uid}`
             return (
-              <Segment>
+              <Segment key={node.uid}>
                 <Link to={roleLink}>
-                  {hit.fields.Name}
+                  {node.name}
                 </Link>
-                {hit.fields.RoleType === 'circle' && <Label className='labelright' color='blue' horizontal basic size='tiny'>Circle</Label> }
-                {hit.fields.RoleType === 'normal' && <Label className='labelright' color='teal' horizontal basic size='tiny'>Role</Label> }
+                {node.roleType === 'circle' && <Label className='labelright' color='blue' horizontal basic size='tiny'>Circle</Label> }
+                {node.roleType === 'normal' && <Label className='labelright' color='teal' horizontal basic size='tiny'>Role</Label> }
               </Segment>
             )
           }
-          if (hit.fields.Type === 'member') {
-            const memberLink = `/member/${hit.id}`
+          if (node.__typename === 'Member') {
+            const memberLink = `/member/${node.uid}`
             return (
-              <Segment>
+              <Segment key={node.uid}>
                 <Link to={memberLink}>
-                  <Avatar uid={hit.id} size={30} inline spaced shape='rounded' />
-                  {hit.fields.UserName}
+                  <Avatar uid={node.uid} size={30} inline spaced shape='rounded' />
+                  {node.userName}
                 </Link>
                 <Label className='labelright' color='green' horizontal basic size='tiny'>Member</Label>
               </Segment>
             )
           }
+          if (node.__typename === 'Tension') {
+            return (
+              <Segment key={node.uid}>
+                {node.title}
+                <Label className='labelright' color='orange' horizontal basic size='tiny'>Tension</Label>
+              </Segment>
+            )
+          }
+          return null
         })
 
         }
@@ -94,8 +103,26 @@ const SearchPageQuery = gql`
   query searchPageQuery($query: String!) {
     search(query: $query) {
       totalHits
-      hits
-      result
+      edges {
+        hit {
+          node {
+            __typename
+            ... on Role {
+              uid
+              name
+              roleType
+            }
+            ... on Member {
+              uid
+              userName
+            }
+            ... on Tension {
+              uid
+              title
+            }
+          }
+        }
+      }
     }
   }
 `